@@ -959,6 +959,10 @@ var xxx_messageInfo_ListSnapshotsRequest proto.InternalMessageInfo
 type OfferSnapshotRequest struct {
 	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 	AppHash  []byte    `protobuf:"bytes,2,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	// Number of chunks, starting from index 0, that the app accepted before the
+	// restoration of this snapshot was interrupted, e.g. by a restart. 0 if the
+	// restoration starts from scratch.
+	AppliedChunks uint32 `protobuf:"varint,3,opt,name=applied_chunks,json=appliedChunks,proto3" json:"applied_chunks,omitempty"`
 }
 
 func (m *OfferSnapshotRequest) Reset()         { *m = OfferSnapshotRequest{} }
@@ -1008,6 +1012,13 @@ func (m *OfferSnapshotRequest) GetAppHash() []byte {
 	return nil
 }
 
+func (m *OfferSnapshotRequest) GetAppliedChunks() uint32 {
+	if m != nil {
+		return m.AppliedChunks
+	}
+	return 0
+}
+
 // Request to load a snapshot chunk.
 type LoadSnapshotChunkRequest struct {
 	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -2521,6 +2532,10 @@ func (m *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
 // provide a snapshot to the requester or not.
 type OfferSnapshotResponse struct {
 	Result OfferSnapshotResult `protobuf:"varint,1,opt,name=result,proto3,enum=cometbft.abci.v2.OfferSnapshotResult" json:"result,omitempty"`
+	// Set along with OFFER_SNAPSHOT_RESULT_ACCEPT to confirm that the app kept
+	// the applied_chunks chunks it accepted before, so that the restoration
+	// continues from chunk applied_chunks. Otherwise all chunks are applied again.
+	Resume bool `protobuf:"varint,2,opt,name=resume,proto3" json:"resume,omitempty"`
 }
 
 func (m *OfferSnapshotResponse) Reset()         { *m = OfferSnapshotResponse{} }
@@ -2563,6 +2578,13 @@ func (m *OfferSnapshotResponse) GetResult() OfferSnapshotResult {
 	return OFFER_SNAPSHOT_RESULT_UNKNOWN
 }
 
+func (m *OfferSnapshotResponse) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
+}
+
 // LoadSnapshotChunkResponse returns a snapshot's chunk.
 type LoadSnapshotChunkResponse struct {
 	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
@@ -3840,218 +3862,219 @@ func init() {
 func init() { proto.RegisterFile("cometbft/abci/v2/types.proto", fileDescriptor_6f0a5b1025f81964) }
 
 var fileDescriptor_6f0a5b1025f81964 = []byte{
-	// 3365 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4b, 0x6c, 0x1b, 0xd7,
-	0xb9, 0xf6, 0xf0, 0x25, 0xf2, 0xe7, 0x43, 0xa3, 0x23, 0xc9, 0xa6, 0x15, 0x47, 0x92, 0xc7, 0x71,
-	0xec, 0xd8, 0x89, 0x74, 0xad, 0xe4, 0xe6, 0x79, 0x93, 0x80, 0xa2, 0xa9, 0x48, 0xb2, 0x2c, 0x31,
-	0x43, 0x5a, 0x37, 0xf6, 0x7d, 0x4c, 0x46, 0xe4, 0xa1, 0x38, 0x31, 0x39, 0x33, 0x99, 0x39, 0x54,
-	0xa4, 0x7b, 0x57, 0x2d, 0x9a, 0xa2, 0xc8, 0x2a, 0x9b, 0x02, 0x45, 0x81, 0x02, 0x05, 0x8a, 0x6e,
-	0xbb, 0xe8, 0xbe, 0xbb, 0xa2, 0xc8, 0xaa, 0xc9, 0xb2, 0xab, 0xb4, 0x48, 0xd0, 0x4d, 0xf7, 0x05,
-	0x0a, 0x74, 0x53, 0x9c, 0xc7, 0xbc, 0xc8, 0x19, 0xc9, 0x76, 0xd2, 0x45, 0xd1, 0xee, 0x78, 0xce,
-	0xf9, 0xfe, 0x7f, 0xce, 0xf9, 0xcf, 0x39, 0xff, 0xe3, 0x3b, 0x84, 0x4b, 0x1d, 0x6b, 0x88, 0xc9,
-	0x41, 0x8f, 0xac, 0xea, 0x07, 0x1d, 0x63, 0xf5, 0x68, 0x6d, 0x95, 0x9c, 0xd8, 0xd8, 0x5d, 0xb1,
-	0x1d, 0x8b, 0x58, 0x48, 0xf6, 0x46, 0x57, 0xe8, 0xe8, 0xca, 0xd1, 0xda, 0xc2, 0xa2, 0x8f, 0xef,
-	0x38, 0x27, 0x36, 0xb1, 0x56, 0x8f, 0x6e, 0xad, 0xda, 0x8e, 0x65, 0xf5, 0xb8, 0x44, 0x68, 0x9c,
-	0xe9, 0xa1, 0x0a, 0x6d, 0xdd, 0xd1, 0x87, 0x42, 0xe3, 0xc2, 0xe5, 0xc9, 0xf1, 0x23, 0x7d, 0x60,
-	0x74, 0x75, 0x62, 0x39, 0x02, 0x32, 0x77, 0x68, 0x1d, 0x5a, 0xec, 0xe7, 0x2a, 0xfd, 0x25, 0x7a,
-	0x97, 0x0e, 0x2d, 0xeb, 0x70, 0x80, 0x57, 0x59, 0xeb, 0x60, 0xd4, 0x5b, 0x25, 0xc6, 0x10, 0xbb,
-	0x44, 0x1f, 0xda, 0xde, 0x97, 0xc7, 0x01, 0xdd, 0x91, 0xa3, 0x13, 0xc3, 0x32, 0xf9, 0xb8, 0xf2,
-	0x79, 0x01, 0xa6, 0x54, 0xfc, 0xe1, 0x08, 0xbb, 0x04, 0xbd, 0x08, 0x19, 0xdc, 0xe9, 0x5b, 0x55,
-	0x69, 0x59, 0xba, 0x5e, 0x5c, 0x7b, 0x7a, 0x65, 0x7c, 0x99, 0x2b, 0x8d, 0x4e, 0xdf, 0x12, 0xe0,
-	0xcd, 0x73, 0x2a, 0x03, 0xa3, 0x97, 0x21, 0xdb, 0x1b, 0x8c, 0xdc, 0x7e, 0x35, 0xc5, 0xa4, 0x16,
-	0x27, 0xa5, 0x36, 0xe8, 0x70, 0x20, 0xc6, 0xe1, 0xf4, 0x63, 0x86, 0xd9, 0xb3, 0xaa, 0xe9, 0xa4,
-	0x8f, 0x6d, 0x99, 0xbd, 0xf0, 0xc7, 0x28, 0x18, 0xd5, 0x01, 0x0c, 0xd3, 0x20, 0x5a, 0xa7, 0xaf,
-	0x1b, 0x66, 0x35, 0xcb, 0x44, 0x95, 0x38, 0x51, 0x83, 0xd4, 0x29, 0x24, 0x90, 0x2f, 0x18, 0x5e,
-	0x1f, 0x9d, 0xf1, 0x87, 0x23, 0xec, 0x9c, 0x54, 0x73, 0x49, 0x33, 0x7e, 0x97, 0x0e, 0x87, 0x66,
-	0xcc, 0xe0, 0xe8, 0x4d, 0xc8, 0x77, 0xfa, 0xb8, 0xf3, 0x50, 0x23, 0xc7, 0xd5, 0x3c, 0x13, 0x5d,
-	0x9e, 0x14, 0xad, 0x53, 0x44, 0xfb, 0x38, 0x10, 0x9e, 0xea, 0xf0, 0x1e, 0xf4, 0x1a, 0xe4, 0x3a,
-	0xd6, 0x70, 0x68, 0x90, 0x6a, 0x91, 0x09, 0x2f, 0xc5, 0x08, 0xb3, 0xf1, 0x40, 0x56, 0x08, 0xa0,
-	0x3d, 0xa8, 0x0c, 0x0c, 0x97, 0x68, 0xae, 0xa9, 0xdb, 0x6e, 0xdf, 0x22, 0x6e, 0xb5, 0xc4, 0x54,
-	0x3c, 0x3b, 0xa9, 0x62, 0xc7, 0x70, 0x49, 0xcb, 0x83, 0x05, 0x9a, 0xca, 0x83, 0x70, 0x3f, 0x55,
-	0x68, 0xf5, 0x7a, 0xd8, 0xf1, 0x35, 0x56, 0xcb, 0x49, 0x0a, 0xf7, 0x28, 0xce, 0x93, 0x0c, 0x29,
-	0xb4, 0xc2, 0xfd, 0xe8, 0xbf, 0x61, 0x76, 0x60, 0xe9, 0x5d, 0x5f, 0x9f, 0xd6, 0xe9, 0x8f, 0xcc,
-	0x87, 0xd5, 0x0a, 0xd3, 0x7a, 0x23, 0x66, 0x9a, 0x96, 0xde, 0xf5, 0x84, 0xeb, 0x14, 0x1a, 0x68,
-	0x9e, 0x19, 0x8c, 0x8f, 0x21, 0x0d, 0xe6, 0x74, 0xdb, 0x1e, 0x9c, 0x8c, 0xab, 0x9f, 0x66, 0xea,
-	0x6f, 0x4e, 0xaa, 0xaf, 0x51, 0x74, 0x82, 0x7e, 0xa4, 0x4f, 0x0c, 0xa2, 0x7b, 0x20, 0xdb, 0x0e,
-	0xb6, 0x75, 0x07, 0x6b, 0xb6, 0x63, 0xd9, 0x96, 0xab, 0x0f, 0xaa, 0x32, 0x53, 0x7e, 0x7d, 0x52,
-	0x79, 0x93, 0x23, 0x9b, 0x02, 0x18, 0x68, 0x9e, 0xb6, 0xa3, 0x23, 0x5c, 0xad, 0xd5, 0xc1, 0xae,
-	0x1b, 0xa8, 0x9d, 0x49, 0x56, 0xcb, 0x90, 0xb1, 0x6a, 0x23, 0x23, 0x68, 0x03, 0x8a, 0xf8, 0x98,
-	0x60, 0xb3, 0xab, 0x1d, 0x59, 0x04, 0x57, 0x11, 0xd3, 0x78, 0x25, 0xe6, 0xba, 0x32, 0xd0, 0xbe,
-	0x45, 0x70, 0xa0, 0x0c, 0xb0, 0xdf, 0x89, 0x0e, 0x60, 0xfe, 0x08, 0x3b, 0x46, 0xef, 0x84, 0xe9,
-	0xd1, 0xd8, 0x88, 0x6b, 0x58, 0x66, 0x75, 0x96, 0x69, 0x7c, 0x7e, 0x52, 0xe3, 0x3e, 0x83, 0x53,
-	0xe1, 0x86, 0x07, 0x0e, 0x54, 0xcf, 0x1e, 0x4d, 0x8e, 0xd2, 0x93, 0xd6, 0x33, 0x4c, 0x7d, 0x60,
-	0xfc, 0x1f, 0xd6, 0x0e, 0x06, 0x56, 0xe7, 0x61, 0x75, 0x2e, 0xe9, 0xa4, 0x6d, 0x08, 0xdc, 0x3a,
-	0x85, 0x85, 0x4e, 0x5a, 0x2f, 0xdc, 0xbf, 0x3e, 0x05, 0xd9, 0x23, 0x7d, 0x30, 0xc2, 0xdb, 0x99,
-	0x7c, 0x46, 0xce, 0x6e, 0x67, 0xf2, 0x53, 0x72, 0x7e, 0x3b, 0x93, 0x2f, 0xc8, 0xb0, 0x9d, 0xc9,
-	0x83, 0x5c, 0x54, 0xae, 0x41, 0x31, 0xe4, 0xa7, 0x50, 0x15, 0xa6, 0x86, 0xd8, 0x75, 0xf5, 0x43,
-	0xcc, 0xfc, 0x5a, 0x41, 0xf5, 0x9a, 0x4a, 0x05, 0x4a, 0x61, 0xd7, 0xa4, 0x7c, 0x2a, 0x41, 0x31,
-	0xe4, 0x74, 0xa8, 0xe4, 0x11, 0x76, 0x98, 0x41, 0x84, 0xa4, 0x68, 0xa2, 0x2b, 0x50, 0x66, 0x6b,
-	0xd1, 0xbc, 0x71, 0xea, 0xfb, 0x32, 0x6a, 0x89, 0x75, 0xee, 0x0b, 0xd0, 0x12, 0x14, 0xed, 0x35,
-	0xdb, 0x87, 0xa4, 0x19, 0x04, 0xec, 0x35, 0xdb, 0x03, 0x5c, 0x86, 0x12, 0x5d, 0xba, 0x8f, 0xc8,
-	0xb0, 0x8f, 0x14, 0x69, 0x9f, 0x80, 0x28, 0xbf, 0x4d, 0x81, 0x3c, 0xee, 0xcc, 0xd0, 0xab, 0x90,
-	0xa1, 0x5e, 0x5e, 0xb8, 0xe9, 0x85, 0x15, 0xee, 0xe1, 0x57, 0x3c, 0x0f, 0xbf, 0xd2, 0xf6, 0x42,
-	0xc0, 0x7a, 0xfe, 0xb3, 0x2f, 0x97, 0xce, 0x7d, 0xfa, 0xfb, 0x25, 0x49, 0x65, 0x12, 0xe8, 0x22,
-	0xf5, 0x60, 0xba, 0x61, 0x6a, 0x46, 0x97, 0x4d, 0xb9, 0x40, 0xbd, 0x93, 0x6e, 0x98, 0x5b, 0x5d,
-	0x74, 0x17, 0xe4, 0x8e, 0x65, 0xba, 0xd8, 0x74, 0x47, 0xae, 0xc6, 0x63, 0x93, 0x70, 0xcd, 0x21,
-	0xff, 0xca, 0x83, 0x20, 0x73, 0x54, 0x02, 0xda, 0x64, 0x48, 0x75, 0xba, 0x13, 0xed, 0x40, 0xef,
-	0x00, 0xf8, 0x01, 0xcc, 0xad, 0x66, 0x96, 0xd3, 0xd7, 0x8b, 0x6b, 0x97, 0x63, 0xce, 0x93, 0x87,
-	0xb9, 0x67, 0x77, 0x75, 0x82, 0xd7, 0x33, 0x74, 0xc2, 0x6a, 0x48, 0x14, 0x3d, 0x0b, 0xd3, 0xba,
-	0x6d, 0x6b, 0x2e, 0xd1, 0x09, 0xd6, 0x0e, 0x4e, 0x08, 0x76, 0x99, 0xdb, 0x2f, 0xa9, 0x65, 0xdd,
-	0xb6, 0x5b, 0xb4, 0x77, 0x9d, 0x76, 0xa2, 0xab, 0x50, 0xa1, 0x1e, 0xde, 0xd0, 0x07, 0x5a, 0x1f,
-	0x1b, 0x87, 0x7d, 0xc2, 0xbc, 0x7b, 0x5a, 0x2d, 0x8b, 0xde, 0x4d, 0xd6, 0xa9, 0x74, 0xa1, 0x14,
-	0x76, 0xee, 0x08, 0x41, 0xa6, 0xab, 0x13, 0x9d, 0xd9, 0xb2, 0xa4, 0xb2, 0xdf, 0xb4, 0xcf, 0xd6,
-	0x49, 0x5f, 0x58, 0x88, 0xfd, 0x46, 0xe7, 0x21, 0x27, 0xd4, 0xa6, 0x99, 0x5a, 0xd1, 0x42, 0x73,
-	0x90, 0xb5, 0x1d, 0xeb, 0x08, 0xb3, 0xcd, 0xcb, 0xab, 0xbc, 0xa1, 0xdc, 0x87, 0x4a, 0x34, 0x0e,
-	0xa0, 0x0a, 0xa4, 0xc8, 0xb1, 0xf8, 0x4a, 0x8a, 0x1c, 0xa3, 0x5b, 0x90, 0xa1, 0xc6, 0x64, 0xda,
-	0x2a, 0x71, 0xd1, 0x4f, 0xc8, 0xb7, 0x4f, 0x6c, 0xac, 0x32, 0xe8, 0x76, 0x26, 0x9f, 0x92, 0xd3,
-	0xca, 0x34, 0x94, 0x23, 0x51, 0x42, 0x39, 0x0f, 0x73, 0x71, 0x3e, 0x5f, 0x31, 0x60, 0x2e, 0xce,
-	0x75, 0xa3, 0x97, 0x21, 0xef, 0x3b, 0x7d, 0xef, 0x04, 0x4d, 0x7c, 0xdd, 0x17, 0xf2, 0xb1, 0xf4,
-	0xec, 0xd0, 0x8d, 0xe8, 0xeb, 0x22, 0xd4, 0x97, 0xd4, 0x29, 0xdd, 0xb6, 0x37, 0x75, 0xb7, 0xaf,
-	0xbc, 0x0f, 0xd5, 0x24, 0x7f, 0x1e, 0x32, 0x9c, 0xc4, 0x2e, 0x80, 0x67, 0xb8, 0xf3, 0x90, 0xeb,
-	0x59, 0xce, 0x50, 0x27, 0x4c, 0x59, 0x59, 0x15, 0x2d, 0x6a, 0x50, 0xee, 0xdb, 0xd3, 0xac, 0x9b,
-	0x37, 0x14, 0x0d, 0x2e, 0x26, 0xba, 0x74, 0x2a, 0x62, 0x98, 0x5d, 0xcc, 0xcd, 0x5b, 0x56, 0x79,
-	0x23, 0x50, 0xc4, 0x27, 0xcb, 0x1b, 0xf4, 0xb3, 0x2e, 0x36, 0xbb, 0xd8, 0x61, 0xfa, 0x0b, 0xaa,
-	0x68, 0x29, 0x3f, 0x4e, 0xc3, 0xf9, 0x78, 0xbf, 0x8e, 0x96, 0xa1, 0x34, 0xd4, 0x8f, 0x35, 0x72,
-	0x2c, 0x8e, 0x9f, 0xc4, 0x0e, 0x00, 0x0c, 0xf5, 0xe3, 0xf6, 0x31, 0x3f, 0x7b, 0x32, 0xa4, 0xc9,
-	0xb1, 0x5b, 0x4d, 0x2d, 0xa7, 0xaf, 0x97, 0x54, 0xfa, 0x13, 0xed, 0xc3, 0xcc, 0xc0, 0xea, 0xe8,
-	0x03, 0x6d, 0xa0, 0xbb, 0x44, 0x13, 0x61, 0x9f, 0x5f, 0xa7, 0x67, 0x92, 0xfc, 0x34, 0xee, 0xf2,
-	0x8d, 0xa5, 0x2e, 0x48, 0x5c, 0x84, 0x69, 0xa6, 0x64, 0x47, 0x77, 0x09, 0x1f, 0x42, 0x0d, 0x28,
-	0x0e, 0x0d, 0xf7, 0x00, 0xf7, 0xf5, 0x23, 0xc3, 0x72, 0xc4, 0xbd, 0x8a, 0x39, 0x3d, 0x77, 0x03,
-	0x90, 0x50, 0x15, 0x96, 0x0b, 0x6d, 0x4a, 0x36, 0x72, 0x9a, 0x3d, 0xcf, 0x92, 0x7b, 0x6c, 0xcf,
-	0xf2, 0x6f, 0x30, 0x67, 0xe2, 0x63, 0xa2, 0x05, 0x37, 0x97, 0x9f, 0x94, 0x29, 0x66, 0x7c, 0x44,
-	0xc7, 0xfc, 0xbb, 0xee, 0xd2, 0x43, 0x83, 0x9e, 0x63, 0xb1, 0xd1, 0xb6, 0x5c, 0xec, 0x68, 0x7a,
-	0xb7, 0xeb, 0x60, 0xd7, 0x65, 0x59, 0x55, 0x89, 0xc5, 0x3b, 0xd6, 0x5f, 0xe3, 0xdd, 0xca, 0x27,
-	0x6c, 0x73, 0xe2, 0xa2, 0xa3, 0x67, 0x7a, 0x29, 0x30, 0x7d, 0x1b, 0xe6, 0x84, 0x7c, 0x37, 0x62,
-	0x7d, 0x9e, 0x9e, 0x5e, 0x4a, 0x4a, 0xba, 0x42, 0x56, 0x47, 0x9e, 0x7c, 0xb2, 0xe1, 0xd3, 0x4f,
-	0x68, 0x78, 0x04, 0x19, 0x66, 0x96, 0x0c, 0x77, 0x37, 0xf4, 0xf7, 0x3f, 0xda, 0x66, 0x7c, 0x9c,
-	0x86, 0x99, 0x89, 0xc4, 0xc2, 0x5f, 0x98, 0x14, 0xbb, 0xb0, 0x54, 0xec, 0xc2, 0xd2, 0x8f, 0xbd,
-	0x30, 0xb1, 0xdb, 0x99, 0xb3, 0x77, 0x3b, 0xfb, 0x6d, 0xee, 0x76, 0xee, 0x09, 0x77, 0xfb, 0xef,
-	0xba, 0x0f, 0x9f, 0x4b, 0xb0, 0x90, 0x9c, 0x8e, 0xc5, 0x6e, 0xc8, 0x4d, 0x98, 0xf1, 0xa7, 0xe2,
-	0xab, 0xe7, 0xee, 0x51, 0xf6, 0x07, 0x84, 0xfe, 0xc4, 0x88, 0x77, 0x15, 0x2a, 0x63, 0xd9, 0x22,
-	0x3f, 0xcc, 0xe5, 0xa3, 0x48, 0xde, 0x77, 0x0b, 0xe6, 0x4d, 0xcb, 0xd4, 0x1c, 0x7b, 0x3c, 0xb7,
-	0xcc, 0x8a, 0xc5, 0x5b, 0xa6, 0x6a, 0x47, 0x66, 0xae, 0xfc, 0x32, 0x0d, 0x73, 0x71, 0x39, 0x60,
-	0xcc, 0x25, 0x57, 0x61, 0xb6, 0x8b, 0x3b, 0x46, 0xf7, 0x89, 0xef, 0xf8, 0x8c, 0x10, 0xff, 0xd7,
-	0x15, 0x9f, 0x3c, 0x5a, 0xe8, 0x06, 0xcc, 0xb8, 0x27, 0x66, 0xc7, 0x30, 0x0f, 0x35, 0x62, 0x79,
-	0xe9, 0x54, 0x81, 0xcd, 0x7c, 0x5a, 0x0c, 0xb4, 0x2d, 0x91, 0x50, 0xfd, 0x1c, 0x20, 0xaf, 0x62,
-	0xd7, 0xa6, 0xf9, 0x1f, 0xaa, 0x43, 0x01, 0x1f, 0x77, 0xb0, 0x4d, 0xbc, 0x9c, 0x39, 0xa1, 0x2c,
-	0x11, 0x10, 0x4f, 0x8e, 0x96, 0xe7, 0xbe, 0x1c, 0x7a, 0x49, 0xb0, 0x10, 0x89, 0x7c, 0x02, 0xcf,
-	0xee, 0x7d, 0x51, 0x4e, 0x43, 0xbc, 0xe2, 0xd1, 0x10, 0xe9, 0xa4, 0xe2, 0x5a, 0xe4, 0xfa, 0xbe,
-	0x9c, 0xe0, 0x21, 0x5e, 0x12, 0x3c, 0x44, 0x26, 0xe9, 0x73, 0xbc, 0x24, 0x08, 0x3e, 0xc7, 0x88,
-	0x88, 0xdb, 0x11, 0x22, 0x22, 0x97, 0xb4, 0xd4, 0x50, 0xee, 0x1e, 0x2c, 0x35, 0x60, 0x22, 0x5e,
-	0xf1, 0x98, 0x88, 0xa9, 0xa4, 0x49, 0x8b, 0x64, 0x35, 0x98, 0x34, 0xa7, 0x22, 0xde, 0x0a, 0x51,
-	0x11, 0x05, 0x26, 0x7b, 0xf9, 0x14, 0x2a, 0xc2, 0x97, 0xf6, 0xb9, 0x88, 0xd7, 0x7d, 0x2e, 0xa2,
-	0x94, 0x48, 0x64, 0x88, 0x2c, 0xd3, 0x17, 0xf6, 0xc8, 0x88, 0xe6, 0x04, 0x19, 0xc1, 0xb9, 0x83,
-	0x6b, 0x67, 0x92, 0x11, 0xbe, 0xaa, 0x31, 0x36, 0xa2, 0x39, 0xc1, 0x46, 0x54, 0x92, 0x34, 0x8e,
-	0xa5, 0xb4, 0x81, 0xc6, 0x28, 0x1d, 0xf1, 0x3f, 0xf1, 0x74, 0x44, 0x22, 0x5f, 0x10, 0x93, 0xbe,
-	0xfa, 0xaa, 0x63, 0xf8, 0x88, 0xf7, 0x13, 0xf8, 0x08, 0x39, 0xa9, 0x6e, 0x8e, 0x4b, 0x5e, 0xfd,
-	0x0f, 0xc4, 0x11, 0x12, 0xfb, 0x31, 0x84, 0x04, 0x67, 0x0e, 0x9e, 0x7b, 0x04, 0x42, 0xc2, 0x57,
-	0x3d, 0xc1, 0x48, 0xec, 0xc7, 0x30, 0x12, 0x28, 0x59, 0xef, 0x58, 0xce, 0x15, 0xd6, 0x1b, 0xa5,
-	0x24, 0xde, 0x89, 0x52, 0x12, 0xb3, 0xa7, 0xa7, 0xba, 0x3c, 0x73, 0xf0, 0xb5, 0x85, 0x39, 0x89,
-	0x4e, 0x12, 0x27, 0xc1, 0x69, 0x83, 0x17, 0x1e, 0x91, 0x93, 0xf0, 0x75, 0xc7, 0x92, 0x12, 0xcd,
-	0x09, 0x52, 0x62, 0x3e, 0xe9, 0xc0, 0x8d, 0x05, 0xa4, 0xe0, 0xc0, 0x25, 0xb2, 0x12, 0x59, 0x39,
-	0xb7, 0x9d, 0xc9, 0xe7, 0xe5, 0x02, 0xe7, 0x23, 0xb6, 0x33, 0xf9, 0xa2, 0x5c, 0x52, 0x9e, 0xa3,
-	0x59, 0xd3, 0x98, 0xdf, 0xa3, 0x35, 0x0a, 0x76, 0x1c, 0xcb, 0x11, 0xfc, 0x02, 0x6f, 0x28, 0xd7,
-	0xa1, 0x14, 0x76, 0x71, 0xa7, 0x30, 0x18, 0xd3, 0x50, 0x8e, 0x78, 0x35, 0xe5, 0xaf, 0x29, 0x28,
-	0x85, 0xfd, 0x55, 0xa4, 0xbe, 0x2d, 0x88, 0xfa, 0x36, 0xc4, 0x6b, 0xa4, 0xa2, 0xbc, 0xc6, 0x12,
-	0x14, 0x69, 0x8d, 0x37, 0x46, 0x59, 0xe8, 0xb6, 0x4f, 0x59, 0xdc, 0x80, 0x19, 0x16, 0x6f, 0x39,
-	0xfb, 0x21, 0x22, 0x43, 0x86, 0x47, 0x06, 0x3a, 0xc0, 0x8c, 0xc1, 0x23, 0x03, 0x7a, 0x01, 0x66,
-	0x43, 0x58, 0xbf, 0x76, 0xe4, 0xf1, 0x5f, 0xf6, 0xd1, 0x35, 0x5e, 0x44, 0xa2, 0xff, 0x82, 0xe9,
-	0x81, 0x6e, 0xd2, 0xe3, 0x6e, 0x58, 0x8e, 0x41, 0x0c, 0xec, 0x8a, 0xbc, 0x6b, 0xed, 0x74, 0x97,
-	0xbc, 0xb2, 0xa3, 0x9b, 0xb8, 0xe9, 0x0b, 0x35, 0x4c, 0xe2, 0x9c, 0xa8, 0x95, 0x41, 0xa4, 0x13,
-	0x5d, 0x86, 0x52, 0x17, 0xf7, 0xf4, 0xd1, 0x80, 0x68, 0x74, 0x84, 0xf9, 0xdb, 0x82, 0x5a, 0x14,
-	0x7d, 0x54, 0xc3, 0x42, 0x0d, 0x66, 0x63, 0x34, 0xd1, 0xdc, 0xe3, 0x21, 0x3e, 0x11, 0xf6, 0xa3,
-	0x3f, 0xe9, 0xa6, 0xb1, 0xad, 0x16, 0x85, 0x2b, 0x6f, 0xbc, 0x9e, 0x7a, 0x55, 0x52, 0x7e, 0x23,
-	0xc1, 0xcc, 0x84, 0xc7, 0x8f, 0x65, 0x56, 0xa4, 0x6f, 0x8b, 0x59, 0x49, 0x3d, 0x39, 0xb3, 0x12,
-	0x2e, 0xe8, 0xd3, 0xd1, 0x82, 0xfe, 0x2f, 0x12, 0x94, 0x23, 0x91, 0x87, 0x9e, 0xa3, 0x8e, 0xd5,
-	0xc5, 0xa2, 0xc4, 0x66, 0xbf, 0xa9, 0x69, 0x06, 0xd6, 0xa1, 0x28, 0xa4, 0xe9, 0x4f, 0x8a, 0xf2,
-	0x63, 0x69, 0x41, 0x44, 0x4a, 0xbf, 0x3a, 0xe7, 0xa9, 0x8f, 0xa8, 0xce, 0x85, 0x59, 0x73, 0xec,
-	0xbb, 0x51, 0xb3, 0xf2, 0x14, 0x86, 0x37, 0xd0, 0x6b, 0x50, 0x60, 0xef, 0x28, 0x9a, 0x65, 0xbb,
-	0x82, 0x74, 0x0f, 0xa5, 0x77, 0xfc, 0xb1, 0x65, 0xe5, 0xe8, 0x16, 0x75, 0x55, 0x56, 0x6f, 0xcf,
-	0x76, 0xd5, 0xbc, 0x2d, 0x7e, 0x85, 0x92, 0xae, 0x42, 0x24, 0xe9, 0xba, 0x04, 0x05, 0x3a, 0x7d,
-	0xd7, 0xd6, 0x3b, 0xb8, 0x0a, 0x6c, 0xa6, 0x41, 0x87, 0xf2, 0xeb, 0x14, 0x4c, 0x8f, 0x05, 0xce,
-	0xd8, 0xc5, 0x7b, 0x17, 0x2b, 0x15, 0x22, 0x8e, 0x1e, 0xcd, 0x20, 0x8b, 0x00, 0x87, 0xba, 0xab,
-	0x7d, 0xa4, 0x9b, 0x04, 0x77, 0x85, 0x55, 0x42, 0x3d, 0x68, 0x01, 0xf2, 0xb4, 0x35, 0x72, 0x71,
-	0x57, 0x70, 0x58, 0x7e, 0x1b, 0x6d, 0x41, 0x0e, 0x1f, 0x61, 0x93, 0xb8, 0xd5, 0x29, 0xb6, 0xf1,
-	0x17, 0x62, 0x3c, 0x2c, 0x1d, 0x5f, 0xaf, 0xd2, 0xed, 0xfe, 0xd3, 0x97, 0x4b, 0x32, 0x87, 0x3f,
-	0x6f, 0x0d, 0x0d, 0x82, 0x87, 0x36, 0x39, 0x51, 0x85, 0x82, 0xa8, 0x19, 0xf2, 0x63, 0x66, 0x40,
-	0x17, 0x60, 0x8a, 0xdd, 0x46, 0xa3, 0xcb, 0x32, 0x84, 0x82, 0x9a, 0xa3, 0xcd, 0xad, 0x2e, 0x63,
-	0x5a, 0x4b, 0x1e, 0x6d, 0x42, 0xad, 0xcd, 0xae, 0xcb, 0x89, 0x5a, 0x1e, 0xe2, 0xa1, 0x6d, 0x59,
-	0x03, 0x8d, 0xfb, 0xb0, 0x1a, 0x54, 0xa2, 0x09, 0x04, 0xba, 0x02, 0x65, 0x07, 0x13, 0xdd, 0x30,
-	0xb5, 0x48, 0x59, 0x51, 0xe2, 0x9d, 0xdc, 0x67, 0x6c, 0x67, 0xf2, 0x92, 0x9c, 0x12, 0x4c, 0xd7,
-	0xbb, 0x30, 0x1f, 0x9b, 0x3f, 0xa0, 0x57, 0xa1, 0x10, 0xe4, 0x1e, 0x12, 0xb3, 0xc3, 0x69, 0x14,
-	0x56, 0x00, 0x56, 0xf6, 0x61, 0x3e, 0x36, 0x81, 0x40, 0x6f, 0x42, 0xce, 0xc1, 0xee, 0x68, 0xc0,
-	0x59, 0xaa, 0xca, 0xda, 0xd5, 0xb3, 0x33, 0x8f, 0xd1, 0x80, 0xa8, 0x42, 0x48, 0xb9, 0x05, 0x17,
-	0x13, 0x33, 0x88, 0x80, 0x88, 0x92, 0x42, 0x44, 0x94, 0xf2, 0x0b, 0x09, 0x16, 0x92, 0xb3, 0x02,
-	0xb4, 0x3e, 0x36, 0xa1, 0x1b, 0x8f, 0x98, 0x53, 0x84, 0x66, 0x45, 0x2b, 0x35, 0x07, 0xf7, 0x30,
-	0xe9, 0xf4, 0x79, 0x7a, 0xc2, 0xbd, 0x45, 0x59, 0x2d, 0x8b, 0x5e, 0x26, 0xe3, 0x72, 0xd8, 0x07,
-	0xb8, 0x43, 0x34, 0xbe, 0xa9, 0x2e, 0x2b, 0x7d, 0x0a, 0x14, 0x46, 0x7b, 0x5b, 0xbc, 0x53, 0xb9,
-	0x09, 0x17, 0x12, 0xf2, 0x8c, 0xc9, 0xfa, 0x4c, 0x79, 0x40, 0xc1, 0xb1, 0xc9, 0x03, 0x7a, 0x1b,
-	0x72, 0x2e, 0xd1, 0xc9, 0xc8, 0x15, 0x2b, 0xbb, 0x76, 0x66, 0xde, 0xd1, 0x62, 0x70, 0x55, 0x88,
-	0x29, 0x18, 0xd0, 0x64, 0x16, 0x11, 0x53, 0x96, 0x4a, 0x71, 0x65, 0xe9, 0x75, 0x90, 0x45, 0x59,
-	0x1a, 0x00, 0xf9, 0x15, 0xae, 0xb0, 0x8a, 0x34, 0xa8, 0x46, 0x0f, 0xe0, 0xa9, 0x53, 0x32, 0x0b,
-	0x54, 0x1f, 0x5b, 0xc6, 0xcd, 0x47, 0x4a, 0x4c, 0xc6, 0x96, 0xf2, 0xab, 0x34, 0xcc, 0xc7, 0x26,
-	0x18, 0xa1, 0x8b, 0x2e, 0x7d, 0xd3, 0x8b, 0xfe, 0x26, 0x00, 0x39, 0xd6, 0xf8, 0x99, 0xf0, 0x02,
-	0x46, 0x5c, 0x55, 0x75, 0x8c, 0x3b, 0xcc, 0xe7, 0xd1, 0x23, 0x54, 0x20, 0xe2, 0x97, 0x8b, 0xda,
-	0x61, 0xd2, 0x60, 0xc4, 0x82, 0x89, 0x2b, 0x8a, 0xe3, 0x47, 0x0e, 0x3b, 0x01, 0xbb, 0xc0, 0xbb,
-	0x5d, 0xf4, 0x00, 0x2e, 0x8c, 0x05, 0x45, 0x5f, 0x77, 0xe6, 0x91, 0x63, 0xe3, 0x7c, 0x34, 0x36,
-	0x7a, 0xba, 0xc3, 0x81, 0x2d, 0x1b, 0x09, 0x6c, 0x34, 0x16, 0xb3, 0xb2, 0x99, 0xe7, 0x24, 0x5d,
-	0x3c, 0xd0, 0xbd, 0x57, 0xe0, 0x8b, 0x13, 0xc5, 0xf7, 0x6d, 0xf1, 0x50, 0xce, 0x6b, 0xef, 0x1f,
-	0xd1, 0xda, 0xbb, 0x42, 0x85, 0xd9, 0x46, 0xdd, 0xa6, 0xa2, 0xca, 0x03, 0x80, 0x80, 0x59, 0xa0,
-	0x17, 0xdd, 0xb1, 0x46, 0x66, 0x97, 0x9d, 0x88, 0xac, 0xca, 0x1b, 0xe8, 0x65, 0xc8, 0xd2, 0x23,
-	0xe8, 0x59, 0x3e, 0xc6, 0x53, 0xd1, 0x13, 0x12, 0xa2, 0x26, 0x38, 0x5c, 0xf9, 0xc0, 0x3b, 0xe6,
-	0x61, 0x5e, 0x38, 0xe1, 0x1b, 0x6f, 0x45, 0xbf, 0xa1, 0x24, 0x53, 0xcc, 0xf1, 0xdf, 0xfa, 0x7f,
-	0xc8, 0xb2, 0xd3, 0x44, 0xe3, 0x15, 0x7b, 0x96, 0x10, 0xe9, 0x22, 0xfd, 0x8d, 0xfe, 0x17, 0x40,
-	0x27, 0xc4, 0x31, 0x0e, 0x46, 0xc1, 0x17, 0x96, 0x13, 0x8e, 0x63, 0xcd, 0x03, 0xae, 0x5f, 0x12,
-	0xe7, 0x72, 0x2e, 0x90, 0x0d, 0x9d, 0xcd, 0x90, 0x46, 0x65, 0x17, 0x2a, 0x51, 0xd9, 0xb3, 0x72,
-	0xae, 0x82, 0x97, 0x1c, 0xf8, 0xa9, 0x45, 0x9a, 0x3f, 0xbe, 0xb0, 0x86, 0xf2, 0x9d, 0x14, 0x94,
-	0xc2, 0x87, 0xf9, 0x9f, 0x30, 0x7c, 0x2b, 0xdf, 0x97, 0x20, 0xef, 0xaf, 0x3f, 0xfa, 0x04, 0x13,
-	0x79, 0xbb, 0xe2, 0xe6, 0x4b, 0x85, 0xdf, 0x4d, 0xf8, 0x4b, 0x55, 0xda, 0x7f, 0xa9, 0xfa, 0x0f,
-	0x3f, 0x12, 0x25, 0x32, 0x24, 0x61, 0x6b, 0x8b, 0x83, 0xe5, 0x45, 0xc6, 0x37, 0xa0, 0xe0, 0xbb,
-	0x04, 0x5a, 0x78, 0x78, 0xcc, 0x93, 0x24, 0xee, 0xa5, 0x60, 0x9c, 0xe6, 0x20, 0x6b, 0x5b, 0x1f,
-	0x89, 0x57, 0x99, 0xb4, 0xca, 0x1b, 0x8a, 0x0b, 0xd3, 0x63, 0xfe, 0x24, 0x00, 0xa6, 0x42, 0x40,
-	0xa4, 0x40, 0xd9, 0x1e, 0x1d, 0x68, 0x0f, 0xf1, 0x89, 0x78, 0xa3, 0xe1, 0xd3, 0x2f, 0xda, 0xa3,
-	0x83, 0x3b, 0xf8, 0x84, 0x3f, 0xd2, 0x2c, 0x43, 0xc9, 0xc3, 0xb0, 0x23, 0xce, 0xf7, 0x14, 0x38,
-	0xa4, 0xcd, 0x1f, 0xd8, 0x24, 0x39, 0xa5, 0xfc, 0x50, 0x82, 0xbc, 0x77, 0x4b, 0xd0, 0xdb, 0x50,
-	0xf0, 0x5d, 0x97, 0x48, 0xda, 0x9f, 0x3a, 0xc5, 0xe9, 0x89, 0xc5, 0x07, 0x32, 0x68, 0xdd, 0x7b,
-	0x29, 0x36, 0xba, 0x5a, 0x6f, 0xa0, 0x1f, 0x8a, 0x07, 0xbf, 0xc5, 0x18, 0xef, 0xc6, 0xfc, 0xca,
-	0xd6, 0xed, 0x8d, 0x81, 0x7e, 0xa8, 0x16, 0x99, 0xd0, 0x56, 0x97, 0x36, 0x44, 0x3a, 0xf4, 0xc7,
-	0x14, 0xc8, 0xe3, 0xb7, 0xf8, 0x9b, 0xcf, 0x6f, 0x32, 0x6c, 0xa6, 0xe3, 0xc2, 0xe6, 0x2a, 0xcc,
-	0xfa, 0x08, 0xcd, 0x35, 0x0e, 0x4d, 0x9d, 0x8c, 0x1c, 0x2c, 0x38, 0x4e, 0xe4, 0x0f, 0xb5, 0xbc,
-	0x91, 0xc9, 0x75, 0x67, 0x1f, 0x7b, 0xdd, 0xc9, 0x14, 0x72, 0x2e, 0x89, 0x42, 0x46, 0x6f, 0xc0,
-	0xc2, 0x78, 0x78, 0x0f, 0x4d, 0x97, 0x57, 0x16, 0x17, 0xa2, 0x81, 0xde, 0x9f, 0xb3, 0xb0, 0xf3,
-	0xc7, 0x29, 0x28, 0x86, 0x28, 0x5e, 0xf4, 0xef, 0x21, 0x97, 0x58, 0x89, 0x0b, 0x79, 0x21, 0x70,
-	0xf0, 0x5a, 0x1b, 0xdd, 0x99, 0xd4, 0x13, 0xec, 0x4c, 0x12, 0xff, 0xee, 0x71, 0xc6, 0x99, 0xc7,
-	0xe6, 0x8c, 0x9f, 0x07, 0x44, 0x2c, 0xa2, 0x0f, 0xa8, 0x39, 0x0d, 0xf3, 0x50, 0xe3, 0x17, 0x89,
-	0x7b, 0x30, 0x99, 0x8d, 0xec, 0xb3, 0x81, 0x26, 0xbb, 0x7c, 0xdf, 0x95, 0x20, 0xef, 0xf3, 0x69,
-	0x8f, 0xfb, 0x8a, 0x7b, 0x1e, 0x72, 0x22, 0xe5, 0xe4, 0xcf, 0xb8, 0xa2, 0x15, 0x4b, 0x8e, 0x2f,
-	0x40, 0x7e, 0x88, 0x89, 0xce, 0xdc, 0x31, 0x0f, 0xd7, 0x7e, 0xfb, 0xc6, 0x01, 0x14, 0x43, 0x0f,
-	0xe1, 0xe8, 0x22, 0xcc, 0xd7, 0x37, 0x1b, 0xf5, 0x3b, 0x5a, 0xfb, 0x3d, 0xad, 0x7d, 0xbf, 0xd9,
-	0xd0, 0xee, 0xed, 0xde, 0xd9, 0xdd, 0xfb, 0xcf, 0x5d, 0xf9, 0xdc, 0xe4, 0x90, 0xda, 0x60, 0x6d,
-	0x59, 0x42, 0x17, 0x60, 0x36, 0x3a, 0xc4, 0x07, 0x52, 0x0b, 0x99, 0x1f, 0xfc, 0x6c, 0xf1, 0xdc,
-	0x8d, 0x3f, 0x4b, 0x30, 0x1b, 0x93, 0xdc, 0xa3, 0xcb, 0xf0, 0xf4, 0xde, 0xc6, 0x46, 0x43, 0xd5,
-	0x5a, 0xbb, 0xb5, 0x66, 0x6b, 0x73, 0xaf, 0xad, 0xa9, 0x8d, 0xd6, 0xbd, 0x9d, 0x76, 0xe8, 0xa3,
-	0xcb, 0x70, 0x29, 0x1e, 0x52, 0xab, 0xd7, 0x1b, 0xcd, 0xb6, 0x2c, 0xa1, 0x25, 0x78, 0x2a, 0x01,
-	0xb1, 0xbe, 0xa7, 0xb6, 0xe5, 0x54, 0xb2, 0x0a, 0xb5, 0xb1, 0xdd, 0xa8, 0xb7, 0xe5, 0x34, 0xba,
-	0x06, 0x57, 0x4e, 0x43, 0x68, 0x1b, 0x7b, 0xea, 0xdd, 0x5a, 0x5b, 0xce, 0x9c, 0x09, 0x6c, 0x35,
-	0x76, 0x6f, 0x37, 0x54, 0x39, 0x2b, 0xd6, 0xfd, 0xd3, 0x14, 0x54, 0x93, 0x6a, 0x08, 0xaa, 0xab,
-	0xd6, 0x6c, 0xee, 0xdc, 0x0f, 0x74, 0xd5, 0x37, 0xef, 0xed, 0xde, 0x99, 0x34, 0xc1, 0xb3, 0xa0,
-	0x9c, 0x06, 0xf4, 0x0d, 0x71, 0x15, 0x2e, 0x9f, 0x8a, 0x13, 0xe6, 0x38, 0x03, 0xa6, 0x36, 0xda,
-	0xea, 0x7d, 0x39, 0x8d, 0x56, 0xe0, 0xc6, 0x99, 0x30, 0x7f, 0x4c, 0xce, 0xa0, 0x55, 0xb8, 0x79,
-	0x3a, 0x9e, 0x1b, 0xc8, 0x13, 0xf0, 0x4c, 0xf4, 0x89, 0x04, 0xf3, 0xb1, 0xc5, 0x08, 0xba, 0x02,
-	0x4b, 0x4d, 0x75, 0xaf, 0xde, 0x68, 0xb5, 0xb4, 0xa6, 0xba, 0xd7, 0xdc, 0x6b, 0xd5, 0x76, 0xb4,
-	0x56, 0xbb, 0xd6, 0xbe, 0xd7, 0x0a, 0xd9, 0x46, 0x81, 0xc5, 0x24, 0x90, 0x6f, 0x97, 0x53, 0x30,
-	0xe2, 0x04, 0x78, 0xe7, 0xf4, 0x27, 0x12, 0x5c, 0x4c, 0x2c, 0x29, 0xd0, 0x75, 0x78, 0x66, 0xbf,
-	0xa1, 0x6e, 0x6d, 0xdc, 0xd7, 0xf6, 0xf7, 0xda, 0x0d, 0xad, 0xf1, 0x5e, 0xbb, 0xb1, 0xdb, 0xda,
-	0xda, 0xdb, 0x9d, 0x9c, 0xd5, 0x35, 0xb8, 0x72, 0x2a, 0xd2, 0x9f, 0xda, 0x59, 0xc0, 0xb1, 0xf9,
-	0x7d, 0x4f, 0x82, 0xe9, 0x31, 0x5f, 0x88, 0x2e, 0x41, 0xf5, 0xee, 0x56, 0x6b, 0xbd, 0xb1, 0x59,
-	0xdb, 0xdf, 0xda, 0x53, 0xc7, 0xef, 0xec, 0x15, 0x58, 0x9a, 0x18, 0xbd, 0x7d, 0xaf, 0xb9, 0xb3,
-	0x55, 0xaf, 0xb5, 0x1b, 0xec, 0xa3, 0xb2, 0x44, 0x17, 0x36, 0x01, 0xda, 0xd9, 0x7a, 0x67, 0xb3,
-	0xad, 0xd5, 0x77, 0xb6, 0x1a, 0xbb, 0x6d, 0xad, 0xd6, 0x6e, 0xd7, 0x82, 0xeb, 0xbc, 0x7e, 0xe7,
-	0xb3, 0xaf, 0x16, 0xa5, 0x2f, 0xbe, 0x5a, 0x94, 0xfe, 0xf0, 0xd5, 0xa2, 0xf4, 0xe9, 0xd7, 0x8b,
-	0xe7, 0xbe, 0xf8, 0x7a, 0xf1, 0xdc, 0xef, 0xbe, 0x5e, 0x3c, 0xf7, 0xe0, 0xd6, 0xa1, 0x41, 0xfa,
-	0xa3, 0x03, 0xea, 0x85, 0x57, 0x83, 0xff, 0xeb, 0xfa, 0x7f, 0xf4, 0xb5, 0x8d, 0xd5, 0xf1, 0x7f,
-	0xfd, 0x1e, 0xe4, 0x98, 0x5b, 0x7d, 0xf1, 0x6f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x54, 0xeb,
-	0x69, 0x10, 0x2c, 0x00, 0x00,
+	// 3379 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4f, 0x6c, 0x1b, 0xc7,
+	0xb9, 0xf7, 0x92, 0x14, 0x45, 0x7e, 0xfc, 0xa3, 0xd5, 0x48, 0xb2, 0x69, 0xc5, 0x91, 0xe4, 0x75,
+	0x1c, 0x3b, 0x76, 0x22, 0x3d, 0x2b, 0xef, 0xe5, 0xef, 0x4b, 0x02, 0x4a, 0xa6, 0x22, 0xc9, 0xb2,
+	0xc4, 0x2c, 0x69, 0xbd, 0xd8, 0xef, 0xbd, 0x6e, 0x56, 0xe4, 0x50, 0xdc, 0x98, 0xdc, 0xdd, 0xec,
+	0x0e, 0x19, 0xaa, 0x3d, 0xb5, 0x68, 0x8a, 0x22, 0xa7, 0x5c, 0x0a, 0x14, 0x05, 0x0a, 0x14, 0x28,
+	0x7a, 0xed, 0xa1, 0xf7, 0xde, 0x8a, 0x22, 0xa7, 0x26, 0xc7, 0x9e, 0xd2, 0x22, 0x41, 0x2f, 0xbd,
+	0x17, 0x28, 0xd0, 0x4b, 0x31, 0x7f, 0xf6, 0x1f, 0xb9, 0x2b, 0xd9, 0x4e, 0x7a, 0x28, 0xda, 0xdb,
+	0xce, 0xcc, 0xf7, 0x7d, 0x33, 0xf3, 0xcd, 0x37, 0xdf, 0x9f, 0xdf, 0x2c, 0x5c, 0x6a, 0x59, 0x7d,
+	0x4c, 0x8e, 0x3a, 0x64, 0x4d, 0x3f, 0x6a, 0x19, 0x6b, 0xc3, 0xf5, 0x35, 0x72, 0x62, 0x63, 0x77,
+	0xd5, 0x76, 0x2c, 0x62, 0x21, 0xd9, 0x1b, 0x5d, 0xa5, 0xa3, 0xab, 0xc3, 0xf5, 0xc5, 0x25, 0x9f,
+	0xbe, 0xe5, 0x9c, 0xd8, 0xc4, 0x5a, 0x1b, 0xde, 0x5a, 0xb3, 0x1d, 0xcb, 0xea, 0x70, 0x8e, 0xd0,
+	0x38, 0x93, 0x43, 0x05, 0xda, 0xba, 0xa3, 0xf7, 0x85, 0xc4, 0xc5, 0xcb, 0x93, 0xe3, 0x43, 0xbd,
+	0x67, 0xb4, 0x75, 0x62, 0x39, 0x82, 0x64, 0xfe, 0xd8, 0x3a, 0xb6, 0xd8, 0xe7, 0x1a, 0xfd, 0x12,
+	0xbd, 0xcb, 0xc7, 0x96, 0x75, 0xdc, 0xc3, 0x6b, 0xac, 0x75, 0x34, 0xe8, 0xac, 0x11, 0xa3, 0x8f,
+	0x5d, 0xa2, 0xf7, 0x6d, 0x6f, 0xe6, 0x71, 0x82, 0xf6, 0xc0, 0xd1, 0x89, 0x61, 0x99, 0x7c, 0x5c,
+	0xf9, 0x2c, 0x0f, 0xd3, 0x2a, 0xfe, 0x60, 0x80, 0x5d, 0x82, 0x5e, 0x84, 0x0c, 0x6e, 0x75, 0xad,
+	0x8a, 0xb4, 0x22, 0x5d, 0x2f, 0xac, 0x3f, 0xbd, 0x3a, 0xbe, 0xcd, 0xd5, 0x5a, 0xab, 0x6b, 0x09,
+	0xe2, 0xed, 0x73, 0x2a, 0x23, 0x46, 0x2f, 0xc1, 0x54, 0xa7, 0x37, 0x70, 0xbb, 0x95, 0x14, 0xe3,
+	0x5a, 0x9a, 0xe4, 0xda, 0xa2, 0xc3, 0x01, 0x1b, 0x27, 0xa7, 0x93, 0x19, 0x66, 0xc7, 0xaa, 0xa4,
+	0x93, 0x26, 0xdb, 0x31, 0x3b, 0xe1, 0xc9, 0x28, 0x31, 0xda, 0x04, 0x30, 0x4c, 0x83, 0x68, 0xad,
+	0xae, 0x6e, 0x98, 0x95, 0x29, 0xc6, 0xaa, 0xc4, 0xb1, 0x1a, 0x64, 0x93, 0x92, 0x04, 0xfc, 0x79,
+	0xc3, 0xeb, 0xa3, 0x2b, 0xfe, 0x60, 0x80, 0x9d, 0x93, 0x4a, 0x36, 0x69, 0xc5, 0xef, 0xd0, 0xe1,
+	0xd0, 0x8a, 0x19, 0x39, 0x7a, 0x03, 0x72, 0xad, 0x2e, 0x6e, 0x3d, 0xd4, 0xc8, 0xa8, 0x92, 0x63,
+	0xac, 0x2b, 0x93, 0xac, 0x9b, 0x94, 0xa2, 0x39, 0x0a, 0x98, 0xa7, 0x5b, 0xbc, 0x07, 0xbd, 0x0a,
+	0xd9, 0x96, 0xd5, 0xef, 0x1b, 0xa4, 0x52, 0x60, 0xcc, 0xcb, 0x31, 0xcc, 0x6c, 0x3c, 0xe0, 0x15,
+	0x0c, 0xe8, 0x00, 0xca, 0x3d, 0xc3, 0x25, 0x9a, 0x6b, 0xea, 0xb6, 0xdb, 0xb5, 0x88, 0x5b, 0x29,
+	0x32, 0x11, 0xcf, 0x4e, 0x8a, 0xd8, 0x33, 0x5c, 0xd2, 0xf0, 0xc8, 0x02, 0x49, 0xa5, 0x5e, 0xb8,
+	0x9f, 0x0a, 0xb4, 0x3a, 0x1d, 0xec, 0xf8, 0x12, 0x2b, 0xa5, 0x24, 0x81, 0x07, 0x94, 0xce, 0xe3,
+	0x0c, 0x09, 0xb4, 0xc2, 0xfd, 0xe8, 0xff, 0x60, 0xae, 0x67, 0xe9, 0x6d, 0x5f, 0x9e, 0xd6, 0xea,
+	0x0e, 0xcc, 0x87, 0x95, 0x32, 0x93, 0x7a, 0x23, 0x66, 0x99, 0x96, 0xde, 0xf6, 0x98, 0x37, 0x29,
+	0x69, 0x20, 0x79, 0xb6, 0x37, 0x3e, 0x86, 0x34, 0x98, 0xd7, 0x6d, 0xbb, 0x77, 0x32, 0x2e, 0x7e,
+	0x86, 0x89, 0xbf, 0x39, 0x29, 0xbe, 0x4a, 0xa9, 0x13, 0xe4, 0x23, 0x7d, 0x62, 0x10, 0xdd, 0x03,
+	0xd9, 0x76, 0xb0, 0xad, 0x3b, 0x58, 0xb3, 0x1d, 0xcb, 0xb6, 0x5c, 0xbd, 0x57, 0x91, 0x99, 0xf0,
+	0xeb, 0x93, 0xc2, 0xeb, 0x9c, 0xb2, 0x2e, 0x08, 0x03, 0xc9, 0x33, 0x76, 0x74, 0x84, 0x8b, 0xb5,
+	0x5a, 0xd8, 0x75, 0x03, 0xb1, 0xb3, 0xc9, 0x62, 0x19, 0x65, 0xac, 0xd8, 0xc8, 0x08, 0xda, 0x82,
+	0x02, 0x1e, 0x11, 0x6c, 0xb6, 0xb5, 0xa1, 0x45, 0x70, 0x05, 0x31, 0x89, 0x57, 0x62, 0xae, 0x2b,
+	0x23, 0x3a, 0xb4, 0x08, 0x0e, 0x84, 0x01, 0xf6, 0x3b, 0xd1, 0x11, 0x2c, 0x0c, 0xb1, 0x63, 0x74,
+	0x4e, 0x98, 0x1c, 0x8d, 0x8d, 0xb8, 0x86, 0x65, 0x56, 0xe6, 0x98, 0xc4, 0xe7, 0x27, 0x25, 0x1e,
+	0x32, 0x72, 0xca, 0x5c, 0xf3, 0x88, 0x03, 0xd1, 0x73, 0xc3, 0xc9, 0x51, 0x6a, 0x69, 0x1d, 0xc3,
+	0xd4, 0x7b, 0xc6, 0xb7, 0xb1, 0x76, 0xd4, 0xb3, 0x5a, 0x0f, 0x2b, 0xf3, 0x49, 0x96, 0xb6, 0x25,
+	0xe8, 0x36, 0x28, 0x59, 0xc8, 0xd2, 0x3a, 0xe1, 0xfe, 0x8d, 0x69, 0x98, 0x1a, 0xea, 0xbd, 0x01,
+	0xde, 0xcd, 0xe4, 0x32, 0xf2, 0xd4, 0x6e, 0x26, 0x37, 0x2d, 0xe7, 0x76, 0x33, 0xb9, 0xbc, 0x0c,
+	0xbb, 0x99, 0x1c, 0xc8, 0x05, 0xe5, 0x1a, 0x14, 0x42, 0x7e, 0x0a, 0x55, 0x60, 0xba, 0x8f, 0x5d,
+	0x57, 0x3f, 0xc6, 0xcc, 0xaf, 0xe5, 0x55, 0xaf, 0xa9, 0x94, 0xa1, 0x18, 0x76, 0x4d, 0xca, 0x27,
+	0x12, 0x14, 0x42, 0x4e, 0x87, 0x72, 0x0e, 0xb1, 0xc3, 0x14, 0x22, 0x38, 0x45, 0x13, 0x5d, 0x81,
+	0x12, 0xdb, 0x8b, 0xe6, 0x8d, 0x53, 0xdf, 0x97, 0x51, 0x8b, 0xac, 0xf3, 0x50, 0x10, 0x2d, 0x43,
+	0xc1, 0x5e, 0xb7, 0x7d, 0x92, 0x34, 0x23, 0x01, 0x7b, 0xdd, 0xf6, 0x08, 0x2e, 0x43, 0x91, 0x6e,
+	0xdd, 0xa7, 0xc8, 0xb0, 0x49, 0x0a, 0xb4, 0x4f, 0x90, 0x28, 0xbf, 0x4b, 0x81, 0x3c, 0xee, 0xcc,
+	0xd0, 0x2b, 0x90, 0xa1, 0x5e, 0x5e, 0xb8, 0xe9, 0xc5, 0x55, 0xee, 0xe1, 0x57, 0x3d, 0x0f, 0xbf,
+	0xda, 0xf4, 0x42, 0xc0, 0x46, 0xee, 0xd3, 0x2f, 0x96, 0xcf, 0x7d, 0xf2, 0x87, 0x65, 0x49, 0x65,
+	0x1c, 0xe8, 0x22, 0xf5, 0x60, 0xba, 0x61, 0x6a, 0x46, 0x9b, 0x2d, 0x39, 0x4f, 0xbd, 0x93, 0x6e,
+	0x98, 0x3b, 0x6d, 0x74, 0x17, 0xe4, 0x96, 0x65, 0xba, 0xd8, 0x74, 0x07, 0xae, 0xc6, 0x63, 0x53,
+	0x25, 0x3d, 0xee, 0x5f, 0x79, 0x10, 0x64, 0x8e, 0x4a, 0x90, 0xd6, 0x19, 0xa5, 0x3a, 0xd3, 0x8a,
+	0x76, 0xa0, 0xb7, 0x01, 0xfc, 0x00, 0xe6, 0x56, 0x32, 0x2b, 0xe9, 0xeb, 0x85, 0xf5, 0xcb, 0x31,
+	0xf6, 0xe4, 0xd1, 0xdc, 0xb3, 0xdb, 0x3a, 0xc1, 0x1b, 0x19, 0xba, 0x60, 0x35, 0xc4, 0x8a, 0x9e,
+	0x85, 0x19, 0xdd, 0xb6, 0x35, 0x97, 0xe8, 0x04, 0x6b, 0x47, 0x27, 0x04, 0xbb, 0xcc, 0xed, 0x17,
+	0xd5, 0x92, 0x6e, 0xdb, 0x0d, 0xda, 0xbb, 0x41, 0x3b, 0xd1, 0x55, 0x28, 0x53, 0x0f, 0x6f, 0xe8,
+	0x3d, 0xad, 0x8b, 0x8d, 0xe3, 0x2e, 0x61, 0xde, 0x3d, 0xad, 0x96, 0x44, 0xef, 0x36, 0xeb, 0x54,
+	0xda, 0x50, 0x0c, 0x3b, 0x77, 0x84, 0x20, 0xd3, 0xd6, 0x89, 0xce, 0x74, 0x59, 0x54, 0xd9, 0x37,
+	0xed, 0xb3, 0x75, 0xd2, 0x15, 0x1a, 0x62, 0xdf, 0xe8, 0x3c, 0x64, 0x85, 0xd8, 0x34, 0x13, 0x2b,
+	0x5a, 0x68, 0x1e, 0xa6, 0x6c, 0xc7, 0x1a, 0x62, 0x76, 0x78, 0x39, 0x95, 0x37, 0x94, 0xfb, 0x50,
+	0x8e, 0xc6, 0x01, 0x54, 0x86, 0x14, 0x19, 0x89, 0x59, 0x52, 0x64, 0x84, 0x6e, 0x41, 0x86, 0x2a,
+	0x93, 0x49, 0x2b, 0xc7, 0x45, 0x3f, 0xc1, 0xdf, 0x3c, 0xb1, 0xb1, 0xca, 0x48, 0x77, 0x33, 0xb9,
+	0x94, 0x9c, 0x56, 0x66, 0xa0, 0x14, 0x89, 0x12, 0xca, 0x79, 0x98, 0x8f, 0xf3, 0xf9, 0xd4, 0x9a,
+	0xe7, 0xe3, 0x7c, 0x37, 0x7a, 0x09, 0x72, 0xbe, 0xd7, 0xf7, 0x4c, 0x68, 0x62, 0x7a, 0x9f, 0xc9,
+	0xa7, 0xa5, 0xc6, 0x43, 0x4f, 0xa2, 0xab, 0x8b, 0x58, 0x5f, 0x54, 0xa7, 0x75, 0xdb, 0xde, 0xd6,
+	0xdd, 0x2e, 0x55, 0x3e, 0x75, 0xaa, 0x06, 0x6e, 0x73, 0xc7, 0xcc, 0x4d, 0xa7, 0xa4, 0x96, 0x44,
+	0x2f, 0x73, 0xb2, 0xae, 0xf2, 0x1e, 0x54, 0x92, 0xfc, 0x7e, 0x48, 0xc1, 0x12, 0xbb, 0x28, 0x9e,
+	0x82, 0xcf, 0x43, 0xb6, 0x63, 0x39, 0x7d, 0x9d, 0xb0, 0x39, 0x4b, 0xaa, 0x68, 0x51, 0xc5, 0xf3,
+	0x18, 0xc0, 0x67, 0xe2, 0x0d, 0x45, 0x83, 0x8b, 0x89, 0xae, 0x9f, 0xb2, 0x18, 0x66, 0x1b, 0xf3,
+	0x63, 0x28, 0xa9, 0xbc, 0x11, 0x08, 0xe2, 0x7b, 0xe2, 0x0d, 0x3a, 0xad, 0x8b, 0xcd, 0x36, 0x76,
+	0x98, 0xfc, 0xbc, 0x2a, 0x5a, 0xca, 0x4f, 0xd2, 0x70, 0x3e, 0xde, 0xff, 0xa3, 0x15, 0x28, 0xf6,
+	0xf5, 0x91, 0x46, 0x46, 0xc2, 0x4c, 0x25, 0x66, 0x28, 0xd0, 0xd7, 0x47, 0xcd, 0x11, 0xb7, 0x51,
+	0x19, 0xd2, 0x64, 0xe4, 0x56, 0x52, 0x2b, 0xe9, 0xeb, 0x45, 0x95, 0x7e, 0xa2, 0x43, 0x98, 0xed,
+	0x59, 0x2d, 0xbd, 0xa7, 0xf5, 0x74, 0x97, 0x68, 0x22, 0x3d, 0xe0, 0xd7, 0xee, 0x99, 0x24, 0x7f,
+	0x8e, 0xdb, 0xdc, 0x00, 0xa8, 0xab, 0x12, 0x17, 0x66, 0x86, 0x09, 0xd9, 0xd3, 0x5d, 0xc2, 0x87,
+	0x50, 0x0d, 0x0a, 0x7d, 0xc3, 0x3d, 0xc2, 0x5d, 0x7d, 0x68, 0x58, 0x8e, 0xb8, 0x7f, 0x31, 0x56,
+	0x76, 0x37, 0x20, 0x12, 0xa2, 0xc2, 0x7c, 0xa1, 0x43, 0x99, 0x8a, 0x58, 0xbd, 0xe7, 0x81, 0xb2,
+	0x8f, 0xed, 0x81, 0xfe, 0x03, 0xe6, 0x4d, 0x3c, 0x22, 0x5a, 0x70, 0xc3, 0xb9, 0x41, 0x4d, 0x33,
+	0xe5, 0x23, 0x3a, 0xe6, 0xfb, 0x04, 0x97, 0xd9, 0xd6, 0x73, 0x2c, 0x86, 0xda, 0x96, 0x8b, 0x1d,
+	0x4d, 0x6f, 0xb7, 0x1d, 0xec, 0xba, 0x2c, 0xfb, 0x2a, 0xaa, 0x33, 0x5e, 0x7f, 0x95, 0x77, 0x2b,
+	0x1f, 0xb3, 0xc3, 0x89, 0x8b, 0xa2, 0x9e, 0xea, 0xa5, 0x40, 0xf5, 0x4d, 0x98, 0x17, 0xfc, 0xed,
+	0x88, 0xf6, 0x79, 0x1a, 0x7b, 0x29, 0x29, 0x39, 0x0b, 0x69, 0x1d, 0x79, 0xfc, 0xc9, 0x8a, 0x4f,
+	0x3f, 0xa1, 0xe2, 0x11, 0x64, 0x98, 0x5a, 0x32, 0xdc, 0x2d, 0xd1, 0xef, 0x7f, 0xb6, 0xc3, 0xf8,
+	0x28, 0x0d, 0xb3, 0x13, 0x09, 0x88, 0xbf, 0x31, 0x29, 0x76, 0x63, 0xa9, 0xd8, 0x8d, 0xa5, 0x1f,
+	0x7b, 0x63, 0xe2, 0xb4, 0x33, 0x67, 0x9f, 0xf6, 0xd4, 0x37, 0x79, 0xda, 0xd9, 0x27, 0x3c, 0xed,
+	0x7f, 0xe8, 0x39, 0x7c, 0x26, 0xc1, 0x62, 0x72, 0xda, 0x16, 0x7b, 0x20, 0x37, 0x61, 0xd6, 0x5f,
+	0x8a, 0x2f, 0x9e, 0xbb, 0x47, 0xd9, 0x1f, 0x10, 0xf2, 0x13, 0x23, 0xe3, 0x55, 0x28, 0x8f, 0x65,
+	0x95, 0xdc, 0x98, 0x4b, 0xc3, 0x48, 0x7e, 0x78, 0x0b, 0x16, 0x4c, 0xcb, 0xd4, 0x1c, 0x7b, 0x3c,
+	0x07, 0x9d, 0x12, 0x9b, 0xb7, 0x4c, 0xd5, 0x8e, 0xac, 0x5c, 0xf9, 0x55, 0x1a, 0xe6, 0xe3, 0x72,
+	0xc5, 0x98, 0x4b, 0xae, 0xc2, 0x5c, 0x1b, 0xb7, 0x8c, 0xf6, 0x13, 0xdf, 0xf1, 0x59, 0xc1, 0xfe,
+	0xef, 0x2b, 0x3e, 0x69, 0x5a, 0xe8, 0x06, 0xcc, 0xba, 0x27, 0x66, 0xcb, 0x30, 0x8f, 0x35, 0x62,
+	0x79, 0x69, 0x57, 0x9e, 0xad, 0x7c, 0x46, 0x0c, 0x34, 0x2d, 0x91, 0x78, 0xfd, 0x02, 0x20, 0xa7,
+	0x62, 0xd7, 0xb6, 0x4c, 0x17, 0xa3, 0x4d, 0xc8, 0xe3, 0x51, 0x0b, 0xdb, 0xc4, 0xcb, 0xad, 0x13,
+	0xca, 0x17, 0x41, 0xe2, 0xf1, 0xd1, 0x32, 0xde, 0xe7, 0x43, 0xff, 0x29, 0xd0, 0x8a, 0x44, 0xdc,
+	0x81, 0x57, 0x01, 0x3e, 0x2b, 0xa3, 0x46, 0x2f, 0x7b, 0x70, 0x45, 0x3a, 0xa9, 0x08, 0x17, 0x35,
+	0x81, 0xcf, 0xc7, 0xe9, 0xe9, 0x74, 0x0c, 0xaf, 0xc8, 0x24, 0x4d, 0xc7, 0x4b, 0x87, 0x60, 0x3a,
+	0x4a, 0x8d, 0x6e, 0x47, 0x00, 0x8b, 0x6c, 0xd2, 0x56, 0x43, 0x39, 0x7e, 0xb0, 0xd5, 0x00, 0xb1,
+	0x78, 0xd9, 0x43, 0x2c, 0xa6, 0x93, 0x16, 0x2d, 0x92, 0xda, 0x60, 0xd1, 0x8c, 0x1e, 0xbd, 0x19,
+	0x82, 0x2c, 0xf2, 0x2b, 0x52, 0x7c, 0x12, 0xee, 0xa7, 0xaa, 0x3e, 0xb7, 0x8f, 0x59, 0xbc, 0xe6,
+	0x63, 0x16, 0xc5, 0x44, 0xc0, 0x43, 0x64, 0xa3, 0x3e, 0xb3, 0xe0, 0x40, 0xf5, 0x09, 0xd0, 0x82,
+	0x63, 0x0c, 0xd7, 0xce, 0x04, 0x2d, 0x7c, 0x51, 0x63, 0xa8, 0x45, 0x7d, 0x02, 0xb5, 0x28, 0x27,
+	0x49, 0x1c, 0xcb, 0x7c, 0x03, 0x89, 0x51, 0xd8, 0xe2, 0xff, 0xe3, 0x61, 0x8b, 0x44, 0x5c, 0x21,
+	0x26, 0x7d, 0xf5, 0x45, 0xc7, 0xe0, 0x16, 0xef, 0x25, 0xe0, 0x16, 0x72, 0x52, 0x7d, 0x1d, 0x97,
+	0xbc, 0xfa, 0x13, 0xc4, 0x01, 0x17, 0x87, 0x31, 0xc0, 0x05, 0x47, 0x18, 0x9e, 0x7b, 0x04, 0xe0,
+	0xc2, 0x17, 0x3d, 0x81, 0x5c, 0x1c, 0xc6, 0x20, 0x17, 0x28, 0x59, 0xee, 0x58, 0xce, 0x15, 0x96,
+	0x1b, 0x19, 0x42, 0x6f, 0x47, 0xa1, 0x8b, 0xb9, 0xd3, 0x53, 0x5d, 0x9e, 0x39, 0xf8, 0xd2, 0xc2,
+	0xd8, 0x45, 0x2b, 0x09, 0xbb, 0xe0, 0xf0, 0xc2, 0x0b, 0x8f, 0x88, 0x5d, 0xf8, 0xb2, 0x63, 0xc1,
+	0x8b, 0xfa, 0x04, 0x78, 0xb1, 0x90, 0x64, 0x70, 0x63, 0x01, 0x29, 0x30, 0xb8, 0x44, 0xf4, 0x62,
+	0x4a, 0xce, 0xee, 0x66, 0x72, 0x39, 0x39, 0xcf, 0x71, 0x8b, 0xdd, 0x4c, 0xae, 0x20, 0x17, 0x95,
+	0xe7, 0x68, 0xd6, 0x34, 0xe6, 0xf7, 0x68, 0x8d, 0x82, 0x1d, 0xc7, 0x72, 0x04, 0x0e, 0xc1, 0x1b,
+	0xca, 0x75, 0x28, 0x86, 0x5d, 0xdc, 0x29, 0x48, 0xc7, 0x0c, 0x94, 0x22, 0x5e, 0x4d, 0xf9, 0x5b,
+	0x0a, 0x8a, 0x61, 0x7f, 0x15, 0xa9, 0x83, 0xf3, 0xa2, 0x0e, 0x0e, 0xe1, 0x1f, 0xa9, 0x28, 0xfe,
+	0xb1, 0x0c, 0x05, 0x5a, 0x0a, 0x8e, 0x41, 0x1b, 0xba, 0xed, 0x43, 0x1b, 0x37, 0x60, 0x96, 0xc5,
+	0x5b, 0x8e, 0x92, 0x88, 0xc8, 0x90, 0xe1, 0x91, 0x81, 0x0e, 0x30, 0x65, 0xf0, 0xc8, 0x80, 0x5e,
+	0x80, 0xb9, 0x10, 0xad, 0x5f, 0x62, 0xf2, 0xf8, 0x2f, 0xfb, 0xd4, 0x55, 0x51, 0x6b, 0xfe, 0x2f,
+	0xcc, 0xf4, 0x74, 0x93, 0x9a, 0xbb, 0x61, 0x39, 0x06, 0x31, 0xb0, 0x2b, 0xf2, 0xae, 0xf5, 0xd3,
+	0x5d, 0xf2, 0xea, 0x9e, 0x6e, 0xe2, 0xba, 0xcf, 0x54, 0x33, 0x89, 0x73, 0xa2, 0x96, 0x7b, 0x91,
+	0x4e, 0x0a, 0xc9, 0xb4, 0x71, 0x47, 0x1f, 0xf4, 0x88, 0x46, 0x47, 0x98, 0xbf, 0xcd, 0xab, 0x05,
+	0xd1, 0x47, 0x25, 0x2c, 0x56, 0x61, 0x2e, 0x46, 0x12, 0xcd, 0x3d, 0x1e, 0xe2, 0x13, 0xa1, 0x3f,
+	0xfa, 0x89, 0xe6, 0xc5, 0x51, 0x8b, 0xc2, 0x95, 0x37, 0x5e, 0x4b, 0xbd, 0x22, 0x29, 0xbf, 0x95,
+	0x60, 0x76, 0xc2, 0xe3, 0xc7, 0x22, 0x30, 0xd2, 0x37, 0x85, 0xc0, 0xa4, 0x9e, 0x1c, 0x81, 0x09,
+	0xd7, 0xfd, 0xe9, 0x48, 0xdd, 0xaf, 0xfc, 0x55, 0x82, 0x52, 0x24, 0xf2, 0x50, 0x3b, 0x6a, 0x59,
+	0x6d, 0x2c, 0x4a, 0x6c, 0xf6, 0x4d, 0x55, 0xd3, 0xb3, 0x8e, 0x45, 0x21, 0x4d, 0x3f, 0x29, 0x95,
+	0x1f, 0x4b, 0xf3, 0x22, 0x52, 0xfa, 0xd5, 0x39, 0x4f, 0x7d, 0x78, 0xc3, 0x53, 0x6b, 0x96, 0xcd,
+	0x1b, 0x55, 0x2b, 0x4f, 0x61, 0x78, 0x03, 0xbd, 0x0a, 0x79, 0xf6, 0xde, 0xa2, 0x59, 0xb6, 0x5b,
+	0xc9, 0x8d, 0xa7, 0x77, 0xfc, 0x51, 0x66, 0x75, 0x78, 0x8b, 0xba, 0x2a, 0xab, 0x73, 0x60, 0xbb,
+	0x6a, 0xce, 0x16, 0x5f, 0xa1, 0xa4, 0x2b, 0x1f, 0x49, 0xba, 0x2e, 0x41, 0x9e, 0x2e, 0xdf, 0xb5,
+	0xf5, 0x16, 0xae, 0x00, 0x5b, 0x69, 0xd0, 0xa1, 0xfc, 0x26, 0x05, 0x33, 0x63, 0x81, 0x33, 0x76,
+	0xf3, 0xde, 0xc5, 0x4a, 0x85, 0x00, 0xa6, 0x47, 0x53, 0xc8, 0x12, 0xc0, 0xb1, 0xee, 0x6a, 0x1f,
+	0xea, 0x26, 0xc1, 0x6d, 0xa1, 0x95, 0x50, 0x0f, 0x5a, 0x84, 0x1c, 0x6d, 0x0d, 0x5c, 0xdc, 0x16,
+	0x58, 0x97, 0xdf, 0x46, 0x3b, 0x90, 0xc5, 0x43, 0x6c, 0x12, 0xb7, 0x32, 0xcd, 0x0e, 0xfe, 0x42,
+	0x8c, 0x87, 0xa5, 0xe3, 0x1b, 0x15, 0x7a, 0xdc, 0x7f, 0xfe, 0x62, 0x59, 0xe6, 0xe4, 0xcf, 0x5b,
+	0x7d, 0x83, 0xe0, 0xbe, 0x4d, 0x4e, 0x54, 0x21, 0x20, 0xaa, 0x86, 0xdc, 0x98, 0x1a, 0xd0, 0x05,
+	0x98, 0x66, 0xb7, 0xd1, 0x68, 0xb3, 0x0c, 0x21, 0xaf, 0x66, 0x69, 0x73, 0xa7, 0xcd, 0x10, 0xd9,
+	0xa2, 0x07, 0x9b, 0x50, 0x6d, 0xb3, 0xeb, 0x72, 0xa2, 0x96, 0xfa, 0xb8, 0x6f, 0x5b, 0x56, 0x4f,
+	0xe3, 0x3e, 0xac, 0x0a, 0xe5, 0x68, 0x02, 0x41, 0xb1, 0x55, 0x07, 0x13, 0x0a, 0x52, 0x46, 0xca,
+	0x8a, 0x22, 0xef, 0xe4, 0x3e, 0x63, 0x37, 0x93, 0x93, 0xe4, 0x94, 0x40, 0xc4, 0xde, 0x81, 0x85,
+	0xd8, 0xfc, 0x01, 0xbd, 0x02, 0xf9, 0x20, 0xf7, 0x90, 0x56, 0xd2, 0x67, 0x20, 0x5d, 0x01, 0xb1,
+	0x62, 0xc2, 0x42, 0x6c, 0x02, 0x81, 0xde, 0x80, 0xac, 0x83, 0xdd, 0x41, 0x8f, 0xa3, 0x54, 0xe5,
+	0xf5, 0xab, 0x67, 0x67, 0x1e, 0x83, 0x1e, 0x51, 0x05, 0x13, 0x35, 0x35, 0xfa, 0xd5, 0xe7, 0x3e,
+	0x21, 0xa7, 0x8a, 0x96, 0x72, 0x0b, 0x2e, 0x26, 0x66, 0x16, 0x01, 0x40, 0x25, 0x85, 0x00, 0x2a,
+	0xe5, 0x97, 0x12, 0x2c, 0x26, 0x67, 0x0b, 0x68, 0x63, 0x6c, 0xa1, 0x37, 0x1e, 0x31, 0xd7, 0x08,
+	0xaf, 0xf6, 0x2a, 0x94, 0x1d, 0xdc, 0xc1, 0xa4, 0xd5, 0xf5, 0x50, 0x3d, 0xea, 0x45, 0x4a, 0x6a,
+	0x49, 0xf4, 0x32, 0x1e, 0x97, 0x93, 0xbd, 0x8f, 0x5b, 0x44, 0xe3, 0x87, 0xed, 0xb2, 0x92, 0x28,
+	0xaf, 0x96, 0x78, 0x6f, 0x83, 0x77, 0x2a, 0x37, 0xe1, 0x42, 0x42, 0xfe, 0x31, 0x59, 0xb7, 0x29,
+	0x0f, 0x28, 0x71, 0x6c, 0x52, 0x81, 0xde, 0x82, 0xac, 0x4b, 0x74, 0x32, 0x70, 0xc5, 0xce, 0xae,
+	0x9d, 0x99, 0x8f, 0x34, 0x18, 0xb9, 0x2a, 0xd8, 0x14, 0x0c, 0x68, 0x32, 0xbb, 0x88, 0x29, 0x57,
+	0xa5, 0xb8, 0x72, 0xf5, 0x3a, 0xc8, 0xa2, 0x5c, 0x0d, 0x08, 0xf9, 0xd5, 0x2e, 0xb3, 0x4a, 0x35,
+	0xa8, 0x52, 0x8f, 0xe0, 0xa9, 0x53, 0x32, 0x0e, 0xb4, 0x39, 0xb6, 0x8d, 0x9b, 0x8f, 0x94, 0xb0,
+	0x8c, 0x6d, 0xe5, 0xd7, 0x69, 0x58, 0x88, 0x4d, 0x3c, 0x42, 0x0e, 0x40, 0xfa, 0xba, 0x0e, 0xe0,
+	0x0d, 0x00, 0x32, 0xd2, 0xb8, 0x4d, 0x78, 0x81, 0x24, 0xae, 0xda, 0x1a, 0xe1, 0x56, 0x73, 0x24,
+	0x4c, 0x28, 0x4f, 0xc4, 0x17, 0x45, 0x5e, 0x42, 0x60, 0xc2, 0x80, 0x05, 0x19, 0xb7, 0x92, 0x7e,
+	0xbc, 0x70, 0x24, 0x0f, 0xa3, 0xdd, 0x2e, 0x7a, 0x00, 0x17, 0xc6, 0x82, 0xa5, 0x2f, 0x3b, 0xf3,
+	0xc8, 0x31, 0x73, 0x21, 0x1a, 0x33, 0x3d, 0xd9, 0xe1, 0x80, 0x37, 0x15, 0x05, 0xba, 0xef, 0x82,
+	0xcc, 0xca, 0x69, 0x9e, 0xab, 0xb4, 0x71, 0x4f, 0xf7, 0x5e, 0x91, 0x2f, 0x4e, 0x14, 0xe5, 0xb7,
+	0xc5, 0x43, 0x3b, 0xaf, 0xc9, 0x7f, 0x4c, 0x6b, 0xf2, 0x32, 0x65, 0x66, 0x07, 0x75, 0x9b, 0xb2,
+	0x2a, 0x0f, 0x00, 0x02, 0xc4, 0x81, 0x5e, 0x74, 0xc7, 0x1a, 0x98, 0x6d, 0x66, 0x11, 0x53, 0x2a,
+	0x6f, 0xd0, 0xd7, 0x6a, 0x6a, 0x82, 0x9e, 0xe6, 0x63, 0x3c, 0x18, 0xb5, 0x90, 0x10, 0x64, 0xc1,
+	0xc9, 0x95, 0xf7, 0x01, 0x4d, 0xe2, 0xc5, 0x09, 0x73, 0xbc, 0x19, 0x9d, 0x43, 0x49, 0x86, 0x9e,
+	0xe3, 0xe7, 0xfa, 0x0e, 0x4c, 0x31, 0x6b, 0xa2, 0x71, 0x8c, 0x3d, 0x6b, 0x88, 0x34, 0x92, 0x7e,
+	0xa3, 0x6f, 0x01, 0xe8, 0x84, 0x38, 0xc6, 0xd1, 0x20, 0x98, 0x61, 0x25, 0xc1, 0x1c, 0xab, 0x1e,
+	0xe1, 0xc6, 0x25, 0x61, 0x97, 0xf3, 0x01, 0x6f, 0xc8, 0x36, 0x43, 0x12, 0x95, 0x7d, 0x28, 0x47,
+	0x79, 0xcf, 0xca, 0xc5, 0xf2, 0x5e, 0xd2, 0xe0, 0xa7, 0x1c, 0x69, 0xfe, 0x78, 0xc3, 0x1a, 0xca,
+	0x77, 0x53, 0x50, 0x0c, 0x1b, 0xf3, 0xbf, 0x60, 0x58, 0x57, 0x7e, 0x20, 0x41, 0xce, 0xdf, 0x7f,
+	0xf4, 0x69, 0x26, 0xf2, 0xf6, 0xc5, 0xd5, 0x97, 0x0a, 0xbf, 0xa7, 0xf0, 0x97, 0xae, 0xb4, 0xff,
+	0xd2, 0xf5, 0xdf, 0x7e, 0x24, 0x4a, 0x44, 0x4e, 0xc2, 0xda, 0x16, 0x86, 0x25, 0x78, 0x94, 0xd7,
+	0x21, 0xef, 0xbb, 0x04, 0x5a, 0x90, 0x78, 0x88, 0x94, 0x24, 0xee, 0x25, 0x6f, 0xd2, 0xa5, 0xd8,
+	0xd6, 0x87, 0xe2, 0xb5, 0x26, 0xad, 0xf2, 0x86, 0xe2, 0xc2, 0xcc, 0x98, 0x3f, 0x09, 0x08, 0x53,
+	0x21, 0x42, 0xa4, 0x40, 0xc9, 0x1e, 0x1c, 0x69, 0x0f, 0xf1, 0x89, 0x78, 0xbb, 0xe1, 0xcb, 0x2f,
+	0xd8, 0x83, 0xa3, 0x3b, 0xf8, 0x84, 0x3f, 0xde, 0xac, 0x40, 0xd1, 0xa3, 0x61, 0x26, 0xce, 0xcf,
+	0x14, 0x38, 0x49, 0x93, 0x3f, 0xd0, 0x49, 0x72, 0x4a, 0xf9, 0x91, 0x04, 0x39, 0xef, 0x96, 0xa0,
+	0xb7, 0x20, 0xef, 0xbb, 0x2e, 0x91, 0xcc, 0x3f, 0x75, 0x8a, 0xd3, 0x13, 0x9b, 0x0f, 0x78, 0xd0,
+	0x86, 0xf7, 0xd2, 0x6c, 0xb4, 0xb5, 0x4e, 0x4f, 0x3f, 0x16, 0x0f, 0x86, 0x4b, 0x31, 0xde, 0x8d,
+	0xf9, 0x95, 0x9d, 0xdb, 0x5b, 0x3d, 0xfd, 0x58, 0x2d, 0x30, 0xa6, 0x9d, 0x36, 0x6d, 0x88, 0x34,
+	0xe9, 0x4f, 0x29, 0x90, 0xc7, 0x6f, 0xf1, 0xd7, 0x5f, 0xdf, 0x64, 0xd8, 0x4c, 0xc7, 0x85, 0xcd,
+	0x35, 0x98, 0xf3, 0x29, 0x34, 0xd7, 0x38, 0x36, 0x75, 0x32, 0x70, 0xb0, 0xc0, 0x3e, 0x91, 0x3f,
+	0xd4, 0xf0, 0x46, 0x26, 0xf7, 0x3d, 0xf5, 0xd8, 0xfb, 0x4e, 0x86, 0x96, 0xb3, 0x49, 0xd0, 0x32,
+	0x7a, 0x1d, 0x16, 0xc7, 0xc3, 0x7b, 0x68, 0xb9, 0xbc, 0xe2, 0xb8, 0x10, 0x0d, 0xf4, 0xfe, 0x9a,
+	0x85, 0x9e, 0x3f, 0x4a, 0x41, 0x21, 0x04, 0xfd, 0xa2, 0xff, 0x0a, 0xb9, 0xc4, 0x72, 0x5c, 0xc8,
+	0x0b, 0x11, 0x07, 0xaf, 0xbd, 0xd1, 0x93, 0x49, 0x3d, 0xc1, 0xc9, 0x24, 0xe1, 0xf2, 0x1e, 0x96,
+	0x9c, 0x79, 0x6c, 0x2c, 0xf9, 0x79, 0x40, 0xc4, 0x22, 0x7a, 0x8f, 0xaa, 0x93, 0x62, 0xbe, 0xfc,
+	0x22, 0x71, 0x0f, 0x26, 0xb3, 0x91, 0x43, 0x36, 0x50, 0x67, 0x97, 0xef, 0x7b, 0x12, 0xe4, 0x7c,
+	0x9c, 0xed, 0x71, 0x5f, 0x77, 0xcf, 0x43, 0x36, 0xf2, 0x90, 0x2c, 0x5a, 0xb1, 0xa0, 0xf9, 0x22,
+	0xe4, 0xfa, 0x98, 0xe8, 0xcc, 0x1d, 0xf3, 0x70, 0xed, 0xb7, 0x6f, 0x1c, 0x41, 0x21, 0xf4, 0x90,
+	0x8e, 0x2e, 0xc2, 0xc2, 0xe6, 0x76, 0x6d, 0xf3, 0x8e, 0xd6, 0x7c, 0x57, 0x6b, 0xde, 0xaf, 0xd7,
+	0xb4, 0x7b, 0xfb, 0x77, 0xf6, 0x0f, 0xfe, 0x67, 0x5f, 0x3e, 0x37, 0x39, 0xa4, 0xd6, 0x58, 0x5b,
+	0x96, 0xd0, 0x05, 0x98, 0x8b, 0x0e, 0xf1, 0x81, 0xd4, 0x62, 0xe6, 0x87, 0x3f, 0x5f, 0x3a, 0x77,
+	0xe3, 0x2f, 0x12, 0xcc, 0xc5, 0x24, 0xfd, 0xe8, 0x32, 0x3c, 0x7d, 0xb0, 0xb5, 0x55, 0x53, 0xb5,
+	0xc6, 0x7e, 0xb5, 0xde, 0xd8, 0x3e, 0x68, 0x6a, 0x6a, 0xad, 0x71, 0x6f, 0xaf, 0x19, 0x9a, 0x74,
+	0x05, 0x2e, 0xc5, 0x93, 0x54, 0x37, 0x37, 0x6b, 0xf5, 0xa6, 0x2c, 0xa1, 0x65, 0x78, 0x2a, 0x81,
+	0x62, 0xe3, 0x40, 0x6d, 0xca, 0xa9, 0x64, 0x11, 0x6a, 0x6d, 0xb7, 0xb6, 0xd9, 0x94, 0xd3, 0xe8,
+	0x1a, 0x5c, 0x39, 0x8d, 0x42, 0xdb, 0x3a, 0x50, 0xef, 0x56, 0x9b, 0x72, 0xe6, 0x4c, 0xc2, 0x46,
+	0x6d, 0xff, 0x76, 0x4d, 0x95, 0xa7, 0xc4, 0xbe, 0x7f, 0x96, 0x82, 0x4a, 0x52, 0x0d, 0x41, 0x65,
+	0x55, 0xeb, 0xf5, 0xbd, 0xfb, 0x81, 0xac, 0xcd, 0xed, 0x7b, 0xfb, 0x77, 0x26, 0x55, 0xf0, 0x2c,
+	0x28, 0xa7, 0x11, 0xfa, 0x8a, 0xb8, 0x0a, 0x97, 0x4f, 0xa5, 0x13, 0xea, 0x38, 0x83, 0x4c, 0xad,
+	0x35, 0xd5, 0xfb, 0x72, 0x1a, 0xad, 0xc2, 0x8d, 0x33, 0xc9, 0xfc, 0x31, 0x39, 0x83, 0xd6, 0xe0,
+	0xe6, 0xe9, 0xf4, 0x5c, 0x41, 0x1e, 0x83, 0xa7, 0xa2, 0x8f, 0x25, 0x58, 0x88, 0x2d, 0x46, 0xd0,
+	0x15, 0x58, 0xae, 0xab, 0x07, 0x9b, 0xb5, 0x46, 0x43, 0xab, 0xab, 0x07, 0xf5, 0x83, 0x46, 0x75,
+	0x4f, 0x6b, 0x34, 0xab, 0xcd, 0x7b, 0x8d, 0x90, 0x6e, 0x14, 0x58, 0x4a, 0x22, 0xf2, 0xf5, 0x72,
+	0x0a, 0x8d, 0xb0, 0x00, 0xcf, 0x4e, 0x7f, 0x2a, 0xc1, 0xc5, 0xc4, 0x92, 0x02, 0x5d, 0x87, 0x67,
+	0x0e, 0x6b, 0xea, 0xce, 0xd6, 0x7d, 0xed, 0xf0, 0xa0, 0x59, 0xd3, 0x6a, 0xef, 0x36, 0x6b, 0xfb,
+	0x8d, 0x9d, 0x83, 0xfd, 0xc9, 0x55, 0x5d, 0x83, 0x2b, 0xa7, 0x52, 0xfa, 0x4b, 0x3b, 0x8b, 0x70,
+	0x6c, 0x7d, 0xdf, 0x97, 0x60, 0x66, 0xcc, 0x17, 0xa2, 0x4b, 0x50, 0xb9, 0xbb, 0xd3, 0xd8, 0xa8,
+	0x6d, 0x57, 0x0f, 0x77, 0x0e, 0xd4, 0xf1, 0x3b, 0x7b, 0x05, 0x96, 0x27, 0x46, 0x6f, 0xdf, 0xab,
+	0xef, 0xed, 0x6c, 0x56, 0x9b, 0x35, 0x36, 0xa9, 0x2c, 0xd1, 0x8d, 0x4d, 0x10, 0xed, 0xed, 0xbc,
+	0xbd, 0xdd, 0xd4, 0x36, 0xf7, 0x76, 0x6a, 0xfb, 0x4d, 0xad, 0xda, 0x6c, 0x56, 0x83, 0xeb, 0xbc,
+	0x71, 0xe7, 0xc1, 0xad, 0x63, 0x83, 0x74, 0x07, 0x47, 0xd4, 0xdb, 0xae, 0x05, 0xff, 0xf5, 0x7a,
+	0x1f, 0xba, 0x6d, 0xac, 0x8d, 0xff, 0x1d, 0xfc, 0xe9, 0x97, 0x4b, 0xd2, 0xe7, 0x5f, 0x2e, 0x49,
+	0x7f, 0xfc, 0x72, 0x49, 0xfa, 0xe4, 0xab, 0xa5, 0x73, 0x9f, 0x7f, 0xb5, 0x74, 0xee, 0xf7, 0x5f,
+	0x2d, 0x9d, 0x3b, 0xca, 0x32, 0xb7, 0xfa, 0xe2, 0xdf, 0x07, 0x00, 0x3e, 0x8b, 0xc0, 0xc6, 0x50,
+	0x2c, 0x00, 0x00,
 }
 
 func (m *Request) Marshal() (dAtA []byte, err error) {
@@ -4761,6 +4784,11 @@ func (m *OfferSnapshotRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AppliedChunks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppliedChunks))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.AppHash) > 0 {
 		i -= len(m.AppHash)
 		copy(dAtA[i:], m.AppHash)
@@ -6157,6 +6185,16 @@ func (m *OfferSnapshotResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Resume {
+		i--
+		if m.Resume {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Result != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
 		i--
@@ -7424,6 +7462,9 @@ func (m *OfferSnapshotRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.AppliedChunks != 0 {
+		n += 1 + sovTypes(uint64(m.AppliedChunks))
+	}
 	return n
 }
 
@@ -8090,6 +8131,9 @@ func (m *OfferSnapshotResponse) Size() (n int) {
 	if m.Result != 0 {
 		n += 1 + sovTypes(uint64(m.Result))
 	}
+	if m.Resume {
+		n += 2
+	}
 	return n
 }
 
@@ -10083,6 +10127,25 @@ func (m *OfferSnapshotRequest) Unmarshal(dAtA []byte) error {
 				m.AppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedChunks", wireType)
+			}
+			m.AppliedChunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppliedChunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13922,6 +13985,26 @@ func (m *OfferSnapshotResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resume", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Resume = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -41,6 +41,18 @@ func (m *StatusResponse) Wrap() proto.Message {
 	return bm
 }
 
+func (m *HeadersRequest) Wrap() proto.Message {
+	bm := &Message{}
+	bm.Sum = &Message_HeadersRequest{HeadersRequest: m}
+	return bm
+}
+
+func (m *HeadersResponse) Wrap() proto.Message {
+	bm := &Message{}
+	bm.Sum = &Message_HeadersResponse{HeadersResponse: m}
+	return bm
+}
+
 // Unwrap implements the p2p Wrapper interface and unwraps a wrapped blockchain
 // message.
 func (m *Message) Unwrap() (proto.Message, error) {
@@ -60,6 +72,12 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_StatusResponse:
 		return m.GetStatusResponse(), nil
 
+	case *Message_HeadersRequest:
+		return m.GetHeadersRequest(), nil
+
+	case *Message_HeadersResponse:
+		return m.GetHeadersResponse(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -256,6 +256,106 @@ func (m *BlockResponse) GetExtCommit() *v2.ExtendedCommit {
 	return nil
 }
 
+// HeadersRequest requests the headers of up to `count` consecutive heights,
+// ending at `height`.
+type HeadersRequest struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Count  int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *HeadersRequest) Reset()         { *m = HeadersRequest{} }
+func (m *HeadersRequest) String() string { return proto.CompactTextString(m) }
+func (*HeadersRequest) ProtoMessage()    {}
+func (*HeadersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_909d653dec9b4244, []int{5}
+}
+func (m *HeadersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeadersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeadersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeadersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeadersRequest.Merge(m, src)
+}
+func (m *HeadersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *HeadersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeadersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeadersRequest proto.InternalMessageInfo
+
+func (m *HeadersRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *HeadersRequest) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// HeadersResponse returns the requested headers, in descending height order.
+// It may contain fewer headers than requested.
+type HeadersResponse struct {
+	Headers []*v2.Header `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+}
+
+func (m *HeadersResponse) Reset()         { *m = HeadersResponse{} }
+func (m *HeadersResponse) String() string { return proto.CompactTextString(m) }
+func (*HeadersResponse) ProtoMessage()    {}
+func (*HeadersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_909d653dec9b4244, []int{6}
+}
+func (m *HeadersResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeadersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeadersResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeadersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeadersResponse.Merge(m, src)
+}
+func (m *HeadersResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *HeadersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeadersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeadersResponse proto.InternalMessageInfo
+
+func (m *HeadersResponse) GetHeaders() []*v2.Header {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
 // Message is an abstract blocksync message.
 type Message struct {
 	// Sum of all possible messages.
@@ -266,6 +366,8 @@ type Message struct {
 	//	*Message_BlockResponse
 	//	*Message_StatusRequest
 	//	*Message_StatusResponse
+	//	*Message_HeadersRequest
+	//	*Message_HeadersResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -273,7 +375,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_909d653dec9b4244, []int{5}
+	return fileDescriptor_909d653dec9b4244, []int{7}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -323,12 +425,20 @@ type Message_StatusRequest struct {
 type Message_StatusResponse struct {
 	StatusResponse *StatusResponse `protobuf:"bytes,5,opt,name=status_response,json=statusResponse,proto3,oneof" json:"status_response,omitempty"`
 }
+type Message_HeadersRequest struct {
+	HeadersRequest *HeadersRequest `protobuf:"bytes,6,opt,name=headers_request,json=headersRequest,proto3,oneof" json:"headers_request,omitempty"`
+}
+type Message_HeadersResponse struct {
+	HeadersResponse *HeadersResponse `protobuf:"bytes,7,opt,name=headers_response,json=headersResponse,proto3,oneof" json:"headers_response,omitempty"`
+}
 
 func (*Message_BlockRequest) isMessage_Sum()    {}
 func (*Message_NoBlockResponse) isMessage_Sum() {}
 func (*Message_BlockResponse) isMessage_Sum()   {}
 func (*Message_StatusRequest) isMessage_Sum()   {}
 func (*Message_StatusResponse) isMessage_Sum()  {}
+func (*Message_HeadersRequest) isMessage_Sum()  {}
+func (*Message_HeadersResponse) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -372,6 +482,20 @@ func (m *Message) GetStatusResponse() *StatusResponse {
 	return nil
 }
 
+func (m *Message) GetHeadersRequest() *HeadersRequest {
+	if x, ok := m.GetSum().(*Message_HeadersRequest); ok {
+		return x.HeadersRequest
+	}
+	return nil
+}
+
+func (m *Message) GetHeadersResponse() *HeadersResponse {
+	if x, ok := m.GetSum().(*Message_HeadersResponse); ok {
+		return x.HeadersResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -380,6 +504,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_BlockResponse)(nil),
 		(*Message_StatusRequest)(nil),
 		(*Message_StatusResponse)(nil),
+		(*Message_HeadersRequest)(nil),
+		(*Message_HeadersResponse)(nil),
 	}
 }
 
@@ -389,39 +515,46 @@ func init() {
 	proto.RegisterType((*StatusRequest)(nil), "cometbft.blocksync.v2.StatusRequest")
 	proto.RegisterType((*StatusResponse)(nil), "cometbft.blocksync.v2.StatusResponse")
 	proto.RegisterType((*BlockResponse)(nil), "cometbft.blocksync.v2.BlockResponse")
+	proto.RegisterType((*HeadersRequest)(nil), "cometbft.blocksync.v2.HeadersRequest")
+	proto.RegisterType((*HeadersResponse)(nil), "cometbft.blocksync.v2.HeadersResponse")
 	proto.RegisterType((*Message)(nil), "cometbft.blocksync.v2.Message")
 }
 
 func init() { proto.RegisterFile("cometbft/blocksync/v2/types.proto", fileDescriptor_909d653dec9b4244) }
 
 var fileDescriptor_909d653dec9b4244 = []byte{
-	// 415 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcf, 0x6a, 0xdb, 0x40,
-	0x10, 0xc6, 0xa5, 0xca, 0x76, 0xe9, 0xd8, 0xb2, 0xa8, 0xa0, 0xc5, 0x14, 0x2a, 0x6a, 0xb5, 0x35,
-	0xed, 0x65, 0x05, 0x2a, 0xf4, 0xd4, 0x43, 0x71, 0x08, 0x98, 0x80, 0x83, 0x51, 0x72, 0xca, 0xc5,
-	0x48, 0xf2, 0xc6, 0x16, 0x89, 0xb4, 0x8a, 0x77, 0x65, 0xec, 0x63, 0xde, 0x20, 0xcf, 0x90, 0xa7,
-	0xc9, 0xd1, 0xc7, 0x1c, 0x83, 0xfd, 0x22, 0x41, 0x2b, 0x79, 0x91, 0x85, 0xec, 0xdc, 0x46, 0xb3,
-	0xdf, 0xf7, 0xd3, 0xfc, 0x61, 0xa0, 0xeb, 0x93, 0x10, 0x33, 0xef, 0x9a, 0x59, 0xde, 0x2d, 0xf1,
-	0x6f, 0xe8, 0x2a, 0xf2, 0xad, 0x85, 0x6d, 0xb1, 0x55, 0x8c, 0x29, 0x8a, 0xe7, 0x84, 0x11, 0xfd,
-	0xd3, 0x4e, 0x82, 0x84, 0x04, 0x2d, 0xec, 0x2f, 0x5f, 0x85, 0x93, 0x8b, 0x53, 0x17, 0x7f, 0xcf,
-	0x5c, 0x55, 0xcf, 0x05, 0xa8, 0xd9, 0x83, 0x56, 0x3f, 0x55, 0x3b, 0xf8, 0x2e, 0xc1, 0x94, 0xe9,
-	0x9f, 0xa1, 0x31, 0xc3, 0xc1, 0x74, 0xc6, 0x3a, 0xf2, 0x37, 0xf9, 0x97, 0xe2, 0xe4, 0x5f, 0xe6,
-	0x6f, 0xd0, 0xce, 0x49, 0xae, 0xa4, 0x31, 0x89, 0x28, 0x3e, 0x28, 0xd5, 0x40, 0xbd, 0x60, 0x2e,
-	0x4b, 0x68, 0xce, 0x34, 0xff, 0x41, 0x7b, 0x97, 0x38, 0x6e, 0xd5, 0x75, 0xa8, 0x79, 0x2e, 0xc5,
-	0x9d, 0x77, 0x3c, 0xcb, 0x63, 0xf3, 0x5e, 0x06, 0x75, 0xff, 0xc7, 0x08, 0xea, 0xbc, 0x43, 0x6e,
-	0x6e, 0xda, 0x1d, 0x24, 0x06, 0x93, 0x75, 0xb6, 0xb0, 0x51, 0x66, 0xc8, 0x64, 0xfa, 0x7f, 0x00,
-	0xbc, 0x64, 0x63, 0x9f, 0x84, 0x61, 0xc0, 0x38, 0xbb, 0x69, 0x77, 0x2b, 0x4c, 0xa7, 0x4b, 0x86,
-	0xa3, 0x09, 0x9e, 0x9c, 0x70, 0xa1, 0xf3, 0x01, 0x2f, 0x59, 0x16, 0x9a, 0x8f, 0x0a, 0xbc, 0x1f,
-	0x62, 0x4a, 0xdd, 0x29, 0xd6, 0xcf, 0x40, 0xe5, 0xd8, 0xf1, 0x3c, 0x6b, 0x2f, 0xaf, 0xe2, 0x3b,
-	0xaa, 0x5c, 0x0f, 0x2a, 0x4e, 0x77, 0x20, 0x39, 0x2d, 0xaf, 0x38, 0xed, 0x4b, 0xf8, 0x18, 0x91,
-	0xf1, 0x0e, 0x97, 0xb5, 0x97, 0x17, 0xd8, 0x3b, 0xc0, 0x2b, 0x6d, 0x61, 0x20, 0x39, 0x5a, 0x54,
-	0x5a, 0xcc, 0x10, 0xda, 0x25, 0xa4, 0xc2, 0x91, 0x3f, 0x8e, 0x97, 0x28, 0x80, 0xaa, 0x57, 0xc6,
-	0x51, 0xbe, 0x3e, 0xd1, 0x71, 0xed, 0x28, 0x6e, 0x6f, 0xf9, 0x29, 0x8e, 0x16, 0x13, 0xfa, 0x08,
-	0x34, 0x81, 0xcb, 0xcb, 0xab, 0x73, 0xde, 0xcf, 0x37, 0x78, 0xa2, 0xbe, 0x36, 0xdd, 0xcb, 0xf4,
-	0xeb, 0xa0, 0xd0, 0x24, 0xec, 0x8f, 0x9e, 0x36, 0x86, 0xbc, 0xde, 0x18, 0xf2, 0xcb, 0xc6, 0x90,
-	0x1f, 0xb6, 0x86, 0xb4, 0xde, 0x1a, 0xd2, 0xf3, 0xd6, 0x90, 0xae, 0xfe, 0x4e, 0x03, 0x36, 0x4b,
-	0xbc, 0x94, 0x6f, 0x89, 0x73, 0x10, 0x81, 0x1b, 0x07, 0x56, 0xe5, 0xf5, 0x79, 0x0d, 0x7e, 0x23,
-	0x7f, 0x5e, 0x03, 0x00, 0x00, 0xff, 0xff, 0xb6, 0xec, 0xde, 0x0f, 0x9d, 0x03, 0x00, 0x00,
+	// 494 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0x4f, 0x8b, 0xd3, 0x40,
+	0x18, 0xc6, 0x13, 0xfb, 0x0f, 0xdf, 0x6e, 0x1b, 0x1d, 0x54, 0xaa, 0x60, 0xd8, 0x8d, 0xba, 0xac,
+	0x97, 0x09, 0x64, 0xc1, 0x93, 0x88, 0x54, 0x94, 0x22, 0xac, 0x94, 0xac, 0x27, 0x2f, 0x25, 0x49,
+	0xc7, 0xa6, 0x68, 0x33, 0xb5, 0x33, 0x29, 0xdd, 0xa3, 0xdf, 0xc0, 0x8f, 0xa5, 0xb7, 0x3d, 0x7a,
+	0x94, 0xf6, 0x8b, 0x48, 0x66, 0xa6, 0xb3, 0x49, 0xc8, 0xa6, 0xb7, 0xe4, 0xcd, 0xf3, 0xfc, 0xf2,
+	0xbe, 0xf3, 0x3e, 0x0c, 0x9c, 0x44, 0x74, 0x41, 0x78, 0xf8, 0x95, 0xbb, 0xe1, 0x77, 0x1a, 0x7d,
+	0x63, 0x57, 0x49, 0xe4, 0xae, 0x3d, 0x97, 0x5f, 0x2d, 0x09, 0xc3, 0xcb, 0x15, 0xe5, 0x14, 0x3d,
+	0xdc, 0x4b, 0xb0, 0x96, 0xe0, 0xb5, 0xf7, 0xe4, 0xa9, 0x76, 0x0a, 0x71, 0xe6, 0x12, 0xdf, 0xa5,
+	0xab, 0xea, 0x73, 0x0e, 0xea, 0x9c, 0xc2, 0xd1, 0x30, 0x53, 0xfb, 0xe4, 0x47, 0x4a, 0x18, 0x47,
+	0x8f, 0xa0, 0x1d, 0x93, 0xf9, 0x2c, 0xe6, 0x03, 0xf3, 0xd8, 0x3c, 0x6b, 0xf8, 0xea, 0xcd, 0x79,
+	0x09, 0xd6, 0x27, 0xaa, 0x94, 0x6c, 0x49, 0x13, 0x46, 0x6e, 0x95, 0x5a, 0xd0, 0xbb, 0xe4, 0x01,
+	0x4f, 0x99, 0x62, 0x3a, 0xaf, 0xa1, 0xbf, 0x2f, 0xd4, 0x5b, 0x11, 0x82, 0x66, 0x18, 0x30, 0x32,
+	0xb8, 0x23, 0xaa, 0xe2, 0xd9, 0xf9, 0x69, 0x42, 0xaf, 0xf8, 0x63, 0x0c, 0x2d, 0x31, 0xa1, 0x30,
+	0x77, 0xbd, 0x01, 0xd6, 0x07, 0x23, 0x27, 0x5b, 0x7b, 0x58, 0x1a, 0xa4, 0x0c, 0xbd, 0x05, 0x20,
+	0x1b, 0x3e, 0x89, 0xe8, 0x62, 0x31, 0xe7, 0x82, 0xdd, 0xf5, 0x4e, 0x2a, 0x4c, 0xef, 0x37, 0x9c,
+	0x24, 0x53, 0x32, 0x7d, 0x27, 0x84, 0xfe, 0x5d, 0xb2, 0xe1, 0xf2, 0xd1, 0x79, 0x03, 0xfd, 0x11,
+	0x09, 0xa6, 0x64, 0xc5, 0x0e, 0x9c, 0x13, 0x7a, 0x00, 0xad, 0x88, 0xa6, 0x09, 0x57, 0x23, 0xc8,
+	0x17, 0xe7, 0x03, 0x58, 0xda, 0xaf, 0x86, 0x38, 0x87, 0x4e, 0x2c, 0x4b, 0x03, 0xf3, 0xb8, 0x71,
+	0xd6, 0xf5, 0x1e, 0x57, 0x74, 0x24, 0x4d, 0xfe, 0x5e, 0xe9, 0xfc, 0x69, 0x42, 0xe7, 0x82, 0x30,
+	0x16, 0xcc, 0x08, 0xfa, 0x08, 0x3d, 0x31, 0xde, 0x64, 0x25, 0x5b, 0x52, 0xa7, 0xf1, 0x0c, 0x57,
+	0xc6, 0x04, 0xe7, 0xb7, 0x3c, 0x32, 0xfc, 0xa3, 0x30, 0xbf, 0xf5, 0xcf, 0x70, 0x3f, 0xa1, 0x93,
+	0x3d, 0x4e, 0x76, 0xa8, 0x0e, 0xea, 0xf4, 0x16, 0x5e, 0x29, 0x0d, 0x23, 0xc3, 0xb7, 0x92, 0x52,
+	0x40, 0x2e, 0xa0, 0x5f, 0x42, 0x36, 0x04, 0xf2, 0x79, 0x7d, 0x8b, 0x1a, 0xd8, 0x0b, 0xcb, 0x38,
+	0x26, 0x62, 0xa4, 0x27, 0x6e, 0xd6, 0xe2, 0x0a, 0x21, 0xcc, 0x70, 0x2c, 0x5f, 0x40, 0x63, 0xb0,
+	0x34, 0x4e, 0xb5, 0xd7, 0x12, 0xbc, 0x17, 0x07, 0x78, 0xba, 0xbf, 0x3e, 0x2b, 0xa6, 0x7a, 0x0c,
+	0x96, 0x5a, 0x94, 0xee, 0xb0, 0x5d, 0x4b, 0x2c, 0x66, 0x2a, 0x23, 0xc6, 0xc5, 0x94, 0x5d, 0xc2,
+	0xbd, 0x1b, 0xa2, 0x6a, 0xb2, 0x53, 0xbb, 0x96, 0x52, 0xcc, 0xb2, 0xb5, 0xc4, 0xc5, 0xd2, 0xb0,
+	0x05, 0x0d, 0x96, 0x2e, 0x86, 0xe3, 0xdf, 0x5b, 0xdb, 0xbc, 0xde, 0xda, 0xe6, 0xbf, 0xad, 0x6d,
+	0xfe, 0xda, 0xd9, 0xc6, 0xf5, 0xce, 0x36, 0xfe, 0xee, 0x6c, 0xe3, 0xcb, 0xab, 0xd9, 0x9c, 0xc7,
+	0x69, 0x98, 0xfd, 0xc1, 0xd5, 0xb7, 0x87, 0x7e, 0x08, 0x96, 0x73, 0xb7, 0xf2, 0xb2, 0x0a, 0xdb,
+	0xe2, 0x4a, 0x39, 0xff, 0x3f, 0x00, 0x94, 0xbd, 0x91, 0x95, 0xcc, 0x04, 0x00, 0x00,
 }
 
 func (m *BlockRequest) Marshal() (dAtA []byte, err error) {
@@ -583,6 +716,76 @@ func (m *BlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *HeadersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeadersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HeadersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HeadersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeadersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HeadersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Message) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -720,6 +923,48 @@ func (m *Message_StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_HeadersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_HeadersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.HeadersRequest != nil {
+		{
+			size, err := m.HeadersRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_HeadersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_HeadersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.HeadersResponse != nil {
+		{
+			size, err := m.HeadersResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -796,6 +1041,36 @@ func (m *BlockResponse) Size() (n int) {
 	return n
 }
 
+func (m *HeadersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Count != 0 {
+		n += 1 + sovTypes(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *HeadersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
@@ -868,6 +1143,30 @@ func (m *Message_StatusResponse) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_HeadersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HeadersRequest != nil {
+		l = m.HeadersRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_HeadersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HeadersResponse != nil {
+		l = m.HeadersResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -1273,6 +1572,178 @@ func (m *BlockResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *HeadersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeadersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeadersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeadersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeadersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeadersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &v2.Header{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1477,6 +1948,76 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_StatusResponse{v}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadersRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HeadersRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_HeadersRequest{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadersResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HeadersResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_HeadersResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/privval/v2/service.proto
+
+package v2
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() { proto.RegisterFile("cometbft/privval/v2/service.proto", fileDescriptor_5bfc107d4131c50c) }
+
+var fileDescriptor_5bfc107d4131c50c = []byte{
+	// 273 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4c, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x28, 0xca, 0x2c, 0x2b, 0x4b, 0xcc, 0xd1, 0x2f, 0x33, 0xd2,
+	0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x86,
+	0x29, 0xd1, 0x83, 0x2a, 0xd1, 0x2b, 0x33, 0x92, 0x92, 0xc7, 0xa6, 0xaf, 0xa4, 0xb2, 0x20, 0xb5,
+	0x18, 0xa2, 0xcb, 0xa8, 0x8d, 0x99, 0x4b, 0x20, 0xa0, 0x28, 0xb3, 0x2c, 0x2c, 0x31, 0x27, 0x33,
+	0x25, 0xb1, 0x24, 0xbf, 0xc8, 0x31, 0xc0, 0x53, 0x28, 0x84, 0x8b, 0xd3, 0x3d, 0xb5, 0x24, 0xa0,
+	0x34, 0xc9, 0x3b, 0xb5, 0x52, 0x48, 0x49, 0x0f, 0x8b, 0xc1, 0x7a, 0x10, 0xc9, 0xa0, 0xd4, 0xc2,
+	0xd2, 0xd4, 0xe2, 0x12, 0x29, 0x65, 0xbc, 0x6a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0x22,
+	0xb9, 0x38, 0x82, 0x33, 0xd3, 0xf3, 0xc2, 0xf2, 0x4b, 0x52, 0x85, 0x54, 0xb0, 0x6a, 0x80, 0x49,
+	0xc3, 0x8c, 0x55, 0xc7, 0xa9, 0x2a, 0x35, 0x05, 0xa2, 0x0e, 0x6a, 0x74, 0x2a, 0x17, 0x0f, 0x48,
+	0x34, 0xa0, 0x28, 0xbf, 0x20, 0xbf, 0x38, 0x31, 0x47, 0x48, 0x03, 0xa7, 0x46, 0x98, 0x12, 0x98,
+	0x15, 0xda, 0x78, 0xac, 0x40, 0xa8, 0x85, 0x5a, 0x13, 0xc5, 0xc5, 0x09, 0x92, 0x71, 0xaa, 0x2c,
+	0x49, 0x2d, 0x16, 0x52, 0xc5, 0xa9, 0x13, 0x2c, 0x0f, 0xb3, 0x40, 0x8d, 0x90, 0x32, 0x88, 0xd9,
+	0x4e, 0x7e, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84,
+	0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0x65, 0x92, 0x9e, 0x59,
+	0x92, 0x51, 0x9a, 0x04, 0x32, 0x47, 0x1f, 0x1e, 0x9d, 0x70, 0x46, 0x62, 0x41, 0xa6, 0x3e, 0x96,
+	0x48, 0x4e, 0x62, 0x03, 0xc7, 0xaf, 0x31, 0x60, 0x00, 0x9c, 0x3c, 0x32, 0xab, 0x3a, 0x02, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PrivValidatorAPIClient is the client API for PrivValidatorAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PrivValidatorAPIClient interface {
+	// GetPubKey returns the public key of the validator.
+	GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error)
+	// SignVote signs a vote.
+	SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error)
+	// SignProposal signs a proposal.
+	SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error)
+	// SignBytes signs arbitrary bytes.
+	SignBytes(ctx context.Context, in *SignBytesRequest, opts ...grpc.CallOption) (*SignBytesResponse, error)
+}
+
+type privValidatorAPIClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewPrivValidatorAPIClient(cc grpc1.ClientConn) PrivValidatorAPIClient {
+	return &privValidatorAPIClient{cc}
+}
+
+func (c *privValidatorAPIClient) GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error) {
+	out := new(PubKeyResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/GetPubKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error) {
+	out := new(SignedVoteResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/SignVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error) {
+	out := new(SignedProposalResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/SignProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignBytes(ctx context.Context, in *SignBytesRequest, opts ...grpc.CallOption) (*SignBytesResponse, error) {
+	out := new(SignBytesResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/SignBytes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivValidatorAPIServer is the server API for PrivValidatorAPI service.
+type PrivValidatorAPIServer interface {
+	// GetPubKey returns the public key of the validator.
+	GetPubKey(context.Context, *PubKeyRequest) (*PubKeyResponse, error)
+	// SignVote signs a vote.
+	SignVote(context.Context, *SignVoteRequest) (*SignedVoteResponse, error)
+	// SignProposal signs a proposal.
+	SignProposal(context.Context, *SignProposalRequest) (*SignedProposalResponse, error)
+	// SignBytes signs arbitrary bytes.
+	SignBytes(context.Context, *SignBytesRequest) (*SignBytesResponse, error)
+}
+
+// UnimplementedPrivValidatorAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedPrivValidatorAPIServer struct {
+}
+
+func (*UnimplementedPrivValidatorAPIServer) GetPubKey(ctx context.Context, req *PubKeyRequest) (*PubKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPubKey not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignVote(ctx context.Context, req *SignVoteRequest) (*SignedVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignVote not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignProposal(ctx context.Context, req *SignProposalRequest) (*SignedProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignBytes(ctx context.Context, req *SignBytesRequest) (*SignBytesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignBytes not implemented")
+}
+
+func RegisterPrivValidatorAPIServer(s grpc1.Server, srv PrivValidatorAPIServer) {
+	s.RegisterService(&_PrivValidatorAPI_serviceDesc, srv)
+}
+
+func _PrivValidatorAPI_GetPubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PubKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).GetPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/GetPubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).GetPubKey(ctx, req.(*PubKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/SignVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignVote(ctx, req.(*SignVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/SignProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignProposal(ctx, req.(*SignProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignBytes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignBytesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignBytes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/SignBytes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignBytes(ctx, req.(*SignBytesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var PrivValidatorAPI_serviceDesc = _PrivValidatorAPI_serviceDesc
+var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.privval.v2.PrivValidatorAPI",
+	HandlerType: (*PrivValidatorAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPubKey",
+			Handler:    _PrivValidatorAPI_GetPubKey_Handler,
+		},
+		{
+			MethodName: "SignVote",
+			Handler:    _PrivValidatorAPI_SignVote_Handler,
+		},
+		{
+			MethodName: "SignProposal",
+			Handler:    _PrivValidatorAPI_SignProposal_Handler,
+		},
+		{
+			MethodName: "SignBytes",
+			Handler:    _PrivValidatorAPI_SignBytes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cometbft/privval/v2/service.proto",
+}
@@ -5,6 +5,7 @@ package v2
 
 import (
 	fmt "fmt"
+	v21 "github.com/cometbft/cometbft/api/cometbft/abci/v2"
 	v2 "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	proto "github.com/cosmos/gogoproto/proto"
 	io "io"
@@ -122,6 +123,110 @@ func (m *GetByHeightResponse) GetBlock() *v2.Block {
 	return nil
 }
 
+// GetCommitRequest is a request for the commit for the block at the specified
+// height.
+type GetCommitRequest struct {
+	// The height of the block whose commit is requested. If 0, the commit for
+	// the latest block is returned.
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetCommitRequest) Reset()         { *m = GetCommitRequest{} }
+func (m *GetCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCommitRequest) ProtoMessage()    {}
+func (*GetCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4818f43c6b99905f, []int{2}
+}
+func (m *GetCommitRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetCommitRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCommitRequest.Merge(m, src)
+}
+func (m *GetCommitRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCommitRequest proto.InternalMessageInfo
+
+func (m *GetCommitRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// GetCommitResponse contains the header of the block at the requested height,
+// along with the commit for it.
+type GetCommitResponse struct {
+	SignedHeader *v2.SignedHeader `protobuf:"bytes,1,opt,name=signed_header,json=signedHeader,proto3" json:"signed_header,omitempty"`
+	// canonical is false if the commit is the one seen by the node for the
+	// latest block, which may differ from the commit included in the next block.
+	Canonical bool `protobuf:"varint,2,opt,name=canonical,proto3" json:"canonical,omitempty"`
+}
+
+func (m *GetCommitResponse) Reset()         { *m = GetCommitResponse{} }
+func (m *GetCommitResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCommitResponse) ProtoMessage()    {}
+func (*GetCommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4818f43c6b99905f, []int{3}
+}
+func (m *GetCommitResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetCommitResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetCommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCommitResponse.Merge(m, src)
+}
+func (m *GetCommitResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetCommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCommitResponse proto.InternalMessageInfo
+
+func (m *GetCommitResponse) GetSignedHeader() *v2.SignedHeader {
+	if m != nil {
+		return m.SignedHeader
+	}
+	return nil
+}
+
+func (m *GetCommitResponse) GetCanonical() bool {
+	if m != nil {
+		return m.Canonical
+	}
+	return false
+}
+
 // GetLatestHeightRequest - empty message since no parameter is required
 type GetLatestHeightRequest struct {
 }
@@ -130,7 +235,7 @@ func (m *GetLatestHeightRequest) Reset()         { *m = GetLatestHeightRequest{}
 func (m *GetLatestHeightRequest) String() string { return proto.CompactTextString(m) }
 func (*GetLatestHeightRequest) ProtoMessage()    {}
 func (*GetLatestHeightRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4818f43c6b99905f, []int{2}
+	return fileDescriptor_4818f43c6b99905f, []int{4}
 }
 func (m *GetLatestHeightRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -170,7 +275,7 @@ func (m *GetLatestHeightResponse) Reset()         { *m = GetLatestHeightResponse
 func (m *GetLatestHeightResponse) String() string { return proto.CompactTextString(m) }
 func (*GetLatestHeightResponse) ProtoMessage()    {}
 func (*GetLatestHeightResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4818f43c6b99905f, []int{3}
+	return fileDescriptor_4818f43c6b99905f, []int{5}
 }
 func (m *GetLatestHeightResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -206,11 +311,192 @@ func (m *GetLatestHeightResponse) GetHeight() int64 {
 	return 0
 }
 
+// GetBlocksWithResultsRequest is a request for the blocks, along with their
+// results, from the specified height.
+type GetBlocksWithResultsRequest struct {
+	// The height of the first block requested. If 0, the blocks are streamed
+	// from the latest block.
+	FromHeight int64 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+}
+
+func (m *GetBlocksWithResultsRequest) Reset()         { *m = GetBlocksWithResultsRequest{} }
+func (m *GetBlocksWithResultsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksWithResultsRequest) ProtoMessage()    {}
+func (*GetBlocksWithResultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4818f43c6b99905f, []int{6}
+}
+func (m *GetBlocksWithResultsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetBlocksWithResultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetBlocksWithResultsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetBlocksWithResultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksWithResultsRequest.Merge(m, src)
+}
+func (m *GetBlocksWithResultsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetBlocksWithResultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksWithResultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlocksWithResultsRequest proto.InternalMessageInfo
+
+func (m *GetBlocksWithResultsRequest) GetFromHeight() int64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+// GetBlocksWithResultsResponse contains a block, its results and the events
+// indexed by the node.
+type GetBlocksWithResultsResponse struct {
+	BlockId               *v2.BlockID                `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Block                 *v2.Block                  `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	FinalizeBlockResponse *v21.FinalizeBlockResponse `protobuf:"bytes,3,opt,name=finalize_block_response,json=finalizeBlockResponse,proto3" json:"finalize_block_response,omitempty"`
+	// The events of the block indexed by the node, with their indexed
+	// attributes only.
+	BlockEvents []*v21.Event `protobuf:"bytes,4,rep,name=block_events,json=blockEvents,proto3" json:"block_events,omitempty"`
+	// The events of each transaction of the block indexed by the node, in the
+	// order of the transactions.
+	TxEvents []*TxEvents `protobuf:"bytes,5,rep,name=tx_events,json=txEvents,proto3" json:"tx_events,omitempty"`
+}
+
+func (m *GetBlocksWithResultsResponse) Reset()         { *m = GetBlocksWithResultsResponse{} }
+func (m *GetBlocksWithResultsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksWithResultsResponse) ProtoMessage()    {}
+func (*GetBlocksWithResultsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4818f43c6b99905f, []int{7}
+}
+func (m *GetBlocksWithResultsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetBlocksWithResultsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetBlocksWithResultsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetBlocksWithResultsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksWithResultsResponse.Merge(m, src)
+}
+func (m *GetBlocksWithResultsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetBlocksWithResultsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksWithResultsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlocksWithResultsResponse proto.InternalMessageInfo
+
+func (m *GetBlocksWithResultsResponse) GetBlockId() *v2.BlockID {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *GetBlocksWithResultsResponse) GetBlock() *v2.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *GetBlocksWithResultsResponse) GetFinalizeBlockResponse() *v21.FinalizeBlockResponse {
+	if m != nil {
+		return m.FinalizeBlockResponse
+	}
+	return nil
+}
+
+func (m *GetBlocksWithResultsResponse) GetBlockEvents() []*v21.Event {
+	if m != nil {
+		return m.BlockEvents
+	}
+	return nil
+}
+
+func (m *GetBlocksWithResultsResponse) GetTxEvents() []*TxEvents {
+	if m != nil {
+		return m.TxEvents
+	}
+	return nil
+}
+
+// TxEvents contains the events of a transaction indexed by the node, with
+// their indexed attributes only.
+type TxEvents struct {
+	Events []*v21.Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (m *TxEvents) Reset()         { *m = TxEvents{} }
+func (m *TxEvents) String() string { return proto.CompactTextString(m) }
+func (*TxEvents) ProtoMessage()    {}
+func (*TxEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4818f43c6b99905f, []int{8}
+}
+func (m *TxEvents) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxEvents.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxEvents.Merge(m, src)
+}
+func (m *TxEvents) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxEvents proto.InternalMessageInfo
+
+func (m *TxEvents) GetEvents() []*v21.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*GetByHeightRequest)(nil), "cometbft.services.block.v2.GetByHeightRequest")
 	proto.RegisterType((*GetByHeightResponse)(nil), "cometbft.services.block.v2.GetByHeightResponse")
+	proto.RegisterType((*GetCommitRequest)(nil), "cometbft.services.block.v2.GetCommitRequest")
+	proto.RegisterType((*GetCommitResponse)(nil), "cometbft.services.block.v2.GetCommitResponse")
 	proto.RegisterType((*GetLatestHeightRequest)(nil), "cometbft.services.block.v2.GetLatestHeightRequest")
 	proto.RegisterType((*GetLatestHeightResponse)(nil), "cometbft.services.block.v2.GetLatestHeightResponse")
+	proto.RegisterType((*GetBlocksWithResultsRequest)(nil), "cometbft.services.block.v2.GetBlocksWithResultsRequest")
+	proto.RegisterType((*GetBlocksWithResultsResponse)(nil), "cometbft.services.block.v2.GetBlocksWithResultsResponse")
+	proto.RegisterType((*TxEvents)(nil), "cometbft.services.block.v2.TxEvents")
 }
 
 func init() {
@@ -218,24 +504,38 @@ func init() {
 }
 
 var fileDescriptor_4818f43c6b99905f = []byte{
-	// 271 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4b, 0xce, 0xcf, 0x4d,
-	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x4f, 0xca,
-	0xc9, 0x4f, 0xce, 0xd6, 0x2f, 0x33, 0x82, 0x30, 0xf4, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0xa4,
-	0x60, 0xea, 0xf4, 0x60, 0xea, 0xf4, 0x20, 0xd2, 0x65, 0x46, 0x52, 0xb2, 0x70, 0x33, 0x4a, 0x2a,
-	0x0b, 0x52, 0x8b, 0x41, 0x5a, 0xc1, 0x0c, 0x88, 0x56, 0x6c, 0xd2, 0x48, 0x26, 0x2b, 0xe9, 0x70,
-	0x09, 0xb9, 0xa7, 0x96, 0x38, 0x55, 0x7a, 0xa4, 0x66, 0xa6, 0x67, 0x94, 0x04, 0xa5, 0x16, 0x96,
-	0xa6, 0x16, 0x97, 0x08, 0x89, 0x71, 0xb1, 0x65, 0x80, 0x05, 0x24, 0x18, 0x15, 0x18, 0x35, 0x98,
-	0x83, 0xa0, 0x3c, 0xa5, 0x1a, 0x2e, 0x61, 0x14, 0xd5, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x42,
-	0xa6, 0x5c, 0x1c, 0x60, 0x33, 0xe3, 0x33, 0x53, 0xc0, 0x1a, 0xb8, 0x8d, 0xa4, 0xf4, 0xe0, 0x2e,
-	0x86, 0x38, 0xa6, 0xcc, 0x48, 0xcf, 0x09, 0xa4, 0xc4, 0xd3, 0x25, 0x88, 0x1d, 0xac, 0xd6, 0x33,
-	0x45, 0x48, 0x8f, 0x8b, 0x15, 0xcc, 0x94, 0x60, 0x02, 0xeb, 0x91, 0xc0, 0xa5, 0x27, 0x08, 0xa2,
-	0x4c, 0x49, 0x82, 0x4b, 0xcc, 0x3d, 0xb5, 0xc4, 0x27, 0xb1, 0x24, 0xb5, 0xb8, 0x04, 0xc5, 0xbd,
-	0x4a, 0x86, 0x5c, 0xe2, 0x18, 0x32, 0x50, 0xb7, 0xe1, 0xf0, 0x8a, 0x53, 0xe8, 0x89, 0x47, 0x72,
-	0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0xc3, 0x85, 0xc7,
-	0x72, 0x0c, 0x37, 0x1e, 0xcb, 0x31, 0x44, 0x59, 0xa7, 0x67, 0x96, 0x64, 0x94, 0x26, 0x81, 0x5c,
-	0xa3, 0x0f, 0x0f, 0x3c, 0x38, 0x23, 0xb1, 0x20, 0x53, 0x1f, 0x77, 0xac, 0x25, 0xb1, 0x81, 0x83,
-	0xd5, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0x88, 0x3a, 0x44, 0x9f, 0xda, 0x01, 0x00, 0x00,
+	// 496 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x94, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x86, 0xeb, 0x86, 0x86, 0x74, 0x52, 0x24, 0x58, 0x44, 0x63, 0x85, 0xe0, 0x56, 0x16, 0x82,
+	0x0a, 0x21, 0x5b, 0x18, 0x71, 0xa1, 0x12, 0x12, 0xa1, 0x90, 0x56, 0xe2, 0xb4, 0x80, 0x90, 0xb8,
+	0x58, 0xb6, 0x33, 0x89, 0x57, 0x24, 0x76, 0xf0, 0x6e, 0x4c, 0x8b, 0x78, 0x08, 0x1e, 0x8b, 0x63,
+	0x8f, 0x1c, 0x51, 0x22, 0xde, 0x03, 0x79, 0x77, 0xed, 0x26, 0x6a, 0x02, 0x9c, 0xb8, 0x8d, 0x67,
+	0xbe, 0xff, 0xdf, 0x7f, 0x9c, 0xac, 0xe1, 0x5e, 0x94, 0x8e, 0x51, 0x84, 0x03, 0xe1, 0x72, 0xcc,
+	0x72, 0x16, 0x21, 0x77, 0xc3, 0x51, 0x1a, 0x7d, 0x74, 0x73, 0x4f, 0x15, 0xce, 0x24, 0x4b, 0x45,
+	0x4a, 0xda, 0x25, 0xe7, 0x94, 0x9c, 0xa3, 0xc6, 0xb9, 0xd7, 0xee, 0x54, 0x1e, 0x41, 0x18, 0xb1,
+	0x42, 0x29, 0xce, 0x26, 0xc8, 0x95, 0xb2, 0x7d, 0xa7, 0x9a, 0xca, 0xee, 0x3f, 0x8c, 0x17, 0xce,
+	0xb5, 0x1f, 0x02, 0xe9, 0xa1, 0xe8, 0x9e, 0x1d, 0x23, 0x1b, 0xc6, 0x82, 0xe2, 0xa7, 0x29, 0x72,
+	0x41, 0x76, 0xa1, 0x1e, 0xcb, 0x86, 0x69, 0xec, 0x1b, 0x07, 0x35, 0xaa, 0x9f, 0xec, 0xaf, 0x70,
+	0x73, 0x89, 0xe6, 0x93, 0x34, 0xe1, 0x48, 0x9e, 0x40, 0x43, 0x7a, 0xfa, 0xac, 0x2f, 0x05, 0x4d,
+	0xaf, 0xed, 0x54, 0xfb, 0xa8, 0x30, 0xb9, 0xe7, 0x74, 0x0b, 0xe4, 0xe4, 0x88, 0x5e, 0x95, 0xec,
+	0x49, 0x9f, 0x38, 0xb0, 0x25, 0x4b, 0x73, 0x53, 0x6a, 0xcc, 0x75, 0x1a, 0xaa, 0x30, 0xfb, 0x01,
+	0x5c, 0xef, 0xa1, 0x78, 0x91, 0x8e, 0xc7, 0xec, 0xaf, 0x49, 0x3f, 0xc3, 0x8d, 0x05, 0x56, 0xe7,
+	0x3c, 0x82, 0x6b, 0x9c, 0x0d, 0x13, 0xec, 0xfb, 0x31, 0x06, 0x7d, 0xcc, 0x74, 0xd8, 0xbd, 0x15,
+	0x07, 0xbf, 0x91, 0xdc, 0xb1, 0xc4, 0xe8, 0x0e, 0x5f, 0x78, 0x22, 0x1d, 0xd8, 0x8e, 0x82, 0x24,
+	0x4d, 0x58, 0x14, 0x8c, 0x64, 0xf4, 0x06, 0xbd, 0x68, 0xd8, 0x26, 0xec, 0xf6, 0x50, 0xbc, 0x0e,
+	0x04, 0x72, 0xb1, 0xf4, 0x52, 0xed, 0x47, 0xd0, 0xba, 0x34, 0xd1, 0xc1, 0xd6, 0x6d, 0xf1, 0x0c,
+	0x6e, 0x17, 0xef, 0xbb, 0xd8, 0x9e, 0xbf, 0x67, 0x22, 0xa6, 0xc8, 0xa7, 0x23, 0xc1, 0xcb, 0xe5,
+	0xf7, 0xa0, 0x39, 0xc8, 0xd2, 0xb1, 0xbf, 0xa4, 0x85, 0xa2, 0xa5, 0xfc, 0xed, 0x5f, 0x9b, 0xd0,
+	0x59, 0x6d, 0xf0, 0x5f, 0x7f, 0x39, 0xe2, 0x43, 0x6b, 0xc0, 0x92, 0x60, 0xc4, 0xbe, 0xa0, 0xaf,
+	0xce, 0xcb, 0x74, 0x02, 0xb3, 0x26, 0x1d, 0xee, 0x5f, 0x38, 0x14, 0xff, 0xf1, 0xc2, 0xe0, 0x95,
+	0x16, 0x28, 0x23, 0x8d, 0xd3, 0x5b, 0x83, 0x55, 0x6d, 0xf2, 0x14, 0x76, 0x94, 0x2f, 0xe6, 0x98,
+	0x08, 0x6e, 0x5e, 0xd9, 0xaf, 0x1d, 0x34, 0xbd, 0xd6, 0x65, 0xd7, 0x97, 0xc5, 0x9c, 0x36, 0x25,
+	0x2c, 0x6b, 0x4e, 0x9e, 0xc3, 0xb6, 0x38, 0x2d, 0x85, 0x5b, 0x52, 0x78, 0xd7, 0x59, 0x7f, 0x1d,
+	0x9d, 0xb7, 0xa7, 0x4a, 0x48, 0x1b, 0x42, 0x57, 0xf6, 0x21, 0x34, 0xca, 0x2e, 0x71, 0xa1, 0xae,
+	0xbd, 0x8c, 0x3f, 0x87, 0xd0, 0x58, 0xf7, 0xdd, 0x87, 0xc3, 0x21, 0x13, 0xf1, 0x34, 0x2c, 0x40,
+	0xb7, 0xba, 0xae, 0x55, 0x11, 0x4c, 0x98, 0xbb, 0xfe, 0x2b, 0xf2, 0x7d, 0x66, 0x19, 0xe7, 0x33,
+	0xcb, 0xf8, 0x39, 0xb3, 0x8c, 0x6f, 0x73, 0x6b, 0xe3, 0x7c, 0x6e, 0x6d, 0xfc, 0x98, 0x5b, 0x1b,
+	0x61, 0x5d, 0x5e, 0xf0, 0xc7, 0xbf, 0x07, 0x00, 0x45, 0x5a, 0x8f, 0x41, 0x82, 0x04, 0x00, 0x00,
 }
 
 func (m *GetByHeightRequest) Marshal() (dAtA []byte, err error) {
@@ -313,6 +613,79 @@ func (m *GetByHeightResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *GetCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetCommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintBlock(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetCommitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetCommitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetCommitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Canonical {
+		i--
+		if m.Canonical {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.SignedHeader != nil {
+		{
+			size, err := m.SignedHeader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *GetLatestHeightRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -364,32 +737,184 @@ func (m *GetLatestHeightResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintBlock(dAtA []byte, offset int, v uint64) int {
-	offset -= sovBlock(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *GetBlocksWithResultsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *GetByHeightRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *GetBlocksWithResultsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetBlocksWithResultsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Height != 0 {
-		n += 1 + sovBlock(uint64(m.Height))
+	if m.FromHeight != 0 {
+		i = encodeVarintBlock(dAtA, i, uint64(m.FromHeight))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetByHeightResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetBlocksWithResultsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetBlocksWithResultsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetBlocksWithResultsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxEvents) > 0 {
+		for iNdEx := len(m.TxEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TxEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBlock(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.BlockEvents) > 0 {
+		for iNdEx := len(m.BlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBlock(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.FinalizeBlockResponse != nil {
+		{
+			size, err := m.FinalizeBlockResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.BlockId != nil {
+		{
+			size, err := m.BlockId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TxEvents) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxEvents) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxEvents) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBlock(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintBlock(dAtA []byte, offset int, v uint64) int {
+	offset -= sovBlock(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetByHeightRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlock(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *GetByHeightResponse) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -404,34 +929,538 @@ func (m *GetByHeightResponse) Size() (n int) {
 	return n
 }
 
+func (m *GetCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlock(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *GetCommitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignedHeader != nil {
+		l = m.SignedHeader.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.Canonical {
+		n += 2
+	}
+	return n
+}
+
 func (m *GetLatestHeightRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
-	var l int
-	_ = l
-	return n
-}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetLatestHeightResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlock(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *GetBlocksWithResultsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		n += 1 + sovBlock(uint64(m.FromHeight))
+	}
+	return n
+}
+
+func (m *GetBlocksWithResultsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockId != nil {
+		l = m.BlockId.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.FinalizeBlockResponse != nil {
+		l = m.FinalizeBlockResponse.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if len(m.BlockEvents) > 0 {
+		for _, e := range m.BlockEvents {
+			l = e.Size()
+			n += 1 + l + sovBlock(uint64(l))
+		}
+	}
+	if len(m.TxEvents) > 0 {
+		for _, e := range m.TxEvents {
+			l = e.Size()
+			n += 1 + l + sovBlock(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TxEvents) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovBlock(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovBlock(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozBlock(x uint64) (n int) {
+	return sovBlock(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetByHeightRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetByHeightRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetByHeightRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetByHeightResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetByHeightResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetByHeightResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockId == nil {
+				m.BlockId = &v2.BlockID{}
+			}
+			if err := m.BlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &v2.Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetCommitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetCommitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetCommitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetCommitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetCommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SignedHeader == nil {
+				m.SignedHeader = &v2.SignedHeader{}
+			}
+			if err := m.SignedHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Canonical", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Canonical = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLatestHeightRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLatestHeightRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLatestHeightRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func (m *GetLatestHeightResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Height != 0 {
-		n += 1 + sovBlock(uint64(m.Height))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovBlock(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozBlock(x uint64) (n int) {
-	return sovBlock(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *GetByHeightRequest) Unmarshal(dAtA []byte) error {
+func (m *GetLatestHeightResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -454,10 +1483,10 @@ func (m *GetByHeightRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetByHeightRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetLatestHeightResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetByHeightRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetLatestHeightResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -500,7 +1529,7 @@ func (m *GetByHeightRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetByHeightResponse) Unmarshal(dAtA []byte) error {
+func (m *GetBlocksWithResultsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -523,10 +1552,79 @@ func (m *GetByHeightResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetByHeightResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetBlocksWithResultsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetByHeightResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetBlocksWithResultsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromHeight", wireType)
+			}
+			m.FromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetBlocksWithResultsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetBlocksWithResultsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetBlocksWithResultsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -601,56 +1699,110 @@ func (m *GetByHeightResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipBlock(dAtA[iNdEx:])
-			if err != nil {
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlockResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FinalizeBlockResponse == nil {
+				m.FinalizeBlockResponse = &v21.FinalizeBlockResponse{}
+			}
+			if err := m.FinalizeBlockResponse.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthBlock
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetLatestHeightRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowBlock
+			m.BlockEvents = append(m.BlockEvents, &v21.Event{})
+			if err := m.BlockEvents[len(m.BlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if iNdEx >= l {
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.TxEvents = append(m.TxEvents, &TxEvents{})
+			if err := m.TxEvents[len(m.TxEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetLatestHeightRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetLatestHeightRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlock(dAtA[iNdEx:])
@@ -672,7 +1824,7 @@ func (m *GetLatestHeightRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetLatestHeightResponse) Unmarshal(dAtA []byte) error {
+func (m *TxEvents) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -695,17 +1847,17 @@ func (m *GetLatestHeightResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetLatestHeightResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxEvents: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetLatestHeightResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxEvents: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			m.Height = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBlock
@@ -715,11 +1867,26 @@ func (m *GetLatestHeightResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &v21.Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlock(dAtA[iNdEx:])
@@ -30,21 +30,25 @@ func init() {
 }
 
 var fileDescriptor_25e6c37400d36016 = []byte{
-	// 219 bytes of a gzipped FileDescriptorProto
+	// 278 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x4b, 0xce, 0xcf, 0x4d,
 	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x4f, 0xca,
 	0xc9, 0x4f, 0xce, 0xd6, 0x2f, 0x33, 0x82, 0x30, 0xe2, 0xa1, 0xe2, 0x7a, 0x05, 0x45, 0xf9, 0x25,
 	0xf9, 0x42, 0x52, 0x30, 0xf5, 0x7a, 0x30, 0xf5, 0x7a, 0x60, 0x65, 0x7a, 0x65, 0x46, 0x52, 0x6a,
-	0x84, 0xcc, 0x82, 0x98, 0x61, 0xf4, 0x87, 0x91, 0x8b, 0xc7, 0x09, 0xc4, 0x0f, 0x86, 0x28, 0x13,
+	0x84, 0xcc, 0x82, 0x98, 0x61, 0xf4, 0x85, 0x99, 0x8b, 0xc7, 0x09, 0xc4, 0x0f, 0x86, 0x28, 0x13,
 	0xca, 0xe3, 0xe2, 0x76, 0x4f, 0x2d, 0x71, 0xaa, 0xf4, 0x48, 0xcd, 0x4c, 0xcf, 0x28, 0x11, 0xd2,
 	0xd3, 0xc3, 0x6d, 0x89, 0x1e, 0x92, 0xc2, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x29, 0x7d,
-	0xa2, 0xd5, 0x17, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x0a, 0xd5, 0x70, 0xf1, 0xbb, 0xa7, 0x96, 0xf8,
-	0x24, 0x96, 0xa4, 0x16, 0x97, 0x40, 0xed, 0x34, 0x22, 0x60, 0x06, 0xb2, 0x62, 0x98, 0xbd, 0xc6,
-	0x24, 0xe9, 0x81, 0xd8, 0x6d, 0xc0, 0xe8, 0x14, 0x7a, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72,
-	0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7,
-	0x72, 0x0c, 0x51, 0xd6, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x20, 0x63, 0xf5, 0xe1, 0x61, 0x09,
-	0x67, 0x24, 0x16, 0x64, 0xea, 0xe3, 0x0e, 0xe1, 0x24, 0x36, 0x70, 0xe0, 0x1a, 0x03, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x0b, 0xfb, 0x4c, 0xa6, 0xd2, 0x01, 0x00, 0x00,
+	0xa2, 0xd5, 0x17, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x0a, 0x65, 0x70, 0x71, 0xba, 0xa7, 0x96, 0x38,
+	0xe7, 0xe7, 0xe6, 0x66, 0x96, 0x08, 0xe9, 0x10, 0xd0, 0x0d, 0x51, 0x06, 0xb3, 0x4b, 0x97, 0x48,
+	0xd5, 0x50, 0x9b, 0x6a, 0xb8, 0xf8, 0xdd, 0x53, 0x4b, 0x7c, 0x12, 0x4b, 0x52, 0x8b, 0x4b, 0xa0,
+	0xbe, 0x33, 0x22, 0x60, 0x02, 0xb2, 0x62, 0x98, 0xad, 0xc6, 0x24, 0xe9, 0x81, 0xd8, 0x6d, 0xc0,
+	0x28, 0xd4, 0xcd, 0xc8, 0x25, 0x02, 0xf2, 0x3f, 0x48, 0x65, 0x71, 0x78, 0x66, 0x49, 0x46, 0x50,
+	0x6a, 0x71, 0x69, 0x4e, 0x49, 0xb1, 0x90, 0x39, 0xa1, 0x10, 0x43, 0xd7, 0x01, 0x73, 0x88, 0x05,
+	0xe9, 0x1a, 0x61, 0xae, 0x71, 0x0a, 0x3d, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07,
+	0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8, 0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86,
+	0x28, 0xeb, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0x90, 0xd9, 0xfa, 0xf0, 0x34, 0x04, 0x67, 0x24,
+	0x16, 0x64, 0xea, 0xe3, 0x4e, 0x59, 0x49, 0x6c, 0xe0, 0x44, 0x65, 0x0c, 0x18, 0x00, 0x6b, 0xee,
+	0xaa, 0xd0, 0xca, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -61,11 +65,19 @@ const _ = grpc.SupportPackageIsVersion4
 type BlockServiceClient interface {
 	// GetBlock retrieves the block information at a particular height.
 	GetByHeight(ctx context.Context, in *GetByHeightRequest, opts ...grpc.CallOption) (*GetByHeightResponse, error)
+	// GetCommit retrieves the signed header at a particular height.
+	GetCommit(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error)
 	// GetLatestHeight returns a stream of the latest block heights committed by
 	// the network. This is a long-lived stream that is only terminated by the
 	// server if an error occurs. The caller is expected to handle such
 	// disconnections and automatically reconnect.
 	GetLatestHeight(ctx context.Context, in *GetLatestHeightRequest, opts ...grpc.CallOption) (BlockService_GetLatestHeightClient, error)
+	// GetBlocksWithResults returns a stream of the blocks, along with their
+	// results, from a particular height up to the latest height, and then as
+	// blocks are committed, without gaps. A consumer slower than the network
+	// receives the blocks at its own pace, loaded from the stores, as long as
+	// they are not pruned.
+	GetBlocksWithResults(ctx context.Context, in *GetBlocksWithResultsRequest, opts ...grpc.CallOption) (BlockService_GetBlocksWithResultsClient, error)
 }
 
 type blockServiceClient struct {
@@ -85,6 +97,15 @@ func (c *blockServiceClient) GetByHeight(ctx context.Context, in *GetByHeightReq
 	return out, nil
 }
 
+func (c *blockServiceClient) GetCommit(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error) {
+	out := new(GetCommitResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.block.v2.BlockService/GetCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockServiceClient) GetLatestHeight(ctx context.Context, in *GetLatestHeightRequest, opts ...grpc.CallOption) (BlockService_GetLatestHeightClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_BlockService_serviceDesc.Streams[0], "/cometbft.services.block.v2.BlockService/GetLatestHeight", opts...)
 	if err != nil {
@@ -117,15 +138,55 @@ func (x *blockServiceGetLatestHeightClient) Recv() (*GetLatestHeightResponse, er
 	return m, nil
 }
 
+func (c *blockServiceClient) GetBlocksWithResults(ctx context.Context, in *GetBlocksWithResultsRequest, opts ...grpc.CallOption) (BlockService_GetBlocksWithResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockService_serviceDesc.Streams[1], "/cometbft.services.block.v2.BlockService/GetBlocksWithResults", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockServiceGetBlocksWithResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockService_GetBlocksWithResultsClient interface {
+	Recv() (*GetBlocksWithResultsResponse, error)
+	grpc.ClientStream
+}
+
+type blockServiceGetBlocksWithResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockServiceGetBlocksWithResultsClient) Recv() (*GetBlocksWithResultsResponse, error) {
+	m := new(GetBlocksWithResultsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BlockServiceServer is the server API for BlockService service.
 type BlockServiceServer interface {
 	// GetBlock retrieves the block information at a particular height.
 	GetByHeight(context.Context, *GetByHeightRequest) (*GetByHeightResponse, error)
+	// GetCommit retrieves the signed header at a particular height.
+	GetCommit(context.Context, *GetCommitRequest) (*GetCommitResponse, error)
 	// GetLatestHeight returns a stream of the latest block heights committed by
 	// the network. This is a long-lived stream that is only terminated by the
 	// server if an error occurs. The caller is expected to handle such
 	// disconnections and automatically reconnect.
 	GetLatestHeight(*GetLatestHeightRequest, BlockService_GetLatestHeightServer) error
+	// GetBlocksWithResults returns a stream of the blocks, along with their
+	// results, from a particular height up to the latest height, and then as
+	// blocks are committed, without gaps. A consumer slower than the network
+	// receives the blocks at its own pace, loaded from the stores, as long as
+	// they are not pruned.
+	GetBlocksWithResults(*GetBlocksWithResultsRequest, BlockService_GetBlocksWithResultsServer) error
 }
 
 // UnimplementedBlockServiceServer can be embedded to have forward compatible implementations.
@@ -135,9 +196,15 @@ type UnimplementedBlockServiceServer struct {
 func (*UnimplementedBlockServiceServer) GetByHeight(ctx context.Context, req *GetByHeightRequest) (*GetByHeightResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByHeight not implemented")
 }
+func (*UnimplementedBlockServiceServer) GetCommit(ctx context.Context, req *GetCommitRequest) (*GetCommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommit not implemented")
+}
 func (*UnimplementedBlockServiceServer) GetLatestHeight(req *GetLatestHeightRequest, srv BlockService_GetLatestHeightServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetLatestHeight not implemented")
 }
+func (*UnimplementedBlockServiceServer) GetBlocksWithResults(req *GetBlocksWithResultsRequest, srv BlockService_GetBlocksWithResultsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlocksWithResults not implemented")
+}
 
 func RegisterBlockServiceServer(s grpc1.Server, srv BlockServiceServer) {
 	s.RegisterService(&_BlockService_serviceDesc, srv)
@@ -161,6 +228,24 @@ func _BlockService_GetByHeight_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockService_GetCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockServiceServer).GetCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.block.v2.BlockService/GetCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockServiceServer).GetCommit(ctx, req.(*GetCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockService_GetLatestHeight_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetLatestHeightRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -182,6 +267,27 @@ func (x *blockServiceGetLatestHeightServer) Send(m *GetLatestHeightResponse) err
 	return x.ServerStream.SendMsg(m)
 }
 
+func _BlockService_GetBlocksWithResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBlocksWithResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockServiceServer).GetBlocksWithResults(m, &blockServiceGetBlocksWithResultsServer{stream})
+}
+
+type BlockService_GetBlocksWithResultsServer interface {
+	Send(*GetBlocksWithResultsResponse) error
+	grpc.ServerStream
+}
+
+type blockServiceGetBlocksWithResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockServiceGetBlocksWithResultsServer) Send(m *GetBlocksWithResultsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var BlockService_serviceDesc = _BlockService_serviceDesc
 var _BlockService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cometbft.services.block.v2.BlockService",
@@ -191,6 +297,10 @@ var _BlockService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetByHeight",
 			Handler:    _BlockService_GetByHeight_Handler,
 		},
+		{
+			MethodName: "GetCommit",
+			Handler:    _BlockService_GetCommit_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -198,6 +308,11 @@ var _BlockService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _BlockService_GetLatestHeight_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetBlocksWithResults",
+			Handler:       _BlockService_GetBlocksWithResults_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "cometbft/services/block/v2/block_service.proto",
 }
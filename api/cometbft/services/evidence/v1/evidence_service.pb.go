@@ -0,0 +1,131 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/evidence/v1/evidence_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/evidence/v1/evidence_service.proto", fileDescriptor_aaba75961d656d22)
+}
+
+var fileDescriptor_aaba75961d656d22 = []byte{
+	// 189 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x49, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x4f, 0x2d,
+	0xcb, 0x4c, 0x49, 0xcd, 0x4b, 0x4e, 0xd5, 0x2f, 0x33, 0x84, 0xb3, 0xe3, 0xa1, 0xb2, 0x7a, 0x05,
+	0x45, 0xf9, 0x25, 0xf9, 0x42, 0xb2, 0x30, 0x5d, 0x7a, 0x30, 0x5d, 0x7a, 0x30, 0x95, 0x7a, 0x65,
+	0x86, 0x52, 0x3a, 0xc4, 0x19, 0x0a, 0x31, 0xcc, 0x68, 0x16, 0x23, 0x17, 0xbf, 0x2b, 0x54, 0x28,
+	0x18, 0xa2, 0x5e, 0xa8, 0x8d, 0x91, 0x4b, 0xd0, 0xa9, 0x28, 0x3f, 0x31, 0x25, 0x39, 0xb1, 0xb8,
+	0x04, 0x26, 0x29, 0x64, 0xae, 0x87, 0xd7, 0x5e, 0x3d, 0x0c, 0x1d, 0x41, 0xa9, 0x85, 0xa5, 0xa9,
+	0xc5, 0x25, 0x52, 0x16, 0xa4, 0x6b, 0x2c, 0x2e, 0xc8, 0xcf, 0x2b, 0x4e, 0x75, 0x8a, 0x38, 0xf1,
+	0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8,
+	0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x28, 0xbb, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24,
+	0x90, 0xc9, 0xfa, 0x70, 0xff, 0xc2, 0x19, 0x89, 0x05, 0x99, 0xfa, 0x78, 0x43, 0x21, 0x89, 0x0d,
+	0xec, 0x7b, 0x63, 0xc0, 0x00, 0xac, 0x83, 0x94, 0x21, 0x82, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EvidenceServiceClient is the client API for EvidenceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EvidenceServiceClient interface {
+	// BroadcastEvidence verifies the given evidence and adds it to the node's
+	// evidence pool, from which it's gossiped to peers.
+	BroadcastEvidence(ctx context.Context, in *BroadcastEvidenceRequest, opts ...grpc.CallOption) (*BroadcastEvidenceResponse, error)
+}
+
+type evidenceServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewEvidenceServiceClient(cc grpc1.ClientConn) EvidenceServiceClient {
+	return &evidenceServiceClient{cc}
+}
+
+func (c *evidenceServiceClient) BroadcastEvidence(ctx context.Context, in *BroadcastEvidenceRequest, opts ...grpc.CallOption) (*BroadcastEvidenceResponse, error) {
+	out := new(BroadcastEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.evidence.v1.EvidenceService/BroadcastEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceServiceServer is the server API for EvidenceService service.
+type EvidenceServiceServer interface {
+	// BroadcastEvidence verifies the given evidence and adds it to the node's
+	// evidence pool, from which it's gossiped to peers.
+	BroadcastEvidence(context.Context, *BroadcastEvidenceRequest) (*BroadcastEvidenceResponse, error)
+}
+
+// UnimplementedEvidenceServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedEvidenceServiceServer struct {
+}
+
+func (*UnimplementedEvidenceServiceServer) BroadcastEvidence(ctx context.Context, req *BroadcastEvidenceRequest) (*BroadcastEvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BroadcastEvidence not implemented")
+}
+
+func RegisterEvidenceServiceServer(s grpc1.Server, srv EvidenceServiceServer) {
+	s.RegisterService(&_EvidenceService_serviceDesc, srv)
+}
+
+func _EvidenceService_BroadcastEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvidenceServiceServer).BroadcastEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.evidence.v1.EvidenceService/BroadcastEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvidenceServiceServer).BroadcastEvidence(ctx, req.(*BroadcastEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var EvidenceService_serviceDesc = _EvidenceService_serviceDesc
+var _EvidenceService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.evidence.v1.EvidenceService",
+	HandlerType: (*EvidenceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BroadcastEvidence",
+			Handler:    _EvidenceService_BroadcastEvidence_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cometbft/services/evidence/v1/evidence_service.proto",
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/validator/v1/validator_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/validator/v1/validator_service.proto", fileDescriptor_6c7fb4b057985480)
+}
+
+var fileDescriptor_6c7fb4b057985480 = []byte{
+	// 248 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4b, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x2f, 0x4b,
+	0xcc, 0xc9, 0x4c, 0x49, 0x2c, 0xc9, 0x2f, 0xd2, 0x2f, 0x33, 0x44, 0x70, 0xe2, 0xa1, 0xf2, 0x7a,
+	0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0x72, 0x30, 0x7d, 0x7a, 0x30, 0x7d, 0x7a, 0x70, 0xa5, 0x7a,
+	0x65, 0x86, 0x52, 0x7a, 0xc4, 0x9a, 0x0b, 0x31, 0xcf, 0x68, 0x0e, 0x33, 0x97, 0x40, 0x18, 0x4c,
+	0x2c, 0x18, 0xa2, 0x45, 0xa8, 0x89, 0x91, 0x8b, 0xdf, 0x3d, 0xb5, 0x04, 0x49, 0xbc, 0x44, 0xc8,
+	0x4c, 0x0f, 0xbf, 0xcd, 0x7a, 0x68, 0x1a, 0x82, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0xa4, 0xcc,
+	0x49, 0xd6, 0x57, 0x5c, 0x90, 0x9f, 0x57, 0x9c, 0x2a, 0xd4, 0xcd, 0xc8, 0x25, 0xe4, 0x9e, 0x5a,
+	0xe2, 0x0c, 0xe2, 0xe4, 0x15, 0x97, 0x16, 0x07, 0x24, 0x16, 0x25, 0xe6, 0x16, 0x0b, 0x59, 0x12,
+	0x61, 0x1e, 0x9a, 0x1e, 0x98, 0x53, 0xac, 0xc8, 0xd1, 0x0a, 0x75, 0x4d, 0x29, 0x17, 0x17, 0x48,
+	0x36, 0x23, 0x31, 0x2f, 0x3d, 0xb5, 0x58, 0xc8, 0x90, 0x18, 0x93, 0x20, 0x6a, 0x61, 0x96, 0x1b,
+	0x91, 0xa2, 0x05, 0x62, 0xa9, 0x01, 0xa3, 0x53, 0xe4, 0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9,
+	0x31, 0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0xc3, 0x85, 0xc7, 0x72, 0x0c, 0x37, 0x1e,
+	0xcb, 0x31, 0x44, 0xd9, 0xa7, 0x67, 0x96, 0x64, 0x94, 0x26, 0x81, 0x4c, 0xd5, 0x87, 0xc7, 0x39,
+	0x9c, 0x91, 0x58, 0x90, 0xa9, 0x8f, 0x3f, 0x25, 0x24, 0xb1, 0x81, 0x13, 0x80, 0x31, 0x60, 0x00,
+	0xe5, 0xeb, 0x9f, 0x8a, 0x8a, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ValidatorServiceClient is the client API for ValidatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ValidatorServiceClient interface {
+	// GetValidatorSet retrieves the full validator set at a particular height.
+	GetValidatorSet(ctx context.Context, in *GetValidatorSetRequest, opts ...grpc.CallOption) (*GetValidatorSetResponse, error)
+	// GetConsensusParams retrieves the consensus params at a particular height.
+	GetConsensusParams(ctx context.Context, in *GetConsensusParamsRequest, opts ...grpc.CallOption) (*GetConsensusParamsResponse, error)
+	// GetChanges streams the heights where the validator set or the consensus
+	// params changed, from a particular height up to the latest height, and
+	// then as blocks are committed.
+	GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (ValidatorService_GetChangesClient, error)
+}
+
+type validatorServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewValidatorServiceClient(cc grpc1.ClientConn) ValidatorServiceClient {
+	return &validatorServiceClient{cc}
+}
+
+func (c *validatorServiceClient) GetValidatorSet(ctx context.Context, in *GetValidatorSetRequest, opts ...grpc.CallOption) (*GetValidatorSetResponse, error) {
+	out := new(GetValidatorSetResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.validator.v1.ValidatorService/GetValidatorSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *validatorServiceClient) GetConsensusParams(ctx context.Context, in *GetConsensusParamsRequest, opts ...grpc.CallOption) (*GetConsensusParamsResponse, error) {
+	out := new(GetConsensusParamsResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.validator.v1.ValidatorService/GetConsensusParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *validatorServiceClient) GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (ValidatorService_GetChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ValidatorService_serviceDesc.Streams[0], "/cometbft.services.validator.v1.ValidatorService/GetChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &validatorServiceGetChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ValidatorService_GetChangesClient interface {
+	Recv() (*GetChangesResponse, error)
+	grpc.ClientStream
+}
+
+type validatorServiceGetChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *validatorServiceGetChangesClient) Recv() (*GetChangesResponse, error) {
+	m := new(GetChangesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ValidatorServiceServer is the server API for ValidatorService service.
+type ValidatorServiceServer interface {
+	// GetValidatorSet retrieves the full validator set at a particular height.
+	GetValidatorSet(context.Context, *GetValidatorSetRequest) (*GetValidatorSetResponse, error)
+	// GetConsensusParams retrieves the consensus params at a particular height.
+	GetConsensusParams(context.Context, *GetConsensusParamsRequest) (*GetConsensusParamsResponse, error)
+	// GetChanges streams the heights where the validator set or the consensus
+	// params changed, from a particular height up to the latest height, and
+	// then as blocks are committed.
+	GetChanges(*GetChangesRequest, ValidatorService_GetChangesServer) error
+}
+
+// UnimplementedValidatorServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedValidatorServiceServer struct {
+}
+
+func (*UnimplementedValidatorServiceServer) GetValidatorSet(ctx context.Context, req *GetValidatorSetRequest) (*GetValidatorSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorSet not implemented")
+}
+func (*UnimplementedValidatorServiceServer) GetConsensusParams(ctx context.Context, req *GetConsensusParamsRequest) (*GetConsensusParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsensusParams not implemented")
+}
+func (*UnimplementedValidatorServiceServer) GetChanges(req *GetChangesRequest, srv ValidatorService_GetChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetChanges not implemented")
+}
+
+func RegisterValidatorServiceServer(s grpc1.Server, srv ValidatorServiceServer) {
+	s.RegisterService(&_ValidatorService_serviceDesc, srv)
+}
+
+func _ValidatorService_GetValidatorSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidatorSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorServiceServer).GetValidatorSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.validator.v1.ValidatorService/GetValidatorSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorServiceServer).GetValidatorSet(ctx, req.(*GetValidatorSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ValidatorService_GetConsensusParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorServiceServer).GetConsensusParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.validator.v1.ValidatorService/GetConsensusParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorServiceServer).GetConsensusParams(ctx, req.(*GetConsensusParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ValidatorService_GetChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ValidatorServiceServer).GetChanges(m, &validatorServiceGetChangesServer{stream})
+}
+
+type ValidatorService_GetChangesServer interface {
+	Send(*GetChangesResponse) error
+	grpc.ServerStream
+}
+
+type validatorServiceGetChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *validatorServiceGetChangesServer) Send(m *GetChangesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var ValidatorService_serviceDesc = _ValidatorService_serviceDesc
+var _ValidatorService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.validator.v1.ValidatorService",
+	HandlerType: (*ValidatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetValidatorSet",
+			Handler:    _ValidatorService_GetValidatorSet_Handler,
+		},
+		{
+			MethodName: "GetConsensusParams",
+			Handler:    _ValidatorService_GetConsensusParams_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetChanges",
+			Handler:       _ValidatorService_GetChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cometbft/services/validator/v1/validator_service.proto",
+}
@@ -501,6 +501,20 @@ type FeatureParams struct {
 	//
 	// Cannot be set to heights lower or equal to the current blockchain height.
 	PbtsEnableHeight *types.Int64Value `protobuf:"bytes,2,opt,name=pbts_enable_height,json=pbtsEnableHeight,proto3" json:"pbts_enable_height,omitempty"`
+	// Height at which the signatures of commits will be aggregated.
+	//
+	// A value of 0 means commits are never aggregated. A value > 0 denotes the
+	// height at which aggregation will be (or has been) enabled.
+	//
+	// From the specified height, and for all subsequent heights, proposers
+	// aggregate the signatures of the last commit of a block into a single
+	// BLS12-381 signature, if its validators all have BLS12-381 keys. A last
+	// commit carrying one signature per validator remains valid, as votes with
+	// identical sign bytes cannot be aggregated. Prior to this height, or when
+	// this height is set to 0, aggregated commits are invalid.
+	//
+	// Cannot be set to heights lower or equal to the current blockchain height.
+	BlsAggregateCommitsEnableHeight *types.Int64Value `protobuf:"bytes,3,opt,name=bls_aggregate_commits_enable_height,json=blsAggregateCommitsEnableHeight,proto3" json:"bls_aggregate_commits_enable_height,omitempty"`
 }
 
 func (m *FeatureParams) Reset()         { *m = FeatureParams{} }
@@ -550,6 +564,13 @@ func (m *FeatureParams) GetPbtsEnableHeight() *types.Int64Value {
 	return nil
 }
 
+func (m *FeatureParams) GetBlsAggregateCommitsEnableHeight() *types.Int64Value {
+	if m != nil {
+		return m.BlsAggregateCommitsEnableHeight
+	}
+	return nil
+}
+
 // ABCIParams is deprecated and its contents moved to FeatureParams
 //
 // Deprecated: Do not use.
@@ -614,53 +635,55 @@ func init() {
 func init() { proto.RegisterFile("cometbft/types/v2/params.proto", fileDescriptor_5f4e06a882ada5b9) }
 
 var fileDescriptor_5f4e06a882ada5b9 = []byte{
-	// 729 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xbf, 0x4e, 0xdb, 0x50,
-	0x14, 0xc6, 0x73, 0xe3, 0x00, 0xc9, 0x09, 0x21, 0xe9, 0x55, 0xa5, 0xba, 0x20, 0x1c, 0xea, 0xa1,
-	0x42, 0x42, 0xb2, 0xa5, 0x94, 0x76, 0x40, 0x42, 0x2d, 0x01, 0x0a, 0xb4, 0xa2, 0x45, 0xa6, 0x62,
-	0x60, 0xb1, 0xae, 0x93, 0x8b, 0x63, 0x11, 0xff, 0x91, 0xaf, 0x9d, 0x26, 0x6f, 0xd1, 0xa9, 0xea,
-	0xc8, 0xd8, 0xbe, 0x41, 0xfb, 0x02, 0x15, 0x23, 0x63, 0x27, 0x5a, 0x85, 0xa5, 0x8f, 0x51, 0xf9,
-	0xda, 0x4e, 0x48, 0x08, 0x85, 0xcd, 0xf6, 0xf9, 0x7e, 0x9f, 0xbf, 0x7b, 0xce, 0xb1, 0x41, 0x6a,
-	0xb8, 0x36, 0x0d, 0x8c, 0x93, 0x40, 0x0d, 0x7a, 0x1e, 0x65, 0x6a, 0xa7, 0xa6, 0x7a, 0xc4, 0x27,
-	0x36, 0x53, 0x3c, 0xdf, 0x0d, 0x5c, 0xfc, 0x20, 0xad, 0x2b, 0xbc, 0xae, 0x74, 0x6a, 0xf3, 0x0f,
-	0x4d, 0xd7, 0x74, 0x79, 0x55, 0x8d, 0xae, 0x62, 0xe1, 0xbc, 0x64, 0xba, 0xae, 0xd9, 0xa6, 0x2a,
-	0xbf, 0x33, 0xc2, 0x13, 0xb5, 0x19, 0xfa, 0x24, 0xb0, 0x5c, 0xe7, 0xb6, 0xfa, 0x47, 0x9f, 0x78,
-	0x1e, 0xf5, 0x93, 0x17, 0xc9, 0x3f, 0x04, 0x28, 0x6f, 0xba, 0x0e, 0xa3, 0x0e, 0x0b, 0xd9, 0x01,
-	0x8f, 0x80, 0x57, 0x61, 0xca, 0x68, 0xbb, 0x8d, 0x53, 0x11, 0x2d, 0xa1, 0xe5, 0x62, 0x4d, 0x52,
-	0x6e, 0x84, 0x51, 0xea, 0x51, 0x3d, 0x96, 0x6b, 0xb1, 0x18, 0xaf, 0x43, 0x9e, 0x76, 0xac, 0x26,
-	0x75, 0x1a, 0x54, 0xcc, 0x72, 0xf0, 0xc9, 0x04, 0x70, 0x3b, 0x91, 0x24, 0xec, 0x00, 0xc1, 0xaf,
-	0xa0, 0xd0, 0x21, 0x6d, 0xab, 0x49, 0x02, 0xd7, 0x17, 0x05, 0xce, 0xcb, 0x13, 0xf8, 0xa3, 0x54,
-	0x93, 0x18, 0x0c, 0x21, 0xbc, 0x06, 0x33, 0x1d, 0xea, 0x33, 0xcb, 0x75, 0xc4, 0x1c, 0xe7, 0x97,
-	0x26, 0xf1, 0xb1, 0x22, 0xa1, 0x53, 0x00, 0x3f, 0x87, 0x1c, 0x31, 0x1a, 0x96, 0x38, 0xc5, 0xc1,
-	0xc5, 0x09, 0xe0, 0x46, 0x7d, 0x73, 0x2f, 0xa6, 0xea, 0x59, 0x11, 0x69, 0x5c, 0x1e, 0x85, 0x66,
-	0x3d, 0xa7, 0xd1, 0xf2, 0x5d, 0xa7, 0x27, 0x4e, 0xdf, 0x1a, 0xfa, 0x30, 0xd5, 0xa4, 0xa1, 0x07,
-	0x50, 0x14, 0xfa, 0x84, 0x92, 0x20, 0xf4, 0xa9, 0x38, 0x73, 0x6b, 0xe8, 0xd7, 0xb1, 0x22, 0x0d,
-	0x9d, 0x00, 0xf2, 0x1e, 0x14, 0xaf, 0xcd, 0x01, 0x2f, 0x40, 0xc1, 0x26, 0x5d, 0xdd, 0xe8, 0x05,
-	0x94, 0xf1, 0xd1, 0x09, 0x5a, 0xde, 0x26, 0xdd, 0x7a, 0x74, 0x8f, 0x1f, 0xc1, 0x4c, 0x54, 0x34,
-	0x09, 0xe3, 0xc3, 0x11, 0xb4, 0x69, 0x9b, 0x74, 0x77, 0x08, 0x7b, 0x93, 0xcb, 0x0b, 0x95, 0x9c,
-	0xfc, 0x0d, 0xc1, 0xdc, 0xe8, 0x68, 0xf0, 0x0a, 0xe0, 0x88, 0x20, 0x26, 0xd5, 0x9d, 0xd0, 0xd6,
-	0xf9, 0x90, 0x53, 0xdf, 0xb2, 0x4d, 0xba, 0x1b, 0x26, 0x7d, 0x17, 0xda, 0x3c, 0x00, 0xc3, 0xfb,
-	0x50, 0x49, 0xc5, 0xe9, 0x02, 0x26, 0x4b, 0xf0, 0x58, 0x89, 0x37, 0x50, 0x49, 0x37, 0x50, 0xd9,
-	0x4a, 0x04, 0xf5, 0xfc, 0xf9, 0x65, 0x35, 0xf3, 0xe5, 0x77, 0x15, 0x69, 0x73, 0xb1, 0x5f, 0x5a,
-	0x19, 0x3d, 0x8a, 0x30, 0x7a, 0x14, 0xf9, 0x25, 0x94, 0xc7, 0xb6, 0x00, 0xcb, 0x50, 0xf2, 0x42,
-	0x43, 0x3f, 0xa5, 0x3d, 0x9d, 0x37, 0x4d, 0x44, 0x4b, 0xc2, 0x72, 0x41, 0x2b, 0x7a, 0xa1, 0xf1,
-	0x96, 0xf6, 0x3e, 0x44, 0x8f, 0xd6, 0xf2, 0xdf, 0xcf, 0xaa, 0xe8, 0xef, 0x59, 0x15, 0xc9, 0x2b,
-	0x50, 0x1a, 0x59, 0x03, 0x5c, 0x01, 0x81, 0x78, 0x1e, 0x3f, 0x5b, 0x4e, 0x8b, 0x2e, 0xaf, 0x89,
-	0x8f, 0x61, 0x76, 0x97, 0xb0, 0x16, 0x6d, 0x26, 0xda, 0xa7, 0x50, 0xe6, 0xad, 0xd0, 0xc7, 0x7b,
-	0x5d, 0xe2, 0x8f, 0xf7, 0xd3, 0x86, 0xcb, 0x50, 0x1a, 0xea, 0x86, 0x6d, 0x2f, 0xa6, 0xaa, 0x1d,
-	0xc2, 0xe4, 0xcf, 0x08, 0xca, 0x63, 0xbb, 0x81, 0xd7, 0xa1, 0xe0, 0xf9, 0xb4, 0x61, 0xf1, 0x3d,
-	0x46, 0x77, 0xb5, 0x30, 0xc7, 0xdb, 0x37, 0x24, 0xf0, 0x16, 0x94, 0x6c, 0xca, 0x18, 0x1f, 0x04,
-	0x6d, 0x93, 0xde, 0xdd, 0x53, 0x88, 0x2d, 0x66, 0x13, 0x6a, 0x2b, 0x82, 0xe4, 0x9f, 0x08, 0x4a,
-	0x23, 0x4b, 0x87, 0x9b, 0xb0, 0xd8, 0x71, 0x03, 0xaa, 0xd3, 0x6e, 0x40, 0x9d, 0xe8, 0x4d, 0x4c,
-	0xa7, 0x0e, 0x31, 0xda, 0x54, 0x6f, 0x51, 0xcb, 0x6c, 0x05, 0x49, 0xd4, 0x85, 0x1b, 0xef, 0xd9,
-	0x73, 0x82, 0x17, 0xab, 0x47, 0xa4, 0x1d, 0xd2, 0x7a, 0xee, 0xfc, 0xb2, 0x8a, 0xb4, 0xf9, 0xc8,
-	0x67, 0x7b, 0x60, 0xb3, 0xcd, 0x5d, 0x76, 0xb9, 0x09, 0x7e, 0x0f, 0xd8, 0x33, 0x82, 0x71, 0xeb,
-	0xec, 0x7d, 0xad, 0x2b, 0x11, 0x7c, 0xdd, 0x50, 0x3e, 0x04, 0x18, 0x7e, 0xb8, 0x78, 0xe3, 0x3e,
-	0x87, 0x10, 0xfe, 0x97, 0x70, 0x2d, 0x2b, 0xa2, 0xfa, 0xc1, 0xd7, 0xbe, 0x84, 0xce, 0xfb, 0x12,
-	0xba, 0xe8, 0x4b, 0xe8, 0x4f, 0x5f, 0x42, 0x9f, 0xae, 0xa4, 0xcc, 0xc5, 0x95, 0x94, 0xf9, 0x75,
-	0x25, 0x65, 0x8e, 0x6b, 0xa6, 0x15, 0xb4, 0x42, 0x23, 0xfa, 0x8c, 0xd5, 0xc1, 0x5f, 0x7e, 0x70,
-	0x41, 0x3c, 0x4b, 0xbd, 0xf1, 0xef, 0x37, 0xa6, 0xf9, 0x99, 0x9e, 0xfd, 0x0b, 0x00, 0x00, 0xff,
-	0xff, 0x6d, 0x17, 0x25, 0xb9, 0x17, 0x06, 0x00, 0x00,
+	// 761 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0xcf, 0x4e, 0xdb, 0x4a,
+	0x14, 0xc6, 0x33, 0x71, 0x80, 0x64, 0x42, 0x48, 0xee, 0xe8, 0x4a, 0xd7, 0x17, 0x84, 0x43, 0x5d,
+	0xa9, 0x42, 0x42, 0xb2, 0xa5, 0x94, 0x76, 0x81, 0x84, 0xda, 0x04, 0x28, 0xd0, 0x8a, 0x16, 0x99,
+	0x8a, 0x05, 0x1b, 0x6b, 0x9c, 0x0c, 0x8e, 0x85, 0xed, 0xb1, 0x3c, 0x76, 0x9a, 0xbc, 0x45, 0x57,
+	0x55, 0x97, 0x2c, 0xdb, 0x27, 0x68, 0xfb, 0x06, 0x2c, 0x59, 0x76, 0x45, 0xab, 0xb0, 0xe9, 0x63,
+	0x54, 0x1e, 0xdb, 0x09, 0xf9, 0x43, 0x9b, 0xdd, 0xd8, 0xe7, 0xfb, 0x7d, 0xe7, 0xcc, 0x39, 0x47,
+	0x36, 0x94, 0x9a, 0xd4, 0x21, 0x81, 0x71, 0x1e, 0xa8, 0x41, 0xcf, 0x23, 0x4c, 0xed, 0xd4, 0x54,
+	0x0f, 0xfb, 0xd8, 0x61, 0x8a, 0xe7, 0xd3, 0x80, 0xa2, 0x7f, 0xd2, 0xb8, 0xc2, 0xe3, 0x4a, 0xa7,
+	0xb6, 0xfc, 0xaf, 0x49, 0x4d, 0xca, 0xa3, 0x6a, 0x74, 0x8a, 0x85, 0xcb, 0x92, 0x49, 0xa9, 0x69,
+	0x13, 0x95, 0x3f, 0x19, 0xe1, 0xb9, 0xda, 0x0a, 0x7d, 0x1c, 0x58, 0xd4, 0xbd, 0x2f, 0xfe, 0xce,
+	0xc7, 0x9e, 0x47, 0xfc, 0x24, 0x91, 0xfc, 0x4d, 0x80, 0xe5, 0x1d, 0xea, 0x32, 0xe2, 0xb2, 0x90,
+	0x1d, 0xf3, 0x12, 0xd0, 0x26, 0x9c, 0x33, 0x6c, 0xda, 0xbc, 0x10, 0xc1, 0x1a, 0x58, 0x2f, 0xd6,
+	0x24, 0x65, 0xa2, 0x18, 0xa5, 0x11, 0xc5, 0x63, 0xb9, 0x16, 0x8b, 0xd1, 0x36, 0xcc, 0x93, 0x8e,
+	0xd5, 0x22, 0x6e, 0x93, 0x88, 0x59, 0x0e, 0x3e, 0x98, 0x02, 0xee, 0x25, 0x92, 0x84, 0x1d, 0x20,
+	0xe8, 0x39, 0x2c, 0x74, 0xb0, 0x6d, 0xb5, 0x70, 0x40, 0x7d, 0x51, 0xe0, 0xbc, 0x3c, 0x85, 0x3f,
+	0x4d, 0x35, 0x89, 0xc1, 0x10, 0x42, 0x5b, 0x70, 0xa1, 0x43, 0x7c, 0x66, 0x51, 0x57, 0xcc, 0x71,
+	0x7e, 0x6d, 0x1a, 0x1f, 0x2b, 0x12, 0x3a, 0x05, 0xd0, 0x13, 0x98, 0xc3, 0x46, 0xd3, 0x12, 0xe7,
+	0x38, 0xb8, 0x3a, 0x05, 0xac, 0x37, 0x76, 0x0e, 0x63, 0xaa, 0x91, 0x15, 0x81, 0xc6, 0xe5, 0x51,
+	0xd1, 0xac, 0xe7, 0x36, 0xdb, 0x3e, 0x75, 0x7b, 0xe2, 0xfc, 0xbd, 0x45, 0x9f, 0xa4, 0x9a, 0xb4,
+	0xe8, 0x01, 0x14, 0x15, 0x7d, 0x4e, 0x70, 0x10, 0xfa, 0x44, 0x5c, 0xb8, 0xb7, 0xe8, 0x17, 0xb1,
+	0x22, 0x2d, 0x3a, 0x01, 0xe4, 0x43, 0x58, 0xbc, 0x33, 0x07, 0xb4, 0x02, 0x0b, 0x0e, 0xee, 0xea,
+	0x46, 0x2f, 0x20, 0x8c, 0x8f, 0x4e, 0xd0, 0xf2, 0x0e, 0xee, 0x36, 0xa2, 0x67, 0xf4, 0x1f, 0x5c,
+	0x88, 0x82, 0x26, 0x66, 0x7c, 0x38, 0x82, 0x36, 0xef, 0xe0, 0xee, 0x3e, 0x66, 0x2f, 0x73, 0x79,
+	0xa1, 0x92, 0x93, 0x3f, 0x03, 0xb8, 0x34, 0x3a, 0x1a, 0xb4, 0x01, 0x51, 0x44, 0x60, 0x93, 0xe8,
+	0x6e, 0xe8, 0xe8, 0x7c, 0xc8, 0xa9, 0x6f, 0xd9, 0xc1, 0xdd, 0xba, 0x49, 0x5e, 0x87, 0x0e, 0x2f,
+	0x80, 0xa1, 0x23, 0x58, 0x49, 0xc5, 0xe9, 0x02, 0x26, 0x4b, 0xf0, 0xbf, 0x12, 0x6f, 0xa0, 0x92,
+	0x6e, 0xa0, 0xb2, 0x9b, 0x08, 0x1a, 0xf9, 0xab, 0x9b, 0x6a, 0xe6, 0xe3, 0x8f, 0x2a, 0xd0, 0x96,
+	0x62, 0xbf, 0x34, 0x32, 0x7a, 0x15, 0x61, 0xf4, 0x2a, 0xf2, 0x33, 0x58, 0x1e, 0xdb, 0x02, 0x24,
+	0xc3, 0x92, 0x17, 0x1a, 0xfa, 0x05, 0xe9, 0xe9, 0xbc, 0x69, 0x22, 0x58, 0x13, 0xd6, 0x0b, 0x5a,
+	0xd1, 0x0b, 0x8d, 0x57, 0xa4, 0xf7, 0x36, 0x7a, 0xb5, 0x95, 0xff, 0x7a, 0x59, 0x05, 0xbf, 0x2e,
+	0xab, 0x40, 0xde, 0x80, 0xa5, 0x91, 0x35, 0x40, 0x15, 0x28, 0x60, 0xcf, 0xe3, 0x77, 0xcb, 0x69,
+	0xd1, 0xf1, 0x8e, 0xf8, 0x0c, 0x2e, 0x1e, 0x60, 0xd6, 0x26, 0xad, 0x44, 0xfb, 0x08, 0x96, 0x79,
+	0x2b, 0xf4, 0xf1, 0x5e, 0x97, 0xf8, 0xeb, 0xa3, 0xb4, 0xe1, 0x32, 0x2c, 0x0d, 0x75, 0xc3, 0xb6,
+	0x17, 0x53, 0xd5, 0x3e, 0x66, 0xf2, 0x07, 0x00, 0xcb, 0x63, 0xbb, 0x81, 0xb6, 0x61, 0xc1, 0xf3,
+	0x49, 0xd3, 0xe2, 0x7b, 0x0c, 0xfe, 0xd6, 0xc2, 0x1c, 0x6f, 0xdf, 0x90, 0x40, 0xbb, 0xb0, 0xe4,
+	0x10, 0xc6, 0xf8, 0x20, 0x88, 0x8d, 0x7b, 0x62, 0x76, 0x36, 0x8b, 0xc5, 0x84, 0xda, 0x8d, 0x20,
+	0xf9, 0x4b, 0x16, 0x96, 0x46, 0x96, 0x0e, 0xb5, 0xe0, 0x6a, 0x87, 0x06, 0x44, 0x27, 0xdd, 0x80,
+	0xb8, 0x51, 0x26, 0xa6, 0x13, 0x17, 0x1b, 0x36, 0xd1, 0xdb, 0xc4, 0x32, 0xdb, 0x41, 0x52, 0xea,
+	0xca, 0x44, 0x9e, 0x43, 0x37, 0x78, 0xba, 0x79, 0x8a, 0xed, 0x90, 0x34, 0x72, 0x57, 0x37, 0x55,
+	0xa0, 0x2d, 0x47, 0x3e, 0x7b, 0x03, 0x9b, 0x3d, 0xee, 0x72, 0xc0, 0x4d, 0xd0, 0x1b, 0x88, 0x3c,
+	0x23, 0x18, 0xb7, 0xce, 0xce, 0x6a, 0x5d, 0x89, 0xe0, 0x11, 0x43, 0x0a, 0x1f, 0x1a, 0x36, 0xd3,
+	0xb1, 0x69, 0xfa, 0xc4, 0xc4, 0x01, 0xd1, 0x9b, 0xd4, 0x71, 0xac, 0x89, 0x0c, 0xc2, 0xac, 0x19,
+	0xaa, 0x86, 0xcd, 0xea, 0xa9, 0xd9, 0x4e, 0xec, 0x75, 0x37, 0xa1, 0x7c, 0x02, 0xe1, 0xf0, 0x4b,
+	0x81, 0xea, 0xb3, 0x74, 0x4d, 0xf8, 0x53, 0x4b, 0xb6, 0xb2, 0x22, 0x68, 0x1c, 0x7f, 0xea, 0x4b,
+	0xe0, 0xaa, 0x2f, 0x81, 0xeb, 0xbe, 0x04, 0x7e, 0xf6, 0x25, 0xf0, 0xfe, 0x56, 0xca, 0x5c, 0xdf,
+	0x4a, 0x99, 0xef, 0xb7, 0x52, 0xe6, 0xac, 0x66, 0x5a, 0x41, 0x3b, 0x34, 0xa2, 0xef, 0x86, 0x3a,
+	0xf8, 0xad, 0x0c, 0x0e, 0xd8, 0xb3, 0xd4, 0x89, 0x9f, 0x8d, 0x31, 0xcf, 0xaf, 0xf8, 0xf8, 0xf7,
+	0x00, 0x60, 0x1c, 0x58, 0xcd, 0x88, 0x06, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -906,6 +929,9 @@ func (this *FeatureParams) Equal(that interface{}) bool {
 	if !this.PbtsEnableHeight.Equal(that1.PbtsEnableHeight) {
 		return false
 	}
+	if !this.BlsAggregateCommitsEnableHeight.Equal(that1.BlsAggregateCommitsEnableHeight) {
+		return false
+	}
 	return true
 }
 func (this *ABCIParams) Equal(that interface{}) bool {
@@ -1269,6 +1295,18 @@ func (m *FeatureParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlsAggregateCommitsEnableHeight != nil {
+		{
+			size, err := m.BlsAggregateCommitsEnableHeight.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.PbtsEnableHeight != nil {
 		{
 			size, err := m.PbtsEnableHeight.MarshalToSizedBuffer(dAtA[:i])
@@ -1569,6 +1607,10 @@ func (m *FeatureParams) Size() (n int) {
 		l = m.PbtsEnableHeight.Size()
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.BlsAggregateCommitsEnableHeight != nil {
+		l = m.BlsAggregateCommitsEnableHeight.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -2563,6 +2605,42 @@ func (m *FeatureParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlsAggregateCommitsEnableHeight", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlsAggregateCommitsEnableHeight == nil {
+				m.BlsAggregateCommitsEnableHeight = &types.Int64Value{}
+			}
+			if err := m.BlsAggregateCommitsEnableHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -588,6 +588,10 @@ type Commit struct {
 	Round      int32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	BlockID    BlockID     `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
 	Signatures []CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures"`
+	// BLS12-381 signature aggregating the signatures of all the validators that
+	// did not vote absent, whose CommitSigs then carry no signature of their own.
+	// Empty unless the commit is aggregated.
+	AggregatedSignature []byte `protobuf:"bytes,5,opt,name=aggregated_signature,json=aggregatedSignature,proto3" json:"aggregated_signature,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
@@ -651,6 +655,13 @@ func (m *Commit) GetSignatures() []CommitSig {
 	return nil
 }
 
+func (m *Commit) GetAggregatedSignature() []byte {
+	if m != nil {
+		return m.AggregatedSignature
+	}
+	return nil
+}
+
 // CommitSig is a part of the Vote included in a Commit.
 type CommitSig struct {
 	BlockIdFlag      BlockIDFlag `protobuf:"varint,1,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=cometbft.types.v2.BlockIDFlag" json:"block_id_flag,omitempty"`
@@ -1250,93 +1261,94 @@ func init() {
 func init() { proto.RegisterFile("cometbft/types/v2/types.proto", fileDescriptor_b33958ab5ece188f) }
 
 var fileDescriptor_b33958ab5ece188f = []byte{
-	// 1363 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xc6, 0x1b, 0xff, 0x79, 0xb6, 0x13, 0x67, 0x89, 0xa8, 0xe3, 0xb6, 0x8e, 0x31, 0xff,
-	0x4c, 0x41, 0x76, 0x6b, 0x40, 0x80, 0x90, 0x90, 0xea, 0x24, 0x6d, 0x23, 0x9a, 0xc4, 0x5a, 0xbb,
-	0x45, 0xc0, 0x61, 0xb5, 0xf6, 0x4e, 0xec, 0x55, 0xed, 0x9d, 0xd5, 0xee, 0xd8, 0x24, 0xfd, 0x04,
-	0xa8, 0xa7, 0x1e, 0x39, 0x50, 0x09, 0x09, 0x0e, 0x7c, 0x01, 0xbe, 0x00, 0xe2, 0xd0, 0x63, 0x6f,
-	0x70, 0x2a, 0x28, 0xb9, 0xf0, 0x31, 0xd0, 0xbc, 0x99, 0xdd, 0xb5, 0x63, 0x87, 0x96, 0xb6, 0x02,
-	0x89, 0xdb, 0xcc, 0x7b, 0xbf, 0xf7, 0xe6, 0xed, 0x7b, 0xbf, 0x79, 0xfb, 0x06, 0x2e, 0x76, 0xe9,
-	0x90, 0xb0, 0xce, 0x01, 0xab, 0xb1, 0x23, 0x97, 0xf8, 0xb5, 0x71, 0x5d, 0x2c, 0xaa, 0xae, 0x47,
-	0x19, 0xd5, 0x56, 0x03, 0x75, 0x55, 0x48, 0xc7, 0xf5, 0x42, 0x31, 0xb4, 0xe8, 0x7a, 0x47, 0x2e,
-	0xa3, 0xb5, 0xf1, 0x95, 0x9a, 0xeb, 0x51, 0x7a, 0x20, 0x4c, 0x0a, 0xaf, 0xcc, 0x7a, 0x1c, 0x9b,
-	0x03, 0xdb, 0x32, 0x19, 0xf5, 0x24, 0x64, 0x23, 0x84, 0x8c, 0x89, 0xe7, 0xdb, 0xd4, 0xe1, 0x3e,
-	0x26, 0x8e, 0x2d, 0xac, 0xf5, 0x68, 0x8f, 0xe2, 0xb2, 0xc6, 0x57, 0x81, 0x59, 0x8f, 0xd2, 0xde,
-	0x80, 0xd4, 0x70, 0xd7, 0x19, 0x1d, 0xd4, 0x98, 0x3d, 0x24, 0x3e, 0x33, 0x87, 0xae, 0x00, 0x94,
-	0x3f, 0x82, 0x6c, 0xd3, 0xf4, 0x58, 0x8b, 0xb0, 0x1b, 0xc4, 0xb4, 0x88, 0xa7, 0xad, 0xc1, 0x12,
-	0xa3, 0xcc, 0x1c, 0xe4, 0x95, 0x92, 0x52, 0xc9, 0xea, 0x62, 0xa3, 0x69, 0xa0, 0xf6, 0x4d, 0xbf,
-	0x9f, 0x5f, 0x2c, 0x29, 0x95, 0x8c, 0x8e, 0xeb, 0xb2, 0x0d, 0x2a, 0x37, 0xe5, 0x16, 0xb6, 0x63,
-	0x91, 0xc3, 0xc0, 0x02, 0x37, 0x5c, 0xda, 0x39, 0x62, 0xc4, 0x97, 0x26, 0x62, 0xa3, 0xbd, 0x0f,
-	0x4b, 0xf8, 0xe1, 0xf9, 0x58, 0x49, 0xa9, 0xa4, 0xeb, 0xeb, 0xd5, 0x30, 0x59, 0x22, 0x33, 0xd5,
-	0xf1, 0x95, 0x6a, 0x93, 0x03, 0x1a, 0xea, 0xc3, 0xc7, 0x1b, 0x0b, 0xba, 0x40, 0x97, 0x87, 0x90,
-	0x68, 0x0c, 0x68, 0xf7, 0xce, 0xce, 0x56, 0x18, 0x89, 0x12, 0x45, 0xa2, 0xed, 0xc1, 0x8a, 0x6b,
-	0x7a, 0xcc, 0xf0, 0x09, 0x33, 0xfa, 0xf8, 0x19, 0x78, 0x6a, 0xba, 0x5e, 0xaa, 0xce, 0x14, 0xa3,
-	0x3a, 0xf5, 0xb9, 0xf2, 0x98, 0xac, 0x3b, 0x29, 0x2c, 0xff, 0xa9, 0x42, 0x5c, 0xa6, 0xe3, 0x13,
-	0x48, 0xc8, 0x84, 0xe3, 0x89, 0xe9, 0x7a, 0x31, 0x72, 0x29, 0x15, 0x3c, 0xe6, 0x4d, 0xea, 0xf8,
-	0xc4, 0xf1, 0x47, 0xbe, 0x74, 0x18, 0x18, 0x69, 0x6f, 0x40, 0xb2, 0xdb, 0x37, 0x6d, 0xc7, 0xb0,
-	0x2d, 0x8c, 0x29, 0xd5, 0x48, 0x1f, 0x3f, 0xde, 0x48, 0x6c, 0x72, 0xd9, 0xce, 0x96, 0x9e, 0x40,
-	0xe5, 0x8e, 0xa5, 0xbd, 0x0c, 0xf1, 0x3e, 0xb1, 0x7b, 0x7d, 0x86, 0x99, 0x89, 0xe9, 0x72, 0xa7,
-	0x7d, 0x08, 0x2a, 0x2f, 0x59, 0x5e, 0xc5, 0xc3, 0x0b, 0x55, 0x51, 0xcf, 0x6a, 0x50, 0xcf, 0x6a,
-	0x3b, 0xa8, 0x67, 0x23, 0xc9, 0x0f, 0xbe, 0xff, 0xfb, 0x86, 0xa2, 0xa3, 0x85, 0xb6, 0x05, 0xd9,
-	0x81, 0xe9, 0x33, 0xa3, 0xc3, 0x13, 0xc7, 0x8f, 0x5f, 0x92, 0x2e, 0x66, 0x53, 0x22, 0x73, 0x2b,
-	0x63, 0x4f, 0x73, 0x33, 0x21, 0xb2, 0xb4, 0x0a, 0xe4, 0xd0, 0x4b, 0x97, 0x0e, 0x87, 0x36, 0x33,
-	0x30, 0xf5, 0x71, 0x4c, 0xfd, 0x32, 0x97, 0x6f, 0xa2, 0xf8, 0x06, 0x2f, 0xc2, 0x79, 0x48, 0x59,
-	0x26, 0x33, 0x05, 0x24, 0x81, 0x90, 0x24, 0x17, 0xa0, 0xf2, 0x4d, 0x58, 0x09, 0x19, 0xed, 0x0b,
-	0x48, 0x52, 0x78, 0x89, 0xc4, 0x08, 0xbc, 0x0c, 0x6b, 0x0e, 0x39, 0x64, 0xc6, 0x69, 0x74, 0x0a,
-	0xd1, 0x1a, 0xd7, 0xdd, 0x9e, 0xb6, 0x78, 0x1d, 0x96, 0xbb, 0x41, 0xf6, 0x05, 0x16, 0x10, 0x9b,
-	0x0d, 0xa5, 0x08, 0x5b, 0x87, 0xa4, 0xe9, 0xba, 0x02, 0x90, 0x46, 0x40, 0xc2, 0x74, 0x5d, 0x54,
-	0x5d, 0x82, 0x55, 0xfc, 0x46, 0x8f, 0xf8, 0xa3, 0x01, 0x93, 0x4e, 0x32, 0x88, 0x59, 0xe1, 0x0a,
-	0x5d, 0xc8, 0x11, 0xfb, 0x2a, 0x64, 0xc9, 0xd8, 0xb6, 0x88, 0xd3, 0x25, 0x02, 0x97, 0x45, 0x5c,
-	0x26, 0x10, 0x22, 0xe8, 0x2d, 0xc8, 0xb9, 0x1e, 0x75, 0xa9, 0x4f, 0x3c, 0xc3, 0xb4, 0x2c, 0x8f,
-	0xf8, 0x7e, 0x7e, 0x59, 0xf8, 0x0b, 0xe4, 0x57, 0x85, 0xb8, 0x9c, 0x07, 0x75, 0xcb, 0x64, 0xa6,
-	0x96, 0x83, 0x18, 0x3b, 0xf4, 0xf3, 0x4a, 0x29, 0x56, 0xc9, 0xe8, 0x7c, 0x59, 0xfe, 0x4e, 0x05,
-	0xf5, 0x36, 0x65, 0x44, 0x7b, 0x0f, 0x54, 0x5e, 0x29, 0xe4, 0xdf, 0xf2, 0x5c, 0x4a, 0xb7, 0xec,
-	0x9e, 0x43, 0xac, 0x5d, 0xbf, 0xd7, 0x3e, 0x72, 0x89, 0x8e, 0xe8, 0x09, 0x42, 0x2d, 0x4e, 0x11,
-	0x6a, 0x0d, 0x96, 0x3c, 0x3a, 0x72, 0x2c, 0xe4, 0xd9, 0x92, 0x2e, 0x36, 0xda, 0x35, 0x48, 0x86,
-	0x3c, 0x51, 0x9f, 0xc8, 0x93, 0x15, 0xce, 0x13, 0x4e, 0x63, 0x29, 0xd0, 0x13, 0x1d, 0x49, 0x97,
-	0x06, 0xa4, 0xc2, 0x0e, 0x13, 0x12, 0xee, 0x69, 0x38, 0x1b, 0x99, 0x69, 0x6f, 0xc3, 0x6a, 0x58,
-	0xfd, 0x30, 0x7d, 0x82, 0x73, 0xb9, 0x50, 0x21, 0xf3, 0x37, 0x45, 0x2c, 0x43, 0xb4, 0xa1, 0x04,
-	0x7e, 0x58, 0x44, 0xac, 0x1d, 0xec, 0x47, 0x17, 0x20, 0xe5, 0xdb, 0x3d, 0xc7, 0x64, 0x23, 0x8f,
-	0x48, 0xee, 0x45, 0x02, 0xae, 0x25, 0x87, 0x8c, 0x38, 0x78, 0xd1, 0x05, 0xd7, 0x22, 0x81, 0x56,
-	0x83, 0x97, 0xc2, 0x8d, 0x11, 0x79, 0x11, 0x3c, 0xd3, 0x42, 0x55, 0x2b, 0x74, 0x57, 0x81, 0x9c,
-	0x43, 0x1d, 0xc3, 0x73, 0x8d, 0xc8, 0xab, 0x20, 0xdd, 0xb2, 0x43, 0x1d, 0xdd, 0xdd, 0x0e, 0x5d,
-	0x7f, 0x0c, 0x85, 0xd3, 0xc8, 0x89, 0x13, 0x04, 0x09, 0xcf, 0x4d, 0xdb, 0x84, 0xc7, 0x94, 0x7f,
-	0x56, 0x20, 0x2e, 0x6e, 0xe0, 0x44, 0xb9, 0x95, 0xf9, 0xe5, 0x5e, 0x3c, 0xab, 0xdc, 0xb1, 0xe7,
-	0x2a, 0x37, 0x84, 0xc1, 0xfa, 0x79, 0xb5, 0x14, 0xab, 0xa4, 0xeb, 0x17, 0xe6, 0x78, 0x12, 0x41,
-	0xb6, 0xec, 0x9e, 0x6c, 0x31, 0x13, 0x56, 0xe5, 0xc7, 0x0a, 0xa4, 0x42, 0xbd, 0xd6, 0x80, 0x6c,
-	0x10, 0x99, 0x71, 0x30, 0x30, 0x7b, 0x92, 0xf5, 0xc5, 0xb3, 0xc3, 0xbb, 0x36, 0x30, 0x7b, 0x7a,
-	0x5a, 0x46, 0xc4, 0x37, 0xf3, 0x09, 0xb4, 0x78, 0x06, 0x81, 0xa6, 0x18, 0x1b, 0x7b, 0x36, 0xc6,
-	0x4e, 0x71, 0x4b, 0x3d, 0xc5, 0xad, 0xf2, 0x89, 0x02, 0xcb, 0x58, 0x3c, 0x8b, 0x58, 0xff, 0x69,
-	0xb5, 0xbe, 0x94, 0x34, 0xb6, 0x88, 0x65, 0xcc, 0x94, 0xed, 0xb5, 0x39, 0x2e, 0xa7, 0xa3, 0x8e,
-	0xca, 0xa7, 0x05, 0x6e, 0x5a, 0x51, 0x19, 0xbf, 0x8d, 0xc1, 0xea, 0x0c, 0xfe, 0x7f, 0x58, 0xce,
-	0xe9, 0x56, 0xb1, 0xf4, 0x94, 0xad, 0x22, 0xfe, 0x8f, 0x5a, 0x45, 0xe2, 0x19, 0x5a, 0x45, 0xf2,
-	0xef, 0x5b, 0xc5, 0x4f, 0x8b, 0x90, 0x6c, 0xe2, 0xbf, 0xc7, 0x1c, 0xfc, 0x2b, 0x7f, 0x94, 0xf3,
-	0x90, 0x72, 0xe9, 0xc0, 0x10, 0x1a, 0x15, 0x35, 0x49, 0x97, 0x0e, 0xf4, 0x19, 0x46, 0x2f, 0xbd,
-	0xa8, 0xdf, 0x4d, 0xfc, 0x05, 0x54, 0x3b, 0x71, 0xfa, 0xf2, 0x32, 0xc8, 0x88, 0x5c, 0xc8, 0x79,
-	0xf0, 0x0a, 0x4f, 0x02, 0x4e, 0x98, 0xca, 0xe9, 0x09, 0x36, 0x8c, 0x5b, 0x40, 0x75, 0x09, 0xe4,
-	0x26, 0x62, 0x7a, 0x92, 0x43, 0xe9, 0xfa, 0x99, 0x0d, 0x52, 0x97, 0xc0, 0xf2, 0x37, 0x0a, 0xc0,
-	0x4d, 0x9e, 0x5c, 0xfc, 0x62, 0x3e, 0xca, 0xf9, 0x18, 0x84, 0x31, 0x75, 0xf6, 0xc6, 0x99, 0x85,
-	0x93, 0x11, 0x64, 0xfc, 0xc9, 0xd0, 0xb7, 0x20, 0x1b, 0xdd, 0x23, 0x9f, 0x04, 0xe1, 0xcc, 0xf3,
-	0x12, 0x8e, 0x58, 0x2d, 0xc2, 0xf4, 0xcc, 0x78, 0x62, 0x57, 0xfe, 0x45, 0x81, 0x14, 0x46, 0xb5,
-	0x4b, 0x98, 0x39, 0x55, 0x48, 0xe5, 0x39, 0x0a, 0x79, 0x11, 0x40, 0xf8, 0xf1, 0xed, 0xbb, 0x44,
-	0xf2, 0x2b, 0x85, 0x92, 0x96, 0x7d, 0x97, 0x68, 0x1f, 0x84, 0x59, 0x8f, 0x3d, 0x21, 0xeb, 0xb2,
-	0x43, 0x05, 0xb9, 0x3f, 0x07, 0x09, 0x67, 0x34, 0x34, 0xf8, 0x68, 0xa5, 0x0a, 0xd2, 0x3a, 0xa3,
-	0x61, 0xfb, 0xd0, 0x2f, 0xdf, 0x81, 0x44, 0xfb, 0x10, 0x5f, 0x1a, 0x9c, 0xa9, 0x1e, 0xa5, 0x72,
-	0xb6, 0x15, 0xcf, 0x8a, 0x24, 0x17, 0xe0, 0x28, 0xa7, 0x81, 0xca, 0x87, 0xd8, 0xe0, 0xe1, 0xc3,
-	0xd7, 0x5a, 0xed, 0x69, 0x1f, 0x31, 0xf2, 0xf9, 0x72, 0xe9, 0x57, 0x05, 0xb2, 0x53, 0x37, 0x4a,
-	0x7b, 0x07, 0xce, 0xb5, 0x76, 0xae, 0xef, 0x6d, 0x6f, 0x19, 0xbb, 0xad, 0xeb, 0x46, 0xfb, 0xf3,
-	0xe6, 0xb6, 0x71, 0x6b, 0xef, 0xd3, 0xbd, 0xfd, 0xcf, 0xf6, 0x72, 0x0b, 0x85, 0x95, 0x7b, 0x0f,
-	0x4a, 0xe9, 0x5b, 0xce, 0x1d, 0x87, 0x7e, 0xe5, 0x9c, 0x85, 0x6e, 0xea, 0xdb, 0xb7, 0xf7, 0xdb,
-	0xdb, 0x39, 0x45, 0xa0, 0x9b, 0x1e, 0x19, 0x53, 0x46, 0x10, 0x7d, 0x19, 0xd6, 0xe7, 0xa0, 0x37,
-	0xf7, 0x77, 0x77, 0x77, 0xda, 0xb9, 0xc5, 0xc2, 0xea, 0xbd, 0x07, 0xa5, 0x6c, 0xd3, 0x23, 0x82,
-	0x6a, 0x68, 0x51, 0x85, 0xfc, 0xac, 0xc5, 0x7e, 0x73, 0xbf, 0x75, 0xf5, 0x66, 0xae, 0x54, 0xc8,
-	0xdd, 0x7b, 0x50, 0xca, 0x04, 0xbd, 0x83, 0xe3, 0x0b, 0xc9, 0xaf, 0xbf, 0x2f, 0x2e, 0xfc, 0xf8,
-	0x43, 0x51, 0x69, 0xdc, 0x7c, 0x78, 0x5c, 0x54, 0x1e, 0x1d, 0x17, 0x95, 0x3f, 0x8e, 0x8b, 0xca,
-	0xfd, 0x93, 0xe2, 0xc2, 0xa3, 0x93, 0xe2, 0xc2, 0x6f, 0x27, 0xc5, 0x85, 0x2f, 0xea, 0x3d, 0x9b,
-	0xf5, 0x47, 0x1d, 0x9e, 0x9b, 0x5a, 0xf4, 0xfc, 0x0d, 0x16, 0xa6, 0x6b, 0xd7, 0x66, 0x1e, 0xbd,
-	0x9d, 0x38, 0xde, 0xd9, 0x77, 0xff, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x67, 0x89, 0x32, 0x06, 0x62,
-	0x0f, 0x00, 0x00,
+	// 1383 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0x4d, 0x6f, 0x1b, 0x55,
+	0x17, 0xce, 0xd8, 0xe3, 0xaf, 0x63, 0x3b, 0x71, 0x6e, 0xa3, 0xb7, 0xae, 0xdb, 0x3a, 0x7e, 0xfd,
+	0xbe, 0x80, 0x29, 0xc8, 0x6e, 0x0c, 0x08, 0x10, 0x12, 0x52, 0x9d, 0xa4, 0x6d, 0x44, 0x93, 0x58,
+	0x63, 0xb7, 0x08, 0x58, 0x8c, 0xc6, 0x9e, 0x9b, 0xf1, 0xa8, 0xf6, 0xdc, 0xd1, 0xcc, 0xb5, 0x49,
+	0xfa, 0x0b, 0x50, 0x57, 0x5d, 0xb2, 0xa0, 0x12, 0x12, 0x2c, 0xf8, 0x03, 0xfc, 0x03, 0x16, 0x5d,
+	0x76, 0x07, 0xab, 0x82, 0x92, 0x0d, 0x7f, 0x80, 0x3d, 0xba, 0x1f, 0x33, 0x63, 0xc7, 0x0e, 0xfd,
+	0x14, 0x48, 0xec, 0xee, 0x3d, 0xe7, 0x39, 0xe7, 0x9e, 0x7b, 0x9e, 0xe7, 0xde, 0xb9, 0x03, 0x97,
+	0xfb, 0x64, 0x84, 0x69, 0xef, 0x80, 0x36, 0xe8, 0x91, 0x8b, 0xfd, 0xc6, 0xa4, 0x29, 0x06, 0x75,
+	0xd7, 0x23, 0x94, 0xa0, 0xd5, 0xc0, 0x5d, 0x17, 0xd6, 0x49, 0xb3, 0x54, 0x0e, 0x23, 0xfa, 0xde,
+	0x91, 0x4b, 0x49, 0x63, 0xb2, 0xd1, 0x70, 0x3d, 0x42, 0x0e, 0x44, 0x48, 0xe9, 0xbf, 0xf3, 0x19,
+	0x27, 0xc6, 0xd0, 0x36, 0x0d, 0x4a, 0x3c, 0x09, 0x59, 0x0f, 0x21, 0x13, 0xec, 0xf9, 0x36, 0x71,
+	0x58, 0x8e, 0xa9, 0x65, 0x4b, 0x6b, 0x16, 0xb1, 0x08, 0x1f, 0x36, 0xd8, 0x28, 0x08, 0xb3, 0x08,
+	0xb1, 0x86, 0xb8, 0xc1, 0x67, 0xbd, 0xf1, 0x41, 0x83, 0xda, 0x23, 0xec, 0x53, 0x63, 0xe4, 0x0a,
+	0x40, 0xf5, 0x43, 0xc8, 0xb7, 0x0d, 0x8f, 0x76, 0x30, 0xbd, 0x89, 0x0d, 0x13, 0x7b, 0x68, 0x0d,
+	0x12, 0x94, 0x50, 0x63, 0x58, 0x54, 0x2a, 0x4a, 0x2d, 0xaf, 0x89, 0x09, 0x42, 0xa0, 0x0e, 0x0c,
+	0x7f, 0x50, 0x8c, 0x55, 0x94, 0x5a, 0x4e, 0xe3, 0xe3, 0xaa, 0x0d, 0x2a, 0x0b, 0x65, 0x11, 0xb6,
+	0x63, 0xe2, 0xc3, 0x20, 0x82, 0x4f, 0x98, 0xb5, 0x77, 0x44, 0xb1, 0x2f, 0x43, 0xc4, 0x04, 0xbd,
+	0x07, 0x09, 0xbe, 0xf1, 0x62, 0xbc, 0xa2, 0xd4, 0xb2, 0xcd, 0x0b, 0xf5, 0xb0, 0x59, 0xa2, 0x33,
+	0xf5, 0xc9, 0x46, 0xbd, 0xcd, 0x00, 0x2d, 0xf5, 0xd1, 0x93, 0xf5, 0x25, 0x4d, 0xa0, 0xab, 0x23,
+	0x48, 0xb5, 0x86, 0xa4, 0x7f, 0x77, 0x67, 0x2b, 0xac, 0x44, 0x89, 0x2a, 0x41, 0x7b, 0xb0, 0xe2,
+	0x1a, 0x1e, 0xd5, 0x7d, 0x4c, 0xf5, 0x01, 0xdf, 0x06, 0x5f, 0x35, 0xdb, 0xac, 0xd4, 0xe7, 0xc8,
+	0xa8, 0xcf, 0x6c, 0x57, 0x2e, 0x93, 0x77, 0xa7, 0x8d, 0xd5, 0xdf, 0x55, 0x48, 0xca, 0x76, 0x7c,
+	0x0c, 0x29, 0xd9, 0x70, 0xbe, 0x62, 0xb6, 0x59, 0x8e, 0x52, 0x4a, 0x07, 0xab, 0x79, 0x93, 0x38,
+	0x3e, 0x76, 0xfc, 0xb1, 0x2f, 0x13, 0x06, 0x41, 0xe8, 0x75, 0x48, 0xf7, 0x07, 0x86, 0xed, 0xe8,
+	0xb6, 0xc9, 0x6b, 0xca, 0xb4, 0xb2, 0xc7, 0x4f, 0xd6, 0x53, 0x9b, 0xcc, 0xb6, 0xb3, 0xa5, 0xa5,
+	0xb8, 0x73, 0xc7, 0x44, 0xff, 0x81, 0xe4, 0x00, 0xdb, 0xd6, 0x80, 0xf2, 0xce, 0xc4, 0x35, 0x39,
+	0x43, 0x1f, 0x80, 0xca, 0x28, 0x2b, 0xaa, 0x7c, 0xf1, 0x52, 0x5d, 0xf0, 0x59, 0x0f, 0xf8, 0xac,
+	0x77, 0x03, 0x3e, 0x5b, 0x69, 0xb6, 0xf0, 0x83, 0x5f, 0xd7, 0x15, 0x8d, 0x47, 0xa0, 0x2d, 0xc8,
+	0x0f, 0x0d, 0x9f, 0xea, 0x3d, 0xd6, 0x38, 0xb6, 0x7c, 0x42, 0xa6, 0x98, 0x6f, 0x89, 0xec, 0xad,
+	0xac, 0x3d, 0xcb, 0xc2, 0x84, 0xc9, 0x44, 0x35, 0x28, 0xf0, 0x2c, 0x7d, 0x32, 0x1a, 0xd9, 0x54,
+	0xe7, 0xad, 0x4f, 0xf2, 0xd6, 0x2f, 0x33, 0xfb, 0x26, 0x37, 0xdf, 0x64, 0x24, 0x5c, 0x84, 0x8c,
+	0x69, 0x50, 0x43, 0x40, 0x52, 0x1c, 0x92, 0x66, 0x06, 0xee, 0x7c, 0x03, 0x56, 0x42, 0x45, 0xfb,
+	0x02, 0x92, 0x16, 0x59, 0x22, 0x33, 0x07, 0x5e, 0x85, 0x35, 0x07, 0x1f, 0x52, 0xfd, 0x34, 0x3a,
+	0xc3, 0xd1, 0x88, 0xf9, 0xee, 0xcc, 0x46, 0xbc, 0x06, 0xcb, 0xfd, 0xa0, 0xfb, 0x02, 0x0b, 0x1c,
+	0x9b, 0x0f, 0xad, 0x1c, 0x76, 0x01, 0xd2, 0x86, 0xeb, 0x0a, 0x40, 0x96, 0x03, 0x52, 0x86, 0xeb,
+	0x72, 0xd7, 0x15, 0x58, 0xe5, 0x7b, 0xf4, 0xb0, 0x3f, 0x1e, 0x52, 0x99, 0x24, 0xc7, 0x31, 0x2b,
+	0xcc, 0xa1, 0x09, 0x3b, 0xc7, 0xfe, 0x0f, 0xf2, 0x78, 0x62, 0x9b, 0xd8, 0xe9, 0x63, 0x81, 0xcb,
+	0x73, 0x5c, 0x2e, 0x30, 0x72, 0xd0, 0x9b, 0x50, 0x70, 0x3d, 0xe2, 0x12, 0x1f, 0x7b, 0xba, 0x61,
+	0x9a, 0x1e, 0xf6, 0xfd, 0xe2, 0xb2, 0xc8, 0x17, 0xd8, 0xaf, 0x09, 0x73, 0xb5, 0x08, 0xea, 0x96,
+	0x41, 0x0d, 0x54, 0x80, 0x38, 0x3d, 0xf4, 0x8b, 0x4a, 0x25, 0x5e, 0xcb, 0x69, 0x6c, 0x58, 0xfd,
+	0x56, 0x05, 0xf5, 0x0e, 0xa1, 0x18, 0xbd, 0x0b, 0x2a, 0x63, 0x8a, 0xeb, 0x6f, 0x79, 0xa1, 0xa4,
+	0x3b, 0xb6, 0xe5, 0x60, 0x73, 0xd7, 0xb7, 0xba, 0x47, 0x2e, 0xd6, 0x38, 0x7a, 0x4a, 0x50, 0xb1,
+	0x19, 0x41, 0xad, 0x41, 0xc2, 0x23, 0x63, 0xc7, 0xe4, 0x3a, 0x4b, 0x68, 0x62, 0x82, 0xae, 0x43,
+	0x3a, 0xd4, 0x89, 0xfa, 0x54, 0x9d, 0xac, 0x30, 0x9d, 0x30, 0x19, 0x4b, 0x83, 0x96, 0xea, 0x49,
+	0xb9, 0xb4, 0x20, 0x13, 0xde, 0x30, 0xc5, 0xc4, 0x73, 0x68, 0x36, 0x0a, 0x43, 0x6f, 0xc1, 0x6a,
+	0xc8, 0x7e, 0xd8, 0x3e, 0xa1, 0xb9, 0x42, 0xe8, 0x90, 0xfd, 0x9b, 0x11, 0x96, 0x2e, 0xae, 0xa1,
+	0x14, 0xdf, 0x58, 0x24, 0xac, 0x1d, 0x66, 0x45, 0x97, 0x20, 0xe3, 0xdb, 0x96, 0x63, 0xd0, 0xb1,
+	0x87, 0xa5, 0xf6, 0x22, 0x03, 0xf3, 0xe2, 0x43, 0x8a, 0x1d, 0x7e, 0xd0, 0x85, 0xd6, 0x22, 0x03,
+	0x6a, 0xc0, 0xb9, 0x70, 0xa2, 0x47, 0x59, 0x84, 0xce, 0x50, 0xe8, 0xea, 0x84, 0xe9, 0x6a, 0x50,
+	0x70, 0x88, 0xa3, 0x7b, 0xae, 0x1e, 0x65, 0x15, 0xa2, 0x5b, 0x76, 0x88, 0xa3, 0xb9, 0xdb, 0x61,
+	0xea, 0x8f, 0xa0, 0x74, 0x1a, 0x39, 0xb5, 0x82, 0x10, 0xe1, 0xf9, 0xd9, 0x98, 0x70, 0x99, 0xea,
+	0x1f, 0x0a, 0x24, 0xc5, 0x09, 0x9c, 0xa2, 0x5b, 0x59, 0x4c, 0x77, 0xec, 0x2c, 0xba, 0xe3, 0x2f,
+	0x45, 0x37, 0x84, 0xc5, 0xfa, 0x45, 0xb5, 0x12, 0xaf, 0x65, 0x9b, 0x97, 0x16, 0x64, 0x12, 0x45,
+	0x76, 0x6c, 0x4b, 0x5e, 0x31, 0x53, 0x51, 0x68, 0x03, 0xd6, 0x0c, 0xcb, 0xf2, 0xb0, 0x65, 0x50,
+	0x6c, 0x4e, 0xed, 0x3d, 0xc1, 0xf7, 0x7e, 0x2e, 0xf2, 0x45, 0xfb, 0x7e, 0xa2, 0x40, 0x26, 0x4c,
+	0x89, 0x5a, 0x90, 0x0f, 0x36, 0xa3, 0x1f, 0x0c, 0x0d, 0x4b, 0x1e, 0x94, 0xf2, 0xd9, 0x3b, 0xba,
+	0x3e, 0x34, 0x2c, 0x2d, 0x2b, 0x37, 0xc1, 0x26, 0x8b, 0x35, 0x17, 0x3b, 0x43, 0x73, 0x33, 0x22,
+	0x8f, 0xbf, 0x98, 0xc8, 0x67, 0xe4, 0xa8, 0x9e, 0x92, 0x63, 0xf5, 0x44, 0x81, 0x65, 0xce, 0xb7,
+	0x89, 0xcd, 0x7f, 0x94, 0xe0, 0x2f, 0xa4, 0xf2, 0xcd, 0x69, 0x6a, 0x02, 0xa6, 0xff, 0xbf, 0x20,
+	0xe5, 0x6c, 0xd5, 0x11, 0xe3, 0x28, 0x48, 0x13, 0xb2, 0xe8, 0x57, 0xbf, 0x89, 0xc3, 0xea, 0x1c,
+	0xfe, 0x5f, 0x48, 0xe7, 0xec, 0xed, 0x92, 0x78, 0xc6, 0xdb, 0x25, 0xf9, 0x5c, 0xb7, 0x4b, 0xea,
+	0x05, 0x6e, 0x97, 0xf4, 0x5f, 0xdf, 0x2e, 0x3f, 0xc6, 0x20, 0xdd, 0xe6, 0x9f, 0x2b, 0x63, 0xf8,
+	0xb7, 0x7c, 0x84, 0x2e, 0x42, 0xc6, 0x25, 0x43, 0x5d, 0x78, 0x54, 0xee, 0x49, 0xbb, 0x64, 0xa8,
+	0xcd, 0x29, 0x3a, 0xf1, 0xaa, 0xbe, 0x50, 0xc9, 0x57, 0xc0, 0x76, 0xea, 0xf4, 0xe1, 0xa5, 0x90,
+	0x13, 0xbd, 0x90, 0x4f, 0xc8, 0x0d, 0xd6, 0x04, 0x36, 0x2a, 0x2a, 0xa7, 0x1f, 0xbd, 0x61, 0xdd,
+	0x02, 0xaa, 0x25, 0x07, 0x61, 0x88, 0x78, 0x70, 0x15, 0x63, 0x67, 0x86, 0x88, 0x13, 0xa3, 0x49,
+	0x60, 0xf5, 0x6b, 0x05, 0xe0, 0x16, 0x6b, 0x2e, 0xdf, 0x31, 0x7b, 0xfd, 0xf9, 0xbc, 0x08, 0x7d,
+	0x66, 0xed, 0xf5, 0x33, 0x89, 0x93, 0x15, 0xe4, 0xfc, 0xe9, 0xd2, 0xb7, 0x20, 0x1f, 0x9d, 0x23,
+	0x1f, 0x07, 0xe5, 0x2c, 0xca, 0x12, 0xbe, 0xca, 0x3a, 0x98, 0x6a, 0xb9, 0xc9, 0xd4, 0xac, 0xfa,
+	0x93, 0x02, 0x19, 0x5e, 0xd5, 0x2e, 0xa6, 0xc6, 0x0c, 0x91, 0xca, 0x4b, 0x10, 0x79, 0x19, 0x40,
+	0xe4, 0xf1, 0xed, 0x7b, 0x58, 0xea, 0x2b, 0xc3, 0x2d, 0x1d, 0xfb, 0x1e, 0x46, 0xef, 0x87, 0x5d,
+	0x8f, 0x3f, 0xa5, 0xeb, 0xf2, 0x86, 0x0a, 0x7a, 0x7f, 0x1e, 0x52, 0xce, 0x78, 0xa4, 0xb3, 0xd7,
+	0x98, 0x2a, 0x44, 0xeb, 0x8c, 0x47, 0xdd, 0x43, 0xbf, 0x7a, 0x17, 0x52, 0xdd, 0x43, 0xfe, 0x73,
+	0xc2, 0x94, 0xea, 0x11, 0x22, 0x9f, 0xc3, 0xe2, 0x4f, 0x24, 0xcd, 0x0c, 0xfc, 0xf5, 0x87, 0x40,
+	0x65, 0xef, 0xde, 0xe0, 0x5f, 0x89, 0x8d, 0x51, 0xe3, 0x59, 0xff, 0x7b, 0xe4, 0x1f, 0xcf, 0x95,
+	0x9f, 0x15, 0xc8, 0xcf, 0x9c, 0x28, 0xf4, 0x36, 0x9c, 0xef, 0xec, 0xdc, 0xd8, 0xdb, 0xde, 0xd2,
+	0x77, 0x3b, 0x37, 0xf4, 0xee, 0x67, 0xed, 0x6d, 0xfd, 0xf6, 0xde, 0x27, 0x7b, 0xfb, 0x9f, 0xee,
+	0x15, 0x96, 0x4a, 0x2b, 0xf7, 0x1f, 0x56, 0xb2, 0xb7, 0x9d, 0xbb, 0x0e, 0xf9, 0xd2, 0x39, 0x0b,
+	0xdd, 0xd6, 0xb6, 0xef, 0xec, 0x77, 0xb7, 0x0b, 0x8a, 0x40, 0xb7, 0x3d, 0x3c, 0x21, 0x14, 0x73,
+	0xf4, 0x55, 0xb8, 0xb0, 0x00, 0xbd, 0xb9, 0xbf, 0xbb, 0xbb, 0xd3, 0x2d, 0xc4, 0x4a, 0xab, 0xf7,
+	0x1f, 0x56, 0xf2, 0x6d, 0x0f, 0x0b, 0xa9, 0xf1, 0x88, 0x3a, 0x14, 0xe7, 0x23, 0xf6, 0xdb, 0xfb,
+	0x9d, 0x6b, 0xb7, 0x0a, 0x95, 0x52, 0xe1, 0xfe, 0xc3, 0x4a, 0x2e, 0xb8, 0x3b, 0x18, 0xbe, 0x94,
+	0xfe, 0xea, 0xbb, 0xf2, 0xd2, 0x0f, 0xdf, 0x97, 0x95, 0xd6, 0xad, 0x47, 0xc7, 0x65, 0xe5, 0xf1,
+	0x71, 0x59, 0xf9, 0xed, 0xb8, 0xac, 0x3c, 0x38, 0x29, 0x2f, 0x3d, 0x3e, 0x29, 0x2f, 0xfd, 0x72,
+	0x52, 0x5e, 0xfa, 0xbc, 0x69, 0xd9, 0x74, 0x30, 0xee, 0xb1, 0xde, 0x34, 0xa2, 0x3f, 0xe6, 0x60,
+	0x60, 0xb8, 0x76, 0x63, 0xee, 0x3f, 0xb9, 0x97, 0xe4, 0x67, 0xf6, 0x9d, 0x3f, 0x07, 0x00, 0x92,
+	0x74, 0x6c, 0xd8, 0x95, 0x0f, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1740,6 +1752,13 @@ func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AggregatedSignature) > 0 {
+		i -= len(m.AggregatedSignature)
+		copy(dAtA[i:], m.AggregatedSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AggregatedSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Signatures) > 0 {
 		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2428,6 +2447,10 @@ func (m *Commit) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.AggregatedSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -4097,6 +4120,40 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatedSignature = append(m.AggregatedSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatedSignature == nil {
+				m.AggregatedSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
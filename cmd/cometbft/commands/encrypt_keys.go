@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/v2/crypto/armor"
+	cmtos "github.com/cometbft/cometbft/v2/internal/os"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+// EncryptKeysCmd encrypts the validator and node key files in place.
+var EncryptKeysCmd = &cobra.Command{
+	Use:     "encrypt-keys",
+	Aliases: []string{"encrypt_keys"},
+	Short:   "Encrypt the validator and node key files in place with a passphrase",
+	Long: `Encrypt the validator and node key files in place with a passphrase.
+
+The passphrase is read from the CMT_KEY_PASSPHRASE environment variable, the
+file descriptor given with --key-passphrase-fd, or else prompted for. The node
+then needs the same passphrase to start. Key files which are already encrypted
+are left as is.`,
+	RunE: encryptKeys,
+}
+
+func encryptKeys(*cobra.Command, []string) error {
+	// Only prompt for a passphrase if there is a key file to encrypt.
+	getPassphrase := sync.OnceValues(newKeyPassphrase)
+
+	if err := encryptKeyFile(config.PrivValidatorKeyFile(), func(keyFile string) error {
+		pvKey, err := privval.LoadFilePVKey(keyFile)
+		if err != nil {
+			return err
+		}
+		passphrase, err := getPassphrase()
+		if err != nil {
+			return err
+		}
+		pvKey.SetPassphrase(passphrase)
+		pvKey.Save()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return encryptKeyFile(config.NodeKeyFile(), func(keyFile string) error {
+		nk, err := p2p.LoadNodeKey(keyFile)
+		if err != nil {
+			return err
+		}
+		passphrase, err := getPassphrase()
+		if err != nil {
+			return err
+		}
+		nk.SetPassphrase(passphrase)
+		return nk.SaveAs(keyFile)
+	})
+}
+
+// encryptKeyFile encrypts keyFile with encrypt, unless it does not exist or is
+// already encrypted.
+func encryptKeyFile(keyFile string, encrypt func(keyFile string) error) error {
+	if !cmtos.FileExists(keyFile) {
+		logger.Info("Key file not found", "path", keyFile)
+		return nil
+	}
+	bz, err := os.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+	if armor.IsArmored(bz) {
+		logger.Info("Key file already encrypted", "path", keyFile)
+		return nil
+	}
+	if err := encrypt(keyFile); err != nil {
+		return fmt.Errorf("encrypting %s: %w", keyFile, err)
+	}
+	logger.Info("Encrypted key file", "path", keyFile)
+	return nil
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+func TestReadKeyPassphrase(t *testing.T) {
+	t.Setenv(keyPassphraseEnv, "from env")
+	passphrase, err := readKeyPassphrase(false)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from env"), passphrase)
+
+	require.NoError(t, os.Unsetenv(keyPassphraseEnv))
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString("from fd\nignored\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	defer func(fd int) { keyPassphraseFD = fd }(keyPassphraseFD)
+	keyPassphraseFD = int(r.Fd())
+	passphrase, err = readKeyPassphrase(false)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from fd"), passphrase)
+}
+
+func TestEncryptKeys(t *testing.T) {
+	defer func(c *cfg.Config) { config = c }(config)
+	config = cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(config))
+	pvKey, err := privval.LoadFilePVKey(config.PrivValidatorKeyFile())
+	require.NoError(t, err)
+	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+
+	t.Setenv(keyPassphraseEnv, "passphrase")
+	require.NoError(t, encryptKeys(nil, nil))
+
+	for _, keyFile := range []string{config.PrivValidatorKeyFile(), config.NodeKeyFile()} {
+		bz, err := os.ReadFile(keyFile)
+		require.NoError(t, err)
+		assert.True(t, armor.IsArmored(bz), keyFile)
+	}
+
+	passphrase := func() ([]byte, error) { return []byte("passphrase"), nil }
+	pvKey2, err := privval.LoadFilePVKey(config.PrivValidatorKeyFile(), privval.WithKeyPassphrase(passphrase))
+	require.NoError(t, err)
+	assert.Equal(t, pvKey.PrivKey, pvKey2.PrivKey)
+	nodeKey2, err := p2p.LoadNodeKey(config.NodeKeyFile(), p2p.NodeKeyPassphrase(passphrase))
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.ID(), nodeKey2.ID())
+
+	// Encrypted key files are left as is.
+	t.Setenv(keyPassphraseEnv, "other")
+	require.NoError(t, encryptKeys(nil, nil))
+	_, err = privval.LoadFilePVKey(config.PrivValidatorKeyFile(), privval.WithKeyPassphrase(passphrase))
+	require.NoError(t, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtos "github.com/cometbft/cometbft/v2/internal/os"
 	"github.com/cometbft/cometbft/v2/p2p"
 )
@@ -18,14 +19,27 @@ var GenNodeKeyCmd = &cobra.Command{
 	RunE:    genNodeKey,
 }
 
+var encryptNodeKey bool
+
+func init() {
+	GenNodeKeyCmd.Flags().BoolVar(&encryptNodeKey, "encrypt", false, "encrypt the node key file with a passphrase")
+}
+
 func genNodeKey(*cobra.Command, []string) error {
 	nodeKeyFile := config.NodeKeyFile()
 	if cmtos.FileExists(nodeKeyFile) {
 		return fmt.Errorf("node key at %s already exists", nodeKeyFile)
 	}
 
-	nk, err := p2p.LoadOrGenNodeKey(nodeKeyFile)
-	if err != nil {
+	nk := &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	if encryptNodeKey {
+		passphrase, err := newKeyPassphrase()
+		if err != nil {
+			return err
+		}
+		nk.SetPassphrase(passphrase)
+	}
+	if err := nk.SaveAs(nodeKeyFile); err != nil {
 		return err
 	}
 	fmt.Println(nk.ID())
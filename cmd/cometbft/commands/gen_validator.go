@@ -21,8 +21,12 @@ var GenValidatorCmd = &cobra.Command{
 	RunE:    genValidator,
 }
 
+var encryptKey bool
+
 func init() {
 	GenValidatorCmd.Flags().StringVarP(&keyType, "key-type", "k", ed25519.KeyType, fmt.Sprintf("private key type (one of %s)", kt.SupportedKeyTypesStr()))
+	GenValidatorCmd.Flags().BoolVar(&encryptKey, "encrypt", false,
+		"print the contents of a key file encrypted with a passphrase, instead of the keypair and state as JSON")
 }
 
 func genValidator(*cobra.Command, []string) error {
@@ -30,6 +34,19 @@ func genValidator(*cobra.Command, []string) error {
 	if err != nil {
 		return fmt.Errorf("cannot generate file pv: %w", err)
 	}
+	if encryptKey {
+		passphrase, err := newKeyPassphrase()
+		if err != nil {
+			return err
+		}
+		pv.Key.SetPassphrase(passphrase)
+		bz, err := pv.Key.MarshalFile()
+		if err != nil {
+			return fmt.Errorf("failed to encrypt private validator key: %w", err)
+		}
+		fmt.Print(string(bz))
+		return nil
+	}
 	jsbz, err := cmtjson.Marshal(pv)
 	if err != nil {
 		return fmt.Errorf("failed to marshal private validator: %w", err)
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/history"
+	"github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+)
+
+var (
+	historyFrom        int64
+	historyTo          int64
+	historyFile        string
+	historyTrustedHash string
+)
+
+func init() {
+	ExportHistoryCmd.Flags().Int64Var(&historyFrom, "from", 0, "first height to export (default: the base of the block store)")
+	ExportHistoryCmd.Flags().Int64Var(&historyTo, "to", 0, "last height to export (default: the height of the block store)")
+	ExportHistoryCmd.Flags().StringVar(&historyFile, "output", "history.cmth", "path of the archive to write")
+
+	ImportHistoryCmd.Flags().StringVar(&historyFile, "input", "history.cmth", "path of the archive to read")
+	ImportHistoryCmd.Flags().StringVar(&historyTrustedHash, "trusted-hash", "", "hex-encoded hash of the first block of the archive")
+}
+
+var ExportHistoryCmd = &cobra.Command{
+	Use:   "export-history",
+	Short: "export the history of the chain to an archive",
+	Long: `
+Export the blocks, seen and extended commits, FinalizeBlock responses, validator
+sets and consensus params of a range of heights to an archive, which can be
+imported by another node with import-history, whatever its database backend.
+If the range ends at the last height of the node, the state is exported as well,
+so that a node can be started from the imported history. The FinalizeBlock
+responses of all the heights are required: history can't be exported from a
+node with discard_abci_responses enabled.
+
+The node must be stopped while exporting.
+`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		header, err := ExportHistory(config, historyFrom, historyTo, historyFile)
+		if err != nil {
+			return fmt.Errorf("failed to export history: %w", err)
+		}
+		fmt.Printf("Exported heights %d to %d of chain %s to %s\n",
+			header.FromHeight, header.ToHeight, header.ChainID, historyFile)
+		return nil
+	},
+}
+
+var ImportHistoryCmd = &cobra.Command{
+	Use:   "import-history",
+	Short: "import the history of the chain from an archive",
+	Long: `
+Import an archive written by export-history into the empty block store and state
+store of the node. Each height is verified before being saved: its commit must be
+signed by its validator set, which must match the hashes of the blocks, and its
+FinalizeBlock response must match the app hash of the next block. The
+FinalizeBlock response of the last height is only imported along with the state,
+which it's verified against. The hash of the first block of the archive must be
+given with --trusted-hash, unless the archive starts at the initial height of the
+chain: its validator set is then verified against the validators of the genesis
+file.
+
+The node must be stopped while importing. If the import fails, the databases may
+hold part of the history and must be removed before importing again.
+`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		var trustedHash []byte
+		if historyTrustedHash != "" {
+			var err error
+			if trustedHash, err = hex.DecodeString(historyTrustedHash); err != nil {
+				return fmt.Errorf("invalid trusted hash: %w", err)
+			}
+		}
+		header, err := ImportHistory(config, historyFile, trustedHash)
+		if err != nil {
+			return fmt.Errorf("failed to import history: %w", err)
+		}
+		fmt.Printf("Imported heights %d to %d of chain %s from %s\n",
+			header.FromHeight, header.ToHeight, header.ChainID, historyFile)
+		return nil
+	},
+}
+
+// ExportHistory exports the heights from to to (inclusive) of the block
+// store and state store to the archive at path. Zero heights default to the
+// base and height of the block store.
+func ExportHistory(config *cfg.Config, from, to int64, path string) (history.Header, error) {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return history.Header{}, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	if from == 0 {
+		from = blockStore.Base()
+	}
+	if to == 0 {
+		to = blockStore.Height()
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return history.Header{}, err
+	}
+	header, err := history.Export(f, blockStore, stateStore, from, to)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return history.Header{}, err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return history.Header{}, err
+	}
+	return header, f.Close()
+}
+
+// ImportHistory imports the archive at path into the empty block store and
+// state store of the node. The chain ID is read from the genesis file.
+func ImportHistory(config *cfg.Config, path string, trustedHash []byte) (history.Header, error) {
+	genDoc, err := state.MakeGenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return history.Header{}, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return history.Header{}, err
+	}
+	defer f.Close()
+
+	dbType := dbm.BackendType(config.DBBackend)
+	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	if err != nil {
+		return history.Header{}, err
+	}
+	coldStorageOpts, err := store.ColdStorageFromConfig(config.Storage.ColdStorage)
+	if err != nil {
+		return history.Header{}, err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, append([]store.BlockStoreOption{store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout)}, coldStorageOpts...)...)
+	defer blockStore.Close()
+
+	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	if err != nil {
+		return history.Header{}, err
+	}
+	stateStore := state.NewStore(stateDB, state.StoreOptions{
+		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		DBKeyLayout:          config.Storage.ExperimentalKeyLayout,
+	})
+	defer stateStore.Close()
+
+	return history.Import(f, blockStore, stateStore, genDoc.ChainID,
+		history.WithTrustedHash(trustedHash), history.WithGenesis(genDoc), history.WithLogger(logger))
+}
@@ -53,7 +53,11 @@ func runInspect(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
-	blockStore := store.NewBlockStore(blockStoreDB, store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout))
+	coldStorageOpts, err := store.ColdStorageFromConfig(config.Storage.ColdStorage)
+	if err != nil {
+		return err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, append([]store.BlockStoreOption{store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout)}, coldStorageOpts...)...)
 	defer blockStore.Close()
 
 	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
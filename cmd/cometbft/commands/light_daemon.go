@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtos "github.com/cometbft/cometbft/v2/internal/os"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/light/daemon"
+	rpcserver "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
+)
+
+// LightDaemonCmd runs a light client daemon tracking several chains.
+var LightDaemonCmd = &cobra.Command{
+	Use:   "light-daemon",
+	Short: "Run a light client daemon, tracking several chains",
+	Long: `Run a light client daemon, tracking several chains.
+
+Each chain, configured in a TOML file, has its own trust options, primary and
+witnesses. Addresses with the grpc:// scheme use the gRPC services of the node,
+others its RPC. The trusted headers and the detected light client attacks are
+persisted in the home directory, so the trusted height and hash are only needed
+the first time a chain is tracked.
+
+The verified headers are served over HTTP only, there is no gRPC API:
+
+	GET /chains                             status of the tracked chains
+	GET /chains/{chain_id}/headers/latest   latest verified header
+	GET /chains/{chain_id}/headers/{height} verified header at the height
+	GET /chains/{chain_id}/subscribe        stream of the new verified headers
+	GET /chains/{chain_id}/attacks          detected light client attacks
+`,
+	RunE: runLightDaemon,
+	Args: cobra.NoArgs,
+	Example: `light-daemon --config daemon.toml
+
+where daemon.toml contains:
+
+	laddr = "tcp://localhost:8889"
+	update_interval = "5s"
+
+	[[chain]]
+	chain_id = "cosmoshub-4"
+	primary = "http://52.57.29.196:26657"
+	witnesses = ["grpc://public-seed-node.cosmoshub.certus.one:26670"]
+	trusting_period = "168h"
+	trusted_height = 962118
+	trusted_hash = "28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD"`,
+}
+
+var (
+	lightDaemonConfig string
+	lightDaemonHome   string
+	lightDaemonLaddr  string
+	lightDaemonDebug  bool
+)
+
+func init() {
+	LightDaemonCmd.Flags().StringVar(&lightDaemonConfig, "config", "daemon.toml",
+		"path to the config file, relative to the home directory unless absolute")
+	LightDaemonCmd.Flags().StringVar(&lightDaemonHome, "home-dir",
+		os.ExpandEnv(filepath.Join("$HOME", ".cometbft-light-daemon")), "specify the home directory")
+	LightDaemonCmd.Flags().StringVar(&lightDaemonLaddr, "laddr", "",
+		"serve the API on the given address, overriding the config file")
+	LightDaemonCmd.Flags().BoolVar(&lightDaemonDebug, "verbose", false, "Verbose output")
+}
+
+func runLightDaemon(_ *cobra.Command, _ []string) error {
+	logger := log.NewLogger(os.Stdout)
+	var option log.Option
+	if lightDaemonDebug {
+		option, _ = log.AllowLevel("debug")
+	} else {
+		option, _ = log.AllowLevel("info")
+	}
+	logger = log.NewFilter(logger, option)
+
+	configPath := lightDaemonConfig
+	if !filepath.IsAbs(configPath) {
+		configPath = filepath.Join(lightDaemonHome, configPath)
+	}
+	daemonConfig, err := daemon.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	if lightDaemonLaddr != "" {
+		daemonConfig.ListenAddress = lightDaemonLaddr
+	}
+	if daemonConfig.ListenAddress == "" {
+		return errors.New("no address to serve the API on. Please set laddr in the config file or use --laddr")
+	}
+
+	db, err := dbm.NewPebbleDB("light-daemon-db", lightDaemonHome)
+	if err != nil {
+		return fmt.Errorf("can't create a db: %w", err)
+	}
+
+	d := daemon.New(daemonConfig, db, logger)
+	logger.Info("Starting light client daemon", "chains", len(daemonConfig.Chains))
+	if err := d.Start(); err != nil {
+		db.Close()
+		return err
+	}
+
+	cfg := rpcserver.DefaultConfig()
+	listener, err := rpcserver.Listen(daemonConfig.ListenAddress, cfg.MaxOpenConnections)
+	if err != nil {
+		_ = d.Stop()
+		db.Close()
+		return err
+	}
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	cmtos.TrapSignal(logger, func() {
+		listener.Close()
+		if err := d.Stop(); err != nil {
+			logger.Error("Error stopping light client daemon", "err", err)
+		}
+		if err := db.Close(); err != nil {
+			logger.Error("Error closing database", "err", err)
+		}
+	})
+
+	logger.Info("Serving verified headers", "laddr", daemonConfig.ListenAddress)
+	if err := rpcserver.Serve(listener, d.Handler(), logger, cfg); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		// Error starting or closing listener:
+		logger.Error("light daemon Serve", "err", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/internal/keylayout"
+	"github.com/cometbft/cometbft/v2/internal/os"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+)
+
+var keyLayoutVersion string
+
+func init() {
+	MigrateDBKeyLayoutCmd.Flags().StringVar(&keyLayoutVersion, "to", "v2", "key layout version to migrate to (v1 or v2)")
+}
+
+// MigrateDBKeyLayoutCmd migrates the block store and state store to another
+// key layout.
+var MigrateDBKeyLayoutCmd = &cobra.Command{
+	Use:   "migrate-db-key-layout",
+	Short: "Migrate the block store and state store to another key layout",
+	Long: `
+Migrate the block metas, parts, commits and hashes of the block store, and the
+validators, consensus params and ABCI responses of the state store, to another
+key layout. The node must be stopped.
+
+The records are copied to the new layout, their number and hashes are verified
+against the records in the old layout, then the layout version of the store is
+switched and the records in the old layout are deleted. An interrupted migration
+is resumed when running the command again.
+
+If storage.experimental_db_key_layout_migration was enabled while the node ran,
+the records are already copied, and only the ones written since the last copy
+are copied again.
+`,
+	Example: "migrate-db-key-layout --to v2",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return MigrateDBKeyLayout(cmd.Context(), config.DBDir(), dbm.BackendType(config.DBBackend), keyLayoutVersion, logger)
+	},
+}
+
+// MigrateDBKeyLayout migrates the block store and state store in dbDir to the
+// key layout with the given version.
+func MigrateDBKeyLayout(ctx context.Context, dbDir string, dbType dbm.BackendType, version string, logger log.Logger) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	stores := []struct {
+		name        string
+		newMigrator func(dbm.DB, string, log.Logger) (*keylayout.Migrator, error)
+	}{
+		{"blockstore", store.NewKeyLayoutMigrator},
+		{"state", state.NewKeyLayoutMigrator},
+	}
+	for _, s := range stores {
+		if !os.FileExists(filepath.Join(dbDir, s.name+".db")) {
+			return fmt.Errorf("no %s found in %v", s.name, dbDir)
+		}
+	}
+
+	for _, s := range stores {
+		if err := migrateDBKeyLayout(ctx, s.name, dbDir, dbType, version, s.newMigrator, logger); err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+func migrateDBKeyLayout(
+	ctx context.Context,
+	name, dbDir string,
+	dbType dbm.BackendType,
+	version string,
+	newMigrator func(dbm.DB, string, log.Logger) (*keylayout.Migrator, error),
+	logger log.Logger,
+) error {
+	db, err := dbm.NewDB(name, dbType, dbDir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(db, version, logger.With("db", name))
+	if errors.As(err, &keylayout.ErrSameLayout{}) {
+		fmt.Printf("%s already uses key layout %s\n", name, version)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	stats, err := migrator.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	records := make([]string, 0, len(stats))
+	for record := range stats {
+		records = append(records, record)
+	}
+	sort.Strings(records)
+	fmt.Printf("Migrated %s to key layout %s\n", name, version)
+	for _, record := range records {
+		fmt.Printf("  %s: %d records, digest %X\n", record, stats[record].Count, stats[record].Digest)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// keyPassphraseEnv is the environment variable the passphrase of encrypted
+// key files is read from, if set.
+const keyPassphraseEnv = "CMT_KEY_PASSPHRASE"
+
+// keyPassphraseFD is the file descriptor the passphrase of encrypted key files
+// is read from, if set and keyPassphraseEnv is not.
+var keyPassphraseFD int
+
+// keyPassphrase returns the passphrase of encrypted key files. It is only
+// read once, so that the user is prompted at most once.
+var keyPassphrase = sync.OnceValues(func() ([]byte, error) {
+	return readKeyPassphrase(false)
+})
+
+// readKeyPassphrase reads the passphrase of encrypted key files from the
+// CMT_KEY_PASSPHRASE environment variable, the file descriptor given with
+// --key-passphrase-fd, or else prompts for it, twice if confirm is true.
+func readKeyPassphrase(confirm bool) ([]byte, error) {
+	if passphrase, ok := os.LookupEnv(keyPassphraseEnv); ok {
+		return []byte(passphrase), nil
+	}
+	if keyPassphraseFD >= 0 {
+		return readPassphraseFD(keyPassphraseFD)
+	}
+	return promptPassphrase(confirm)
+}
+
+// newKeyPassphrase reads the passphrase to encrypt key files with, which must
+// not be empty.
+func newKeyPassphrase() ([]byte, error) {
+	passphrase, err := readKeyPassphrase(true)
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("the passphrase cannot be empty")
+	}
+	return passphrase, nil
+}
+
+// readPassphraseFD reads the first line of the file descriptor fd.
+func readPassphraseFD(fd int) ([]byte, error) {
+	f := os.NewFile(uintptr(fd), "passphrase")
+	if f == nil {
+		return nil, fmt.Errorf("invalid passphrase file descriptor %d", fd)
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading passphrase from file descriptor %d: %w", fd, err)
+	}
+	return bytes.TrimRight(line, "\r\n"), nil
+}
+
+func promptPassphrase(confirm bool) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("a key file passphrase is required: set %s or --key-passphrase-fd", keyPassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "Enter key passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat key passphrase: ")
+		repeated, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(passphrase, repeated) {
+			return nil, errors.New("the passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
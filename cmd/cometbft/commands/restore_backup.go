@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/v2/internal/backup"
+)
+
+var backupDir string
+
+func init() {
+	RestoreBackupCmd.Flags().StringVar(&backupDir, "input", "", "directory of the backup to restore")
+}
+
+var RestoreBackupCmd = &cobra.Command{
+	Use:   "restore-backup",
+	Short: "restore a backup of the node data taken with the unsafe_backup RPC endpoint",
+	Long: `
+Restore a backup taken by a running node, in its storage.backup_dir directory,
+with the unsafe_backup RPC endpoint: the databases, the consensus WAL and the cold storage segments are copied to the
+node, which must not have any of them, and must use the same database backend as
+the backed up node. The private validator state is only restored if it's more
+recent than the node's, so that a validator never signs twice at a height.
+
+The node must be stopped while restoring.
+`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if backupDir == "" {
+			return errors.New("the backup directory must be given with --input")
+		}
+		manifest, err := backup.Restore(backupDir, config)
+		if err != nil {
+			return fmt.Errorf("failed to restore the backup: %w", err)
+		}
+		fmt.Printf("Restored the backup of chain %s at height %d, app hash %X\n",
+			manifest.ChainID, manifest.Height, manifest.AppHash)
+		return nil
+	},
+}
@@ -3,25 +3,34 @@ package commands
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	dbm "github.com/cometbft/cometbft-db"
 	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/consensus"
 	"github.com/cometbft/cometbft/v2/internal/os"
 	"github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/state/indexer"
 	"github.com/cometbft/cometbft/v2/store"
 )
 
-var removeBlock = false
+var (
+	removeBlock    = false
+	rollbackHeight int64
+	rollbackDryRun bool
+)
 
 func init() {
 	RollbackStateCmd.Flags().BoolVar(&removeBlock, "hard", false, "remove last block as well as state")
+	RollbackStateCmd.Flags().Int64Var(&rollbackHeight, "height", 0, "height to roll back to (default: one height below the state)")
+	RollbackStateCmd.Flags().BoolVar(&rollbackDryRun, "dry-run", false, "print the rollback without applying it")
 }
 
 var RollbackStateCmd = &cobra.Command{
 	Use:   "rollback",
-	Short: "rollback CometBFT state by one height",
+	Short: "rollback CometBFT state by one height, or to a given height",
 	Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when CometBFT has persisted an incorrect app hash and is thus unable to make
@@ -30,8 +39,27 @@ The application should also roll back to height n - 1. If the --hard flag is not
 no blocks will be removed so upon restarting CometBFT the transactions in block n will be
 re-executed against the application. Using --hard will also remove block n. This can
 be done multiple times.
+
+With the --height flag, the state is rolled back to the given height h at once,
+from the stored validators, consensus params and FinalizeBlock responses. The
+blocks above h + 1 are removed, as well as block h + 1 with --hard, and so are
+the entries above them in the kv indexers and the consensus WAL. The
+application should also roll back to height h. The --dry-run flag prints what
+would be changed without changing anything.
+
+Note that the validator won't sign again until the chain is past the heights it
+last signed, since the private validator state isn't rolled back.
 `,
 	RunE: func(_ *cobra.Command, _ []string) error {
+		if rollbackHeight != 0 || rollbackDryRun {
+			res, err := RollbackStateTo(config, rollbackHeight, removeBlock, rollbackDryRun)
+			if err != nil {
+				return fmt.Errorf("failed to rollback state: %w", err)
+			}
+			printRollback(res, rollbackDryRun)
+			return nil
+		}
+
 		height, hash, err := RollbackState(config, removeBlock)
 		if err != nil {
 			return fmt.Errorf("failed to rollback state: %w", err)
@@ -65,6 +93,119 @@ func RollbackState(config *cfg.Config, removeBlock bool) (int64, []byte, error)
 	return state.Rollback(blockStore, stateStore, removeBlock)
 }
 
+// RollbackResult describes a rollback applied, or planned, by RollbackStateTo.
+type RollbackResult struct {
+	Plan state.RollbackPlan
+	// IndexedHeights is the number of heights deleted from the indexers. It's
+	// -1 if the indexers can't be rolled back, or if it's a dry run.
+	IndexedHeights int64
+	// WALBytes is the number of bytes removed from the consensus WAL.
+	WALBytes int64
+}
+
+// RollbackStateTo rolls the state and the block store back to height, then
+// deletes the entries above it from the indexers and the consensus WAL. A zero
+// height rolls back one height, like RollbackState. If dryRun is true, the
+// rollback is computed but nothing is changed.
+// Note state here refers to CometBFT state not application state.
+func RollbackStateTo(config *cfg.Config, height int64, removeBlock, dryRun bool) (RollbackResult, error) {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	current, err := stateStore.Load()
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	if height == 0 {
+		// As with Rollback, a block above the state is rolled back first.
+		height = current.LastBlockHeight
+		if blockStore.Height() == current.LastBlockHeight {
+			height--
+		}
+	}
+
+	plan, err := state.PlanRollback(blockStore, stateStore, height, removeBlock)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	res := RollbackResult{Plan: plan, IndexedHeights: -1}
+
+	if !dryRun {
+		if err := state.ExecuteRollback(blockStore, stateStore, plan); err != nil {
+			return RollbackResult{}, err
+		}
+		if res.IndexedHeights, err = rollbackIndexers(config, current.ChainID, plan.State.LastBlockHeight); err != nil {
+			return RollbackResult{}, fmt.Errorf("failed to rollback the indexers: %w", err)
+		}
+	}
+
+	// Consensus resumes after the last retained block, which is replayed on
+	// top of the state if it wasn't removed.
+	res.WALBytes, err = consensus.TrimWAL(config.Consensus.WalFile(), plan.RetainBlockHeight, dryRun)
+	if err != nil {
+		return RollbackResult{}, fmt.Errorf("failed to trim the consensus WAL: %w", err)
+	}
+	return res, nil
+}
+
+// rollbackIndexers deletes the entries above height from the indexers, and
+// returns the number of heights deleted, or -1 if they can't be rolled back.
+func rollbackIndexers(config *cfg.Config, chainID string, height int64) (int64, error) {
+	if strings.ToLower(config.TxIndex.Indexer) == "null" {
+		return 0, nil
+	}
+	blockIndexer, txIndexer, err := loadEventSinks(config, chainID)
+	if err != nil {
+		return 0, err
+	}
+	defer txIndexer.Close()
+
+	blockRollbacker, ok := blockIndexer.(indexer.Rollbacker)
+	if !ok {
+		return -1, nil
+	}
+	txRollbacker, ok := txIndexer.(indexer.Rollbacker)
+	if !ok {
+		return -1, nil
+	}
+	if _, err := txRollbacker.Rollback(height); err != nil {
+		return 0, err
+	}
+	return blockRollbacker.Rollback(height)
+}
+
+func printRollback(res RollbackResult, dryRun bool) {
+	plan := res.Plan
+	if dryRun {
+		fmt.Printf("Would roll back state from height %d to height %d and hash %X\n",
+			plan.StateHeight, plan.State.LastBlockHeight, plan.State.AppHash)
+		if plan.RetainBlockHeight < plan.BlockStoreHeight {
+			fmt.Printf("Would remove blocks %d to %d\n", plan.RetainBlockHeight+1, plan.BlockStoreHeight)
+		}
+		fmt.Printf("Would delete the indexed entries above height %d\n", plan.State.LastBlockHeight)
+		fmt.Printf("Would trim %d bytes from the consensus WAL\n", res.WALBytes)
+		return
+	}
+
+	fmt.Printf("Rolled back state from height %d to height %d and hash %X\n",
+		plan.StateHeight, plan.State.LastBlockHeight, plan.State.AppHash)
+	if plan.RetainBlockHeight < plan.BlockStoreHeight {
+		fmt.Printf("Removed blocks %d to %d\n", plan.RetainBlockHeight+1, plan.BlockStoreHeight)
+	}
+	if res.IndexedHeights < 0 {
+		fmt.Println("The indexer doesn't support rollbacks, its entries above the state height must be deleted separately")
+	} else {
+		fmt.Printf("Deleted the indexed entries of %d heights\n", res.IndexedHeights)
+	}
+	fmt.Printf("Trimmed %d bytes from the consensus WAL\n", res.WALBytes)
+}
+
 func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
 	dbType := dbm.BackendType(config.DBBackend)
 
@@ -77,7 +218,11 @@ func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store,
 	if err != nil {
 		return nil, nil, err
 	}
-	blockStore := store.NewBlockStore(blockStoreDB, store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout))
+	coldStorageOpts, err := store.ColdStorageFromConfig(config.Storage.ColdStorage)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, append([]store.BlockStoreOption{store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout)}, coldStorageOpts...)...)
 
 	if !os.FileExists(filepath.Join(config.DBDir(), "state.db")) {
 		return nil, nil, fmt.Errorf("no statestore found in %v", config.DBDir())
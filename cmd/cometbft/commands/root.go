@@ -24,6 +24,8 @@ func init() {
 
 func registerFlagsRootCmd(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("log_level", config.LogLevel, "log level")
+	cmd.PersistentFlags().IntVar(&keyPassphraseFD, "key-passphrase-fd", -1,
+		"file descriptor to read the passphrase of encrypted key files from, if "+keyPassphraseEnv+" is not set (default: prompt)")
 }
 
 func ConfigHome(cmd *cobra.Command) (string, error) {
@@ -100,6 +100,9 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 		Aliases: []string{"node", "run"},
 		Short:   "Run the CometBFT node",
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if cliParams.KeyPassphrase == nil {
+				cliParams.KeyPassphrase = keyPassphrase
+			}
 			n, err := nodeProvider(config, logger, cliParams, genPrivKeyFromFlag)
 			if err != nil {
 				return fmt.Errorf("failed to create node: %w", err)
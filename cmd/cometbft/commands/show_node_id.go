@@ -17,7 +17,7 @@ var ShowNodeIDCmd = &cobra.Command{
 }
 
 func showNodeID(*cobra.Command, []string) error {
-	nk, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	nk, err := p2p.LoadNodeKey(config.NodeKeyFile(), p2p.NodeKeyPassphrase(keyPassphrase))
 	if err != nil {
 		return err
 	}
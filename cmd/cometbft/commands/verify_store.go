@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/store"
+)
+
+var (
+	verifyFrom int64
+	verifyTo   int64
+)
+
+func init() {
+	VerifyStoreCmd.Flags().Int64Var(&verifyFrom, "from", 0, "first height to verify (default: the base of the block store)")
+	VerifyStoreCmd.Flags().Int64Var(&verifyTo, "to", 0, "last height to verify (default: the height of the block store)")
+}
+
+var VerifyStoreCmd = &cobra.Command{
+	Use:   "verify-store",
+	Short: "verify the integrity of the block store",
+	Long: `
+Verify the integrity of the block store, from its base to its height. For each
+height, the block meta and all the block parts must be present, the parts must
+match the part set header and reassemble into the block of the block meta, and
+the commits must be signed by the validators of the state store, when it still
+has them.
+
+The corrupted blocks are reported, and the command fails if there are any. They
+can be repaired by a running node, fetching them from peers, by enabling the
+repair in the [storage.integrity_check] section of the configuration.
+
+The node must be stopped while verifying.
+`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := VerifyStore(cmd.Context(), config, verifyFrom, verifyTo)
+		if err != nil {
+			return fmt.Errorf("failed to verify the block store: %w", err)
+		}
+		for _, corrupted := range res.Corrupted {
+			fmt.Printf("Height %d: %v\n", corrupted.Height, corrupted.Err)
+		}
+		if len(res.Corrupted) > 0 {
+			return fmt.Errorf("found %d corrupted blocks between heights %d and %d", len(res.Corrupted), res.From, res.To)
+		}
+		fmt.Printf("Verified heights %d to %d\n", res.From, res.To)
+		return nil
+	},
+}
+
+// VerifyStore verifies the heights from to to (inclusive) of the block store.
+// Zero heights default to the base and height of the block store.
+func VerifyStore(ctx context.Context, config *cfg.Config, from, to int64) (store.VerifyResult, error) {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return store.VerifyResult{}, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// The corrupted blocks are printed by the command instead of logged.
+	return store.NewVerifier(blockStore, stateStore, log.NewNopLogger()).Verify(ctx, from, to)
+}
@@ -18,6 +18,7 @@ func main() {
 		cmd.GenValidatorCmd,
 		cmd.InitFilesCmd,
 		cmd.LightCmd,
+		cmd.LightDaemonCmd,
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ResetStateCmd,
@@ -26,8 +27,14 @@ func main() {
 		cmd.ShowNodeIDCmd,
 		cmd.ReIndexEventCmd,
 		cmd.GenNodeKeyCmd,
+		cmd.EncryptKeysCmd,
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
+		cmd.MigrateDBKeyLayoutCmd,
+		cmd.ExportHistoryCmd,
+		cmd.ImportHistoryCmd,
+		cmd.VerifyStoreCmd,
+		cmd.RestoreBackupCmd,
 		cmd.CompactGoLevelDBCmd,
 		cmd.InspectCmd,
 		debug.DebugCmd,
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"encoding/base64"
 	"flag"
+	"net"
 	"os"
 	"time"
 
+	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtnet "github.com/cometbft/cometbft/v2/internal/net"
 	cmtos "github.com/cometbft/cometbft/v2/internal/os"
 	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/p2p"
 	"github.com/cometbft/cometbft/v2/privval"
+	privvalgrpc "github.com/cometbft/cometbft/v2/privval/grpc"
 )
 
 func main() {
@@ -18,6 +23,11 @@ func main() {
 		chainID          = flag.String("chain-id", "mychain", "chain id")
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
+		connKeyPath      = flag.String("conn-key", "", "file path of the key authenticating connections to the node, generated if missing; if empty, an ephemeral key is used over TCP and UNIX connections are not authenticated")
+		nodePubKey       = flag.String("node-pubkey", "", "base64-encoded public key of the node; connections to other nodes are rejected")
+		certFile         = flag.String("cert", "", "certificate file authenticating the signer over gRPC")
+		keyFile          = flag.String("key", "", "key file of the certificate authenticating the signer over gRPC")
+		caFile           = flag.String("ca", "", "certificate file of the CA the certificates of nodes must be signed by over gRPC")
 
 		logger = log.NewLogger(
 			os.Stdout,
@@ -35,20 +45,69 @@ func main() {
 
 	pv := privval.LoadFilePV(*privValKeyPath, *privValStatePath)
 
+	// Over gRPC, the signer is the server.
+	if protocol, address := cmtnet.ProtocolAndAddress(*addr); protocol == "grpc" {
+		tlsConfig, err := privvalgrpc.ServerTLSConfig(*certFile, *keyFile, *caFile)
+		if err != nil {
+			logger.Error("Failed to load certificates", "err", err)
+			os.Exit(1)
+		}
+		ln, err := net.Listen("tcp", address)
+		if err != nil {
+			logger.Error("Failed to listen", "err", err)
+			os.Exit(1)
+		}
+		server := privvalgrpc.NewServer(privvalgrpc.NewSignerServer(*chainID, pv, logger), tlsConfig)
+		cmtos.TrapSignal(logger, server.Stop)
+		if err := server.Serve(ln); err != nil {
+			logger.Error("gRPC server stopped", "err", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var connKey crypto.PrivKey
+	if *connKeyPath != "" {
+		nodeKey, err := p2p.LoadOrGenNodeKey(*connKeyPath)
+		if err != nil {
+			logger.Error("Failed to load connection key", "err", err)
+			os.Exit(1)
+		}
+		connKey = nodeKey.PrivKey
+		logger.Info("Authenticating connections", "pubKey", base64.StdEncoding.EncodeToString(connKey.PubKey().Bytes()))
+	}
+
+	var options []privval.SignerServiceEndpointOption
+	if *nodePubKey != "" {
+		pubKey, err := privval.ParsePubKey(*nodePubKey)
+		if err != nil {
+			logger.Error("Invalid node public key", "err", err)
+			os.Exit(1)
+		}
+		options = append(options, privval.SignerDialerEndpointNodeKey(pubKey))
+	}
+
 	var dialer privval.SocketDialer
 	protocol, address := cmtnet.ProtocolAndAddress(*addr)
 	switch protocol {
 	case "unix":
-		dialer = privval.DialUnixFn(address)
+		if connKey != nil {
+			dialer = privval.DialSecretUnixFn(address, connKey)
+		} else {
+			dialer = privval.DialUnixFn(address)
+		}
 	case "tcp":
+		if connKey == nil {
+			connKey = ed25519.GenPrivKey()
+		}
 		connTimeout := 3 * time.Second // TODO
-		dialer = privval.DialTCPFn(address, connTimeout, ed25519.GenPrivKey())
+		dialer = privval.DialTCPFn(address, connTimeout, connKey)
 	default:
 		logger.Error("Unknown protocol", "protocol", protocol)
 		os.Exit(1)
 	}
 
-	sd := privval.NewSignerDialerEndpoint(logger, dialer)
+	sd := privval.NewSignerDialerEndpoint(logger, dialer, options...)
 	ss := privval.NewSignerServer(sd, *chainID, pv)
 
 	err := ss.Start()
@@ -46,6 +46,12 @@ ifeq (bls12381,$(findstring bls12381,$(COMETBFT_BUILD_OPTIONS)))
   BUILD_TAGS += bls12381
 endif
 
+# handle pkcs11
+ifeq (pkcs11,$(findstring pkcs11,$(COMETBFT_BUILD_OPTIONS)))
+  CGO_ENABLED=1
+  BUILD_TAGS += pkcs11
+endif
+
 # handle secp256k1eth
 ifeq (secp256k1eth,$(findstring secp256k1eth,$(COMETBFT_BUILD_OPTIONS)))
   BUILD_TAGS += secp256k1eth
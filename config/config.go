@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -9,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -148,6 +151,8 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.BlockSync.RootDir = root
+	cfg.Storage.ColdStorage.RootDir = root
 	return cfg
 }
 
@@ -268,9 +273,44 @@ type BaseConfig struct {
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
 	// TCP or UNIX socket address for CometBFT to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process, or address of an
+	// external PrivValidator process serving the PrivValidatorAPI over gRPC
+	// for CometBFT to connect to, prefixed with grpc://
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Number of external PrivValidator processes which may be connected at
+	// once to PrivValidatorListenAddr. Requests go to one of them, and fail
+	// over to the others if its connection fails, but not if a sign request
+	// times out. They must all hold the same key. Over a UNIX socket, several
+	// signers require PrivValidatorAllowedKeys.
+	PrivValidatorSigners int `mapstructure:"priv_validator_signers"`
+
+	// Base64-encoded ed25519 public keys of the external PrivValidator
+	// processes allowed to connect to PrivValidatorListenAddr. If empty, any
+	// process may connect. If set, connections over a UNIX socket are
+	// authenticated and encrypted too. The node authenticates itself with its
+	// node key, which signers may pin.
+	PrivValidatorAllowedKeys []string `mapstructure:"priv_validator_allowed_keys"`
+
+	// Certificate and key authenticating CometBFT to the external
+	// PrivValidator process over gRPC, and certificate of the CA the
+	// certificate of the PrivValidator process must be signed by.
+	PrivValidatorClientCertificate string `mapstructure:"priv_validator_client_certificate_file"`
+	PrivValidatorClientKey         string `mapstructure:"priv_validator_client_key_file"`
+	PrivValidatorRootCA            string `mapstructure:"priv_validator_root_ca_file"`
+
+	// Path of the PKCS#11 module of an HSM holding the key of the validator,
+	// and label of the token, user PIN and label of the key pair within it.
+	// The PIN can instead be read from the first line of
+	// PrivValidatorPKCS11PINFile, to keep it out of the config file. If set, CometBFT signs with the HSM instead of PrivValidatorKey, and
+	// keeps the last sign state in PrivValidatorState. Requires the pkcs11
+	// build tag.
+	PrivValidatorPKCS11Module     string `mapstructure:"priv_validator_pkcs11_module"`
+	PrivValidatorPKCS11TokenLabel string `mapstructure:"priv_validator_pkcs11_token_label"`
+	PrivValidatorPKCS11PIN        string `mapstructure:"priv_validator_pkcs11_pin"`
+	PrivValidatorPKCS11PINFile    string `mapstructure:"priv_validator_pkcs11_pin_file"`
+	PrivValidatorPKCS11KeyLabel   string `mapstructure:"priv_validator_pkcs11_key_label"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
@@ -285,20 +325,21 @@ type BaseConfig struct {
 // DefaultBaseConfig returns a default base configuration for a CometBFT node.
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Version:            version.CMTSemVer,
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultLogLevel,
-		LogFormat:          LogFormatPlain,
-		LogColors:          true,
-		FilterPeers:        false,
-		DBBackend:          "pebbledb",
-		DBPath:             DefaultDataDir,
+		Version:              version.CMTSemVer,
+		Genesis:              defaultGenesisJSONPath,
+		PrivValidatorKey:     defaultPrivValKeyPath,
+		PrivValidatorState:   defaultPrivValStatePath,
+		PrivValidatorSigners: 1,
+		NodeKey:              defaultNodeKeyPath,
+		Moniker:              defaultMoniker,
+		ProxyApp:             "tcp://127.0.0.1:26658",
+		ABCI:                 "socket",
+		LogLevel:             DefaultLogLevel,
+		LogFormat:            LogFormatPlain,
+		LogColors:            true,
+		FilterPeers:          false,
+		DBBackend:            "pebbledb",
+		DBPath:               DefaultDataDir,
 	}
 }
 
@@ -330,6 +371,34 @@ func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// PrivValidatorClientCertificateFile returns the full path to the certificate
+// authenticating the node to an external PrivValidator process over gRPC.
+func (cfg BaseConfig) PrivValidatorClientCertificateFile() string {
+	return rootify(cfg.PrivValidatorClientCertificate, cfg.RootDir)
+}
+
+// PrivValidatorClientKeyFile returns the full path to the key of the
+// certificate authenticating the node to an external PrivValidator process
+// over gRPC.
+func (cfg BaseConfig) PrivValidatorClientKeyFile() string {
+	return rootify(cfg.PrivValidatorClientKey, cfg.RootDir)
+}
+
+// PrivValidatorPKCS11PINFilePath returns the full path to the file holding the
+// user PIN of the PKCS#11 token, or "" if unset.
+func (cfg BaseConfig) PrivValidatorPKCS11PINFilePath() string {
+	if cfg.PrivValidatorPKCS11PINFile == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorPKCS11PINFile, cfg.RootDir)
+}
+
+// PrivValidatorRootCAFile returns the full path to the certificate of the CA
+// the certificate of an external PrivValidator process must be signed by.
+func (cfg BaseConfig) PrivValidatorRootCAFile() string {
+	return rootify(cfg.PrivValidatorRootCA, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory.
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -350,6 +419,44 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
 
+	if cfg.PrivValidatorSigners < 1 {
+		return errors.New("priv_validator_signers must be at least 1")
+	}
+
+	for _, key := range cfg.PrivValidatorAllowedKeys {
+		if _, err := base64.StdEncoding.DecodeString(key); err != nil {
+			return fmt.Errorf("invalid priv_validator_allowed_keys entry %q: %w", key, err)
+		}
+	}
+	if strings.HasPrefix(cfg.PrivValidatorListenAddr, "unix://") && cfg.PrivValidatorSigners > 1 &&
+		len(cfg.PrivValidatorAllowedKeys) == 0 {
+		return errors.New("priv_validator_allowed_keys must be set with a unix:// priv_validator_laddr and several priv_validator_signers")
+	}
+
+	if strings.HasPrefix(cfg.PrivValidatorListenAddr, "grpc://") {
+		if cfg.PrivValidatorClientCertificate == "" || cfg.PrivValidatorClientKey == "" || cfg.PrivValidatorRootCA == "" {
+			return errors.New("priv_validator_client_certificate_file, priv_validator_client_key_file and priv_validator_root_ca_file must be set with a grpc:// priv_validator_laddr")
+		}
+		if cfg.PrivValidatorSigners != 1 {
+			return errors.New("priv_validator_signers must be 1 with a grpc:// priv_validator_laddr")
+		}
+		if len(cfg.PrivValidatorAllowedKeys) > 0 {
+			return errors.New("priv_validator_allowed_keys cannot be set with a grpc:// priv_validator_laddr, which is authenticated with certificates")
+		}
+	}
+
+	if cfg.PrivValidatorPKCS11Module != "" {
+		if cfg.PrivValidatorPKCS11TokenLabel == "" || cfg.PrivValidatorPKCS11KeyLabel == "" {
+			return errors.New("priv_validator_pkcs11_token_label and priv_validator_pkcs11_key_label must be set with priv_validator_pkcs11_module")
+		}
+		if cfg.PrivValidatorListenAddr != "" {
+			return errors.New("priv_validator_pkcs11_module and priv_validator_laddr cannot both be set")
+		}
+		if cfg.PrivValidatorPKCS11PIN != "" && cfg.PrivValidatorPKCS11PINFile != "" {
+			return errors.New("priv_validator_pkcs11_pin and priv_validator_pkcs11_pin_file cannot both be set")
+		}
+	}
+
 	return cfg.validateProxyApp()
 }
 
@@ -470,6 +577,12 @@ type RPCConfig struct {
 	// predictability in subscription behavior.
 	CloseOnSlowClient bool `mapstructure:"experimental_close_on_slow_client"`
 
+	// The number of latest events of committed blocks kept by the node for
+	// WebSocket subscribers to resume from the cursor of the last event they
+	// received. The events which are no longer kept are replayed from the
+	// stores. Other events are not kept and cannot be resumed.
+	EventLogSize int `mapstructure:"event_log_size"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
@@ -504,6 +617,43 @@ type RPCConfig struct {
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls_key_file"`
 
+	// The path to a JSON file containing the API keys accepted by the RPC and
+	// gRPC servers, with the scopes they grant.
+	// Might be either absolute path or path related to CometBFT's config directory.
+	//
+	// NOTE: authentication is enabled if either auth_api_keys_file or
+	// auth_jwt_secret_file is set.
+	AuthAPIKeysFile string `mapstructure:"auth_api_keys_file"`
+
+	// The path to a file containing the HMAC secret of the HS256 JWTs accepted
+	// by the RPC and gRPC servers.
+	// Might be either absolute path or path related to CometBFT's config directory.
+	AuthJWTSecretFile string `mapstructure:"auth_jwt_secret_file"`
+
+	// The scopes granted to the callers presenting no credentials when
+	// authentication is enabled: "read", "broadcast", "subscribe" or "admin".
+	AuthAnonymousScopes []string `mapstructure:"auth_anonymous_scopes"`
+
+	// The default number of calls per second allowed to each caller when
+	// authentication is enabled. 0 means unlimited.
+	AuthRateLimit float64 `mapstructure:"auth_rate_limit"`
+
+	// The default number of calls allowed to each caller in a burst.
+	AuthRateBurst int `mapstructure:"auth_rate_burst"`
+
+	// The number of cost units per second each client IP may spend on calls to
+	// the RPC server. Every route has a cost, higher for searches and paginated
+	// queries. 0 means unlimited.
+	RateLimit float64 `mapstructure:"rate_limit"`
+
+	// The number of cost units each client IP may spend in a burst.
+	RateBurst int `mapstructure:"rate_burst"`
+
+	// The number of calls per second each client IP may make to a route, in
+	// addition to rate_limit, as "<route>=<calls per second>" entries such as
+	// "tx_search=1".
+	RouteRateLimits []string `mapstructure:"route_rate_limits"`
+
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	// FIXME: This should be moved under the instrumentation section
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
@@ -525,6 +675,7 @@ func DefaultRPCConfig() *RPCConfig {
 		SubscriptionBufferSize:    defaultSubscriptionBufferSize,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 		WebSocketWriteBufferSize:  defaultSubscriptionBufferSize,
+		EventLogSize:              1000,
 
 		MaxRequestBatchSize: 10,             // maximum requests in a JSON-RPC batch request
 		MaxBodyBytes:        int64(1000000), // 1MB
@@ -532,6 +683,14 @@ func DefaultRPCConfig() *RPCConfig {
 
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		AuthAnonymousScopes: []string{},
+		AuthRateLimit:       0,
+		AuthRateBurst:       10,
+
+		RateLimit:       0,
+		RateBurst:       100,
+		RouteRateLimits: []string{},
 	}
 }
 
@@ -564,6 +723,9 @@ func (cfg *RPCConfig) ValidateBasic() error {
 			cfg.SubscriptionBufferSize,
 		)
 	}
+	if cfg.EventLogSize < 0 {
+		return cmterrors.ErrNegativeField{Field: "event_log_size"}
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return cmterrors.ErrNegativeField{Field: "timeout_broadcast_tx_commit"}
 	}
@@ -576,6 +738,21 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_header_bytes"}
 	}
+	if cfg.AuthRateLimit < 0 {
+		return cmterrors.ErrNegativeField{Field: "auth_rate_limit"}
+	}
+	if cfg.AuthRateBurst < 0 {
+		return cmterrors.ErrNegativeField{Field: "auth_rate_burst"}
+	}
+	if cfg.RateLimit < 0 {
+		return cmterrors.ErrNegativeField{Field: "rate_limit"}
+	}
+	if cfg.RateBurst < 0 {
+		return cmterrors.ErrNegativeField{Field: "rate_burst"}
+	}
+	if _, err := cfg.RouteRates(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -608,6 +785,52 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+func (cfg RPCConfig) APIKeysFile() string {
+	path := cfg.AuthAPIKeysFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+func (cfg RPCConfig) JWTSecretFile() string {
+	path := cfg.AuthJWTSecretFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+// RouteRates returns the number of calls per second each client IP may make
+// to the routes named by RouteRateLimits.
+func (cfg RPCConfig) RouteRates() (map[string]float64, error) {
+	rates := make(map[string]float64, len(cfg.RouteRateLimits))
+	for _, entry := range cfg.RouteRateLimits {
+		route, rate, ok := strings.Cut(entry, "=")
+		if !ok || route == "" {
+			return nil, fmt.Errorf("route_rate_limits entry %q must be <route>=<calls per second>", entry)
+		}
+		r, err := strconv.ParseFloat(rate, 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("route_rate_limits entry %q: rate must be a positive number", entry)
+		}
+		rates[route] = r
+	}
+	return rates, nil
+}
+
+// IsRateLimitEnabled returns true if the calls to the RPC server are rate
+// limited per client IP.
+func (cfg RPCConfig) IsRateLimitEnabled() bool {
+	return cfg.RateLimit > 0 || len(cfg.RouteRateLimits) > 0
+}
+
+// IsAuthEnabled returns true if the callers of the RPC and gRPC servers are
+// authenticated.
+func (cfg RPCConfig) IsAuthEnabled() bool {
+	return cfg.AuthAPIKeysFile != "" || cfg.AuthJWTSecretFile != ""
+}
+
 // -----------------------------------------------------------------------------
 // GRPCConfig
 
@@ -628,6 +851,13 @@ type GRPCConfig struct {
 	// If no height is provided, the block results of the latest height are returned
 	BlockResultsService *GRPCBlockResultsServiceConfig `mapstructure:"block_results_service"`
 
+	// The gRPC validator service provides the validator set and the consensus
+	// params for a given height, and streams their changes
+	ValidatorService *GRPCValidatorServiceConfig `mapstructure:"validator_service"`
+
+	// The gRPC evidence service allows submitting evidence of misbehavior
+	EvidenceService *GRPCEvidenceServiceConfig `mapstructure:"evidence_service"`
+
 	// The "privileged" section provides configuration for the gRPC server
 	// dedicated to privileged clients.
 	Privileged *GRPCPrivilegedConfig `mapstructure:"privileged"`
@@ -639,6 +869,8 @@ func DefaultGRPCConfig() *GRPCConfig {
 		VersionService:      DefaultGRPCVersionServiceConfig(),
 		BlockService:        DefaultGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		ValidatorService:    DefaultGRPCValidatorServiceConfig(),
+		EvidenceService:     DefaultGRPCEvidenceServiceConfig(),
 		Privileged:          DefaultGRPCPrivilegedConfig(),
 	}
 }
@@ -649,6 +881,8 @@ func TestGRPCConfig() *GRPCConfig {
 		VersionService:      TestGRPCVersionServiceConfig(),
 		BlockService:        TestGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		ValidatorService:    TestGRPCValidatorServiceConfig(),
+		EvidenceService:     TestGRPCEvidenceServiceConfig(),
 		Privileged:          TestGRPCPrivilegedConfig(),
 	}
 }
@@ -708,6 +942,38 @@ func TestGRPCBlockServiceConfig() *GRPCBlockServiceConfig {
 	}
 }
 
+type GRPCValidatorServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCValidatorServiceConfig() *GRPCValidatorServiceConfig {
+	return &GRPCValidatorServiceConfig{
+		Enabled: false,
+	}
+}
+
+func TestGRPCValidatorServiceConfig() *GRPCValidatorServiceConfig {
+	return &GRPCValidatorServiceConfig{
+		Enabled: true,
+	}
+}
+
+type GRPCEvidenceServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCEvidenceServiceConfig() *GRPCEvidenceServiceConfig {
+	return &GRPCEvidenceServiceConfig{
+		Enabled: false,
+	}
+}
+
+func TestGRPCEvidenceServiceConfig() *GRPCEvidenceServiceConfig {
+	return &GRPCEvidenceServiceConfig{
+		Enabled: true,
+	}
+}
+
 // -----------------------------------------------------------------------------
 // GRPCPrivilegedConfig
 
@@ -1201,7 +1467,23 @@ func (cfg *StateSyncConfig) PossibleMisconfigurations() []string {
 
 // BlockSyncConfig (formerly known as FastSync) defines the configuration for the CometBFT block sync service.
 type BlockSyncConfig struct {
+	RootDir string `mapstructure:"home"`
 	Version string `mapstructure:"version"`
+
+	// TrustedCheckpoints are blocks trusted by the operator, as "height:hash"
+	// with a hex-encoded block hash. If the node is below the highest
+	// checkpoint, block sync first verifies the header chain backwards from
+	// it, and then only checks that the downloaded blocks match the verified
+	// headers instead of verifying their commits.
+	TrustedCheckpoints []string `mapstructure:"trusted_checkpoints"`
+
+	// CheckpointsFile is the path to a JSON file with additional trusted
+	// checkpoints, which must be signed by CheckpointsSigner.
+	CheckpointsFile string `mapstructure:"checkpoints_file"`
+
+	// CheckpointsSigner is the hex-encoded ed25519 public key that signed
+	// CheckpointsFile.
+	CheckpointsSigner string `mapstructure:"checkpoints_signer"`
 }
 
 // DefaultBlockSyncConfig returns a default configuration for the block sync service.
@@ -1220,12 +1502,68 @@ func TestBlockSyncConfig() *BlockSyncConfig {
 func (cfg *BlockSyncConfig) ValidateBasic() error {
 	switch cfg.Version {
 	case v0:
-		return nil
 	case v1, v2:
 		return ErrDeprecatedBlocksyncVersion{Version: cfg.Version, Allowed: []string{v0}}
 	default:
 		return ErrUnknownBlocksyncVersion{cfg.Version}
 	}
+
+	for _, checkpoint := range cfg.TrustedCheckpoints {
+		if err := validateCheckpoint(checkpoint); err != nil {
+			return cmterrors.ErrWrongField{Field: "trusted_checkpoints", Err: err}
+		}
+	}
+
+	if cfg.CheckpointsFile != "" {
+		if cfg.CheckpointsSigner == "" {
+			return cmterrors.ErrRequiredField{Field: "checkpoints_signer"}
+		}
+		signer, err := hex.DecodeString(cfg.CheckpointsSigner)
+		if err != nil {
+			return cmterrors.ErrWrongField{Field: "checkpoints_signer", Err: err}
+		}
+		if len(signer) != ed25519PubKeySize {
+			return cmterrors.ErrWrongField{
+				Field: "checkpoints_signer",
+				Err:   fmt.Errorf("expected %d bytes, got %d", ed25519PubKeySize, len(signer)),
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckpointsFilePath returns the full path to the trusted checkpoints file.
+func (cfg *BlockSyncConfig) CheckpointsFilePath() string {
+	return rootify(cfg.CheckpointsFile, cfg.RootDir)
+}
+
+// ed25519PubKeySize is the size of an ed25519 public key. It's redefined here
+// to avoid depending on the crypto packages.
+const ed25519PubKeySize = 32
+
+// validateCheckpoint checks that a trusted checkpoint has the "height:hash"
+// format.
+func validateCheckpoint(checkpoint string) error {
+	heightStr, hashStr, ok := strings.Cut(checkpoint, ":")
+	if !ok {
+		return fmt.Errorf("checkpoint %q: expected height:hash", checkpoint)
+	}
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("checkpoint %q: invalid height: %w", checkpoint, err)
+	}
+	if height <= 0 {
+		return fmt.Errorf("checkpoint %q: height must be positive", checkpoint)
+	}
+	hash, err := hex.DecodeString(hashStr)
+	if err != nil {
+		return fmt.Errorf("checkpoint %q: invalid hash: %w", checkpoint, err)
+	}
+	if len(hash) != sha256.Size {
+		return fmt.Errorf("checkpoint %q: expected a %d-byte hash, got %d", checkpoint, sha256.Size, len(hash))
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -1383,6 +1721,11 @@ type StorageConfig struct {
 	DiscardABCIResponses bool `mapstructure:"discard_abci_responses"`
 	// Configuration related to storage pruning.
 	Pruning *PruningConfig `mapstructure:"pruning"`
+	// Configuration related to the cold storage of old blocks.
+	ColdStorage *ColdStorageConfig `mapstructure:"cold_storage"`
+	// Configuration related to the background integrity check of the block
+	// store.
+	IntegrityCheck *IntegrityCheckConfig `mapstructure:"integrity_check"`
 	// Compaction on pruning - enable or disable in-process compaction.
 	// If the DB backend supports it, this will force the DB to compact
 	// the database levels and save on storage space. Setting this to true
@@ -1405,6 +1748,17 @@ type StorageConfig struct {
 	// Not that this is an experimental feature and switching back from v2 to v1
 	// is not supported by CometBFT.
 	ExperimentalKeyLayout string `mapstructure:"experimental_db_key_layout"`
+
+	// If the stores were created with another key layout than
+	// ExperimentalKeyLayout, copy their records to this layout in the
+	// background. The migration is completed with the
+	// `cometbft migrate-db-key-layout` command, which then only copies the
+	// records written since the last copy.
+	ExperimentalKeyLayoutMigration bool `mapstructure:"experimental_db_key_layout_migration"`
+
+	// The directory in which the backups taken with the unsafe_backup RPC
+	// endpoint are written, relative to the home directory unless absolute.
+	BackupDir string `mapstructure:"backup_dir"`
 }
 
 // DefaultStorageConfig returns the default configuration options relating to
@@ -1413,9 +1767,12 @@ func DefaultStorageConfig() *StorageConfig {
 	return &StorageConfig{
 		DiscardABCIResponses:  false,
 		Pruning:               DefaultPruningConfig(),
+		ColdStorage:           DefaultColdStorageConfig(),
+		IntegrityCheck:        DefaultIntegrityCheckConfig(),
 		Compact:               false,
 		CompactionInterval:    1000,
 		ExperimentalKeyLayout: "v1",
+		BackupDir:             "backups",
 	}
 }
 
@@ -1425,6 +1782,9 @@ func TestStorageConfig() *StorageConfig {
 	return &StorageConfig{
 		DiscardABCIResponses: false,
 		Pruning:              TestPruningConfig(),
+		ColdStorage:          DefaultColdStorageConfig(),
+		IntegrityCheck:       DefaultIntegrityCheckConfig(),
+		BackupDir:            "backups",
 	}
 }
 
@@ -1432,9 +1792,18 @@ func (cfg *StorageConfig) ValidateBasic() error {
 	if err := cfg.Pruning.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [pruning] section: %w", err)
 	}
+	if err := cfg.ColdStorage.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [cold_storage] section: %w", err)
+	}
+	if err := cfg.IntegrityCheck.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [integrity_check] section: %w", err)
+	}
 	if cfg.ExperimentalKeyLayout != "v1" && cfg.ExperimentalKeyLayout != "v2" {
 		return fmt.Errorf("unsupported version of DB Key layout, expected v1 or v2, got %s", cfg.ExperimentalKeyLayout)
 	}
+	if cfg.BackupDir == "" {
+		return errors.New("backup_dir can't be empty")
+	}
 	return nil
 }
 
@@ -1600,6 +1969,91 @@ func (cfg *PruningConfig) ValidateBasic() error {
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// ColdStorageConfig
+
+// ColdStorageConfig defines the configuration of the cold storage of old
+// blocks, in compressed segment files outside of the database.
+type ColdStorageConfig struct {
+	RootDir string `mapstructure:"home"`
+
+	// Whether the blocks older than RetainBlocks heights are moved to the
+	// cold storage. They are still served from there. Disabled by default.
+	Enabled bool `mapstructure:"enabled"`
+	// Path to the directory of the segment files.
+	Dir string `mapstructure:"dir"`
+	// Number of the latest blocks kept in the database.
+	RetainBlocks int64 `mapstructure:"retain_blocks"`
+	// Number of heights in a segment file. Pruning deletes whole segments.
+	// It must not be changed once segments were written.
+	SegmentSize int64 `mapstructure:"segment_size"`
+}
+
+func DefaultColdStorageConfig() *ColdStorageConfig {
+	return &ColdStorageConfig{
+		Enabled:      false,
+		Dir:          filepath.Join(DefaultDataDir, "cold"),
+		RetainBlocks: 100000,
+		SegmentSize:  10000,
+	}
+}
+
+func (cfg *ColdStorageConfig) ValidateBasic() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Dir == "" {
+		return cmterrors.ErrRequiredField{Field: "dir"}
+	}
+	if cfg.RetainBlocks <= 0 {
+		return cmterrors.ErrNegativeOrZeroField{Field: "retain_blocks"}
+	}
+	if cfg.SegmentSize <= 0 {
+		return cmterrors.ErrNegativeOrZeroField{Field: "segment_size"}
+	}
+	return nil
+}
+
+// DirPath returns the full path to the directory of the segment files.
+func (cfg *ColdStorageConfig) DirPath() string {
+	return rootify(cfg.Dir, cfg.RootDir)
+}
+
+// -----------------------------------------------------------------------------
+// IntegrityCheckConfig
+
+// IntegrityCheckConfig defines the configuration of the background integrity
+// check of the block store.
+type IntegrityCheckConfig struct {
+	// Whether the blocks, their parts and commits are checked in the
+	// background, from the base to the height of the block store, at every
+	// interval. Disabled by default.
+	Enabled bool `mapstructure:"enabled"`
+	// The time period between two checks of the whole block store.
+	Interval time.Duration `mapstructure:"interval"`
+	// Whether the corrupted blocks are fetched again from peers, through the
+	// blocksync protocol. Otherwise, they are only reported.
+	Repair bool `mapstructure:"repair"`
+}
+
+func DefaultIntegrityCheckConfig() *IntegrityCheckConfig {
+	return &IntegrityCheckConfig{
+		Enabled:  false,
+		Interval: 24 * time.Hour,
+		Repair:   false,
+	}
+}
+
+func (cfg *IntegrityCheckConfig) ValidateBasic() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Interval <= 0 {
+		return cmterrors.ErrNegativeOrZeroField{Field: "interval"}
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // DataCompanionPruningConfig
 
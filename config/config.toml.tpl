@@ -62,9 +62,49 @@ priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
 # TCP or UNIX socket address for CometBFT to listen on for
-# connections from an external PrivValidator process
+# connections from an external PrivValidator process, or address of an external
+# PrivValidator process serving the PrivValidatorAPI over gRPC for CometBFT to
+# connect to, prefixed with grpc:// (e.g. "grpc://127.0.0.1:26659")
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# Number of external PrivValidator processes which may be connected at once to
+# priv_validator_laddr, holding the same key. Requests go to one of them, and
+# fail over to the others if its connection fails. A sign request timing out is
+# not failed over, since the signer may have signed. Over a UNIX socket, several
+# signers require priv_validator_allowed_keys.
+priv_validator_signers = {{ .BaseConfig.PrivValidatorSigners }}
+
+# Base64-encoded ed25519 public keys of the external PrivValidator processes
+# allowed to connect to priv_validator_laddr. Connections from other keys are
+# rejected. If empty, any process may connect.
+# If set, connections over a UNIX socket are authenticated and encrypted too.
+# The node authenticates itself to the PrivValidator processes with its node key.
+priv_validator_allowed_keys = [{{ range .BaseConfig.PrivValidatorAllowedKeys }}{{ printf "%q, " . }}{{end}}]
+
+# With a grpc:// priv_validator_laddr, the connection is mutually authenticated
+# with TLS: certificate and key authenticating CometBFT to the PrivValidator
+# process, and certificate of the CA the certificate of the PrivValidator
+# process must be signed by. The PrivValidator process must in turn only
+# accept certificates signed by a CA it trusts.
+priv_validator_client_certificate_file = "{{ js .BaseConfig.PrivValidatorClientCertificate }}"
+priv_validator_client_key_file = "{{ js .BaseConfig.PrivValidatorClientKey }}"
+priv_validator_root_ca_file = "{{ js .BaseConfig.PrivValidatorRootCA }}"
+
+# Path to the PKCS#11 module of an HSM holding the key of the validator
+# (e.g. "/usr/lib/softhsm/libsofthsm2.so"), and label of the token, user PIN
+# and label of the key pair within it. If set, CometBFT signs with the HSM
+# instead of priv_validator_key_file, and keeps the last sign state in
+# priv_validator_state_file. Only ed25519 and secp256k1 keys are supported.
+# Requires CometBFT to be built with the pkcs11 build tag.
+# To keep the PIN out of this file, it can be set with the
+# CMT_PRIV_VALIDATOR_PKCS11_PIN environment variable, or read from the first
+# line of priv_validator_pkcs11_pin_file instead.
+priv_validator_pkcs11_module = "{{ js .BaseConfig.PrivValidatorPKCS11Module }}"
+priv_validator_pkcs11_token_label = "{{ js .BaseConfig.PrivValidatorPKCS11TokenLabel }}"
+priv_validator_pkcs11_pin = "{{ js .BaseConfig.PrivValidatorPKCS11PIN }}"
+priv_validator_pkcs11_pin_file = "{{ js .BaseConfig.PrivValidatorPKCS11PINFile }}"
+priv_validator_pkcs11_key_label = "{{ js .BaseConfig.PrivValidatorPKCS11KeyLabel }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
@@ -147,6 +187,14 @@ experimental_websocket_write_buffer_size = {{ .RPC.WebSocketWriteBufferSize }}
 # predictability in subscription behavior.
 experimental_close_on_slow_client = {{ .RPC.CloseOnSlowClient }}
 
+# The number of latest events of committed blocks kept by the node, so that
+# WebSocket subscribers can resume from the cursor of the last event they
+# received, e.g. after reconnecting. The events which are no longer kept are
+# replayed from the block store, provided their results are stored (see
+# "discard_abci_responses"). Other events, e.g. the consensus ones, are only
+# delivered as they are published. Higher values use more memory.
+event_log_size = {{ .RPC.EventLogSize }}
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
@@ -179,6 +227,42 @@ tls_cert_file = "{{ .RPC.TLSCertFile }}"
 # Otherwise, HTTP server is run.
 tls_key_file = "{{ .RPC.TLSKeyFile }}"
 
+# The path to a JSON file containing the API keys accepted by the RPC and gRPC servers,
+# with the scopes they grant.
+# Might be either absolute path or path related to CometBFT's config directory.
+# NOTE: authentication is enabled if either auth_api_keys_file or auth_jwt_secret_file is set.
+auth_api_keys_file = "{{ .RPC.AuthAPIKeysFile }}"
+
+# The path to a file containing the HMAC secret of the HS256 JWTs accepted by the RPC
+# and gRPC servers.
+# Might be either absolute path or path related to CometBFT's config directory.
+auth_jwt_secret_file = "{{ .RPC.AuthJWTSecretFile }}"
+
+# The scopes granted to the callers presenting no credentials when authentication is enabled.
+# Possible values: "read", "broadcast", "subscribe" and "admin".
+auth_anonymous_scopes = [{{ range .RPC.AuthAnonymousScopes }}{{ printf "%q, " . }}{{end}}]
+
+# The default number of calls per second allowed to each caller when authentication is
+# enabled. API keys may set their own. 0 means unlimited.
+auth_rate_limit = {{ .RPC.AuthRateLimit }}
+
+# The default number of calls allowed to each caller in a burst.
+auth_rate_burst = {{ .RPC.AuthRateBurst }}
+
+# The number of cost units per second each client IP may spend on calls to the RPC
+# server. Every route has a cost, higher for searches and paginated queries.
+# Calls over the limit are rejected with the HTTP status code 429. 0 means unlimited.
+# NOTE: the clients behind a proxy share the IP of the proxy.
+rate_limit = {{ .RPC.RateLimit }}
+
+# The number of cost units each client IP may spend in a burst.
+rate_burst = {{ .RPC.RateBurst }}
+
+# The number of calls per second each client IP may make to a route, in addition to
+# rate_limit, as "<route>=<calls per second>" entries.
+# Example: ["tx_search=1", "block_search=1"]
+route_rate_limits = [{{ range .RPC.RouteRateLimits }}{{ printf "%q, " . }}{{end}}]
+
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 
@@ -221,6 +305,17 @@ enabled = {{ .GRPC.BlockService.Enabled }}
 [grpc.block_results_service]
 enabled = {{ .GRPC.BlockResultsService.Enabled }}
 
+# The gRPC validator service returns the validator set and the consensus params for a
+# given height. If no height is given, it will return those of the latest height. It
+# also streams their changes from a given height as blocks are committed.
+[grpc.validator_service]
+enabled = {{ .GRPC.ValidatorService.Enabled }}
+
+# The gRPC evidence service allows submitting evidence of misbehavior, e.g. by light
+# clients, which is added to the evidence pool.
+[grpc.evidence_service]
+enabled = {{ .GRPC.EvidenceService.Enabled }}
+
 #
 # Configuration for privileged gRPC endpoints, which should **never** be exposed
 # to the public internet.
@@ -425,6 +520,9 @@ max_discovery_time = "{{ .StateSync.MaxDiscoveryTime }}"
 
 # Temporary directory for state sync snapshot chunks, defaults to the OS tempdir (typically /tmp).
 # Will create a new, randomly named directory within, and remove it when done.
+# If set, the progress of the snapshot restoration is also recorded here, so that a node restarted
+# halfway through state sync offers the same snapshot to the app again. If the app confirms it
+# kept the chunks it applied, chunks are applied from where it left off, otherwise from the first.
 temp_dir = "{{ .StateSync.TempDir }}"
 
 # The timeout duration before re-requesting a chunk, possibly from a different
@@ -447,6 +545,20 @@ chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 #   1) "v0" - the default block sync implementation
 version = "{{ .BlockSync.Version }}"
 
+# Blocks trusted by the operator (comma-separated), as "height:hash" with a hex-encoded block hash.
+# If the node is below the highest checkpoint, block sync first fetches the headers from peers and
+# verifies them backwards from the checkpoint, and then only checks that the downloaded blocks
+# match the verified headers, instead of verifying their commits.
+trusted_checkpoints = "{{ StringsJoin .BlockSync.TrustedCheckpoints "," }}"
+
+# Path to a JSON file with additional trusted checkpoints, signed by checkpoints_signer.
+# The file has the form {"checkpoints": [{"height": "<height>", "hash": "<hash>"}], "signature": "<base64>"},
+# where the signature is over the JSON encoding of the "checkpoints" list.
+checkpoints_file = "{{ js .BlockSync.CheckpointsFile }}"
+
+# Hex-encoded ed25519 public key that signed checkpoints_file.
+checkpoints_signer = "{{ .BlockSync.CheckpointsSigner }}"
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -497,11 +609,18 @@ discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 # Note that this is an experimental feature and switching back from v2 to v1
 # is not supported by CometBFT.
 # If the database was initially created with v1, it is necessary to migrate the DB
-# before switching to v2. The migration is not done automatically.
+# before switching to v2, with the `cometbft migrate-db-key-layout` command.
 # v1 - the legacy layout existing in Comet prior to v1.
 # v2 - Order preserving representation ordering entries by height.
 experimental_db_key_layout = "{{ .Storage.ExperimentalKeyLayout }}"
 
+# If set to true, and the databases were created with another key layout than
+# experimental_db_key_layout, their records are copied to this layout in the
+# background while the node runs. The migration is then completed with the
+# `cometbft migrate-db-key-layout` command, which only copies the records written
+# since the last copy.
+experimental_db_key_layout_migration = {{ .Storage.ExperimentalKeyLayoutMigration }}
+
 # If set to true, CometBFT will force compaction to happen for databases that support this feature.
 # and save on storage space. Setting this to true is most benefits when used in combination
 # with pruning as it will physically delete the entries marked for deletion.
@@ -515,6 +634,10 @@ compact = {{ .Storage.Compact }}
 # large multiple of your retain height as it might occur bigger overheads.
 compaction_interval = "{{ .Storage.CompactionInterval }}"
 
+# The directory in which the backups taken with the unsafe_backup RPC endpoint
+# are written, relative to the home directory unless absolute.
+backup_dir = "{{ js .Storage.BackupDir }}"
+
 [storage.pruning]
 
 # The time period between automated background pruning operations.
@@ -545,6 +668,45 @@ initial_block_retain_height = {{ .Storage.Pruning.DataCompanion.InitialBlockReta
 # already set a block results retain height, this is ignored.
 initial_block_results_retain_height = {{ .Storage.Pruning.DataCompanion.InitialBlockResultsRetainHeight }}
 
+#
+# Cold storage of old blocks, in compressed segment files outside of the
+# database, to bound the size of the database of archive nodes.
+#
+[storage.cold_storage]
+
+# Whether the blocks older than retain_blocks heights are moved to the cold
+# storage. They are still served from there. Disabled by default.
+enabled = {{ .Storage.ColdStorage.Enabled }}
+
+# Path to the directory of the segment files.
+dir = "{{ js .Storage.ColdStorage.Dir }}"
+
+# Number of the latest blocks kept in the database.
+retain_blocks = {{ .Storage.ColdStorage.RetainBlocks }}
+
+# Number of heights in a segment file. Pruning deletes whole segments, so the
+# blocks of a segment are kept until all of them are pruned. It must not be
+# changed once segments were written.
+segment_size = {{ .Storage.ColdStorage.SegmentSize }}
+
+#
+# Background integrity check of the block store.
+#
+[storage.integrity_check]
+
+# Whether the blocks, their parts and commits are checked in the background,
+# from the base to the height of the block store, at every interval. The
+# commits are verified against the validators of the state store. The
+# `cometbft verify-store` command runs the same check on a stopped node.
+enabled = {{ .Storage.IntegrityCheck.Enabled }}
+
+# The time period between two checks of the whole block store.
+interval = "{{ .Storage.IntegrityCheck.Interval }}"
+
+# Whether the corrupted blocks are fetched again from peers, through the
+# blocksync protocol, and rewritten. Otherwise, they are only reported.
+repair = {{ .Storage.IntegrityCheck.Repair }}
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -59,6 +60,21 @@ func TestTLSConfiguration(t *testing.T) {
 	assert.Equal("/abs/path/to/file.key", cfg.RPC.KeyFile())
 }
 
+func TestRPCAuthConfiguration(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SetRoot("/home/user")
+	assert.False(t, cfg.RPC.IsAuthEnabled())
+
+	cfg.RPC.AuthAPIKeysFile = "api_keys.json"
+	assert.True(t, cfg.RPC.IsAuthEnabled())
+	assert.Equal(t, "/home/user/config/api_keys.json", cfg.RPC.APIKeysFile())
+
+	cfg.RPC.AuthAPIKeysFile = ""
+	cfg.RPC.AuthJWTSecretFile = "/abs/path/to/jwt_secret"
+	assert.True(t, cfg.RPC.IsAuthEnabled())
+	assert.Equal(t, "/abs/path/to/jwt_secret", cfg.RPC.JWTSecretFile())
+}
+
 func TestBaseConfigValidateBasic(t *testing.T) {
 	cfg := config.TestBaseConfig()
 	require.NoError(t, cfg.ValidateBasic())
@@ -66,6 +82,45 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	require.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.PrivValidatorSigners = 0
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.PrivValidatorAllowedKeys = []string{"not base64"}
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.PrivValidatorListenAddr = "unix:///tmp/privval.sock"
+	cfg.PrivValidatorSigners = 2
+	require.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorAllowedKeys = []string{"Dv5zUmG7aWIPwPcQSIIHVOJjbCIPfBkmTRMqVxLSNxo="}
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.PrivValidatorListenAddr = "grpc://127.0.0.1:26659"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorClientCertificate = "config/client.crt"
+	cfg.PrivValidatorClientKey = "config/client.key"
+	cfg.PrivValidatorRootCA = "config/ca.crt"
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorSigners = 2
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.PrivValidatorPKCS11Module = "/usr/lib/softhsm/libsofthsm2.so"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorPKCS11TokenLabel = "validator"
+	cfg.PrivValidatorPKCS11KeyLabel = "consensus"
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorPKCS11PIN = "1234"
+	cfg.PrivValidatorPKCS11PINFile = "config/pin"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorPKCS11PIN = ""
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+	require.Error(t, cfg.ValidateBasic())
 }
 
 func TestBaseConfigProxyApp_ValidateBasic(t *testing.T) {
@@ -109,6 +164,8 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
 		"MaxRequestBatchSize",
+		"AuthRateBurst",
+		"RateBurst",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -116,6 +173,31 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		require.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.AuthRateLimit = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.AuthRateLimit = 0
+
+	cfg.RateLimit = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.RateLimit = 0
+}
+
+func TestRPCRateLimitConfiguration(t *testing.T) {
+	cfg := config.TestRPCConfig()
+	assert.False(t, cfg.IsRateLimitEnabled())
+
+	cfg.RouteRateLimits = []string{"tx_search=0.5", "block_search=2"}
+	require.NoError(t, cfg.ValidateBasic())
+	assert.True(t, cfg.IsRateLimitEnabled())
+	rates, err := cfg.RouteRates()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"tx_search": 0.5, "block_search": 2}, rates)
+
+	for _, entry := range []string{"tx_search", "=1", "tx_search=0", "tx_search=-1", "tx_search=fast"} {
+		cfg.RouteRateLimits = []string{entry}
+		require.Error(t, cfg.ValidateBasic(), entry)
+	}
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -216,6 +298,24 @@ func TestBlockSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	require.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBlockSyncConfig()
+	hash := strings.Repeat("ab", 32)
+	cfg.TrustedCheckpoints = []string{"100:" + hash}
+	require.NoError(t, cfg.ValidateBasic())
+
+	for _, checkpoint := range []string{"100", "0:" + hash, "x:" + hash, "100:xyz", "100:abcd"} {
+		cfg.TrustedCheckpoints = []string{checkpoint}
+		require.Error(t, cfg.ValidateBasic(), checkpoint)
+	}
+
+	cfg = config.TestBlockSyncConfig()
+	cfg.CheckpointsFile = "checkpoints.json"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.CheckpointsSigner = "abcd"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.CheckpointsSigner = hash
+	require.NoError(t, cfg.ValidateBasic())
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
@@ -19,3 +19,35 @@ func TestArmor(t *testing.T) {
 	assert.Equal(t, blockType, blockType2)
 	assert.Equal(t, data, data2)
 }
+
+func TestEncryptArmor(t *testing.T) {
+	blockType := "MINT TEST"
+	data := []byte("somedata")
+	armorStr, err := EncryptArmor(blockType, data, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.True(t, IsArmored([]byte(armorStr)))
+	assert.NotContains(t, armorStr, "somedata")
+
+	blockType2, data2, err := DecryptArmor(armorStr, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.Equal(t, blockType, blockType2)
+	assert.Equal(t, data, data2)
+
+	_, _, err = DecryptArmor(armorStr, []byte("wrong"))
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+
+	// The block type is authenticated.
+	_, headers, ciphertext, err := DecodeArmor(armorStr)
+	require.NoError(t, err)
+	otherStr, err := EncodeArmor("OTHER TEST", headers, ciphertext)
+	require.NoError(t, err)
+	_, _, err = DecryptArmor(otherStr, []byte("passphrase"))
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+
+	// Unencrypted armor cannot be decrypted.
+	plainStr, err := EncodeArmor(blockType, nil, data)
+	require.NoError(t, err)
+	_, _, err = DecryptArmor(plainStr, []byte("passphrase"))
+	require.ErrorAs(t, err, &ErrUnsupportedKDF{})
+	assert.False(t, IsArmored([]byte(`{"priv_key": {}}`)))
+}
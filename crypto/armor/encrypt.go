@@ -0,0 +1,110 @@
+package armor
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	headerKDF  = "kdf"
+	headerSalt = "salt"
+
+	kdfScrypt = "scrypt"
+	saltSize  = 16
+
+	// scrypt parameters recommended for interactive logins.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrWrongPassphrase is returned by DecryptArmor when the data cannot be
+// decrypted with the passphrase.
+var ErrWrongPassphrase = errors.New("armor: wrong passphrase")
+
+// ErrUnsupportedKDF is returned by DecryptArmor when the key derivation function
+// of the armor is not supported.
+type ErrUnsupportedKDF struct {
+	KDF string
+}
+
+func (e ErrUnsupportedKDF) Error() string {
+	return fmt.Sprintf("armor: unsupported key derivation function %q", e.KDF)
+}
+
+// IsArmored returns true if data is ASCII armor, as opposed to e.g. JSON.
+func IsArmored(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN "))
+}
+
+// EncryptArmor encrypts data with XChaCha20-Poly1305, with a key derived from
+// passphrase with scrypt, and encodes it as ASCII armor of the given block
+// type.
+func EncryptArmor(blockType string, data, passphrase []byte) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	// The block type is authenticated, so that the data cannot be passed off
+	// as another kind of data.
+	ciphertext := aead.Seal(nonce, nonce, data, []byte(blockType))
+
+	headers := map[string]string{
+		headerKDF:  kdfScrypt,
+		headerSalt: hex.EncodeToString(salt),
+	}
+	return EncodeArmor(blockType, headers, ciphertext)
+}
+
+// DecryptArmor decodes ASCII armor produced by EncryptArmor, and decrypts it
+// with passphrase.
+func DecryptArmor(armorStr string, passphrase []byte) (blockType string, data []byte, err error) {
+	blockType, headers, ciphertext, err := DecodeArmor(armorStr)
+	if err != nil {
+		return "", nil, err
+	}
+	if kdf := headers[headerKDF]; kdf != kdfScrypt {
+		return "", nil, ErrUnsupportedKDF{KDF: kdf}
+	}
+	salt, err := hex.DecodeString(headers[headerSalt])
+	if err != nil {
+		return "", nil, fmt.Errorf("armor: invalid salt: %w", err)
+	}
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return "", nil, errors.New("armor: ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	data, err = aead.Open(nil, nonce, ciphertext, []byte(blockType))
+	if err != nil {
+		return "", nil, ErrWrongPassphrase
+	}
+	return blockType, data, nil
+}
+
+func newAEAD(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.NewX(key)
+}
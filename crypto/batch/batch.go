@@ -1,16 +1,47 @@
 package batch
 
 import (
+	"fmt"
+
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/crypto/secp256k1"
+	"github.com/cometbft/cometbft/v2/crypto/secp256k1eth"
 )
 
+// ErrUnsupportedKeyType is returned when a key whose type does not support
+// batch verification is added to a batch verifier created by NewBatchVerifier.
+type ErrUnsupportedKeyType struct {
+	KeyType string
+}
+
+func (e ErrUnsupportedKeyType) Error() string {
+	return fmt.Sprintf("batch verification is not supported for key type %s", e.KeyType)
+}
+
 // CreateBatchVerifier checks if a key type implements the batch verifier interface.
-// Currently only ed25519 supports batch verification.
+// ed25519, secp256k1, secp256k1eth (if enabled) and bls12381 (if enabled)
+// support batch verification.
+//
+// ECDSA signatures cannot be verified as a batch: the secp256k1 and
+// secp256k1eth batch verifiers verify each signature on its own, spread over
+// GOMAXPROCS goroutines. They are faster than sequential verification only on
+// several cores, and are slightly slower on a single one.
 func CreateBatchVerifier(pk crypto.PubKey) (crypto.BatchVerifier, bool) {
+	if !SupportsBatchVerifier(pk) {
+		return nil, false
+	}
+
 	switch pk.Type() {
 	case ed25519.KeyType:
 		return ed25519.NewBatchVerifier(), true
+	case secp256k1.KeyType:
+		return secp256k1.NewBatchVerifier(), true
+	case secp256k1eth.KeyType:
+		return secp256k1eth.NewBatchVerifier(), true
+	case bls12381.KeyType:
+		return bls12381.NewBatchVerifier(), true
 	default:
 		return nil, false
 	}
@@ -24,9 +55,83 @@ func SupportsBatchVerifier(pk crypto.PubKey) bool {
 	}
 
 	switch pk.Type() {
-	case ed25519.KeyType:
+	case ed25519.KeyType, secp256k1.KeyType:
 		return true
+	case secp256k1eth.KeyType:
+		return secp256k1eth.Enabled
+	case bls12381.KeyType:
+		return bls12381.Enabled
 	default:
 		return false
 	}
 }
+
+// NewBatchVerifier returns a batch verifier which accepts keys of any type
+// supporting batch verification. The entries are grouped per key type, and
+// each group is verified by the batch verifier of its key type.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &mixedBatchVerifier{
+		verifiers: make(map[string]*typedBatchVerifier),
+	}
+}
+
+type typedBatchVerifier struct {
+	crypto.BatchVerifier
+	size int
+}
+
+// batchEntry locates an entry of a mixedBatchVerifier in the batch verifier
+// of its key type.
+type batchEntry struct {
+	verifier *typedBatchVerifier
+	idx      int
+}
+
+type mixedBatchVerifier struct {
+	verifiers map[string]*typedBatchVerifier
+	entries   []batchEntry
+}
+
+var _ crypto.BatchVerifier = &mixedBatchVerifier{}
+
+func (b *mixedBatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	if key == nil {
+		return ErrUnsupportedKeyType{}
+	}
+
+	v, ok := b.verifiers[key.Type()]
+	if !ok {
+		bv, ok := CreateBatchVerifier(key)
+		if !ok {
+			return ErrUnsupportedKeyType{KeyType: key.Type()}
+		}
+		v = &typedBatchVerifier{BatchVerifier: bv}
+		b.verifiers[key.Type()] = v
+	}
+
+	if err := v.Add(key, msg, signature); err != nil {
+		return err
+	}
+	b.entries = append(b.entries, batchEntry{verifier: v, idx: v.size})
+	v.size++
+
+	return nil
+}
+
+func (b *mixedBatchVerifier) Verify() (bool, []bool) {
+	results := make(map[*typedBatchVerifier][]bool, len(b.verifiers))
+	allValid := len(b.entries) > 0
+	for _, v := range b.verifiers {
+		ok, valid := v.Verify()
+		allValid = allValid && ok
+		results[v] = valid
+	}
+
+	valid := make([]bool, len(b.entries))
+	for i, entry := range b.entries {
+		if res := results[entry.verifier]; entry.idx < len(res) {
+			valid[i] = res[entry.idx]
+		}
+	}
+	return allValid, valid
+}
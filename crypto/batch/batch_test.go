@@ -0,0 +1,69 @@
+package batch_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/batch"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/crypto/secp256k1"
+)
+
+func TestNewBatchVerifier_MixedKeyTypes(t *testing.T) {
+	privKeys := []crypto.PrivKey{
+		ed25519.GenPrivKey(),
+		secp256k1.GenPrivKey(),
+		ed25519.GenPrivKey(),
+		secp256k1.GenPrivKey(),
+	}
+	if bls12381.Enabled {
+		for i := 0; i < 2; i++ {
+			privKey, err := bls12381.GenPrivKey()
+			require.NoError(t, err)
+			privKeys = append(privKeys, privKey)
+		}
+	}
+
+	for invalid := -1; invalid < len(privKeys); invalid++ {
+		v := batch.NewBatchVerifier()
+		for i, privKey := range privKeys {
+			msg := []byte(fmt.Sprintf("message %d", i))
+			sig, err := privKey.Sign(msg)
+			require.NoError(t, err)
+			if i == invalid {
+				msg = []byte("wrong")
+			}
+			require.NoError(t, v.Add(privKey.PubKey(), msg, sig))
+		}
+
+		ok, valid := v.Verify()
+		assert.Equal(t, invalid == -1, ok)
+		require.Len(t, valid, len(privKeys))
+		for i, sigValid := range valid {
+			assert.Equal(t, i != invalid, sigValid, "signature %d (invalid %d)", i, invalid)
+		}
+	}
+}
+
+func TestNewBatchVerifier_UnsupportedKeyType(t *testing.T) {
+	v := batch.NewBatchVerifier()
+	err := v.Add(unsupportedPubKey{}, []byte("msg"), []byte("sig"))
+	require.ErrorAs(t, err, &batch.ErrUnsupportedKeyType{})
+
+	ok, valid := v.Verify()
+	assert.False(t, ok)
+	assert.Empty(t, valid)
+}
+
+type unsupportedPubKey struct {
+	crypto.PubKey
+}
+
+func (unsupportedPubKey) Type() string {
+	return "unsupported"
+}
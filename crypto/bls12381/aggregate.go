@@ -0,0 +1,17 @@
+//go:build !bls12381
+
+package bls12381
+
+import (
+	"github.com/cometbft/cometbft/v2/crypto"
+)
+
+// AggregateSignatures returns ErrDisabled.
+func AggregateSignatures([][]byte) ([]byte, error) {
+	return nil, ErrDisabled
+}
+
+// VerifyAggregateSignature always returns false.
+func VerifyAggregateSignature([]crypto.PubKey, [][]byte, []byte) bool {
+	return false
+}
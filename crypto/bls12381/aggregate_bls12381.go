@@ -0,0 +1,68 @@
+//go:build bls12381
+
+package bls12381
+
+import (
+	"errors"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+)
+
+// ErrNoSignatures is returned when there are no signatures to aggregate.
+var ErrNoSignatures = errors.New("bls12381: no signatures to aggregate")
+
+// AggregateSignatures aggregates the given signatures into a single one, which
+// can be verified with VerifyAggregateSignature. An aggregated signature can
+// itself be aggregated with further signatures.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, ErrNoSignatures
+	}
+
+	var agg blstAggregateSignature
+	// Group check the signatures, so that invalid points are not aggregated.
+	if !agg.AggregateCompressed(sigs, true) {
+		return nil, ErrDeserialization
+	}
+	return agg.ToAffine().Compress(), nil
+}
+
+// VerifyAggregateSignature verifies that sig aggregates the signatures of
+// msgs[i] by pubKeys[i], for all i.
+//
+// The messages must be distinct: as the keys come with no proof of possession,
+// an aggregated signature over duplicate messages would be open to rogue key
+// attacks.
+func VerifyAggregateSignature(pubKeys []crypto.PubKey, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) {
+		return false
+	}
+
+	seen := make(map[string]struct{}, len(msgs))
+	for _, msg := range msgs {
+		if _, ok := seen[string(msg)]; ok {
+			return false
+		}
+		seen[string(msg)] = struct{}{}
+	}
+
+	pks := make([]*blstPublicKey, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		switch pk := pubKey.(type) {
+		case *PubKey:
+			pks[i] = pk.pk
+		case PubKey:
+			pks[i] = pk.pk
+		}
+		if pks[i] == nil {
+			return false
+		}
+	}
+
+	signature := new(blstSignature).Uncompress(sig)
+	if signature == nil {
+		return false
+	}
+	// The keys were validated when they were created.
+	return signature.AggregateVerify(true, pks, false, msgs, dstMinPk)
+}
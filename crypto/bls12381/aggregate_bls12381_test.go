@@ -0,0 +1,75 @@
+//go:build bls12381
+
+package bls12381_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
+)
+
+func TestAggregateSignatures(t *testing.T) {
+	const n = 4
+	var (
+		pubKeys = make([]crypto.PubKey, n)
+		msgs    = make([][]byte, n)
+		sigs    = make([][]byte, n)
+	)
+	for i := 0; i < n; i++ {
+		privKey, err := bls12381.GenPrivKey()
+		require.NoError(t, err)
+		pubKeys[i] = privKey.PubKey()
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], err = privKey.Sign(msgs[i])
+		require.NoError(t, err)
+	}
+
+	aggSig, err := bls12381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	assert.Len(t, aggSig, bls12381.SignatureLength)
+	assert.True(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, aggSig))
+
+	// An aggregated signature can be aggregated further.
+	partial, err := bls12381.AggregateSignatures(sigs[:2])
+	require.NoError(t, err)
+	aggSig2, err := bls12381.AggregateSignatures(append([][]byte{partial}, sigs[2:]...))
+	require.NoError(t, err)
+	assert.Equal(t, aggSig, aggSig2)
+
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys[:n-1], msgs[:n-1], aggSig))
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, msgs[:n-1], aggSig))
+	assert.False(t, bls12381.VerifyAggregateSignature(nil, nil, aggSig))
+
+	wrongMsgs := append([][]byte{[]byte("wrong")}, msgs[1:]...)
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, wrongMsgs, aggSig))
+
+	_, err = bls12381.AggregateSignatures(nil)
+	require.ErrorIs(t, err, bls12381.ErrNoSignatures)
+	_, err = bls12381.AggregateSignatures([][]byte{[]byte("invalid")})
+	require.ErrorIs(t, err, bls12381.ErrDeserialization)
+}
+
+func TestVerifyAggregateSignature_DuplicateMessages(t *testing.T) {
+	msg := []byte("message")
+	var (
+		pubKeys []crypto.PubKey
+		sigs    [][]byte
+	)
+	for i := 0; i < 2; i++ {
+		privKey, err := bls12381.GenPrivKey()
+		require.NoError(t, err)
+		pubKeys = append(pubKeys, privKey.PubKey())
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+		sigs = append(sigs, sig)
+	}
+
+	aggSig, err := bls12381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, [][]byte{msg, msg}, aggSig))
+}
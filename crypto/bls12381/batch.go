@@ -0,0 +1,28 @@
+//go:build !bls12381
+
+package bls12381
+
+import (
+	"github.com/cometbft/cometbft/v2/crypto"
+)
+
+// Compile-time type assertion.
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier represents a BLS batch verifier noop when blst is not set as a build flag and cgo is disabled.
+type BatchVerifier struct{}
+
+// NewBatchVerifier returns a batch verifier which accepts no signatures.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add returns ErrDisabled.
+func (*BatchVerifier) Add(crypto.PubKey, []byte, []byte) error {
+	return ErrDisabled
+}
+
+// Verify always returns false.
+func (*BatchVerifier) Verify() (bool, []bool) {
+	return false, nil
+}
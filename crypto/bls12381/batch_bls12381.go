@@ -0,0 +1,103 @@
+//go:build bls12381
+
+package bls12381
+
+import (
+	"errors"
+
+	blst "github.com/supranational/blst/bindings/go"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+)
+
+var (
+	// ErrNotBLS12381Key is returned when a key of another type is added to a
+	// BatchVerifier.
+	ErrNotBLS12381Key = errors.New("bls12381: pubkey is not BLS12-381")
+	// ErrInvalidSignature is returned when a signature of the wrong length is
+	// added to a BatchVerifier.
+	ErrInvalidSignature = errors.New("bls12381: invalid signature")
+)
+
+// randBits is the number of random bits by which each signature is weighted
+// in batch verification.
+const randBits = 64
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for BLS12-381.
+//
+// The signatures are verified together with a single multi-pairing, each
+// weighted by a random scalar so that invalid signatures cannot cancel each
+// other out. If the batch fails, the signatures are verified one by one to
+// find the invalid ones.
+type BatchVerifier struct {
+	pubKeys []*blstPublicKey
+	msgs    []blst.Message
+	sigs    [][]byte
+}
+
+// NewBatchVerifier returns a new BLS12-381 batch verifier.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add appends an entry into the BatchVerifier.
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	var pk *blstPublicKey
+	switch k := key.(type) {
+	case *PubKey:
+		pk = k.pk
+	case PubKey:
+		pk = k.pk
+	}
+	if pk == nil {
+		return ErrNotBLS12381Key
+	}
+
+	// check that the signature is the correct length
+	if len(signature) != SignatureLength {
+		return ErrInvalidSignature
+	}
+
+	b.pubKeys = append(b.pubKeys, pk)
+	b.msgs = append(b.msgs, msg)
+	b.sigs = append(b.sigs, signature)
+
+	return nil
+}
+
+// Verify verifies all the entries in the BatchVerifier.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	n := len(b.sigs)
+	valid := make([]bool, n)
+	if n == 0 {
+		return false, valid
+	}
+
+	sigs := make([]*blstSignature, n)
+	allDecoded := true
+	for i, sig := range b.sigs {
+		sigs[i] = new(blstSignature).Uncompress(sig)
+		allDecoded = allDecoded && sigs[i] != nil
+	}
+
+	// The keys were validated when they were created.
+	if allDecoded && new(blstSignature).MultipleAggregateVerify(
+		sigs, true, b.pubKeys, false, b.msgs, dstMinPk, randScalar, randBits) {
+		for i := range valid {
+			valid[i] = true
+		}
+		return true, valid
+	}
+
+	for i, sig := range sigs {
+		valid[i] = sig != nil && sig.Verify(true, b.pubKeys[i], false, b.msgs[i], dstMinPk)
+	}
+	return false, valid
+}
+
+// randScalar sets s to a random scalar.
+func randScalar(s *blst.Scalar) {
+	s.FromBEndian(crypto.CRandBytes(blst.BLST_SCALAR_BYTES))
+}
@@ -0,0 +1,70 @@
+//go:build bls12381
+
+package bls12381_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	const n = 10
+	var (
+		v    = bls12381.NewBatchVerifier()
+		bad  = bls12381.NewBatchVerifier()
+		sigs [][]byte
+	)
+	for i := 0; i < n; i++ {
+		priv, err := bls12381.GenPrivKey()
+		require.NoError(t, err)
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		sigs = append(sigs, sig)
+
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+		if i == 3 {
+			msg = []byte("wrong")
+		}
+		require.NoError(t, bad.Add(priv.PubKey(), msg, sig))
+	}
+
+	ok, valid := v.Verify()
+	require.True(t, ok)
+	require.Len(t, valid, n)
+	for i, sigValid := range valid {
+		assert.True(t, sigValid, "signature %d", i)
+	}
+
+	ok, valid = bad.Verify()
+	require.False(t, ok)
+	require.Len(t, valid, n)
+	for i, sigValid := range valid {
+		assert.Equal(t, i != 3, sigValid, "signature %d", i)
+	}
+
+	// Swapped signatures of the same key would pass if the signatures were
+	// simply aggregated.
+	priv, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	msg0, msg1 := []byte("message 0"), []byte("message 1")
+	sig0, err := priv.Sign(msg0)
+	require.NoError(t, err)
+	sig1, err := priv.Sign(msg1)
+	require.NoError(t, err)
+	swapped := bls12381.NewBatchVerifier()
+	require.NoError(t, swapped.Add(priv.PubKey(), msg0, sig1))
+	require.NoError(t, swapped.Add(priv.PubKey(), msg1, sig0))
+	ok, valid = swapped.Verify()
+	require.False(t, ok)
+	assert.Equal(t, []bool{false, false}, valid)
+
+	require.ErrorIs(t, v.Add(priv.PubKey(), []byte("msg"), []byte("short")), bls12381.ErrInvalidSignature)
+	require.ErrorIs(t, v.Add(ed25519.GenPrivKey().PubKey(), []byte("msg"), sigs[0]), bls12381.ErrNotBLS12381Key)
+}
@@ -37,8 +37,8 @@ var (
 type (
 	blstPublicKey          = blst.P1Affine
 	blstSignature          = blst.P2Affine
-	blstAggregateSignature = blst.P1Aggregate
-	blstAggregatePublicKey = blst.P2Aggregate
+	blstAggregateSignature = blst.P2Aggregate
+	blstAggregatePublicKey = blst.P1Aggregate
 )
 
 // -------------------------------------.
@@ -0,0 +1,46 @@
+// Package parallel verifies signatures concurrently. It backs the batch
+// verifiers of the key types whose signatures cannot be verified as a batch.
+package parallel
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Verify calls verify for every index in [0, n), spreading the calls over up
+// to GOMAXPROCS goroutines. It returns true if every call returned true, along
+// with the result of each call.
+func Verify(n int, verify func(i int) bool) (bool, []bool) {
+	valid := make([]bool, n)
+	if n == 0 {
+		return false, valid
+	}
+
+	var (
+		next    atomic.Int64
+		wg      sync.WaitGroup
+		workers = min(runtime.GOMAXPROCS(0), n)
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				valid[i] = verify(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, ok := range valid {
+		if !ok {
+			return false, valid
+		}
+	}
+	return true, valid
+}
@@ -0,0 +1,25 @@
+package parallel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/v2/crypto/internal/parallel"
+)
+
+func TestVerify(t *testing.T) {
+	ok, valid := parallel.Verify(100, func(int) bool { return true })
+	assert.True(t, ok)
+	assert.Len(t, valid, 100)
+
+	ok, valid = parallel.Verify(100, func(i int) bool { return i != 42 })
+	assert.False(t, ok)
+	for i, v := range valid {
+		assert.Equal(t, i != 42, v, "index %d", i)
+	}
+
+	ok, valid = parallel.Verify(0, func(int) bool { return true })
+	assert.False(t, ok)
+	assert.Empty(t, valid)
+}
@@ -0,0 +1,57 @@
+package secp256k1_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/secp256k1"
+)
+
+// BenchmarkVerifyBatch compares the batch verifier, which verifies the
+// signatures concurrently, with verifying them one after the other, for
+// commits of typical sizes.
+func BenchmarkVerifyBatch(b *testing.B) {
+	msg := []byte("BatchVerifyTest")
+
+	for _, sigsCount := range []int{4, 32, 100, 175} {
+		// Pre-generate all of the keys, and signatures, but do not
+		// benchmark key-generation and signing.
+		pubs := make([]crypto.PubKey, 0, sigsCount)
+		sigs := make([][]byte, 0, sigsCount)
+		for i := 0; i < sigsCount; i++ {
+			priv := secp256k1.GenPrivKey()
+			sig, err := priv.Sign(msg)
+			require.NoError(b, err)
+			pubs = append(pubs, priv.PubKey())
+			sigs = append(sigs, sig)
+		}
+
+		b.Run(fmt.Sprintf("sig-count-%d/batch", sigsCount), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				v := secp256k1.NewBatchVerifier()
+				for i := 0; i < sigsCount; i++ {
+					err := v.Add(pubs[i], msg, sigs[i])
+					require.NoError(b, err)
+				}
+				if ok, _ := v.Verify(); !ok {
+					b.Fatal("signature set failed batch verification")
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("sig-count-%d/sequential", sigsCount), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for i := 0; i < sigsCount; i++ {
+					if !pubs[i].VerifySignature(msg, sigs[i]) {
+						b.Fatal("signature failed verification")
+					}
+				}
+			}
+		})
+	}
+}
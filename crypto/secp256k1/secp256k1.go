@@ -2,6 +2,7 @@ package secp256k1
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -11,6 +12,7 @@ import (
 	"golang.org/x/crypto/ripemd160" //nolint: gosec,staticcheck // necessary for Bitcoin address format
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/internal/parallel"
 	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
 )
 
@@ -23,6 +25,11 @@ const (
 	PrivKeySize = 32
 )
 
+var (
+	ErrNotSecp256k1Key  = errors.New("secp256k1: pubkey is not secp256k1")
+	ErrInvalidSignature = errors.New("secp256k1: invalid signature")
+)
+
 func init() {
 	cmtjson.RegisterType(PubKey{}, PubKeyName)
 	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
@@ -224,3 +231,45 @@ func signatureFromBytes(sigStr []byte) *ecdsa.Signature {
 	s.SetByteSlice(sigStr[32:64])
 	return ecdsa.NewSignature(&r, &s)
 }
+
+// -------------------------------------
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for secp256k1.
+//
+// ECDSA signatures cannot be verified as a batch, so the signatures are
+// verified concurrently instead.
+type BatchVerifier struct {
+	pubKeys []PubKey
+	msgs    [][]byte
+	sigs    [][]byte
+}
+
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pk, ok := key.(PubKey)
+	if !ok {
+		return ErrNotSecp256k1Key
+	}
+
+	// check that the signature is the correct length
+	if len(signature) != 64 {
+		return ErrInvalidSignature
+	}
+
+	b.pubKeys = append(b.pubKeys, pk)
+	b.msgs = append(b.msgs, msg)
+	b.sigs = append(b.sigs, signature)
+
+	return nil
+}
+
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	return parallel.Verify(len(b.sigs), func(i int) bool {
+		return b.pubKeys[i].VerifySignature(b.msgs[i], b.sigs[i])
+	})
+}
@@ -2,6 +2,7 @@ package secp256k1_test
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/crypto/secp256k1"
 )
 
@@ -123,3 +125,29 @@ func TestGenPrivKeySecp256k1(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchVerifier(t *testing.T) {
+	v := secp256k1.NewBatchVerifier()
+
+	for i := 0; i < 10; i++ {
+		priv := secp256k1.GenPrivKey()
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		if i == 3 {
+			msg = []byte("wrong")
+		}
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+	}
+
+	ok, valid := v.Verify()
+	require.False(t, ok)
+	require.Len(t, valid, 10)
+	for i, sigValid := range valid {
+		assert.Equal(t, i != 3, sigValid, "signature %d", i)
+	}
+
+	priv := secp256k1.GenPrivKey()
+	require.ErrorIs(t, v.Add(priv.PubKey(), []byte("msg"), []byte("short")), secp256k1.ErrInvalidSignature)
+	require.ErrorIs(t, v.Add(ed25519.GenPrivKey().PubKey(), []byte("msg"), make([]byte, 64)), secp256k1.ErrNotSecp256k1Key)
+}
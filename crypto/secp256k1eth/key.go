@@ -95,3 +95,29 @@ func (PubKey) Bytes() []byte {
 func (PubKey) Type() string {
 	return KeyType
 }
+
+// ===============================================================================================
+// Batch Verifier
+// ===============================================================================================
+
+// Compile-time type assertion.
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier represents a stub of the secp256k1eth batch verifier when
+// build flag `secp256k1eth` is not set.
+type BatchVerifier struct{}
+
+// NewBatchVerifier returns a batch verifier which accepts no signatures.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add returns ErrDisabled.
+func (*BatchVerifier) Add(crypto.PubKey, []byte, []byte) error {
+	return ErrDisabled
+}
+
+// Verify always returns false.
+func (*BatchVerifier) Verify() (bool, []bool) {
+	return false, nil
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"crypto/subtle"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -14,6 +15,7 @@ import (
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/internal/parallel"
 	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
 )
 
@@ -24,6 +26,11 @@ const (
 	Enabled = true
 )
 
+var (
+	ErrNotSecp256k1EthKey = errors.New("secp256k1eth: pubkey is not secp256k1eth")
+	ErrInvalidSignature   = errors.New("secp256k1eth: invalid signature")
+)
+
 func init() {
 	cmtjson.RegisterType(PubKey{}, PubKeyName)
 	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
@@ -184,3 +191,45 @@ func (pubKey PubKey) VerifySignature(msg []byte, sigStr []byte) bool {
 	hash := ethcrypto.Keccak256(msg)
 	return ethcrypto.VerifySignature(pubKey, hash, sigStr[:64])
 }
+
+// -------------------------------------
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for secp256k1eth.
+//
+// ECDSA signatures cannot be verified as a batch, so the signatures are
+// verified concurrently instead.
+type BatchVerifier struct {
+	pubKeys []PubKey
+	msgs    [][]byte
+	sigs    [][]byte
+}
+
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pk, ok := key.(PubKey)
+	if !ok {
+		return ErrNotSecp256k1EthKey
+	}
+
+	// check that the signature is the correct length
+	if len(signature) != SignatureLength {
+		return ErrInvalidSignature
+	}
+
+	b.pubKeys = append(b.pubKeys, pk)
+	b.msgs = append(b.msgs, msg)
+	b.sigs = append(b.sigs, signature)
+
+	return nil
+}
+
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	return parallel.Verify(len(b.sigs), func(i int) bool {
+		return b.pubKeys[i].VerifySignature(b.msgs[i], b.sigs[i])
+	})
+}
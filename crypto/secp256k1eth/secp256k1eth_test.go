@@ -4,6 +4,7 @@ package secp256k1eth_test
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/crypto/secp256k1eth"
 )
 
@@ -116,3 +118,29 @@ func TestGenPrivKeySecp256k1Eth(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchVerifier(t *testing.T) {
+	v := secp256k1eth.NewBatchVerifier()
+
+	for i := 0; i < 10; i++ {
+		priv := secp256k1eth.GenPrivKey()
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		if i == 3 {
+			msg = []byte("wrong")
+		}
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+	}
+
+	ok, valid := v.Verify()
+	require.False(t, ok)
+	require.Len(t, valid, 10)
+	for i, sigValid := range valid {
+		assert.Equal(t, i != 3, sigValid, "signature %d", i)
+	}
+
+	priv := secp256k1eth.GenPrivKey()
+	require.ErrorIs(t, v.Add(priv.PubKey(), []byte("msg"), []byte("short")), secp256k1eth.ErrInvalidSignature)
+	require.ErrorIs(t, v.Add(ed25519.GenPrivKey().PubKey(), []byte("msg"), make([]byte, 65)), secp256k1eth.ErrNotSecp256k1EthKey)
+}
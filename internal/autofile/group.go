@@ -317,6 +317,45 @@ func (g *Group) RotateFile() {
 	g.maxIndex++
 }
 
+// Truncate discards the content of the group after offset, counted from the
+// start of the file at MinIndex. The files which start after offset are
+// removed, except for the head, which is emptied. The group must not be
+// started.
+func (g *Group) Truncate(offset int64) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if err := g.headBuf.Flush(); err != nil {
+		return err
+	}
+
+	remaining := offset
+	for index := g.minIndex; index <= g.maxIndex; index++ {
+		path := filePathForIndex(g.Head.Path, index, g.maxIndex)
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case remaining > info.Size():
+			remaining -= info.Size()
+		case remaining > 0 || index == g.maxIndex:
+			if err := os.Truncate(path, remaining); err != nil {
+				return err
+			}
+			remaining = 0
+		default:
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+
+	gInfo := g.readGroupInfo()
+	g.minIndex, g.maxIndex = gInfo.MinIndex, gInfo.MaxIndex
+	return nil
+}
+
 // NewReader returns a new group reader.
 // CONTRACT: Caller must close the returned GroupReader.
 func (g *Group) NewReader(index int) (*GroupReader, error) {
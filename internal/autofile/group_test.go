@@ -196,6 +196,39 @@ func TestWrite(t *testing.T) {
 	destroyTestGroup(t, g)
 }
 
+func TestTruncate(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+
+	for _, data := range []string{"Professor", "Frankenstein", "Monster"} {
+		_, err := g.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, g.FlushAndSync())
+		g.RotateFile()
+	}
+	_, err := g.Write([]byte("Igor"))
+	require.NoError(t, err)
+
+	// Truncate in the middle of the second file.
+	require.NoError(t, g.Truncate(int64(len("ProfessorFranken"))))
+	assertGroupInfo(t, g.ReadGroupInfo(), 2, int64(len("ProfessorFranken")), 0)
+	assert.Equal(t, 0, g.MinIndex())
+	assert.Equal(t, 2, g.MaxIndex())
+
+	gr, err := g.NewReader(0)
+	require.NoError(t, err)
+	read, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, "ProfessorFranken", string(read))
+	require.NoError(t, gr.Close())
+
+	// Truncate everything.
+	require.NoError(t, g.Truncate(0))
+	assertGroupInfo(t, g.ReadGroupInfo(), 0, 0, 0)
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
+
 // test that Read reads the required amount of bytes from all the files in the
 // group and returns no error if n == size of the given slice.
 func TestGroupReaderRead(t *testing.T) {
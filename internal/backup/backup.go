@@ -0,0 +1,328 @@
+// Package backup takes consistent backups of the data of a running node, and
+// restores them.
+//
+// A backup is started while commits are paused, between two heights: the
+// databases are checkpointed, using the checkpoints of the backend when it
+// supports them (pebble) and its copy-on-write snapshots otherwise
+// (goleveldb), and the files written by the node, the consensus WAL, the
+// private validator state and the cold storage segments, are opened along
+// with their sizes. Commits then resume while the backup is completed,
+// copying the snapshots and the files up to their recorded sizes. The other
+// database backends aren't supported, as they would have to be copied while
+// commits are paused.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/v2/config"
+	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
+)
+
+const (
+	manifestFile           = "manifest.json"
+	dataDir                = "data"
+	walDir                 = "wal"
+	coldStorageDir         = "cold"
+	privValidatorStateFile = "priv_validator_state.json"
+
+	// copyBatchSize is the number of keys written at once when copying a
+	// database.
+	copyBatchSize = 1000
+)
+
+var (
+	// ErrBackupInProgress is returned when a backup is started while another
+	// one is running in the background.
+	ErrBackupInProgress = errors.New("a backup is already in progress")
+	// ErrInvalidBackupName is returned when the name of a backup isn't the
+	// name of a directory.
+	ErrInvalidBackupName = errors.New("the backup name must be a directory name")
+)
+
+// ErrUnsupportedBackend is returned by Begin when a database can't be
+// checkpointed or snapshotted.
+type ErrUnsupportedBackend struct {
+	Name    string
+	Backend string
+}
+
+func (e ErrUnsupportedBackend) Error() string {
+	return fmt.Sprintf("cannot back up the %s database: backend %s not supported, only pebble and goleveldb are",
+		e.Name, e.Backend)
+}
+
+// ErrBackupExists is returned by Begin when the backup directory already
+// exists.
+type ErrBackupExists struct {
+	Dir string
+}
+
+func (e ErrBackupExists) Error() string {
+	return fmt.Sprintf("backup directory %s already exists", e.Dir)
+}
+
+// Manifest describes a backup. It's saved in the backup directory.
+type Manifest struct {
+	ChainID   string            `json:"chain_id"`
+	Height    int64             `json:"height"`
+	AppHash   cmtbytes.HexBytes `json:"app_hash"`
+	Time      time.Time         `json:"time"`
+	DBBackend string            `json:"db_backend"`
+	Databases []string          `json:"databases"`
+	// WALSize is the size of the consensus WAL when the backup was taken. The
+	// WAL is copied up to this position, which follows #ENDHEIGHT Height.
+	WALSize int64 `json:"wal_size"`
+}
+
+// Status is the status of a backup taken in the background.
+type Status struct {
+	Dir     string
+	Running bool
+	// Manifest is the manifest of the completed backup.
+	Manifest Manifest
+	// Err is the error the backup failed with, if any.
+	Err error
+}
+
+// Backup is a backup in progress, started by Begin and completed by Finish.
+type Backup struct {
+	dir      string
+	manifest Manifest
+
+	// copies completing the backup once commits resume
+	pending []func() error
+	// releases the resources held by the pending copies
+	release []func()
+}
+
+// Begin starts a backup in dir, which must not exist, of the given databases,
+// by name in the data directory, and of the files of the node described by
+// config. It must be called while commits are paused. Once they resume, the
+// backup must be completed by Finish, or discarded by Abort.
+func Begin(dir string, config *cfg.Config, manifest Manifest, dbs map[string]dbm.DB) (*Backup, error) {
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil, ErrBackupExists{Dir: dir}
+		}
+		return nil, err
+	}
+	for _, sub := range []string{dataDir, walDir} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o700); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	b := &Backup{dir: dir, manifest: manifest}
+	b.manifest.Databases = make([]string, 0, len(dbs))
+	for name := range dbs {
+		b.manifest.Databases = append(b.manifest.Databases, name)
+	}
+	sort.Strings(b.manifest.Databases)
+	if err := b.begin(config, dbs); err != nil {
+		b.Abort()
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *Backup) begin(config *cfg.Config, dbs map[string]dbm.DB) error {
+	for _, name := range b.manifest.Databases {
+		if err := b.addDB(name, dbs[name]); err != nil {
+			return fmt.Errorf("failed to checkpoint the %s database: %w", name, err)
+		}
+	}
+
+	walFile := config.Consensus.WalFile()
+	walFiles, err := filepath.Glob(walFile + ".*")
+	if err != nil {
+		return err
+	}
+	for _, path := range append(walFiles, walFile) {
+		size, err := b.addFile(path, filepath.Join(walDir, filepath.Base(path)))
+		if err != nil {
+			return err
+		}
+		b.manifest.WALSize += size
+	}
+
+	if _, err := b.addFile(config.PrivValidatorStateFile(), privValidatorStateFile); err != nil {
+		return err
+	}
+
+	if config.Storage.ColdStorage.Enabled {
+		segments, err := filepath.Glob(filepath.Join(config.Storage.ColdStorage.DirPath(), "*"))
+		if err != nil {
+			return err
+		}
+		if err := os.Mkdir(filepath.Join(b.dir, coldStorageDir), 0o700); err != nil {
+			return err
+		}
+		for _, path := range segments {
+			if _, err := b.addFile(path, filepath.Join(coldStorageDir, filepath.Base(path))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addDB checkpoints db in the data directory of the backup: a pebble database
+// is checkpointed at once, and a goleveldb one is snapshotted and copied
+// later.
+func (b *Backup) addDB(name string, db dbm.DB) error {
+	dir := filepath.Join(b.dir, dataDir)
+	switch db := db.(type) {
+	case *dbm.PebbleDB:
+		// Flush the WAL so that the checkpoint has the writes made without sync.
+		return db.DB().Checkpoint(filepath.Join(dir, name+".db"), pebble.WithFlushedWAL())
+
+	case *dbm.GoLevelDB:
+		snap, err := db.DB().GetSnapshot()
+		if err != nil {
+			return err
+		}
+		b.release = append(b.release, snap.Release)
+		b.pending = append(b.pending, func() error {
+			return copyLevelDBSnapshot(snap, name, dir)
+		})
+		return nil
+
+	default:
+		return ErrUnsupportedBackend{Name: name, Backend: b.manifest.DBBackend}
+	}
+}
+
+func copyLevelDBSnapshot(snap *leveldb.Snapshot, name, dir string) error {
+	out, err := dbm.NewGoLevelDB(name, dir)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	it := snap.NewIterator(nil, nil)
+	defer it.Release()
+	w := newBatchWriter(out)
+	for it.Next() {
+		if err := w.set(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+// addFile opens the file at path, if it exists, to copy it up to its current
+// size to rel in the backup. It returns that size.
+func (b *Backup) addFile(path, rel string) (int64, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	size := info.Size()
+	b.release = append(b.release, func() { f.Close() })
+	b.pending = append(b.pending, func() error {
+		out, err := os.OpenFile(filepath.Join(b.dir, rel), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, io.LimitReader(f, size)); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+	return size, nil
+}
+
+// Finish completes the backup once commits resumed, and saves its manifest.
+// If it fails, the backup is discarded.
+func (b *Backup) Finish() (Manifest, error) {
+	for _, copyData := range b.pending {
+		if err := copyData(); err != nil {
+			b.Abort()
+			return Manifest{}, err
+		}
+	}
+	b.close()
+
+	bz, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(b.dir, manifestFile), bz, 0o600)
+	}
+	if err != nil {
+		_ = os.RemoveAll(b.dir)
+		return Manifest{}, err
+	}
+	return b.manifest, nil
+}
+
+// Abort discards the backup.
+func (b *Backup) Abort() {
+	b.close()
+	_ = os.RemoveAll(b.dir)
+}
+
+func (b *Backup) close() {
+	for _, release := range b.release {
+		release()
+	}
+	b.release = nil
+	b.pending = nil
+}
+
+// batchWriter writes key-value pairs to a database in batches.
+type batchWriter struct {
+	db    dbm.DB
+	batch dbm.Batch
+	n     int
+}
+
+func newBatchWriter(db dbm.DB) *batchWriter {
+	return &batchWriter{db: db, batch: db.NewBatch()}
+}
+
+func (w *batchWriter) set(key, value []byte) error {
+	if err := w.batch.Set(key, value); err != nil {
+		return err
+	}
+	w.n++
+	if w.n < copyBatchSize {
+		return nil
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	w.batch, w.n = w.db.NewBatch(), 0
+	return nil
+}
+
+func (w *batchWriter) flush() error {
+	if err := w.batch.WriteSync(); err != nil {
+		return err
+	}
+	return w.batch.Close()
+}
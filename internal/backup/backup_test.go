@@ -0,0 +1,136 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+func testConfig(t *testing.T, backend dbm.BackendType) *cfg.Config {
+	t.Helper()
+	config := test.ResetTestRoot("backup_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	config.DBBackend = string(backend)
+	require.NoError(t, os.MkdirAll(config.DBDir(), 0o700))
+	return config
+}
+
+func setPrivValidatorState(t *testing.T, config *cfg.Config, height int64) {
+	t.Helper()
+	pv := privval.LoadFilePVEmptyState(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pv.LastSignState.Height = height
+	pv.LastSignState.Save()
+}
+
+func TestBackupRestore(t *testing.T) {
+	for _, backend := range []dbm.BackendType{dbm.GoLevelDBBackend, dbm.PebbleDBBackend} {
+		t.Run(string(backend), func(t *testing.T) {
+			config := testConfig(t, backend)
+			blockStoreDB, err := dbm.NewDB("blockstore", backend, config.DBDir())
+			require.NoError(t, err)
+			defer blockStoreDB.Close()
+			require.NoError(t, blockStoreDB.Set([]byte("height"), []byte("5")))
+			evidenceDB, err := dbm.NewDB("evidence", backend, config.DBDir())
+			require.NoError(t, err)
+			defer evidenceDB.Close()
+			require.NoError(t, evidenceDB.Set([]byte("evidence"), []byte("1")))
+
+			walFile := config.Consensus.WalFile()
+			require.NoError(t, os.MkdirAll(filepath.Dir(walFile), 0o700))
+			require.NoError(t, os.WriteFile(walFile+".000", []byte("height 4"), 0o600))
+			require.NoError(t, os.WriteFile(walFile, []byte("height 5"), 0o600))
+			setPrivValidatorState(t, config, 5)
+
+			dir := filepath.Join(config.RootDir, "backup")
+			dbs := map[string]dbm.DB{"blockstore": blockStoreDB, "evidence": evidenceDB}
+			b, err := Begin(dir, config, Manifest{Height: 5, AppHash: []byte{1, 2}, DBBackend: config.DBBackend}, dbs)
+			require.NoError(t, err)
+			_, err = Begin(dir, config, Manifest{Height: 5, DBBackend: config.DBBackend}, dbs)
+			require.ErrorAs(t, err, &ErrBackupExists{})
+
+			// Commits resume before the backup is completed.
+			require.NoError(t, blockStoreDB.Set([]byte("height"), []byte("6")))
+			f, err := os.OpenFile(walFile, os.O_APPEND|os.O_WRONLY, 0o600)
+			require.NoError(t, err)
+			_, err = f.WriteString(", height 6")
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			setPrivValidatorState(t, config, 6)
+
+			manifest, err := b.Finish()
+			require.NoError(t, err)
+			require.Equal(t, []string{"blockstore", "evidence"}, manifest.Databases)
+			require.EqualValues(t, len("height 4")+len("height 5"), manifest.WALSize)
+			loaded, err := LoadManifest(dir)
+			require.NoError(t, err)
+			require.Equal(t, manifest, loaded)
+
+			// Restore to another node.
+			restoreConfig := testConfig(t, backend)
+			_, err = Restore(dir, restoreConfig)
+			require.NoError(t, err)
+
+			restoredDB, err := dbm.NewDB("blockstore", backend, restoreConfig.DBDir())
+			require.NoError(t, err)
+			value, err := restoredDB.Get([]byte("height"))
+			require.NoError(t, err)
+			require.Equal(t, "5", string(value))
+			require.NoError(t, restoredDB.Close())
+			restoredDB, err = dbm.NewDB("evidence", backend, restoreConfig.DBDir())
+			require.NoError(t, err)
+			value, err = restoredDB.Get([]byte("evidence"))
+			require.NoError(t, err)
+			require.Equal(t, "1", string(value))
+			require.NoError(t, restoredDB.Close())
+
+			wal, err := os.ReadFile(restoreConfig.Consensus.WalFile())
+			require.NoError(t, err)
+			require.Equal(t, "height 5", string(wal))
+			state, err := loadPrivValidatorState(restoreConfig.PrivValidatorStateFile())
+			require.NoError(t, err)
+			require.EqualValues(t, 5, state.Height)
+
+			// The node now has data.
+			_, err = Restore(dir, restoreConfig)
+			require.ErrorAs(t, err, &ErrNodeDataExists{})
+		})
+	}
+}
+
+func TestBackupUnsupportedBackend(t *testing.T) {
+	config := testConfig(t, dbm.MemDBBackend)
+	dir := filepath.Join(config.RootDir, "backup")
+	_, err := Begin(dir, config, Manifest{Height: 5, DBBackend: config.DBBackend},
+		map[string]dbm.DB{"blockstore": dbm.NewMemDB()})
+	require.ErrorAs(t, err, &ErrUnsupportedBackend{})
+	require.NoDirExists(t, dir)
+}
+
+func TestRestoreKeepsLaterPrivValidatorState(t *testing.T) {
+	config := testConfig(t, dbm.GoLevelDBBackend)
+	setPrivValidatorState(t, config, 5)
+	dir := filepath.Join(config.RootDir, "backup")
+	b, err := Begin(dir, config, Manifest{Height: 5, DBBackend: config.DBBackend}, nil)
+	require.NoError(t, err)
+	_, err = b.Finish()
+	require.NoError(t, err)
+
+	restoreConfig := testConfig(t, dbm.PebbleDBBackend)
+	_, err = Restore(dir, restoreConfig)
+	require.ErrorAs(t, err, &ErrBackendMismatch{})
+
+	restoreConfig.DBBackend = config.DBBackend
+	setPrivValidatorState(t, restoreConfig, 8)
+	_, err = Restore(dir, restoreConfig)
+	require.NoError(t, err)
+	state, err := loadPrivValidatorState(restoreConfig.PrivValidatorStateFile())
+	require.NoError(t, err)
+	require.EqualValues(t, 8, state.Height)
+}
@@ -0,0 +1,189 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+// ErrBackendMismatch is returned by Restore when the backup was taken with
+// another database backend than the configured one.
+type ErrBackendMismatch struct {
+	Backup     string
+	Configured string
+}
+
+func (e ErrBackendMismatch) Error() string {
+	return fmt.Sprintf("the backup uses the %s database backend, but %s is configured", e.Backup, e.Configured)
+}
+
+// ErrNodeDataExists is returned by Restore when the node already has data
+// which the backup would overwrite.
+type ErrNodeDataExists struct {
+	Path string
+}
+
+func (e ErrNodeDataExists) Error() string {
+	return fmt.Sprintf("%s already exists, the backup must be restored to a node without data", e.Path)
+}
+
+// LoadManifest loads the manifest of the backup in dir.
+func LoadManifest(dir string) (Manifest, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read the backup manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(bz, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to decode the backup manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// Restore copies the backup in dir to the node described by config, which must
+// not have any of the databases, WAL files or cold storage segments of the
+// backup. The private validator state of the node is only replaced if the
+// backup's is more recent, so that the validator never signs twice at a
+// height.
+func Restore(dir string, config *cfg.Config) (Manifest, error) {
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if manifest.DBBackend != config.DBBackend {
+		return Manifest{}, ErrBackendMismatch{Backup: manifest.DBBackend, Configured: config.DBBackend}
+	}
+
+	dirs := map[string]string{
+		dataDir: config.DBDir(),
+		walDir:  filepath.Dir(config.Consensus.WalFile()),
+	}
+	if _, err := os.Stat(filepath.Join(dir, coldStorageDir)); err == nil {
+		dirs[coldStorageDir] = config.Storage.ColdStorage.DirPath()
+	}
+	for from, to := range dirs {
+		if err := checkNoConflict(filepath.Join(dir, from), to); err != nil {
+			return Manifest{}, err
+		}
+	}
+	for from, to := range dirs {
+		if err := copyDir(filepath.Join(dir, from), to); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	if err := restorePrivValidatorState(filepath.Join(dir, privValidatorStateFile), config.PrivValidatorStateFile()); err != nil {
+		return Manifest{}, fmt.Errorf("failed to restore the private validator state: %w", err)
+	}
+	return manifest, nil
+}
+
+// checkNoConflict returns an error if an entry of the directory from exists in
+// the directory to.
+func checkNoConflict(from, to string) error {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(to, entry.Name())
+		if _, err := os.Stat(path); err == nil {
+			return ErrNodeDataExists{Path: path}
+		}
+	}
+	return nil
+}
+
+// copyDir copies the directory from into to, recursively.
+func copyDir(from, to string) error {
+	return filepath.WalkDir(from, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o700)
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(from, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// restorePrivValidatorState replaces the private validator state at path with
+// the one of the backup at from, unless it's more recent.
+func restorePrivValidatorState(from, path string) error {
+	backupState, err := loadPrivValidatorState(from)
+	if err != nil || backupState == nil {
+		return err
+	}
+	currentState, err := loadPrivValidatorState(path)
+	if err != nil {
+		return err
+	}
+	if currentState != nil && !signedBefore(currentState, backupState) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return copyFile(from, path)
+}
+
+// loadPrivValidatorState loads the private validator state at path, or returns
+// nil if there is none.
+func loadPrivValidatorState(path string) (*privval.FilePVLastSignState, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	state := new(privval.FilePVLastSignState)
+	if err := cmtjson.Unmarshal(bz, state); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// signedBefore returns whether a was last signed at a lower height, round and
+// step than b.
+func signedBefore(a, b *privval.FilePVLastSignState) bool {
+	if a.Height != b.Height {
+		return a.Height < b.Height
+	}
+	if a.Round != b.Round {
+		return a.Round < b.Round
+	}
+	return a.Step < b.Step
+}
@@ -0,0 +1,155 @@
+package blocksync
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+)
+
+// Checkpoint is a block trusted by the operator, identified by its height and
+// hash.
+type Checkpoint struct {
+	Height int64             `json:"height"`
+	Hash   cmtbytes.HexBytes `json:"hash"`
+}
+
+// ValidateBasic performs basic validation.
+func (c Checkpoint) ValidateBasic() error {
+	if c.Height <= 0 {
+		return fmt.Errorf("checkpoint height must be positive, got %d", c.Height)
+	}
+	if len(c.Hash) != tmhash.Size {
+		return fmt.Errorf("expected checkpoint hash of %d bytes, got %d", tmhash.Size, len(c.Hash))
+	}
+	return nil
+}
+
+// ParseCheckpoint parses a checkpoint in the "height:hash" format, with a
+// hex-encoded hash.
+func ParseCheckpoint(s string) (Checkpoint, error) {
+	heightStr, hashStr, ok := strings.Cut(s, ":")
+	if !ok {
+		return Checkpoint{}, fmt.Errorf("checkpoint %q: expected height:hash", s)
+	}
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("checkpoint %q: invalid height: %w", s, err)
+	}
+	hash, err := hex.DecodeString(hashStr)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("checkpoint %q: invalid hash: %w", s, err)
+	}
+	checkpoint := Checkpoint{Height: height, Hash: hash}
+	if err := checkpoint.ValidateBasic(); err != nil {
+		return Checkpoint{}, fmt.Errorf("checkpoint %q: %w", s, err)
+	}
+	return checkpoint, nil
+}
+
+// checkpointsFile is the JSON encoding of a signed checkpoints file.
+type checkpointsFile struct {
+	Checkpoints []Checkpoint `json:"checkpoints"`
+	Signature   []byte       `json:"signature"`
+}
+
+// CheckpointsSignBytes returns the bytes that are signed in a checkpoints
+// file: the JSON encoding of the checkpoints.
+func CheckpointsSignBytes(checkpoints []Checkpoint) ([]byte, error) {
+	return cmtjson.Marshal(checkpoints)
+}
+
+// LoadCheckpointsFile loads the checkpoints from a JSON file, after checking
+// that they were signed by signer.
+func LoadCheckpointsFile(path string, signer crypto.PubKey) ([]Checkpoint, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints file %v: %w", path, err)
+	}
+	var file checkpointsFile
+	if err := cmtjson.Unmarshal(bz, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoints file %v: %w", path, err)
+	}
+
+	signBytes, err := CheckpointsSignBytes(file.Checkpoints)
+	if err != nil {
+		return nil, err
+	}
+	if !signer.VerifySignature(signBytes, file.Signature) {
+		return nil, fmt.Errorf("checkpoints file %v: invalid signature", path)
+	}
+
+	for _, checkpoint := range file.Checkpoints {
+		if err := checkpoint.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("checkpoints file %v: %w", path, err)
+		}
+	}
+	return file.Checkpoints, nil
+}
+
+// CheckpointsFromConfig returns the trusted checkpoints from the config and
+// the checkpoints file, if any, sorted by height. It returns an error if two
+// checkpoints at the same height have different hashes.
+func CheckpointsFromConfig(config *cfg.BlockSyncConfig) ([]Checkpoint, error) {
+	checkpoints := make([]Checkpoint, 0, len(config.TrustedCheckpoints))
+	for _, s := range config.TrustedCheckpoints {
+		checkpoint, err := ParseCheckpoint(s)
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	if config.CheckpointsFile != "" {
+		signerBz, err := hex.DecodeString(config.CheckpointsSigner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoints signer: %w", err)
+		}
+		if len(signerBz) != ed25519.PubKeySize {
+			return nil, fmt.Errorf("expected checkpoints signer of %d bytes, got %d", ed25519.PubKeySize, len(signerBz))
+		}
+		fromFile, err := LoadCheckpointsFile(config.CheckpointsFilePath(), ed25519.PubKey(signerBz))
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, fromFile...)
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].Height < checkpoints[j].Height
+	})
+	deduped := checkpoints[:0]
+	for _, checkpoint := range checkpoints {
+		if n := len(deduped); n > 0 && deduped[n-1].Height == checkpoint.Height {
+			if !bytes.Equal(deduped[n-1].Hash, checkpoint.Hash) {
+				return nil, ErrConflictingCheckpoints{Height: checkpoint.Height}
+			}
+			continue
+		}
+		deduped = append(deduped, checkpoint)
+	}
+	return deduped, nil
+}
+
+// highestCheckpoint returns the highest checkpoint, if it's above the given
+// height. checkpoints must be sorted by height.
+func highestCheckpoint(checkpoints []Checkpoint, height int64) (Checkpoint, bool) {
+	if len(checkpoints) == 0 {
+		return Checkpoint{}, false
+	}
+	checkpoint := checkpoints[len(checkpoints)-1]
+	if checkpoint.Height <= height {
+		return Checkpoint{}, false
+	}
+	return checkpoint, true
+}
@@ -0,0 +1,104 @@
+package blocksync
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+)
+
+func TestParseCheckpoint(t *testing.T) {
+	hash := strings.Repeat("AB", 32)
+
+	checkpoint, err := ParseCheckpoint("100:" + hash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, checkpoint.Height)
+	assert.Equal(t, hash, checkpoint.Hash.String())
+
+	for _, s := range []string{"", "100", "0:" + hash, "-1:" + hash, "x:" + hash, "100:xyz", "100:ABCD"} {
+		_, err := ParseCheckpoint(s)
+		require.Error(t, err, s)
+	}
+}
+
+// writeCheckpointsFile writes the checkpoints to a file in dir, signed by key.
+func writeCheckpointsFile(t *testing.T, dir string, key ed25519.PrivKey, checkpoints []Checkpoint) string {
+	t.Helper()
+
+	signBytes, err := CheckpointsSignBytes(checkpoints)
+	require.NoError(t, err)
+	signature, err := key.Sign(signBytes)
+	require.NoError(t, err)
+	bz, err := cmtjson.Marshal(checkpointsFile{Checkpoints: checkpoints, Signature: signature})
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "checkpoints.json")
+	require.NoError(t, os.WriteFile(path, bz, 0o600))
+	return path
+}
+
+func TestLoadCheckpointsFile(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	checkpoints := []Checkpoint{
+		{Height: 10, Hash: make([]byte, 32)},
+		{Height: 20, Hash: make([]byte, 32)},
+	}
+	path := writeCheckpointsFile(t, t.TempDir(), key, checkpoints)
+
+	loaded, err := LoadCheckpointsFile(path, key.PubKey())
+	require.NoError(t, err)
+	assert.Equal(t, checkpoints, loaded)
+
+	_, err = LoadCheckpointsFile(path, ed25519.GenPrivKey().PubKey())
+	require.Error(t, err)
+
+	// Tampering with the checkpoints invalidates the signature.
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	bz = []byte(strings.Replace(string(bz), `"20"`, `"21"`, 1))
+	require.NoError(t, os.WriteFile(path, bz, 0o600))
+	_, err = LoadCheckpointsFile(path, key.PubKey())
+	require.Error(t, err)
+}
+
+func TestCheckpointsFromConfig(t *testing.T) {
+	var (
+		key   = ed25519.GenPrivKey()
+		dir   = t.TempDir()
+		hashA = strings.Repeat("AA", 32)
+		hashB = strings.Repeat("BB", 32)
+	)
+	hashBBz, err := hex.DecodeString(hashB)
+	require.NoError(t, err)
+	writeCheckpointsFile(t, dir, key, []Checkpoint{{Height: 5, Hash: hashBBz}})
+
+	config := cfg.TestBlockSyncConfig()
+	config.RootDir = dir
+	config.TrustedCheckpoints = []string{"30:" + hashA, "5:" + hashB}
+	config.CheckpointsFile = "checkpoints.json"
+	config.CheckpointsSigner = hex.EncodeToString(key.PubKey().Bytes())
+
+	checkpoints, err := CheckpointsFromConfig(config)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.EqualValues(t, 5, checkpoints[0].Height)
+	assert.EqualValues(t, 30, checkpoints[1].Height)
+
+	checkpoint, ok := highestCheckpoint(checkpoints, 29)
+	require.True(t, ok)
+	assert.EqualValues(t, 30, checkpoint.Height)
+	_, ok = highestCheckpoint(checkpoints, 30)
+	assert.False(t, ok)
+
+	config.TrustedCheckpoints = []string{"5:" + hashA}
+	_, err = CheckpointsFromConfig(config)
+	require.ErrorAs(t, err, &ErrConflictingCheckpoints{})
+}
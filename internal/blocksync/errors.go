@@ -49,3 +49,47 @@ func (e ErrReactorValidation) Error() string {
 func (e ErrReactorValidation) Unwrap() error {
 	return e.Err
 }
+
+// ErrConflictingCheckpoints is returned when two trusted checkpoints at the
+// same height have different hashes.
+type ErrConflictingCheckpoints struct {
+	Height int64
+}
+
+func (e ErrConflictingCheckpoints) Error() string {
+	return fmt.Sprintf("conflicting trusted checkpoints at height %v", e.Height)
+}
+
+// ErrInvalidHeaders is returned when a peer sends headers that don't match the
+// verified header chain.
+type ErrInvalidHeaders struct {
+	Height int64
+	Reason string
+}
+
+func (e ErrInvalidHeaders) Error() string {
+	return fmt.Sprintf("invalid header at height %v: %s", e.Height, e.Reason)
+}
+
+// ErrCheckpointTooFar is returned when a trusted checkpoint is too far above
+// the starting height to sync headers from it.
+type ErrCheckpointTooFar struct {
+	Height  int64
+	Base    int64
+	MaxSpan int64
+}
+
+func (e ErrCheckpointTooFar) Error() string {
+	return fmt.Sprintf("checkpoint at height %v is more than %v heights above %v", e.Height, e.MaxSpan, e.Base)
+}
+
+// ErrInvalidHeadersCount is returned when a peer requests or sends an invalid
+// number of headers.
+type ErrInvalidHeadersCount struct {
+	Count  int64
+	Reason string
+}
+
+func (e ErrInvalidHeadersCount) Error() string {
+	return fmt.Sprintf("invalid headers count %v: %s", e.Count, e.Reason)
+}
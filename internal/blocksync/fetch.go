@@ -0,0 +1,128 @@
+package blocksync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bcproto "github.com/cometbft/cometbft/api/cometbft/blocksync/v2"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// fetchBlockTimeout is how long FetchBlock waits for a peer to respond,
+// before asking the next one.
+const fetchBlockTimeout = 10 * time.Second
+
+// fetchResponsesBuffer is the number of responses buffered for a request of
+// FetchBlock, as peers which timed out may still respond.
+const fetchResponsesBuffer = 16
+
+var _ store.BlockFetcher = (*Reactor)(nil)
+
+// ErrBlockNotFetched is returned by FetchBlock when no peer sent the block.
+type ErrBlockNotFetched struct {
+	Height int64
+}
+
+func (e ErrBlockNotFetched) Error() string {
+	return fmt.Sprintf("no peer sent the block at height %d", e.Height)
+}
+
+// fetchResponse is the response of a peer to a request of FetchBlock. The
+// block is nil if the peer doesn't have it.
+type fetchResponse struct {
+	peerID    p2p.ID
+	block     *types.Block
+	extCommit *types.ExtendedCommit
+}
+
+// fetchRequest is a pending request of FetchBlock.
+type fetchRequest struct {
+	respCh chan fetchResponse
+	// sent is the number of requests sent to each peer and not answered yet.
+	// The responses to the requests of the pool for the same height are
+	// passed to the pool.
+	sent map[p2p.ID]int
+}
+
+// FetchBlock requests the block at height from the peers, one at a time,
+// until one of them sends it. It's used to repair the block store, while the
+// reactor may be syncing or not. The block isn't verified. It implements
+// store.BlockFetcher.
+func (bcR *Reactor) FetchBlock(ctx context.Context, height int64) (*types.Block, *types.ExtendedCommit, error) {
+	req := &fetchRequest{
+		respCh: make(chan fetchResponse, fetchResponsesBuffer),
+		sent:   make(map[p2p.ID]int),
+	}
+	bcR.fetchesMtx.Lock()
+	if _, ok := bcR.fetches[height]; ok {
+		bcR.fetchesMtx.Unlock()
+		return nil, nil, fmt.Errorf("already fetching the block at height %d", height)
+	}
+	bcR.fetches[height] = req
+	bcR.fetchesMtx.Unlock()
+	defer func() {
+		bcR.fetchesMtx.Lock()
+		delete(bcR.fetches, height)
+		bcR.fetchesMtx.Unlock()
+	}()
+	respCh := req.respCh
+
+	for _, peer := range bcR.Switch.Peers().Copy() {
+		bcR.fetchesMtx.Lock()
+		req.sent[peer.ID()]++
+		bcR.fetchesMtx.Unlock()
+		err := peer.Send(p2p.Envelope{
+			ChannelID: BlocksyncChannel,
+			Message:   &bcproto.BlockRequest{Height: height},
+		})
+		if err != nil {
+			bcR.fetchesMtx.Lock()
+			req.sent[peer.ID()]--
+			bcR.fetchesMtx.Unlock()
+			continue
+		}
+
+		timer := time.NewTimer(fetchBlockTimeout)
+	WAIT:
+		for {
+			select {
+			case resp := <-respCh:
+				if resp.block != nil {
+					timer.Stop()
+					return resp.block, resp.extCommit, nil
+				}
+				if resp.peerID == peer.ID() {
+					break WAIT
+				}
+			case <-timer.C:
+				break WAIT
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, nil, ctx.Err()
+			}
+		}
+		timer.Stop()
+	}
+	return nil, nil, ErrBlockNotFetched{Height: height}
+}
+
+// deliverFetched passes the response of a peer to a pending request of
+// FetchBlock, if the peer was sent one for the height, and returns whether
+// it was. Otherwise, the response is to a request of the pool.
+func (bcR *Reactor) deliverFetched(height int64, resp fetchResponse) bool {
+	bcR.fetchesMtx.Lock()
+	defer bcR.fetchesMtx.Unlock()
+	req, ok := bcR.fetches[height]
+	if !ok || req.sent[resp.peerID] == 0 {
+		return false
+	}
+	req.sent[resp.peerID]--
+	select {
+	case req.respCh <- resp:
+	default:
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package blocksync
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/p2p"
+)
+
+func TestFetchBlock(t *testing.T) {
+	config = test.ResetTestRoot("blocksync_fetch_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc()
+
+	reactorPairs := []ReactorPair{
+		newReactor(t, log.TestingLogger(), genDoc, privVals, 20),
+		newReactor(t, log.TestingLogger(), genDoc, privVals, 20),
+	}
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKSYNC", reactorPairs[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+	defer func() {
+		for _, r := range reactorPairs {
+			require.NoError(t, r.reactor.Stop())
+			require.NoError(t, r.app.Stop())
+		}
+	}()
+
+	expected, _ := reactorPairs[0].reactor.store.LoadBlock(5)
+	block, extCommit, err := reactorPairs[1].reactor.FetchBlock(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash(), block.Hash())
+	require.NotNil(t, extCommit)
+
+	_, _, err = reactorPairs[1].reactor.FetchBlock(context.Background(), 100)
+	require.ErrorAs(t, err, &ErrBlockNotFetched{})
+}
+
+func TestDeliverFetched(t *testing.T) {
+	bcR := &Reactor{fetches: make(map[int64]*fetchRequest)}
+	req := &fetchRequest{respCh: make(chan fetchResponse, 1), sent: map[p2p.ID]int{"a": 1}}
+	bcR.fetches[5] = req
+
+	// The responses of the peers which weren't sent a request by FetchBlock
+	// are passed to the pool.
+	require.False(t, bcR.deliverFetched(5, fetchResponse{peerID: "b"}))
+	require.False(t, bcR.deliverFetched(6, fetchResponse{peerID: "a"}))
+
+	require.True(t, bcR.deliverFetched(5, fetchResponse{peerID: "a"}))
+	require.Equal(t, p2p.ID("a"), (<-req.respCh).peerID)
+	// A single response is expected per request.
+	require.False(t, bcR.deliverFetched(5, fetchResponse{peerID: "a"}))
+}
@@ -0,0 +1,136 @@
+package blocksync
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	// maxHeadersPerResponse is the maximum number of headers that are
+	// requested from, or sent to, a peer in a single message.
+	maxHeadersPerResponse = 500
+
+	// headersRequestTimeout is how long to wait for a peer to answer a
+	// headers request before asking another one.
+	headersRequestTimeout = 15 * time.Second
+
+	// maxHeaderChainSpan is the maximum number of heights between the
+	// starting height and a checkpoint. It bounds the memory used by the
+	// hashes of a header chain to 32 MB.
+	maxHeaderChainSpan = 1_000_000
+)
+
+// headerChain holds the block hashes below a trusted checkpoint, verified by
+// following the chain of headers backwards from it: the hash of the header
+// at height H-1 is the LastBlockID of the header at height H. Blocks below the
+// checkpoint whose hashes match can be executed without verifying their
+// commits.
+//
+// Only the hashes are kept, so the memory usage is 32 bytes per height between
+// the starting height and the checkpoint, which are at most maxHeaderChainSpan
+// heights apart.
+type headerChain struct {
+	checkpoint Checkpoint
+	base       int64 // the lowest height whose hash is needed
+
+	mtx cmtsync.Mutex
+	// hashes[i] is the verified hash of the block at base+i, for every height
+	// from next to the checkpoint.
+	hashes [][tmhash.Size]byte
+	// next is the height of the next header to verify, whose hash is already
+	// known.
+	next int64
+}
+
+// newHeaderChain returns a header chain that verifies hashes from the
+// checkpoint down to base. The checkpoint must be above base, and at most
+// maxHeaderChainSpan heights above it.
+func newHeaderChain(checkpoint Checkpoint, base int64) (*headerChain, error) {
+	if span := checkpoint.Height - base + 1; span > maxHeaderChainSpan {
+		return nil, ErrCheckpointTooFar{Height: checkpoint.Height, Base: base, MaxSpan: maxHeaderChainSpan}
+	}
+	c := &headerChain{
+		checkpoint: checkpoint,
+		base:       base,
+		hashes:     make([][tmhash.Size]byte, checkpoint.Height-base+1),
+		next:       checkpoint.Height,
+	}
+	copy(c.hashes[checkpoint.Height-base][:], checkpoint.Hash)
+	return c, nil
+}
+
+// Covers returns true if the block at the given height is below the
+// checkpoint, so that it's verified against the header chain.
+func (c *headerChain) Covers(height int64) bool {
+	return height >= c.base && height < c.checkpoint.Height
+}
+
+// Hash returns the verified hash of the block at the given height, or false if
+// it's not known (yet).
+func (c *headerChain) Hash(height int64) ([]byte, bool) {
+	if height < c.base || height > c.checkpoint.Height {
+		return nil, false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if height < c.next {
+		return nil, false
+	}
+	hash := c.hashes[height-c.base]
+	return hash[:], true
+}
+
+// Complete returns true if the hashes of all heights down to base are known.
+func (c *headerChain) Complete() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.next <= c.base
+}
+
+// NextRequest returns the height and number of the headers to request next,
+// in descending order, or false if the chain is complete.
+func (c *headerChain) NextRequest() (height, count int64, ok bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.next <= c.base {
+		return 0, 0, false
+	}
+	return c.next, min(c.next-c.base, maxHeadersPerResponse), true
+}
+
+// AddHeaders verifies headers, in descending order down from the next height
+// to verify, and records the hashes they commit to. Headers that were already
+// verified are skipped, and those after the first invalid one are dropped.
+func (c *headerChain) AddHeaders(headers []*types.Header) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, header := range headers {
+		if c.next <= c.base {
+			return nil
+		}
+		if header.Height > c.next {
+			// Already verified, e.g. in a late response to an earlier request.
+			continue
+		}
+		if header.Height < c.next {
+			return ErrInvalidHeaders{Height: header.Height, Reason: "unexpected height"}
+		}
+		if err := header.ValidateBasic(); err != nil {
+			return ErrInvalidHeaders{Height: header.Height, Reason: err.Error()}
+		}
+		if !bytes.Equal(header.Hash(), c.hashes[header.Height-c.base][:]) {
+			return ErrInvalidHeaders{Height: header.Height, Reason: "hash does not match the verified chain"}
+		}
+		if len(header.LastBlockID.Hash) != tmhash.Size {
+			return ErrInvalidHeaders{Height: header.Height, Reason: "missing last block ID"}
+		}
+		copy(c.hashes[header.Height-1-c.base][:], header.LastBlockID.Hash)
+		c.next--
+	}
+	return nil
+}
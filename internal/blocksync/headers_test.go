@@ -0,0 +1,114 @@
+package blocksync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// makeHeaders makes a chain of headers from height 1 to numHeaders, where each
+// header's LastBlockID refers to the previous one.
+func makeHeaders(t *testing.T, numHeaders int64) []*types.Header {
+	t.Helper()
+
+	headers := make([]*types.Header, 0, numHeaders)
+	lastBlockID := test.MakeBlockID()
+	for height := int64(1); height <= numHeaders; height++ {
+		header := test.MakeHeader(t, &types.Header{Height: height, LastBlockID: lastBlockID})
+		lastBlockID = test.MakeBlockIDWithHash(header.Hash())
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+// descending returns the headers between from and to, in descending order.
+func descending(headers []*types.Header, from, to int64) []*types.Header {
+	result := make([]*types.Header, 0, from-to+1)
+	for height := from; height >= to; height-- {
+		result = append(result, headers[height-1])
+	}
+	return result
+}
+
+func TestHeaderChain(t *testing.T) {
+	headers := makeHeaders(t, 10)
+	checkpoint := Checkpoint{Height: 10, Hash: headers[9].Hash()}
+	chain, err := newHeaderChain(checkpoint, 3)
+	require.NoError(t, err)
+
+	assert.False(t, chain.Covers(2))
+	assert.True(t, chain.Covers(3))
+	assert.True(t, chain.Covers(9))
+	assert.False(t, chain.Covers(10))
+
+	hash, ok := chain.Hash(10)
+	require.True(t, ok)
+	assert.EqualValues(t, checkpoint.Hash, hash)
+	_, ok = chain.Hash(9)
+	assert.False(t, ok)
+
+	height, count, ok := chain.NextRequest()
+	require.True(t, ok)
+	assert.EqualValues(t, 10, height)
+	assert.EqualValues(t, 7, count)
+
+	// A partial response is accepted.
+	require.NoError(t, chain.AddHeaders(descending(headers, 10, 8)))
+	hash, ok = chain.Hash(7)
+	require.True(t, ok)
+	assert.EqualValues(t, headers[6].Hash(), hash)
+	_, ok = chain.Hash(6)
+	assert.False(t, ok)
+
+	// A header that doesn't match the verified hash is rejected.
+	forged := *headers[6]
+	forged.AppHash = []byte("forged")
+	err = chain.AddHeaders([]*types.Header{&forged})
+	require.ErrorAs(t, err, &ErrInvalidHeaders{})
+
+	// So is a gap in the chain.
+	err = chain.AddHeaders(descending(headers, 6, 4))
+	require.ErrorAs(t, err, &ErrInvalidHeaders{})
+
+	// Already verified headers are skipped.
+	require.NoError(t, chain.AddHeaders(descending(headers, 9, 3)))
+	assert.True(t, chain.Complete())
+	_, _, ok = chain.NextRequest()
+	assert.False(t, ok)
+	for height := int64(3); height <= 10; height++ {
+		hash, ok := chain.Hash(height)
+		require.True(t, ok)
+		assert.EqualValues(t, headers[height-1].Hash(), hash)
+	}
+	_, ok = chain.Hash(2)
+	assert.False(t, ok)
+}
+
+func TestVerifyAgainstHeaders(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(1, 10)
+	blocks := makeVerifierChain(t, vals, privVals, 3)
+	checkpoint := Checkpoint{Height: 3, Hash: blocks[2].Hash()}
+	chain, err := newHeaderChain(checkpoint, 1)
+	require.NoError(t, err)
+	require.NoError(t, chain.AddHeaders([]*types.Header{&blocks[2].Header, &blocks[1].Header}))
+
+	require.NoError(t, verifyAgainstHeaders(chain, blocks[0], blocks[1]))
+
+	other := types.MakeBlock(1, []types.Tx{types.Tx("other")}, &types.Commit{}, nil)
+	other.ChainID = blocks[0].ChainID
+	require.Error(t, verifyAgainstHeaders(chain, other, blocks[1]))
+}
+
+func TestHeaderChainTooFar(t *testing.T) {
+	checkpoint := Checkpoint{Height: maxHeaderChainSpan + 1, Hash: make([]byte, tmhash.Size)}
+	_, err := newHeaderChain(checkpoint, 2)
+	require.NoError(t, err)
+
+	_, err = newHeaderChain(checkpoint, 1)
+	require.ErrorAs(t, err, &ErrCheckpointTooFar{})
+}
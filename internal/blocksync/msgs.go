@@ -49,6 +49,20 @@ func ValidateMsg(pb proto.Message) error {
 		}
 	case *bcproto.StatusRequest:
 		return nil
+	case *bcproto.HeadersRequest:
+		if msg.Height < 0 {
+			return ErrInvalidHeight{Height: msg.Height, Reason: "negative height"}
+		}
+		if msg.Count <= 0 {
+			return ErrInvalidHeadersCount{Count: msg.Count, Reason: "must be positive"}
+		}
+	case *bcproto.HeadersResponse:
+		if len(msg.Headers) > maxHeadersPerResponse {
+			return ErrInvalidHeadersCount{
+				Count:  int64(len(msg.Headers)),
+				Reason: fmt.Sprintf("more than %d headers", maxHeadersPerResponse),
+			}
+		}
 	default:
 		return ErrUnknownMessageType{Msg: msg}
 	}
@@ -76,6 +76,27 @@ func TestBcStatusResponseMessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestBcHeadersRequestMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName  string
+		height    int64
+		count     int64
+		expectErr bool
+	}{
+		{"Valid Request Message", 1, 1, false},
+		{"Valid Request Message", 100, 1000, false},
+		{"Invalid Request Message", -1, 1, true},
+		{"Invalid Request Message", 1, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			request := bcproto.HeadersRequest{Height: tc.height, Count: tc.count}
+			assert.Equal(t, tc.expectErr, blocksync.ValidateMsg(&request) != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 //nolint:lll // ignore line length in tests
 func TestBlocksyncMessageVectors(t *testing.T) {
 	block := types.MakeBlock(int64(3), []types.Tx{types.Tx("Hello World")}, nil, nil)
@@ -128,6 +149,18 @@ func TestBlocksyncMessageVectors(t *testing.T) {
 			}},
 			"2a1408ffffffffffffffff7f10ffffffffffffffff7f",
 		},
+		{
+			"HeadersRequestMessage", &bcproto.Message{Sum: &bcproto.Message_HeadersRequest{
+				HeadersRequest: &bcproto.HeadersRequest{Height: 1, Count: 2},
+			}},
+			"320408011002",
+		},
+		{
+			"HeadersResponseMessage", &bcproto.Message{Sum: &bcproto.Message_HeadersResponse{
+				HeadersResponse: &bcproto.HeadersResponse{},
+			}},
+			"3a00",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -229,6 +229,24 @@ func (pool *BlockPool) PeekTwoBlocks() (first, second *types.Block, firstExtComm
 	return first, second, firstExtCommit
 }
 
+// PeekBlocks returns up to maxBlocks blocks, starting at pool.height. Blocks
+// that have not been received yet are nil. Unlike PeekTwoBlocks, it is meant
+// for looking ahead, e.g. to verify upcoming blocks before they are executed.
+func (pool *BlockPool) PeekBlocks(maxBlocks int) []*types.Block {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	blocks := make([]*types.Block, 0, maxBlocks)
+	for i := int64(0); i < int64(maxBlocks); i++ {
+		r := pool.requesters[pool.height+i]
+		if r == nil {
+			break
+		}
+		blocks = append(blocks, r.getBlock())
+	}
+	return blocks
+}
+
 // PopRequest removes the requester at pool.height and increments pool.height.
 func (pool *BlockPool) PopRequest() {
 	pool.mtx.Lock()
@@ -480,6 +498,26 @@ func (pool *BlockPool) pickIncrAvailablePeer(height int64, excludePeerID p2p.ID)
 	return nil
 }
 
+// PickPeerForRange returns a peer that has all the blocks between minHeight and
+// maxHeight, other than excludePeerID, preferring faster peers. If no peers
+// are available, returns an empty ID. Unlike pickIncrAvailablePeer, it doesn't
+// count as a pending block request.
+func (pool *BlockPool) PickPeerForRange(minHeight, maxHeight int64, excludePeerID p2p.ID) p2p.ID {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	for _, peer := range pool.sortedPeers {
+		if peer.id == excludePeerID || peer.didTimeout {
+			continue
+		}
+		if minHeight < peer.base || maxHeight > peer.height {
+			continue
+		}
+		return peer.id
+	}
+	return ""
+}
+
 // Sort peers by curRate, highest first.
 //
 // CONTRACT: pool.mtx must be locked.
@@ -1,12 +1,15 @@
 package blocksync
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	bcproto "github.com/cometbft/cometbft/api/cometbft/blocksync/v2"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/p2p"
@@ -71,12 +74,44 @@ type Reactor struct {
 
 	switchToConsensusMs int
 
+	// checkpoints are the blocks trusted by the operator, sorted by height.
+	// If the pool starts below the highest one, headers holds the header
+	// chain verified backwards from it.
+	checkpoints []Checkpoint
+	headers     atomic.Pointer[headerChain]
+	headersCh   chan headersResponse
+
+	// fetches are the pending requests of FetchBlock, by height.
+	fetchesMtx sync.Mutex
+	fetches    map[int64]*fetchRequest
+
 	metrics *Metrics
 }
 
+// headersResponse is a set of headers received from a peer.
+type headersResponse struct {
+	peerID  p2p.ID
+	headers []*types.Header
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithTrustedCheckpoints sets the blocks trusted by the operator. If the node
+// is below the highest checkpoint, the reactor first fetches the headers below
+// it and verifies them backwards from the checkpoint, and then only checks
+// that the blocks below the checkpoint match the verified headers, instead of
+// verifying their commits.
+func WithTrustedCheckpoints(checkpoints []Checkpoint) ReactorOption {
+	return func(bcR *Reactor) {
+		bcR.checkpoints = checkpoints
+	}
+}
+
 // NewReactor returns new reactor instance.
 func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
 	blockSync bool, localAddr crypto.Address, metrics *Metrics, offlineStateSyncHeight int64,
+	options ...ReactorOption,
 ) *Reactor {
 	storeHeight := store.Height()
 	if storeHeight == 0 {
@@ -115,8 +150,13 @@ func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockS
 		localAddr:    localAddr,
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
+		headersCh:    make(chan headersResponse, 10),
+		fetches:      make(map[int64]*fetchRequest),
 		metrics:      metrics,
 	}
+	for _, option := range options {
+		option(bcR)
+	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("Reactor", bcR)
 	return bcR
 }
@@ -150,6 +190,18 @@ func (bcR *Reactor) SwitchToBlockSync(state sm.State) error {
 }
 
 func (bcR *Reactor) startPool(stateSynced bool) error {
+	if checkpoint, ok := highestCheckpoint(bcR.checkpoints, bcR.pool.Height()); ok {
+		headers, err := newHeaderChain(checkpoint, bcR.pool.Height())
+		if err != nil {
+			// Blocks are still synced, verifying their commits.
+			bcR.Logger.Error("Not syncing headers from trusted checkpoint", "err", err)
+		} else {
+			bcR.Logger.Info("Syncing headers from trusted checkpoint",
+				"height", checkpoint.Height, "hash", checkpoint.Hash)
+			bcR.headers.Store(headers)
+		}
+	}
+
 	err := bcR.pool.Start()
 	if err != nil {
 		return err
@@ -249,6 +301,51 @@ func (bcR *Reactor) respondToPeer(msg *bcproto.BlockRequest, src p2p.Peer) (queu
 	return err == nil
 }
 
+// respondToHeadersRequest sends the requested headers that we have, in
+// descending order, to the requesting peer.
+func (bcR *Reactor) respondToHeadersRequest(msg *bcproto.HeadersRequest, src p2p.Peer) (queued bool) {
+	count := min(msg.Count, maxHeadersPerResponse)
+	headers := make([]*cmtproto.Header, 0, count)
+	for height := msg.Height; height > msg.Height-count; height-- {
+		meta := bcR.store.LoadBlockMeta(height)
+		if meta == nil {
+			break
+		}
+		headers = append(headers, meta.Header.ToProto())
+	}
+	if len(headers) == 0 {
+		bcR.Logger.Info("Peer asking for headers we don't have", "src", src, "height", msg.Height)
+	}
+
+	err := src.TrySend(p2p.Envelope{
+		ChannelID: BlocksyncChannel,
+		Message:   &bcproto.HeadersResponse{Headers: headers},
+	})
+	return err == nil
+}
+
+// handleHeadersResponse hands the headers received from a peer over to the
+// header sync routine. It never blocks, so it is called from Receive directly.
+func (bcR *Reactor) handleHeadersResponse(msg *bcproto.HeadersResponse, src p2p.Peer) {
+	headers := make([]*types.Header, 0, len(msg.Headers))
+	for _, pbHeader := range msg.Headers {
+		header, err := types.HeaderFromProto(pbHeader)
+		if err != nil {
+			bcR.Logger.Error("Peer sent us invalid header", "peer", src, "err", err)
+			bcR.Switch.StopPeerForError(src, err)
+			return
+		}
+		headers = append(headers, &header)
+	}
+
+	select {
+	case bcR.headersCh <- headersResponse{peerID: src.ID(), headers: headers}:
+	default:
+		// Not syncing headers, or too busy to handle it; the request is
+		// retried if needed.
+	}
+}
+
 func (bcR *Reactor) handlePeerResponse(msg *bcproto.BlockResponse, src p2p.Peer) {
 	bi, err := types.BlockFromProto(msg.Block)
 	if err != nil {
@@ -256,6 +353,16 @@ func (bcR *Reactor) handlePeerResponse(msg *bcproto.BlockResponse, src p2p.Peer)
 		bcR.Switch.StopPeerForError(src, err)
 		return
 	}
+	if headers := bcR.headers.Load(); headers != nil {
+		// Reject blocks that don't match the verified headers right away,
+		// rather than when they're about to be executed.
+		if hash, ok := headers.Hash(bi.Height); ok && !bytes.Equal(hash, bi.Hash()) {
+			err := ErrInvalidHeaders{Height: bi.Height, Reason: "block does not match the verified header"}
+			bcR.Logger.Error("Peer sent us invalid block", "peer", src, "err", err)
+			bcR.Switch.StopPeerForError(src, err)
+			return
+		}
+	}
 	var extCommit *types.ExtendedCommit
 	if msg.ExtCommit != nil {
 		var err error
@@ -269,12 +376,16 @@ func (bcR *Reactor) handlePeerResponse(msg *bcproto.BlockResponse, src p2p.Peer)
 		}
 	}
 
+	if bcR.deliverFetched(bi.Height, fetchResponse{peerID: src.ID(), block: bi, extCommit: extCommit}) {
+		return
+	}
+
 	if err := bcR.pool.AddBlock(src.ID(), bi, extCommit, msg.Block.Size()); err != nil {
 		bcR.Logger.Error("failed to add block", "peer", src, "err", err)
 	}
 }
 
-// Receive implements Reactor by handling 4 types of messages (look below).
+// Receive implements Reactor by handling the messages below.
 func (bcR *Reactor) Receive(e p2p.Envelope) {
 	if err := ValidateMsg(e.Message); err != nil {
 		bcR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", e.Message, "err", err)
@@ -303,7 +414,13 @@ func (bcR *Reactor) Receive(e p2p.Envelope) {
 		bcR.pool.SetPeerRange(e.Src.ID(), msg.Base, msg.Height)
 	case *bcproto.NoBlockResponse:
 		bcR.Logger.Debug("Peer does not have requested block", "peer", e.Src, "height", msg.Height)
-		bcR.pool.RedoRequestFrom(msg.Height, e.Src.ID())
+		if !bcR.deliverFetched(msg.Height, fetchResponse{peerID: e.Src.ID()}) {
+			bcR.pool.RedoRequestFrom(msg.Height, e.Src.ID())
+		}
+	case *bcproto.HeadersRequest:
+		bcR.respondToHeadersRequest(msg, e.Src)
+	case *bcproto.HeadersResponse:
+		bcR.handleHeadersResponse(msg, e.Src)
 	default:
 		bcR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
@@ -325,6 +442,25 @@ func (bcR *Reactor) poolRoutine(stateSynced bool) {
 
 	go bcR.handleBlockRequestsRoutine()
 
+	headers := bcR.headers.Load()
+	if headers != nil {
+		go bcR.syncHeadersRoutine(headers)
+	}
+
+	// Verify the commits of upcoming blocks in parallel, while blocks are
+	// executed one at a time below.
+	verifier := newBlockVerifier(bcR.initialState.ChainID)
+	verifier.SetLogger(bcR.Logger.With("module", "blocksync-verifier"))
+	if err := verifier.Start(); err != nil {
+		bcR.Logger.Error("Error starting block verifier", "err", err)
+		return
+	}
+	defer func() {
+		if err := verifier.Stop(); err != nil {
+			bcR.Logger.Error("Error stopping block verifier", "err", err)
+		}
+	}()
+
 	if bcR.switchToConsensusMs == 0 {
 		bcR.switchToConsensusMs = switchToConsensusIntervalSeconds * 1000
 	}
@@ -369,6 +505,14 @@ FOR_LOOP:
 			// coupling them as it's written here.  TODO uncouple from request
 			// routine.
 
+			// Verify the upcoming blocks we already have, even if we're
+			// still missing the next one.
+			// Blocks below the trusted checkpoint are checked against the
+			// verified headers instead.
+			if headers == nil || !headers.Covers(bcR.pool.Height()) {
+				verifier.Schedule(state, bcR.pool.PeekBlocks(verifyAheadBlocks+1))
+			}
+
 			// See if there are any blocks to sync.
 			first, second, extCommit := bcR.pool.PeekTwoBlocks()
 			if first == nil || second == nil {
@@ -385,6 +529,12 @@ FOR_LOOP:
 				// Panicking because this is an obvious bug in the block pool, which is totally under our control
 				panic(fmt.Errorf("heights of first and second block are not consecutive; expected %d, got %d", state.LastBlockHeight, first.Height))
 			}
+			if headers != nil && headers.Covers(first.Height) {
+				if _, ok := headers.Hash(first.Height); !ok {
+					// Wait for the headers to be verified down to this height.
+					continue FOR_LOOP
+				}
+			}
 
 			// Before priming didProcessCh for another check on the next
 			// iteration, break the loop if the BlockPool or the Reactor itself
@@ -396,18 +546,32 @@ FOR_LOOP:
 			// Try again quickly next loop.
 			didProcessCh <- struct{}{}
 
-			firstParts, err := first.MakePartSet(types.BlockPartSizeBytes)
-			if err != nil {
-				bcR.Logger.Error("failed to make ",
-					"height", first.Height,
-					"err", err.Error())
-				break FOR_LOOP
+			var (
+				verification = verifier.Result(first, second)
+				firstParts   *types.PartSet
+				err          error
+			)
+			if verification != nil {
+				firstParts = verification.parts
+			} else {
+				firstParts, err = first.MakePartSet(types.BlockPartSizeBytes)
+				if err != nil {
+					bcR.Logger.Error("failed to make ",
+						"height", first.Height,
+						"err", err.Error())
+					break FOR_LOOP
+				}
 			}
 
-			if state, err = bcR.processBlock(first, second, firstParts, state, extCommit); err != nil {
+			state, err = bcR.processBlock(first, second, firstParts, state, extCommit, verification, headers)
+			if err != nil {
 				bcR.Logger.Error("Invalid block", "height", first.Height, "err", err)
+				// The blocks are refetched; until they are replaced, verify
+				// them again rather than reusing the outcome.
+				verifier.Discard(first.Height)
 				continue FOR_LOOP
 			}
+			verifier.Prune(bcR.pool.Height())
 
 			blocksSynced++
 
@@ -532,7 +696,19 @@ func (bcR *Reactor) isCaughtUp(state sm.State, blocksSynced uint64, stateSynced
 	return false
 }
 
-func (bcR *Reactor) processBlock(first, second *types.Block, firstParts *types.PartSet, state sm.State, extCommit *types.ExtendedCommit) (sm.State, error) {
+// processBlock verifies the first block using the second's commit, and
+// executes it. If verification is not nil, it holds the outcome of verifying
+// the commit, carried out ahead of time by the block verifier. If headers
+// covers the first block, both blocks are instead checked against the
+// verified headers.
+func (bcR *Reactor) processBlock(
+	first, second *types.Block,
+	firstParts *types.PartSet,
+	state sm.State,
+	extCommit *types.ExtendedCommit,
+	verification *blockVerification,
+	headers *headerChain,
+) (sm.State, error) {
 	var (
 		chainID            = bcR.initialState.ChainID
 		firstPartSetHeader = firstParts.Header()
@@ -544,8 +720,19 @@ func (bcR *Reactor) processBlock(first, second *types.Block, firstParts *types.P
 	// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 	// currently necessary.
 	// TODO(sergio): Should we also validate against the extended commit?
-	err := state.Validators.VerifyCommitLight(
-		chainID, firstID, first.Height, second.LastCommit)
+	var err error
+	if headers != nil && headers.Covers(first.Height) {
+		// The second block's hash is verified too, so that its LastCommit,
+		// which is saved as the first block's seen commit, is authentic.
+		err = verifyAgainstHeaders(headers, first, second)
+	} else if verification != nil && bytes.Equal(verification.valsHash, first.ValidatorsHash) {
+		// The commit was verified with the validator set claimed by the
+		// header. ValidateBlock below ensures that it's the state's one.
+		err = verification.err
+	} else {
+		err = state.Validators.VerifyCommitLight(
+			chainID, firstID, first.Height, second.LastCommit)
+	}
 
 	if err == nil {
 		// validate the block before we persist it
@@ -609,3 +796,98 @@ func (bcR *Reactor) processBlock(first, second *types.Block, firstParts *types.P
 
 	return state, nil
 }
+
+// verifyAgainstHeaders checks that the hashes of the given blocks match the
+// verified header chain.
+func verifyAgainstHeaders(headers *headerChain, blocks ...*types.Block) error {
+	for _, block := range blocks {
+		hash, ok := headers.Hash(block.Height)
+		if !ok {
+			return ErrInvalidHeaders{Height: block.Height, Reason: "header not verified yet"}
+		}
+		if !bytes.Equal(hash, block.Hash()) {
+			return ErrInvalidHeaders{Height: block.Height, Reason: "block does not match the verified header"}
+		}
+	}
+	return nil
+}
+
+// syncHeadersRoutine fetches the headers below the trusted checkpoint from
+// peers, a batch at a time, and verifies them backwards until the pool's
+// starting height is reached.
+func (bcR *Reactor) syncHeadersRoutine(headers *headerChain) {
+	retryTicker := time.NewTicker(time.Second)
+	defer retryTicker.Stop()
+
+	var (
+		peerID      p2p.ID // the peer the pending request was sent to, if any
+		excludePeer p2p.ID // the peer that last failed to answer
+		timeout     = time.NewTimer(headersRequestTimeout)
+	)
+	defer timeout.Stop()
+
+	for {
+		height, count, ok := headers.NextRequest()
+		if !ok {
+			bcR.Logger.Info("Verified headers from trusted checkpoint",
+				"checkpoint", headers.checkpoint.Height, "base", headers.base)
+			return
+		}
+
+		if peerID == "" {
+			peerID = bcR.pool.PickPeerForRange(height-count+1, height, excludePeer)
+			peer := bcR.Switch.Peers().Get(peerID)
+			if peer == nil || peer.TrySend(p2p.Envelope{
+				ChannelID: BlocksyncChannel,
+				Message:   &bcproto.HeadersRequest{Height: height, Count: count},
+			}) != nil {
+				peerID = ""
+				select {
+				case <-retryTicker.C:
+					excludePeer = ""
+					continue
+				case <-bcR.Quit():
+					return
+				case <-bcR.pool.Quit():
+					return
+				}
+			}
+			timeout.Reset(headersRequestTimeout)
+		}
+
+		select {
+		case resp := <-bcR.headersCh:
+			if resp.peerID != peerID {
+				// A late response to an earlier request.
+				if err := headers.AddHeaders(resp.headers); err != nil {
+					bcR.stopPeerForHeaders(resp.peerID, err)
+				}
+				continue
+			}
+			peerID = ""
+			if len(resp.headers) == 0 {
+				excludePeer = resp.peerID
+				continue
+			}
+			if err := headers.AddHeaders(resp.headers); err != nil {
+				excludePeer = resp.peerID
+				bcR.stopPeerForHeaders(resp.peerID, err)
+			}
+		case <-timeout.C:
+			bcR.Logger.Debug("Timed out waiting for headers", "peer", peerID, "height", height)
+			excludePeer = peerID
+			peerID = ""
+		case <-bcR.Quit():
+			return
+		case <-bcR.pool.Quit():
+			return
+		}
+	}
+}
+
+func (bcR *Reactor) stopPeerForHeaders(peerID p2p.ID, err error) {
+	bcR.Logger.Error("Peer sent us invalid headers", "peer", peerID, "err", err)
+	if peer := bcR.Switch.Peers().Get(peerID); peer != nil {
+		bcR.Switch.StopPeerForError(peer, err)
+	}
+}
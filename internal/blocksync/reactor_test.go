@@ -572,6 +572,10 @@ func (bcR *ByzantineReactor) Receive(e p2p.Envelope) {
 			}
 		}
 
+		if bcR.deliverFetched(bi.Height, fetchResponse{peerID: e.Src.ID(), block: bi, extCommit: extCommit}) {
+			return
+		}
+
 		if err := bcR.pool.AddBlock(e.Src.ID(), bi, extCommit, msg.Block.Size()); err != nil {
 			bcR.Logger.Error("failed to add block", "peer", e.Src, "err", err)
 		}
@@ -589,7 +593,9 @@ func (bcR *ByzantineReactor) Receive(e p2p.Envelope) {
 		bcR.pool.SetPeerRange(e.Src.ID(), msg.Base, msg.Height)
 	case *bcproto.NoBlockResponse:
 		bcR.Logger.Debug("Peer does not have requested block", "peer", e.Src, "height", msg.Height)
-		bcR.pool.RedoRequestFrom(msg.Height, e.Src.ID())
+		if !bcR.deliverFetched(msg.Height, fetchResponse{peerID: e.Src.ID()}) {
+			bcR.pool.RedoRequestFrom(msg.Height, e.Src.ID())
+		}
 	default:
 		bcR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
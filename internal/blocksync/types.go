@@ -11,4 +11,6 @@ var (
 	_ types.Wrapper = &cmtbs.NoBlockResponse{}
 	_ types.Wrapper = &cmtbs.BlockResponse{}
 	_ types.Wrapper = &cmtbs.BlockRequest{}
+	_ types.Wrapper = &cmtbs.HeadersRequest{}
+	_ types.Wrapper = &cmtbs.HeadersResponse{}
 )
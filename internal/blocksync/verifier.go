@@ -0,0 +1,233 @@
+package blocksync
+
+import (
+	"bytes"
+	"runtime"
+
+	"github.com/cometbft/cometbft/v2/libs/service"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	// verifyAheadBlocks is the number of upcoming blocks, starting from the
+	// pool's height, whose commits are verified ahead of their execution.
+	verifyAheadBlocks = 64
+)
+
+// blockVerification is the outcome of verifying a block against the
+// LastCommit of the block that follows it.
+type blockVerification struct {
+	// block and next identify the exact blocks that were verified. The pool
+	// may replace a block if it has to be refetched from another peer.
+	block *types.Block
+	next  *types.Block
+
+	// vals is the validator set the commit is verified with, and valsHash its
+	// hash.
+	vals     *types.ValidatorSet
+	valsHash []byte
+
+	parts *types.PartSet
+	err   error // result of verifying the commit, if parts is not nil
+
+	done chan struct{}
+}
+
+// blockVerifier verifies the commits of upcoming blocks in parallel, while
+// blocks are still executed sequentially by the reactor.
+//
+// Verifying the commit for a block at height H requires the validator set at
+// H, which is only known for sure once H-1 has been executed. The verifier
+// speculatively uses the current and next validator sets of the latest state,
+// for every block whose header claims one of them. The reactor only uses a
+// verification if the block's header matches the state's validator set, which
+// ValidateBlock ensures, so the outcome is the same as verifying sequentially.
+// Blocks with any other validator set are verified sequentially.
+//
+// Commits are verified with VerifyCommitLight, which uses batch verification
+// where the key type supports it.
+type blockVerifier struct {
+	service.BaseService
+
+	chainID string
+	jobs    chan *blockVerification
+	workers int
+
+	mtx           cmtsync.Mutex
+	verifications map[int64]*blockVerification
+}
+
+// newBlockVerifier returns a new verifier, which uses one worker per CPU.
+func newBlockVerifier(chainID string) *blockVerifier {
+	v := &blockVerifier{
+		chainID:       chainID,
+		jobs:          make(chan *blockVerification, verifyAheadBlocks),
+		workers:       runtime.NumCPU(),
+		verifications: make(map[int64]*blockVerification),
+	}
+	v.BaseService = *service.NewBaseService(nil, "BlockVerifier", v)
+	return v
+}
+
+// OnStart implements service.Service by spawning the workers.
+func (v *blockVerifier) OnStart() error {
+	for i := 0; i < v.workers; i++ {
+		go v.verifyRoutine()
+	}
+	return nil
+}
+
+// Schedule schedules the verification of each pair of consecutive blocks that
+// has not been scheduled yet. blocks must hold consecutive heights, with nil
+// for the blocks that have not been received yet. It never blocks: pairs that
+// can't be scheduled now are scheduled on a later call.
+func (v *blockVerifier) Schedule(state sm.State, blocks []*types.Block) {
+	var (
+		candidates []*types.ValidatorSet
+		hashes     [][]byte
+	)
+	for i := 0; i+1 < len(blocks); i++ {
+		block, next := blocks[i], blocks[i+1]
+		if block == nil || next == nil || v.isScheduled(block, next) {
+			continue
+		}
+
+		// Compute the candidate validator sets lazily, since most calls find
+		// nothing to schedule.
+		if candidates == nil {
+			candidates, hashes = verificationCandidates(state)
+		}
+		vals, valsHash := pickValidatorSet(block, candidates, hashes)
+		if vals == nil {
+			continue
+		}
+
+		verification := &blockVerification{
+			block:    block,
+			next:     next,
+			vals:     vals,
+			valsHash: valsHash,
+			done:     make(chan struct{}),
+		}
+		select {
+		case v.jobs <- verification:
+		default:
+			// All workers are busy; try again on the next call.
+			return
+		}
+		v.mtx.Lock()
+		v.verifications[block.Height] = verification
+		v.mtx.Unlock()
+	}
+}
+
+// isScheduled returns true if the verification of block against next has
+// already been scheduled.
+func (v *blockVerifier) isScheduled(block, next *types.Block) bool {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	verification := v.verifications[block.Height]
+	return verification != nil && verification.block == block && verification.next == next
+}
+
+// Result waits for and returns the verification of block against next, or nil
+// if it was not scheduled or could not be carried out.
+func (v *blockVerifier) Result(block, next *types.Block) *blockVerification {
+	v.mtx.Lock()
+	verification := v.verifications[block.Height]
+	v.mtx.Unlock()
+	if verification == nil || verification.block != block || verification.next != next {
+		return nil
+	}
+	select {
+	case <-verification.done:
+	case <-v.Quit():
+		return nil
+	}
+	if verification.parts == nil {
+		return nil
+	}
+	return verification
+}
+
+// Discard removes the verification at the given height, if any, so that the
+// blocks at that height are verified again.
+func (v *blockVerifier) Discard(height int64) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	delete(v.verifications, height)
+}
+
+// Prune removes all verifications below the given height.
+func (v *blockVerifier) Prune(height int64) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	for h := range v.verifications {
+		if h < height {
+			delete(v.verifications, h)
+		}
+	}
+}
+
+func (v *blockVerifier) verifyRoutine() {
+	for {
+		select {
+		case verification := <-v.jobs:
+			v.verify(verification)
+		case <-v.Quit():
+			return
+		}
+	}
+}
+
+func (v *blockVerifier) verify(verification *blockVerification) {
+	defer close(verification.done)
+
+	block := verification.block
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		v.Logger.Debug("Failed to make part set", "height", block.Height, "err", err)
+		return
+	}
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+	verification.err = verification.vals.VerifyCommitLight(
+		v.chainID, blockID, block.Height, verification.next.LastCommit)
+	verification.parts = parts
+}
+
+// verificationCandidates returns copies of the validator sets that upcoming
+// blocks are verified with, along with their hashes. The copies are prepared
+// so that they can be used concurrently.
+func verificationCandidates(state sm.State) ([]*types.ValidatorSet, [][]byte) {
+	var (
+		candidates []*types.ValidatorSet
+		hashes     [][]byte
+	)
+	for _, vals := range []*types.ValidatorSet{state.Validators, state.NextValidators} {
+		if vals == nil || vals.IsNilOrEmpty() {
+			continue
+		}
+		vals = vals.Copy()
+		// Populate the lazily computed fields, which would otherwise be
+		// written to by concurrent verifications.
+		vals.GetProposer()
+		vals.TotalVotingPower()
+		candidates = append(candidates, vals)
+		hashes = append(hashes, vals.Hash())
+	}
+	return candidates, hashes
+}
+
+// pickValidatorSet returns the candidate validator set that the block's header
+// claims, if any.
+func pickValidatorSet(block *types.Block, candidates []*types.ValidatorSet, hashes [][]byte) (*types.ValidatorSet, []byte) {
+	for i, hash := range hashes {
+		if bytes.Equal(block.ValidatorsHash, hash) {
+			return candidates[i], hash
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,92 @@
+package blocksync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// makeVerifierChain makes numBlocks consecutive blocks, starting at height 1,
+// each of which is committed by the LastCommit of the next one.
+func makeVerifierChain(t *testing.T, vals *types.ValidatorSet, privVals []types.PrivValidator, numBlocks int) []*types.Block {
+	t.Helper()
+
+	blocks := make([]*types.Block, 0, numBlocks)
+	lastCommit := &types.Commit{}
+	for height := int64(1); height <= int64(numBlocks); height++ {
+		block := types.MakeBlock(height, nil, lastCommit, nil)
+		block.ChainID = test.DefaultTestChainID
+		block.ValidatorsHash = vals.Hash()
+		block.ProposerAddress = vals.Validators[0].Address
+		block.LastBlockID = lastCommit.BlockID
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+		lastCommit, err = test.MakeCommit(blockID, height, 0, vals, privVals, test.DefaultTestChainID, cmttime.Now())
+		require.NoError(t, err)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func TestBlockVerifier(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(4, 10)
+	state := sm.State{ChainID: test.DefaultTestChainID, Validators: vals, NextValidators: vals}
+	blocks := makeVerifierChain(t, vals, privVals, 4)
+
+	verifier := newBlockVerifier(test.DefaultTestChainID)
+	require.NoError(t, verifier.Start())
+	t.Cleanup(func() { _ = verifier.Stop() })
+
+	// The last block can't be verified yet, and the missing one breaks the
+	// chain of pairs.
+	verifier.Schedule(state, []*types.Block{blocks[0], blocks[1], nil, blocks[3]})
+
+	verification := verifier.Result(blocks[0], blocks[1])
+	require.NotNil(t, verification)
+	require.NoError(t, verification.err)
+	parts, err := blocks[0].MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	assert.Equal(t, parts.Header(), verification.parts.Header())
+
+	assert.Nil(t, verifier.Result(blocks[1], blocks[2]))
+	assert.Nil(t, verifier.Result(blocks[2], blocks[3]))
+
+	// Verifications are scheduled once the missing block arrives.
+	verifier.Schedule(state, blocks)
+	verification = verifier.Result(blocks[1], blocks[2])
+	require.NotNil(t, verification)
+	require.NoError(t, verification.err)
+
+	// A different next block, committing to another block, is verified again.
+	otherID := types.BlockID{
+		Hash:          tmhash.Sum([]byte("other")),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("other_parts"))},
+	}
+	otherCommit, err := test.MakeCommit(otherID, 1, 0, vals, privVals, test.DefaultTestChainID, cmttime.Now())
+	require.NoError(t, err)
+	otherNext := types.MakeBlock(2, nil, otherCommit, nil)
+	verifier.Schedule(state, []*types.Block{blocks[0], otherNext})
+	verification = verifier.Result(blocks[0], otherNext)
+	require.NotNil(t, verification)
+	require.Error(t, verification.err)
+
+	// Blocks claiming an unknown validator set are left for sequential
+	// verification.
+	otherVals, otherPrivVals := types.RandValidatorSet(4, 10)
+	unknownBlocks := makeVerifierChain(t, otherVals, otherPrivVals, 2)
+	verifier.Schedule(state, unknownBlocks)
+	assert.Nil(t, verifier.Result(unknownBlocks[0], unknownBlocks[1]))
+
+	verifier.Prune(2)
+	assert.Nil(t, verifier.Result(blocks[0], otherNext))
+	assert.NotNil(t, verifier.Result(blocks[1], blocks[2]))
+}
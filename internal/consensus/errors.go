@@ -12,6 +12,7 @@ var (
 	ErrCommitQuorumNotMet            = errors.New("extended commit does not have +2/3 majority")
 	ErrNilPrivValidator              = errors.New("entered createProposalBlock with privValidator being nil")
 	ErrProposalWithoutPreviousCommit = errors.New("propose step; cannot propose anything without commit for the previous block")
+	ErrConsensusStopped              = errors.New("consensus is stopped")
 )
 
 // Consensus sentinel errors.
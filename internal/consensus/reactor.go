@@ -938,6 +938,13 @@ func pickVoteToSend(
 			if c == nil {
 				return nil
 			}
+			// The votes of an aggregated commit carry no signature, unlike
+			// those of the seen commit, unless it was block synced.
+			if c.IsAggregated() {
+				if sc := conS.blockStore.LoadSeenCommit(prs.Height); sc != nil && !sc.IsAggregated() {
+					c = sc
+				}
+			}
 			ec = c.WrappedExtendedCommit()
 		}
 		if ec == nil {
@@ -1323,6 +1330,11 @@ func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader, rng *rand.Rand) *
 		if vote == nil {
 			ps.logger.Error("votes.GetByIndex returned nil", "votes", votes, "index", index)
 		}
+		// Votes reconstructed from an aggregated commit carry no signature,
+		// so they cannot be sent.
+		if vote != nil && len(vote.Signature) == 0 {
+			return nil
+		}
 		return vote
 	}
 	return nil
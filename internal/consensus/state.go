@@ -141,6 +141,16 @@ type State struct {
 	// a buffer to store the concatenated proposal block parts (serialization format)
 	// should only be accessed under the cs.mtx lock
 	serializedBlockBuffer []byte
+
+	// requests of PauseBetweenHeights, served by the receive routine once a
+	// height is committed
+	pauseRequests chan pauseRequest
+}
+
+// pauseRequest is a request of PauseBetweenHeights.
+type pauseRequest struct {
+	f     func() error
+	errCh chan error
 }
 
 // StateOption sets an optional parameter on the State.
@@ -171,6 +181,7 @@ func NewState(
 		evpool:           evpool,
 		evsw:             cmtevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		pauseRequests:    make(chan pauseRequest),
 	}
 	for _, option := range options {
 		option(cs)
@@ -453,6 +464,35 @@ func (cs *State) Wait() {
 	<-cs.done
 }
 
+// PauseBetweenHeights runs f once the current height is committed, before
+// the block of the next one is saved. While f runs, no block is saved or
+// applied, and neither the WAL, which is flushed beforehand, nor the private
+// validator state change. f runs without holding the consensus mutex, so the
+// consensus state can still be queried, but must return quickly as consensus
+// is paused meanwhile. It returns the error of f, or ctx's if no height is
+// committed before ctx is done.
+func (cs *State) PauseBetweenHeights(ctx context.Context, f func() error) error {
+	req := pauseRequest{f: f, errCh: make(chan error, 1)}
+	select {
+	case cs.pauseRequests <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-cs.Quit():
+		return ErrConsensusStopped
+	}
+	return <-req.errCh
+}
+
+// servePauseRequest runs a request of PauseBetweenHeights. It's called by the
+// receive routine, between heights.
+func (cs *State) servePauseRequest(req pauseRequest) {
+	if err := cs.wal.FlushAndSync(); err != nil {
+		req.errCh <- fmt.Errorf("failed to flush the WAL: %w", err)
+		return
+	}
+	req.errCh <- req.f()
+}
+
 // OpenWAL opens a file to log all consensus messages and timeouts for
 // deterministic accountability.
 func (cs *State) OpenWAL(walFile string) (WAL, error) {
@@ -640,7 +680,10 @@ func (cs *State) votesFromSeenCommit(state sm.State) (*types.VoteSet, error) {
 		return nil, fmt.Errorf("heights don't match in votesFromSeenCommit %v!=%v",
 			commit.Height, state.LastBlockHeight)
 	}
-	vs := commit.ToVoteSet(state.ChainID, state.LastValidators)
+	vs, err := commit.ToVoteSet(state.ChainID, state.LastValidators)
+	if err != nil {
+		return nil, err
+	}
 	if !vs.HasTwoThirdsMajority() {
 		return nil, ErrCommitQuorumNotMet
 	}
@@ -822,6 +865,7 @@ func (cs *State) receiveRoutine(maxSteps int) {
 		}
 	}()
 
+	height := cs.Height
 	for {
 		if maxSteps > 0 {
 			if cs.nSteps >= maxSteps {
@@ -834,7 +878,25 @@ func (cs *State) receiveRoutine(maxSteps int) {
 		rs := cs.RoundState
 		var mi msgInfo
 
+		// A request of PauseBetweenHeights is served in priority once a
+		// height is committed, or later while waiting for the next one.
+		var pauseRequests chan pauseRequest
+		if rs.Height > cs.state.InitialHeight &&
+			(rs.Height != height || rs.Step == cstypes.RoundStepNewHeight) {
+			height = rs.Height
+			pauseRequests = cs.pauseRequests
+			select {
+			case req := <-pauseRequests:
+				cs.servePauseRequest(req)
+				continue
+			default:
+			}
+		}
+
 		select {
+		case req := <-pauseRequests:
+			cs.servePauseRequest(req)
+
 		case <-cs.txNotifier.TxsAvailable():
 			cs.handleTxsAvailable()
 
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -379,6 +380,46 @@ func TestStateFullRound1(t *testing.T) {
 	validateLastPrecommit(t, cs, vss[0], propBlockHash)
 }
 
+func TestStatePauseBetweenHeights(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, cs.PauseBetweenHeights(ctx, func() error { return nil }), context.Canceled)
+
+	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	paused := make(chan int64)
+	resume := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cs.PauseBetweenHeights(context.Background(), func() error {
+			paused <- cs.blockStore.Height()
+			<-resume
+			return errors.New("paused")
+		})
+	}()
+
+	startTestRound(cs, height, round)
+	ensureNewRound(newRoundCh, height, round)
+
+	// The pause starts once the height is committed, and the next block is
+	// only committed once it's over.
+	select {
+	case h := <-paused:
+		require.Equal(t, height, h)
+	case <-time.After(ensureTimeout):
+		t.Fatal("consensus didn't pause")
+	}
+	ensureNewBlock(newBlockCh, height)
+	ensureNoNewEvent(newBlockCh, ensureTimeout, "consensus should be paused")
+	require.Equal(t, height, cs.blockStore.Height())
+	close(resume)
+	require.EqualError(t, <-errCh, "paused")
+	ensureNewBlock(newBlockCh, height+1)
+}
+
 // nil is proposed, so prevote and precommit nil.
 func TestStateFullRoundNil(t *testing.T) {
 	cs, _ := randState(1)
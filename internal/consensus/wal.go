@@ -284,6 +284,76 @@ func (wal *BaseWAL) SearchForEndHeight(
 	return nil, false, nil
 }
 
+// TrimWAL removes the messages written after #ENDHEIGHT height from the WAL at
+// walFile, so that consensus resumes at height + 1 after the state has been
+// rolled back. If the WAL doesn't contain #ENDHEIGHT height but later heights,
+// all its messages are removed. It returns the number of bytes removed, or
+// which would be removed if dryRun is true. The WAL must not be in use.
+func TrimWAL(walFile string, height int64, dryRun bool) (int64, error) {
+	if !cmtos.FileExists(walFile) {
+		return 0, nil
+	}
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return 0, err
+	}
+	defer group.Close()
+
+	gr, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		return 0, err
+	}
+	defer gr.Close()
+
+	var (
+		rd        = &countingReader{rd: gr}
+		dec       = NewWALDecoder(rd)
+		offset    = int64(-1)
+		laterSeen bool
+	)
+	for {
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("failed to read the WAL at offset %d: %w", rd.n, err)
+		}
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			switch {
+			case m.Height == height:
+				offset = rd.n
+			case m.Height > height:
+				laterSeen = true
+			}
+		}
+	}
+	switch {
+	case offset >= 0:
+	case laterSeen:
+		offset = 0
+	default:
+		return 0, nil
+	}
+
+	removed := rd.n - offset
+	if dryRun || removed == 0 {
+		return removed, nil
+	}
+	return removed, group.Truncate(offset)
+}
+
+// countingReader counts the bytes read from rd.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
 // A WALEncoder writes custom-encoded WAL messages to an output stream.
 //
 // Format: 4 bytes CRC sum + 4 bytes length + arbitrary-length value.
@@ -322,6 +322,47 @@ func TestWALSearchForEndHeight(t *testing.T) {
 	assert.Equal(t, rs.Height, h+1, "wrong height")
 }
 
+func TestTrimWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 6, getConfig(t))
+	require.NoError(t, err)
+	walFile := tempWALWithData(walBody)
+	defer os.Remove(walFile)
+
+	// Nothing is written after the last height.
+	removed, err := TrimWAL(walFile, 6, false)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	removed, err = TrimWAL(walFile, 3, true)
+	require.NoError(t, err)
+	assert.Positive(t, removed)
+	info, err := os.Stat(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(walBody), info.Size())
+
+	removedTrimmed, err := TrimWAL(walFile, 3, false)
+	require.NoError(t, err)
+	assert.Equal(t, removed, removedTrimmed)
+	info, err = os.Stat(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, int64(len(walBody))-removed, info.Size())
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+
+	_, found, err := wal.SearchForEndHeight(4, &WALSearchOptions{})
+	require.NoError(t, err)
+	assert.False(t, found, "expected not to find end height for 4")
+
+	gr, found, err := wal.SearchForEndHeight(3, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.True(t, found, "expected to find end height for 3")
+	defer gr.Close()
+	_, err = NewWALDecoder(gr).Decode()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
 func TestWALPeriodicSync(t *testing.T) {
 	walDir, err := os.MkdirTemp("", "wal")
 	require.NoError(t, err)
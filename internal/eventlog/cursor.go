@@ -0,0 +1,46 @@
+package eventlog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cursor identifies an event in the log. Height is the height of the block
+// whose commit published the event, and Index the position of the event among
+// the ones of the block, which is the same when they are replayed from the
+// stores.
+type Cursor struct {
+	Height int64
+	Index  int64
+}
+
+// ParseCursor parses a cursor in the format returned by Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	height, index, ok := strings.Cut(s, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor{Cursor: s}
+	}
+	h, err := strconv.ParseInt(height, 10, 64)
+	if err != nil || h < 0 {
+		return Cursor{}, ErrInvalidCursor{Cursor: s, Source: err}
+	}
+	i, err := strconv.ParseInt(index, 10, 64)
+	if err != nil || i < -1 {
+		return Cursor{}, ErrInvalidCursor{Cursor: s, Source: err}
+	}
+	return Cursor{Height: h, Index: i}, nil
+}
+
+// String returns the cursor as "<height>.<index>".
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d.%d", c.Height, c.Index)
+}
+
+// Before returns true if c identifies an event published before o.
+func (c Cursor) Before(o Cursor) bool {
+	if c.Height != o.Height {
+		return c.Height < o.Height
+	}
+	return c.Index < o.Index
+}
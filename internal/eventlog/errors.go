@@ -0,0 +1,35 @@
+package eventlog
+
+import "fmt"
+
+type ErrInvalidCursor struct {
+	Cursor string
+	Source error
+}
+
+func (e ErrInvalidCursor) Error() string {
+	if e.Source == nil {
+		return fmt.Sprintf("invalid cursor %q: expected <height>.<index>", e.Cursor)
+	}
+	return fmt.Sprintf("invalid cursor %q: %v", e.Cursor, e.Source)
+}
+
+func (e ErrInvalidCursor) Unwrap() error {
+	return e.Source
+}
+
+// ErrEventsUnavailable is returned when the events following a cursor are no
+// longer in the log, and the block they belong to can't be replayed from the
+// stores.
+type ErrEventsUnavailable struct {
+	Height int64
+	Source error
+}
+
+func (e ErrEventsUnavailable) Error() string {
+	return fmt.Sprintf("the events of height %d are no longer available: %v", e.Height, e.Source)
+}
+
+func (e ErrEventsUnavailable) Unwrap() error {
+	return e.Source
+}
@@ -0,0 +1,281 @@
+// Package eventlog keeps a bounded log of the events published on an event
+// bus when blocks are committed, assigning each a cursor, so that subscribers
+// can resume from the event following the last one they received. The events
+// of committed blocks which are no longer in the log are replayed from the
+// block and state stores. Other events, e.g. the consensus ones, cannot be
+// replayed and are not logged.
+package eventlog
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/libs/service"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const subscriber = "EventLog"
+
+var errBlockNotFound = errors.New("block not found in the block store")
+
+// Item is an event in the log.
+type Item struct {
+	Cursor Cursor
+	Data   types.TMEventData
+	Events map[string][]string
+}
+
+// Log keeps the latest events published on an event bus. It must be started
+// before the events to log are published.
+type Log struct {
+	service.BaseService
+
+	eventBus   *types.EventBus
+	blockStore sm.BlockStore
+	stateStore sm.Store
+
+	mtx     cmtsync.RWMutex
+	items   []Item // ring buffer of the latest events
+	head    int    // position of the oldest event in items
+	count   int    // number of events in items
+	floor   Cursor // all the events after floor are in items
+	last    Cursor // the latest event
+	changed chan struct{}
+
+	// Only accessed by the routine adding events.
+	blockEvents int // events of the latest block still to add
+}
+
+// New returns a log of the size latest events published on eventBus when
+// blocks are committed.
+func New(eventBus *types.EventBus, blockStore sm.BlockStore, stateStore sm.Store, size int) *Log {
+	l := &Log{
+		eventBus:   eventBus,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		items:      make([]Item, size),
+		changed:    make(chan struct{}),
+	}
+	l.BaseService = *service.NewBaseService(log.NewNopLogger(), "EventLog", l)
+	return l
+}
+
+// OnStart implements service.Service by subscribing to the events.
+func (l *Log) OnStart() error {
+	l.mtx.Lock()
+	l.last = l.initialCursor()
+	l.floor = l.last
+	l.mtx.Unlock()
+
+	// An unbuffered subscription is never canceled for not pulling events fast
+	// enough, which the log does.
+	sub, err := l.eventBus.SubscribeUnbuffered(context.Background(), subscriber, cmtquery.All)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				l.add(msg)
+			case <-sub.Canceled():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from all events.
+func (l *Log) OnStop() {
+	if l.eventBus.IsRunning() {
+		_ = l.eventBus.UnsubscribeAll(context.Background(), subscriber)
+	}
+}
+
+// initialCursor returns the cursor of the latest event of the latest block, so
+// that the cursors assigned after a restart follow the ones of its events.
+func (l *Log) initialCursor() Cursor {
+	height := l.blockStore.Height()
+	if msgs, err := l.loadBlockEvents(height); err == nil {
+		return Cursor{Height: height, Index: int64(len(msgs)) - 1}
+	}
+	block, _ := l.blockStore.LoadBlock(height)
+	if block == nil {
+		return Cursor{Height: height, Index: -1}
+	}
+	// Whether the validator set was updated is unknown without the response
+	// to FinalizeBlock.
+	return Cursor{Height: height, Index: int64(numBlockEvents(block, nil)) - 1}
+}
+
+// add assigns the next cursor to msg and adds it to the log if it is one of
+// the events of a block. Those are published from a single routine, starting
+// with NewBlock, so other events published amid them are ignored.
+func (l *Log) add(msg cmtpubsub.Message) {
+	if data, ok := msg.Data().(types.EventDataNewBlock); ok {
+		// The events of a block published again, e.g. on replay, are ignored.
+		l.blockEvents = 0
+		if data.Block.Height > l.last.Height {
+			l.append(Cursor{Height: data.Block.Height, Index: 0}, msg)
+			l.blockEvents = numBlockEvents(data.Block, &data.ResultFinalizeBlock) - 1
+		}
+		return
+	}
+
+	if l.blockEvents > 0 && isBlockEvent(msg.Data()) {
+		l.append(l.next(), msg)
+		l.blockEvents--
+	}
+}
+
+func (l *Log) next() Cursor {
+	return Cursor{Height: l.last.Height, Index: l.last.Index + 1}
+}
+
+func (l *Log) append(cursor Cursor, msg cmtpubsub.Message) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if size := len(l.items); size > 0 {
+		if l.count == size {
+			l.floor = l.items[l.head].Cursor
+			l.items[l.head] = Item{}
+			l.head = (l.head + 1) % size
+			l.count--
+		}
+		l.items[(l.head+l.count)%size] = Item{Cursor: cursor, Data: msg.Data(), Events: msg.Events()}
+		l.count++
+	} else {
+		l.floor = cursor
+	}
+	l.last = cursor
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// Last returns the cursor of the latest event.
+func (l *Log) Last() Cursor {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.last
+}
+
+// Changed returns a channel closed when the next event is added to the log.
+func (l *Log) Changed() <-chan struct{} {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.changed
+}
+
+// After returns the events following cursor, in order. If they are no longer
+// in the log, the events of the next block are replayed from the stores, so
+// only the events of committed blocks are returned until the log is reached.
+// It returns no events if there are none yet following cursor.
+//
+// It returns ErrEventsUnavailable if the next block or its response to
+// FinalizeBlock is not in the stores, e.g. because it was pruned or the
+// responses are discarded.
+func (l *Log) After(cursor Cursor) ([]Item, error) {
+	l.mtx.RLock()
+	floor := l.floor
+	if !cursor.Before(floor) {
+		defer l.mtx.RUnlock()
+		return l.itemsAfter(cursor), nil
+	}
+	l.mtx.RUnlock()
+
+	for height := max(cursor.Height, 1); height <= floor.Height; height++ {
+		msgs, err := l.loadBlockEvents(height)
+		if err != nil {
+			return nil, ErrEventsUnavailable{Height: height, Source: err}
+		}
+		var items []Item
+		for i, msg := range msgs {
+			c := Cursor{Height: height, Index: int64(i)}
+			if cursor.Before(c) && !floor.Before(c) {
+				items = append(items, Item{Cursor: c, Data: msg.Data(), Events: msg.Events()})
+			}
+		}
+		if len(items) > 0 {
+			return items, nil
+		}
+	}
+	return l.After(floor)
+}
+
+// itemsAfter returns the events in the log following cursor. The caller must
+// hold the lock.
+func (l *Log) itemsAfter(cursor Cursor) []Item {
+	size := len(l.items)
+	first := sort.Search(l.count, func(i int) bool {
+		return cursor.Before(l.items[(l.head+i)%size].Cursor)
+	})
+	items := make([]Item, 0, l.count-first)
+	for i := first; i < l.count; i++ {
+		items = append(items, l.items[(l.head+i)%size])
+	}
+	return items
+}
+
+// loadBlockEvents returns the events of the block at height, as published when
+// it was committed.
+func (l *Log) loadBlockEvents(height int64) ([]cmtpubsub.Message, error) {
+	block, meta := l.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	resp, err := l.stateStore.LoadFinalizeBlockResponse(height)
+	if err != nil {
+		return nil, err
+	}
+	recorder := &types.EventRecorder{}
+	if err := sm.FireBlockEvents(recorder, block, meta.BlockID, resp); err != nil {
+		return nil, err
+	}
+	return recorder.Messages, nil
+}
+
+// numBlockEvents returns the number of events published when block is
+// committed, given the response to FinalizeBlock if known.
+func numBlockEvents(block *types.Block, resp *abci.FinalizeBlockResponse) int {
+	// NewBlock, NewBlockHeader and NewBlockEvents, then NewEvidence and Tx for
+	// each evidence and transaction.
+	n := 3 + len(block.Evidence.Evidence) + len(block.Txs)
+	if resp != nil && len(resp.ValidatorUpdates) > 0 {
+		n++
+	}
+	return n
+}
+
+// IsLogged returns true for the events kept in a log, which are the ones
+// published when a block is committed.
+func IsLogged(data types.TMEventData) bool {
+	_, ok := data.(types.EventDataNewBlock)
+	return ok || isBlockEvent(data)
+}
+
+// isBlockEvent returns true for the events published when a block is
+// committed, other than NewBlock.
+func isBlockEvent(data types.TMEventData) bool {
+	switch data.(type) {
+	case types.EventDataNewBlockHeader,
+		types.EventDataNewBlockEvents,
+		types.EventDataNewEvidence,
+		types.EventDataTx,
+		types.EventDataValidatorSetUpdates:
+		return true
+	default:
+		return false
+	}
+}
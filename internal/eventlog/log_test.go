@@ -0,0 +1,146 @@
+package eventlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/state/mocks"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// blockEvents returns the events published when a block with two transactions
+// is committed at height.
+func blockEvents(t *testing.T, height int64) (*types.Block, *types.BlockMeta, *abci.FinalizeBlockResponse, []cmtpubsub.Message) {
+	t.Helper()
+	block := types.MakeBlock(height, []types.Tx{types.Tx("a"), types.Tx("b")}, &types.Commit{}, nil)
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	meta := &types.BlockMeta{BlockID: types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}}
+	resp := &abci.FinalizeBlockResponse{
+		TxResults: []*abci.ExecTxResult{{Code: abci.CodeTypeOK}, {Code: 1}},
+	}
+
+	recorder := &types.EventRecorder{}
+	require.NoError(t, sm.FireBlockEvents(recorder, block, meta.BlockID, resp))
+	return block, meta, resp, recorder.Messages
+}
+
+func cursors(items []Item) []string {
+	cs := make([]string, len(items))
+	for i, item := range items {
+		cs[i] = item.Cursor.String()
+	}
+	return cs
+}
+
+func TestLogCursors(t *testing.T) {
+	block, _, resp, msgs := blockEvents(t, 1)
+	require.Len(t, msgs, 5)
+
+	pendingTx := cmtpubsub.NewMessage(types.EventDataPendingTx{Tx: []byte("c")}, nil)
+	newRound := cmtpubsub.NewMessage(types.EventDataNewRound{Height: 2}, nil)
+
+	l := New(nil, nil, nil, 10)
+	l.last = Cursor{Height: 0, Index: -1}
+	l.floor = l.last
+	// The events other than the ones of the block are not logged.
+	l.add(newRound)
+	for i, msg := range msgs {
+		l.add(msg)
+		if i == 2 {
+			l.add(pendingTx)
+		}
+	}
+	l.add(newRound)
+	// Nor are the events of a block published again.
+	l.add(cmtpubsub.NewMessage(types.EventDataNewBlock{Block: block, ResultFinalizeBlock: *resp}, nil))
+	l.add(msgs[1])
+
+	items, err := l.After(Cursor{Height: 0, Index: -1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.0", "1.1", "1.2", "1.3", "1.4"}, cursors(items))
+	for i, msg := range msgs {
+		assert.Equal(t, msg.Data(), items[i].Data)
+		assert.True(t, IsLogged(msg.Data()))
+	}
+	assert.False(t, IsLogged(pendingTx.Data()))
+	assert.Equal(t, Cursor{Height: 1, Index: 4}, l.Last())
+
+	items, err = l.After(Cursor{Height: 1, Index: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.3", "1.4"}, cursors(items))
+
+	items, err = l.After(l.Last())
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestLogAfterReplaysFromStores(t *testing.T) {
+	block, meta, resp, msgs := blockEvents(t, 1)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlock", int64(1)).Return(block, meta)
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadFinalizeBlockResponse", int64(1)).Return(resp, nil)
+
+	l := New(nil, blockStore, stateStore, 2)
+	l.last = Cursor{Height: 0, Index: -1}
+	l.floor = l.last
+	for _, msg := range msgs {
+		l.add(msg)
+	}
+
+	// The events up to 1.2 are no longer in the log.
+	items, err := l.After(Cursor{Height: 1, Index: 0})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.1", "1.2"}, cursors(items))
+	for i, item := range items {
+		assert.Equal(t, msgs[i+1].Data(), item.Data)
+		assert.Equal(t, msgs[i+1].Events(), item.Events)
+	}
+
+	items, err = l.After(items[len(items)-1].Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.3", "1.4"}, cursors(items))
+}
+
+func TestLogAfterEventsUnavailable(t *testing.T) {
+	_, _, _, msgs := blockEvents(t, 1)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlock", int64(1)).Return(nil, nil)
+	stateStore := &mocks.Store{}
+
+	l := New(nil, blockStore, stateStore, 1)
+	l.last = Cursor{Height: 0, Index: -1}
+	l.floor = l.last
+	for _, msg := range msgs {
+		l.add(msg)
+	}
+
+	_, err := l.After(Cursor{Height: 0, Index: -1})
+	var unavailable ErrEventsUnavailable
+	require.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, int64(1), unavailable.Height)
+	require.ErrorIs(t, err, errBlockNotFound)
+}
+
+func TestParseCursor(t *testing.T) {
+	for _, s := range []string{"0.-1", "1.0", "12.345"} {
+		c, err := ParseCursor(s)
+		require.NoError(t, err)
+		assert.Equal(t, s, c.String())
+	}
+	for _, s := range []string{"", "1", "1.", ".1", "a.1", "1.a", "-1.0", "1.-2"} {
+		_, err := ParseCursor(s)
+		require.ErrorAs(t, err, &ErrInvalidCursor{}, s)
+	}
+	assert.True(t, Cursor{Height: 1, Index: 5}.Before(Cursor{Height: 2, Index: 0}))
+	assert.True(t, Cursor{Height: 2, Index: 0}.Before(Cursor{Height: 2, Index: 1}))
+	assert.False(t, Cursor{Height: 2, Index: 1}.Before(Cursor{Height: 2, Index: 1}))
+}
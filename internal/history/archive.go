@@ -0,0 +1,256 @@
+// Package history exports and imports the history of a chain, stored in the
+// block store and state store, in a portable archive format, independent of
+// the database backend and key layout.
+//
+// An archive is a gzip stream holding a magic string followed by a sequence
+// of records:
+//
+//	kind (1 byte) | length (4 bytes) | payload | CRC32-C (4 bytes)
+//
+// The first record is the header of the archive, describing its content.
+// Then, for each height, the block comes first, followed by its seen commit,
+// extended commit, validator set, consensus params and FinalizeBlock
+// response. The state at the last height follows if it was exported, and the
+// last record is a trailer with the number of previous records and the
+// SHA-256 hash of the archive up to the trailer.
+package history
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// Version is the version of the archive format.
+const Version = 1
+
+const (
+	magic = "CMTHIST\x00"
+
+	// maxRecordSize bounds the size of a record, to detect corrupted
+	// lengths before allocating.
+	maxRecordSize = 1 << 30
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+type recordKind byte
+
+const (
+	kindHeader recordKind = iota + 1
+	kindBlock
+	kindSeenCommit
+	kindExtendedCommit
+	kindValidators
+	kindConsensusParams
+	kindFinalizeBlockResponse
+	kindState
+	kindTrailer
+)
+
+func (k recordKind) String() string {
+	switch k {
+	case kindHeader:
+		return "header"
+	case kindBlock:
+		return "block"
+	case kindSeenCommit:
+		return "seen commit"
+	case kindExtendedCommit:
+		return "extended commit"
+	case kindValidators:
+		return "validators"
+	case kindConsensusParams:
+		return "consensus params"
+	case kindFinalizeBlockResponse:
+		return "FinalizeBlock response"
+	case kindState:
+		return "state"
+	case kindTrailer:
+		return "trailer"
+	default:
+		return fmt.Sprintf("unknown (%d)", byte(k))
+	}
+}
+
+// Header describes the content of an archive.
+type Header struct {
+	Version    uint32 `json:"version"`
+	ChainID    string `json:"chain_id"`
+	FromHeight int64  `json:"from_height"`
+	ToHeight   int64  `json:"to_height"`
+}
+
+// ErrCorruptedArchive is returned when reading an archive that isn't valid.
+type ErrCorruptedArchive struct {
+	Err error
+}
+
+func (e ErrCorruptedArchive) Error() string {
+	return fmt.Sprintf("corrupted archive: %v", e.Err)
+}
+
+func (e ErrCorruptedArchive) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnsupportedVersion is returned when reading an archive with another
+// version of the format.
+type ErrUnsupportedVersion struct {
+	Version uint32
+}
+
+func (e ErrUnsupportedVersion) Error() string {
+	return fmt.Sprintf("unsupported archive version %d, expected %d", e.Version, Version)
+}
+
+// writer writes the records of an archive.
+type writer struct {
+	gz      *gzip.Writer
+	w       io.Writer // writes to gz and hash
+	hash    hash.Hash
+	records uint64
+}
+
+func newWriter(w io.Writer, header Header) (*writer, error) {
+	gz := gzip.NewWriter(w)
+	h := sha256.New()
+	aw := &writer{gz: gz, w: io.MultiWriter(gz, h), hash: h}
+	if _, err := io.WriteString(aw.w, magic); err != nil {
+		return nil, err
+	}
+	bz, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if err := aw.write(kindHeader, bz); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+func (w *writer) write(kind recordKind, payload []byte) error {
+	if len(payload) > maxRecordSize {
+		return fmt.Errorf("%s record too large: %d bytes", kind, len(payload))
+	}
+	header := make([]byte, 5)
+	header[0] = byte(kind)
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	crc := crc32.Update(crc32.Checksum(header, crc32c), crc32c, payload)
+	if _, err := w.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(payload); err != nil {
+		return err
+	}
+	if err := binary.Write(w.w, binary.BigEndian, crc); err != nil {
+		return err
+	}
+	w.records++
+	return nil
+}
+
+// close writes the trailer and flushes the archive.
+func (w *writer) close() error {
+	trailer := binary.BigEndian.AppendUint64(nil, w.records)
+	trailer = w.hash.Sum(trailer)
+	if err := w.write(kindTrailer, trailer); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+// reader reads the records of an archive, verifying their checksums.
+type reader struct {
+	r       *bufio.Reader
+	hash    hash.Hash
+	records uint64
+	done    bool
+}
+
+func newReader(r io.Reader) (*reader, Header, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, Header{}, ErrCorruptedArchive{Err: err}
+	}
+	ar := &reader{r: bufio.NewReader(gz), hash: sha256.New()}
+	bz := make([]byte, len(magic))
+	if _, err := io.ReadFull(ar.r, bz); err != nil {
+		return nil, Header{}, ErrCorruptedArchive{Err: err}
+	}
+	if string(bz) != magic {
+		return nil, Header{}, ErrCorruptedArchive{Err: errors.New("not a history archive")}
+	}
+	ar.hash.Write(bz)
+
+	kind, payload, err := ar.next()
+	if err != nil {
+		return nil, Header{}, err
+	}
+	if kind != kindHeader {
+		return nil, Header{}, ErrCorruptedArchive{Err: fmt.Errorf("expected header, got %s record", kind)}
+	}
+	var header Header
+	if err := json.Unmarshal(payload, &header); err != nil {
+		return nil, Header{}, ErrCorruptedArchive{Err: fmt.Errorf("decoding header: %w", err)}
+	}
+	if header.Version != Version {
+		return nil, Header{}, ErrUnsupportedVersion{Version: header.Version}
+	}
+	return ar, header, nil
+}
+
+// next returns the next record. It verifies the trailer when reaching it,
+// and returns io.EOF after it.
+func (r *reader) next() (recordKind, []byte, error) {
+	if r.done {
+		return 0, nil, io.EOF
+	}
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return 0, nil, ErrCorruptedArchive{Err: fmt.Errorf("reading record: %w", err)}
+	}
+	kind := recordKind(header[0])
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > maxRecordSize {
+		return 0, nil, ErrCorruptedArchive{Err: fmt.Errorf("%s record too large: %d bytes", kind, length)}
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		return 0, nil, ErrCorruptedArchive{Err: fmt.Errorf("reading %s record: %w", kind, err)}
+	}
+	var crc uint32
+	if err := binary.Read(r.r, binary.BigEndian, &crc); err != nil {
+		return 0, nil, ErrCorruptedArchive{Err: fmt.Errorf("reading %s record: %w", kind, err)}
+	}
+	if crc32.Update(crc32.Checksum(header, crc32c), crc32c, payload) != crc {
+		return 0, nil, ErrCorruptedArchive{Err: fmt.Errorf("checksum mismatch in %s record", kind)}
+	}
+
+	if kind == kindTrailer {
+		if len(payload) != 8+sha256.Size {
+			return 0, nil, ErrCorruptedArchive{Err: errors.New("invalid trailer")}
+		}
+		if records := binary.BigEndian.Uint64(payload); records != r.records {
+			return 0, nil, ErrCorruptedArchive{Err: fmt.Errorf("expected %d records, got %d", records, r.records)}
+		}
+		if sum := r.hash.Sum(nil); string(sum) != string(payload[8:]) {
+			return 0, nil, ErrCorruptedArchive{Err: errors.New("archive hash mismatch")}
+		}
+		r.done = true
+		return kind, nil, nil
+	}
+
+	r.hash.Write(header)
+	r.hash.Write(payload)
+	_ = binary.Write(r.hash, binary.BigEndian, crc)
+	r.records++
+	return kind, payload, nil
+}
@@ -0,0 +1,128 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+)
+
+// ErrHeightOutOfRange is returned when exporting heights the block store
+// doesn't have.
+type ErrHeightOutOfRange struct {
+	From, To     int64
+	Base, Height int64
+}
+
+func (e ErrHeightOutOfRange) Error() string {
+	return fmt.Sprintf("cannot export heights %d to %d, the block store has heights %d to %d",
+		e.From, e.To, e.Base, e.Height)
+}
+
+// Export writes the history of the chain between the given heights
+// (inclusive) to w, as an archive. If to is the last height of the state
+// store, the state is exported as well, so that a node can be started from
+// the imported history.
+func Export(w io.Writer, blockStore *store.BlockStore, stateStore sm.Store, from, to int64) (Header, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return Header{}, err
+	}
+	base, height := blockStore.Base(), blockStore.Height()
+	if from > to || from < base || to > height {
+		return Header{}, ErrHeightOutOfRange{From: from, To: to, Base: base, Height: height}
+	}
+
+	header := Header{Version: Version, ChainID: state.ChainID, FromHeight: from, ToHeight: to}
+	aw, err := newWriter(w, header)
+	if err != nil {
+		return Header{}, err
+	}
+	for h := from; h <= to; h++ {
+		if err := exportHeight(aw, blockStore, stateStore, h); err != nil {
+			return Header{}, fmt.Errorf("exporting height %d: %w", h, err)
+		}
+	}
+	if state.LastBlockHeight == to {
+		pb, err := state.ToProto()
+		if err != nil {
+			return Header{}, err
+		}
+		if err := writeProto(aw, kindState, pb); err != nil {
+			return Header{}, err
+		}
+	}
+	return header, aw.close()
+}
+
+func exportHeight(aw *writer, blockStore *store.BlockStore, stateStore sm.Store, height int64) error {
+	block, _ := blockStore.LoadBlock(height)
+	if block == nil {
+		return errors.New("block not found")
+	}
+	pbb, err := block.ToProto()
+	if err != nil {
+		return err
+	}
+	if err := writeProto(aw, kindBlock, pbb); err != nil {
+		return err
+	}
+
+	seenCommit := blockStore.LoadSeenCommit(height)
+	if seenCommit == nil {
+		// The commit in the next block is used instead.
+		seenCommit = blockStore.LoadBlockCommit(height)
+	}
+	if seenCommit == nil {
+		return errors.New("commit not found")
+	}
+	if err := writeProto(aw, kindSeenCommit, seenCommit.ToProto()); err != nil {
+		return err
+	}
+
+	if extCommit := blockStore.LoadBlockExtendedCommit(height); extCommit != nil {
+		if err := writeProto(aw, kindExtendedCommit, extCommit.ToProto()); err != nil {
+			return err
+		}
+	}
+
+	vals, err := stateStore.LoadValidators(height)
+	if err != nil {
+		return err
+	}
+	pbv, err := vals.ToProto()
+	if err != nil {
+		return err
+	}
+	if err := writeProto(aw, kindValidators, pbv); err != nil {
+		return err
+	}
+
+	params, err := stateStore.LoadConsensusParams(height)
+	if err != nil {
+		return err
+	}
+	pbp := params.ToProto()
+	if err := writeProto(aw, kindConsensusParams, &pbp); err != nil {
+		return err
+	}
+
+	// The responses are required to verify the app hashes on import.
+	resp, err := stateStore.LoadFinalizeBlockResponse(height)
+	if err != nil {
+		return err
+	}
+	return writeProto(aw, kindFinalizeBlockResponse, resp)
+}
+
+func writeProto(aw *writer, kind recordKind, msg proto.Message) error {
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return aw.write(kind, bz)
+}
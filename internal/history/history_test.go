@@ -0,0 +1,324 @@
+package history
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	mpmocks "github.com/cometbft/cometbft/v2/mempool/mocks"
+	"github.com/cometbft/cometbft/v2/proxy"
+	sm "github.com/cometbft/cometbft/v2/state"
+	smmocks "github.com/cometbft/cometbft/v2/state/mocks"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+type testChain struct {
+	genDoc     *types.GenesisDoc
+	blockStore *store.BlockStore
+	stateStore sm.Store
+	state      sm.State
+}
+
+// makeChain commits the given number of blocks with a single validator.
+// Vote extensions are enabled from height extHeight, if not zero.
+func makeChain(t *testing.T, height, extHeight int64) *testChain {
+	t.Helper()
+	val, privVal := types.RandValidator(false, 30)
+	params := types.DefaultConsensusParams()
+	params.Feature.VoteExtensionsEnableHeight = extHeight
+	genDoc := &types.GenesisDoc{
+		GenesisTime:     cmttime.Now(),
+		ChainID:         test.DefaultTestChainID,
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+		ConsensusParams: params,
+	}
+
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { _ = proxyApp.Stop() })
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: false})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Save(state))
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("PreUpdate").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mp, sm.EmptyEvidencePool{}, blockStore)
+
+	seenExtCommit := &types.ExtendedCommit{}
+	for h := int64(1); h <= height; h++ {
+		txs := []types.Tx{[]byte(fmt.Sprintf("key%d=value%d", h, h))}
+		block := state.MakeBlock(h, txs, seenExtCommit.ToCommit(), nil, state.Validators.Proposer.Address)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+		vote, err := types.MakeVote(privVal, block.ChainID, 0, h, 0, types.PrecommitType, blockID, cmttime.Now())
+		require.NoError(t, err)
+		seenExtCommit = &types.ExtendedCommit{
+			Height:             h,
+			BlockID:            blockID,
+			ExtendedSignatures: []types.ExtendedCommitSig{vote.ExtendedCommitSig()},
+		}
+
+		state, err = blockExec.ApplyBlock(state, blockID, block, height)
+		require.NoError(t, err)
+		if params.Feature.VoteExtensionsEnabled(h) {
+			blockStore.SaveBlockWithExtendedCommit(block, parts, seenExtCommit)
+		} else {
+			blockStore.SaveBlock(block, parts, seenExtCommit.ToCommit())
+		}
+	}
+	return &testChain{genDoc: genDoc, blockStore: blockStore, stateStore: stateStore, state: state}
+}
+
+func newStores() (*store.BlockStore, sm.Store) {
+	return store.NewBlockStore(dbm.NewMemDB()), sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+}
+
+func export(t *testing.T, chain *testChain, from, to int64) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	header, err := Export(&buf, chain.blockStore, chain.stateStore, from, to)
+	require.NoError(t, err)
+	require.Equal(t, Header{Version: Version, ChainID: test.DefaultTestChainID, FromHeight: from, ToHeight: to}, header)
+	return buf.Bytes()
+}
+
+func TestExportImport(t *testing.T) {
+	chain := makeChain(t, 10, 4)
+	archive := export(t, chain, 1, 10)
+
+	blockStore, stateStore := newStores()
+	first := chain.blockStore.LoadBlockMeta(1).BlockID.Hash
+	header, err := Import(bytes.NewReader(archive), blockStore, stateStore, test.DefaultTestChainID, WithTrustedHash(first))
+	require.NoError(t, err)
+	require.EqualValues(t, 10, header.ToHeight)
+
+	require.EqualValues(t, 1, blockStore.Base())
+	require.EqualValues(t, 10, blockStore.Height())
+	for h := int64(1); h <= 10; h++ {
+		block, _ := blockStore.LoadBlock(h)
+		expected, _ := chain.blockStore.LoadBlock(h)
+		require.Equal(t, expected.Hash(), block.Hash())
+		require.Equal(t, chain.blockStore.LoadSeenCommit(h).Hash(), blockStore.LoadSeenCommit(h).Hash())
+		if h >= 4 {
+			require.NotNil(t, blockStore.LoadBlockExtendedCommit(h))
+		} else {
+			require.Nil(t, blockStore.LoadBlockExtendedCommit(h))
+		}
+
+		vals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err)
+		require.EqualValues(t, expected.ValidatorsHash, vals.Hash())
+		params, err := stateStore.LoadConsensusParams(h)
+		require.NoError(t, err)
+		require.EqualValues(t, expected.ConsensusHash, params.Hash())
+		resp, err := stateStore.LoadFinalizeBlockResponse(h)
+		require.NoError(t, err)
+		expectedResp, err := chain.stateStore.LoadFinalizeBlockResponse(h)
+		require.NoError(t, err)
+		require.Equal(t, expectedResp.AppHash, resp.AppHash)
+	}
+
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, chain.state.LastBlockID, state.LastBlockID)
+	require.Equal(t, chain.state.AppHash, state.AppHash)
+
+	// The stores must be empty.
+	_, err = Import(bytes.NewReader(archive), blockStore, stateStore, test.DefaultTestChainID)
+	require.ErrorIs(t, err, ErrNonEmptyStore)
+}
+
+func TestExportImportRange(t *testing.T) {
+	chain := makeChain(t, 10, 0)
+
+	_, err := Export(io.Discard, chain.blockStore, chain.stateStore, 5, 11)
+	require.ErrorAs(t, err, &ErrHeightOutOfRange{})
+
+	// The state isn't exported if the range doesn't end at the last height.
+	blockStore, stateStore := newStores()
+	_, err = Import(bytes.NewReader(export(t, chain, 3, 7)), blockStore, stateStore, test.DefaultTestChainID,
+		WithTrustedHash(chain.blockStore.LoadBlockMeta(3).BlockID.Hash))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, blockStore.Base())
+	require.EqualValues(t, 7, blockStore.Height())
+	_, err = stateStore.LoadFinalizeBlockResponse(6)
+	require.NoError(t, err)
+	// Without the state, the last FinalizeBlock response can't be verified.
+	_, err = stateStore.LoadFinalizeBlockResponse(7)
+	require.Error(t, err)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	require.True(t, state.IsEmpty())
+}
+
+func TestImportWrongChainID(t *testing.T) {
+	chain := makeChain(t, 3, 0)
+	blockStore, stateStore := newStores()
+	_, err := Import(bytes.NewReader(export(t, chain, 1, 3)), blockStore, stateStore, "other-chain")
+	require.ErrorAs(t, err, &ErrChainIDMismatch{})
+	require.True(t, blockStore.IsEmpty())
+}
+
+func TestImportWrongTrustedHash(t *testing.T) {
+	chain := makeChain(t, 3, 0)
+	blockStore, stateStore := newStores()
+	_, err := Import(bytes.NewReader(export(t, chain, 1, 3)), blockStore, stateStore, test.DefaultTestChainID,
+		WithTrustedHash(chain.blockStore.LoadBlockMeta(2).BlockID.Hash))
+	require.ErrorAs(t, err, &ErrInvalidHeight{})
+	require.True(t, blockStore.IsEmpty())
+}
+
+func TestImportCorruptedArchive(t *testing.T) {
+	chain := makeChain(t, 3, 0)
+	archive := export(t, chain, 1, 3)
+
+	// Decompress, flip a byte in the middle and compress again, so that only
+	// the record checksums detect the corruption.
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	raw[len(raw)/2] ^= 0xff
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err = w.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	blockStore, stateStore := newStores()
+	_, err = Import(&buf, blockStore, stateStore, test.DefaultTestChainID, WithGenesis(chain.genDoc))
+	require.ErrorAs(t, err, &ErrCorruptedArchive{})
+
+	// A truncated archive is detected too.
+	blockStore, stateStore = newStores()
+	_, err = Import(bytes.NewReader(archive[:len(archive)-20]), blockStore, stateStore, test.DefaultTestChainID,
+		WithGenesis(chain.genDoc))
+	require.ErrorAs(t, err, &ErrCorruptedArchive{})
+}
+
+func TestImportInvalidCommit(t *testing.T) {
+	chain := makeChain(t, 3, 0)
+
+	// Replace the seen commit of the last height with a commit of another
+	// validator.
+	_, privVal := types.RandValidator(false, 30)
+	meta := chain.blockStore.LoadBlockMeta(3)
+	vote, err := types.MakeVote(privVal, test.DefaultTestChainID, 0, 3, 0, types.PrecommitType, meta.BlockID, cmttime.Now())
+	require.NoError(t, err)
+	commit := &types.Commit{Height: 3, BlockID: meta.BlockID, Signatures: []types.CommitSig{vote.CommitSig()}}
+
+	forged := rewriteArchive(t, export(t, chain, 1, 3), func(aw *writer, kind recordKind, height int64, payload []byte) error {
+		if kind == kindSeenCommit && height == 3 {
+			return writeProto(aw, kind, commit.ToProto())
+		}
+		return aw.write(kind, payload)
+	})
+
+	blockStore, stateStore := newStores()
+	_, err = Import(bytes.NewReader(forged), blockStore, stateStore, test.DefaultTestChainID, WithGenesis(chain.genDoc))
+	var invalidErr ErrInvalidHeight
+	require.ErrorAs(t, err, &invalidErr)
+	require.EqualValues(t, 3, invalidErr.Height)
+	// The previous heights were verified and saved.
+	require.EqualValues(t, 1, blockStore.Height())
+}
+
+// rewriteArchive rewrites the records of an archive with write, given the
+// height of the last block read.
+func rewriteArchive(t *testing.T, archive []byte, write func(aw *writer, kind recordKind, height int64, payload []byte) error) []byte {
+	t.Helper()
+	ar, header, err := newReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	aw, err := newWriter(&buf, header)
+	require.NoError(t, err)
+	height := int64(0)
+	for {
+		kind, payload, err := ar.next()
+		require.NoError(t, err)
+		if kind == kindTrailer {
+			break
+		}
+		if kind == kindBlock {
+			height++
+		}
+		require.NoError(t, write(aw, kind, height, payload))
+	}
+	require.NoError(t, aw.close())
+	return buf.Bytes()
+}
+
+func TestImportGenesis(t *testing.T) {
+	chain := makeChain(t, 5, 0)
+
+	// An archive starting at the initial height is verified against the
+	// genesis validators.
+	blockStore, stateStore := newStores()
+	_, err := Import(bytes.NewReader(export(t, chain, 1, 5)), blockStore, stateStore, test.DefaultTestChainID,
+		WithGenesis(chain.genDoc))
+	require.NoError(t, err)
+	require.EqualValues(t, 5, blockStore.Height())
+
+	// Other validators are rejected.
+	val, _ := types.RandValidator(false, 30)
+	otherGenDoc := *chain.genDoc
+	otherGenDoc.Validators = []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}}
+	blockStore, stateStore = newStores()
+	_, err = Import(bytes.NewReader(export(t, chain, 1, 5)), blockStore, stateStore, test.DefaultTestChainID,
+		WithGenesis(&otherGenDoc))
+	require.ErrorAs(t, err, &ErrInvalidHeight{})
+	require.True(t, blockStore.IsEmpty())
+
+	// A trusted hash is required otherwise.
+	blockStore, stateStore = newStores()
+	_, err = Import(bytes.NewReader(export(t, chain, 1, 5)), blockStore, stateStore, test.DefaultTestChainID)
+	require.ErrorIs(t, err, ErrNoTrustedHash)
+	_, err = Import(bytes.NewReader(export(t, chain, 2, 5)), blockStore, stateStore, test.DefaultTestChainID,
+		WithGenesis(chain.genDoc))
+	require.ErrorIs(t, err, ErrNoTrustedHash)
+	require.True(t, blockStore.IsEmpty())
+}
+
+func TestImportMissingFinalizeBlockResponse(t *testing.T) {
+	chain := makeChain(t, 3, 0)
+	archive := rewriteArchive(t, export(t, chain, 1, 3), func(aw *writer, kind recordKind, height int64, payload []byte) error {
+		if kind == kindFinalizeBlockResponse && height == 2 {
+			return nil
+		}
+		return aw.write(kind, payload)
+	})
+
+	blockStore, stateStore := newStores()
+	_, err := Import(bytes.NewReader(archive), blockStore, stateStore, test.DefaultTestChainID, WithGenesis(chain.genDoc))
+	var invalidErr ErrInvalidHeight
+	require.ErrorAs(t, err, &invalidErr)
+	require.EqualValues(t, 2, invalidErr.Height)
+}
+
+func TestImportUnsupportedStore(t *testing.T) {
+	chain := makeChain(t, 3, 0)
+	blockStore, _ := newStores()
+	_, err := Import(bytes.NewReader(export(t, chain, 1, 3)), blockStore, &smmocks.Store{}, test.DefaultTestChainID,
+		WithGenesis(chain.genDoc))
+	require.ErrorIs(t, err, ErrUnsupportedStore)
+}
@@ -0,0 +1,449 @@
+package history
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtstate "github.com/cometbft/cometbft/api/cometbft/state/v2"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+var (
+	// ErrNonEmptyStore is returned when importing an archive into a store
+	// which isn't empty.
+	ErrNonEmptyStore = errors.New("the block store and state store must be empty")
+	// ErrUnsupportedStore is returned when importing an archive into a state
+	// store which can't save historical heights.
+	ErrUnsupportedStore = errors.New("the state store doesn't support importing history")
+	// ErrNoTrustedHash is returned when importing an archive which can't be
+	// verified against the genesis validators without a trusted hash.
+	ErrNoTrustedHash = errors.New("a trusted hash is required unless the archive starts at the initial height " +
+		"of a genesis with validators")
+)
+
+// ErrChainIDMismatch is returned when importing an archive of another chain.
+type ErrChainIDMismatch struct {
+	Expected, Got string
+}
+
+func (e ErrChainIDMismatch) Error() string {
+	return fmt.Sprintf("archive of chain %q, expected %q", e.Got, e.Expected)
+}
+
+// ErrInvalidHeight is returned when the data of a height of an archive
+// doesn't verify.
+type ErrInvalidHeight struct {
+	Height int64
+	Err    error
+}
+
+func (e ErrInvalidHeight) Error() string {
+	return fmt.Sprintf("invalid height %d: %v", e.Height, e.Err)
+}
+
+func (e ErrInvalidHeight) Unwrap() error {
+	return e.Err
+}
+
+// ImportOption sets an optional parameter of Import.
+type ImportOption func(*importer)
+
+// WithTrustedHash sets the hash of the first block of the archive, which is
+// trusted by the operator. It is required unless the archive starts at the
+// initial height of the genesis set with WithGenesis.
+func WithTrustedHash(hash []byte) ImportOption {
+	return func(im *importer) { im.trustedHash = hash }
+}
+
+// WithGenesis sets the genesis of the chain. Without a trusted hash, an
+// archive starting at the initial height is verified against the genesis
+// validators.
+func WithGenesis(genDoc *types.GenesisDoc) ImportOption {
+	return func(im *importer) { im.genDoc = genDoc }
+}
+
+// WithLogger sets the logger of the import.
+func WithLogger(logger log.Logger) ImportOption {
+	return func(im *importer) { im.logger = logger }
+}
+
+// heightData is the data of a height read from an archive.
+type heightData struct {
+	block      *types.Block
+	parts      *types.PartSet
+	blockID    types.BlockID
+	seenCommit *types.Commit
+	extCommit  *types.ExtendedCommit
+	vals       *types.ValidatorSet
+	params     *types.ConsensusParams
+	resp       *abci.FinalizeBlockResponse
+}
+
+type importer struct {
+	blockStore  *store.BlockStore
+	stateStore  sm.Store
+	historical  sm.HistoricalStore
+	chainID     string
+	trustedHash []byte
+	genDoc      *types.GenesisDoc
+	logger      log.Logger
+
+	// genesisVals is the validator set the first block is verified against,
+	// if no trusted hash is given.
+	genesisVals *types.ValidatorSet
+
+	header Header
+	// prev is the last verified height, saved once the next one is read, as
+	// some of its data is verified against the next block.
+	prev  *heightData
+	state *sm.State
+}
+
+// Import imports the history of the chain with the given ID from an archive
+// into empty stores. Each height is verified before being saved: the commit
+// of each block must be signed by its validator set, which must match the
+// validators hash of the block and the next validators hash of the previous
+// block. The consensus params, FinalizeBlock responses and the state are
+// verified against the hashes of the blocks. The FinalizeBlock response of the
+// last height is only verified against the state, so it isn't saved if the
+// archive has no state.
+//
+// The first block must match the trusted hash set with WithTrustedHash or, if
+// the archive starts at the initial height of the chain, its validator set
+// must match the genesis validators set with WithGenesis.
+//
+// Heights are saved as soon as they are verified, so if Import fails, the
+// stores may hold part of the history and must be discarded.
+func Import(r io.Reader, blockStore *store.BlockStore, stateStore sm.Store, chainID string, options ...ImportOption) (Header, error) {
+	im := &importer{
+		blockStore: blockStore,
+		stateStore: stateStore,
+		chainID:    chainID,
+		logger:     log.NewNopLogger(),
+	}
+	for _, option := range options {
+		option(im)
+	}
+
+	historical, ok := stateStore.(sm.HistoricalStore)
+	if !ok {
+		return Header{}, ErrUnsupportedStore
+	}
+	im.historical = historical
+
+	if !blockStore.IsEmpty() {
+		return Header{}, ErrNonEmptyStore
+	}
+	if state, err := stateStore.Load(); err != nil {
+		return Header{}, err
+	} else if !state.IsEmpty() {
+		return Header{}, ErrNonEmptyStore
+	}
+
+	ar, header, err := newReader(r)
+	if err != nil {
+		return Header{}, err
+	}
+	if header.ChainID != chainID {
+		return Header{}, ErrChainIDMismatch{Expected: chainID, Got: header.ChainID}
+	}
+	if header.FromHeight <= 0 || header.FromHeight > header.ToHeight {
+		return Header{}, ErrCorruptedArchive{Err: fmt.Errorf("invalid heights %d to %d", header.FromHeight, header.ToHeight)}
+	}
+	im.header = header
+	if len(im.trustedHash) == 0 {
+		if im.genesisVals, err = im.genesisValidators(); err != nil {
+			return Header{}, err
+		}
+	}
+
+	if err := im.run(ar); err != nil {
+		return Header{}, err
+	}
+	return header, nil
+}
+
+// genesisValidators returns the genesis validators, against which the
+// archive is verified if it starts at the initial height.
+func (im *importer) genesisValidators() (*types.ValidatorSet, error) {
+	if im.genDoc == nil {
+		return nil, ErrNoTrustedHash
+	}
+	genState, err := sm.MakeGenesisState(im.genDoc)
+	if err != nil {
+		return nil, err
+	}
+	// The validators of a genesis without any are set by the app.
+	if im.header.FromHeight != genState.InitialHeight || genState.Validators.IsNilOrEmpty() {
+		return nil, ErrNoTrustedHash
+	}
+	return genState.Validators, nil
+}
+
+func (im *importer) run(ar *reader) error {
+	var cur *heightData
+	for {
+		kind, payload, err := ar.next()
+		if err != nil {
+			return err
+		}
+		if kind == kindTrailer {
+			break
+		}
+		if im.state != nil {
+			return ErrCorruptedArchive{Err: fmt.Errorf("unexpected %s record after the state", kind)}
+		}
+
+		switch kind {
+		case kindBlock:
+			next, err := im.decodeBlock(payload)
+			if err != nil {
+				return err
+			}
+			if cur != nil {
+				if err := im.complete(cur, next.block); err != nil {
+					return err
+				}
+			}
+			cur = next
+		case kindState:
+			state, err := decodeState(payload)
+			if err != nil {
+				return err
+			}
+			im.state = state
+		default:
+			if cur == nil {
+				return ErrCorruptedArchive{Err: fmt.Errorf("unexpected %s record before the first block", kind)}
+			}
+			if err := decodeRecord(cur, kind, payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cur == nil || cur.block.Height != im.header.ToHeight {
+		return ErrCorruptedArchive{Err: fmt.Errorf("archive doesn't end at height %d", im.header.ToHeight)}
+	}
+	if err := im.complete(cur, nil); err != nil {
+		return err
+	}
+	if im.state != nil {
+		if err := im.verifyState(cur); err != nil {
+			return err
+		}
+	} else {
+		// No next block nor state commits to the last FinalizeBlock response.
+		cur.resp = nil
+	}
+	if err := im.save(cur); err != nil {
+		return err
+	}
+	if im.state != nil {
+		if err := im.stateStore.Bootstrap(*im.state); err != nil {
+			return err
+		}
+		im.logger.Info("Imported state", "height", im.state.LastBlockHeight)
+	}
+	return nil
+}
+
+func (im *importer) decodeBlock(payload []byte) (*heightData, error) {
+	pbb := new(cmtproto.Block)
+	if err := proto.Unmarshal(payload, pbb); err != nil {
+		return nil, ErrCorruptedArchive{Err: fmt.Errorf("decoding block: %w", err)}
+	}
+	block, err := types.BlockFromProto(pbb)
+	if err != nil {
+		return nil, ErrCorruptedArchive{Err: fmt.Errorf("decoding block: %w", err)}
+	}
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &heightData{
+		block:   block,
+		parts:   parts,
+		blockID: types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()},
+	}, nil
+}
+
+func decodeRecord(d *heightData, kind recordKind, payload []byte) error {
+	var err error
+	switch kind {
+	case kindSeenCommit:
+		pbc := new(cmtproto.Commit)
+		if err = proto.Unmarshal(payload, pbc); err == nil {
+			d.seenCommit, err = types.CommitFromProto(pbc)
+		}
+	case kindExtendedCommit:
+		pbec := new(cmtproto.ExtendedCommit)
+		if err = proto.Unmarshal(payload, pbec); err == nil {
+			d.extCommit, err = types.ExtendedCommitFromProto(pbec)
+		}
+	case kindValidators:
+		pbv := new(cmtproto.ValidatorSet)
+		if err = proto.Unmarshal(payload, pbv); err == nil {
+			d.vals, err = types.ValidatorSetFromProto(pbv)
+		}
+	case kindConsensusParams:
+		pbp := new(cmtproto.ConsensusParams)
+		if err = proto.Unmarshal(payload, pbp); err == nil {
+			params := types.ConsensusParamsFromProto(*pbp)
+			d.params = &params
+		}
+	case kindFinalizeBlockResponse:
+		d.resp = new(abci.FinalizeBlockResponse)
+		err = proto.Unmarshal(payload, d.resp)
+	default:
+		err = fmt.Errorf("unexpected %s record", kind)
+	}
+	if err != nil {
+		return ErrCorruptedArchive{Err: fmt.Errorf("decoding %s of height %d: %w", kind, d.block.Height, err)}
+	}
+	return nil
+}
+
+func decodeState(payload []byte) (*sm.State, error) {
+	pbs := new(cmtstate.State)
+	if err := proto.Unmarshal(payload, pbs); err != nil {
+		return nil, ErrCorruptedArchive{Err: fmt.Errorf("decoding state: %w", err)}
+	}
+	state, err := sm.FromProto(pbs)
+	if err != nil {
+		return nil, ErrCorruptedArchive{Err: fmt.Errorf("decoding state: %w", err)}
+	}
+	return state, nil
+}
+
+// complete verifies the data of a height, given the next block if any, and
+// saves the previous height.
+func (im *importer) complete(d *heightData, next *types.Block) error {
+	if err := im.verify(d, next); err != nil {
+		return ErrInvalidHeight{Height: d.block.Height, Err: err}
+	}
+	if im.prev != nil {
+		if err := im.save(im.prev); err != nil {
+			return err
+		}
+	}
+	im.prev = d
+	return nil
+}
+
+func (im *importer) verify(d *heightData, next *types.Block) error {
+	block := d.block
+	expected := im.header.FromHeight
+	if im.prev != nil {
+		expected = im.prev.block.Height + 1
+	}
+	if block.Height != expected {
+		return fmt.Errorf("expected block at height %d, got %d", expected, block.Height)
+	}
+	if block.ChainID != im.chainID {
+		return ErrChainIDMismatch{Expected: im.chainID, Got: block.ChainID}
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid block: %w", err)
+	}
+	// Without the FinalizeBlock response, the app hash of the next block
+	// couldn't be verified.
+	if d.seenCommit == nil || d.vals == nil || d.params == nil || d.resp == nil {
+		return errors.New("missing commit, validators, consensus params or FinalizeBlock response")
+	}
+
+	switch {
+	case im.prev == nil && len(im.trustedHash) > 0:
+		if !bytes.Equal(block.Hash(), im.trustedHash) {
+			return fmt.Errorf("block hash %X doesn't match the trusted hash %X", block.Hash(), im.trustedHash)
+		}
+	case im.prev == nil:
+		if !bytes.Equal(d.vals.Hash(), im.genesisVals.Hash()) {
+			return errors.New("validators don't match the genesis validators")
+		}
+	default:
+		if !block.LastBlockID.Equals(im.prev.blockID) {
+			return fmt.Errorf("last block ID %v doesn't match the previous block %v", block.LastBlockID, im.prev.blockID)
+		}
+		if !bytes.Equal(im.prev.block.NextValidatorsHash, d.vals.Hash()) {
+			return errors.New("validators don't match the next validators hash of the previous block")
+		}
+	}
+
+	if !bytes.Equal(block.ValidatorsHash, d.vals.Hash()) {
+		return errors.New("validators don't match the validators hash of the block")
+	}
+	if !bytes.Equal(block.ConsensusHash, d.params.Hash()) {
+		return errors.New("consensus params don't match the consensus hash of the block")
+	}
+	if err := d.vals.VerifyCommit(im.chainID, d.blockID, block.Height, d.seenCommit); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+	if d.extCommit != nil {
+		if err := d.extCommit.EnsureExtensions(true); err != nil {
+			return fmt.Errorf("invalid extended commit: %w", err)
+		}
+		if err := d.vals.VerifyCommit(im.chainID, d.blockID, block.Height, d.extCommit.ToCommit()); err != nil {
+			return fmt.Errorf("invalid extended commit: %w", err)
+		}
+	}
+
+	if next != nil {
+		if !bytes.Equal(next.AppHash, d.resp.AppHash) {
+			return errors.New("FinalizeBlock response doesn't match the app hash of the next block")
+		}
+		if !bytes.Equal(next.LastResultsHash, sm.TxResultsHash(d.resp.TxResults)) {
+			return errors.New("FinalizeBlock response doesn't match the last results hash of the next block")
+		}
+	}
+	return nil
+}
+
+// verifyState verifies the state against the last height.
+func (im *importer) verifyState(last *heightData) error {
+	state := im.state
+	switch {
+	case state.ChainID != im.chainID:
+		return ErrChainIDMismatch{Expected: im.chainID, Got: state.ChainID}
+	case state.LastBlockHeight != last.block.Height || !state.LastBlockID.Equals(last.blockID):
+		return errors.New("state doesn't match the last block")
+	case !bytes.Equal(state.Validators.Hash(), last.block.NextValidatorsHash):
+		return errors.New("state validators don't match the next validators hash of the last block")
+	case state.LastValidators != nil && !bytes.Equal(state.LastValidators.Hash(), last.vals.Hash()):
+		return errors.New("state last validators don't match the validators of the last block")
+	}
+	if !bytes.Equal(state.AppHash, last.resp.AppHash) {
+		return errors.New("state app hash doesn't match the last FinalizeBlock response")
+	}
+	if !bytes.Equal(state.LastResultsHash, sm.TxResultsHash(last.resp.TxResults)) {
+		return errors.New("state last results hash doesn't match the last FinalizeBlock response")
+	}
+	return nil
+}
+
+func (im *importer) save(d *heightData) error {
+	if d.extCommit != nil {
+		im.blockStore.SaveBlockWithExtendedCommit(d.block, d.parts, d.extCommit)
+	} else {
+		im.blockStore.SaveBlock(d.block, d.parts, d.seenCommit)
+	}
+	if err := im.historical.SaveHistoricalHeight(d.block.Height, d.vals, *d.params); err != nil {
+		return err
+	}
+	if d.resp != nil {
+		if err := im.stateStore.SaveFinalizeBlockResponse(d.block.Height, d.resp); err != nil {
+			return err
+		}
+	}
+	im.logger.Debug("Imported height", "height", d.block.Height)
+	return nil
+}
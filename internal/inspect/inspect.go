@@ -77,7 +77,11 @@ func NewFromConfig(cfg *config.Config) (*Inspector, error) {
 	if err != nil {
 		return nil, err
 	}
-	bs := store.NewBlockStore(bsDB, store.WithDBKeyLayout(cfg.Storage.ExperimentalKeyLayout))
+	coldStorageOpts, err := store.ColdStorageFromConfig(cfg.Storage.ColdStorage)
+	if err != nil {
+		return nil, err
+	}
+	bs := store.NewBlockStore(bsDB, append([]store.BlockStoreOption{store.WithDBKeyLayout(cfg.Storage.ExperimentalKeyLayout)}, coldStorageOpts...)...)
 	sDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
 	if err != nil {
 		return nil, err
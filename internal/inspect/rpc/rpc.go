@@ -40,7 +40,7 @@ func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, txidx txin
 		"consensus_params": server.NewRPCFunc(env.ConsensusParams, "height"),
 		"block":            server.NewRPCFunc(env.Block, "height"),
 		"block_by_hash":    server.NewRPCFunc(env.BlockByHash, "hash"),
-		"block_results":    server.NewRPCFunc(env.BlockResults, "height"),
+		"block_results":    server.NewRPCFunc(env.BlockResults, "height,prove", server.OptionalArgs("prove")),
 		"commit":           server.NewRPCFunc(env.Commit, "height"),
 		"header":           server.NewRPCFunc(env.Header, "height"),
 		"header_by_hash":   server.NewRPCFunc(env.HeaderByHash, "hash"),
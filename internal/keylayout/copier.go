@@ -0,0 +1,77 @@
+package keylayout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/libs/service"
+)
+
+// BackgroundCopier copies the records of databases in use to their new
+// layout, in the background, with a copy pass at every interval. Completing
+// the migrations with Migrator.Run then only needs to copy the records
+// written since the last pass.
+type BackgroundCopier struct {
+	service.BaseService
+
+	migrators []*Migrator
+	interval  time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBackgroundCopier returns a copier running the copy passes of the given
+// migrators.
+func NewBackgroundCopier(migrators []*Migrator, interval time.Duration, logger log.Logger) *BackgroundCopier {
+	c := &BackgroundCopier{
+		migrators: migrators,
+		interval:  interval,
+		done:      make(chan struct{}),
+	}
+	c.BaseService = *service.NewBaseService(logger, "KeyLayoutCopier", c)
+	return c
+}
+
+// OnStart implements service.Service.
+func (c *BackgroundCopier) OnStart() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	go c.routine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service. It interrupts the ongoing pass, whose
+// progress is saved, and waits for it to return.
+func (c *BackgroundCopier) OnStop() {
+	c.cancel()
+	<-c.done
+}
+
+func (c *BackgroundCopier) routine(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		for _, m := range c.migrators {
+			start := time.Now()
+			if err := m.Copy(ctx); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				c.Logger.Error("Failed to copy records to the new key layout", "version", m.Version(), "err", err)
+				continue
+			}
+			c.Logger.Info("Copied records to the new key layout", "version", m.Version(), "duration", time.Since(start))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,490 @@
+// Package keylayout migrates the records of a database from one key layout to
+// another, e.g. the block store and state store records from the v1 to the v2
+// layout.
+package keylayout
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/libs/log"
+)
+
+const (
+	// batchSize is the number of records copied or deleted in a batch.
+	batchSize = 1000
+
+	phaseCopy    = "copy"
+	phaseCleanup = "cleanup"
+)
+
+var (
+	// versionKey is the key of the layout version of the database.
+	versionKey = []byte("version")
+	// progressKey is the key of the progress of an ongoing migration.
+	progressKey = []byte("keyLayoutMigration")
+)
+
+// Record is a kind of records whose keys depend on the key layout, e.g. the
+// block metas.
+type Record struct {
+	Name string
+	// Prefixes of the keys of the records in the old and new layouts.
+	OldPrefix, NewPrefix []byte
+	// NewKey converts a key from the old layout to the new layout, and
+	// OldKey does the reverse.
+	NewKey func(oldKey []byte) ([]byte, error)
+	OldKey func(newKey []byte) ([]byte, error)
+}
+
+// Stats are the number of records of a kind, and a digest of their keys (in
+// the new layout) and values, which doesn't depend on their order.
+type Stats struct {
+	Count  int64
+	Digest [sha256.Size]byte
+}
+
+func (s *Stats) add(key, value []byte) {
+	h := sha256.New()
+	h.Write(key)
+	h.Write(value)
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	for i := range s.Digest {
+		s.Digest[i] ^= sum[i]
+	}
+	s.Count++
+}
+
+// ErrVerification is returned when the records in the new layout don't match
+// the records in the old layout after they were copied.
+type ErrVerification struct {
+	Record   string
+	Old, New Stats
+}
+
+func (e ErrVerification) Error() string {
+	return fmt.Sprintf("%s records don't match after the migration: %d records (digest %X) in the old layout, %d (digest %X) in the new one",
+		e.Record, e.Old.Count, e.Old.Digest, e.New.Count, e.New.Digest)
+}
+
+// ErrSameLayout is returned when migrating a database to the layout it
+// already uses.
+type ErrSameLayout struct {
+	Version string
+}
+
+func (e ErrSameLayout) Error() string {
+	return fmt.Sprintf("the database already uses the %s key layout", e.Version)
+}
+
+// Version returns the version of the layout of the records of db, which is
+// "v1" if it's not set. If a migration is being completed, it's the version
+// it's migrating from, as some records are still in that layout.
+func Version(db dbm.DB) (string, error) {
+	bz, err := db.Get(progressKey)
+	if err != nil {
+		return "", err
+	}
+	if len(bz) > 0 {
+		p := &progress{}
+		if err := json.Unmarshal(bz, p); err != nil {
+			return "", fmt.Errorf("failed to decode key layout migration progress: %w", err)
+		}
+		if p.Phase == phaseCleanup {
+			return p.From, nil
+		}
+	}
+	version, err := db.Get(versionKey)
+	if err != nil {
+		return "", err
+	}
+	if len(version) == 0 {
+		return "v1", nil
+	}
+	return string(version), nil
+}
+
+// progress is the persisted progress of a migration, so that it can be
+// resumed after being interrupted.
+type progress struct {
+	From    string `json:"from"`
+	Version string `json:"version"`
+	Phase   string `json:"phase"`
+	// The index of the record being copied, and the last copied key of this
+	// record. Record is len(records) when a copy pass is complete.
+	Record  int    `json:"record"`
+	LastKey []byte `json:"last_key,omitempty"`
+}
+
+// Migrator migrates the records of a database to a new key layout. The
+// records are first copied to the new layout, leaving the old records in
+// place, so copying can happen while the database is in use (see Copy). Then,
+// while the database is not in use, Run completes the copy, verifies it,
+// switches the layout version of the database and deletes the old records.
+//
+// The progress is persisted in the database, so an interrupted migration
+// resumes where it left off.
+type Migrator struct {
+	db      dbm.DB
+	from    string
+	version string
+	records []Record
+	logger  log.Logger
+}
+
+// NewMigrator returns a migrator of the records of db from the layout with
+// the from version to the one with the given version.
+func NewMigrator(db dbm.DB, from, version string, records []Record, logger log.Logger) *Migrator {
+	return &Migrator{
+		db:      db,
+		from:    from,
+		version: version,
+		records: records,
+		logger:  logger,
+	}
+}
+
+// Version returns the version of the layout the records are migrated to.
+func (m *Migrator) Version() string {
+	return m.version
+}
+
+func (m *Migrator) loadProgress() (*progress, error) {
+	bz, err := m.db.Get(progressKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return &progress{From: m.from, Version: m.version, Phase: phaseCopy}, nil
+	}
+	p := &progress{}
+	if err := json.Unmarshal(bz, p); err != nil {
+		return nil, fmt.Errorf("failed to decode key layout migration progress: %w", err)
+	}
+	if p.Version != m.version {
+		if p.Phase == phaseCleanup {
+			return nil, fmt.Errorf("a migration to the %s key layout is being completed", p.Version)
+		}
+		// A migration to another layout was started: start over.
+		return &progress{From: m.from, Version: m.version, Phase: phaseCopy}, nil
+	}
+	return p, nil
+}
+
+func (*Migrator) setProgress(batch dbm.Batch, p *progress) error {
+	bz, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return batch.Set(progressKey, bz)
+}
+
+// Copy copies the records that are missing or different in the new layout,
+// and deletes the records of the new layout that are no longer in the old
+// one, e.g. because they were pruned. It resumes an interrupted copy pass, or
+// starts a new one if the last one completed.
+//
+// Copy can be called while the database is in use, in which case the records
+// written during the pass may not be copied, until the next one.
+func (m *Migrator) Copy(ctx context.Context) error {
+	p, err := m.loadProgress()
+	if err != nil {
+		return err
+	}
+	if p.Phase == phaseCleanup {
+		return nil
+	}
+	if p.Record >= len(m.records) {
+		p.Record, p.LastKey = 0, nil
+	}
+	return m.copy(ctx, p)
+}
+
+// copy copies the records from the given progress on.
+func (m *Migrator) copy(ctx context.Context, p *progress) error {
+	for ; p.Record < len(m.records); p.Record, p.LastKey = p.Record+1, nil {
+		record := m.records[p.Record]
+		if err := m.copyRecords(ctx, record, p); err != nil {
+			return fmt.Errorf("failed to copy %s records: %w", record.Name, err)
+		}
+		if err := m.deleteOrphans(ctx, record); err != nil {
+			return fmt.Errorf("failed to delete orphan %s records: %w", record.Name, err)
+		}
+	}
+	return m.saveProgress(p)
+}
+
+// copyRecords copies the records after p.LastKey, saving the progress after
+// each batch.
+func (m *Migrator) copyRecords(ctx context.Context, record Record, p *progress) error {
+	start := record.OldPrefix
+	if p.LastKey != nil {
+		start = append(bytes.Clone(p.LastKey), 0)
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		lastKey, done, err := m.copyBatch(record, start, p)
+		if err != nil || done {
+			return err
+		}
+		start = append(lastKey, 0)
+	}
+}
+
+// copyBatch copies up to batchSize records from start, and returns the last
+// key it read, and whether there are no records left.
+func (m *Migrator) copyBatch(record Record, start []byte, p *progress) ([]byte, bool, error) {
+	type kv struct{ key, value []byte }
+	var (
+		kvs  = make([]kv, 0, batchSize)
+		done = true
+	)
+	iter, err := m.db.Iterator(start, prefixEnd(record.OldPrefix))
+	if err != nil {
+		return nil, false, err
+	}
+	for ; iter.Valid(); iter.Next() {
+		if len(kvs) == batchSize {
+			done = false
+			break
+		}
+		kvs = append(kvs, kv{bytes.Clone(iter.Key()), bytes.Clone(iter.Value())})
+	}
+	if err := iter.Error(); err != nil {
+		iter.Close()
+		return nil, false, err
+	}
+	iter.Close()
+
+	batch := m.db.NewBatch()
+	defer batch.Close()
+	for _, kv := range kvs {
+		newKey, err := record.NewKey(kv.key)
+		if err != nil {
+			return nil, false, fmt.Errorf("key %X: %w", kv.key, err)
+		}
+		existing, err := m.db.Get(newKey)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil && bytes.Equal(existing, kv.value) {
+			continue
+		}
+		if err := batch.Set(newKey, kv.value); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(kvs) > 0 {
+		p.LastKey = kvs[len(kvs)-1].key
+	}
+	if err := m.setProgress(batch, p); err != nil {
+		return nil, false, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return nil, false, err
+	}
+	return p.LastKey, done, nil
+}
+
+// deleteOrphans deletes the records of the new layout which are not in the
+// old one.
+func (m *Migrator) deleteOrphans(ctx context.Context, record Record) error {
+	var orphans [][]byte
+	err := m.iterate(ctx, record.NewPrefix, func(key, _ []byte) error {
+		oldKey, err := record.OldKey(key)
+		if err != nil {
+			return fmt.Errorf("key %X: %w", key, err)
+		}
+		ok, err := m.db.Has(oldKey)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			orphans = append(orphans, bytes.Clone(key))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return m.deleteKeys(orphans)
+}
+
+// Verify checks that the records in the new layout match the records in the
+// old layout, and returns their stats. It must not be called while the
+// database is in use.
+func (m *Migrator) Verify(ctx context.Context) (map[string]Stats, error) {
+	stats := make(map[string]Stats, len(m.records))
+	for _, record := range m.records {
+		var oldStats, newStats Stats
+		err := m.iterate(ctx, record.OldPrefix, func(key, value []byte) error {
+			newKey, err := record.NewKey(key)
+			if err != nil {
+				return fmt.Errorf("key %X: %w", key, err)
+			}
+			oldStats.add(newKey, value)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = m.iterate(ctx, record.NewPrefix, func(key, value []byte) error {
+			newStats.add(key, value)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if oldStats != newStats {
+			return nil, ErrVerification{Record: record.Name, Old: oldStats, New: newStats}
+		}
+		stats[record.Name] = newStats
+	}
+	return stats, nil
+}
+
+// Run completes the migration: it copies the remaining records, verifies
+// them, switches the layout version of the database and deletes the records
+// of the old layout. It must not be called while the database is in use.
+//
+// If Run is interrupted, calling it again resumes the migration.
+func (m *Migrator) Run(ctx context.Context) (map[string]Stats, error) {
+	p, err := m.loadProgress()
+	if err != nil {
+		return nil, err
+	}
+	var stats map[string]Stats
+	if p.Phase == phaseCopy {
+		// A full copy pass is always made, as the database may have been in
+		// use during the previous ones. The records that were already copied
+		// are not written again.
+		p.Record, p.LastKey = 0, nil
+		m.logger.Info("Copying records to the new key layout", "version", m.version)
+		if err := m.copy(ctx, p); err != nil {
+			return nil, err
+		}
+		m.logger.Info("Verifying the records in the new key layout", "version", m.version)
+		if stats, err = m.Verify(ctx); err != nil {
+			return nil, err
+		}
+
+		// Switch the version along with the phase, so the old records are
+		// deleted even if the cleanup is interrupted.
+		batch := m.db.NewBatch()
+		defer batch.Close()
+		if err := batch.Set(versionKey, []byte(m.version)); err != nil {
+			return nil, err
+		}
+		p = &progress{From: m.from, Version: m.version, Phase: phaseCleanup}
+		if err := m.setProgress(batch, p); err != nil {
+			return nil, err
+		}
+		if err := batch.WriteSync(); err != nil {
+			return nil, err
+		}
+	}
+
+	m.logger.Info("Deleting records in the old key layout", "version", m.version)
+	for _, record := range m.records {
+		if err := m.deleteAll(ctx, record.OldPrefix); err != nil {
+			return nil, fmt.Errorf("failed to delete old %s records: %w", record.Name, err)
+		}
+	}
+	if err := m.db.DeleteSync(progressKey); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (m *Migrator) saveProgress(p *progress) error {
+	batch := m.db.NewBatch()
+	defer batch.Close()
+	if err := m.setProgress(batch, p); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+// iterate calls fn for each record with the given key prefix.
+func (m *Migrator) iterate(ctx context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	iter, err := m.db.Iterator(prefix, prefixEnd(prefix))
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// deleteAll deletes all the records with the given key prefix.
+func (m *Migrator) deleteAll(ctx context.Context, prefix []byte) error {
+	for {
+		keys := make([][]byte, 0, batchSize)
+		err := m.iterate(ctx, prefix, func(key, _ []byte) error {
+			if len(keys) == batchSize {
+				return errBatchFull
+			}
+			keys = append(keys, bytes.Clone(key))
+			return nil
+		})
+		if err != nil && !errors.Is(err, errBatchFull) {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := m.deleteKeys(keys); err != nil {
+			return err
+		}
+	}
+}
+
+var errBatchFull = errors.New("batch full")
+
+func (m *Migrator) deleteKeys(keys [][]byte) error {
+	for len(keys) > 0 {
+		n := min(len(keys), batchSize)
+		batch := m.db.NewBatch()
+		for _, key := range keys[:n] {
+			if err := batch.Delete(key); err != nil {
+				batch.Close()
+				return err
+			}
+		}
+		err := batch.WriteSync()
+		batch.Close()
+		if err != nil {
+			return err
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+// prefixEnd returns the end of the range of the keys with the given prefix,
+// or nil if there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
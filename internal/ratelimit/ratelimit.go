@@ -0,0 +1,131 @@
+// Package ratelimit limits the rate of events with token buckets.
+package ratelimit
+
+import (
+	"math"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+)
+
+// Bucket is a token bucket, filled at rate tokens per second up to burst
+// tokens. It is not safe for concurrent use.
+type Bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket returns a full bucket. A burst of less than one is raised to one,
+// since it would never allow any event.
+func NewBucket(rate float64, burst int) *Bucket {
+	b := math.Max(float64(burst), 1)
+	return &Bucket{rate: rate, burst: b, tokens: b}
+}
+
+func (b *Bucket) fill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	if now.After(b.last) {
+		b.last = now
+	}
+}
+
+// Take takes n tokens from the bucket at time now. An event costing more than
+// the burst takes a full bucket. If the bucket holds too few tokens, none is
+// taken, and Take returns false with the time until it will hold enough.
+func (b *Bucket) Take(now time.Time, n float64) (bool, time.Duration) {
+	b.fill(now)
+
+	n = math.Min(n, b.burst)
+	if b.tokens < n {
+		if b.rate == 0 {
+			return false, time.Duration(math.MaxInt64)
+		}
+		wait := (n - b.tokens) / b.rate
+		return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+	}
+	b.tokens -= n
+	return true, 0
+}
+
+// Refund gives back n tokens taken from the bucket, for an event that did not
+// happen after all.
+func (b *Bucket) Refund(now time.Time, n float64) {
+	b.fill(now)
+	b.tokens = math.Min(b.burst, b.tokens+math.Min(n, b.burst))
+}
+
+// isFull returns true if the bucket is full at time now, so that it can be
+// dropped and recreated later without loss.
+func (b *Bucket) isFull(now time.Time) bool {
+	b.fill(now)
+	return b.tokens >= b.burst
+}
+
+// pruneInterval is the interval at which a Limiter drops its full buckets.
+const pruneInterval = time.Minute
+
+// Limiter limits the rate of events per key, with a bucket for each key. It is
+// safe for concurrent use.
+type Limiter struct {
+	rate  float64
+	burst int
+
+	mtx        cmtsync.Mutex
+	buckets    map[string]*Bucket
+	lastPruned time.Time
+}
+
+// NewLimiter returns a Limiter whose buckets are filled at rate tokens per
+// second up to burst tokens.
+func NewLimiter(rate float64, burst int) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*Bucket),
+	}
+}
+
+// Take takes n tokens from the bucket of key at time now, as Bucket.Take does.
+func (l *Limiter) Take(key string, now time.Time, n float64) (bool, time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	// Dropping the full buckets keeps the ones of the keys seen recently only.
+	if now.Sub(l.lastPruned) >= pruneInterval {
+		for k, b := range l.buckets {
+			if b.isFull(now) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastPruned = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = NewBucket(l.rate, l.burst)
+		l.buckets[key] = b
+	}
+	return b.Take(now, n)
+}
+
+// Refund gives back n tokens taken from the bucket of key, as Bucket.Refund
+// does.
+func (l *Limiter) Refund(key string, now time.Time, n float64) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	// A dropped bucket was full.
+	if b, ok := l.buckets[key]; ok {
+		b.Refund(now, n)
+	}
+}
+
+// Len returns the number of buckets held by the Limiter.
+func (l *Limiter) Len() int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return len(l.buckets)
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket(t *testing.T) {
+	b := NewBucket(2, 2)
+	now := time.Now()
+	ok, _ := b.Take(now, 1)
+	assert.True(t, ok)
+	ok, _ = b.Take(now, 1)
+	assert.True(t, ok)
+	ok, retryAfter := b.Take(now, 1)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// Half a second later, one token was added.
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = b.Take(now, 1)
+	assert.True(t, ok)
+
+	// The bucket holds at most burst tokens, and an event costing more takes
+	// all of them.
+	now = now.Add(time.Minute)
+	ok, _ = b.Take(now, 5)
+	assert.True(t, ok)
+	ok, retryAfter = b.Take(now, 2)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Refunded tokens can be taken again, up to burst.
+	b.Refund(now, 5)
+	ok, _ = b.Take(now, 2)
+	assert.True(t, ok)
+	ok, _ = b.Take(now, 1)
+	assert.False(t, ok)
+}
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(1, 1)
+	now := time.Now()
+
+	ok, _ := l.Take("a", now, 1)
+	assert.True(t, ok)
+	ok, _ = l.Take("a", now, 1)
+	assert.False(t, ok)
+	// Keys have their own bucket.
+	ok, _ = l.Take("b", now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 2, l.Len())
+
+	// The full buckets are dropped.
+	now = now.Add(2 * pruneInterval)
+	ok, _ = l.Take("a", now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 1, l.Len())
+}
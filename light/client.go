@@ -125,6 +125,17 @@ func MaxBlockLag(d time.Duration) Option {
 	}
 }
 
+// AttackHandler option can be used to be notified of light client attacks
+// detected while cross-checking the primary against the witnesses, e.g. to
+// persist the evidence. The handler is called with the evidence and the
+// provider it was formed against, in addition to the evidence being reported
+// to the other provider.
+func AttackHandler(fn func(ev *types.LightClientAttackEvidence, faulty provider.Provider)) Option {
+	return func(c *Client) {
+		c.attackHandler = fn
+	}
+}
+
 // Client represents a light client, connected to a single chain, which gets
 // light blocks from a primary provider, verifies them either sequentially or by
 // skipping some and stores them in a trusted store (usually, a local FS).
@@ -155,6 +166,8 @@ type Client struct {
 	pruningSize uint16
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
+	// See AttackHandler option
+	attackHandler func(ev *types.LightClientAttackEvidence, faulty provider.Provider)
 
 	quit chan struct{}
 
@@ -230,6 +243,7 @@ func NewClientFromTrustedStore(
 		trustedStore:     trustedStore,
 		pruningSize:      defaultPruningSize,
 		confirmationFn:   func(_ string) bool { return true },
+		attackHandler:    func(*types.LightClientAttackEvidence, provider.Provider) {},
 		quit:             make(chan struct{}),
 		logger:           log.NewNopLogger(),
 	}
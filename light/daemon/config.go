@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
+	"github.com/cometbft/cometbft/v2/light"
+	cmterrors "github.com/cometbft/cometbft/v2/types/errors"
+)
+
+const (
+	defaultUpdateInterval = 5 * time.Second
+	defaultTrustingPeriod = 168 * time.Hour
+)
+
+// Config is the configuration of the light client daemon, usually loaded from
+// a TOML file with LoadConfig.
+type Config struct {
+	// Address to serve the verified headers API on.
+	ListenAddress string `toml:"laddr"`
+	// How often each chain is updated to the latest header of its primary.
+	UpdateInterval time.Duration `toml:"update_interval"`
+	// The tracked chains.
+	Chains []ChainConfig `toml:"chain"`
+}
+
+// ChainConfig is the configuration of a chain tracked by the daemon.
+type ChainConfig struct {
+	ChainID string `toml:"chain_id"`
+	// Addresses of the primary and witnesses. Addresses with the "grpc://"
+	// scheme are served by the gRPC services of the node, others by its RPC.
+	Primary   string   `toml:"primary"`
+	Witnesses []string `toml:"witnesses"`
+
+	// Trust options. The trusted height and hash are only required when the
+	// chain is tracked for the first time, to initialize its trusted store.
+	TrustingPeriod time.Duration `toml:"trusting_period"`
+	TrustedHeight  int64         `toml:"trusted_height"`
+	TrustedHash    string        `toml:"trusted_hash"`
+	// Trust level of skipping verification, in the "1/3" format.
+	TrustLevel string `toml:"trust_level"`
+	// Verify all headers sequentially, instead of using skipping verification.
+	Sequential bool `toml:"sequential"`
+}
+
+// LoadConfig loads the configuration from a TOML file, filling in the
+// defaults for unset fields, and validates it.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+	if _, err := toml.DecodeFile(path, config); err != nil {
+		return nil, fmt.Errorf("failed to load light client daemon config %v: %w", path, err)
+	}
+	config.setDefaults()
+	if err := config.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid light client daemon config %v: %w", path, err)
+	}
+	return config, nil
+}
+
+func (cfg *Config) setDefaults() {
+	if cfg.UpdateInterval == 0 {
+		cfg.UpdateInterval = defaultUpdateInterval
+	}
+	for i := range cfg.Chains {
+		if cfg.Chains[i].TrustingPeriod == 0 {
+			cfg.Chains[i].TrustingPeriod = defaultTrustingPeriod
+		}
+		if cfg.Chains[i].TrustLevel == "" {
+			cfg.Chains[i].TrustLevel = light.DefaultTrustLevel.String()
+		}
+	}
+}
+
+// ValidateBasic performs basic validation.
+func (cfg *Config) ValidateBasic() error {
+	if cfg.UpdateInterval <= 0 {
+		return cmterrors.ErrNegativeOrZeroField{Field: "update_interval"}
+	}
+	if len(cfg.Chains) == 0 {
+		return errors.New("no chains to track")
+	}
+	seen := make(map[string]bool, len(cfg.Chains))
+	for _, chain := range cfg.Chains {
+		if err := chain.ValidateBasic(); err != nil {
+			return fmt.Errorf("chain %q: %w", chain.ChainID, err)
+		}
+		if seen[chain.ChainID] {
+			return fmt.Errorf("chain %q is configured more than once", chain.ChainID)
+		}
+		seen[chain.ChainID] = true
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation.
+func (cfg ChainConfig) ValidateBasic() error {
+	if cfg.ChainID == "" {
+		return cmterrors.ErrRequiredField{Field: "chain_id"}
+	}
+	// The chain ID is used in the database key prefixes.
+	if strings.Contains(cfg.ChainID, "/") {
+		return cmterrors.ErrWrongField{Field: "chain_id", Err: errors.New("must not contain '/'")}
+	}
+	if cfg.Primary == "" {
+		return cmterrors.ErrRequiredField{Field: "primary"}
+	}
+	if len(cfg.Witnesses) == 0 {
+		return cmterrors.ErrRequiredField{Field: "witnesses"}
+	}
+	if cfg.TrustingPeriod <= 0 {
+		return cmterrors.ErrNegativeOrZeroField{Field: "trusting_period"}
+	}
+	if _, err := cfg.trustLevel(); err != nil {
+		return cmterrors.ErrWrongField{Field: "trust_level", Err: err}
+	}
+	if cfg.TrustedHeight < 0 {
+		return cmterrors.ErrNegativeField{Field: "trusted_height"}
+	}
+	if cfg.TrustedHeight > 0 {
+		if _, err := cfg.trustOptions(); err != nil {
+			return cmterrors.ErrWrongField{Field: "trusted_hash", Err: err}
+		}
+	}
+	return nil
+}
+
+// hasTrustOptions returns true if a trusted height and hash are configured.
+func (cfg ChainConfig) hasTrustOptions() bool {
+	return cfg.TrustedHeight > 0
+}
+
+func (cfg ChainConfig) trustOptions() (light.TrustOptions, error) {
+	hash, err := hex.DecodeString(cfg.TrustedHash)
+	if err != nil {
+		return light.TrustOptions{}, err
+	}
+	if len(hash) != tmhash.Size {
+		return light.TrustOptions{}, fmt.Errorf("expected %d bytes, got %d", tmhash.Size, len(hash))
+	}
+	return light.TrustOptions{
+		Period: cfg.TrustingPeriod,
+		Height: cfg.TrustedHeight,
+		Hash:   hash,
+	}, nil
+}
+
+func (cfg ChainConfig) trustLevel() (cmtmath.Fraction, error) {
+	trustLevel, err := cmtmath.ParseFraction(cfg.TrustLevel)
+	if err != nil {
+		return cmtmath.Fraction{}, err
+	}
+	return trustLevel, light.ValidateTrustLevel(trustLevel)
+}
@@ -0,0 +1,77 @@
+package daemon_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/light/daemon"
+)
+
+func TestLoadConfig(t *testing.T) {
+	const hash = "28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD"
+
+	testCases := []struct {
+		name    string
+		toml    string
+		wantErr bool
+	}{
+		{
+			"valid",
+			`
+laddr = "tcp://localhost:8889"
+
+[[chain]]
+chain_id = "chain-1"
+primary = "http://localhost:26657"
+witnesses = ["grpc://localhost:26670"]
+trusted_height = 10
+trusted_hash = "` + hash + `"
+
+[[chain]]
+chain_id = "chain-2"
+primary = "grpc://localhost:36670"
+witnesses = ["http://localhost:36657"]
+trusting_period = "24h"
+trust_level = "2/3"
+sequential = true
+`,
+			false,
+		},
+		{"no chains", `laddr = "tcp://localhost:8889"`, true},
+		{"no chain ID", "[[chain]]\nprimary = \"a\"\nwitnesses = [\"b\"]", true},
+		{"chain ID with slash", "[[chain]]\nchain_id = \"a/b\"\nprimary = \"a\"\nwitnesses = [\"b\"]", true},
+		{"no witnesses", "[[chain]]\nchain_id = \"a\"\nprimary = \"a\"", true},
+		{"invalid trust level", "[[chain]]\nchain_id = \"a\"\nprimary = \"a\"\nwitnesses = [\"b\"]\ntrust_level = \"1/4\"", true},
+		{"invalid trusted hash", "[[chain]]\nchain_id = \"a\"\nprimary = \"a\"\nwitnesses = [\"b\"]\ntrusted_height = 1\ntrusted_hash = \"AB\"", true},
+		{
+			"duplicate chain",
+			"[[chain]]\nchain_id = \"a\"\nprimary = \"a\"\nwitnesses = [\"b\"]\n[[chain]]\nchain_id = \"a\"\nprimary = \"a\"\nwitnesses = [\"b\"]",
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "daemon.toml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.toml), 0o600))
+			config, err := daemon.LoadConfig(path)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, config.Chains, 2)
+			// defaults
+			assert.Equal(t, 5*time.Second, config.UpdateInterval)
+			assert.Equal(t, 168*time.Hour, config.Chains[0].TrustingPeriod)
+			assert.Equal(t, "1/3", config.Chains[0].TrustLevel)
+			assert.Equal(t, 24*time.Hour, config.Chains[1].TrustingPeriod)
+			assert.True(t, config.Chains[1].Sequential)
+		})
+	}
+}
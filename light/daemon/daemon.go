@@ -0,0 +1,357 @@
+// Package daemon implements a long-running light client service, which tracks
+// several chains, each with its own trust options, primary and witnesses, and
+// serves their verified headers.
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/libs/service"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/light"
+	"github.com/cometbft/cometbft/v2/light/provider"
+	grpcprovider "github.com/cometbft/cometbft/v2/light/provider/grpc"
+	httpprovider "github.com/cometbft/cometbft/v2/light/provider/http"
+	dbs "github.com/cometbft/cometbft/v2/light/store/db"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	// grpcScheme is the scheme of the addresses of gRPC providers.
+	grpcScheme = "grpc://"
+
+	// subscriptionCapacity is the number of headers buffered for each
+	// subscriber.
+	subscriptionCapacity = 100
+)
+
+// ErrUnknownChain is returned when the chain is not tracked by the daemon.
+type ErrUnknownChain struct {
+	ChainID string
+}
+
+func (e ErrUnknownChain) Error() string {
+	return fmt.Sprintf("chain %q is not tracked", e.ChainID)
+}
+
+// chain is a chain tracked by the daemon.
+type chain struct {
+	config  ChainConfig
+	client  *light.Client
+	reports *reportStore
+	// Publishes the new verified headers.
+	pubsub *cmtpubsub.Server
+}
+
+// Daemon tracks several chains, each with its own light client. Each chain is
+// periodically updated to the latest header of its primary, and the new
+// verified headers are published to the subscribers of the chain. The
+// trusted light blocks and the attack reports of all the chains are persisted
+// in a single database, under a prefix per chain.
+type Daemon struct {
+	service.BaseService
+
+	config *Config
+	db     dbm.DB
+
+	mtx    cmtsync.RWMutex
+	chains map[string]*chain
+
+	quit chan struct{}
+}
+
+// New returns a new daemon tracking the chains of the config. The light
+// clients are created when it's started.
+func New(config *Config, db dbm.DB, logger log.Logger) *Daemon {
+	d := &Daemon{
+		config: config,
+		db:     db,
+		chains: make(map[string]*chain, len(config.Chains)),
+		quit:   make(chan struct{}),
+	}
+	d.BaseService = *service.NewBaseService(logger, "LightDaemon", d)
+	return d
+}
+
+// OnStart implements service.Service. It creates the light client of each
+// chain, which requires its primary and witnesses to be reachable, and starts
+// updating them.
+func (d *Daemon) OnStart() error {
+	chains := make(map[string]*chain, len(d.config.Chains))
+	for _, config := range d.config.Chains {
+		c, err := d.newChain(config)
+		if err != nil {
+			return fmt.Errorf("chain %q: %w", config.ChainID, err)
+		}
+		chains[config.ChainID] = c
+	}
+
+	started := make([]*chain, 0, len(chains))
+	for chainID, c := range chains {
+		if err := c.pubsub.Start(); err != nil {
+			for _, c := range started {
+				if err := c.pubsub.Stop(); err != nil {
+					d.Logger.Error("Error stopping pubsub", "chain", c.config.ChainID, "err", err)
+				}
+			}
+			return fmt.Errorf("chain %q: %w", chainID, err)
+		}
+		started = append(started, c)
+	}
+	// The chains are only updated once they have all been started, so that
+	// nothing is left running if one of them fails to start.
+	for _, c := range chains {
+		go d.updateRoutine(c)
+	}
+
+	d.mtx.Lock()
+	d.chains = chains
+	d.mtx.Unlock()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (d *Daemon) OnStop() {
+	close(d.quit)
+
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	for chainID, c := range d.chains {
+		if err := c.pubsub.Stop(); err != nil {
+			d.Logger.Error("Error stopping pubsub", "chain", chainID, "err", err)
+		}
+	}
+}
+
+func (d *Daemon) newChain(config ChainConfig) (*chain, error) {
+	primary, err := newProvider(config.ChainID, config.Primary)
+	if err != nil {
+		return nil, err
+	}
+	witnesses := make([]provider.Provider, len(config.Witnesses))
+	for i, addr := range config.Witnesses {
+		if witnesses[i], err = newProvider(config.ChainID, addr); err != nil {
+			return nil, err
+		}
+	}
+
+	logger := d.Logger.With("chain", config.ChainID)
+	c := &chain{
+		config:  config,
+		reports: newReportStore(d.db, config.ChainID),
+		pubsub:  cmtpubsub.NewServer(),
+	}
+	c.pubsub.SetLogger(logger.With("module", "pubsub"))
+
+	options := []light.Option{
+		light.Logger(logger),
+		light.AttackHandler(func(ev *types.LightClientAttackEvidence, faulty provider.Provider) {
+			report := &AttackReport{
+				ChainID:    config.ChainID,
+				DetectedAt: time.Now().UTC(),
+				Provider:   fmt.Sprint(faulty),
+				Evidence:   ev,
+			}
+			if err := c.reports.Save(report); err != nil {
+				logger.Error("Failed to save attack report", "err", err)
+			}
+		}),
+	}
+	if config.Sequential {
+		options = append(options, light.SequentialVerification())
+	} else {
+		trustLevel, err := config.trustLevel()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, light.SkippingVerification(trustLevel))
+	}
+
+	// Each chain has its own prefix, as the store keeps its size under a key
+	// shared by all chains.
+	store := dbs.New(dbm.NewPrefixDB(d.db, chainPrefix("chain", config.ChainID)), config.ChainID)
+	if config.hasTrustOptions() {
+		trustOptions, err := config.trustOptions()
+		if err != nil {
+			return nil, err
+		}
+		c.client, err = light.NewClient(context.Background(), config.ChainID, trustOptions,
+			primary, witnesses, store, options...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c.client, err = light.NewClientFromTrustedStore(config.ChainID, config.TrustingPeriod,
+			primary, witnesses, store, options...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// chainPrefix returns the prefix of the keys of a chain in the given
+// namespace. The chain ID is length-prefixed, so that the prefix of a chain is
+// never a prefix of the one of another chain, e.g. "a" and "a/b".
+func chainPrefix(namespace, chainID string) []byte {
+	return fmt.Appendf(nil, "%s/%d:%s/", namespace, len(chainID), chainID)
+}
+
+// newProvider returns a gRPC provider for addresses with the "grpc://" scheme,
+// and an RPC one otherwise.
+func newProvider(chainID, addr string) (provider.Provider, error) {
+	if remote, ok := strings.CutPrefix(addr, grpcScheme); ok {
+		return grpcprovider.New(chainID, remote)
+	}
+	return httpprovider.New(chainID, addr)
+}
+
+// updateRoutine periodically updates the light client of the chain, and
+// publishes the new verified headers.
+func (d *Daemon) updateRoutine(c *chain) {
+	ticker := time.NewTicker(d.config.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		d.update(c)
+		select {
+		case <-ticker.C:
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+func (d *Daemon) update(c *chain) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-d.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	lb, err := c.client.Update(ctx, time.Now())
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			d.Logger.Error("Failed to update light client", "chain", c.config.ChainID, "err", err)
+		}
+		return
+	}
+	if lb == nil {
+		return
+	}
+	d.Logger.Debug("Verified new header", "chain", c.config.ChainID, "height", lb.Height)
+	if err := c.pubsub.Publish(ctx, lb.SignedHeader); err != nil {
+		d.Logger.Error("Failed to publish header", "chain", c.config.ChainID, "err", err)
+	}
+}
+
+func (d *Daemon) lookupChain(chainID string) (*chain, error) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	c, ok := d.chains[chainID]
+	if !ok {
+		return nil, ErrUnknownChain{ChainID: chainID}
+	}
+	return c, nil
+}
+
+// ChainStatus is the status of a tracked chain.
+type ChainStatus struct {
+	ChainID string `json:"chain_id"`
+	Primary string `json:"primary"`
+	// The heights of the first and last trusted headers, or -1 if there are
+	// none.
+	FirstTrustedHeight int64 `json:"first_trusted_height"`
+	LastTrustedHeight  int64 `json:"last_trusted_height"`
+}
+
+// Chains returns the status of the tracked chains, sorted by chain ID.
+func (d *Daemon) Chains() ([]ChainStatus, error) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	statuses := make([]ChainStatus, 0, len(d.chains))
+	for chainID, c := range d.chains {
+		first, err := c.client.FirstTrustedHeight()
+		if err != nil {
+			return nil, err
+		}
+		last, err := c.client.LastTrustedHeight()
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, ChainStatus{
+			ChainID:            chainID,
+			Primary:            fmt.Sprint(c.client.Primary()),
+			FirstTrustedHeight: first,
+			LastTrustedHeight:  last,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ChainID < statuses[j].ChainID
+	})
+	return statuses, nil
+}
+
+// Header returns the verified header of the chain at the given height, or
+// the latest trusted one if height is 0. Headers which are not trusted yet
+// are verified first.
+func (d *Daemon) Header(ctx context.Context, chainID string, height int64) (*types.SignedHeader, error) {
+	c, err := d.lookupChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		lb, err := c.client.TrustedLightBlock(0)
+		if err != nil {
+			return nil, err
+		}
+		return lb.SignedHeader, nil
+	}
+	lb, err := c.client.VerifyLightBlockAtHeight(ctx, height, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return lb.SignedHeader, nil
+}
+
+// Subscribe subscribes the subscriber to the new verified headers of the
+// chain. The subscription is canceled if the subscriber does not keep up.
+func (d *Daemon) Subscribe(ctx context.Context, subscriber, chainID string) (*cmtpubsub.Subscription, error) {
+	c, err := d.lookupChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.pubsub.Subscribe(ctx, subscriber, cmtquery.All, subscriptionCapacity)
+}
+
+// Unsubscribe cancels the subscription of the subscriber to the chain.
+func (d *Daemon) Unsubscribe(ctx context.Context, subscriber, chainID string) error {
+	c, err := d.lookupChain(chainID)
+	if err != nil {
+		return err
+	}
+	return c.pubsub.UnsubscribeAll(ctx, subscriber)
+}
+
+// AttackReports returns the light client attacks detected on the chain.
+func (d *Daemon) AttackReports(chainID string) ([]*AttackReport, error) {
+	c, err := d.lookupChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.reports.List()
+}
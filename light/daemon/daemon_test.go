@@ -0,0 +1,133 @@
+package daemon_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/light/daemon"
+	rpcclient "github.com/cometbft/cometbft/v2/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/v2/rpc/client/http"
+	rpctest "github.com/cometbft/cometbft/v2/rpc/test"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+func TestDaemon(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	node := rpctest.StartCometBFT(app, rpctest.RecreateConfig)
+	defer rpctest.StopCometBFT(node)
+
+	cfg := rpctest.GetConfig()
+	defer os.RemoveAll(cfg.RootDir)
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	chainID := genDoc.ChainID
+
+	c, err := rpchttp.New(cfg.RPC.ListenAddress)
+	require.NoError(t, err)
+	require.NoError(t, rpcclient.WaitForHeight(c, 3, nil))
+	commit, err := c.Commit(context.Background(), nil)
+	require.NoError(t, err)
+	trustedHeader := commit.Header
+
+	chainConfig := daemon.ChainConfig{
+		ChainID:        chainID,
+		Primary:        cfg.RPC.ListenAddress,
+		Witnesses:      []string{"grpc://" + strings.TrimPrefix(cfg.GRPC.ListenAddress, "tcp://")},
+		TrustingPeriod: time.Hour,
+		TrustedHeight:  trustedHeader.Height,
+		TrustedHash:    trustedHeader.Hash().String(),
+		TrustLevel:     "1/3",
+	}
+	config := &daemon.Config{
+		UpdateInterval: 100 * time.Millisecond,
+		Chains:         []daemon.ChainConfig{chainConfig},
+	}
+	require.NoError(t, config.ValidateBasic())
+
+	db := dbm.NewMemDB()
+	d := daemon.New(config, db, log.TestingLogger())
+	require.NoError(t, d.Start())
+	server := httptest.NewServer(d.Handler())
+	defer server.Close()
+
+	// new verified headers are streamed to the subscribers
+	resp, err := http.Get(server.URL + "/chains/" + chainID + "/subscribe")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 1<<20), 1<<20)
+	var streamed types.SignedHeader
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			require.NoError(t, cmtjson.Unmarshal([]byte(data), &streamed))
+			break
+		}
+	}
+	resp.Body.Close()
+	require.NoError(t, streamed.ValidateBasic(chainID))
+	assert.Greater(t, streamed.Height, trustedHeader.Height)
+
+	var statuses []daemon.ChainStatus
+	getJSON(t, server.URL+"/chains", http.StatusOK, &statuses)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, chainID, statuses[0].ChainID)
+	assert.Equal(t, trustedHeader.Height, statuses[0].FirstTrustedHeight)
+	assert.GreaterOrEqual(t, statuses[0].LastTrustedHeight, streamed.Height)
+
+	var header types.SignedHeader
+	getJSON(t, server.URL+"/chains/"+chainID+"/headers/latest", http.StatusOK, &header)
+	assert.GreaterOrEqual(t, header.Height, streamed.Height)
+
+	// headers below the latest trusted one are verified on demand
+	getJSON(t, server.URL+"/chains/"+chainID+"/headers/2", http.StatusOK, &header)
+	assert.EqualValues(t, 2, header.Height)
+	require.NoError(t, header.ValidateBasic(chainID))
+
+	getJSON(t, server.URL+"/chains/"+chainID+"/headers/abc", http.StatusBadRequest, nil)
+	getJSON(t, server.URL+"/chains/other-chain/headers/latest", http.StatusNotFound, nil)
+
+	var reports []*daemon.AttackReport
+	getJSON(t, server.URL+"/chains/"+chainID+"/attacks", http.StatusOK, &reports)
+	assert.Empty(t, reports)
+
+	require.NoError(t, d.Stop())
+
+	// the daemon restarts from the trusted store, without trust options
+	chainConfig.TrustedHeight, chainConfig.TrustedHash = 0, ""
+	config.Chains = []daemon.ChainConfig{chainConfig}
+	d = daemon.New(config, db, log.TestingLogger())
+	require.NoError(t, d.Start())
+	defer func() { require.NoError(t, d.Stop()) }()
+	statuses, err = d.Chains()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.EqualValues(t, 2, statuses[0].FirstTrustedHeight)
+	assert.GreaterOrEqual(t, statuses[0].LastTrustedHeight, streamed.Height)
+}
+
+func getJSON(t *testing.T, url string, status int, v any) {
+	t.Helper()
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	bz, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, status, resp.StatusCode, string(bz))
+	if v != nil {
+		require.NoError(t, cmtjson.Unmarshal(bz, v))
+	}
+}
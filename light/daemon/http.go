@@ -0,0 +1,152 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/light"
+	"github.com/cometbft/cometbft/v2/light/provider"
+)
+
+// subscriberID is used to give a unique ID to each HTTP subscriber.
+var subscriberID atomic.Uint64
+
+// Handler returns the HTTP handler of the verified headers API:
+//
+//	GET /chains                             status of the tracked chains
+//	GET /chains/{chain_id}/headers/latest   latest verified header
+//	GET /chains/{chain_id}/headers/{height} verified header at the height
+//	GET /chains/{chain_id}/subscribe        stream of the new verified headers
+//	GET /chains/{chain_id}/attacks          detected light client attacks
+//
+// Responses are JSON-encoded. The subscription stream uses server-sent
+// events, with one "header" event per new verified header. It is the only API
+// of the daemon, which has no gRPC service.
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /chains", d.handleChains)
+	mux.HandleFunc("GET /chains/{chain_id}/headers/latest", d.handleHeader)
+	mux.HandleFunc("GET /chains/{chain_id}/headers/{height}", d.handleHeader)
+	mux.HandleFunc("GET /chains/{chain_id}/subscribe", d.handleSubscribe)
+	mux.HandleFunc("GET /chains/{chain_id}/attacks", d.handleAttacks)
+	return mux
+}
+
+func (d *Daemon) handleChains(w http.ResponseWriter, _ *http.Request) {
+	statuses, err := d.Chains()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (d *Daemon) handleHeader(w http.ResponseWriter, r *http.Request) {
+	var height int64
+	if s := r.PathValue("height"); s != "" {
+		var err error
+		height, err = strconv.ParseInt(s, 10, 64)
+		if err != nil || height <= 0 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid height %q", s)})
+			return
+		}
+	}
+	header, err := d.Header(r.Context(), r.PathValue("chain_id"), height)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, header)
+}
+
+func (d *Daemon) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	chainID := r.PathValue("chain_id")
+	subscriber := fmt.Sprintf("%s#%d", r.RemoteAddr, subscriberID.Add(1))
+	sub, err := d.Subscribe(r.Context(), subscriber, chainID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer func() {
+		if err := d.Unsubscribe(context.Background(), subscriber, chainID); err != nil {
+			d.Logger.Debug("Failed to unsubscribe", "subscriber", subscriber, "err", err)
+		}
+	}()
+
+	// The stream outlives the write timeout of the server.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		d.Logger.Debug("Failed to clear write deadline", "err", err)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			bz, err := cmtjson.Marshal(msg.Data())
+			if err != nil {
+				d.Logger.Error("Failed to encode header", "err", err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: header\ndata: %s\n\n", bz); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-sub.Canceled():
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (d *Daemon) handleAttacks(w http.ResponseWriter, r *http.Request) {
+	reports, err := d.AttackReports(r.PathValue("chain_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, reports)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeError writes the error with the matching HTTP status.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var errUnknownChain ErrUnknownChain
+	switch {
+	case errors.As(err, &errUnknownChain),
+		errors.Is(err, provider.ErrLightBlockNotFound),
+		errors.Is(err, provider.ErrHeightTooHigh),
+		errors.Is(err, light.ErrNoHeadersExist):
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	bz, err := cmtjson.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		bz = []byte(`{"error":"failed to encode response"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(bz)
+}
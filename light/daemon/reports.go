@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// AttackReport is a light client attack detected on a tracked chain, when the
+// primary and a witness returned conflicting headers.
+type AttackReport struct {
+	ChainID    string    `json:"chain_id"`
+	DetectedAt time.Time `json:"detected_at"`
+	// The provider the evidence was formed against.
+	Provider string                           `json:"provider"`
+	Evidence *types.LightClientAttackEvidence `json:"evidence"`
+}
+
+// reportStore persists the attack reports of a chain, ordered by the height of
+// the evidence and the detection time.
+type reportStore struct {
+	db dbm.DB
+}
+
+func newReportStore(db dbm.DB, chainID string) *reportStore {
+	return &reportStore{db: dbm.NewPrefixDB(db, chainPrefix("attacks", chainID))}
+}
+
+func reportKey(report *AttackReport) []byte {
+	return fmt.Appendf(nil, "%020d/%020d", report.Evidence.Height(), report.DetectedAt.UnixNano())
+}
+
+// Save persists a report.
+func (s *reportStore) Save(report *AttackReport) error {
+	bz, err := cmtjson.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode attack report: %w", err)
+	}
+	return s.db.SetSync(reportKey(report), bz)
+}
+
+// List returns all the reports.
+func (s *reportStore) List() ([]*AttackReport, error) {
+	iter, err := s.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	reports := []*AttackReport{}
+	for ; iter.Valid(); iter.Next() {
+		report := &AttackReport{}
+		if err := cmtjson.Unmarshal(iter.Value(), report); err != nil {
+			return nil, fmt.Errorf("failed to decode attack report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, iter.Error()
+}
@@ -0,0 +1,57 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+func TestReportStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newReportStore(db, "chain-1")
+	// The chain ID of the store is a prefix of this one.
+	otherStore := newReportStore(db, "chain-1/2")
+
+	reports, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+
+	now := time.Now().UTC()
+	newReport := func(height int64, detectedAt time.Time) *AttackReport {
+		return &AttackReport{
+			ChainID:    "chain-1",
+			DetectedAt: detectedAt,
+			Provider:   "http{localhost}",
+			Evidence: &types.LightClientAttackEvidence{
+				CommonHeight:     height,
+				TotalVotingPower: 10,
+				Timestamp:        detectedAt,
+			},
+		}
+	}
+	saved := []*AttackReport{
+		newReport(5, now),
+		newReport(5, now.Add(time.Second)),
+		newReport(100, now.Add(-time.Second)),
+	}
+	for _, i := range []int{2, 1, 0} {
+		require.NoError(t, store.Save(saved[i]))
+	}
+	other := newReport(5, now)
+	other.ChainID = "chain-1/2"
+	require.NoError(t, otherStore.Save(other))
+
+	// reports are ordered by height and detection time
+	reports, err = store.List()
+	require.NoError(t, err)
+	assert.Equal(t, saved, reports)
+
+	reports, err = otherStore.List()
+	require.NoError(t, err)
+	assert.Equal(t, []*AttackReport{other}, reports)
+}
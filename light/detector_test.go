@@ -43,6 +43,7 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 	}
 	primary := mockp.New(chainID, primaryHeaders, primaryValidators)
 
+	var faultyProviders []provider.Provider
 	c, err := light.NewClient(
 		ctx,
 		chainID,
@@ -56,6 +57,9 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		dbs.New(dbm.NewMemDB(), chainID),
 		light.Logger(log.TestingLogger()),
 		light.MaxRetryAttempts(1),
+		light.AttackHandler(func(_ *types.LightClientAttackEvidence, faulty provider.Provider) {
+			faultyProviders = append(faultyProviders, faulty)
+		}),
 	)
 	require.NoError(t, err)
 
@@ -86,6 +90,9 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		CommonHeight: 4,
 	}
 	assert.True(t, primary.HasEvidence(evAgainstWitness))
+
+	// Check the attack handler was called for both.
+	assert.Equal(t, []provider.Provider{primary, witness}, faultyProviders)
 }
 
 func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
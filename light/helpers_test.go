@@ -5,6 +5,7 @@ import (
 
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	"github.com/cometbft/cometbft/v2/types"
@@ -30,6 +31,20 @@ func genPrivKeys(n int) privKeys {
 	return res
 }
 
+// genBLSPrivKeys produces an array of BLS12-381 private keys, whose signatures
+// can be aggregated.
+func genBLSPrivKeys(n int) privKeys {
+	res := make(privKeys, n)
+	for i := range res {
+		key, err := bls12381.GenPrivKey()
+		if err != nil {
+			panic(err)
+		}
+		res[i] = key
+	}
+	return res
+}
+
 // // Change replaces the key at index i.
 // func (pkz privKeys) Change(i int) privKeys {
 // 	res := make(privKeys, len(pkz))
@@ -179,6 +194,20 @@ func (pkz privKeys) GenSignedHeaderLastBlockID(chainID string, height int64, bTi
 	}
 }
 
+// GenAggregatedSignedHeader calls GenSignedHeader and aggregates the
+// signatures of its commit.
+func (pkz privKeys) GenAggregatedSignedHeader(chainID string, height int64, bTime time.Time, txs types.Txs,
+	valset, nextValset *types.ValidatorSet, appHash, consHash, resHash []byte, first, last int,
+) *types.SignedHeader {
+	sh := pkz.GenSignedHeader(chainID, height, bTime, txs, valset, nextValset, appHash, consHash, resHash, first, last)
+	commit, err := sh.Commit.Aggregate(chainID)
+	if err != nil {
+		panic(err)
+	}
+	sh.Commit = commit
+	return sh
+}
+
 func (pkz privKeys) ChangeKeys(delta int) privKeys {
 	newKeys := pkz[delta:]
 	return newKeys.Extend(delta)
@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/v2/light/provider"
+	grpcclient "github.com/cometbft/cometbft/v2/rpc/grpc/client"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// timeout is used for all requests.
+var timeout = 5 * time.Second
+
+// Client is the subset of the CometBFT gRPC client that the provider uses.
+type Client interface {
+	grpcclient.BlockServiceClient
+	grpcclient.ValidatorServiceClient
+	grpcclient.EvidenceServiceClient
+}
+
+// grpc provider uses the gRPC block, validator and evidence services to
+// obtain the necessary information.
+type grpc struct {
+	chainID string
+	remote  string
+	client  Client
+}
+
+// New creates a gRPC provider, which connects to the gRPC server of the node
+// at the given address. Unless other options are given, the connection is not
+// secured, see grpcclient.New. The 5s timeout is used for all requests.
+func New(chainID, remote string, opts ...grpcclient.Option) (provider.Provider, error) {
+	if len(opts) == 0 {
+		opts = []grpcclient.Option{grpcclient.WithInsecure()}
+	}
+	client, err := grpcclient.New(context.Background(), remote, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithClient(chainID, remote, client), nil
+}
+
+// NewWithClient allows you to provide a custom client.
+func NewWithClient(chainID, remote string, client Client) provider.Provider {
+	return &grpc{
+		chainID: chainID,
+		remote:  remote,
+		client:  client,
+	}
+}
+
+// ChainID returns a chainID this provider was configured with.
+func (p *grpc) ChainID() string {
+	return p.chainID
+}
+
+func (p *grpc) String() string {
+	return fmt.Sprintf("grpc{%s}", p.remote)
+}
+
+// LightBlock fetches a LightBlock at the given height and checks the
+// chainID matches.
+func (p *grpc) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: provider.ErrNegativeHeight{Height: height}}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	commit, err := p.client.GetCommit(ctx, height)
+	if err != nil {
+		return nil, providerError(err)
+	}
+	sh := commit.SignedHeader
+	if height != 0 && sh.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", sh.Height, height),
+		}
+	}
+
+	vals, err := p.client.GetValidatorSet(ctx, sh.Height)
+	if err != nil {
+		return nil, providerError(err)
+	}
+	if vals.Height != sh.Height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("validator set height %d responded doesn't match height %d requested", vals.Height, sh.Height),
+		}
+	}
+
+	lb := &types.LightBlock{
+		SignedHeader: sh,
+		ValidatorSet: vals.ValidatorSet,
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence submits the evidence through the evidence service.
+func (p *grpc) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := p.client.BroadcastEvidence(ctx, ev)
+	return err
+}
+
+// providerError converts the status of a failed request into the errors
+// expected by the light client.
+func providerError(err error) error {
+	switch status.Code(err) {
+	case codes.OutOfRange:
+		return provider.ErrHeightTooHigh
+	case codes.NotFound:
+		return provider.ErrLightBlockNotFound
+	case codes.DeadlineExceeded, codes.Unavailable:
+		return provider.ErrNoResponse
+	default:
+		// Malformed responses are returned as is, like errors that are not
+		// related to the request itself, e.g. a canceled context.
+		return err
+	}
+}
@@ -0,0 +1,76 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
+	"github.com/cometbft/cometbft/v2/light/provider"
+	lightgrpc "github.com/cometbft/cometbft/v2/light/provider/grpc"
+	rpcclient "github.com/cometbft/cometbft/v2/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/v2/rpc/client/http"
+	rpctest "github.com/cometbft/cometbft/v2/rpc/test"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+func TestProvider(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	// Blocks are produced quickly, so keep enough of them for the historical
+	// queries below to succeed.
+	app.RetainBlocks = 50
+	node := rpctest.StartCometBFT(app, rpctest.RecreateConfig)
+	defer rpctest.StopCometBFT(node)
+
+	cfg := rpctest.GetConfig()
+	defer os.RemoveAll(cfg.RootDir)
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	chainID := genDoc.ChainID
+
+	c, err := rpchttp.New(cfg.RPC.ListenAddress)
+	require.NoError(t, err)
+	// let it produce enough blocks for the first ones to be pruned
+	waiter := func(int64) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}
+	require.NoError(t, rpcclient.WaitForHeight(c, 60, waiter))
+
+	grpcAddr := strings.TrimPrefix(cfg.GRPC.ListenAddress, "tcp://")
+	p, err := lightgrpc.New(chainID, grpcAddr)
+	require.NoError(t, err)
+	require.Equal(t, chainID, p.ChainID())
+
+	// let's get the highest block
+	lb, err := p.LightBlock(context.Background(), 0)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, lb.Height, int64(60))
+	require.NoError(t, lb.ValidateBasic(chainID))
+
+	// historical queries
+	lower := lb.Height - 3
+	lb, err = p.LightBlock(context.Background(), lower)
+	require.NoError(t, err)
+	assert.Equal(t, lower, lb.Height)
+
+	// fetching missing heights (both future and pruned) should return appropriate errors
+	_, err = p.LightBlock(context.Background(), lb.Height+100000)
+	require.ErrorIs(t, err, provider.ErrHeightTooHigh)
+	require.Eventually(t, func() bool {
+		_, err = p.LightBlock(context.Background(), 1)
+		return errors.Is(err, provider.ErrLightBlockNotFound)
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// evidence is checked against the node's state
+	ev, err := types.NewMockDuplicateVoteEvidence(lower, cmttime.Now(), chainID)
+	require.NoError(t, err)
+	require.Error(t, p.ReportEvidence(context.Background(), ev))
+}
@@ -0,0 +1,100 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/v2/light/provider"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// local provider reads the necessary information directly from the block and
+// state stores of a node running in the same process, or sharing its
+// databases.
+type local struct {
+	chainID      string
+	blockStore   sm.BlockStore
+	stateStore   sm.Store
+	evidencePool sm.EvidencePool
+}
+
+// New creates a provider that reads from the given stores, and reports
+// evidence to the given evidence pool.
+func New(chainID string, blockStore sm.BlockStore, stateStore sm.Store, evidencePool sm.EvidencePool) provider.Provider {
+	return &local{
+		chainID:      chainID,
+		blockStore:   blockStore,
+		stateStore:   stateStore,
+		evidencePool: evidencePool,
+	}
+}
+
+// ChainID returns a chainID this provider was configured with.
+func (p *local) ChainID() string {
+	return p.chainID
+}
+
+func (*local) String() string {
+	return "local"
+}
+
+// LightBlock loads the LightBlock at the given height from the stores and
+// checks the chainID matches.
+func (p *local) LightBlock(_ context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: provider.ErrNegativeHeight{Height: height}}
+	}
+
+	latestHeight := p.blockStore.Height()
+	if height == 0 {
+		height = latestHeight
+	}
+	switch {
+	case height == 0 || height > latestHeight:
+		return nil, provider.ErrHeightTooHigh
+	case height < p.blockStore.Base():
+		return nil, provider.ErrLightBlockNotFound
+	}
+
+	blockMeta := p.blockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, provider.ErrLightBlockNotFound
+	}
+	// If the next block has not been committed yet, use the commit seen for
+	// the latest block.
+	var commit *types.Commit
+	if height == latestHeight {
+		commit = p.blockStore.LoadSeenCommit(height)
+	} else {
+		commit = p.blockStore.LoadBlockCommit(height)
+	}
+	if commit == nil {
+		return nil, provider.ErrLightBlockNotFound
+	}
+
+	vals, err := p.stateStore.LoadValidators(height)
+	if errors.As(err, &sm.ErrNoValSetForHeight{}) {
+		return nil, provider.ErrLightBlockNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load validator set at height %d: %w", height, err)
+	}
+
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &blockMeta.Header, Commit: commit},
+		ValidatorSet: vals,
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence adds the evidence to the evidence pool.
+func (p *local) ReportEvidence(_ context.Context, ev types.Evidence) error {
+	if err := ev.ValidateBasic(); err != nil {
+		return err
+	}
+	return p.evidencePool.AddEvidence(ev)
+}
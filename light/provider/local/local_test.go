@@ -0,0 +1,76 @@
+package local_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/light/provider"
+	"github.com/cometbft/cometbft/v2/light/provider/local"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/state/mocks"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+func TestProvider(t *testing.T) {
+	const height = 5
+	vals, privVals := types.RandValidatorSet(2, 10)
+	header := test.MakeHeader(t, &types.Header{
+		Height:             height,
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+	})
+	blockID := test.MakeBlockIDWithHash(header.Hash())
+	commit, err := test.MakeCommit(blockID, height, 0, vals, privVals, test.DefaultTestChainID, cmttime.Now())
+	require.NoError(t, err)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(2))
+	blockStore.On("Height").Return(int64(height))
+	blockStore.On("LoadBlockMeta", int64(height)).Return(&types.BlockMeta{BlockID: blockID, Header: *header})
+	blockStore.On("LoadSeenCommit", int64(height)).Return(commit)
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", int64(height)).Return(vals, nil)
+	evidencePool := &mocks.EvidencePool{}
+
+	p := local.New(test.DefaultTestChainID, blockStore, stateStore, evidencePool)
+	assert.Equal(t, test.DefaultTestChainID, p.ChainID())
+
+	for _, h := range []int64{0, height} {
+		lb, err := p.LightBlock(context.Background(), h)
+		require.NoError(t, err)
+		assert.EqualValues(t, height, lb.Height)
+		assert.Equal(t, vals.Hash(), lb.ValidatorSet.Hash())
+		assert.Equal(t, commit, lb.Commit)
+	}
+
+	_, err = p.LightBlock(context.Background(), height+1)
+	require.ErrorIs(t, err, provider.ErrHeightTooHigh)
+	_, err = p.LightBlock(context.Background(), 1)
+	require.ErrorIs(t, err, provider.ErrLightBlockNotFound)
+	_, err = p.LightBlock(context.Background(), -1)
+	require.ErrorAs(t, err, &provider.ErrBadLightBlock{})
+
+	// The validator set may have been pruned.
+	blockStore.On("LoadBlockMeta", int64(3)).Return(&types.BlockMeta{Header: *header})
+	blockStore.On("LoadBlockCommit", int64(3)).Return(commit)
+	stateStore.On("LoadValidators", int64(3)).Return(nil, sm.ErrNoValSetForHeight{Height: 3})
+	_, err = p.LightBlock(context.Background(), 3)
+	require.ErrorIs(t, err, provider.ErrLightBlockNotFound)
+
+	// Light blocks that don't match the chain are rejected.
+	p = local.New("other-chain", blockStore, stateStore, evidencePool)
+	_, err = p.LightBlock(context.Background(), height)
+	require.ErrorAs(t, err, &provider.ErrBadLightBlock{})
+
+	ev, err := types.NewMockDuplicateVoteEvidenceWithValidator(height, cmttime.Now(), privVals[0], test.DefaultTestChainID)
+	require.NoError(t, err)
+	evidencePool.On("AddEvidence", mock.Anything).Return(nil)
+	require.NoError(t, p.ReportEvidence(context.Background(), ev))
+	evidencePool.AssertCalled(t, "AddEvidence", ev)
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"time"
 
+	abcitypes "github.com/cometbft/cometbft/v2/abci/types"
 	"github.com/cometbft/cometbft/v2/crypto/merkle"
 	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
 	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
@@ -49,7 +50,10 @@ type Client struct {
 	keyPathFn KeyPathFunc
 }
 
-var _ rpcclient.Client = (*Client)(nil)
+var (
+	_ rpcclient.Client             = (*Client)(nil)
+	_ rpcclient.ResultsProofClient = (*Client)(nil)
+)
 
 // Option allow you to tweak Client.
 type Option func(*Client)
@@ -376,11 +380,49 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 // provided, the results of the block preceding the latest are returned.
 // NOTE: Light client only verifies the tx results.
 func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	res, _, err := c.blockResults(ctx, height, false)
+	return res, err
+}
+
+// BlockResultsWithProofs returns the block results for the given height, with
+// a merkle proof of each tx result against the LastResultsHash of the next
+// header, as BlockResults does. The proofs are verified, so they can be handed
+// to third parties trusting the same header. The next client must implement
+// rpcclient.ResultsProofClient.
+func (c *Client) BlockResultsWithProofs(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	res, trustedBlock, err := c.blockResults(ctx, height, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.TxResultProofs) != len(res.TxResults) {
+		return nil, ErrTxResultProofCount{Proofs: len(res.TxResultProofs), Results: len(res.TxResults)}
+	}
+	for i, proof := range res.TxResultProofs {
+		if proof.Index != int64(i) || proof.Total != int64(len(res.TxResults)) {
+			return nil, ErrVerifyTxResultProof{
+				Index: i,
+				Err:   fmt.Errorf("proof of result %d of %d", proof.Index, proof.Total),
+			}
+		}
+		if err := types.VerifyResult(trustedBlock.LastResultsHash, res.TxResults[i], proof); err != nil {
+			return nil, ErrVerifyTxResultProof{Index: i, Err: err}
+		}
+	}
+
+	return res, nil
+}
+
+func (c *Client) blockResults(
+	ctx context.Context,
+	height *int64,
+	prove bool,
+) (*ctypes.ResultBlockResults, *types.LightBlock, error) {
 	var h int64
 	if height == nil {
 		res, err := c.next.Status(ctx)
 		if err != nil {
-			return nil, ErrGetLatestHeight{Err: err}
+			return nil, nil, ErrGetLatestHeight{Err: err}
 		}
 		// Can't return the latest block results here because we won't be able to
 		// prove them. Return the results for the previous block instead.
@@ -389,21 +431,33 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 		h = *height
 	}
 
-	res, err := c.next.BlockResults(ctx, &h)
+	var (
+		res *ctypes.ResultBlockResults
+		err error
+	)
+	if prove {
+		next, ok := c.next.(rpcclient.ResultsProofClient)
+		if !ok {
+			return nil, nil, ErrResultsProofsNotSupported
+		}
+		res, err = next.BlockResultsWithProofs(ctx, &h)
+	} else {
+		res, err = c.next.BlockResults(ctx, &h)
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Validate res.
 	if res.Height <= 0 {
-		return nil, ErrNegOrZeroHeight
+		return nil, nil, ErrNegOrZeroHeight
 	}
 
 	// Update the light client if we're behind.
 	nextHeight := h + 1
 	trustedBlock, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Build a Merkle tree out of the above 3 binary slices.
@@ -411,10 +465,46 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 
 	// Verify block results.
 	if !bytes.Equal(rH, trustedBlock.LastResultsHash) {
-		return nil, ErrLastResultMismatch{ResultHash: rH, LastResultHash: trustedBlock.LastResultsHash}
+		return nil, nil, ErrLastResultMismatch{ResultHash: rH, LastResultHash: trustedBlock.LastResultsHash}
 	}
 
-	return res, nil
+	return res, trustedBlock, nil
+}
+
+// VerifyTxResult verifies that result is the result of the tx at index in the
+// block at height, proven by proof against the LastResultsHash of the next
+// header. The light client is updated to that header if needed.
+//
+// Only the code, data and gas of the result are proven. Its log, info and
+// events are not part of the LastResultsHash, so they cannot be verified.
+func (c *Client) VerifyTxResult(
+	ctx context.Context,
+	height int64,
+	index int,
+	result *abcitypes.ExecTxResult,
+	proof merkle.Proof,
+) error {
+	if height <= 0 {
+		return ErrNegOrZeroHeight
+	}
+	if proof.Index != int64(index) {
+		return ErrVerifyTxResultProof{
+			Index: index,
+			Err:   fmt.Errorf("proof of result %d", proof.Index),
+		}
+	}
+
+	// NOTE: the results of block H are hashed in header H+1.
+	nextHeight := height + 1
+	trustedBlock, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
+	if err != nil {
+		return err
+	}
+
+	if err := types.VerifyResult(trustedBlock.LastResultsHash, result, proof); err != nil {
+		return ErrVerifyTxResultProof{Index: index, Err: err}
+	}
+	return nil
 }
 
 // Header fetches and verifies the header directly via the light client.
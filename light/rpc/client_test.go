@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abcitypes "github.com/cometbft/cometbft/v2/abci/types"
+	lcmock "github.com/cometbft/cometbft/v2/light/rpc/mocks"
+	rpcmock "github.com/cometbft/cometbft/v2/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/v2/rpc/core/types"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// resultsProofClient is a mock client which can prove block results.
+type resultsProofClient struct {
+	*rpcmock.Client
+}
+
+func (c resultsProofClient) BlockResultsWithProofs(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	ret := c.Called(ctx, height)
+	res, _ := ret.Get(0).(*ctypes.ResultBlockResults)
+	return res, ret.Error(1)
+}
+
+func TestBlockResultsWithProofs(t *testing.T) {
+	txResults := []*abcitypes.ExecTxResult{
+		{Code: 0, Data: []byte{0x01}, Log: "ok"},
+		{Code: 1, Log: "not ok", Events: []abcitypes.Event{{Type: "transfer"}}},
+	}
+	results := types.NewResults(txResults)
+
+	// The results of block 10 are hashed in header 11.
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(11), mock.Anything).Return(&types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 11, LastResultsHash: results.Hash()}},
+	}, nil)
+
+	next := &rpcmock.Client{}
+	height := int64(10)
+	next.On("BlockResultsWithProofs", mock.Anything, &height).Return(&ctypes.ResultBlockResults{
+		Height:         height,
+		TxResults:      txResults,
+		TxResultProofs: results.ProveResults(),
+	}, nil).Once()
+
+	c := NewClient(resultsProofClient{next}, lc)
+	res, err := c.BlockResultsWithProofs(context.Background(), &height)
+	require.NoError(t, err)
+	require.Len(t, res.TxResultProofs, 2)
+
+	// A proof can be verified on its own.
+	require.NoError(t, c.VerifyTxResult(context.Background(), height, 1, txResults[1], res.TxResultProofs[1]))
+	// Only the code, data and gas are proven.
+	require.NoError(t, c.VerifyTxResult(context.Background(), height, 1,
+		&abcitypes.ExecTxResult{Code: 1, Log: "other log"}, res.TxResultProofs[1]))
+
+	err = c.VerifyTxResult(context.Background(), height, 1, &abcitypes.ExecTxResult{Code: 2}, res.TxResultProofs[1])
+	assert.ErrorAs(t, err, &ErrVerifyTxResultProof{})
+	err = c.VerifyTxResult(context.Background(), height, 0, txResults[1], res.TxResultProofs[1])
+	assert.ErrorAs(t, err, &ErrVerifyTxResultProof{})
+
+	// Proofs not matching the results are rejected.
+	proofs := results.ProveResults()
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	next.On("BlockResultsWithProofs", mock.Anything, &height).Return(&ctypes.ResultBlockResults{
+		Height:         height,
+		TxResults:      txResults,
+		TxResultProofs: proofs,
+	}, nil).Once()
+	_, err = c.BlockResultsWithProofs(context.Background(), &height)
+	assert.ErrorAs(t, err, &ErrVerifyTxResultProof{})
+
+	next.On("BlockResultsWithProofs", mock.Anything, &height).Return(&ctypes.ResultBlockResults{
+		Height:         height,
+		TxResults:      txResults,
+		TxResultProofs: proofs[:1],
+	}, nil).Once()
+	_, err = c.BlockResultsWithProofs(context.Background(), &height)
+	assert.ErrorAs(t, err, &ErrTxResultProofCount{})
+}
+
+func TestBlockResultsWithProofsNotSupported(t *testing.T) {
+	// The next client does not implement rpcclient.ResultsProofClient.
+	c := NewClient(&rpcmock.Client{}, &lcmock.LightClient{})
+	height := int64(10)
+	_, err := c.BlockResultsWithProofs(context.Background(), &height)
+	require.ErrorIs(t, err, ErrResultsProofsNotSupported)
+}
@@ -12,6 +12,8 @@ var (
 	ErrNegOrZeroHeight = errors.New("negative or zero height")
 	ErrNoProofOps      = errors.New("no proof ops")
 	ErrNilKeyPathFn    = errors.New("please configure Client with KeyPathFn option")
+
+	ErrResultsProofsNotSupported = errors.New("next client can't prove block results")
 )
 
 type ErrMissingStoreName struct {
@@ -66,6 +68,28 @@ func (e ErrLastResultMismatch) Error() string {
 	return fmt.Sprintf("last results %X does not match with trusted last results %X", e.ResultHash, e.LastResultHash)
 }
 
+type ErrTxResultProofCount struct {
+	Proofs  int
+	Results int
+}
+
+func (e ErrTxResultProofCount) Error() string {
+	return fmt.Sprintf("got %d tx result proofs for %d tx results", e.Proofs, e.Results)
+}
+
+type ErrVerifyTxResultProof struct {
+	Index int
+	Err   error
+}
+
+func (e ErrVerifyTxResultProof) Error() string {
+	return fmt.Sprintf("verify proof of tx result %d: %v", e.Index, e.Err)
+}
+
+func (e ErrVerifyTxResultProof) Unwrap() error {
+	return e.Err
+}
+
 type ErrPrimaryHeaderMismatch struct {
 	PrimaryHeaderHash cmtbytes.HexBytes
 	TrustedHeaderHash cmtbytes.HexBytes
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
 	"github.com/cometbft/cometbft/v2/light"
 	"github.com/cometbft/cometbft/v2/types"
@@ -287,6 +288,80 @@ func TestVerifyNonAdjacentHeaders(t *testing.T) {
 	}
 }
 
+func TestVerifyNonAdjacentHeadersAggregated(t *testing.T) {
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	const (
+		chainID    = "TestVerifyNonAdjacentHeadersAggregated"
+		lastHeight = 1
+	)
+
+	var (
+		keys = genBLSPrivKeys(4)
+		// 20, 30, 40, 50
+		vals     = keys.ToValidators(20, 10)
+		bTime, _ = time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+		header   = keys.GenSignedHeader(chainID, lastHeight, bTime, nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+
+		// 10, 20, 30, 40: the first is not trusted
+		newKeys = append(genBLSPrivKeys(1), keys[1:]...)
+		newVals = newKeys.ToValidators(10, 10)
+	)
+
+	testCases := []struct {
+		newHeader *types.SignedHeader
+		newVals   *types.ValidatorSet
+		expErr    error
+	}{
+		// 3/3 new vals signed, 3/3 old vals present -> no error
+		0: {
+			keys.GenAggregatedSignedHeader(chainID, 3, bTime.Add(1*time.Hour), nil, vals, vals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys)),
+			vals,
+			nil,
+		},
+		// 3/4 new vals signed, all of them trusted -> no error
+		1: {
+			newKeys.GenAggregatedSignedHeader(chainID, 4, bTime.Add(1*time.Hour), nil, newVals, newVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 1, len(newKeys)),
+			newVals,
+			nil,
+		},
+		// 4/4 new vals signed, one of them not trusted -> error, as the
+		// aggregated signature can't be verified with the trusted vals alone,
+		// although the trusted signers have more than 1/3 of their voting power
+		2: {
+			newKeys.GenAggregatedSignedHeader(chainID, 5, bTime.Add(1*time.Hour), nil, newVals, newVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys)),
+			newVals,
+			light.ErrNewValSetCantBeTrusted{types.ErrNotEnoughVotingPowerSigned{Got: 0, Needed: 46}},
+		},
+		// same, but not aggregated -> no error
+		3: {
+			newKeys.GenSignedHeader(chainID, 5, bTime.Add(1*time.Hour), nil, newVals, newVals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys)),
+			newVals,
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			err := light.VerifyNonAdjacent(header, vals, tc.newHeader, tc.newVals, 3*time.Hour,
+				bTime.Add(2*time.Hour), maxClockDrift,
+				light.DefaultTrustLevel)
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestVerifyReturnsErrorIfTrustLevelIsInvalid(t *testing.T) {
 	const (
 		chainID    = "TestVerifyReturnsErrorIfTrustLevelIsInvalid"
@@ -0,0 +1,203 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/backup"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+const (
+	// backupIndexerTimeout is how long a backup waits for the indexer to
+	// catch up with the last committed height.
+	backupIndexerTimeout = 10 * time.Second
+	// backupPausedIndexerTimeout is how long a backup waits, with commits
+	// paused, for the indexer to index the height just committed.
+	backupPausedIndexerTimeout = time.Second
+	backupIndexerPoll          = 10 * time.Millisecond
+)
+
+// openedDBs records the databases opened by the node, by ID, for backups.
+type openedDBs struct {
+	mtx sync.Mutex
+	dbs map[string]dbm.DB
+}
+
+// provider wraps dbProvider to record the databases it opens.
+func (o *openedDBs) provider(dbProvider cfg.DBProvider) cfg.DBProvider {
+	return func(ctx *cfg.DBContext) (dbm.DB, error) {
+		db, err := dbProvider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		o.mtx.Lock()
+		defer o.mtx.Unlock()
+		if o.dbs == nil {
+			o.dbs = make(map[string]dbm.DB)
+		}
+		o.dbs[ctx.ID] = db
+		return db, nil
+	}
+}
+
+func (o *openedDBs) all() map[string]dbm.DB {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	dbs := make(map[string]dbm.DB, len(o.dbs))
+	for id, db := range o.dbs {
+		dbs[id] = db
+	}
+	return dbs
+}
+
+// backupTracker tracks the backups taken in the background.
+type backupTracker struct {
+	mtx    sync.Mutex
+	status *backup.Status // of the last backup started, if any
+}
+
+// backupDir returns the directory of the backup with the given name, in the
+// backup directory of the node.
+func (n *Node) backupDir(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return "", backup.ErrInvalidBackupName
+	}
+	dir := n.config.Storage.BackupDir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(n.config.RootDir, dir)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Backup takes a backup of the node data, named name, in the backup directory
+// of the node, without stopping the node. The backup must not exist. Commits
+// are paused after the next height is committed while the databases are
+// checkpointed and the position of the WAL is recorded, and resume while the
+// backup is completed. The backup can be restored to a stopped node with
+// backup.Restore.
+func (n *Node) Backup(ctx context.Context, name string) (backup.Manifest, error) {
+	dir, err := n.backupDir(name)
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+	return n.backup(ctx, dir)
+}
+
+// StartBackup starts taking a backup like Backup, in the background, and
+// returns its directory. Only one backup is taken in the background at a
+// time. Its status is returned by BackupStatus.
+func (n *Node) StartBackup(name string) (string, error) {
+	dir, err := n.backupDir(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return "", backup.ErrBackupExists{Dir: dir}
+	}
+
+	n.backups.mtx.Lock()
+	defer n.backups.mtx.Unlock()
+	if n.backups.status != nil && n.backups.status.Running {
+		return "", backup.ErrBackupInProgress
+	}
+	n.backups.status = &backup.Status{Dir: dir, Running: true}
+
+	go func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-n.Quit():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		manifest, err := n.backup(ctx, dir)
+		if err != nil {
+			n.Logger.Error("Failed to back up the node data", "dir", dir, "err", err)
+		}
+
+		n.backups.mtx.Lock()
+		defer n.backups.mtx.Unlock()
+		n.backups.status = &backup.Status{Dir: dir, Manifest: manifest, Err: err}
+	}()
+	return dir, nil
+}
+
+// BackupStatus returns the status of the last backup started with
+// StartBackup, or false if none was.
+func (n *Node) BackupStatus() (backup.Status, bool) {
+	n.backups.mtx.Lock()
+	defer n.backups.mtx.Unlock()
+	if n.backups.status == nil {
+		return backup.Status{}, false
+	}
+	return *n.backups.status, true
+}
+
+func (n *Node) backup(ctx context.Context, dir string) (backup.Manifest, error) {
+	if n.consensusReactor.WaitSync() {
+		return backup.Manifest{}, ErrBackupWhileSyncing
+	}
+	// Wait for the indexer to catch up before pausing, so that the pause
+	// only lasts until it indexes the next height.
+	if err := n.waitForIndexer(ctx, n.blockStore.Height(), backupIndexerTimeout); err != nil {
+		return backup.Manifest{}, err
+	}
+
+	var b *backup.Backup
+	err := n.consensusState.PauseBetweenHeights(ctx, func() error {
+		state, err := n.stateStore.Load()
+		if err != nil {
+			return err
+		}
+		if err := n.waitForIndexer(ctx, state.LastBlockHeight, backupPausedIndexerTimeout); err != nil {
+			return err
+		}
+		b, err = backup.Begin(dir, n.config, backup.Manifest{
+			ChainID:   state.ChainID,
+			Height:    state.LastBlockHeight,
+			AppHash:   state.AppHash,
+			Time:      cmttime.Now(),
+			DBBackend: n.config.DBBackend,
+		}, n.openedDBs.all())
+		return err
+	})
+	if err != nil {
+		return backup.Manifest{}, fmt.Errorf("failed to start the backup: %w", err)
+	}
+
+	manifest, err := b.Finish()
+	if err != nil {
+		return backup.Manifest{}, fmt.Errorf("failed to complete the backup: %w", err)
+	}
+	n.Logger.Info("Backed up the node data", "dir", dir, "height", manifest.Height)
+	return manifest, nil
+}
+
+// waitForIndexer waits up to timeout for the indexer, which indexes blocks
+// asynchronously, to index height.
+func (n *Node) waitForIndexer(ctx context.Context, height int64, timeout time.Duration) error {
+	if n.indexerService == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(backupIndexerPoll)
+	defer ticker.Stop()
+	for n.indexerService.IndexedHeight() < height {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the indexer did not index height %d: %w", height, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/backup"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+)
+
+func TestNodeBackup(t *testing.T) {
+	config := test.ResetTestRoot("node_backup_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	n, err := DefaultNewNode(config, log.TestingLogger(), CliParams{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer func() { require.NoError(t, n.Stop()) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	manifest, err := n.Backup(ctx, "backup")
+	require.NoError(t, err)
+	require.Positive(t, manifest.Height)
+	require.Equal(t, []string{"blockstore", "evidence", "state", "tx_index"}, manifest.Databases)
+	_, err = n.Backup(ctx, "backup")
+	require.ErrorAs(t, err, &backup.ErrBackupExists{})
+
+	restoreConfig := test.ResetTestRoot("node_backup_restore_test")
+	defer os.RemoveAll(restoreConfig.RootDir)
+	restoreConfig.DBBackend = config.DBBackend
+	_, err = backup.Restore(filepath.Join(config.RootDir, "backups", "backup"), restoreConfig)
+	require.NoError(t, err)
+
+	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: restoreConfig})
+	require.NoError(t, err)
+	defer stateDB.Close()
+	state, err := sm.NewStore(stateDB, sm.StoreOptions{}).Load()
+	require.NoError(t, err)
+	require.Equal(t, manifest.Height, state.LastBlockHeight)
+	require.EqualValues(t, manifest.AppHash, state.AppHash)
+}
+
+func TestNodeStartBackup(t *testing.T) {
+	config := test.ResetTestRoot("node_start_backup_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	n, err := DefaultNewNode(config, log.TestingLogger(), CliParams{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer func() { require.NoError(t, n.Stop()) }()
+
+	for _, name := range []string{"", "..", "../backup", "/tmp/backup"} {
+		_, err = n.StartBackup(name)
+		require.ErrorIs(t, err, backup.ErrInvalidBackupName, name)
+	}
+	_, ok := n.BackupStatus()
+	require.False(t, ok)
+
+	dir, err := n.StartBackup("backup")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(config.RootDir, "backups", "backup"), dir)
+
+	var status backup.Status
+	require.Eventually(t, func() bool {
+		status, ok = n.BackupStatus()
+		return ok && !status.Running
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, status.Err)
+	require.Equal(t, dir, status.Dir)
+	require.Positive(t, status.Manifest.Height)
+
+	_, err = n.StartBackup("backup")
+	require.ErrorAs(t, err, &backup.ErrBackupExists{})
+}
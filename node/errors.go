@@ -18,6 +18,8 @@ var (
 	ErrPassedGenesisHashMismatch = errors.New("genesis doc hash in db does not match passed --genesis_hash value")
 	// ErrLoadedGenesisDocHashMismatch is returned when the genesis doc hash in the database does not match the loaded genesis doc.
 	ErrLoadedGenesisDocHashMismatch = errors.New("genesis doc hash in db does not match loaded genesis doc")
+	// ErrBackupWhileSyncing is returned when a backup is requested while the node is block syncing or state syncing.
+	ErrBackupWhileSyncing = errors.New("cannot back up the node data while syncing")
 )
 
 // ErrLightClientStateProvider is returned when the node fails to create the blockstore.
@@ -289,3 +291,20 @@ func (e ErrorLoadOrGenFilePV) Error() string {
 func (e ErrorLoadOrGenFilePV) Unwrap() error {
 	return e.Err
 }
+
+// ErrorLoadPKCS11PrivValidator is returned when the node fails to open the
+// PKCS#11 module or key of its priv validator.
+type ErrorLoadPKCS11PrivValidator struct {
+	Err       error
+	Module    string
+	StateFile string
+}
+
+func (e ErrorLoadPKCS11PrivValidator) Error() string {
+	return fmt.Sprintf("failed to load PKCS#11 privval; "+
+		"module %s, state file %s: %v", e.Module, e.StateFile, e.Err)
+}
+
+func (e ErrorLoadPKCS11PrivValidator) Unwrap() error {
+	return e.Err
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,12 +19,15 @@ import (
 
 	_ "net/http/pprof" //nolint: gosec
 
+	dbm "github.com/cometbft/cometbft-db"
 	abcicli "github.com/cometbft/cometbft/v2/abci/client"
 	abcitypes "github.com/cometbft/cometbft/v2/abci/types"
 	cfg "github.com/cometbft/cometbft/v2/config"
 	bc "github.com/cometbft/cometbft/v2/internal/blocksync"
 	cs "github.com/cometbft/cometbft/v2/internal/consensus"
+	"github.com/cometbft/cometbft/v2/internal/eventlog"
 	"github.com/cometbft/cometbft/v2/internal/evidence"
+	"github.com/cometbft/cometbft/v2/internal/keylayout"
 	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
@@ -34,10 +39,12 @@ import (
 	"github.com/cometbft/cometbft/v2/p2p/pex"
 	"github.com/cometbft/cometbft/v2/p2p/transport/tcp"
 	"github.com/cometbft/cometbft/v2/proxy"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
 	rpccore "github.com/cometbft/cometbft/v2/rpc/core"
 	grpcserver "github.com/cometbft/cometbft/v2/rpc/grpc/server"
 	grpcprivserver "github.com/cometbft/cometbft/v2/rpc/grpc/server/privileged"
 	rpcserver "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
+	"github.com/cometbft/cometbft/v2/rpc/openapi"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/state/indexer"
 	"github.com/cometbft/cometbft/v2/state/txindex"
@@ -69,11 +76,14 @@ type Node struct {
 
 	// services
 	eventBus         *types.EventBus // pub/sub for services
+	eventLog         *eventlog.Log   // latest events, for subscribers to resume
 	stateStore       sm.Store
 	blockStore       *store.BlockStore // store the blockchain to disk
 	pruner           *sm.Pruner
-	bcReactor        p2p.Reactor    // for block-syncing
-	mempoolReactor   mempoolReactor // for gossipping transactions
+	keyLayoutCopier  *keylayout.BackgroundCopier // nil unless migrating the key layout
+	storeVerifier    *store.Verifier             // nil unless the integrity check is enabled
+	bcReactor        p2p.Reactor                 // for block-syncing
+	mempoolReactor   mempoolReactor              // for gossipping transactions
 	mempool          mempl.Mempool
 	consensusState   *cs.State      // latest consensus state
 	consensusReactor *cs.Reactor    // for participating in the consensus
@@ -81,9 +91,12 @@ type Node struct {
 	evidencePool     *evidence.Pool // tracking evidence
 	proxyApp         proxy.AppConns // connection to the application
 	rpcListeners     []net.Listener // rpc servers
+	rpcMetrics       *rpcserver.Metrics
 	txIndexer        txindex.TxIndexer
 	blockIndexer     indexer.BlockIndexer
 	indexerService   *txindex.IndexerService
+	openedDBs        *openedDBs // databases to back up
+	backups          backupTracker
 	prometheusSrv    *http.Server
 	pprofSrv         *http.Server
 
@@ -324,6 +337,8 @@ func NewNodeWithCliParams(ctx context.Context,
 	cliParams CliParams,
 	options ...Option,
 ) (*Node, error) {
+	openedDBs := &openedDBs{}
+	dbProvider = openedDBs.provider(dbProvider)
 	blockStoreDB, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
@@ -348,7 +363,11 @@ func NewNodeWithCliParams(ctx context.Context,
 		DBKeyLayout:          config.Storage.ExperimentalKeyLayout,
 	})
 
-	blockStore := store.NewBlockStore(blockStoreDB, store.WithMetrics(bstMetrics), store.WithCompaction(config.Storage.Compact, config.Storage.CompactionInterval), store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout))
+	coldStorageOpts, err := store.ColdStorageFromConfig(config.Storage.ColdStorage)
+	if err != nil {
+		return nil, err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, append([]store.BlockStoreOption{store.WithMetrics(bstMetrics), store.WithCompaction(config.Storage.Compact, config.Storage.CompactionInterval), store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout), store.WithLogger(logger.With("module", "store"))}, coldStorageOpts...)...)
 	logger.Info("Blockstore version", "version", blockStore.GetVersion())
 
 	// The key will be deleted if it existed.
@@ -387,7 +406,17 @@ func NewNodeWithCliParams(ctx context.Context,
 	// external signing process.
 	if config.PrivValidatorListenAddr != "" {
 		// FIXME: we should start services inside OnStart
-		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
+		if strings.HasPrefix(config.PrivValidatorListenAddr, "grpc://") {
+			privValidator, err = createPrivValidatorGRPCClient(config, genDoc.ChainID)
+		} else {
+			privValidator, err = createAndStartPrivValidatorSocketClient(
+				config,
+				nodeKey,
+				genDoc.ChainID,
+				DefaultPrivValidatorMetricsProvider(config.Instrumentation)(genDoc.ChainID),
+				logger,
+			)
+		}
 		if err != nil {
 			return nil, ErrPrivValidatorSocketClient{Err: err}
 		}
@@ -429,6 +458,13 @@ func NewNodeWithCliParams(ctx context.Context,
 		}
 	}
 
+	// The event log is started after the handshake, so that the cursors of the
+	// events of the blocks replayed are not assigned again.
+	eventLog, err := createAndStartEventLog(config, eventBus, blockStore, stateStore, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
 	// Blocksync is always active, except if the local node blocks the chain
@@ -454,6 +490,11 @@ func NewNodeWithCliParams(ctx context.Context,
 		return nil, ErrCreatePruner{Err: err}
 	}
 
+	keyLayoutCopier, err := createKeyLayoutCopier(config, blockStoreDB, stateDB, logger.With("module", "keylayout"))
+	if err != nil {
+		return nil, err
+	}
+
 	// make block executor for consensus and blocksync reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
@@ -480,6 +521,8 @@ func NewNodeWithCliParams(ctx context.Context,
 		return nil, ErrCreateBlockSyncReactor{Err: err}
 	}
 
+	storeVerifier := createStoreVerifier(config, blockStore, stateStore, bcReactor, logger.With("module", "store"))
+
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
 		privValidator, csMetrics, waitSync, eventBus, consensusLogger, offlineStateSyncHeight,
@@ -565,6 +608,8 @@ func NewNodeWithCliParams(ctx context.Context,
 		stateStore:       stateStore,
 		blockStore:       blockStore,
 		pruner:           pruner,
+		keyLayoutCopier:  keyLayoutCopier,
+		storeVerifier:    storeVerifier,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
@@ -576,7 +621,10 @@ func NewNodeWithCliParams(ctx context.Context,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
 		blockIndexer:     blockIndexer,
+		openedDBs:        openedDBs,
+		rpcMetrics:       DefaultRPCMetricsProvider(config.Instrumentation)(genDoc.ChainID),
 		eventBus:         eventBus,
+		eventLog:         eventLog,
 
 		// statesync
 		stateSync:        stateSync,
@@ -713,6 +761,18 @@ func (n *Node) OnStart() error {
 		return ErrStartPruning{Err: err}
 	}
 
+	if n.keyLayoutCopier != nil {
+		if err := n.keyLayoutCopier.Start(); err != nil {
+			return fmt.Errorf("failed to start key layout copier: %w", err)
+		}
+	}
+
+	if n.storeVerifier != nil {
+		if err := n.storeVerifier.Start(); err != nil {
+			return fmt.Errorf("failed to start block store verifier: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -726,6 +786,16 @@ func (n *Node) OnStop() {
 	if err := n.pruner.Stop(); err != nil {
 		n.Logger.Error("Error stopping the pruning service", "err", err)
 	}
+	if n.keyLayoutCopier != nil {
+		if err := n.keyLayoutCopier.Stop(); err != nil {
+			n.Logger.Error("Error stopping the key layout copier", "err", err)
+		}
+	}
+	if n.storeVerifier != nil {
+		if err := n.storeVerifier.Stop(); err != nil {
+			n.Logger.Error("Error stopping the block store verifier", "err", err)
+		}
+	}
 	if err := n.eventBus.Stop(); err != nil {
 		n.Logger.Error("Error closing eventBus", "err", err)
 	}
@@ -734,6 +804,9 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Error closing indexerService", "err", err)
 		}
 	}
+	if err := n.eventLog.Stop(); err != nil {
+		n.Logger.Error("Error closing eventLog", "err", err)
+	}
 	// now stop the reactors
 	if err := n.sw.Stop(); err != nil {
 		n.Logger.Error("Error closing switch", "err", err)
@@ -757,6 +830,10 @@ func (n *Node) OnStop() {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
+	} else if pvc, ok := n.privValidator.(io.Closer); ok {
+		if err := pvc.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
 	}
 
 	if n.prometheusSrv != nil {
@@ -808,6 +885,7 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
+		NodeBackup:     n,
 		PubKey:         pubKey,
 
 		TxIndexer:        n.txIndexer,
@@ -815,6 +893,7 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		ConsensusReactor: n.consensusReactor,
 		MempoolReactor:   n.mempoolReactor,
 		EventBus:         n.eventBus,
+		EventLog:         n.eventLog,
 		Mempool:          n.mempool,
 
 		Logger: n.Logger.With("module", "rpc"),
@@ -832,6 +911,9 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 	return rpcEnv, nil
 }
 
+// restPrefix is the path under which the REST gateway of the RPC is served.
+const restPrefix = "/rest"
+
 func (n *Node) startRPC() ([]net.Listener, error) {
 	env, err := n.ConfigureRPC()
 	if err != nil {
@@ -845,6 +927,27 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		env.AddUnsafeRoutes(routes)
 	}
 
+	restSpec, err := openapi.REST(rpcserver.RESTRoutes(routes), restPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("generating the OpenAPI document of the REST gateway: %w", err)
+	}
+
+	var authenticator *auth.Authenticator
+	if n.config.RPC.IsAuthEnabled() {
+		authenticator, err = createRPCAuthenticator(n.config.RPC, n.Logger.With("module", "rpc-audit"))
+		if err != nil {
+			return nil, fmt.Errorf("configuring RPC authentication: %w", err)
+		}
+	}
+
+	var rateLimiter *rpcserver.RateLimiter
+	if n.config.RPC.IsRateLimitEnabled() {
+		rateLimiter, err = createRPCRateLimiter(n.config.RPC, n.rpcMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("configuring RPC rate limits: %w", err)
+		}
+	}
+
 	config := rpcserver.DefaultConfig()
 	config.MaxRequestBatchSize = n.config.RPC.MaxRequestBatchSize
 	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
@@ -877,6 +980,13 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		mux.HandleFunc("/v1/websocket", wm.WebsocketHandler)
 		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+		rpcserver.RegisterRESTFuncs(mux, restPrefix, routes, rpccore.HTTPStatus, rpcLogger)
+		mux.HandleFunc("GET "+restPrefix+"/openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", rpcserver.ContentTypeJSON)
+			if _, err := w.Write(restSpec); err != nil {
+				rpcLogger.Error("failed to write response", "err", err)
+			}
+		})
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config.MaxOpenConnections,
@@ -886,13 +996,19 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		}
 
 		var rootHandler http.Handler = mux
+		if authenticator != nil {
+			rootHandler = rpcserver.AuthHandler(rootHandler, authenticator, rpcLogger)
+		}
+		if rateLimiter != nil {
+			rootHandler = rpcserver.RateLimitHandler(rootHandler, rateLimiter)
+		}
 		if n.config.RPC.IsCorsEnabled() {
 			corsMiddleware := cors.New(cors.Options{
 				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
 				AllowedMethods: n.config.RPC.CORSAllowedMethods,
 				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
 			})
-			rootHandler = corsMiddleware.Handler(mux)
+			rootHandler = corsMiddleware.Handler(rootHandler)
 		}
 		if n.config.RPC.IsTLSEnabled() {
 			go func() {
@@ -935,15 +1051,24 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		opts := []grpcserver.Option{
 			grpcserver.WithLogger(n.Logger),
 		}
+		if authenticator != nil {
+			opts = append(opts, grpcserver.WithAuth(authenticator))
+		}
 		if n.config.GRPC.VersionService.Enabled {
 			opts = append(opts, grpcserver.WithVersionService())
 		}
 		if n.config.GRPC.BlockService.Enabled {
-			opts = append(opts, grpcserver.WithBlockService(n.blockStore, n.eventBus, n.Logger))
+			opts = append(opts, grpcserver.WithBlockService(n.blockStore, n.stateStore, n.eventBus, n.Logger))
 		}
 		if n.config.GRPC.BlockResultsService.Enabled {
 			opts = append(opts, grpcserver.WithBlockResultsService(n.blockStore, n.stateStore, n.Logger))
 		}
+		if n.config.GRPC.ValidatorService.Enabled {
+			opts = append(opts, grpcserver.WithValidatorService(n.blockStore, n.stateStore, n.eventBus, n.Logger))
+		}
+		if n.config.GRPC.EvidenceService.Enabled {
+			opts = append(opts, grpcserver.WithEvidenceService(n.evidencePool, n.Logger))
+		}
 		go func() {
 			if err := grpcserver.Serve(listener, opts...); err != nil {
 				n.Logger.Error("Error starting gRPC server", "err", err)
@@ -960,6 +1085,9 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		opts := []grpcprivserver.Option{
 			grpcprivserver.WithLogger(n.Logger),
 		}
+		if authenticator != nil {
+			opts = append(opts, grpcprivserver.WithAuth(authenticator))
+		}
 		if n.config.GRPC.Privileged.PruningService.Enabled {
 			opts = append(opts, grpcprivserver.WithPruningService(n.pruner, n.Logger))
 		}
@@ -1196,6 +1324,44 @@ func createPruner(
 	return sm.NewPruner(stateStore, blockStore, blockIndexer, txIndexer, logger, prunerOpts...), nil
 }
 
+// keyLayoutCopyInterval is the interval between the passes copying the records
+// of the stores to the configured key layout.
+const keyLayoutCopyInterval = 10 * time.Minute
+
+// createKeyLayoutCopier returns a service copying the records of the block
+// store and state store to the configured key layout, if enabled and if they
+// use another layout, or nil.
+func createKeyLayoutCopier(
+	config *cfg.Config,
+	blockStoreDB dbm.DB,
+	stateDB dbm.DB,
+	logger log.Logger,
+) (*keylayout.BackgroundCopier, error) {
+	if !config.Storage.ExperimentalKeyLayoutMigration || config.Storage.ExperimentalKeyLayout == "" {
+		return nil, nil
+	}
+
+	var migrators []*keylayout.Migrator
+	blockStoreMigrator, err := store.NewKeyLayoutMigrator(blockStoreDB, config.Storage.ExperimentalKeyLayout, logger)
+	switch {
+	case err == nil:
+		migrators = append(migrators, blockStoreMigrator)
+	case !errors.As(err, &keylayout.ErrSameLayout{}):
+		return nil, fmt.Errorf("failed to create block store key layout migrator: %w", err)
+	}
+	stateMigrator, err := sm.NewKeyLayoutMigrator(stateDB, config.Storage.ExperimentalKeyLayout, logger)
+	switch {
+	case err == nil:
+		migrators = append(migrators, stateMigrator)
+	case !errors.As(err, &keylayout.ErrSameLayout{}):
+		return nil, fmt.Errorf("failed to create state store key layout migrator: %w", err)
+	}
+	if len(migrators) == 0 {
+		return nil, nil
+	}
+	return keylayout.NewBackgroundCopier(migrators, keyLayoutCopyInterval, logger), nil
+}
+
 // Set the initial application retain height to 0 to avoid the data companion
 // pruning blocks before the application indicates it is OK. We set this to 0
 // only if the retain height was not set before by the application.
@@ -1234,3 +1400,22 @@ func initCompanionRetainHeights(stateStore sm.Store, initBlockRH, initBlockResul
 	}
 	return nil
 }
+
+// createStoreVerifier returns the verifier of the block store running the
+// background integrity check, or nil if it's disabled.
+func createStoreVerifier(
+	config *cfg.Config,
+	blockStore *store.BlockStore,
+	stateStore sm.Store,
+	bcReactor p2p.Reactor,
+	logger log.Logger,
+) *store.Verifier {
+	if !config.Storage.IntegrityCheck.Enabled {
+		return nil
+	}
+	opts := []store.VerifierOption{store.WithVerifyInterval(config.Storage.IntegrityCheck.Interval)}
+	if fetcher, ok := bcReactor.(store.BlockFetcher); ok && config.Storage.IntegrityCheck.Repair {
+		opts = append(opts, store.WithRepair(fetcher))
+	}
+	return store.NewVerifier(blockStore, stateStore, logger, opts...)
+}
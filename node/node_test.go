@@ -180,6 +180,27 @@ func TestPprofServer(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestPKCS11PIN(t *testing.T) {
+	config := test.ResetTestRoot("node_pkcs11_pin_test")
+	defer os.RemoveAll(config.RootDir)
+
+	config.PrivValidatorPKCS11PIN = "1234"
+	pin, err := pkcs11PIN(config)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", pin)
+
+	// The PIN file is relative to the root directory, and only its first line
+	// is read.
+	config.PrivValidatorPKCS11PIN = ""
+	config.PrivValidatorPKCS11PINFile = "config/pin"
+	_, err = pkcs11PIN(config)
+	require.Error(t, err)
+	require.NoError(t, os.WriteFile(config.PrivValidatorPKCS11PINFilePath(), []byte("5678\r\nignored\n"), 0o600))
+	pin, err = pkcs11PIN(config)
+	require.NoError(t, err)
+	assert.Equal(t, "5678", pin)
+}
+
 func TestNodeSetPrivValTCP(t *testing.T) {
 	addr := "tcp://" + testFreeAddr(t)
 
@@ -21,7 +21,9 @@ import (
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	"github.com/cometbft/cometbft/v2/internal/blocksync"
 	cs "github.com/cometbft/cometbft/v2/internal/consensus"
+	"github.com/cometbft/cometbft/v2/internal/eventlog"
 	"github.com/cometbft/cometbft/v2/internal/evidence"
+	cmtnet "github.com/cometbft/cometbft/v2/internal/net"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/light"
 	mempl "github.com/cometbft/cometbft/v2/mempool"
@@ -32,7 +34,11 @@ import (
 	"github.com/cometbft/cometbft/v2/p2p/transport/tcp"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	"github.com/cometbft/cometbft/v2/privval"
+	privvalgrpc "github.com/cometbft/cometbft/v2/privval/grpc"
+	"github.com/cometbft/cometbft/v2/privval/pkcs11"
 	"github.com/cometbft/cometbft/v2/proxy"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
+	rpcserver "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/state/indexer"
 	"github.com/cometbft/cometbft/v2/state/indexer/block"
@@ -63,6 +69,10 @@ type CliParams struct {
 	// If there is a mismatch between the hash provided via cli and the
 	// hash of the genesis file or the hash in the DB, the node will not boot.
 	GenesisHash []byte
+
+	// KeyPassphrase returns the passphrase of the validator and node key
+	// files, if they are encrypted. It is only called if one of them is.
+	KeyPassphrase func() ([]byte, error)
 }
 
 // GenesisDocProvider returns a GenesisDoc together with its SHA256 checksum.
@@ -105,21 +115,52 @@ func DefaultNewNode(
 	cliParams CliParams,
 	keyGenF func() (crypto.PrivKey, error),
 ) (*Node, error) {
-	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	var (
+		nodeKeyOptions []p2p.NodeKeyLoadOption
+		pvOptions      []privval.LoadOption
+	)
+	if cliParams.KeyPassphrase != nil {
+		nodeKeyOptions = append(nodeKeyOptions, p2p.NodeKeyPassphrase(cliParams.KeyPassphrase))
+		pvOptions = append(pvOptions, privval.WithKeyPassphrase(cliParams.KeyPassphrase))
+	}
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile(), nodeKeyOptions...)
 	if err != nil {
 		return nil, ErrorLoadOrGenNodeKey{Err: err, NodeKeyFile: config.NodeKeyFile()}
 	}
 
-	pv, err := privval.LoadOrGenFilePV(
-		config.PrivValidatorKeyFile(),
-		config.PrivValidatorStateFile(),
-		keyGenF,
-	)
-	if err != nil {
-		return nil, ErrorLoadOrGenFilePV{
-			Err:       err,
-			KeyFile:   config.PrivValidatorKeyFile(),
-			StateFile: config.PrivValidatorStateFile(),
+	var pv types.PrivValidator
+	if config.PrivValidatorPKCS11Module != "" {
+		var pin string
+		pin, err = pkcs11PIN(config)
+		if err == nil {
+			pv, err = pkcs11.NewPrivValidator(pkcs11.Config{
+				Module:     config.PrivValidatorPKCS11Module,
+				TokenLabel: config.PrivValidatorPKCS11TokenLabel,
+				PIN:        pin,
+				KeyLabel:   config.PrivValidatorPKCS11KeyLabel,
+			}, config.PrivValidatorStateFile())
+		}
+		if err != nil {
+			return nil, ErrorLoadPKCS11PrivValidator{
+				Err:       err,
+				Module:    config.PrivValidatorPKCS11Module,
+				StateFile: config.PrivValidatorStateFile(),
+			}
+		}
+	} else {
+		pv, err = privval.LoadOrGenFilePV(
+			config.PrivValidatorKeyFile(),
+			config.PrivValidatorStateFile(),
+			keyGenF,
+			pvOptions...,
+		)
+		if err != nil {
+			return nil, ErrorLoadOrGenFilePV{
+				Err:       err,
+				KeyFile:   config.PrivValidatorKeyFile(),
+				StateFile: config.PrivValidatorStateFile(),
+			}
 		}
 	}
 
@@ -135,7 +176,22 @@ func DefaultNewNode(
 	)
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
+// pkcs11PIN returns the user PIN of the PKCS#11 token, read from the first line
+// of the PIN file if set.
+func pkcs11PIN(config *cfg.Config) (string, error) {
+	path := config.PrivValidatorPKCS11PINFilePath()
+	if path == "" {
+		return config.PrivValidatorPKCS11PIN, nil
+	}
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading PKCS#11 PIN file: %w", err)
+	}
+	pin, _, _ := strings.Cut(string(bz), "\n")
+	return strings.TrimSuffix(pin, "\r"), nil
+}
+
+// MetricsProvider returns the Metrics of the node components.
 type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *store.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
@@ -156,6 +212,35 @@ func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
 	}
 }
 
+// PrivValidatorMetricsProvider returns the Metrics of the connections to the
+// external PrivValidator processes.
+type PrivValidatorMetricsProvider func(chainID string) *privval.Metrics
+
+// DefaultPrivValidatorMetricsProvider returns Metrics build using Prometheus
+// client library if Prometheus is enabled. Otherwise, it returns no-op Metrics.
+func DefaultPrivValidatorMetricsProvider(config *cfg.InstrumentationConfig) PrivValidatorMetricsProvider {
+	return func(chainID string) *privval.Metrics {
+		if config.Prometheus {
+			return privval.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+		}
+		return privval.NopMetrics()
+	}
+}
+
+// RPCMetricsProvider returns the Metrics of the RPC server.
+type RPCMetricsProvider func(chainID string) *rpcserver.Metrics
+
+// DefaultRPCMetricsProvider returns Metrics build using Prometheus client
+// library if Prometheus is enabled. Otherwise, it returns no-op Metrics.
+func DefaultRPCMetricsProvider(config *cfg.InstrumentationConfig) RPCMetricsProvider {
+	return func(chainID string) *rpcserver.Metrics {
+		if config.Prometheus {
+			return rpcserver.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+		}
+		return rpcserver.NopMetrics()
+	}
+}
+
 type blockSyncReactor interface {
 	SwitchToBlockSync(state sm.State) error
 }
@@ -194,6 +279,21 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
+func createAndStartEventLog(
+	config *cfg.Config,
+	eventBus *types.EventBus,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	logger log.Logger,
+) (*eventlog.Log, error) {
+	eventLog := eventlog.New(eventBus, blockStore, stateStore, config.RPC.EventLogSize)
+	eventLog.SetLogger(logger.With("module", "events"))
+	if err := eventLog.Start(); err != nil {
+		return nil, err
+	}
+	return eventLog, nil
+}
+
 func createAndStartIndexerService(
 	config *cfg.Config,
 	chainID string,
@@ -362,7 +462,12 @@ func createBlocksyncReactor(config *cfg.Config,
 ) (bcReactor p2p.Reactor, err error) {
 	switch config.BlockSync.Version {
 	case "v0":
-		bcReactor = blocksync.NewReactor(state.Copy(), blockExec, blockStore, blockSync, localAddr, metrics, offlineStateSyncHeight)
+		checkpoints, err := blocksync.CheckpointsFromConfig(config.BlockSync)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trusted checkpoints: %w", err)
+		}
+		bcReactor = blocksync.NewReactor(state.Copy(), blockExec, blockStore, blockSync, localAddr, metrics, offlineStateSyncHeight,
+			blocksync.WithTrustedCheckpoints(checkpoints))
 	case "v1", "v2":
 		return nil, fmt.Errorf("block sync version %s has been deprecated. Please use v0", config.BlockSync.Version)
 	default:
@@ -709,20 +814,49 @@ func LoadStateFromDBOrGenesisDocProvider(
 }
 
 func createAndStartPrivValidatorSocketClient(
-	listenAddr,
+	config *cfg.Config,
+	nodeKey *p2p.NodeKey,
 	chainID string,
+	metrics *privval.Metrics,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
-	pve, err := privval.NewSignerListener(listenAddr, logger)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	allowedKeys := make([]crypto.PubKey, len(config.PrivValidatorAllowedKeys))
+	for i, key := range config.PrivValidatorAllowedKeys {
+		pubKey, err := privval.ParsePubKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("priv_validator_allowed_keys: %w", err)
+		}
+		allowedKeys[i] = pubKey
 	}
 
-	pvsc, err := privval.NewSignerClient(pve, chainID)
+	endpoints, err := privval.NewSignerListeners(
+		config.PrivValidatorListenAddr,
+		config.PrivValidatorSigners,
+		nodeKey.PrivKey,
+		allowedKeys,
+		logger,
+		privval.SignerListenerEndpointMetrics(metrics),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
 
+	clients := make([]*privval.SignerClient, len(endpoints))
+	for i, pve := range endpoints {
+		clients[i], err = privval.NewSignerClient(pve, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+	}
+
+	var pvsc privval.RemoteSignerClient = clients[0]
+	if len(clients) > 1 {
+		pvsc, err = privval.NewFailoverSignerClient(clients, logger.With("module", "privval"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+	}
+
 	// try to get a pubkey from private validate first time
 	_, err = pvsc.GetPubKey()
 	if err != nil {
@@ -738,6 +872,82 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+// createPrivValidatorGRPCClient connects to an external PrivValidator process
+// serving the PrivValidatorAPI over gRPC, with mutual TLS authentication.
+func createPrivValidatorGRPCClient(config *cfg.Config, chainID string) (types.PrivValidator, error) {
+	tlsConfig, err := privvalgrpc.ClientTLSConfig(
+		config.PrivValidatorClientCertificateFile(),
+		config.PrivValidatorClientKeyFile(),
+		config.PrivValidatorRootCAFile(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	_, address := cmtnet.ProtocolAndAddress(config.PrivValidatorListenAddr)
+	pvsc, err := privvalgrpc.DialSignerClient(address, tlsConfig, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	const (
+		retries = 50 // 50 * 100ms = 5s total
+		timeout = 100 * time.Millisecond
+	)
+	pvscWithRetries := privval.NewRetrySignerClient(pvsc, retries, timeout)
+
+	// try to get a pubkey from private validate first time
+	if _, err := pvscWithRetries.GetPubKey(); err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	return pvscWithRetries, nil
+}
+
+// createRPCAuthenticator returns the Authenticator of the callers of the RPC
+// and gRPC servers. Privileged calls are audit logged to logger.
+func createRPCAuthenticator(config *cfg.RPCConfig, logger log.Logger) (*auth.Authenticator, error) {
+	authCfg := auth.Config{
+		RateLimit: config.AuthRateLimit,
+		RateBurst: config.AuthRateBurst,
+	}
+	for _, s := range config.AuthAnonymousScopes {
+		scope, err := auth.ParseScope(s)
+		if err != nil {
+			return nil, fmt.Errorf("auth_anonymous_scopes: %w", err)
+		}
+		authCfg.AnonymousScopes = append(authCfg.AnonymousScopes, scope)
+	}
+
+	var err error
+	if config.AuthAPIKeysFile != "" {
+		if authCfg.APIKeys, err = auth.LoadAPIKeys(config.APIKeysFile()); err != nil {
+			return nil, err
+		}
+	}
+	if config.AuthJWTSecretFile != "" {
+		if authCfg.JWTSecret, err = auth.LoadJWTSecret(config.JWTSecretFile()); err != nil {
+			return nil, err
+		}
+	}
+
+	return auth.NewAuthenticator(authCfg, logger)
+}
+
+// createRPCRateLimiter returns the RateLimiter of the requests to the RPC
+// server.
+func createRPCRateLimiter(config *cfg.RPCConfig, metrics *rpcserver.Metrics) (*rpcserver.RateLimiter, error) {
+	routeRates, err := config.RouteRates()
+	if err != nil {
+		return nil, err
+	}
+	return rpcserver.NewRateLimiter(rpcserver.RateLimitConfig{
+		Rate:       config.RateLimit,
+		Burst:      config.RateBurst,
+		RouteRates: routeRates,
+	}, metrics)
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
@@ -3,10 +3,12 @@ package nodekey
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtos "github.com/cometbft/cometbft/v2/internal/os"
 	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
@@ -19,14 +21,29 @@ type ID = string
 // TODO: support other length addresses ?
 const IDByteLength = crypto.AddressSize
 
+// BlockType is the ASCII armor block type of encrypted NodeKey files.
+const BlockType = "COMETBFT NODE KEY"
+
+// ErrEncrypted is returned when loading an encrypted NodeKey file without a
+// passphrase.
+var ErrEncrypted = errors.New("node key file is encrypted: a passphrase is required")
+
 // ------------------------------------------------------------------------------
 // Persistent peer ID
-// TODO: encrypt on disk
 
 // NodeKey is the persistent peer key.
 // It contains the nodes private key for authentication.
 type NodeKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"` // our priv key
+
+	passphrase []byte
+}
+
+// SetPassphrase sets the passphrase the NodeKey is encrypted with when saved.
+// A nil passphrase saves it as plaintext JSON. A NodeKey loaded from an
+// encrypted file keeps the passphrase it was decrypted with.
+func (nk *NodeKey) SetPassphrase(passphrase []byte) {
+	nk.passphrase = passphrase
 }
 
 // ID returns the peer's canonical ID - the hash of its public key.
@@ -45,11 +62,24 @@ func PubKeyToID(pubKey crypto.PubKey) ID {
 	return hex.EncodeToString(pubKey.Address())
 }
 
+// LoadOption sets an optional parameter on the loading of a NodeKey.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	passphrase func() ([]byte, error)
+}
+
+// WithPassphrase sets the function returning the passphrase of an encrypted
+// NodeKey file. It is only called if the file is encrypted.
+func WithPassphrase(passphrase func() ([]byte, error)) LoadOption {
+	return func(o *loadOptions) { o.passphrase = passphrase }
+}
+
 // LoadOrGen attempts to load the NodeKey from the given filePath. If
 // the file does not exist, it generates and saves a new NodeKey.
-func LoadOrGen(filePath string) (*NodeKey, error) {
+func LoadOrGen(filePath string, options ...LoadOption) (*NodeKey, error) {
 	if cmtos.FileExists(filePath) {
-		nodeKey, err := Load(filePath)
+		nodeKey, err := Load(filePath, options...)
 		if err != nil {
 			return nil, err
 		}
@@ -68,27 +98,60 @@ func LoadOrGen(filePath string) (*NodeKey, error) {
 	return nodeKey, nil
 }
 
-// Load loads NodeKey located in filePath.
-func Load(filePath string) (*NodeKey, error) {
+// Load loads NodeKey located in filePath, decrypting it if it is encrypted.
+// It returns ErrEncrypted if it is encrypted and no passphrase is given with
+// WithPassphrase.
+func Load(filePath string, options ...LoadOption) (*NodeKey, error) {
+	var opts loadOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
 	jsonBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
+	var passphrase []byte
+	if armor.IsArmored(jsonBytes) {
+		if opts.passphrase == nil {
+			return nil, ErrEncrypted
+		}
+		if passphrase, err = opts.passphrase(); err != nil {
+			return nil, fmt.Errorf("reading passphrase: %w", err)
+		}
+		blockType, data, err := armor.DecryptArmor(string(jsonBytes), passphrase)
+		if err != nil {
+			return nil, err
+		}
+		if blockType != BlockType {
+			return nil, fmt.Errorf("unexpected block type %q", blockType)
+		}
+		jsonBytes = data
+	}
+
 	nodeKey := new(NodeKey)
 	err = cmtjson.Unmarshal(jsonBytes, nodeKey)
 	if err != nil {
 		return nil, err
 	}
+	nodeKey.passphrase = passphrase
 	return nodeKey, nil
 }
 
-// SaveAs persists the NodeKey to filePath.
+// SaveAs persists the NodeKey to filePath, encrypted if a passphrase is set.
 func (nk *NodeKey) SaveAs(filePath string) error {
-	jsonBytes, err := cmtjson.Marshal(nk)
+	bz, err := cmtjson.Marshal(nk)
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filePath, jsonBytes, 0o600)
+	if nk.passphrase != nil {
+		armorStr, err := armor.EncryptArmor(BlockType, bz, nk.passphrase)
+		if err != nil {
+			return err
+		}
+		bz = []byte(armorStr)
+	}
+	err = os.WriteFile(filePath, bz, 0o600)
 	if err != nil {
 		return err
 	}
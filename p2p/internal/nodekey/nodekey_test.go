@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
 )
@@ -53,6 +54,24 @@ func TestNodeKey_SaveAs(t *testing.T) {
 	assert.FileExists(t, filePath)
 }
 
+func TestNodeKey_Encrypted(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "node_key.json")
+
+	nodeKey := &NodeKey{PrivKey: ed25519.GenPrivKey()}
+	nodeKey.SetPassphrase([]byte("passphrase"))
+	require.NoError(t, nodeKey.SaveAs(filePath))
+
+	_, err := Load(filePath)
+	require.ErrorIs(t, err, ErrEncrypted)
+	_, err = LoadOrGen(filePath, WithPassphrase(func() ([]byte, error) { return []byte("wrong"), nil }))
+	require.ErrorIs(t, err, armor.ErrWrongPassphrase)
+
+	nodeKey2, err := Load(filePath, WithPassphrase(func() ([]byte, error) { return []byte("passphrase"), nil }))
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.PrivKey, nodeKey2.PrivKey)
+	assert.Equal(t, []byte("passphrase"), nodeKey2.passphrase)
+}
+
 // ----------------------------------------------------------
 
 func padBytes(bz []byte) []byte {
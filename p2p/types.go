@@ -23,6 +23,8 @@ type (
 	ID = nodekey.ID
 	// NodeKey is the node key.
 	NodeKey = nodekey.NodeKey
+	// NodeKeyLoadOption sets an optional parameter on the loading of a NodeKey.
+	NodeKeyLoadOption = nodekey.LoadOption
 
 	// NodeInfo is the information about a peer.
 	NodeInfo = ni.NodeInfo
@@ -72,11 +74,17 @@ var (
 )
 
 // LoadOrGenNodeKey loads a node key from the given path or generates a new one.
-func LoadOrGenNodeKey(path string) (*nodekey.NodeKey, error) {
-	return nodekey.LoadOrGen(path)
+func LoadOrGenNodeKey(path string, options ...NodeKeyLoadOption) (*nodekey.NodeKey, error) {
+	return nodekey.LoadOrGen(path, options...)
 }
 
 // LoadNodeKey loads a node key from the given path.
-func LoadNodeKey(path string) (*nodekey.NodeKey, error) {
-	return nodekey.Load(path)
+func LoadNodeKey(path string, options ...NodeKeyLoadOption) (*nodekey.NodeKey, error) {
+	return nodekey.Load(path, options...)
+}
+
+// NodeKeyPassphrase sets the function returning the passphrase of an
+// encrypted node key file. It is only called if the file is encrypted.
+func NodeKeyPassphrase(passphrase func() ([]byte, error)) NodeKeyLoadOption {
+	return nodekey.WithPassphrase(passphrase)
 }
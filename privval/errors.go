@@ -3,6 +3,8 @@ package privval
 import (
 	"errors"
 	"fmt"
+
+	"github.com/cometbft/cometbft/v2/crypto"
 )
 
 // EndpointTimeoutError occurs when endpoint times out.
@@ -21,6 +23,14 @@ var (
 	ErrWriteTimeout      = errors.New("endpoint write timed out")
 )
 
+// ErrSignerQuarantined is returned when a signer whose sign request timed out
+// is not connected again.
+var ErrSignerQuarantined = errors.New("the signer is quarantined: its last sign request timed out and it may have signed")
+
+// ErrKeyFileEncrypted is returned when loading an encrypted key file without
+// a passphrase.
+var ErrKeyFileEncrypted = errors.New("key file is encrypted: a passphrase is required")
+
 // RemoteSignerError allows (remote) validators to include meaningful error
 // descriptions in their reply.
 type RemoteSignerError struct {
@@ -32,3 +42,35 @@ type RemoteSignerError struct {
 func (e *RemoteSignerError) Error() string {
 	return fmt.Sprintf("signerEndpoint returned error #%d: %s", e.Code, e.Description)
 }
+
+// ErrSignerPubKeyMismatch is returned when a remote signer's public key differs
+// from the one of the other signers.
+type ErrSignerPubKeyMismatch struct {
+	Expected crypto.PubKey
+	Got      crypto.PubKey
+}
+
+func (e ErrSignerPubKeyMismatch) Error() string {
+	return fmt.Sprintf("signer public key %X does not match the expected %X", e.Got.Bytes(), e.Expected.Bytes())
+}
+
+// ErrUnknownSignerKey is returned when a remote signer connects with a public
+// key which is not allowed.
+type ErrUnknownSignerKey struct {
+	PubKey crypto.PubKey
+}
+
+func (e ErrUnknownSignerKey) Error() string {
+	return fmt.Sprintf("signer public key %X is not allowed", e.PubKey.Bytes())
+}
+
+// ErrNodeKeyMismatch is returned when the node a signer connects to has
+// another public key than the pinned one.
+type ErrNodeKeyMismatch struct {
+	Expected crypto.PubKey
+	Got      crypto.PubKey
+}
+
+func (e ErrNodeKeyMismatch) Error() string {
+	return fmt.Sprintf("node public key %X does not match the pinned %X", e.Got.Bytes(), e.Expected.Bytes())
+}
@@ -0,0 +1,264 @@
+package privval
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// FailoverSignerClient implements PrivValidator on top of several
+// SignerClients, connected to redundant remote signers holding the same key.
+//
+// Requests go to one signer, the active one, and fail over to the next
+// connected signer if the active one is no longer connected. Each endpoint
+// pings its signer to detect failed connections beforehand. Requests are sent
+// one at a time, so that the same request is never sent to two signers at once.
+// A signer refusing to sign, e.g. because of its double signing protection, is
+// not failed over.
+//
+// If a sign request times out, the signer may have signed anyway, so the
+// request is not failed over, which could make another signer sign a
+// conflicting vote. The connection of the active signer is
+// dropped and it is quarantined: all the requests go to it, failing until it
+// reconnects with the same authentication key and its public key is checked
+// again, or the lease is handed over to another signer with HandOver. The
+// signers must therefore be connected over authenticated connections: one
+// connected over an unauthenticated connection cannot be told apart from the
+// others, so only HandOver lifts its quarantine.
+//
+// The public key of each signer is checked whenever it connects, and the
+// connection is dropped if the key differs from the one of the first signer.
+type FailoverSignerClient struct {
+	logger log.Logger
+
+	mtx         cmtsync.Mutex
+	clients     []*SignerClient
+	active      int
+	quarantined bool          // whether the active signer may have served a failed sign request
+	quarantine  crypto.PubKey // authentication key of the quarantined signer, if any
+	pubKey      crypto.PubKey
+	verified    []uint64 // ID of the connection of each client whose public key was checked
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+
+// NewFailoverSignerClient returns a FailoverSignerClient sending requests to
+// clients, starting with the first one.
+func NewFailoverSignerClient(clients []*SignerClient, logger log.Logger) (*FailoverSignerClient, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("no signer clients provided")
+	}
+	return &FailoverSignerClient{
+		logger:   logger,
+		clients:  clients,
+		verified: make([]uint64, len(clients)),
+	}, nil
+}
+
+// Close closes the connections to all the signers.
+func (fc *FailoverSignerClient) Close() error {
+	var errs []error
+	for _, sc := range fc.clients {
+		errs = append(errs, sc.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// IsConnected indicates whether any signer is connected.
+func (fc *FailoverSignerClient) IsConnected() bool {
+	for _, sc := range fc.clients {
+		if sc.endpoint.hasConnection() {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForConnection waits maxWait for any signer to connect or returns a
+// timeout error.
+func (fc *FailoverSignerClient) WaitForConnection(maxWait time.Duration) error {
+	const pollInterval = 100 * time.Millisecond
+	deadline := time.Now().Add(maxWait)
+	for !fc.IsConnected() {
+		if time.Now().After(deadline) {
+			return ErrConnectionTimeout
+		}
+		time.Sleep(pollInterval)
+	}
+	return nil
+}
+
+// HandOver makes the signer connected to the client at idx the active one,
+// lifting the quarantine of the previous one. The caller must make sure that the previous
+// signer did not sign anything the new one does not know about, e.g. because
+// it is stopped and their double signing protection state is shared.
+func (fc *FailoverSignerClient) HandOver(idx int) error {
+	if idx < 0 || idx >= len(fc.clients) {
+		return fmt.Errorf("invalid signer index %d, expected [0, %d)", idx, len(fc.clients))
+	}
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	fc.logger.Info("Handing over to another signer", "signer", idx, "previous", fc.active)
+	fc.active = idx
+	fc.quarantined = false
+	fc.quarantine = nil
+	return nil
+}
+
+// Ping sends a ping request to the active signer.
+func (fc *FailoverSignerClient) Ping() error {
+	return fc.do(false, func(sc *SignerClient) error { return sc.Ping() })
+}
+
+// GetPubKey returns the public key of the signers.
+func (fc *FailoverSignerClient) GetPubKey() (crypto.PubKey, error) {
+	// The public key is requested from a signer whenever it connects.
+	if err := fc.do(false, func(*SignerClient) error { return nil }); err != nil {
+		return nil, err
+	}
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	return fc.pubKey, nil
+}
+
+// SignVote requests the active signer to sign a vote.
+func (fc *FailoverSignerClient) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	return fc.do(true, func(sc *SignerClient) error { return sc.SignVote(chainID, vote, signExtension) })
+}
+
+// SignProposal requests the active signer to sign a proposal.
+func (fc *FailoverSignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	return fc.do(true, func(sc *SignerClient) error { return sc.SignProposal(chainID, proposal) })
+}
+
+// SignBytes requests the active signer to sign bytes.
+func (fc *FailoverSignerClient) SignBytes(signBytes []byte) ([]byte, error) {
+	var sig []byte
+	err := fc.do(true, func(sc *SignerClient) error {
+		var err error
+		sig, err = sc.SignBytes(signBytes)
+		return err
+	})
+	return sig, err
+}
+
+// do sends a request with the active signer, failing over to the next
+// connected ones until one succeeds, unless the active signer is quarantined.
+// If no signer is connected, it waits for one to connect to the endpoint of the
+// active signer.
+func (fc *FailoverSignerClient) do(sign bool, request func(*SignerClient) error) error {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	if fc.quarantined {
+		return fc.sendQuarantined(sign, request)
+	}
+
+	var err error
+	connected := false
+	for i := range fc.clients {
+		idx := (fc.active + i) % len(fc.clients)
+		if !fc.clients[idx].endpoint.hasConnection() {
+			continue
+		}
+		connected = true
+
+		err = fc.send(idx, sign, request)
+		var remoteErr *RemoteSignerError
+		if err == nil || errors.As(err, &remoteErr) || fc.quarantined {
+			return err
+		}
+	}
+	if !connected {
+		return fc.send(fc.active, sign, request)
+	}
+	return err
+}
+
+// sendQuarantined sends a request with the quarantined signer, if it is
+// connected again. The caller must hold the lock.
+func (fc *FailoverSignerClient) sendQuarantined(sign bool, request func(*SignerClient) error) error {
+	if fc.quarantine == nil {
+		return ErrSignerQuarantined
+	}
+	for idx, sc := range fc.clients {
+		if sc.endpoint.hasConnection() && pubKeysEqual(sc.endpoint.remoteKey(), fc.quarantine) {
+			return fc.send(idx, sign, request)
+		}
+	}
+	return ErrSignerQuarantined
+}
+
+// send sends a request with the signer of the client at idx, which becomes the
+// active one if it succeeds. If a sign request times out, the signer is
+// quarantined. The caller must hold the lock.
+func (fc *FailoverSignerClient) send(idx int, sign bool, request func(*SignerClient) error) error {
+	sc := fc.clients[idx]
+	if err := fc.verify(idx); err != nil {
+		fc.logger.Error("Signer failed, dropping its connection", "signer", idx, "err", err)
+		sc.endpoint.triggerReconnect()
+		return err
+	}
+	if fc.quarantined {
+		// The public key of the quarantined signer was checked again since it
+		// reconnected: it knows whether it served the failed request.
+		fc.logger.Info("Signer reconnected, lifting its quarantine", "signer", idx)
+		fc.active = idx
+		fc.quarantined = false
+		fc.quarantine = nil
+	}
+
+	key := sc.endpoint.remoteKey()
+	err := request(sc)
+	if err == nil {
+		if idx != fc.active {
+			fc.logger.Info("Failed over to another signer", "signer", idx)
+			fc.active = idx
+		}
+		return nil
+	}
+	var remoteErr *RemoteSignerError
+	if errors.As(err, &remoteErr) {
+		return err
+	}
+
+	// Drop the connection, so that the request is not served by this signer
+	// after being sent to another one. A signer closing the connection without
+	// responding did not sign, but one timing out may have.
+	fc.logger.Error("Signer failed, dropping its connection", "signer", idx, "err", err)
+	sc.endpoint.triggerReconnect()
+	if sign && (errors.Is(err, ErrReadTimeout) || errors.Is(err, ErrWriteTimeout)) {
+		fc.logger.Error("Sign request may have been served, quarantining the signer", "signer", idx)
+		fc.active = idx
+		fc.quarantined = true
+		fc.quarantine = key
+	}
+	return err
+}
+
+// verify checks the public key of the signer of the client at idx if it was not
+// checked since it connected. The caller must hold the lock.
+func (fc *FailoverSignerClient) verify(idx int) error {
+	sc := fc.clients[idx]
+	if fc.verified[idx] != 0 && fc.verified[idx] == sc.endpoint.connectionID() {
+		return nil
+	}
+
+	pubKey, err := sc.GetPubKey()
+	if err != nil {
+		return err
+	}
+	if fc.pubKey == nil {
+		fc.pubKey = pubKey
+	} else if !pubKeysEqual(pubKey, fc.pubKey) {
+		return ErrSignerPubKeyMismatch{Expected: fc.pubKey, Got: pubKey}
+	}
+	fc.verified[idx] = sc.endpoint.connectionID()
+	return nil
+}
@@ -0,0 +1,193 @@
+package privval
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	cmtnet "github.com/cometbft/cometbft/v2/internal/net"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// getFailoverTestCase returns a FailoverSignerClient with a signer per
+// PrivValidator, and the signer servers in the order of the clients.
+func getFailoverTestCase(t *testing.T, chainID string, pvs ...types.PrivValidator) (*FailoverSignerClient, []*SignerServer) {
+	t.Helper()
+	logger := log.TestingLogger()
+
+	addr := GetFreeLocalhostAddrPort()
+	ln, err := net.Listen(cmtnet.ProtocolAndAddress("tcp://" + addr))
+	require.NoError(t, err)
+	tcpLn := NewTCPListener(ln, ed25519.GenPrivKey())
+	TCPListenerTimeoutAccept(testTimeoutAccept)(tcpLn)
+	TCPListenerTimeoutReadWrite(testTimeoutReadWrite)(tcpLn)
+	shared := &sharedListener{Listener: tcpLn}
+
+	clients := make([]*SignerClient, len(pvs))
+	for i := range clients {
+		sl := NewSignerListenerEndpoint(logger, shared, SignerListenerEndpointTimeoutReadWrite(testTimeoutReadWrite))
+		require.NoError(t, sl.Start())
+		clients[i], err = NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+	}
+	fc, err := NewFailoverSignerClient(clients, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = fc.Close() })
+
+	// Connect the signers one at a time to find out which client each one is
+	// connected to.
+	servers := make([]*SignerServer, len(pvs))
+	for _, pv := range pvs {
+		sd := NewSignerDialerEndpoint(logger, DialTCPFn(addr, testTimeoutReadWrite, ed25519.GenPrivKey()))
+		SignerDialerEndpointTimeoutReadWrite(testTimeoutReadWrite)(sd)
+		SignerDialerEndpointConnRetries(1e6)(sd)
+		require.NoError(t, sd.Start())
+		ss := NewSignerServer(sd, chainID, pv)
+		require.NoError(t, ss.Start())
+		t.Cleanup(func() { _ = ss.Stop() })
+
+		require.Eventually(t, func() bool {
+			for i, sc := range clients {
+				if servers[i] == nil && sc.endpoint.hasConnection() {
+					servers[i] = ss
+					return true
+				}
+			}
+			return false
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	return fc, servers
+}
+
+func TestFailoverSignerClientFailover(t *testing.T) {
+	chainID := cmtrand.Str(12)
+	mockPV := types.NewMockPV()
+	fc, servers := getFailoverTestCase(t, chainID, mockPV, mockPV)
+
+	pubKey, err := fc.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, mockPV.PrivKey.PubKey(), pubKey)
+
+	vote := testVote(1)
+	require.NoError(t, fc.SignVote(chainID, vote.ToProto(), false))
+	assert.Equal(t, 0, fc.active)
+
+	// The active signer goes away: the request is sent to the other one.
+	require.NoError(t, servers[0].Stop())
+	vote = testVote(2)
+	require.NoError(t, fc.SignVote(chainID, vote.ToProto(), false))
+	assert.Equal(t, 1, fc.active)
+	assert.True(t, fc.IsConnected())
+}
+
+func TestFailoverSignerClientPubKeyMismatch(t *testing.T) {
+	chainID := cmtrand.Str(12)
+	fc, servers := getFailoverTestCase(t, chainID, types.NewMockPV(), types.NewMockPV())
+
+	_, err := fc.GetPubKey()
+	require.NoError(t, err)
+
+	// The other signer holds another key: it is not used.
+	require.NoError(t, servers[0].Stop())
+	vote := testVote(1)
+	err = fc.SignVote(chainID, vote.ToProto(), false)
+	require.ErrorAs(t, err, &ErrSignerPubKeyMismatch{})
+	assert.Equal(t, 0, fc.active)
+}
+
+func TestFailoverSignerClientRemoteSignerError(t *testing.T) {
+	chainID := cmtrand.Str(12)
+	erroringPV := types.NewErroringMockPV()
+	fc, servers := getFailoverTestCase(t, chainID, erroringPV, erroringPV)
+
+	// A signer refusing to sign is not failed over.
+	vote := testVote(1)
+	err := fc.SignVote(chainID, vote.ToProto(), false)
+	var remoteErr *RemoteSignerError
+	require.ErrorAs(t, err, &remoteErr)
+	assert.Equal(t, 0, fc.active)
+	assert.True(t, servers[0].endpoint.IsConnected())
+}
+
+// slowPV is a PrivValidator which takes too long to sign its first vote after
+// being made slow, and counts the votes it signs.
+type slowPV struct {
+	types.PrivValidator
+	slow   atomic.Bool
+	signed atomic.Int64
+}
+
+func (pv *slowPV) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	if pv.slow.Swap(false) {
+		time.Sleep(3 * testTimeoutReadWrite)
+	}
+	pv.signed.Add(1)
+	return pv.PrivValidator.SignVote(chainID, vote, signExtension)
+}
+
+func TestFailoverSignerClientQuarantine(t *testing.T) {
+	chainID := cmtrand.Str(12)
+	mockPV := types.NewMockPV()
+	fc, servers := getFailoverTestCase(t, chainID, &slowPV{PrivValidator: mockPV}, &slowPV{PrivValidator: mockPV})
+	pvs := []*slowPV{servers[0].privVal.(*slowPV), servers[1].privVal.(*slowPV)}
+
+	_, err := fc.GetPubKey()
+	require.NoError(t, err)
+
+	// The active signer times out, but may have signed: the request is not
+	// failed over.
+	pvs[0].slow.Store(true)
+	vote := testVote(1)
+	require.Error(t, fc.SignVote(chainID, vote.ToProto(), false))
+	assert.Equal(t, 0, fc.active)
+	assert.True(t, fc.quarantined)
+
+	// Once it reconnects, it is used again.
+	vote = testVote(2)
+	require.ErrorIs(t, fc.SignVote(chainID, vote.ToProto(), false), ErrSignerQuarantined)
+	require.Eventually(t, func() bool {
+		return fc.SignVote(chainID, vote.ToProto(), false) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, fc.active)
+	assert.False(t, fc.quarantined)
+	assert.EqualValues(t, 0, pvs[1].signed.Load())
+
+	// It times out again and goes away: the requests fail until the lease is
+	// handed over to the other signer.
+	pvs[0].slow.Store(true)
+	vote = testVote(3)
+	require.Error(t, fc.SignVote(chainID, vote.ToProto(), false))
+	require.NoError(t, servers[0].Stop())
+	vote = testVote(4)
+	require.ErrorIs(t, fc.SignVote(chainID, vote.ToProto(), false), ErrSignerQuarantined)
+	assert.EqualValues(t, 0, pvs[1].signed.Load())
+
+	other := 0
+	for !fc.clients[other].endpoint.hasConnection() {
+		other++
+	}
+	require.NoError(t, fc.HandOver(other))
+	require.NoError(t, fc.SignVote(chainID, vote.ToProto(), false))
+	assert.EqualValues(t, 1, pvs[1].signed.Load())
+	require.Error(t, fc.HandOver(2))
+}
+
+func testVote(height int64) *types.Vote {
+	return &types.Vote{
+		Type:             types.PrecommitType,
+		Height:           height,
+		Round:            0,
+		BlockID:          types.BlockID{Hash: cmtrand.Bytes(32), PartSetHeader: types.PartSetHeader{Hash: cmtrand.Bytes(32), Total: 1}},
+		ValidatorAddress: cmtrand.Bytes(20),
+		ValidatorIndex:   0,
+	}
+}
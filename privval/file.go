@@ -11,6 +11,7 @@ import (
 
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtos "github.com/cometbft/cometbft/v2/internal/os"
 	"github.com/cometbft/cometbft/v2/internal/tempfile"
@@ -43,13 +44,25 @@ func voteToStep(vote *cmtproto.Vote) int8 {
 
 // -------------------------------------------------------------------------------
 
+// FilePVKeyBlockType is the ASCII armor block type of encrypted FilePVKey
+// files.
+const FilePVKeyBlockType = "COMETBFT PRIVATE VALIDATOR KEY"
+
 // FilePVKey stores the immutable part of PrivValidator.
 type FilePVKey struct {
 	Address types.Address  `json:"address"`
 	PubKey  crypto.PubKey  `json:"pub_key"`
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
-	filePath string
+	filePath   string
+	passphrase []byte
+}
+
+// SetPassphrase sets the passphrase the FilePVKey is encrypted with when
+// saved. A nil passphrase saves it as plaintext JSON. A FilePVKey loaded from
+// an encrypted file keeps the passphrase it was decrypted with.
+func (pvKey *FilePVKey) SetPassphrase(passphrase []byte) {
+	pvKey.passphrase = passphrase
 }
 
 // Save persists the FilePVKey to its filePath.
@@ -59,16 +72,33 @@ func (pvKey FilePVKey) Save() {
 		panic("cannot save PrivValidator key: filePath not set")
 	}
 
-	jsonBytes, err := cmtjson.MarshalIndent(pvKey, "", "  ")
+	bz, err := pvKey.MarshalFile()
 	if err != nil {
 		panic(err)
 	}
 
-	if err := tempfile.WriteFileAtomic(outFile, jsonBytes, 0o600); err != nil {
+	if err := tempfile.WriteFileAtomic(outFile, bz, 0o600); err != nil {
 		panic(err)
 	}
 }
 
+// MarshalFile returns the contents of the key file of the FilePVKey: JSON,
+// encrypted into ASCII armor if a passphrase is set.
+func (pvKey FilePVKey) MarshalFile() ([]byte, error) {
+	jsonBytes, err := cmtjson.MarshalIndent(pvKey, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if pvKey.passphrase == nil {
+		return jsonBytes, nil
+	}
+	armorStr, err := armor.EncryptArmor(FilePVKeyBlockType, jsonBytes, pvKey.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(armorStr), nil
+}
+
 // -------------------------------------------------------------------------------
 
 // FilePVLastSignState stores the mutable part of PrivValidator.
@@ -198,35 +228,85 @@ func GenFilePV(keyFilePath, stateFilePath string, keyGen func() (crypto.PrivKey,
 	return NewFilePV(key, keyFilePath, stateFilePath), nil
 }
 
+// LoadOption sets an optional parameter on the loading of a FilePV.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	passphrase func() ([]byte, error)
+}
+
+// WithKeyPassphrase sets the function returning the passphrase of an encrypted
+// key file. It is only called if the key file is encrypted, so that e.g. the
+// user is only prompted for the passphrase when needed. The key file stays
+// encrypted with it when saved.
+func WithKeyPassphrase(passphrase func() ([]byte, error)) LoadOption {
+	return func(o *loadOptions) { o.passphrase = passphrase }
+}
+
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
-func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+func LoadFilePV(keyFilePath, stateFilePath string, options ...LoadOption) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, options)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
-func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+func LoadFilePVEmptyState(keyFilePath, stateFilePath string, options ...LoadOption) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, options)
 }
 
-// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
-	keyJSONBytes, err := os.ReadFile(keyFilePath)
+// LoadFilePVKey loads a FilePVKey from keyFilePath, decrypting it if it is
+// encrypted. It returns ErrKeyFileEncrypted if it is encrypted and no
+// passphrase is given with WithKeyPassphrase.
+func LoadFilePVKey(keyFilePath string, options ...LoadOption) (FilePVKey, error) {
+	var opts loadOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	keyBytes, err := os.ReadFile(keyFilePath)
 	if err != nil {
-		cmtos.Exit(err.Error())
+		return FilePVKey{}, err
+	}
+	var passphrase []byte
+	if armor.IsArmored(keyBytes) {
+		if opts.passphrase == nil {
+			return FilePVKey{}, ErrKeyFileEncrypted
+		}
+		if passphrase, err = opts.passphrase(); err != nil {
+			return FilePVKey{}, fmt.Errorf("reading passphrase: %w", err)
+		}
+		blockType, data, err := armor.DecryptArmor(string(keyBytes), passphrase)
+		if err != nil {
+			return FilePVKey{}, err
+		}
+		if blockType != FilePVKeyBlockType {
+			return FilePVKey{}, fmt.Errorf("unexpected block type %q", blockType)
+		}
+		keyBytes = data
 	}
+
 	pvKey := FilePVKey{}
-	err = cmtjson.Unmarshal(keyJSONBytes, &pvKey)
-	if err != nil {
-		cmtos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
+	if err := cmtjson.Unmarshal(keyBytes, &pvKey); err != nil {
+		return FilePVKey{}, err
 	}
 
 	// overwrite pubkey and address for convenience
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
 	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
+	pvKey.passphrase = passphrase
+
+	return pvKey, nil
+}
+
+// If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, options []LoadOption) *FilePV {
+	pvKey, err := LoadFilePVKey(keyFilePath, options...)
+	if err != nil {
+		cmtos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
+	}
 
 	pvState := FilePVLastSignState{}
 
@@ -249,12 +329,34 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	}
 }
 
+// NewFilePVFromStateFile returns a FilePV signing with privKey, which is not
+// persisted, and preventing double signing by persisting data to the
+// stateFilePath. The state is loaded from stateFilePath if it exists, and saved
+// to it otherwise. It allows keys held outside of the file system, e.g. in an
+// HSM, to be used with the same protection as FilePV.
+func NewFilePVFromStateFile(privKey crypto.PrivKey, stateFilePath string) (*FilePV, error) {
+	pv := NewFilePV(privKey, "", stateFilePath)
+	if !cmtos.FileExists(stateFilePath) {
+		pv.LastSignState.Save()
+		return pv, nil
+	}
+
+	stateJSONBytes, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := cmtjson.Unmarshal(stateJSONBytes, &pv.LastSignState); err != nil {
+		return nil, fmt.Errorf("error reading PrivValidator state from %v: %w", stateFilePath, err)
+	}
+	return pv, nil
+}
+
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
-func LoadOrGenFilePV(keyFilePath, stateFilePath string, keyGenF func() (crypto.PrivKey, error)) (*FilePV, error) {
+func LoadOrGenFilePV(keyFilePath, stateFilePath string, keyGenF func() (crypto.PrivKey, error), options ...LoadOption) (*FilePV, error) {
 	var pv *FilePV
 	if cmtos.FileExists(keyFilePath) {
-		pv = LoadFilePV(keyFilePath, stateFilePath)
+		pv = LoadFilePV(keyFilePath, stateFilePath, options...)
 	} else {
 		var err error
 		pv, err = GenFilePV(keyFilePath, stateFilePath, keyGenF)
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	kt "github.com/cometbft/cometbft/v2/internal/keytypes"
@@ -102,6 +104,32 @@ func TestLoadOrGenValidator(t *testing.T) {
 	}
 }
 
+func TestNewFilePVFromStateFile(t *testing.T) {
+	stateFilePath := filepath.Join(t.TempDir(), "priv_validator_state.json")
+	privKey := ed25519.GenPrivKey()
+
+	// The state file is created if it does not exist.
+	privVal, err := NewFilePVFromStateFile(privKey, stateFilePath)
+	require.NoError(t, err)
+	require.FileExists(t, stateFilePath)
+	assert.Equal(t, privKey.PubKey().Address(), privVal.GetAddress())
+
+	blockID := types.BlockID{Hash: cmtrand.Bytes(tmhash.Size)}
+	vote := newVote(privVal.Key.Address, 10, 1, types.PrevoteType, blockID)
+	require.NoError(t, privVal.SignVote("mychainid", vote.ToProto(), false))
+
+	// The state is loaded if it exists, and prevents double signing.
+	privVal, err = NewFilePVFromStateFile(privKey, stateFilePath)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, privVal.LastSignState.Height)
+	vote = newVote(privVal.Key.Address, 10, 1, types.PrevoteType, types.BlockID{Hash: cmtrand.Bytes(tmhash.Size)})
+	require.Error(t, privVal.SignVote("mychainid", vote.ToProto(), false))
+
+	require.NoError(t, os.WriteFile(stateFilePath, []byte("{"), 0o600))
+	_, err = NewFilePVFromStateFile(privKey, stateFilePath)
+	require.Error(t, err)
+}
+
 func TestUnmarshalValidatorState(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
@@ -527,3 +555,37 @@ func newTestFilePV(t *testing.T, keyGenF func() (crypto.PrivKey, error)) (*FileP
 
 	return privVal, tempKeyFile.Name(), tempStateFile.Name()
 }
+
+func TestEncryptedFilePVKey(t *testing.T) {
+	privVal, keyFilePath, stateFilePath := newTestFilePV(t, nil)
+	privVal.Key.SetPassphrase([]byte("passphrase"))
+	privVal.Save()
+
+	keyBytes, err := os.ReadFile(keyFilePath)
+	require.NoError(t, err)
+	assert.True(t, armor.IsArmored(keyBytes))
+
+	_, err = LoadFilePVKey(keyFilePath)
+	require.ErrorIs(t, err, ErrKeyFileEncrypted)
+	_, err = LoadFilePVKey(keyFilePath, WithKeyPassphrase(func() ([]byte, error) { return []byte("wrong"), nil }))
+	require.ErrorIs(t, err, armor.ErrWrongPassphrase)
+
+	passphrase := func() ([]byte, error) { return []byte("passphrase"), nil }
+	loaded := LoadFilePV(keyFilePath, stateFilePath, WithKeyPassphrase(passphrase))
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+
+	// The key stays encrypted when saved again.
+	loaded.Reset()
+	keyBytes, err = os.ReadFile(keyFilePath)
+	require.NoError(t, err)
+	assert.True(t, armor.IsArmored(keyBytes))
+
+	// The passphrase is not needed for plaintext key files.
+	loaded.Key.SetPassphrase(nil)
+	loaded.Save()
+	_, err = LoadFilePVKey(keyFilePath, WithKeyPassphrase(func() ([]byte, error) {
+		t.Fatal("passphrase requested for a plaintext key file")
+		return nil, nil
+	}))
+	require.NoError(t, err)
+}
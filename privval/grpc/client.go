@@ -0,0 +1,183 @@
+// Package grpc implements the gRPC transport of the remote signer protocol, as
+// an alternative to the socket protocol of the privval package: a remote
+// signer serves the PrivValidatorAPI with a SignerServer, and the node uses it
+// with a SignerClient, over a mutually authenticated TLS connection.
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto"
+	cryptoenc "github.com/cometbft/cometbft/v2/crypto/encoding"
+	"github.com/cometbft/cometbft/v2/privval"
+	"github.com/cometbft/cometbft/v2/types"
+	cmterrors "github.com/cometbft/cometbft/v2/types/errors"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// ClientOption sets an optional parameter on the SignerClient.
+type ClientOption func(*SignerClient)
+
+// WithTimeout sets the timeout of each request to the remote signer.
+//
+// Default: 5s.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(sc *SignerClient) { sc.timeout = timeout }
+}
+
+// SignerClient implements PrivValidator by sending requests to a remote
+// signer serving the PrivValidatorAPI.
+type SignerClient struct {
+	conn    *ggrpc.ClientConn
+	client  pvproto.PrivValidatorAPIClient
+	chainID string
+	timeout time.Duration
+}
+
+var (
+	_ types.PrivValidator        = (*SignerClient)(nil)
+	_ privval.RemoteSignerClient = (*SignerClient)(nil)
+)
+
+// NewSignerClient returns a SignerClient sending requests for chainID over
+// conn.
+func NewSignerClient(conn *ggrpc.ClientConn, chainID string, options ...ClientOption) *SignerClient {
+	sc := &SignerClient{
+		conn:    conn,
+		client:  pvproto.NewPrivValidatorAPIClient(conn),
+		chainID: chainID,
+		timeout: defaultTimeout,
+	}
+	for _, option := range options {
+		option(sc)
+	}
+	return sc
+}
+
+// DialSignerClient returns a SignerClient connecting to the remote signer at
+// addr with the given TLS configuration, see ClientTLSConfig. The connection
+// is established lazily, and re-established whenever it fails.
+func DialSignerClient(addr string, tlsConfig *tls.Config, chainID string, options ...ClientOption) (*SignerClient, error) {
+	conn, err := ggrpc.NewClient(addr, ggrpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("dialing remote signer %s: %w", addr, err)
+	}
+	return NewSignerClient(conn, chainID, options...), nil
+}
+
+// Close closes the connection to the remote signer.
+func (sc *SignerClient) Close() error {
+	return sc.conn.Close()
+}
+
+// IsConnected indicates whether the connection to the remote signer is ready.
+func (sc *SignerClient) IsConnected() bool {
+	return sc.conn.GetState() == connectivity.Ready
+}
+
+// WaitForConnection waits maxWait for the connection to the remote signer to
+// be ready or returns a timeout error.
+func (sc *SignerClient) WaitForConnection(maxWait time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), maxWait)
+	defer cancel()
+
+	sc.conn.Connect()
+	for state := sc.conn.GetState(); state != connectivity.Ready; state = sc.conn.GetState() {
+		if !sc.conn.WaitForStateChange(ctx, state) {
+			return privval.ErrConnectionTimeout
+		}
+	}
+	return nil
+}
+
+// Ping checks the remote signer is reachable. As the PrivValidatorAPI has no
+// ping request, it requests the public key.
+func (sc *SignerClient) Ping() error {
+	_, err := sc.GetPubKey()
+	return err
+}
+
+// GetPubKey retrieves a public key from the remote signer.
+func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.GetPubKey(ctx, &pvproto.PubKeyRequest{ChainId: sc.chainID})
+	if err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, remoteSignerError(resp.Error)
+	}
+
+	return cryptoenc.PubKeyFromTypeAndBytes(resp.PubKeyType, resp.PubKeyBytes)
+}
+
+// SignVote requests the remote signer to sign a vote.
+func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignVote(ctx, &pvproto.SignVoteRequest{Vote: vote, ChainId: chainID, SkipExtensionSigning: !signExtension})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return remoteSignerError(resp.Error)
+	}
+
+	*vote = resp.Vote
+
+	return nil
+}
+
+// SignProposal requests the remote signer to sign a proposal.
+func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignProposal(ctx, &pvproto.SignProposalRequest{Proposal: proposal, ChainId: chainID})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return remoteSignerError(resp.Error)
+	}
+
+	*proposal = resp.Proposal
+
+	return nil
+}
+
+// SignBytes requests the remote signer to sign bytes.
+func (sc *SignerClient) SignBytes(signBytes []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignBytes(ctx, &pvproto.SignBytesRequest{Value: signBytes})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, remoteSignerError(resp.Error)
+	}
+	if resp.Signature == nil {
+		return nil, cmterrors.ErrRequiredField{Field: "signature"}
+	}
+
+	return resp.Signature, nil
+}
+
+func remoteSignerError(err *pvproto.RemoteSignerError) *privval.RemoteSignerError {
+	return &privval.RemoteSignerError{Code: int(err.Code), Description: err.Description}
+}
@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/privval"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// writeCert writes a certificate for template, signed by parent and parentKey,
+// or self-signed if parent is nil, and its key to dir.
+func writeCert(t *testing.T, dir, name string, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (certFile, keyFile string, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile, cert, key
+}
+
+func certTemplate(serial int64, name string, isCA bool) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	return template
+}
+
+// newTestTLSConfigs returns the TLS configurations of a remote signer, of a
+// node, and of a node with a certificate signed by another CA.
+func newTestTLSConfigs(t *testing.T) (server, client, otherClient *tls.Config) {
+	t.Helper()
+	dir := t.TempDir()
+
+	caFile, _, ca, caKey := writeCert(t, dir, "ca", certTemplate(1, "ca", true), nil, nil)
+	serverCert, serverKey, _, _ := writeCert(t, dir, "server", certTemplate(2, "signer", false), ca, caKey)
+	clientCert, clientKey, _, _ := writeCert(t, dir, "client", certTemplate(3, "node", false), ca, caKey)
+	_, _, otherCA, otherCAKey := writeCert(t, dir, "other-ca", certTemplate(4, "other-ca", true), nil, nil)
+	otherCert, otherKey, _, _ := writeCert(t, dir, "other", certTemplate(5, "node", false), otherCA, otherCAKey)
+
+	server, err := ServerTLSConfig(serverCert, serverKey, caFile)
+	require.NoError(t, err)
+	client, err = ClientTLSConfig(clientCert, clientKey, caFile)
+	require.NoError(t, err)
+	otherClient, err = ClientTLSConfig(otherCert, otherKey, caFile)
+	require.NoError(t, err)
+	return server, client, otherClient
+}
+
+func startTestServer(t *testing.T, chainID string, pv types.PrivValidator, tlsConfig *tls.Config) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := NewServer(NewSignerServer(chainID, pv, log.TestingLogger()), tlsConfig)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+	return ln.Addr().String()
+}
+
+func newTestSignerClient(t *testing.T, addr string, tlsConfig *tls.Config, chainID string) *SignerClient {
+	t.Helper()
+	sc, err := DialSignerClient(addr, tlsConfig, chainID, WithTimeout(time.Second))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sc.Close() })
+	return sc
+}
+
+func testVote() *types.Vote {
+	hash := cmtrand.Bytes(tmhash.Size)
+	return &types.Vote{
+		Type:             types.PrecommitType,
+		Height:           1,
+		Round:            2,
+		BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+		Timestamp:        cmttime.Now(),
+		ValidatorAddress: cmtrand.Bytes(20),
+		ValidatorIndex:   1,
+	}
+}
+
+func TestSignerClient(t *testing.T) {
+	serverTLS, clientTLS, _ := newTestTLSConfigs(t)
+	chainID := cmtrand.Str(12)
+	mockPV := types.NewMockPV()
+	sc := newTestSignerClient(t, startTestServer(t, chainID, mockPV, serverTLS), clientTLS, chainID)
+
+	require.NoError(t, sc.WaitForConnection(5*time.Second))
+	assert.True(t, sc.IsConnected())
+	require.NoError(t, sc.Ping())
+
+	pubKey, err := sc.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, mockPV.PrivKey.PubKey(), pubKey)
+
+	vote := testVote().ToProto()
+	require.NoError(t, sc.SignVote(chainID, vote, false))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+
+	proposal := types.NewProposal(1, 2, 2, types.BlockID{Hash: cmtrand.Bytes(tmhash.Size)}, cmttime.Now()).ToProto()
+	require.NoError(t, sc.SignProposal(chainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	sig, err := sc.SignBytes([]byte("hello"))
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifySignature([]byte("hello"), sig))
+}
+
+func TestSignerClientErrors(t *testing.T) {
+	serverTLS, clientTLS, _ := newTestTLSConfigs(t)
+	chainID := cmtrand.Str(12)
+	addr := startTestServer(t, chainID, types.NewErroringMockPV(), serverTLS)
+
+	// A remote signer refusing to sign replies with a RemoteSignerError.
+	sc := newTestSignerClient(t, addr, clientTLS, chainID)
+	err := sc.SignVote(chainID, testVote().ToProto(), false)
+	var remoteErr *privval.RemoteSignerError
+	require.ErrorAs(t, err, &remoteErr)
+
+	// Requests for another chain are rejected.
+	other := newTestSignerClient(t, addr, clientTLS, "other-chain")
+	_, err = other.GetPubKey()
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = other.SignVote("other-chain", testVote().ToProto(), false)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSignerClientMutualTLS(t *testing.T) {
+	serverTLS, clientTLS, otherClientTLS := newTestTLSConfigs(t)
+	chainID := cmtrand.Str(12)
+	addr := startTestServer(t, chainID, types.NewMockPV(), serverTLS)
+
+	// The remote signer rejects nodes with a certificate signed by another CA.
+	sc := newTestSignerClient(t, addr, otherClientTLS, chainID)
+	_, err := sc.GetPubKey()
+	require.Error(t, err)
+	assert.False(t, sc.IsConnected())
+
+	// The node rejects remote signers with a certificate signed by another CA.
+	untrustingClientTLS := clientTLS.Clone()
+	untrustingClientTLS.RootCAs = x509.NewCertPool()
+	sc = newTestSignerClient(t, addr, untrustingClientTLS, chainID)
+	_, err = sc.GetPubKey()
+	require.Error(t, err)
+}
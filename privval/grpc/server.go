@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/privval"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// SignerServer serves the PrivValidatorAPI with a PrivValidator. Requests are
+// validated and handled as by the SignerServer of the socket protocol.
+type SignerServer struct {
+	chainID string
+	privVal types.PrivValidator
+	logger  log.Logger
+}
+
+var _ pvproto.PrivValidatorAPIServer = (*SignerServer)(nil)
+
+// NewSignerServer returns a SignerServer signing for chainID with privVal.
+func NewSignerServer(chainID string, privVal types.PrivValidator, logger log.Logger) *SignerServer {
+	return &SignerServer{
+		chainID: chainID,
+		privVal: privVal,
+		logger:  logger,
+	}
+}
+
+// NewServer returns a gRPC server serving the PrivValidatorAPI with ss, with
+// the given TLS configuration, see ServerTLSConfig.
+func NewServer(ss *SignerServer, tlsConfig *tls.Config, options ...ggrpc.ServerOption) *ggrpc.Server {
+	options = append(options, ggrpc.Creds(credentials.NewTLS(tlsConfig)))
+	server := ggrpc.NewServer(options...)
+	pvproto.RegisterPrivValidatorAPIServer(server, ss)
+	return server
+}
+
+// Serve serves the PrivValidatorAPI with ss on listener until it fails.
+func Serve(listener net.Listener, ss *SignerServer, tlsConfig *tls.Config) error {
+	return NewServer(ss, tlsConfig).Serve(listener)
+}
+
+// GetPubKey implements pvproto.PrivValidatorAPIServer.
+func (ss *SignerServer) GetPubKey(_ context.Context, req *pvproto.PubKeyRequest) (*pvproto.PubKeyResponse, error) {
+	if err := ss.checkChainID(req.ChainId); err != nil {
+		return nil, err
+	}
+	res := ss.handle(pvproto.Message{Sum: &pvproto.Message_PubKeyRequest{PubKeyRequest: req}})
+	return res.GetPubKeyResponse(), nil
+}
+
+// SignVote implements pvproto.PrivValidatorAPIServer.
+func (ss *SignerServer) SignVote(_ context.Context, req *pvproto.SignVoteRequest) (*pvproto.SignedVoteResponse, error) {
+	if err := ss.checkChainID(req.ChainId); err != nil {
+		return nil, err
+	}
+	if req.Vote == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing vote")
+	}
+	res := ss.handle(pvproto.Message{Sum: &pvproto.Message_SignVoteRequest{SignVoteRequest: req}})
+	return res.GetSignedVoteResponse(), nil
+}
+
+// SignProposal implements pvproto.PrivValidatorAPIServer.
+func (ss *SignerServer) SignProposal(_ context.Context, req *pvproto.SignProposalRequest) (*pvproto.SignedProposalResponse, error) {
+	if err := ss.checkChainID(req.ChainId); err != nil {
+		return nil, err
+	}
+	if req.Proposal == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing proposal")
+	}
+	res := ss.handle(pvproto.Message{Sum: &pvproto.Message_SignProposalRequest{SignProposalRequest: req}})
+	return res.GetSignedProposalResponse(), nil
+}
+
+// SignBytes implements pvproto.PrivValidatorAPIServer.
+func (ss *SignerServer) SignBytes(_ context.Context, req *pvproto.SignBytesRequest) (*pvproto.SignBytesResponse, error) {
+	res := ss.handle(pvproto.Message{Sum: &pvproto.Message_SignBytesRequest{SignBytesRequest: req}})
+	return res.GetSignBytesResponse(), nil
+}
+
+func (ss *SignerServer) checkChainID(chainID string) error {
+	if chainID != ss.chainID {
+		ss.logger.Error("SignerServer: Rejected request for another chain", "chainID", chainID)
+		return status.Errorf(codes.InvalidArgument, "want chainID: %s, got chainID: %s", ss.chainID, chainID)
+	}
+	return nil
+}
+
+// handle handles a request with privval.DefaultValidationRequestHandler. As
+// with the socket protocol, signing errors are only logged, and replied with
+// in the response.
+func (ss *SignerServer) handle(req pvproto.Message) pvproto.Message {
+	res, err := privval.DefaultValidationRequestHandler(ss.privVal, req, ss.chainID)
+	if err != nil {
+		ss.logger.Error("SignerServer: handleMessage", "err", err)
+	}
+	return res
+}
@@ -0,0 +1,30 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package privval
+
+import (
+	"github.com/cometbft/cometbft/v2/libs/metrics/discard"
+	prometheus "github.com/cometbft/cometbft/v2/libs/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RejectedSignerConnections: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_signer_connections",
+			Help:      "Number of connections from external signing processes rejected because their public key is not allowed.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RejectedSignerConnections: discard.NewCounter(),
+	}
+}
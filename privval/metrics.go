@@ -0,0 +1,20 @@
+package privval
+
+import (
+	"github.com/cometbft/cometbft/v2/libs/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+//go:generate go run ../scripts/metricsgen -struct=Metrics
+
+// Metrics contains the prometheus metrics exposed by the privval package.
+type Metrics struct {
+	// Number of connections from external signing processes rejected because
+	// their public key is not allowed.
+	RejectedSignerConnections metrics.Counter
+}
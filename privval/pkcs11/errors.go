@@ -0,0 +1,33 @@
+package pkcs11
+
+import "fmt"
+
+// ErrTokenNotFound is returned when no token with the configured label is
+// present.
+type ErrTokenNotFound struct {
+	Label string
+}
+
+func (e ErrTokenNotFound) Error() string {
+	return fmt.Sprintf("no PKCS#11 token labelled %q", e.Label)
+}
+
+// ErrKeyNotFound is returned when the token holds no key pair with the
+// configured label.
+type ErrKeyNotFound struct {
+	Label string
+}
+
+func (e ErrKeyNotFound) Error() string {
+	return fmt.Sprintf("no PKCS#11 key pair labelled %q", e.Label)
+}
+
+// ErrUnsupportedKey is returned when the key is neither an ed25519 nor a
+// secp256k1 key.
+type ErrUnsupportedKey struct {
+	Label string
+}
+
+func (e ErrUnsupportedKey) Error() string {
+	return fmt.Sprintf("PKCS#11 key %q is neither an ed25519 nor a secp256k1 key", e.Label)
+}
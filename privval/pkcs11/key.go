@@ -0,0 +1,18 @@
+//go:build !pkcs11
+
+package pkcs11
+
+import "errors"
+
+const (
+	// Enabled indicates if the PKCS#11 support is enabled.
+	Enabled = false
+)
+
+// ErrDisabled is returned if the caller didn't use the `pkcs11` build tag.
+var ErrDisabled = errors.New("pkcs11 is disabled")
+
+// openKey returns ErrDisabled.
+func openKey(Config) (key, error) {
+	return nil, ErrDisabled
+}
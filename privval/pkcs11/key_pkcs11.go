@@ -0,0 +1,260 @@
+//go:build pkcs11
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	p11 "github.com/miekg/pkcs11"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	cmtsecp256k1 "github.com/cometbft/cometbft/v2/crypto/secp256k1"
+)
+
+const (
+	// Enabled indicates if the PKCS#11 support is enabled.
+	Enabled = true
+)
+
+// CKM_EDDSA, which is missing from the PKCS#11 headers of
+// github.com/miekg/pkcs11.
+const ckmEdDSA = 0x1057
+
+// DER encodings of the CKA_EC_PARAMS of the supported curves. Ed25519 curves
+// are identified either by their OID or by their name.
+var (
+	secp256k1Params      = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+	ed25519Params        = []byte{0x06, 0x03, 0x2b, 0x65, 0x70}
+	ed25519NameParams, _ = asn1.MarshalWithParams("edwards25519", "printable")
+)
+
+// privKey is a key held by a PKCS#11 token, used through a session opened for
+// its lifetime. PKCS#11 sessions are not safe for concurrent use.
+type privKey struct {
+	mtx       sync.Mutex
+	ctx       *p11.Ctx
+	finalize  bool
+	session   p11.SessionHandle
+	handle    p11.ObjectHandle
+	mechanism uint
+	pubKey    crypto.PubKey
+}
+
+var _ crypto.PrivKey = (*privKey)(nil)
+
+// openKey loads the PKCS#11 module of cfg, logs into its token, and finds the
+// key pair of cfg.
+func openKey(cfg Config) (key, error) {
+	ctx := p11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", cfg.Module)
+	}
+	k := &privKey{ctx: ctx, finalize: true}
+	if err := ctx.Initialize(); err != nil {
+		// The module may already be used by another part of the process, which
+		// is then responsible for finalizing it.
+		if !errors.Is(err, p11.Error(p11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+			ctx.Destroy()
+			return nil, fmt.Errorf("initializing PKCS#11 module: %w", err)
+		}
+		k.finalize = false
+	}
+
+	if err := k.open(cfg); err != nil {
+		_ = k.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+func (k *privKey) open(cfg Config) error {
+	slot, err := findSlot(k.ctx, cfg.TokenLabel)
+	if err != nil {
+		return err
+	}
+	k.session, err = k.ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("opening PKCS#11 session: %w", err)
+	}
+	err = k.ctx.Login(k.session, p11.CKU_USER, cfg.PIN)
+	if err != nil && !errors.Is(err, p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN)) {
+		return fmt.Errorf("logging into PKCS#11 token: %w", err)
+	}
+
+	k.handle, err = k.findObject(p11.CKO_PRIVATE_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	// Private keys do not necessarily expose their public key: it is read
+	// from the public key object.
+	pub, err := k.findObject(p11.CKO_PUBLIC_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	attrs, err := k.ctx.GetAttributeValue(k.session, pub, []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_EC_PARAMS, nil),
+		p11.NewAttribute(p11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("reading PKCS#11 public key %q: %w", cfg.KeyLabel, err)
+	}
+	params, point := attrs[0].Value, decodePoint(attrs[1].Value)
+
+	switch {
+	case bytes.Equal(params, secp256k1Params):
+		pubKey, err := secp256k1.ParsePubKey(point)
+		if err != nil {
+			return fmt.Errorf("parsing PKCS#11 public key %q: %w", cfg.KeyLabel, err)
+		}
+		k.mechanism = p11.CKM_ECDSA
+		k.pubKey = cmtsecp256k1.PubKey(pubKey.SerializeCompressed())
+	case bytes.Equal(params, ed25519Params), bytes.Equal(params, ed25519NameParams):
+		if len(point) != ed25519.PubKeySize {
+			return fmt.Errorf("parsing PKCS#11 public key %q: invalid ed25519 public key size %d", cfg.KeyLabel, len(point))
+		}
+		k.mechanism = ckmEdDSA
+		k.pubKey = ed25519.PubKey(point)
+	default:
+		return ErrUnsupportedKey{Label: cfg.KeyLabel}
+	}
+	return nil
+}
+
+func findSlot(ctx *p11.Ctx, label string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("listing PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("reading PKCS#11 token info: %w", err)
+		}
+		// Token labels are padded with spaces.
+		if strings.TrimRight(info.Label, " \x00") == label {
+			return slot, nil
+		}
+	}
+	return 0, ErrTokenNotFound{Label: label}
+}
+
+func (k *privKey) findObject(class uint, label string) (p11.ObjectHandle, error) {
+	template := []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, class),
+		p11.NewAttribute(p11.CKA_LABEL, label),
+	}
+	if err := k.ctx.FindObjectsInit(k.session, template); err != nil {
+		return 0, fmt.Errorf("finding PKCS#11 key %q: %w", label, err)
+	}
+	objects, _, err := k.ctx.FindObjects(k.session, 2)
+	if finalErr := k.ctx.FindObjectsFinal(k.session); err == nil {
+		err = finalErr
+	}
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("finding PKCS#11 key %q: %w", label, err)
+	case len(objects) == 0:
+		return 0, ErrKeyNotFound{Label: label}
+	case len(objects) > 1:
+		return 0, fmt.Errorf("several PKCS#11 keys labelled %q", label)
+	}
+	return objects[0], nil
+}
+
+// decodePoint returns the content of the DER octet string of a CKA_EC_POINT.
+// Some modules omit the encoding, in which case the value is returned as is.
+func decodePoint(value []byte) []byte {
+	var point []byte
+	if rest, err := asn1.Unmarshal(value, &point); err != nil || len(rest) > 0 {
+		return value
+	}
+	return point
+}
+
+// Bytes returns nil, as the key never leaves the token.
+func (*privKey) Bytes() []byte {
+	return nil
+}
+
+// Sign signs msg with the token. As with secp256k1.PrivKey, secp256k1
+// signatures are over the SHA-256 hash of msg, in their 64 bytes R || S form
+// with a low S.
+func (k *privKey) Sign(msg []byte) ([]byte, error) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	if k.mechanism == p11.CKM_ECDSA {
+		sum := sha256.Sum256(msg)
+		msg = sum[:]
+	}
+	if err := k.ctx.SignInit(k.session, []*p11.Mechanism{p11.NewMechanism(k.mechanism, nil)}, k.handle); err != nil {
+		return nil, fmt.Errorf("signing with PKCS#11 token: %w", err)
+	}
+	sig, err := k.ctx.Sign(k.session, msg)
+	if err != nil {
+		return nil, fmt.Errorf("signing with PKCS#11 token: %w", err)
+	}
+
+	if k.mechanism == p11.CKM_ECDSA {
+		if len(sig) != 64 {
+			return nil, fmt.Errorf("invalid PKCS#11 ECDSA signature size %d", len(sig))
+		}
+		// Signatures with a high S are malleable, and rejected by
+		// secp256k1.PubKey.VerifySignature.
+		var s secp256k1.ModNScalar
+		s.SetByteSlice(sig[32:])
+		if s.IsOverHalfOrder() {
+			s.Negate().PutBytesUnchecked(sig[32:])
+		}
+	}
+	return sig, nil
+}
+
+// PubKey returns the public key of the key pair.
+func (k *privKey) PubKey() crypto.PubKey {
+	return k.pubKey
+}
+
+// Type returns the key's type.
+func (k *privKey) Type() string {
+	return k.pubKey.Type()
+}
+
+// Close logs out of the token and closes the session.
+func (k *privKey) Close() error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	var errs []error
+	if k.session != 0 {
+		// Logging out applies to all the sessions of the process: it is left
+		// to whoever initialized the module.
+		if k.finalize {
+			if err := k.ctx.Logout(k.session); err != nil && !errors.Is(err, p11.Error(p11.CKR_USER_NOT_LOGGED_IN)) {
+				errs = append(errs, err)
+			}
+		}
+		if err := k.ctx.CloseSession(k.session); err != nil {
+			errs = append(errs, err)
+		}
+		k.session = 0
+	}
+	if k.finalize {
+		if err := k.ctx.Finalize(); err != nil {
+			errs = append(errs, err)
+		}
+		// The module is only unloaded if initialized here: it may still be
+		// in use otherwise.
+		k.ctx.Destroy()
+		k.finalize = false
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,168 @@
+//go:build pkcs11
+
+package pkcs11
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// ckmECEdwardsKeyPairGen is CKM_EC_EDWARDS_KEY_PAIR_GEN.
+const ckmECEdwardsKeyPairGen = 0x1055
+
+// testConfig returns the configuration of a token of the module in
+// PKCS11_MODULE, e.g. SoftHSM, with the label in PKCS11_TOKEN_LABEL and the
+// user PIN in PKCS11_PIN, or skips the test if it is not set.
+func testConfig(t *testing.T) Config {
+	t.Helper()
+	cfg := Config{
+		Module:     os.Getenv("PKCS11_MODULE"),
+		TokenLabel: os.Getenv("PKCS11_TOKEN_LABEL"),
+		PIN:        os.Getenv("PKCS11_PIN"),
+	}
+	if cfg.Module == "" {
+		t.Skip("PKCS11_MODULE is not set")
+	}
+	return cfg
+}
+
+// generateKey generates a key pair with the given mechanism and curve
+// parameters in the token of cfg, and returns cfg with its label. The key pair
+// is destroyed at the end of the test.
+func generateKey(t *testing.T, cfg Config, mechanism uint, params []byte) Config {
+	t.Helper()
+	cfg.KeyLabel = "cometbft-test-" + cmtrand.Str(8)
+
+	withSession := func(f func(ctx *p11.Ctx, session p11.SessionHandle)) {
+		ctx := p11.New(cfg.Module)
+		require.NotNil(t, ctx)
+		defer ctx.Destroy()
+		require.NoError(t, ctx.Initialize())
+		defer func() { _ = ctx.Finalize() }()
+		slot, err := findSlot(ctx, cfg.TokenLabel)
+		require.NoError(t, err)
+		session, err := ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION)
+		require.NoError(t, err)
+		defer func() { _ = ctx.CloseSession(session) }()
+		require.NoError(t, ctx.Login(session, p11.CKU_USER, cfg.PIN))
+		defer func() { _ = ctx.Logout(session) }()
+		f(ctx, session)
+	}
+
+	withSession(func(ctx *p11.Ctx, session p11.SessionHandle) {
+		_, _, err := ctx.GenerateKeyPair(session,
+			[]*p11.Mechanism{p11.NewMechanism(mechanism, nil)},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_LABEL, cfg.KeyLabel),
+				p11.NewAttribute(p11.CKA_EC_PARAMS, params),
+				p11.NewAttribute(p11.CKA_VERIFY, true),
+			},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_LABEL, cfg.KeyLabel),
+				p11.NewAttribute(p11.CKA_PRIVATE, true),
+				p11.NewAttribute(p11.CKA_SENSITIVE, true),
+				p11.NewAttribute(p11.CKA_SIGN, true),
+			})
+		require.NoError(t, err)
+	})
+	t.Cleanup(func() {
+		withSession(func(ctx *p11.Ctx, session p11.SessionHandle) {
+			require.NoError(t, ctx.FindObjectsInit(session, []*p11.Attribute{p11.NewAttribute(p11.CKA_LABEL, cfg.KeyLabel)}))
+			objects, _, err := ctx.FindObjects(session, 2)
+			require.NoError(t, err)
+			require.NoError(t, ctx.FindObjectsFinal(session))
+			for _, object := range objects {
+				require.NoError(t, ctx.DestroyObject(session, object))
+			}
+		})
+	})
+	return cfg
+}
+
+func TestPrivValidator(t *testing.T) {
+	testCases := map[string]struct {
+		mechanism uint
+		params    []byte
+		keyType   string
+	}{
+		"ed25519":   {ckmECEdwardsKeyPairGen, ed25519Params, "ed25519"},
+		"secp256k1": {p11.CKM_EC_KEY_PAIR_GEN, secp256k1Params, "secp256k1"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := generateKey(t, testConfig(t), tc.mechanism, tc.params)
+			stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+			chainID := "test-chain"
+
+			pv, err := NewPrivValidator(cfg, stateFile)
+			require.NoError(t, err)
+			pubKey, err := pv.GetPubKey()
+			require.NoError(t, err)
+			assert.Equal(t, tc.keyType, pubKey.Type())
+
+			vote := newVote(pv.GetAddress(), 10)
+			v := vote.ToProto()
+			require.NoError(t, pv.SignVote(chainID, v, false))
+			assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, v), v.Signature))
+
+			for i := 0; i < 10; i++ {
+				msg := cmtrand.Bytes(32)
+				sig, err := pv.SignBytes(msg)
+				require.NoError(t, err)
+				assert.True(t, pubKey.VerifySignature(msg, sig))
+			}
+			require.NoError(t, pv.Close())
+
+			// The last sign state is kept across restarts.
+			pv, err = NewPrivValidator(cfg, stateFile)
+			require.NoError(t, err)
+			defer func() { _ = pv.Close() }()
+			require.Error(t, pv.SignVote(chainID, newVote(pv.GetAddress(), 10).ToProto(), false))
+			require.Error(t, pv.SignVote(chainID, newVote(pv.GetAddress(), 9).ToProto(), false))
+			require.NoError(t, pv.SignVote(chainID, newVote(pv.GetAddress(), 11).ToProto(), false))
+		})
+	}
+}
+
+func TestNewPrivValidatorErrors(t *testing.T) {
+	cfg := testConfig(t)
+	stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+
+	tokenCfg := cfg
+	tokenCfg.TokenLabel = "missing-token"
+	_, err := NewPrivValidator(tokenCfg, stateFile)
+	require.ErrorAs(t, err, &ErrTokenNotFound{})
+
+	keyCfg := cfg
+	keyCfg.KeyLabel = "missing-key"
+	_, err = NewPrivValidator(keyCfg, stateFile)
+	require.ErrorAs(t, err, &ErrKeyNotFound{})
+
+	// P-256 keys are not supported.
+	p256Params := []byte{0x06, 0x08, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07}
+	p256Cfg := generateKey(t, cfg, p11.CKM_EC_KEY_PAIR_GEN, p256Params)
+	_, err = NewPrivValidator(p256Cfg, stateFile)
+	require.ErrorAs(t, err, &ErrUnsupportedKey{})
+}
+
+func newVote(addr types.Address, height int64) *types.Vote {
+	hash := cmtrand.Bytes(tmhash.Size)
+	return &types.Vote{
+		Type:             types.PrevoteType,
+		Height:           height,
+		Round:            0,
+		BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 1}},
+		ValidatorAddress: addr,
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !pkcs11
+
+package pkcs11
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrivValidatorDisabled(t *testing.T) {
+	_, err := NewPrivValidator(Config{Module: "libsofthsm2.so"}, filepath.Join(t.TempDir(), "state.json"))
+	require.ErrorIs(t, err, ErrDisabled)
+}
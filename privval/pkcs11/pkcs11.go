@@ -0,0 +1,103 @@
+// Package pkcs11 implements a PrivValidator signing with an ed25519 or
+// secp256k1 key held by an HSM, through its PKCS#11 module.
+//
+// The PKCS#11 support requires cgo and the `pkcs11` build tag. Without it,
+// NewPrivValidator returns ErrDisabled.
+package pkcs11
+
+import (
+	"fmt"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/privval"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// Config identifies the key a PrivValidator signs with.
+type Config struct {
+	// Module is the path of the PKCS#11 module of the HSM.
+	Module string
+	// TokenLabel is the label of the token holding the key.
+	TokenLabel string
+	// PIN is the user PIN of the token.
+	PIN string
+	// KeyLabel is the label of the key pair.
+	KeyLabel string
+}
+
+// key is a private key which never leaves the HSM.
+type key interface {
+	crypto.PrivKey
+	Close() error
+}
+
+var _ types.PrivValidator = (*PrivValidator)(nil)
+
+// PrivValidator implements PrivValidator with a key held by an HSM. As
+// privval.FilePV, it persists the height, round and step of the last signature
+// to a state file to prevent double signing.
+type PrivValidator struct {
+	key key
+	pv  *privval.FilePV
+}
+
+// NewPrivValidator opens a session with the token and key of cfg, and returns
+// a PrivValidator signing with this key and keeping its state in
+// stateFilePath, which is created if it does not exist.
+func NewPrivValidator(cfg Config, stateFilePath string) (*PrivValidator, error) {
+	key, err := openKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pv, err := privval.NewFilePVFromStateFile(key, stateFilePath)
+	if err != nil {
+		_ = key.Close()
+		return nil, err
+	}
+	return &PrivValidator{key: key, pv: pv}, nil
+}
+
+// GetAddress returns the address of the validator.
+func (pv *PrivValidator) GetAddress() types.Address {
+	return pv.pv.GetAddress()
+}
+
+// GetPubKey returns the public key of the validator.
+// Implements PrivValidator.
+func (pv *PrivValidator) GetPubKey() (crypto.PubKey, error) {
+	return pv.pv.GetPubKey()
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *PrivValidator) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	return pv.pv.SignVote(chainID, vote, signExtension)
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (pv *PrivValidator) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	return pv.pv.SignProposal(chainID, proposal)
+}
+
+// SignBytes signs the given bytes. Implements PrivValidator.
+func (pv *PrivValidator) SignBytes(bytes []byte) ([]byte, error) {
+	return pv.pv.SignBytes(bytes)
+}
+
+// Close closes the session with the token.
+func (pv *PrivValidator) Close() error {
+	return pv.key.Close()
+}
+
+// String returns a string representation of the PrivValidator.
+func (pv *PrivValidator) String() string {
+	return fmt.Sprintf(
+		"PKCS11PrivValidator{%v LH:%v, LR:%v, LS:%v}",
+		pv.GetAddress(),
+		pv.pv.LastSignState.Height,
+		pv.pv.LastSignState.Round,
+		pv.pv.LastSignState.Step,
+	)
+}
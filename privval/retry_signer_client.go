@@ -9,17 +9,28 @@ import (
 	"github.com/cometbft/cometbft/v2/types"
 )
 
-// RetrySignerClient wraps SignerClient adding retry for each operation (except
-// Ping) w/ a timeout.
+// RemoteSignerClient is a PrivValidator backed by remote signers, such as
+// SignerClient and FailoverSignerClient.
+type RemoteSignerClient interface {
+	types.PrivValidator
+
+	Close() error
+	IsConnected() bool
+	WaitForConnection(maxWait time.Duration) error
+	Ping() error
+}
+
+// RetrySignerClient wraps a RemoteSignerClient adding retry for each operation
+// (except Ping) w/ a timeout.
 type RetrySignerClient struct {
-	next    *SignerClient
+	next    RemoteSignerClient
 	retries int
 	timeout time.Duration
 }
 
 // NewRetrySignerClient returns RetrySignerClient. If +retries+ is 0, the
 // client will be retrying each operation indefinitely.
-func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration) *RetrySignerClient {
+func NewRetrySignerClient(sc RemoteSignerClient, retries int, timeout time.Duration) *RetrySignerClient {
 	return &RetrySignerClient{sc, retries, timeout}
 }
 
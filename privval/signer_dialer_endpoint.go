@@ -1,8 +1,11 @@
 package privval
 
 import (
+	"errors"
+	"net"
 	"time"
 
+	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/libs/service"
 )
@@ -33,12 +36,20 @@ func SignerDialerEndpointRetryWaitInterval(interval time.Duration) SignerService
 	return func(ss *SignerDialerEndpoint) { ss.retryWait = interval }
 }
 
+// SignerDialerEndpointNodeKey pins the public key of the node to connect to.
+// Connections to a node with another key are dropped. The dialer must establish
+// authenticated connections, see DialTCPFn and DialSecretUnixFn.
+func SignerDialerEndpointNodeKey(pubKey crypto.PubKey) SignerServiceEndpointOption {
+	return func(ss *SignerDialerEndpoint) { ss.nodeKey = pubKey }
+}
+
 // SignerDialerEndpoint dials using its dialer and responds to any signature
 // requests using its privVal.
 type SignerDialerEndpoint struct {
 	signerEndpoint
 
-	dialer SocketDialer
+	dialer  SocketDialer
+	nodeKey crypto.PubKey
 
 	retryWait      time.Duration
 	maxConnRetries int
@@ -76,6 +87,9 @@ func (sd *SignerDialerEndpoint) ensureConnection() error {
 	retries := 0
 	for retries < sd.maxConnRetries {
 		conn, err := sd.dialer()
+		if err == nil {
+			err = sd.checkNodeKey(conn)
+		}
 		if err != nil {
 			retries++
 			sd.Logger.Debug("SignerDialer: Reconnection failed", "retries", retries, "max", sd.maxConnRetries, "err", err)
@@ -93,3 +107,23 @@ func (sd *SignerDialerEndpoint) ensureConnection() error {
 
 	return ErrNoConnection
 }
+
+// checkNodeKey closes conn and returns an error if the node does not have the
+// pinned key.
+func (sd *SignerDialerEndpoint) checkNodeKey(conn net.Conn) error {
+	if sd.nodeKey == nil {
+		return nil
+	}
+
+	var err error
+	if authConn, ok := conn.(interface{ RemotePubKey() crypto.PubKey }); !ok {
+		err = errors.New("cannot check the node key over an unauthenticated connection")
+	} else if nodeKey := authConn.RemotePubKey(); !pubKeysEqual(nodeKey, sd.nodeKey) {
+		err = ErrNodeKeyMismatch{Expected: sd.nodeKey, Got: nodeKey}
+	}
+	if err != nil {
+		sd.Logger.Error("SignerDialer: Rejected connection to node", "err", err)
+		_ = conn.Close()
+	}
+	return err
+}
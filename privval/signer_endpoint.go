@@ -6,6 +6,7 @@ import (
 	"time"
 
 	privvalproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
+	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/libs/protoio"
 	"github.com/cometbft/cometbft/v2/libs/service"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
@@ -20,6 +21,7 @@ type signerEndpoint struct {
 
 	connMtx cmtsync.Mutex
 	conn    net.Conn
+	connID  uint64 // incremented on each new connection
 
 	timeoutReadWrite time.Duration
 }
@@ -45,6 +47,7 @@ func (se *signerEndpoint) GetAvailableConnection(connectionAvailableCh chan net.
 	// Is there a connection ready?
 	select {
 	case se.conn = <-connectionAvailableCh:
+		se.connID++
 		return true
 	default:
 	}
@@ -68,6 +71,26 @@ func (se *signerEndpoint) SetConnection(newConnection net.Conn) {
 	se.connMtx.Lock()
 	defer se.connMtx.Unlock()
 	se.conn = newConnection
+	se.connID++
+}
+
+// connectionID returns an identifier of the current connection, which changes
+// whenever a new connection is set.
+func (se *signerEndpoint) connectionID() uint64 {
+	se.connMtx.Lock()
+	defer se.connMtx.Unlock()
+	return se.connID
+}
+
+// remoteKey returns the public key the remote end of the current connection
+// authenticated with, or nil if there is no authenticated connection.
+func (se *signerEndpoint) remoteKey() crypto.PubKey {
+	se.connMtx.Lock()
+	defer se.connMtx.Unlock()
+	if authConn, ok := se.conn.(interface{ RemotePubKey() crypto.PubKey }); ok {
+		return authConn.RemotePubKey()
+	}
+	return nil
 }
 
 // DropConnection closes the current connection if it exists.
@@ -23,6 +23,11 @@ func SignerListenerEndpointTimeoutReadWrite(timeout time.Duration) SignerListene
 	return func(sl *SignerListenerEndpoint) { sl.signerEndpoint.timeoutReadWrite = timeout }
 }
 
+// SignerListenerEndpointMetrics sets the metrics.
+func SignerListenerEndpointMetrics(metrics *Metrics) SignerListenerEndpointOption {
+	return func(sl *SignerListenerEndpoint) { sl.metrics = metrics }
+}
+
 // SignerListenerEndpoint listens for an external process to dial in and keeps
 // the connection alive by dropping and reconnecting.
 //
@@ -41,6 +46,8 @@ type SignerListenerEndpoint struct {
 	pingInterval    time.Duration
 
 	instanceMtx cmtsync.Mutex // Ensures instance public methods access, i.e. SendRequest
+
+	metrics *Metrics
 }
 
 // NewSignerListenerEndpoint returns an instance of SignerListenerEndpoint.
@@ -52,6 +59,7 @@ func NewSignerListenerEndpoint(
 	sl := &SignerListenerEndpoint{
 		listener:      listener,
 		timeoutAccept: defaultTimeoutAcceptSeconds * time.Second,
+		metrics:       NopMetrics(),
 	}
 
 	sl.BaseService = *service.NewBaseService(logger, "SignerListenerEndpoint", sl)
@@ -131,6 +139,12 @@ func (sl *SignerListenerEndpoint) SendRequest(request privvalproto.Message) (*pr
 	return &res, nil
 }
 
+// hasConnection returns true if a signer is connected, taking the connection
+// accepted last if there is one, without waiting for a connection.
+func (sl *SignerListenerEndpoint) hasConnection() bool {
+	return sl.IsConnected() || sl.GetAvailableConnection(sl.connectionAvailableCh)
+}
+
 func (sl *SignerListenerEndpoint) ensureConnection(maxWait time.Duration) error {
 	if sl.IsConnected() {
 		return nil
@@ -194,6 +208,13 @@ func (sl *SignerListenerEndpoint) serviceLoop() {
 
 			// Listen for remote signer
 			conn, err := sl.acceptNewConnection()
+			var unknownKeyErr ErrUnknownSignerKey
+			if errors.As(err, &unknownKeyErr) {
+				sl.Logger.Error("SignerListener: Rejected connection from signer with unknown key", "pubKey", unknownKeyErr.PubKey)
+				sl.metrics.RejectedSignerConnections.Add(1)
+				sl.triggerConnect()
+				continue
+			}
 			if err != nil {
 				sl.Logger.Error("SignerListener: Error accepting connection", "err", err, "failures", sl.acceptFailCount.Load())
 				sl.triggerConnect()
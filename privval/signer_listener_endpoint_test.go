@@ -3,16 +3,19 @@ package privval
 import (
 	"errors"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtnet "github.com/cometbft/cometbft/v2/internal/net"
 	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
 	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/libs/metrics"
 	"github.com/cometbft/cometbft/v2/types"
 )
 
@@ -323,3 +326,78 @@ func (l *testListener) Accept() (net.Conn, error) {
 
 	return nil, nil // Note this doesn't actually return a valid connection, it just doesn't error.
 }
+
+type testCounter struct {
+	metrics.Counter
+	count atomic.Int64
+}
+
+func (c *testCounter) Add(delta float64) { c.count.Add(int64(delta)) }
+
+func TestSignerListenerEndpointRejectsUnknownKey(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	tcpLn := NewTCPListener(ln, ed25519.GenPrivKey())
+	TCPListenerAllowedKeys([]crypto.PubKey{ed25519.GenPrivKey().PubKey()})(tcpLn)
+
+	rejected := &testCounter{}
+	listenerEndpoint := NewSignerListenerEndpoint(log.TestingLogger(), tcpLn,
+		SignerListenerEndpointMetrics(&Metrics{RejectedSignerConnections: rejected}))
+	require.NoError(t, listenerEndpoint.Start())
+	t.Cleanup(func() { _ = listenerEndpoint.Stop() })
+
+	dialer := DialTCPFn(ln.Addr().String(), testTimeoutReadWrite, ed25519.GenPrivKey())
+	if conn, err := dialer(); err == nil {
+		conn.Close()
+	}
+
+	require.Eventually(t, func() bool { return rejected.count.Load() == 1 }, time.Second, 10*time.Millisecond)
+	assert.False(t, listenerEndpoint.IsConnected())
+}
+
+func TestSignerDialerEndpointNodeKey(t *testing.T) {
+	nodeKey := ed25519.GenPrivKey()
+
+	for _, tc := range []struct {
+		pinnedKey crypto.PubKey
+		wantErr   bool
+	}{
+		{nodeKey.PubKey(), false},
+		{ed25519.GenPrivKey().PubKey(), true},
+	} {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		tcpLn := NewTCPListener(ln, nodeKey)
+		go func() {
+			if conn, err := tcpLn.Accept(); err == nil {
+				conn.Close()
+			}
+		}()
+
+		dialerEndpoint := NewSignerDialerEndpoint(log.TestingLogger(),
+			DialTCPFn(ln.Addr().String(), testTimeoutReadWrite, ed25519.GenPrivKey()),
+			SignerDialerEndpointConnRetries(1),
+			SignerDialerEndpointNodeKey(tc.pinnedKey))
+		err = dialerEndpoint.ensureConnection()
+		if tc.wantErr {
+			require.ErrorIs(t, err, ErrNoConnection)
+			assert.False(t, dialerEndpoint.IsConnected())
+		} else {
+			require.NoError(t, err)
+			assert.True(t, dialerEndpoint.IsConnected())
+			dialerEndpoint.DropConnection()
+		}
+		tcpLn.Close()
+	}
+
+	// The node key cannot be checked over an unauthenticated connection.
+	addr, err := testUnixAddr()
+	require.NoError(t, err)
+	ln, err := net.Listen("unix", addr)
+	require.NoError(t, err)
+	defer ln.Close()
+	dialerEndpoint := NewSignerDialerEndpoint(log.TestingLogger(), DialUnixFn(addr),
+		SignerDialerEndpointConnRetries(1),
+		SignerDialerEndpointNodeKey(nodeKey.PubKey()))
+	require.ErrorIs(t, dialerEndpoint.ensureConnection(), ErrNoConnection)
+}
@@ -68,6 +68,10 @@ func (ss *SignerServer) servicePendingRequest() {
 	if err != nil {
 		if err != io.EOF {
 			ss.Logger.Error("SignerServer: HandleMessage", "err", err)
+		} else {
+			// The node closed the connection, e.g. after a request timed out:
+			// dial it again.
+			ss.endpoint.DropConnection()
 		}
 		return
 	}
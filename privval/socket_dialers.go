@@ -41,3 +41,21 @@ func DialUnixFn(addr string) SocketDialer {
 		return net.DialUnix("unix", nil, unixAddr)
 	}
 }
+
+// DialSecretUnixFn dials the given unix socket, using the given privKey for the
+// authenticated encryption handshake. The listener must authenticate
+// connections, see UnixListenerAuthenticate.
+func DialSecretUnixFn(addr string, privKey crypto.PrivKey) SocketDialer {
+	return func() (net.Conn, error) {
+		conn, err := DialUnixFn(addr)()
+		if err != nil {
+			return nil, err
+		}
+		secretConn, err := p2pconn.MakeSecretConnection(conn, privKey)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		return secretConn, nil
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"net"
 	"time"
 
-	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/crypto"
 	p2pconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 )
 
@@ -36,6 +36,13 @@ func TCPListenerTimeoutReadWrite(timeout time.Duration) TCPListenerOption {
 	return func(tl *TCPListener) { tl.timeoutReadWrite = timeout }
 }
 
+// TCPListenerAllowedKeys sets the public keys of the external signing
+// processes allowed to connect. Connections from other keys are rejected with
+// ErrUnknownSignerKey. If empty, any key is allowed.
+func TCPListenerAllowedKeys(keys []crypto.PubKey) TCPListenerOption {
+	return func(tl *TCPListener) { tl.allowedKeys = keys }
+}
+
 // tcpListener implements net.Listener.
 var _ net.Listener = (*TCPListener)(nil)
 
@@ -44,7 +51,8 @@ var _ net.Listener = (*TCPListener)(nil)
 type TCPListener struct {
 	*net.TCPListener
 
-	secretConnKey ed25519.PrivKey
+	secretConnKey crypto.PrivKey
+	allowedKeys   []crypto.PubKey
 
 	timeoutAccept    time.Duration
 	timeoutReadWrite time.Duration
@@ -52,7 +60,7 @@ type TCPListener struct {
 
 // NewTCPListener returns a listener that accepts authenticated encrypted connections
 // using the given secretConnKey and the default timeout values.
-func NewTCPListener(ln net.Listener, secretConnKey ed25519.PrivKey) *TCPListener {
+func NewTCPListener(ln net.Listener, secretConnKey crypto.PrivKey) *TCPListener {
 	return &TCPListener{
 		TCPListener:      ln.(*net.TCPListener),
 		secretConnKey:    secretConnKey,
@@ -76,12 +84,7 @@ func (ln *TCPListener) Accept() (net.Conn, error) {
 
 	// Wrap the conn in our timeout and encryption wrappers
 	timeoutConn := newTimeoutConn(tc, ln.timeoutReadWrite)
-	secretConn, err := p2pconn.MakeSecretConnection(timeoutConn, ln.secretConnKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return secretConn, nil
+	return makeAuthenticatedConn(timeoutConn, ln.secretConnKey, ln.allowedKeys)
 }
 
 // ------------------------------------------------------------------
@@ -104,11 +107,27 @@ func UnixListenerTimeoutReadWrite(timeout time.Duration) UnixListenerOption {
 	return func(ul *UnixListener) { ul.timeoutReadWrite = timeout }
 }
 
+// UnixListenerAuthenticate makes the listener wrap connections in an
+// authenticated encrypted connection using the given secretConnKey, like the
+// TCPListener, and only accept external signing processes with one of the
+// allowed keys. If allowedKeys is empty, any key is allowed. Signing processes
+// must then dial with DialSecretUnixFn.
+func UnixListenerAuthenticate(secretConnKey crypto.PrivKey, allowedKeys []crypto.PubKey) UnixListenerOption {
+	return func(ul *UnixListener) {
+		ul.secretConnKey = secretConnKey
+		ul.allowedKeys = allowedKeys
+	}
+}
+
 // UnixListener wraps a *net.UnixListener to standardize protocol timeouts
-// and potentially other tuning parameters. It returns unencrypted connections.
+// and potentially other tuning parameters. It returns unencrypted connections,
+// unless UnixListenerAuthenticate is set.
 type UnixListener struct {
 	*net.UnixListener
 
+	secretConnKey crypto.PrivKey
+	allowedKeys   []crypto.PubKey
+
 	timeoutAccept    time.Duration
 	timeoutReadWrite time.Duration
 }
@@ -138,11 +157,36 @@ func (ln *UnixListener) Accept() (net.Conn, error) {
 
 	// Wrap the conn in our timeout wrapper
 	conn := newTimeoutConn(tc, ln.timeoutReadWrite)
+	if ln.secretConnKey == nil {
+		return conn, nil
+	}
+	return makeAuthenticatedConn(conn, ln.secretConnKey, ln.allowedKeys)
+}
 
-	// TODO: wrap in something that authenticates
-	// with a MAC - https://github.com/tendermint/tendermint/issues/3099
+// ------------------------------------------------------------------
+// Authentication
 
-	return conn, nil
+// makeAuthenticatedConn wraps conn in a SecretConnection using secretConnKey,
+// and closes it with ErrUnknownSignerKey if the key of the remote signer is not
+// one of allowedKeys. If allowedKeys is empty, any key is allowed.
+func makeAuthenticatedConn(conn net.Conn, secretConnKey crypto.PrivKey, allowedKeys []crypto.PubKey) (net.Conn, error) {
+	secretConn, err := p2pconn.MakeSecretConnection(conn, secretConnKey)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if len(allowedKeys) == 0 {
+		return secretConn, nil
+	}
+
+	remoteKey := secretConn.RemotePubKey()
+	for _, key := range allowedKeys {
+		if pubKeysEqual(key, remoteKey) {
+			return secretConn, nil
+		}
+	}
+	_ = secretConn.Close()
+	return nil, ErrUnknownSignerKey{PubKey: remoteKey}
 }
 
 // ------------------------------------------------------------------
@@ -6,7 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	p2pconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 )
 
 // -------------------------------------------
@@ -141,3 +146,65 @@ func TestListenerTimeoutReadWrite(t *testing.T) {
 		}
 	}
 }
+
+func TestListenerAllowedKeys(t *testing.T) {
+	var (
+		listenerKey = newPrivKey()
+		signerKey   = newPrivKey()
+		allowedKeys = []crypto.PubKey{signerKey.PubKey()}
+	)
+
+	tcpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	tcpListener := NewTCPListener(tcpLn, listenerKey)
+	TCPListenerAllowedKeys(allowedKeys)(tcpListener)
+
+	addr, err := testUnixAddr()
+	require.NoError(t, err)
+	unixLn, err := net.Listen("unix", addr)
+	require.NoError(t, err)
+	unixListener := NewUnixListener(unixLn)
+	UnixListenerAuthenticate(listenerKey, allowedKeys)(unixListener)
+
+	testCases := []struct {
+		description string
+		listener    net.Listener
+		dialer      func(crypto.PrivKey) SocketDialer
+	}{
+		{"TCP", tcpListener, func(key crypto.PrivKey) SocketDialer {
+			return DialTCPFn(tcpLn.Addr().String(), testTimeoutReadWrite, key)
+		}},
+		{"Unix", unixListener, func(key crypto.PrivKey) SocketDialer {
+			return DialSecretUnixFn(addr, key)
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			defer tc.listener.Close()
+
+			// An allowed signer is accepted, and sees the key of the listener.
+			go func() {
+				conn, err := tc.dialer(signerKey)()
+				if assert.NoError(t, err) {
+					assert.Equal(t, listenerKey.PubKey(), conn.(*p2pconn.SecretConnection).RemotePubKey())
+					conn.Close()
+				}
+			}()
+			conn, err := tc.listener.Accept()
+			require.NoError(t, err)
+			conn.Close()
+
+			// An unknown signer is rejected.
+			otherKey := newPrivKey()
+			go func() {
+				if conn, err := tc.dialer(otherKey)(); err == nil {
+					conn.Close()
+				}
+			}()
+			_, err = tc.listener.Accept()
+			var unknownKeyErr ErrUnknownSignerKey
+			require.ErrorAs(t, err, &unknownKeyErr)
+			assert.Equal(t, otherKey.PubKey(), unknownKeyErr.PubKey)
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package privval
 
 import (
+	"bytes"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 
+	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtnet "github.com/cometbft/cometbft/v2/internal/net"
 	"github.com/cometbft/cometbft/v2/libs/log"
@@ -27,19 +31,51 @@ func IsConnTimeout(err error) bool {
 
 // NewSignerListener creates a new SignerListenerEndpoint using the corresponding listen address.
 func NewSignerListener(listenAddr string, logger log.Logger) (*SignerListenerEndpoint, error) {
+	endpoints, err := NewSignerListeners(listenAddr, 1, ed25519.GenPrivKey(), nil, logger)
+	if err != nil {
+		return nil, err
+	}
+	return endpoints[0], nil
+}
+
+// NewSignerListeners creates n SignerListenerEndpoints accepting connections on
+// the listen address, so that up to n signers can be connected at once, one per
+// endpoint.
+//
+// Connections are authenticated with secretConnKey, which signers may pin, and
+// only signers with one of allowedKeys are accepted. If allowedKeys is empty,
+// any signer is accepted, and connections over a UNIX socket are neither
+// authenticated nor encrypted, so n must be 1: a FailoverSignerClient could
+// not tell a quarantined signer apart from the others.
+func NewSignerListeners(
+	listenAddr string,
+	n int,
+	secretConnKey crypto.PrivKey,
+	allowedKeys []crypto.PubKey,
+	logger log.Logger,
+	options ...SignerListenerEndpointOption,
+) ([]*SignerListenerEndpoint, error) {
 	var listener net.Listener
 
 	protocol, address := cmtnet.ProtocolAndAddress(listenAddr)
+	if protocol == "unix" && n > 1 && len(allowedKeys) == 0 {
+		return nil, errors.New("several signers can only connect over a UNIX socket with allowed keys")
+	}
 	ln, err := net.Listen(protocol, address)
 	if err != nil {
 		return nil, err
 	}
 	switch protocol {
 	case "unix":
-		listener = NewUnixListener(ln)
+		unixLn := NewUnixListener(ln)
+		if len(allowedKeys) > 0 {
+			UnixListenerAuthenticate(secretConnKey, allowedKeys)(unixLn)
+		}
+		listener = unixLn
 	case "tcp":
-		// TODO: persist this key so external signer can actually authenticate us
-		listener = NewTCPListener(ln, ed25519.GenPrivKey())
+		tcpLn := NewTCPListener(ln, secretConnKey)
+		TCPListenerAllowedKeys(allowedKeys)(tcpLn)
+		listener = tcpLn
 	default:
 		return nil, fmt.Errorf(
 			"wrong listen address: expected either 'tcp' or 'unix' protocols, got %s",
@@ -47,9 +83,47 @@ func NewSignerListener(listenAddr string, logger log.Logger) (*SignerListenerEnd
 		)
 	}
 
-	pve := NewSignerListenerEndpoint(logger.With("module", "privval"), listener)
+	// Each endpoint closes the listener when stopped.
+	shared := &sharedListener{Listener: listener}
+	endpoints := make([]*SignerListenerEndpoint, n)
+	for i := range endpoints {
+		endpointLogger := logger.With("module", "privval")
+		if n > 1 {
+			endpointLogger = endpointLogger.With("signer", i)
+		}
+		endpoints[i] = NewSignerListenerEndpoint(endpointLogger, shared, options...)
+	}
+
+	return endpoints, nil
+}
+
+// sharedListener is a listener shared by several endpoints, closed once.
+type sharedListener struct {
+	net.Listener
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func (l *sharedListener) Close() error {
+	l.closeOnce.Do(func() { l.closeErr = l.Listener.Close() })
+	return l.closeErr
+}
+
+// ParsePubKey parses a base64-encoded ed25519 public key, as found in key
+// files, identifying a signer or a node.
+func ParsePubKey(s string) (crypto.PubKey, error) {
+	bz, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %q: %w", s, err)
+	}
+	if len(bz) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("invalid public key %q: expected %d bytes, got %d", s, ed25519.PubKeySize, len(bz))
+	}
+	return ed25519.PubKey(bz), nil
+}
 
-	return pve, nil
+func pubKeysEqual(a, b crypto.PubKey) bool {
+	return a.Type() == b.Type() && bytes.Equal(a.Bytes(), b.Bytes())
 }
 
 // GetFreeLocalhostAddrPort returns a free localhost:port address.
@@ -3,12 +3,32 @@ package privval
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/libs/log"
 )
 
 func TestIsConnTimeoutForNonTimeoutErrors(t *testing.T) {
 	assert.False(t, IsConnTimeout(fmt.Errorf("max retries exceeded: %w", ErrDialRetryMax)))
 	assert.False(t, IsConnTimeout(errors.New("completely irrelevant error")))
 }
+
+func TestNewSignerListenersUnix(t *testing.T) {
+	addr := "unix://" + filepath.Join(t.TempDir(), "privval.sock")
+	key := ed25519.GenPrivKey()
+
+	// Unauthenticated signers can't be told apart, so they can't fail over.
+	_, err := NewSignerListeners(addr, 2, key, nil, log.TestingLogger())
+	require.Error(t, err)
+
+	endpoints, err := NewSignerListeners(addr, 2, key, []crypto.PubKey{ed25519.GenPrivKey().PubKey()}, log.TestingLogger())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	require.NoError(t, endpoints[0].listener.Close())
+}
@@ -0,0 +1,377 @@
+// Package auth authenticates the callers of the RPC servers with API keys or
+// JWT bearer tokens, and authorizes their calls by scope.
+//
+// Every route of the JSON-RPC server and every service of the gRPC servers
+// requires a scope. A caller is granted the scopes of its API key or token, or
+// the anonymous scopes if it presents no credentials. The calls of each caller
+// are rate limited, and the calls requiring the admin scope are audit logged.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/v2/internal/ratelimit"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// Scope is a set of RPC calls a caller may be granted.
+type Scope string
+
+const (
+	// ScopeRead grants the calls which read the state of the node.
+	ScopeRead Scope = "read"
+	// ScopeBroadcast grants the calls which submit transactions and evidence.
+	ScopeBroadcast Scope = "broadcast"
+	// ScopeSubscribe grants the subscriptions to events.
+	ScopeSubscribe Scope = "subscribe"
+	// ScopeAdmin grants the calls which control the node. It implies every
+	// other scope.
+	ScopeAdmin Scope = "admin"
+)
+
+// ParseScope returns the scope named s.
+func ParseScope(s string) (Scope, error) {
+	switch scope := Scope(s); scope {
+	case ScopeRead, ScopeBroadcast, ScopeSubscribe, ScopeAdmin:
+		return scope, nil
+	default:
+		return "", ErrUnknownScope{Scope: s}
+	}
+}
+
+// APIKey is an API key accepted by an Authenticator. Only the SHA-256 hash of
+// the key is known to the node.
+type APIKey struct {
+	// Name identifies the holder of the key in the logs.
+	Name string `json:"name"`
+	// SHA256 is the hex encoded SHA-256 hash of the key.
+	SHA256 string `json:"sha256"`
+	// Scopes are the scopes granted to the key.
+	Scopes []Scope `json:"scopes"`
+	// RateLimit is the number of calls per second allowed to the key. 0 means
+	// the default rate limit.
+	RateLimit float64 `json:"rate_limit,omitempty"`
+	// RateBurst is the number of calls allowed to the key in a burst. 0 means
+	// the default burst.
+	RateBurst int `json:"rate_burst,omitempty"`
+}
+
+// LoadAPIKeys reads the API keys from the JSON array in the file at path.
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading API keys: %w", err)
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(bz, &keys); err != nil {
+		return nil, fmt.Errorf("decoding API keys from %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// LoadJWTSecret reads the HMAC secret of JWTs from the file at path, ignoring
+// surrounding whitespace.
+func LoadJWTSecret(path string) ([]byte, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWT secret: %w", err)
+	}
+	secret := []byte(strings.TrimSpace(string(bz)))
+	if len(secret) < minJWTSecretSize {
+		return nil, fmt.Errorf("JWT secret in %s is shorter than %d bytes", path, minJWTSecretSize)
+	}
+	return secret, nil
+}
+
+// Config configures an Authenticator.
+type Config struct {
+	// APIKeys are the API keys accepted as bearer tokens.
+	APIKeys []APIKey
+	// JWTSecret is the HMAC secret of the HS256 JWTs accepted as bearer tokens.
+	// JWTs are rejected if it is empty.
+	JWTSecret []byte
+	// AnonymousScopes are the scopes granted to the callers presenting no
+	// credentials.
+	AnonymousScopes []Scope
+	// RateLimit is the default number of calls per second allowed to a caller.
+	// 0 means unlimited.
+	RateLimit float64
+	// RateBurst is the default number of calls allowed to a caller in a burst.
+	RateBurst int
+}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Name identifies the caller: the name of its API key, the subject of its
+	// JWT prefixed by "jwt:", or "anonymous".
+	Name   string
+	scopes map[Scope]struct{}
+	// limiter rate limits the principal, along with the principals sharing
+	// its rate limit. It is nil if the principal is not rate limited.
+	limiter *ratelimit.Limiter
+	// limiterKey identifies the principal in its limiter. It is empty for
+	// anonymous callers, which are rate limited per host.
+	limiterKey string
+	// expiresAt is the expiration time of the JWT of the principal. It is
+	// zero for the other principals, which do not expire.
+	expiresAt time.Time
+}
+
+// HasScope returns true if the principal is granted scope.
+func (p *Principal) HasScope(scope Scope) bool {
+	if _, ok := p.scopes[ScopeAdmin]; ok {
+		return true
+	}
+	_, ok := p.scopes[scope]
+	return ok
+}
+
+// ExpiresAt returns the time at which the credentials of the principal expire,
+// or the zero time if they do not.
+func (p *Principal) ExpiresAt() time.Time {
+	return p.expiresAt
+}
+
+func newPrincipal(name, limiterKey string, scopes []Scope, limiter *ratelimit.Limiter) *Principal {
+	p := &Principal{
+		Name:       name,
+		scopes:     make(map[Scope]struct{}, len(scopes)),
+		limiter:    limiter,
+		limiterKey: limiterKey,
+	}
+	for _, scope := range scopes {
+		p.scopes[scope] = struct{}{}
+	}
+	return p
+}
+
+// Authenticator authenticates the callers of the RPC servers and authorizes
+// their calls. It is safe for concurrent use.
+type Authenticator struct {
+	apiKeys   map[string]*Principal // by SHA-256 hash of the key
+	jwtSecret []byte
+	anonymous []Scope
+	limiter   *ratelimit.Limiter // default rate limit, nil if unlimited
+	logger    log.Logger
+}
+
+// rateLimit is the number of calls per second allowed to a principal, and the
+// number of calls allowed in a burst.
+type rateLimit struct {
+	rate  float64
+	burst int
+}
+
+// NewAuthenticator returns an Authenticator configured by cfg. Privileged calls
+// are audit logged to logger.
+func NewAuthenticator(cfg Config, logger log.Logger) (*Authenticator, error) {
+	a := &Authenticator{
+		apiKeys:   make(map[string]*Principal, len(cfg.APIKeys)),
+		jwtSecret: cfg.JWTSecret,
+		anonymous: cfg.AnonymousScopes,
+		logger:    logger,
+	}
+	if cfg.RateLimit < 0 {
+		return nil, fmt.Errorf("negative rate limit: %v", cfg.RateLimit)
+	}
+
+	// The principals sharing a rate limit share a Limiter, which keeps a
+	// bucket for each of them, and drops the buckets of the idle ones.
+	limiters := make(map[rateLimit]*ratelimit.Limiter)
+	limiter := func(rate float64, burst int) *ratelimit.Limiter {
+		if rate == 0 {
+			return nil
+		}
+		l, ok := limiters[rateLimit{rate, burst}]
+		if !ok {
+			l = ratelimit.NewLimiter(rate, burst)
+			limiters[rateLimit{rate, burst}] = l
+		}
+		return l
+	}
+	a.limiter = limiter(cfg.RateLimit, cfg.RateBurst)
+	for _, scope := range cfg.AnonymousScopes {
+		if _, err := ParseScope(string(scope)); err != nil {
+			return nil, fmt.Errorf("anonymous scopes: %w", err)
+		}
+	}
+
+	names := make(map[string]struct{}, len(cfg.APIKeys))
+	for i, key := range cfg.APIKeys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("API key #%d has no name", i)
+		}
+		if _, ok := names[key.Name]; ok {
+			return nil, fmt.Errorf("duplicate API key name %q", key.Name)
+		}
+		names[key.Name] = struct{}{}
+
+		hash, err := hex.DecodeString(key.SHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("API key %q: sha256 must be %d hex encoded bytes", key.Name, sha256.Size)
+		}
+		for _, scope := range key.Scopes {
+			if _, err := ParseScope(string(scope)); err != nil {
+				return nil, fmt.Errorf("API key %q: %w", key.Name, err)
+			}
+		}
+		if key.RateLimit < 0 || key.RateBurst < 0 {
+			return nil, fmt.Errorf("API key %q: negative rate limit", key.Name)
+		}
+
+		rate, burst := key.RateLimit, key.RateBurst
+		if rate == 0 {
+			rate = cfg.RateLimit
+		}
+		if burst == 0 {
+			burst = cfg.RateBurst
+		}
+		a.apiKeys[string(hash)] = newPrincipal(key.Name, "key:"+key.Name, key.Scopes, limiter(rate, burst))
+	}
+
+	return a, nil
+}
+
+// Authenticate returns the principal presenting the bearer token, an API key
+// or a JWT. A caller presenting no token is anonymous.
+func (a *Authenticator) Authenticate(token string) (*Principal, error) {
+	if token == "" {
+		return newPrincipal("anonymous", "", a.anonymous, a.limiter), nil
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	if p, ok := a.apiKeys[string(hash[:])]; ok {
+		return p, nil
+	}
+
+	if strings.Count(token, ".") == 2 && len(a.jwtSecret) > 0 {
+		claims, err := verifyJWT(token, a.jwtSecret, cmttime.Now())
+		if err != nil {
+			return nil, ErrUnauthenticated{Reason: err.Error()}
+		}
+		scopes := make([]Scope, 0, len(claims.Scopes))
+		for _, s := range claims.Scopes {
+			scope, err := ParseScope(s)
+			if err != nil {
+				// Scopes meant for other services are ignored.
+				continue
+			}
+			scopes = append(scopes, scope)
+		}
+		p := newPrincipal("jwt:"+claims.Subject, "jwt:"+claims.Subject, scopes, a.limiter)
+		p.expiresAt = claims.ExpiresAt
+		return p, nil
+	}
+
+	return nil, ErrUnauthenticated{Reason: "unknown API key"}
+}
+
+// Authorize checks that principal p, calling from remoteAddr, may call method
+// which requires scope, and that its credentials have not expired since it was
+// authenticated. It consumes one call of the rate limit of p. Calls
+// requiring the admin scope are audit logged, whether they are allowed or not.
+func (a *Authenticator) Authorize(p *Principal, remoteAddr, method string, scope Scope) error {
+	err := a.authorize(p, remoteAddr, method, scope)
+	if scope == ScopeAdmin {
+		a.logger.Info("Privileged RPC call",
+			"principal", p.Name,
+			"remote", remoteAddr,
+			"method", method,
+			"allowed", err == nil,
+			"err", err)
+	}
+	return err
+}
+
+func (*Authenticator) authorize(p *Principal, remoteAddr, method string, scope Scope) error {
+	now := cmttime.Now()
+	// A session, e.g. over a websocket, outlives the authentication of its
+	// principal.
+	if !p.expiresAt.IsZero() && !now.Before(p.expiresAt) {
+		return ErrUnauthenticated{Reason: "JWT has expired"}
+	}
+	if !p.HasScope(scope) {
+		return ErrForbidden{Principal: p.Name, Method: method, Scope: scope}
+	}
+	if p.limiter == nil {
+		return nil
+	}
+
+	// Anonymous callers are rate limited per host rather than as a whole.
+	limiterKey := p.limiterKey
+	if limiterKey == "" {
+		host, _, err := net.SplitHostPort(remoteAddr)
+		if err != nil {
+			host = remoteAddr
+		}
+		limiterKey = "anonymous@" + host
+	}
+
+	if allowed, retryAfter := p.limiter.Take(limiterKey, now, 1); !allowed {
+		return ErrRateLimited{Principal: p.Name, RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Session is an authenticated caller of an RPC server, whose calls are
+// authorized by an Authenticator.
+type Session struct {
+	Principal  *Principal
+	RemoteAddr string
+	authn      *Authenticator
+}
+
+// NewSession returns the session of principal p calling from remoteAddr.
+func (a *Authenticator) NewSession(p *Principal, remoteAddr string) *Session {
+	return &Session{Principal: p, RemoteAddr: remoteAddr, authn: a}
+}
+
+// Authorize checks that the caller may call method which requires scope. All
+// calls are allowed without a session, that is if authentication is disabled.
+func (s *Session) Authorize(method string, scope Scope) error {
+	if s == nil {
+		return nil
+	}
+	return s.authn.Authorize(s.Principal, s.RemoteAddr, method, scope)
+}
+
+// ExpiresAt returns the time at which the credentials of the caller expire, or
+// the zero time if they do not or if there is no session.
+func (s *Session) ExpiresAt() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.Principal.ExpiresAt()
+}
+
+type sessionKey struct{}
+
+// NewContext returns a copy of ctx carrying session s.
+func NewContext(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// FromContext returns the session carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Session {
+	s, _ := ctx.Value(sessionKey{}).(*Session)
+	return s
+}
+
+// BearerToken returns the token of an "Authorization: Bearer <token>" header
+// value, or an empty string if there is none.
+func BearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
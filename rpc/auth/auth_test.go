@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/v2/libs/log"
+)
+
+var testJWTSecret = bytes.Repeat([]byte{'s'}, minJWTSecretSize)
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func makeJWT(t *testing.T, secret []byte, alg string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newTestAuthenticator(t *testing.T, logger log.Logger) *Authenticator {
+	t.Helper()
+	a, err := NewAuthenticator(Config{
+		APIKeys: []APIKey{
+			{Name: "reader", SHA256: hashKey("reader-key"), Scopes: []Scope{ScopeRead}},
+			{Name: "admin", SHA256: hashKey("admin-key"), Scopes: []Scope{ScopeAdmin}},
+			{Name: "limited", SHA256: hashKey("limited-key"), Scopes: []Scope{ScopeRead}, RateLimit: 1, RateBurst: 2},
+		},
+		JWTSecret:       testJWTSecret,
+		AnonymousScopes: []Scope{ScopeRead},
+	}, logger)
+	require.NoError(t, err)
+	return a
+}
+
+func TestAuthenticator_APIKeys(t *testing.T) {
+	a := newTestAuthenticator(t, log.NewNopLogger())
+
+	p, err := a.Authenticate("reader-key")
+	require.NoError(t, err)
+	assert.Equal(t, "reader", p.Name)
+	require.NoError(t, a.Authorize(p, "127.0.0.1:1234", "status", ScopeRead))
+	require.ErrorAs(t, a.Authorize(p, "127.0.0.1:1234", "broadcast_tx_sync", ScopeBroadcast), &ErrForbidden{})
+
+	// The admin scope implies every other scope.
+	p, err = a.Authenticate("admin-key")
+	require.NoError(t, err)
+	for _, scope := range []Scope{ScopeRead, ScopeBroadcast, ScopeSubscribe, ScopeAdmin} {
+		require.NoError(t, a.Authorize(p, "127.0.0.1:1234", "method", scope))
+	}
+
+	_, err = a.Authenticate("unknown-key")
+	require.ErrorAs(t, err, &ErrUnauthenticated{})
+
+	p, err = a.Authenticate("")
+	require.NoError(t, err)
+	assert.Equal(t, "anonymous", p.Name)
+	require.NoError(t, a.Authorize(p, "127.0.0.1:1234", "status", ScopeRead))
+	require.ErrorAs(t, a.Authorize(p, "127.0.0.1:1234", "subscribe", ScopeSubscribe), &ErrForbidden{})
+}
+
+func TestNewAuthenticator_InvalidConfig(t *testing.T) {
+	for name, cfg := range map[string]Config{
+		"no name":         {APIKeys: []APIKey{{SHA256: hashKey("key")}}},
+		"duplicate name":  {APIKeys: []APIKey{{Name: "a", SHA256: hashKey("1")}, {Name: "a", SHA256: hashKey("2")}}},
+		"bad hash":        {APIKeys: []APIKey{{Name: "a", SHA256: "abcd"}}},
+		"unknown scope":   {APIKeys: []APIKey{{Name: "a", SHA256: hashKey("1"), Scopes: []Scope{"write"}}}},
+		"negative rate":   {APIKeys: []APIKey{{Name: "a", SHA256: hashKey("1"), RateLimit: -1}}},
+		"anonymous scope": {AnonymousScopes: []Scope{"all"}},
+	} {
+		_, err := NewAuthenticator(cfg, log.NewNopLogger())
+		require.Error(t, err, name)
+	}
+}
+
+func TestAuthenticator_JWT(t *testing.T) {
+	a := newTestAuthenticator(t, log.NewNopLogger())
+	exp := time.Now().Add(time.Hour).Unix()
+
+	token := makeJWT(t, testJWTSecret, "HS256", map[string]any{"sub": "partner", "exp": exp, "scope": "read broadcast other"})
+	p, err := a.Authenticate(token)
+	require.NoError(t, err)
+	assert.Equal(t, "jwt:partner", p.Name)
+	assert.True(t, p.HasScope(ScopeRead))
+	assert.True(t, p.HasScope(ScopeBroadcast))
+	assert.False(t, p.HasScope(ScopeSubscribe))
+
+	for name, token := range map[string]string{
+		"wrong secret":  makeJWT(t, bytes.Repeat([]byte{'x'}, 32), "HS256", map[string]any{"sub": "partner", "exp": exp}),
+		"none alg":      makeJWT(t, testJWTSecret, "none", map[string]any{"sub": "partner", "exp": exp}),
+		"expired":       makeJWT(t, testJWTSecret, "HS256", map[string]any{"sub": "partner", "exp": time.Now().Add(-time.Minute).Unix()}),
+		"not yet valid": makeJWT(t, testJWTSecret, "HS256", map[string]any{"sub": "partner", "exp": exp, "nbf": exp}),
+		"no expiration": makeJWT(t, testJWTSecret, "HS256", map[string]any{"sub": "partner"}),
+		"no subject":    makeJWT(t, testJWTSecret, "HS256", map[string]any{"exp": exp}),
+	} {
+		_, err := a.Authenticate(token)
+		require.ErrorAs(t, err, &ErrUnauthenticated{}, name)
+	}
+
+	// The expiration time is checked again on every call of a session.
+	assert.Equal(t, time.Unix(exp, 0), p.ExpiresAt())
+	require.NoError(t, a.Authorize(p, "127.0.0.1:1", "status", ScopeRead))
+	p.expiresAt = time.Now().Add(-time.Second)
+	require.ErrorAs(t, a.Authorize(p, "127.0.0.1:1", "status", ScopeRead), &ErrUnauthenticated{})
+}
+
+func TestAuthenticator_RateLimit(t *testing.T) {
+	a := newTestAuthenticator(t, log.NewNopLogger())
+
+	p, err := a.Authenticate("limited-key")
+	require.NoError(t, err)
+	require.NoError(t, a.Authorize(p, "127.0.0.1:1", "status", ScopeRead))
+	require.NoError(t, a.Authorize(p, "127.0.0.2:1", "status", ScopeRead))
+	err = a.Authorize(p, "127.0.0.3:1", "status", ScopeRead)
+	require.ErrorAs(t, err, &ErrRateLimited{})
+	assert.Equal(t, 429, HTTPStatus(err))
+
+	// Other keys are not affected.
+	p, err = a.Authenticate("reader-key")
+	require.NoError(t, err)
+	require.NoError(t, a.Authorize(p, "127.0.0.1:1", "status", ScopeRead))
+
+	// The principals with the same rate limit share a limiter, with a bucket
+	// for each of them.
+	a, err = NewAuthenticator(Config{
+		APIKeys: []APIKey{
+			{Name: "a", SHA256: hashKey("a-key"), Scopes: []Scope{ScopeRead}, RateLimit: 1},
+			{Name: "b", SHA256: hashKey("b-key"), Scopes: []Scope{ScopeRead}, RateLimit: 1},
+			{Name: "c", SHA256: hashKey("c-key"), Scopes: []Scope{ScopeRead}, RateLimit: 2},
+		},
+		AnonymousScopes: []Scope{ScopeRead},
+		RateLimit:       1,
+	}, log.NewNopLogger())
+	require.NoError(t, err)
+	principals := make(map[string]*Principal)
+	for _, token := range []string{"a-key", "b-key", "c-key", ""} {
+		p, err := a.Authenticate(token)
+		require.NoError(t, err)
+		principals[p.Name] = p
+	}
+	assert.Same(t, principals["a"].limiter, principals["b"].limiter)
+	assert.Same(t, principals["a"].limiter, principals["anonymous"].limiter)
+	assert.NotSame(t, principals["a"].limiter, principals["c"].limiter)
+	require.NoError(t, a.Authorize(principals["a"], "127.0.0.1:1", "status", ScopeRead))
+	require.NoError(t, a.Authorize(principals["b"], "127.0.0.1:1", "status", ScopeRead))
+	require.NoError(t, a.Authorize(principals["anonymous"], "127.0.0.1:1", "status", ScopeRead))
+	require.NoError(t, a.Authorize(principals["anonymous"], "127.0.0.2:1", "status", ScopeRead))
+	require.ErrorAs(t, a.Authorize(principals["anonymous"], "127.0.0.2:1", "status", ScopeRead), &ErrRateLimited{})
+	assert.Equal(t, 4, principals["a"].limiter.Len())
+}
+
+func TestAuthenticator_AuditLog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	a := newTestAuthenticator(t, log.NewLoggerWithColor(buf, false))
+
+	reader, err := a.Authenticate("reader-key")
+	require.NoError(t, err)
+	require.NoError(t, a.Authorize(reader, "127.0.0.1:1", "status", ScopeRead))
+	assert.Empty(t, buf.String())
+
+	require.Error(t, a.Authorize(reader, "127.0.0.1:1", "dial_peers", ScopeAdmin))
+	assert.Contains(t, buf.String(), "principal=reader")
+	assert.Contains(t, buf.String(), "allowed=false")
+
+	buf.Reset()
+	admin, err := a.Authenticate("admin-key")
+	require.NoError(t, err)
+	require.NoError(t, a.Authorize(admin, "127.0.0.1:1", "dial_peers", ScopeAdmin))
+	assert.Contains(t, buf.String(), "method=dial_peers")
+	assert.Contains(t, buf.String(), "allowed=true")
+}
+
+func TestSession(t *testing.T) {
+	// Without a session, all calls are allowed.
+	require.NoError(t, FromContext(context.Background()).Authorize("dial_peers", ScopeAdmin))
+
+	a := newTestAuthenticator(t, log.NewNopLogger())
+	p, err := a.Authenticate("")
+	require.NoError(t, err)
+	ctx := NewContext(context.Background(), a.NewSession(p, "127.0.0.1:1"))
+	require.NoError(t, FromContext(ctx).Authorize("status", ScopeRead))
+	require.ErrorAs(t, FromContext(ctx).Authorize("dial_peers", ScopeAdmin), &ErrForbidden{})
+}
+
+func TestBearerToken(t *testing.T) {
+	assert.Equal(t, "abc", BearerToken("Bearer abc"))
+	assert.Equal(t, "abc", BearerToken("bearer  abc"))
+	assert.Empty(t, BearerToken("Basic abc"))
+	assert.Empty(t, BearerToken(""))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	a := newTestAuthenticator(t, log.NewNopLogger())
+	interceptor := UnaryServerInterceptor(a, map[string]Scope{"test.ReadService": ScopeRead})
+	handler := func(ctx context.Context, _ any) (any, error) {
+		return FromContext(ctx).Principal.Name, nil
+	}
+
+	call := func(token, method string) (any, error) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}})
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	res, err := call("reader-key", "/test.ReadService/Get")
+	require.NoError(t, err)
+	assert.Equal(t, "reader", res)
+
+	res, err = call("", "/test.ReadService/Get")
+	require.NoError(t, err)
+	assert.Equal(t, "anonymous", res)
+
+	// Services without a scope require the admin scope.
+	_, err = call("reader-key", "/test.OtherService/Do")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = call("admin-key", "/test.OtherService/Do")
+	require.NoError(t, err)
+
+	_, err = call("unknown-key", "/test.ReadService/Get")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrUnknownScope is returned when a scope is not one of the scopes defined by
+// this package.
+type ErrUnknownScope struct {
+	Scope string
+}
+
+func (e ErrUnknownScope) Error() string {
+	return fmt.Sprintf("unknown scope %q", e.Scope)
+}
+
+// ErrUnauthenticated is returned when a caller presents invalid credentials.
+type ErrUnauthenticated struct {
+	Reason string
+}
+
+func (e ErrUnauthenticated) Error() string {
+	return "unauthenticated: " + e.Reason
+}
+
+// ErrForbidden is returned when a caller is not granted the scope required by
+// the method it calls.
+type ErrForbidden struct {
+	Principal string
+	Method    string
+	Scope     Scope
+}
+
+func (e ErrForbidden) Error() string {
+	return fmt.Sprintf("%s is not granted the %s scope required by %s", e.Principal, e.Scope, e.Method)
+}
+
+// ErrRateLimited is returned when a caller exceeds its rate limit.
+type ErrRateLimited struct {
+	Principal string
+	// RetryAfter is the time until the call would be allowed.
+	RetryAfter time.Duration
+}
+
+func (e ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limit of %s exceeded", e.Principal)
+}
+
+// HTTPStatus returns the HTTP status code of the response to a call failing
+// with err, one of the errors of this package.
+func HTTPStatus(err error) int {
+	switch err.(type) {
+	case ErrUnauthenticated:
+		return http.StatusUnauthorized
+	case ErrForbidden:
+		return http.StatusForbidden
+	case ErrRateLimited:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a gRPC interceptor authorizing the unary calls
+// with a. The calls to the methods of a service require the scope of the
+// service in scopes, or the admin scope if it has none.
+//
+// The bearer token is read from the "authorization" metadata, and the session
+// of the caller is added to the context of the call.
+func UnaryServerInterceptor(a *Authenticator, scopes map[string]Scope) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorizeGRPC(ctx, a, scopes, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor. The context of a stream is canceled when the
+// credentials of the caller expire.
+func StreamServerInterceptor(a *Authenticator, scopes map[string]Scope) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorizeGRPC(ss.Context(), a, scopes, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if expiresAt := FromContext(ctx).ExpiresAt(); !expiresAt.IsZero() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, expiresAt)
+			defer cancel()
+		}
+		return handler(srv, &sessionServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authorizeGRPC(ctx context.Context, a *Authenticator, scopes map[string]Scope, fullMethod string) (context.Context, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			token = BearerToken(values[0])
+		}
+	}
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	principal, err := a.Authenticate(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// fullMethod is "/<service>/<method>".
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	scope, ok := scopes[service]
+	if !ok {
+		scope = ScopeAdmin
+	}
+
+	session := a.NewSession(principal, remoteAddr)
+	if err := session.Authorize(fullMethod, scope); err != nil {
+		return nil, status.Error(grpcCode(err), err.Error())
+	}
+	return NewContext(ctx, session), nil
+}
+
+func grpcCode(err error) codes.Code {
+	switch err.(type) {
+	case ErrUnauthenticated:
+		return codes.Unauthenticated
+	case ErrForbidden:
+		return codes.PermissionDenied
+	case ErrRateLimited:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// sessionServerStream is a grpc.ServerStream whose context carries the session
+// of the caller.
+type sessionServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *sessionServerStream) Context() context.Context {
+	return s.ctx
+}
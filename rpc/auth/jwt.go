@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// minJWTSecretSize is the minimum size of the HMAC secret of JWTs, that of the
+// SHA-256 output as recommended by RFC 7518.
+const minJWTSecretSize = sha256.Size
+
+// jwtClaims are the claims of a JWT which are relevant to authorization.
+type jwtClaims struct {
+	Subject   string
+	Scopes    []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+}
+
+// verifyJWT verifies the signature of the HS256 JWT token with secret, and
+// returns its claims if they are valid at time now.
+//
+// The subject ("sub") and expiration time ("exp") claims are required. The
+// scopes are the space separated values of the "scope" claim, as in RFC 8693.
+func verifyJWT(token string, secret []byte, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("JWT header: %w", err)
+	}
+	// Only accepting HS256 prevents the "none" algorithm and key confusion.
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("JWT signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, errors.New("invalid JWT signature")
+	}
+
+	var payload struct {
+		Sub   string `json:"sub"`
+		Scope string `json:"scope"`
+		Exp   *int64 `json:"exp"`
+		Nbf   *int64 `json:"nbf"`
+	}
+	if err := decodeJWTPart(parts[1], &payload); err != nil {
+		return nil, fmt.Errorf("JWT claims: %w", err)
+	}
+	if payload.Sub == "" {
+		return nil, errors.New("JWT has no subject")
+	}
+	if payload.Exp == nil {
+		return nil, errors.New("JWT has no expiration time")
+	}
+
+	claims := &jwtClaims{
+		Subject:   payload.Sub,
+		Scopes:    strings.Fields(payload.Scope),
+		ExpiresAt: time.Unix(*payload.Exp, 0),
+	}
+	if !now.Before(claims.ExpiresAt) {
+		return nil, errors.New("JWT has expired")
+	}
+	if payload.Nbf != nil {
+		claims.NotBefore = time.Unix(*payload.Nbf, 0)
+		if now.Before(claims.NotBefore) {
+			return nil, errors.New("JWT is not valid yet")
+		}
+	}
+	return claims, nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	bz, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
@@ -11,8 +11,11 @@ import (
 
 	abci "github.com/cometbft/cometbft/v2/abci/types"
 	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
 	"github.com/cometbft/cometbft/v2/rpc/client"
 	ctypes "github.com/cometbft/cometbft/v2/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/v2/rpc/jsonrpc/client"
+	rpctest "github.com/cometbft/cometbft/v2/rpc/test"
 	"github.com/cometbft/cometbft/v2/types"
 )
 
@@ -95,6 +98,58 @@ func TestBlockEvents(t *testing.T) {
 	}
 }
 
+// subscribe to new blocks from the cursor of an event received earlier, and
+// make sure the subscription resumes from the next event.
+func TestBlockEventsResumeFromCursor(t *testing.T) {
+	c := getHTTPClient()
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	query := types.QueryForEvent(types.EventNewBlock).String()
+	eventCh, err := c.Subscribe(context.Background(), "TestBlockEventsResumeFromCursor", query)
+	require.NoError(t, err)
+
+	var events []ctypes.ResultEvent
+	for i := 0; i < 3; i++ {
+		select {
+		case event := <-eventCh:
+			require.NotEmpty(t, event.Cursor)
+			events = append(events, event)
+		case <-time.After(waitForEventTimeout):
+			t.Fatal("timed out waiting for a new block")
+		}
+	}
+
+	ws, err := jsonrpcclient.NewWS(rpctest.GetConfig().RPC.ListenAddress, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, ws.Start())
+	t.Cleanup(func() {
+		if err := ws.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.NoError(t, ws.SubscribeAfter(context.Background(), query, events[0].Cursor))
+
+	for _, expected := range events[1:] {
+		var event ctypes.ResultEvent
+		for event.Cursor == "" { // skip the response to subscribe
+			select {
+			case resp := <-ws.ResponsesCh:
+				require.Nil(t, resp.Error)
+				require.NoError(t, cmtjson.Unmarshal(resp.Result, &event))
+			case <-time.After(waitForEventTimeout):
+				t.Fatal("timed out waiting for a replayed block")
+			}
+		}
+		require.Equal(t, expected.Cursor, event.Cursor)
+		require.Equal(t, expected.Data.(types.EventDataNewBlock).Block.Height, event.Data.(types.EventDataNewBlock).Block.Height)
+	}
+}
+
 func TestTxEventsSentWithBroadcastTxAsync(t *testing.T) { testTxEventsSent(t, "async") }
 func TestTxEventsSentWithBroadcastTxSync(t *testing.T)  { testTxEventsSent(t, "sync") }
 
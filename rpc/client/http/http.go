@@ -102,6 +102,8 @@ var (
 	_ rpcClient = (*HTTP)(nil)
 	_ rpcClient = (*BatchHTTP)(nil)
 	_ rpcClient = (*baseRPCClient)(nil)
+
+	_ rpcclient.ResultsProofClient = (*HTTP)(nil)
 )
 
 // -----------------------------------------------------------------------------
@@ -446,12 +448,30 @@ func (c *baseRPCClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.R
 func (c *baseRPCClient) BlockResults(
 	ctx context.Context,
 	height *int64,
+) (*ctypes.ResultBlockResults, error) {
+	return c.blockResults(ctx, height, false)
+}
+
+func (c *baseRPCClient) BlockResultsWithProofs(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultBlockResults, error) {
+	return c.blockResults(ctx, height, true)
+}
+
+func (c *baseRPCClient) blockResults(
+	ctx context.Context,
+	height *int64,
+	prove bool,
 ) (*ctypes.ResultBlockResults, error) {
 	result := new(ctypes.ResultBlockResults)
 	params := make(map[string]any)
 	if height != nil {
 		params["height"] = height
 	}
+	if prove {
+		params["prove"] = prove
+	}
 	_, err := c.caller.Call(ctx, "block_results", params, result)
 	if err != nil {
 		return nil, err
@@ -616,20 +636,29 @@ type WSEvents struct {
 	ws       *jsonrpcclient.WSClient
 
 	mtx           cmtsync.RWMutex
-	subscriptions map[string]chan ctypes.ResultEvent // query -> chan
+	subscriptions map[string]*wsSubscription // query -> subscription
+}
+
+type wsSubscription struct {
+	out chan ctypes.ResultEvent
+	// cursor of the last event delivered, to resume from after reconnecting
+	cursor string
+	// whether an event with a cursor was dropped since resubscribing, in which
+	// case the cursor is no longer advanced, not to skip that event
+	dropped bool
 }
 
 func newWSEvents(remote, endpoint string) (*WSEvents, error) {
 	w := &WSEvents{
 		endpoint:      endpoint,
 		remote:        remote,
-		subscriptions: make(map[string]chan ctypes.ResultEvent),
+		subscriptions: make(map[string]*wsSubscription),
 	}
 	w.BaseService = *service.NewBaseService(nil, "WSEvents", w)
 
 	var err error
 	w.ws, err = jsonrpcclient.NewWS(w.remote, w.endpoint, jsonrpcclient.OnReconnect(func() {
-		// resubscribe immediately
+		// resubscribe immediately, resuming from the last events received
 		w.redoSubscriptionsAfter(0 * time.Second)
 	}))
 	if err != nil {
@@ -664,6 +693,11 @@ func (w *WSEvents) OnStop() {
 //
 // Channel is never closed to prevent clients from seeing an erroneous event.
 //
+// After reconnecting, the subscription resumes from the event following the
+// last one delivered, so that no event is missed unless the node no longer has
+// it. If events were dropped because the channel was full, the ones delivered
+// after them may be delivered again.
+//
 // It returns an error if WSEvents is not running.
 func (w *WSEvents) Subscribe(ctx context.Context, _, query string,
 	outCapacity ...int,
@@ -685,7 +719,7 @@ func (w *WSEvents) Subscribe(ctx context.Context, _, query string,
 	w.mtx.Lock()
 	// subscriber param is ignored because CometBFT will override it with
 	// remote IP anyway.
-	w.subscriptions[query] = outc
+	w.subscriptions[query] = &wsSubscription{out: outc}
 	w.mtx.Unlock()
 
 	return outc, nil
@@ -728,7 +762,7 @@ func (w *WSEvents) UnsubscribeAll(ctx context.Context, _ string) error {
 	}
 
 	w.mtx.Lock()
-	w.subscriptions = make(map[string]chan ctypes.ResultEvent)
+	w.subscriptions = make(map[string]*wsSubscription)
 	w.mtx.Unlock()
 
 	return nil
@@ -739,13 +773,20 @@ func (w *WSEvents) UnsubscribeAll(ctx context.Context, _ string) error {
 func (w *WSEvents) redoSubscriptionsAfter(d time.Duration) {
 	time.Sleep(d)
 
-	w.mtx.RLock()
-	defer w.mtx.RUnlock()
-	for q := range w.subscriptions {
-		err := w.ws.Subscribe(context.Background(), q)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for q, sub := range w.subscriptions {
+		var err error
+		if sub.cursor == "" {
+			err = w.ws.Subscribe(context.Background(), q)
+		} else {
+			err = w.ws.SubscribeAfter(context.Background(), q, sub.cursor)
+		}
 		if err != nil {
 			w.Logger.Error("Failed to resubscribe", "err", err)
+			continue
 		}
+		sub.dropped = false
 	}
 }
 
@@ -782,19 +823,28 @@ func (w *WSEvents) eventListener() {
 				continue
 			}
 
-			w.mtx.RLock()
-			if out, ok := w.subscriptions[result.Query]; ok {
-				if cap(out) == 0 {
-					out <- *result
+			w.mtx.Lock()
+			if sub, ok := w.subscriptions[result.Query]; ok {
+				delivered := true
+				if cap(sub.out) == 0 {
+					sub.out <- *result
 				} else {
 					select {
-					case out <- *result:
+					case sub.out <- *result:
 					default:
+						delivered = false
 						w.Logger.Error("wanted to publish ResultEvent, but out channel is full", "result", result, "query", result.Query)
 					}
 				}
+				if result.Cursor != "" {
+					if !delivered {
+						sub.dropped = true
+					} else if !sub.dropped {
+						sub.cursor = result.Cursor
+					}
+				}
 			}
-			w.mtx.RUnlock()
+			w.mtx.Unlock()
 		case <-w.Quit():
 			return
 		}
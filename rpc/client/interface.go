@@ -146,6 +146,15 @@ type EvidenceClient interface {
 	BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
 }
 
+// ResultsProofClient is implemented by the clients which can prove the results
+// of the txs of a block. It is not part of SignClient, so that the clients
+// implementing it need not implement ResultsProofClient too.
+type ResultsProofClient interface {
+	// BlockResultsWithProofs returns the block results with a merkle proof of
+	// each tx result against the LastResultsHash of the next header.
+	BlockResultsWithProofs(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+}
+
 // RemoteClient is a Client, which can also return the remote network address.
 type RemoteClient interface {
 	Client
@@ -58,7 +58,10 @@ func New(node *nm.Node) *Local {
 	}
 }
 
-var _ rpcclient.Client = (*Local)(nil)
+var (
+	_ rpcclient.Client             = (*Local)(nil)
+	_ rpcclient.ResultsProofClient = (*Local)(nil)
+)
 
 type ErrParseQuery struct {
 	Source error
@@ -181,7 +184,11 @@ func (c *Local) BlockByHash(_ context.Context, hash []byte) (*ctypes.ResultBlock
 }
 
 func (c *Local) BlockResults(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
-	return c.env.BlockResults(c.ctx, height)
+	return c.env.BlockResults(c.ctx, height, false)
+}
+
+func (c *Local) BlockResultsWithProofs(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	return c.env.BlockResults(c.ctx, height, true)
 }
 
 func (c *Local) Header(_ context.Context, height *int64) (*ctypes.ResultHeader, error) {
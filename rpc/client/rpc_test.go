@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"strings"
@@ -94,6 +95,38 @@ func TestCorsEnabled(t *testing.T) {
 	assert.Equal(t, resp.Header.Get("Access-Control-Allow-Origin"), origin)
 }
 
+func TestRESTGateway(t *testing.T) {
+	remote := strings.ReplaceAll(rpctest.GetConfig().RPC.ListenAddress, "tcp", "http")
+	get := func(path string) (*http.Response, []byte) {
+		t.Helper()
+		resp, err := http.Get(remote + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, body
+	}
+
+	c := getHTTPClient()
+	h := int64(1)
+	want, err := c.Block(ctx, &h)
+	require.NoError(t, err)
+
+	resp, body := get("/rest/blocks/1")
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+	var block ctypes.ResultBlock
+	require.NoError(t, cmtjson.Unmarshal(body, &block))
+	assert.Equal(t, want.BlockID, block.BlockID)
+
+	resp, body = get("/rest/headers/1000000")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+
+	resp, body = get("/rest/openapi.json")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "/rest/blocks/{height}")
+}
+
 // Make sure status is correct (we connect properly).
 func TestStatus(t *testing.T) {
 	for i, c := range GetClients() {
@@ -296,6 +329,15 @@ func TestAppCalls(t *testing.T) {
 			// check success code
 			assert.EqualValues(0, blockResults.TxResults[0].Code)
 		}
+		assert.Empty(blockResults.TxResultProofs)
+
+		// the results are proven against the next header
+		blockResults, err = c.(client.ResultsProofClient).BlockResultsWithProofs(context.Background(), &txh)
+		require.NoError(err)
+		if assert.Len(blockResults.TxResultProofs, 1) {
+			err = types.VerifyResult(header.Header.LastResultsHash, blockResults.TxResults[0], blockResults.TxResultProofs[0])
+			require.NoError(err)
+		}
 
 		// check blockchain info, now that we know there is info
 		info, err := c.BlockchainInfo(context.Background(), apph, apph)
@@ -3,6 +3,7 @@ package core
 import (
 	"sort"
 
+	"github.com/cometbft/cometbft/v2/crypto/merkle"
 	"github.com/cometbft/cometbft/v2/libs/bytes"
 	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
 	cmtquery "github.com/cometbft/cometbft/v2/libs/pubsub/query"
@@ -167,8 +168,13 @@ func (env *Environment) Commit(_ *rpctypes.Context, heightPtr *int64) (*ctypes.R
 // Results are for the height of the block containing the txs.
 // Thus response.results.deliver_tx[5] is the results of executing
 // getBlock(h).Txs[5]
+//
+// If prove is true, the response includes a merkle proof of each tx result
+// against the LastResultsHash of the header at height+1. Only the
+// deterministic fields of the results (code, data and gas) are proven, not
+// their events.
 // More: https://docs.cometbft.com/main/rpc/#/Info/block_results
-func (env *Environment) BlockResults(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockResults, error) {
+func (env *Environment) BlockResults(_ *rpctypes.Context, heightPtr *int64, prove bool) (*ctypes.ResultBlockResults, error) {
 	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
 	if err != nil {
 		return nil, err
@@ -179,9 +185,15 @@ func (env *Environment) BlockResults(_ *rpctypes.Context, heightPtr *int64) (*ct
 		return nil, err
 	}
 
+	var proofs []merkle.Proof
+	if prove {
+		proofs = types.NewResults(results.TxResults).ProveResults()
+	}
+
 	return &ctypes.ResultBlockResults{
 		Height:                height,
 		TxResults:             results.TxResults,
+		TxResultProofs:        proofs,
 		FinalizeBlockEvents:   results.Events,
 		ValidatorUpdates:      results.ValidatorUpdates,
 		ConsensusParamUpdates: results.ConsensusParamUpdates,
@@ -13,6 +13,7 @@ import (
 	rpctypes "github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/state/mocks"
+	"github.com/cometbft/cometbft/v2/types"
 )
 
 func TestBlockchainInfo(t *testing.T) {
@@ -108,7 +109,7 @@ func TestBlockResults(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		res, err := env.BlockResults(&rpctypes.Context{}, &tc.height)
+		res, err := env.BlockResults(&rpctypes.Context{}, &tc.height, false)
 		if tc.wantErr {
 			require.Error(t, err)
 		} else {
@@ -116,4 +117,14 @@ func TestBlockResults(t *testing.T) {
 			assert.Equal(t, tc.wantRes, res)
 		}
 	}
+
+	// Each result is proven against the results hash.
+	height := int64(100)
+	res, err := env.BlockResults(&rpctypes.Context{}, &height, true)
+	require.NoError(t, err)
+	require.Len(t, res.TxResultProofs, len(results.TxResults))
+	resultsHash := types.NewResults(results.TxResults).Hash()
+	for i, proof := range res.TxResultProofs {
+		require.NoError(t, types.VerifyResult(resultsHash, res.TxResults[i], proof), "%d", i)
+	}
 }
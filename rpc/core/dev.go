@@ -10,3 +10,46 @@ func (env *Environment) UnsafeFlushMempool(*rpctypes.Context) (*ctypes.ResultUns
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeBackup starts a backup of the node data, in the directory name of the
+// backup directory of the node, without stopping the node. The backup is
+// taken in the background after the next height is committed, its status is
+// returned by UnsafeBackupStatus, and it's restored with the restore-backup
+// command.
+func (env *Environment) UnsafeBackup(_ *rpctypes.Context, name string) (*ctypes.ResultUnsafeBackup, error) {
+	if env.NodeBackup == nil {
+		return nil, ErrBackupUnsupported
+	}
+	if name == "" {
+		return nil, ErrNoBackupName
+	}
+	dir, err := env.NodeBackup.StartBackup(name)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsafeBackup{Dir: dir}, nil
+}
+
+// UnsafeBackupStatus returns the status of the last backup started with
+// UnsafeBackup.
+func (env *Environment) UnsafeBackupStatus(*rpctypes.Context) (*ctypes.ResultUnsafeBackupStatus, error) {
+	if env.NodeBackup == nil {
+		return nil, ErrBackupUnsupported
+	}
+	status, ok := env.NodeBackup.BackupStatus()
+	if !ok {
+		return nil, ErrNoBackup
+	}
+	res := &ctypes.ResultUnsafeBackupStatus{
+		Dir:       status.Dir,
+		Running:   status.Running,
+		ChainID:   status.Manifest.ChainID,
+		Height:    status.Manifest.Height,
+		AppHash:   status.Manifest.AppHash,
+		Databases: status.Manifest.Databases,
+	}
+	if status.Err != nil {
+		res.Error = status.Err.Error()
+	}
+	return res, nil
+}
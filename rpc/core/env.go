@@ -13,6 +13,8 @@ import (
 	abcicli "github.com/cometbft/cometbft/v2/abci/client"
 	cfg "github.com/cometbft/cometbft/v2/config"
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/internal/backup"
+	"github.com/cometbft/cometbft/v2/internal/eventlog"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	mempl "github.com/cometbft/cometbft/v2/mempool"
 	"github.com/cometbft/cometbft/v2/p2p"
@@ -68,6 +70,12 @@ type syncReactor interface {
 	WaitSync() bool
 }
 
+// A node taking backups of its data in the background.
+type nodeBackup interface {
+	StartBackup(name string) (string, error)
+	BackupStatus() (backup.Status, bool)
+}
+
 type mempoolReactor interface {
 	syncReactor
 	TryAddTx(tx types.Tx, sender p2p.Peer) (*abcicli.ReqRes, error)
@@ -96,12 +104,14 @@ type Environment struct {
 	MempoolReactor   mempoolReactor
 	P2PPeers         peers
 	P2PTransport     transport
+	NodeBackup       nodeBackup
 
 	// objects
 	PubKey       crypto.PubKey
 	TxIndexer    txindex.TxIndexer
 	BlockIndexer indexer.BlockIndexer
 	EventBus     *types.EventBus // thread safe
+	EventLog     *eventlog.Log   // thread safe
 	Mempool      mempl.Mempool
 
 	Logger log.Logger
@@ -203,7 +213,7 @@ func validatePage(pagePtr *int, perPage, totalCount int) (int, error) {
 	}
 	page := *pagePtr
 	if page <= 0 || page > pages {
-		return 1, fmt.Errorf("page should be within [1, %d] range, given %d", pages, page)
+		return 1, ErrInvalidPage{Page: page, Pages: pages}
 	}
 
 	return page, nil
@@ -237,16 +247,14 @@ func (env *Environment) getHeight(latestHeight int64, heightPtr *int64) (int64,
 	if heightPtr != nil {
 		height := *heightPtr
 		if height <= 0 {
-			return 0, fmt.Errorf("height must be greater than 0, but got %d", height)
+			return 0, ErrInvalidHeight{Height: height}
 		}
 		if height > latestHeight {
-			return 0, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d",
-				height, latestHeight)
+			return 0, ErrHeightNotReached{Height: height, Latest: latestHeight}
 		}
 		base := env.BlockStore.Base()
 		if height < base {
-			return 0, fmt.Errorf("height %d is not available, lowest height is %d",
-				height, base)
+			return 0, ErrHeightPruned{Height: height, Base: base}
 		}
 		return height, nil
 	}
@@ -3,6 +3,9 @@ package core
 import (
 	"errors"
 	"fmt"
+	"net/http"
+
+	"github.com/cometbft/cometbft/v2/internal/backup"
 )
 
 var (
@@ -18,6 +21,10 @@ var (
 	ErrGenesisRespSize         = errors.New("genesis response is too large, please use the genesis_chunked API instead")
 	ErrChunkNotInitialized     = errors.New("genesis chunks are not initialized")
 	ErrNoChunks                = errors.New("genesis file is small, therefore there are no chunks to serve. Please use the /genesis API instead")
+	ErrBackupUnsupported       = errors.New("backups are not supported by this node")
+	ErrNoBackupName            = errors.New("no backup name was provided")
+	ErrNoBackup                = errors.New("no backup was started")
+	ErrEventLogDisabled        = errors.New("the event log is disabled, subscriptions can't be resumed")
 )
 
 type ErrMaxSubscription struct {
@@ -45,6 +52,41 @@ func (e ErrHeightMinGTMax) Error() string {
 	return fmt.Sprintf("min height %d can't be greater than max height %d", e.Min, e.Max)
 }
 
+type ErrInvalidHeight struct {
+	Height int64
+}
+
+func (e ErrInvalidHeight) Error() string {
+	return fmt.Sprintf("height must be greater than 0, but got %d", e.Height)
+}
+
+type ErrHeightNotReached struct {
+	Height int64
+	Latest int64
+}
+
+func (e ErrHeightNotReached) Error() string {
+	return fmt.Sprintf("height %d must be less than or equal to the current blockchain height %d", e.Height, e.Latest)
+}
+
+type ErrHeightPruned struct {
+	Height int64
+	Base   int64
+}
+
+func (e ErrHeightPruned) Error() string {
+	return fmt.Sprintf("height %d is not available, lowest height is %d", e.Height, e.Base)
+}
+
+type ErrInvalidPage struct {
+	Page  int
+	Pages int
+}
+
+func (e ErrInvalidPage) Error() string {
+	return fmt.Sprintf("page should be within [1, %d] range, given %d", e.Pages, e.Page)
+}
+
 type ErrQueryLength struct {
 	length    int
 	maxLength int
@@ -159,3 +201,60 @@ type ErrInvalidNodeType struct {
 func (e ErrInvalidNodeType) Error() string {
 	return fmt.Sprintf("peer %s has an invalid node type: maxLength %s but got %s", e.PeerID, e.Expected, e.Actual)
 }
+
+// HTTPStatus returns the HTTP status code the REST gateway responds with when
+// an RPC function returns err.
+func HTTPStatus(err error) int {
+	var (
+		errInvalidHeight    ErrInvalidHeight
+		errHeightMinGTMax   ErrHeightMinGTMax
+		errInvalidPage      ErrInvalidPage
+		errQueryLength      ErrQueryLength
+		errInvalidOrderBy   ErrInvalidOrderBy
+		errValidation       ErrValidation
+		errInvalidChunkID   ErrInvalidChunkID
+		errHeightNotReached ErrHeightNotReached
+		errHeightPruned     ErrHeightPruned
+		errTxNotFound       ErrTxNotFound
+		errMaxSubscription  ErrMaxSubscription
+		errBackupExists     backup.ErrBackupExists
+	)
+	switch {
+	case errors.Is(err, ErrNegativeHeight),
+		errors.Is(err, ErrNoEvidence),
+		errors.Is(err, ErrorEmptyTxHash),
+		errors.Is(err, ErrNoBackupName),
+		errors.Is(err, backup.ErrInvalidBackupName),
+		errors.As(err, &errInvalidHeight),
+		errors.As(err, &errHeightMinGTMax),
+		errors.As(err, &errInvalidPage),
+		errors.As(err, &errQueryLength),
+		errors.As(err, &errInvalidOrderBy),
+		errors.As(err, &errValidation),
+		errors.As(err, &errInvalidChunkID):
+		return http.StatusBadRequest
+	case errors.As(err, &errHeightNotReached),
+		errors.As(err, &errHeightPruned),
+		errors.As(err, &errTxNotFound),
+		errors.Is(err, ErrNoBackup):
+		return http.StatusNotFound
+	case errors.Is(err, backup.ErrBackupInProgress),
+		errors.As(err, &errBackupExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrGenesisRespSize),
+		errors.Is(err, ErrNoChunks):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrEndpointClosedCatchingUp),
+		errors.As(err, &errMaxSubscription):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrBlockIndexing),
+		errors.Is(err, ErrTxIndexingDisabled),
+		errors.Is(err, ErrBackupUnsupported),
+		errors.Is(err, ErrEventLogDisabled):
+		return http.StatusNotImplemented
+	case errors.Is(err, ErrTimedOutWaitingForTx):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	testCases := []struct {
+		err  error
+		want int
+	}{
+		{ErrInvalidHeight{Height: -1}, http.StatusBadRequest},
+		{ErrInvalidPage{Page: 3, Pages: 2}, http.StatusBadRequest},
+		{ErrValidation{Source: errors.New("bad"), ValType: "evidence"}, http.StatusBadRequest},
+		{ErrHeightNotReached{Height: 10, Latest: 5}, http.StatusNotFound},
+		{ErrHeightPruned{Height: 1, Base: 5}, http.StatusNotFound},
+		{ErrTxNotFound{Hash: []byte{1}}, http.StatusNotFound},
+		{ErrServiceConfig{ErrNoChunks}, http.StatusUnprocessableEntity},
+		{ErrEndpointClosedCatchingUp, http.StatusServiceUnavailable},
+		{ErrTxIndexingDisabled, http.StatusNotImplemented},
+		{ErrEventLogDisabled, http.StatusNotImplemented},
+		{fmt.Errorf("waiting: %w", ErrTimedOutWaitingForTx), http.StatusGatewayTimeout},
+		{errors.New("unexpected"), http.StatusInternalServerError},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, HTTPStatus(tc.err), tc.err.Error())
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/cometbft/cometbft/v2/internal/eventlog"
 	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
 	cmtquery "github.com/cometbft/cometbft/v2/libs/pubsub/query"
 	ctypes "github.com/cometbft/cometbft/v2/rpc/core/types"
+	rpc "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
 	rpctypes "github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
 )
 
@@ -30,9 +33,11 @@ func (e ErrParseQuery) Unwrap() error {
 	return e.Source
 }
 
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. If a cursor is given, the subscription
+// resumes from the event following it, replaying the events of the blocks
+// committed since. The other events are delivered as they are published.
 // More: https://docs.cometbft.com/main/rpc/#/Websocket/subscribe
-func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func (env *Environment) Subscribe(ctx *rpctypes.Context, query, cursor string) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
 	switch {
@@ -42,18 +47,42 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 		return nil, ErrMaxPerClientSubscription{env.Config.MaxSubscriptionsPerClient}
 	case len(query) > maxQueryLength:
 		return nil, ErrQueryLength{len(query), maxQueryLength}
+	case env.EventLog == nil && cursor != "":
+		return nil, ErrEventLogDisabled
 	}
 
-	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
+	env.Logger.Info("Subscribe to query", "remote", addr, "query", query, "cursor", cursor)
 
 	q, err := cmtquery.New(query)
 	if err != nil {
 		return nil, ErrParseQuery{Source: err}
 	}
 
+	var (
+		pos     eventlog.Cursor
+		changed <-chan struct{}
+		items   []eventlog.Item
+	)
+	if env.EventLog != nil {
+		pos = env.EventLog.Last()
+		if cursor != "" {
+			if pos, err = eventlog.ParseCursor(cursor); err != nil {
+				return nil, err
+			}
+		}
+		// Get the channel before the events, not to miss the next one.
+		changed = env.EventLog.Changed()
+		if items, err = env.EventLog.After(pos); err != nil {
+			return nil, err
+		}
+	}
+
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
+	// The subscription accounts for the client, and is canceled when it
+	// unsubscribes. The events kept in the event log are delivered from it,
+	// which assigns their cursors, and the other events from the subscription.
 	sub, err := env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
 	if err != nil {
 		return nil, err
@@ -63,54 +92,103 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
+	var cancelOnce sync.Once
+	cancelSubscription := func(err error) {
+		cancelOnce.Do(func() {
+			resp := rpctypes.RPCServerError(subscriptionID, ErrSubCanceled{err.Error()})
+			if !ctx.WSConn.TryWriteRPCResponse(resp) {
+				env.Logger.Info("Can't write response (slow client)",
+					"to", addr, "subscriptionID", subscriptionID, "err", err)
+			}
+			if err := env.EventBus.Unsubscribe(context.Background(), addr, q); err != nil && !errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+				env.Logger.Error("Can't unsubscribe", "remote", addr, "query", query, "err", err)
+			}
+		})
+	}
+	// writeEvent writes an event to the client, and returns false if no more
+	// events should be written.
+	writeEvent := func(resultEvent *ctypes.ResultEvent) bool {
+		resp := rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := ctx.WSConn.WriteRPCResponse(writeCtx, resp)
+		cancel()
+		if errors.Is(err, rpc.ErrConnectionStopped) {
+			return false
+		}
+		if err != nil {
+			env.Logger.Info("Can't write response (slow client)",
+				"to", addr, "subscriptionID", subscriptionID, "err", err)
+
+			if closeIfSlow {
+				cancelSubscription(ErrSlowClient)
+				return false
+			}
+		}
+		return true
+	}
+
 	go func() {
 		for {
 			select {
 			case msg := <-sub.Out():
-				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
-				)
-				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
-					env.Logger.Info("Can't write response (slow client)",
-						"to", addr, "subscriptionID", subscriptionID, "err", err)
-
-					if closeIfSlow {
-						var (
-							err  = ErrSubCanceled{ErrSlowClient.Error()}
-							resp = rpctypes.RPCServerError(subscriptionID, err)
-						)
-						if !ctx.WSConn.TryWriteRPCResponse(resp) {
-							env.Logger.Info("Can't write response (slow client)",
-								"to", addr, "subscriptionID", subscriptionID, "err", err)
-						}
-						return
-					}
+				if env.EventLog != nil && eventlog.IsLogged(msg.Data()) {
+					continue
+				}
+				if !writeEvent(&ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}) {
+					return
 				}
 			case <-sub.Canceled():
 				if !errors.Is(sub.Err(), cmtpubsub.ErrUnsubscribed) {
-					var reason string
-					if sub.Err() == nil {
-						reason = ErrCometBFTExited.Error()
-					} else {
-						reason = sub.Err().Error()
-					}
-					var (
-						err  = ErrSubCanceled{reason}
-						resp = rpctypes.RPCServerError(subscriptionID, err)
-					)
-					if !ctx.WSConn.TryWriteRPCResponse(resp) {
-						env.Logger.Info("Can't write response (slow client)",
-							"to", addr, "subscriptionID", subscriptionID, "err", err)
+					reason := ErrCometBFTExited
+					if sub.Err() != nil {
+						reason = sub.Err()
 					}
+					cancelSubscription(reason)
 				}
 				return
 			}
 		}
 	}()
 
+	if env.EventLog == nil {
+		return &ctypes.ResultSubscribe{}, nil
+	}
+
+	go func() {
+		for {
+			for _, item := range items {
+				pos = item.Cursor
+				if match, err := q.Matches(item.Events); err != nil || !match {
+					continue
+				}
+				resultEvent := &ctypes.ResultEvent{Query: query, Data: item.Data, Events: item.Events, Cursor: item.Cursor.String()}
+				if !writeEvent(resultEvent) {
+					return
+				}
+			}
+
+			// Wait for the next event, unless replaying events from the stores.
+			if len(items) == 0 {
+				select {
+				case <-changed:
+				case <-sub.Canceled():
+					return
+				}
+			}
+			select {
+			case <-sub.Canceled():
+				return
+			default:
+			}
+
+			changed = env.EventLog.Changed()
+			if items, err = env.EventLog.After(pos); err != nil {
+				cancelSubscription(err)
+				return
+			}
+		}
+	}()
+
 	return &ctypes.ResultSubscribe{}, nil
 }
 
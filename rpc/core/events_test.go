@@ -0,0 +1,117 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/eventlog"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	ctypes "github.com/cometbft/cometbft/v2/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// wsConn records the responses written to a WebSocket connection.
+type wsConn struct {
+	responses chan rpctypes.RPCResponse
+}
+
+func (*wsConn) GetRemoteAddr() string { return "127.0.0.1:1234" }
+
+func (c *wsConn) WriteRPCResponse(ctx context.Context, res rpctypes.RPCResponse) error {
+	select {
+	case c.responses <- res:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *wsConn) TryWriteRPCResponse(res rpctypes.RPCResponse) bool {
+	select {
+	case c.responses <- res:
+		return true
+	default:
+		return false
+	}
+}
+
+func (*wsConn) Context() context.Context { return context.Background() }
+
+func newEventsEnv(t *testing.T, withEventLog bool) *Environment {
+	t.Helper()
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { require.NoError(t, eventBus.Stop()) })
+
+	env := &Environment{
+		EventBus: eventBus,
+		Logger:   log.TestingLogger(),
+		Config:   *cfg.DefaultRPCConfig(),
+	}
+	if withEventLog {
+		blockStore := store.NewBlockStore(dbm.NewMemDB())
+		stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+		env.EventLog = eventlog.New(eventBus, blockStore, stateStore, 10)
+		require.NoError(t, env.EventLog.Start())
+		t.Cleanup(func() { require.NoError(t, env.EventLog.Stop()) })
+	}
+	return env
+}
+
+func receiveEvent(t *testing.T, conn *wsConn) ctypes.ResultEvent {
+	t.Helper()
+	select {
+	case res := <-conn.responses:
+		require.Nil(t, res.Error)
+		var event ctypes.ResultEvent
+		require.NoError(t, cmtjson.Unmarshal(res.Result, &event))
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no event received")
+		return ctypes.ResultEvent{}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	for _, withEventLog := range []bool{true, false} {
+		env := newEventsEnv(t, withEventLog)
+		conn := &wsConn{responses: make(chan rpctypes.RPCResponse, 10)}
+		ctx := &rpctypes.Context{JSONReq: &rpctypes.RPCRequest{}, WSConn: conn}
+
+		_, err := env.Subscribe(ctx, "tm.event EXISTS", "")
+		require.NoError(t, err)
+
+		// Only the events of blocks, kept in the event log, carry a cursor.
+		block := types.MakeBlock(1, nil, &types.Commit{}, nil)
+		require.NoError(t, env.EventBus.PublishEventNewBlock(types.EventDataNewBlock{Block: block}))
+		event := receiveEvent(t, conn)
+		require.IsType(t, types.EventDataNewBlock{}, event.Data)
+		if withEventLog {
+			require.Equal(t, "1.0", event.Cursor)
+		} else {
+			require.Empty(t, event.Cursor)
+		}
+
+		require.NoError(t, env.EventBus.PublishEventNewRound(types.EventDataNewRound{Height: 2}))
+		event = receiveEvent(t, conn)
+		require.IsType(t, types.EventDataNewRound{}, event.Data)
+		require.Empty(t, event.Cursor)
+
+		// Without the event log, subscriptions can't be resumed.
+		_, err = env.Subscribe(ctx, "tm.event = 'NewBlock'", "1.0")
+		if withEventLog {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, ErrEventLogDisabled)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"github.com/cometbft/cometbft/v2/rpc/auth"
 	rpc "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
 )
 
@@ -9,56 +10,68 @@ import (
 type RoutesMap map[string]*rpc.RPCFunc
 
 // Routes is a map of available routes.
+//
+// The cost of a route reflects the load a call puts on the node when rate
+// limiting is enabled: searches and paginated queries cost more than lookups.
 func (env *Environment) GetRoutes() RoutesMap {
 	return RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
-		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
-		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
+		"subscribe": rpc.NewWSRPCFunc(env.Subscribe, "query,cursor", rpc.OptionalArgs("cursor"),
+			rpc.Scope(auth.ScopeSubscribe)),
+		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query", rpc.Scope(auth.ScopeSubscribe)),
+		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, "", rpc.Scope(auth.ScopeSubscribe)),
 
 		// info AP
 		"health":               rpc.NewRPCFunc(env.Health, ""),
 		"status":               rpc.NewRPCFunc(env.Status, ""),
 		"net_info":             rpc.NewRPCFunc(env.NetInfo, ""),
-		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
-		"genesis":              rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
-		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
-		"block":                rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
-		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
-		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height")),
-		"commit":               rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
-		"header":               rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
-		"header_by_hash":       rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
-		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx"),
-		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable()),
-		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
-		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height")),
-		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, ""),
+		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable(), rpc.REST("GET /blocks"), rpc.Cost(3)),
+		"genesis":              rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable(), rpc.Cost(5)),
+		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable(), rpc.REST("GET /genesis/chunks/{chunk}"), rpc.Cost(2)),
+		"block":                rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height"), rpc.REST("GET /blocks/{height}")),
+		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable(), rpc.REST("GET /blocks/by_hash/{hash}")),
+		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height,prove", rpc.OptionalArgs("prove"), rpc.Cacheable("height"), rpc.REST("GET /block_results/{height}"), rpc.Cost(5)),
+		"commit":               rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height"), rpc.REST("GET /commits/{height}")),
+		"header":               rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height"), rpc.REST("GET /headers/{height}")),
+		"header_by_hash":       rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable(), rpc.REST("GET /headers/by_hash/{hash}")),
+		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx", rpc.REST("POST /txs/check"), rpc.Scope(auth.ScopeBroadcast), rpc.Cost(3)),
+		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable(), rpc.REST("GET /txs/{hash}")),
+		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", rpc.REST("GET /txs/search"), rpc.Cost(10)),
+		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", rpc.REST("GET /blocks/search"), rpc.Cost(10)),
+		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height"), rpc.REST("GET /validators/{height}"), rpc.Cost(2)),
+		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, "", rpc.REST("GET /consensus_state/dump"), rpc.Cost(5)),
 		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, ""),
-		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
-		"unconfirmed_tx":       rpc.NewRPCFunc(env.UnconfirmedTx, "hash"),
-		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit"),
-		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height"), rpc.REST("GET /consensus_params/{height}")),
+		"unconfirmed_tx":       rpc.NewRPCFunc(env.UnconfirmedTx, "hash", rpc.REST("GET /unconfirmed_txs/{hash}")),
+		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit", rpc.Cost(3)),
+		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, "", rpc.REST("GET /unconfirmed_txs/count")),
 
 		// tx broadcast API
-		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx"),
-		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx"),
-		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx"),
+		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx", rpc.REST("POST /txs/commit"), rpc.Scope(auth.ScopeBroadcast), rpc.Cost(5)),
+		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx", rpc.REST("POST /txs"), rpc.Scope(auth.ScopeBroadcast)),
+		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx", rpc.REST("POST /txs/async"), rpc.Scope(auth.ScopeBroadcast)),
 
 		// abci API
-		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove"),
-		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", rpc.Cacheable()),
+		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove", rpc.REST("GET /abci/query"), rpc.Cost(5)),
+		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", rpc.Cacheable(), rpc.REST("GET /abci/info")),
 
 		// evidence API
-		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence"),
+		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence", rpc.REST("POST /evidence"),
+			rpc.Scope(auth.ScopeBroadcast)),
 	}
 }
 
 // AddUnsafeRoutes adds unsafe routes.
 func (env *Environment) AddUnsafeRoutes(routes RoutesMap) {
 	// control API
-	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds")
-	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private")
-	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "")
+	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds", rpc.REST("POST /dial_seeds"),
+		rpc.Scope(auth.ScopeAdmin))
+	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private",
+		rpc.REST("POST /dial_peers"), rpc.Scope(auth.ScopeAdmin))
+	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "", rpc.REST("POST /unsafe_flush_mempool"),
+		rpc.Scope(auth.ScopeAdmin))
+	routes["unsafe_backup"] = rpc.NewRPCFunc(env.UnsafeBackup, "name", rpc.REST("POST /unsafe_backup"),
+		rpc.Scope(auth.ScopeAdmin))
+	routes["unsafe_backup_status"] = rpc.NewRPCFunc(env.UnsafeBackupStatus, "",
+		rpc.REST("GET /unsafe_backup_status"), rpc.Scope(auth.ScopeAdmin))
 }
@@ -0,0 +1,76 @@
+package coretypes
+
+import (
+	"github.com/cosmos/gogoproto/proto"
+
+	blocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v2"
+	brs "github.com/cometbft/cometbft/api/cometbft/services/block_results/v2"
+	valsvc "github.com/cometbft/cometbft/api/cometbft/services/validator/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+)
+
+// The results below can be encoded as Protobuf, using the messages returned by
+// the equivalent gRPC services where there is one.
+
+// ToProto returns the result as the response of the gRPC block service to
+// GetByHeight.
+func (r *ResultBlock) ToProto() (proto.Message, error) {
+	blockID := r.BlockID.ToProto()
+	resp := &blocksvc.GetByHeightResponse{BlockId: &blockID}
+	if r.Block != nil {
+		block, err := r.Block.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		resp.Block = block
+	}
+	return resp, nil
+}
+
+// ToProto returns the header, empty if it was not found.
+func (r *ResultHeader) ToProto() (proto.Message, error) {
+	if r.Header == nil {
+		return &cmtproto.Header{}, nil
+	}
+	return r.Header.ToProto(), nil
+}
+
+// ToProto returns the result as the response of the gRPC block service to
+// GetCommit.
+func (r *ResultCommit) ToProto() (proto.Message, error) {
+	return &blocksvc.GetCommitResponse{
+		SignedHeader: r.SignedHeader.ToProto(),
+		Canonical:    r.CanonicalCommit,
+	}, nil
+}
+
+// ToProto returns the result as the response of the gRPC block results
+// service.
+func (r *ResultBlockResults) ToProto() (proto.Message, error) {
+	return &brs.GetBlockResultsResponse{
+		Height:                r.Height,
+		TxResults:             r.TxResults,
+		FinalizeBlockEvents:   toRefs(r.FinalizeBlockEvents),
+		ValidatorUpdates:      toRefs(r.ValidatorUpdates),
+		ConsensusParamUpdates: r.ConsensusParamUpdates,
+		AppHash:               r.AppHash,
+	}, nil
+}
+
+// ToProto returns the result as the response of the gRPC validator service to
+// GetConsensusParams.
+func (r *ResultConsensusParams) ToProto() (proto.Message, error) {
+	params := r.ConsensusParams.ToProto()
+	return &valsvc.GetConsensusParamsResponse{
+		Height:          r.BlockHeight,
+		ConsensusParams: &params,
+	}, nil
+}
+
+func toRefs[T any](collection []T) []*T {
+	refs := make([]*T, len(collection))
+	for i := range collection {
+		refs[i] = &collection[i]
+	}
+	return refs
+}
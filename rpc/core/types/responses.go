@@ -7,6 +7,7 @@ import (
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	abcitypes "github.com/cometbft/cometbft/v2/abci/types"
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/merkle"
 	"github.com/cometbft/cometbft/v2/libs/bytes"
 	"github.com/cometbft/cometbft/v2/p2p"
 	"github.com/cometbft/cometbft/v2/types"
@@ -52,8 +53,11 @@ type ResultCommit struct {
 
 // ABCI results from a block.
 type ResultBlockResults struct {
-	Height                int64                       `json:"height"`
-	TxResults             []*abcitypes.ExecTxResult   `json:"txs_results"`
+	Height    int64                     `json:"height"`
+	TxResults []*abcitypes.ExecTxResult `json:"txs_results"`
+	// TxResultProofs are the merkle proofs of TxResults against the
+	// LastResultsHash of the header at Height+1, if requested.
+	TxResultProofs        []merkle.Proof              `json:"tx_result_proofs,omitempty"`
 	FinalizeBlockEvents   []abcitypes.Event           `json:"finalize_block_events"`
 	ValidatorUpdates      []abcitypes.ValidatorUpdate `json:"validator_updates"`
 	ConsensusParamUpdates *cmtproto.ConsensusParams   `json:"consensus_param_updates"`
@@ -244,6 +248,23 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of starting a backup of the node data.
+type ResultUnsafeBackup struct {
+	Dir string `json:"dir"`
+}
+
+// Status of the last backup of the node data. The chain ID, height, app hash
+// and databases are set once the backup is completed.
+type ResultUnsafeBackupStatus struct {
+	Dir       string         `json:"dir"`
+	Running   bool           `json:"running"`
+	Error     string         `json:"error,omitempty"`
+	ChainID   string         `json:"chain_id,omitempty"`
+	Height    int64          `json:"height,omitempty"`
+	AppHash   bytes.HexBytes `json:"app_hash,omitempty"`
+	Databases []string       `json:"databases,omitempty"`
+}
+
 // empty results.
 type (
 	ResultUnsafeFlushMempool struct{}
@@ -258,4 +279,6 @@ type ResultEvent struct {
 	Query  string              `json:"query"`
 	Data   types.TMEventData   `json:"data"`
 	Events map[string][]string `json:"events"`
+	// Cursor of the event, to resume the subscription from the next event.
+	Cursor string `json:"cursor,omitempty"`
 }
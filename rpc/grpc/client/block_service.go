@@ -5,6 +5,7 @@ import (
 
 	"github.com/cosmos/gogoproto/grpc"
 
+	abci "github.com/cometbft/cometbft/api/cometbft/abci/v2"
 	blocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v2"
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	"github.com/cometbft/cometbft/v2/types"
@@ -33,6 +34,15 @@ func blockFromProto(pblockID *cmtproto.BlockID, pblock *cmtproto.Block) (*Block,
 	}, nil
 }
 
+// Commit is the signed header returned by the CometBFT BlockService gRPC API.
+type Commit struct {
+	SignedHeader *types.SignedHeader `json:"signed_header"`
+	// Canonical is false if the commit is the one seen by the node for the
+	// latest block, which may differ from the commit included in the next
+	// block.
+	Canonical bool `json:"canonical"`
+}
+
 // LatestHeightResult type used in GetLatestResult and send to the client
 // via a channel.
 type LatestHeightResult struct {
@@ -40,6 +50,32 @@ type LatestHeightResult struct {
 	Error  error
 }
 
+// BlockWithResultsResult type used in GetBlocksWithResults and sent to the
+// client via a channel. BlockEvents and TxEvents are the events of the block
+// and of each of its transactions indexed by the node, with their indexed
+// attributes only.
+type BlockWithResultsResult struct {
+	Block                 *Block
+	FinalizeBlockResponse *abci.FinalizeBlockResponse
+	BlockEvents           []*abci.Event
+	TxEvents              [][]*abci.Event
+	Error                 error
+}
+
+type getBlocksWithResultsConfig struct {
+	chSize uint
+}
+
+type GetBlocksWithResultsOption func(*getBlocksWithResultsConfig)
+
+// GetBlocksWithResultsChannelSize allows control over the channel size. If not
+// used or the channel size is set to 0, an unbuffered channel will be created.
+func GetBlocksWithResultsChannelSize(sz uint) GetBlocksWithResultsOption {
+	return func(opts *getBlocksWithResultsConfig) {
+		opts.chSize = sz
+	}
+}
+
 type getLatestHeightConfig struct {
 	chSize uint
 }
@@ -60,9 +96,19 @@ type BlockServiceClient interface {
 	// given height.
 	GetBlockByHeight(ctx context.Context, height int64) (*Block, error)
 
+	// GetCommit attempts to retrieve the signed header associated with the
+	// given height, or with the latest height if height is 0.
+	GetCommit(ctx context.Context, height int64) (*Commit, error)
+
 	// GetLatestHeight provides sends the latest committed block height to the
 	// resulting output channel as blocks are committed.
 	GetLatestHeight(ctx context.Context, opts ...GetLatestHeightOption) (<-chan LatestHeightResult, error)
+
+	// GetBlocksWithResults sends the blocks, along with their results, from
+	// fromHeight (or latest if 0) to the resulting output channel, up to the
+	// latest height and then as blocks are committed. Unlike GetLatestHeight,
+	// results are never skipped: the stream waits for the client to read them.
+	GetBlocksWithResults(ctx context.Context, fromHeight int64, opts ...GetBlocksWithResultsOption) (<-chan BlockWithResultsResult, error)
 }
 
 type blockServiceClient struct {
@@ -87,6 +133,25 @@ func (c *blockServiceClient) GetBlockByHeight(ctx context.Context, height int64)
 	return blockFromProto(res.BlockId, res.Block)
 }
 
+// GetCommit implements BlockServiceClient GetCommit.
+func (c *blockServiceClient) GetCommit(ctx context.Context, height int64) (*Commit, error) {
+	res, err := c.client.GetCommit(ctx, &blocksvc.GetCommitRequest{
+		Height: height,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sh, err := types.SignedHeaderFromProto(res.SignedHeader)
+	if err != nil {
+		return nil, err
+	}
+	return &Commit{
+		SignedHeader: sh,
+		Canonical:    res.Canonical,
+	}, nil
+}
+
 // GetLatestHeight implements BlockServiceClient GetLatestHeight.
 func (c *blockServiceClient) GetLatestHeight(ctx context.Context, opts ...GetLatestHeightOption) (<-chan LatestHeightResult, error) {
 	req := blocksvc.GetLatestHeightRequest{}
@@ -129,6 +194,50 @@ func (c *blockServiceClient) GetLatestHeight(ctx context.Context, opts ...GetLat
 	return resultCh, nil
 }
 
+// GetBlocksWithResults implements BlockServiceClient GetBlocksWithResults.
+func (c *blockServiceClient) GetBlocksWithResults(ctx context.Context, fromHeight int64, opts ...GetBlocksWithResultsOption) (<-chan BlockWithResultsResult, error) {
+	blocksClient, err := c.client.GetBlocksWithResults(ctx, &blocksvc.GetBlocksWithResultsRequest{FromHeight: fromHeight})
+	if err != nil {
+		return nil, ErrStreamSetup{Source: err}
+	}
+
+	cfg := &getBlocksWithResultsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	resultCh := make(chan BlockWithResultsResult, cfg.chSize)
+
+	go func(client blocksvc.BlockService_GetBlocksWithResultsClient) {
+		defer close(resultCh)
+		for {
+			var res BlockWithResultsResult
+			response, err := client.Recv()
+			if err == nil {
+				res.Block, err = blockFromProto(response.BlockId, response.Block)
+				res.FinalizeBlockResponse = response.FinalizeBlockResponse
+				res.BlockEvents = response.BlockEvents
+				res.TxEvents = make([][]*abci.Event, len(response.TxEvents))
+				for i, txEvents := range response.TxEvents {
+					res.TxEvents[i] = txEvents.GetEvents()
+				}
+			}
+			if err != nil {
+				res = BlockWithResultsResult{Error: ErrStreamReceive{Source: err}}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+			if res.Error != nil {
+				return
+			}
+		}
+	}(blocksClient)
+
+	return resultCh, nil
+}
+
 type disabledBlockServiceClient struct{}
 
 func newDisabledBlockServiceClient() BlockServiceClient {
@@ -140,6 +249,11 @@ func (*disabledBlockServiceClient) GetBlockByHeight(context.Context, int64) (*Bl
 	panic("block service client is disabled")
 }
 
+// GetCommit implements BlockServiceClient GetCommit - disabled client.
+func (*disabledBlockServiceClient) GetCommit(context.Context, int64) (*Commit, error) {
+	panic("block service client is disabled")
+}
+
 // GetLatestBlock implements BlockServiceClient.
 func (*disabledBlockServiceClient) GetLatestBlock(context.Context) (*Block, error) {
 	panic("block service client is disabled")
@@ -149,3 +263,8 @@ func (*disabledBlockServiceClient) GetLatestBlock(context.Context) (*Block, erro
 func (*disabledBlockServiceClient) GetLatestHeight(context.Context, ...GetLatestHeightOption) (<-chan LatestHeightResult, error) {
 	panic("block service client is disabled")
 }
+
+// GetBlocksWithResults implements BlockServiceClient GetBlocksWithResults - disabled client.
+func (*disabledBlockServiceClient) GetBlocksWithResults(context.Context, int64, ...GetBlocksWithResultsOption) (<-chan BlockWithResultsResult, error) {
+	panic("block service client is disabled")
+}
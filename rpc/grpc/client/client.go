@@ -22,6 +22,8 @@ type Client interface {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	ValidatorServiceClient
+	EvidenceServiceClient
 
 	// Close the connection to the server. Any subsequent requests will fail.
 	Close() error
@@ -34,6 +36,8 @@ type clientBuilder struct {
 	versionServiceEnabled      bool
 	blockServiceEnabled        bool
 	blockResultsServiceEnabled bool
+	validatorServiceEnabled    bool
+	evidenceServiceEnabled     bool
 }
 
 func newClientBuilder() *clientBuilder {
@@ -43,6 +47,8 @@ func newClientBuilder() *clientBuilder {
 		versionServiceEnabled:      true,
 		blockServiceEnabled:        true,
 		blockResultsServiceEnabled: true,
+		validatorServiceEnabled:    true,
+		evidenceServiceEnabled:     true,
 	}
 }
 
@@ -56,6 +62,8 @@ type client struct {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	ValidatorServiceClient
+	EvidenceServiceClient
 }
 
 // Close implements Client.
@@ -105,6 +113,28 @@ func WithBlockResultsServiceEnabled(enabled bool) Option {
 	}
 }
 
+// WithValidatorServiceEnabled allows control of whether or not to create a
+// client for interacting with the validator service of a CometBFT node.
+//
+// If disabled and the client attempts to access the validator service API,
+// the client will panic.
+func WithValidatorServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.validatorServiceEnabled = enabled
+	}
+}
+
+// WithEvidenceServiceEnabled allows control of whether or not to create a
+// client for interacting with the evidence service of a CometBFT node.
+//
+// If disabled and the client attempts to access the evidence service API, the
+// client will panic.
+func WithEvidenceServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.evidenceServiceEnabled = enabled
+	}
+}
+
 // WithGRPCDialOption allows passing lower-level gRPC dial options through to
 // the gRPC dialer when creating the client.
 func WithGRPCDialOption(opt ggrpc.DialOption) Option {
@@ -144,10 +174,20 @@ func New(_ context.Context, addr string, opts ...Option) (Client, error) {
 	if builder.blockResultsServiceEnabled {
 		blockResultServiceClient = newBlockResultsServiceClient(conn)
 	}
+	validatorServiceClient := newDisabledValidatorServiceClient()
+	if builder.validatorServiceEnabled {
+		validatorServiceClient = newValidatorServiceClient(conn)
+	}
+	evidenceServiceClient := newDisabledEvidenceServiceClient()
+	if builder.evidenceServiceEnabled {
+		evidenceServiceClient = newEvidenceServiceClient(conn)
+	}
 	return &client{
 		conn:                      conn,
 		VersionServiceClient:      versionServiceClient,
 		BlockServiceClient:        blockServiceClient,
 		BlockResultsServiceClient: blockResultServiceClient,
+		ValidatorServiceClient:    validatorServiceClient,
+		EvidenceServiceClient:     evidenceServiceClient,
 	}, nil
 }
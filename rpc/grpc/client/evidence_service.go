@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	evsvc "github.com/cometbft/cometbft/api/cometbft/services/evidence/v1"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// EvidenceServiceClient allows submitting evidence of misbehavior.
+type EvidenceServiceClient interface {
+	// BroadcastEvidence submits the evidence to the node's evidence pool, and
+	// returns its hash.
+	BroadcastEvidence(ctx context.Context, ev types.Evidence) ([]byte, error)
+}
+
+type evidenceServiceClient struct {
+	client evsvc.EvidenceServiceClient
+}
+
+func newEvidenceServiceClient(conn grpc.ClientConn) EvidenceServiceClient {
+	return &evidenceServiceClient{
+		client: evsvc.NewEvidenceServiceClient(conn),
+	}
+}
+
+// BroadcastEvidence implements EvidenceServiceClient BroadcastEvidence.
+func (c *evidenceServiceClient) BroadcastEvidence(ctx context.Context, ev types.Evidence) ([]byte, error) {
+	evProto, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.client.BroadcastEvidence(ctx, &evsvc.BroadcastEvidenceRequest{Evidence: evProto})
+	if err != nil {
+		return nil, err
+	}
+	return res.Hash, nil
+}
+
+type disabledEvidenceServiceClient struct{}
+
+func newDisabledEvidenceServiceClient() EvidenceServiceClient {
+	return &disabledEvidenceServiceClient{}
+}
+
+// BroadcastEvidence implements EvidenceServiceClient BroadcastEvidence - disabled client.
+func (*disabledEvidenceServiceClient) BroadcastEvidence(context.Context, types.Evidence) ([]byte, error) {
+	panic("evidence service client is disabled")
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	valsvc "github.com/cometbft/cometbft/api/cometbft/services/validator/v1"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// ValidatorSet is the validator set returned by the CometBFT ValidatorService
+// gRPC API.
+type ValidatorSet struct {
+	Height       int64               `json:"height"`
+	ValidatorSet *types.ValidatorSet `json:"validator_set"`
+}
+
+// ConsensusParams are the consensus params returned by the CometBFT
+// ValidatorService gRPC API.
+type ConsensusParams struct {
+	Height          int64                 `json:"height"`
+	ConsensusParams types.ConsensusParams `json:"consensus_params"`
+}
+
+// ChangesResult is a change of the validator set or of the consensus params
+// sent by GetChanges to the client via a channel. Either ValidatorSet or
+// ConsensusParams is nil if it didn't change at Height.
+type ChangesResult struct {
+	Height          int64
+	ValidatorSet    *types.ValidatorSet
+	ConsensusParams *types.ConsensusParams
+	Error           error
+}
+
+type getChangesConfig struct {
+	chSize uint
+}
+
+type GetChangesOption func(*getChangesConfig)
+
+// GetChangesChannelSize allows control over the channel size. If not used or
+// the channel size is set to 0, an unbuffered channel will be created.
+func GetChangesChannelSize(sz uint) GetChangesOption {
+	return func(opts *getChangesConfig) {
+		opts.chSize = sz
+	}
+}
+
+// ValidatorServiceClient provides the validator set and the consensus params
+// of a given height (or latest if none provided), and their changes.
+type ValidatorServiceClient interface {
+	GetValidatorSet(ctx context.Context, height int64) (*ValidatorSet, error)
+
+	GetConsensusParams(ctx context.Context, height int64) (*ConsensusParams, error)
+
+	// GetChanges sends the validator set and consensus params of fromHeight
+	// (or latest if 0) to the resulting output channel, and then their
+	// changes up to the latest height and as blocks are committed. Unlike
+	// GetLatestHeight, results are never skipped: the stream waits for the
+	// client to read them.
+	GetChanges(ctx context.Context, fromHeight int64, opts ...GetChangesOption) (<-chan ChangesResult, error)
+}
+
+type validatorServiceClient struct {
+	client valsvc.ValidatorServiceClient
+}
+
+func newValidatorServiceClient(conn grpc.ClientConn) ValidatorServiceClient {
+	return &validatorServiceClient{
+		client: valsvc.NewValidatorServiceClient(conn),
+	}
+}
+
+// GetValidatorSet implements ValidatorServiceClient GetValidatorSet.
+func (c *validatorServiceClient) GetValidatorSet(ctx context.Context, height int64) (*ValidatorSet, error) {
+	res, err := c.client.GetValidatorSet(ctx, &valsvc.GetValidatorSetRequest{Height: height})
+	if err != nil {
+		return nil, err
+	}
+
+	vals, err := types.ValidatorSetFromProto(res.ValidatorSet)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorSet{
+		Height:       res.Height,
+		ValidatorSet: vals,
+	}, nil
+}
+
+// GetConsensusParams implements ValidatorServiceClient GetConsensusParams.
+func (c *validatorServiceClient) GetConsensusParams(ctx context.Context, height int64) (*ConsensusParams, error) {
+	res, err := c.client.GetConsensusParams(ctx, &valsvc.GetConsensusParamsRequest{Height: height})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.ConsensusParams == nil {
+		return nil, errors.New("the response has no consensus params")
+	}
+	return &ConsensusParams{
+		Height:          res.Height,
+		ConsensusParams: types.ConsensusParamsFromProto(*res.ConsensusParams),
+	}, nil
+}
+
+// GetChanges implements ValidatorServiceClient GetChanges.
+func (c *validatorServiceClient) GetChanges(ctx context.Context, fromHeight int64, opts ...GetChangesOption) (<-chan ChangesResult, error) {
+	changesClient, err := c.client.GetChanges(ctx, &valsvc.GetChangesRequest{FromHeight: fromHeight})
+	if err != nil {
+		return nil, ErrStreamSetup{Source: err}
+	}
+
+	cfg := &getChangesConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	resultCh := make(chan ChangesResult, cfg.chSize)
+
+	go func(client valsvc.ValidatorService_GetChangesClient) {
+		defer close(resultCh)
+		for {
+			res := ChangesResult{}
+			response, err := client.Recv()
+			if err == nil {
+				res, err = changesFromProto(response)
+			}
+			if err != nil {
+				res = ChangesResult{Error: ErrStreamReceive{Source: err}}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+			if res.Error != nil {
+				return
+			}
+		}
+	}(changesClient)
+
+	return resultCh, nil
+}
+
+func changesFromProto(response *valsvc.GetChangesResponse) (ChangesResult, error) {
+	res := ChangesResult{Height: response.Height}
+	if response.ValidatorSet != nil {
+		vals, err := types.ValidatorSetFromProto(response.ValidatorSet)
+		if err != nil {
+			return ChangesResult{}, err
+		}
+		res.ValidatorSet = vals
+	}
+	if response.ConsensusParams != nil {
+		params := types.ConsensusParamsFromProto(*response.ConsensusParams)
+		res.ConsensusParams = &params
+	}
+	return res, nil
+}
+
+type disabledValidatorServiceClient struct{}
+
+func newDisabledValidatorServiceClient() ValidatorServiceClient {
+	return &disabledValidatorServiceClient{}
+}
+
+// GetValidatorSet implements ValidatorServiceClient GetValidatorSet - disabled client.
+func (*disabledValidatorServiceClient) GetValidatorSet(context.Context, int64) (*ValidatorSet, error) {
+	panic("validator service client is disabled")
+}
+
+// GetConsensusParams implements ValidatorServiceClient GetConsensusParams - disabled client.
+func (*disabledValidatorServiceClient) GetConsensusParams(context.Context, int64) (*ConsensusParams, error) {
+	panic("validator service client is disabled")
+}
+
+// GetChanges implements ValidatorServiceClient GetChanges - disabled client.
+func (*disabledValidatorServiceClient) GetChanges(context.Context, int64, ...GetChangesOption) (<-chan ChangesResult, error) {
+	panic("validator service client is disabled")
+}
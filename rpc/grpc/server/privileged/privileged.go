@@ -8,10 +8,17 @@ import (
 
 	pbpruningsvc "github.com/cometbft/cometbft/api/cometbft/services/pruning/v1"
 	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
 	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/pruningservice"
 	sm "github.com/cometbft/cometbft/v2/state"
 )
 
+// serviceScopes are the scopes required to call the services of the server
+// when authentication is enabled.
+var serviceScopes = map[string]auth.Scope{
+	pbpruningsvc.PruningService_serviceDesc.ServiceName: auth.ScopeAdmin,
+}
+
 // Option is any function that allows for configuration of the gRPC server
 // during its creation.
 type Option func(*serverBuilder)
@@ -19,6 +26,7 @@ type Option func(*serverBuilder)
 type serverBuilder struct {
 	listener       net.Listener
 	pruningService pbpruningsvc.PruningServiceServer
+	authenticator  *auth.Authenticator
 	logger         log.Logger
 	grpcOpts       []grpc.ServerOption
 }
@@ -38,6 +46,15 @@ func WithPruningService(pruner *sm.Pruner, logger log.Logger) Option {
 	}
 }
 
+// WithAuth enables the authentication of the callers with a, and the
+// authorization of their calls by service. If not specified, all the calls are
+// allowed.
+func WithAuth(a *auth.Authenticator) Option {
+	return func(b *serverBuilder) {
+		b.authenticator = a
+	}
+}
+
 // WithLogger enables logging using the given logger. If not specified, the
 // gRPC server does not log anything.
 func WithLogger(logger log.Logger) Option {
@@ -65,6 +82,12 @@ func Serve(listener net.Listener, opts ...Option) error {
 		opt(b)
 	}
 	b.grpcOpts = append(b.grpcOpts, grpc.MaxConcurrentStreams(100)) // Limit to 100 streams per connection
+	if b.authenticator != nil {
+		b.grpcOpts = append(b.grpcOpts,
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(b.authenticator, serviceScopes)),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(b.authenticator, serviceScopes)),
+		)
+	}
 	server := grpc.NewServer(b.grpcOpts...)
 	if b.pruningService != nil {
 		pbpruningsvc.RegisterPruningServiceServer(server, b.pruningService)
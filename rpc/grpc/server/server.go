@@ -9,17 +9,32 @@ import (
 
 	pbblocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v2"
 	brs "github.com/cometbft/cometbft/api/cometbft/services/block_results/v2"
+	pbevidencesvc "github.com/cometbft/cometbft/api/cometbft/services/evidence/v1"
+	pbvalidatorsvc "github.com/cometbft/cometbft/api/cometbft/services/validator/v1"
 	pbversionsvc "github.com/cometbft/cometbft/api/cometbft/services/version/v1"
 	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
 	grpcerr "github.com/cometbft/cometbft/v2/rpc/grpc/errors"
 	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/blockresultservice"
 	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/blockservice"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/evidenceservice"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/validatorservice"
 	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/versionservice"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/store"
 	"github.com/cometbft/cometbft/v2/types"
 )
 
+// serviceScopes are the scopes required to call the services of the server
+// when authentication is enabled.
+var serviceScopes = map[string]auth.Scope{
+	pbversionsvc.VersionService_serviceDesc.ServiceName:     auth.ScopeRead,
+	pbblocksvc.BlockService_serviceDesc.ServiceName:         auth.ScopeRead,
+	brs.BlockResultsService_serviceDesc.ServiceName:         auth.ScopeRead,
+	pbvalidatorsvc.ValidatorService_serviceDesc.ServiceName: auth.ScopeRead,
+	pbevidencesvc.EvidenceService_serviceDesc.ServiceName:   auth.ScopeBroadcast,
+}
+
 // Option is any function that allows for configuration of the gRPC server
 // during its creation.
 type Option func(*serverBuilder)
@@ -29,6 +44,9 @@ type serverBuilder struct {
 	versionService      pbversionsvc.VersionServiceServer
 	blockService        pbblocksvc.BlockServiceServer
 	blockResultsService brs.BlockResultsServiceServer
+	validatorService    pbvalidatorsvc.ValidatorServiceServer
+	evidenceService     pbevidencesvc.EvidenceServiceServer
+	authenticator       *auth.Authenticator
 	logger              log.Logger
 	grpcOpts            []grpc.ServerOption
 }
@@ -62,9 +80,9 @@ func WithVersionService() Option {
 }
 
 // WithBlockService enables the block service on the CometBFT server.
-func WithBlockService(store *store.BlockStore, eventBus *types.EventBus, logger log.Logger) Option {
+func WithBlockService(store *store.BlockStore, stateStore sm.Store, eventBus *types.EventBus, logger log.Logger) Option {
 	return func(b *serverBuilder) {
-		b.blockService = blockservice.New(store, eventBus, logger)
+		b.blockService = blockservice.New(store, stateStore, eventBus, logger)
 	}
 }
 
@@ -74,6 +92,29 @@ func WithBlockResultsService(bs *store.BlockStore, ss sm.Store, logger log.Logge
 	}
 }
 
+// WithValidatorService enables the validator service on the CometBFT server.
+func WithValidatorService(bs *store.BlockStore, ss sm.Store, eventBus *types.EventBus, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.validatorService = validatorservice.New(bs, ss, eventBus, logger)
+	}
+}
+
+// WithEvidenceService enables the evidence service on the CometBFT server.
+func WithEvidenceService(evidencePool sm.EvidencePool, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.evidenceService = evidenceservice.New(evidencePool, logger)
+	}
+}
+
+// WithAuth enables the authentication of the callers with a, and the
+// authorization of their calls by service. If not specified, all the calls are
+// allowed.
+func WithAuth(a *auth.Authenticator) Option {
+	return func(b *serverBuilder) {
+		b.authenticator = a
+	}
+}
+
 // WithLogger enables logging using the given logger. If not specified, the
 // gRPC server does not log anything.
 func WithLogger(logger log.Logger) Option {
@@ -101,6 +142,12 @@ func Serve(listener net.Listener, opts ...Option) error {
 		opt(b)
 	}
 	b.grpcOpts = append(b.grpcOpts, grpc.MaxConcurrentStreams(100)) // Limit to 100 streams per connection
+	if b.authenticator != nil {
+		b.grpcOpts = append(b.grpcOpts,
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(b.authenticator, serviceScopes)),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(b.authenticator, serviceScopes)),
+		)
+	}
 	server := grpc.NewServer(b.grpcOpts...)
 	if b.versionService != nil {
 		pbversionsvc.RegisterVersionServiceServer(server, b.versionService)
@@ -114,6 +161,14 @@ func Serve(listener net.Listener, opts ...Option) error {
 		brs.RegisterBlockResultsServiceServer(server, b.blockResultsService)
 		b.logger.Debug("Registered block results service")
 	}
+	if b.validatorService != nil {
+		pbvalidatorsvc.RegisterValidatorServiceServer(server, b.validatorService)
+		b.logger.Debug("Registered validator service")
+	}
+	if b.evidenceService != nil {
+		pbevidencesvc.RegisterEvidenceServiceServer(server, b.evidenceService)
+		b.logger.Debug("Registered evidence service")
+	}
 	b.logger.Info("serve", "msg", fmt.Sprintf("Starting gRPC server on %s", listener.Addr()))
 	return server.Serve(b.listener)
 }
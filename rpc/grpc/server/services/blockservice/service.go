@@ -2,32 +2,43 @@ package blockservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	abci "github.com/cometbft/cometbft/api/cometbft/abci/v2"
 	blocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v2"
 	ptypes "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	"github.com/cometbft/cometbft/v2/internal/rpctrace"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/store"
 	"github.com/cometbft/cometbft/v2/types"
 )
 
+// recentBlocksSize is the number of new blocks kept by a stream of blocks
+// with their results, for the consumer to read them, and the capacity of its
+// new block subscription.
+const recentBlocksSize = 100
+
 type blockServiceServer struct {
-	store    *store.BlockStore
-	eventBus *types.EventBus
-	logger   log.Logger
+	store      *store.BlockStore
+	stateStore sm.Store
+	eventBus   *types.EventBus
+	logger     log.Logger
 }
 
 // New creates a new CometBFT version service server.
-func New(store *store.BlockStore, eventBus *types.EventBus, logger log.Logger) blocksvc.BlockServiceServer {
+func New(store *store.BlockStore, stateStore sm.Store, eventBus *types.EventBus, logger log.Logger) blocksvc.BlockServiceServer {
 	return &blockServiceServer{
-		store:    store,
-		eventBus: eventBus,
-		logger:   logger.With("service", "BlockService"),
+		store:      store,
+		stateStore: stateStore,
+		eventBus:   eventBus,
+		logger:     logger.With("service", "BlockService"),
 	}
 }
 
@@ -49,6 +60,52 @@ func (s *blockServiceServer) GetByHeight(_ context.Context, req *blocksvc.GetByH
 	}, nil
 }
 
+// GetCommit implements v2.BlockServiceServer GetCommit method.
+func (s *blockServiceServer) GetCommit(_ context.Context, req *blocksvc.GetCommitRequest) (*blocksvc.GetCommitResponse, error) {
+	logger := s.logger.With("endpoint", "GetCommit")
+	latestHeight := s.store.Height()
+	height := req.Height
+	if height == 0 {
+		height = latestHeight
+	}
+	// Use distinct codes for heights that are not available yet and heights
+	// that are no longer available, so that clients such as light client
+	// providers can tell them apart.
+	switch {
+	case height < 0:
+		return nil, status.Error(codes.InvalidArgument, "Height cannot be negative")
+	case height == 0 || height > latestHeight:
+		return nil, status.Errorf(codes.OutOfRange, "Requested height %d is higher than latest height %d", height, latestHeight)
+	case height < s.store.Base():
+		return nil, status.Errorf(codes.NotFound, "Requested height %d is below base height %d", height, s.store.Base())
+	}
+
+	blockMeta := s.store.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, status.Errorf(codes.NotFound, "Block not found for height %d", height)
+	}
+
+	// If the next block has not been committed yet, use a non-canonical
+	// commit.
+	canonical := height < latestHeight
+	var commit *types.Commit
+	if canonical {
+		commit = s.store.LoadBlockCommit(height)
+	} else {
+		commit = s.store.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		logger.Error("Failed to load commit when block meta was successfully loaded", "height", height)
+		return nil, status.Errorf(codes.NotFound, "Commit not found for height %d", height)
+	}
+
+	signedHeader := types.SignedHeader{Header: &blockMeta.Header, Commit: commit}
+	return &blocksvc.GetCommitResponse{
+		SignedHeader: signedHeader.ToProto(),
+		Canonical:    canonical,
+	}, nil
+}
+
 func (s *blockServiceServer) getBlock(height int64, logger log.Logger) (*ptypes.BlockID, *ptypes.Block, error) {
 	traceID, err := rpctrace.New()
 	if err != nil {
@@ -124,6 +181,219 @@ func (s *blockServiceServer) GetLatestHeight(_ *blocksvc.GetLatestHeightRequest,
 	}
 }
 
+// GetBlocksWithResults implements v2.BlockServiceServer GetBlocksWithResults method.
+func (s *blockServiceServer) GetBlocksWithResults(req *blocksvc.GetBlocksWithResultsRequest, stream blocksvc.BlockService_GetBlocksWithResultsServer) error {
+	logger := s.logger.With("endpoint", "GetBlocksWithResults")
+	switch latestHeight := s.store.Height(); {
+	case req.FromHeight < 0:
+		return status.Error(codes.InvalidArgument, "Height cannot be negative")
+	case req.FromHeight > latestHeight:
+		return status.Errorf(codes.OutOfRange, "Requested height %d is higher than latest height %d", req.FromHeight, latestHeight)
+	case req.FromHeight > 0 && req.FromHeight < s.store.Base():
+		return status.Errorf(codes.NotFound, "Requested height %d is below base height %d", req.FromHeight, s.store.Base())
+	}
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	// Subscribe before loading the latest height, not to miss any block. The
+	// trace ID is reused as a unique subscriber ID.
+	query := types.QueryForEvent(types.EventNewBlock)
+	sub, err := s.eventBus.Subscribe(context.Background(), traceID, query, recentBlocksSize)
+	if err != nil {
+		logger.Error("Cannot subscribe to new block events", "err", err, "traceID", traceID)
+		return status.Errorf(codes.Internal, "Cannot subscribe to new block events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.Unsubscribe(context.Background(), traceID, query); err != nil && !errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Cannot unsubscribe from new block events", "err", err, "traceID", traceID)
+		}
+	}()
+
+	// Keep the latest new blocks, so that the subscription is never canceled
+	// because of a slow consumer, and that their results are available even
+	// if the FinalizeBlock responses are not persisted. The blocks it misses
+	// are loaded from the stores.
+	recent := newRecentBlocks(recentBlocksSize)
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				if data, ok := msg.Data().(types.EventDataNewBlock); ok {
+					recent.add(data)
+				}
+			case <-sub.Canceled():
+				return
+			}
+		}
+	}()
+
+	// The results of a block are saved before its new block event, but after
+	// the block itself, so the latest height with results is the state's.
+	state, err := s.stateStore.Load()
+	if err != nil {
+		logger.Error("Error loading state", "err", err, "traceID", traceID)
+		return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+	}
+	var (
+		lastHeight = state.LastBlockHeight
+		height     = req.FromHeight
+	)
+	for {
+		if height == 0 {
+			height = lastHeight
+		}
+		for ; height > 0 && height <= lastHeight; height++ {
+			var res *blocksvc.GetBlocksWithResultsResponse
+			if newBlock, ok := recent.get(height); ok {
+				res, err = newBlockWithResults(newBlock.BlockID, newBlock.Block, &newBlock.ResultFinalizeBlock)
+			} else {
+				res, err = s.loadBlockWithResults(height)
+			}
+			if err != nil {
+				if _, ok := status.FromError(err); !ok {
+					logger.Error("Error loading block with results", "height", height, "err", err, "traceID", traceID)
+					err = status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+				}
+				return err
+			}
+			if err := stream.Send(res); err != nil {
+				logger.Error("Failed to stream block", "err", err, "height", height, "traceID", traceID)
+				return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+			}
+		}
+
+		select {
+		case <-recent.newBlock:
+			lastHeight = max(lastHeight, recent.latestHeight())
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-sub.Canceled():
+			switch sub.Err() {
+			case cmtpubsub.ErrUnsubscribed:
+				return status.Error(codes.Canceled, "Subscription terminated")
+			case nil:
+				return status.Error(codes.Canceled, "Subscription canceled without errors")
+			default:
+				logger.Info("Subscription canceled with errors", "err", sub.Err(), "traceID", traceID)
+				return status.Errorf(codes.Canceled, "Subscription canceled with errors (see logs for trace ID: %s)", traceID)
+			}
+		}
+	}
+}
+
+// recentBlocks keeps the latest new blocks of a subscription.
+type recentBlocks struct {
+	mtx    sync.Mutex
+	size   int64
+	latest int64
+	blocks map[int64]types.EventDataNewBlock
+
+	// newBlock is notified when a new block is added. A single notification
+	// is enough to catch up with any number of new blocks.
+	newBlock chan struct{}
+}
+
+func newRecentBlocks(size int64) *recentBlocks {
+	return &recentBlocks{
+		size:     size,
+		blocks:   make(map[int64]types.EventDataNewBlock),
+		newBlock: make(chan struct{}, 1),
+	}
+}
+
+func (r *recentBlocks) add(data types.EventDataNewBlock) {
+	r.mtx.Lock()
+	height := data.Block.Height
+	r.blocks[height] = data
+	delete(r.blocks, height-r.size)
+	r.latest = max(r.latest, height)
+	r.mtx.Unlock()
+
+	select {
+	case r.newBlock <- struct{}{}:
+	default:
+	}
+}
+
+func (r *recentBlocks) get(height int64) (types.EventDataNewBlock, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	data, ok := r.blocks[height]
+	return data, ok
+}
+
+func (r *recentBlocks) latestHeight() int64 {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.latest
+}
+
+// loadBlockWithResults loads the block of height along with its results. It
+// returns a status error if they are not available.
+func (s *blockServiceServer) loadBlockWithResults(height int64) (*blocksvc.GetBlocksWithResultsResponse, error) {
+	block, blockMeta := s.store.LoadBlock(height)
+	if block == nil || blockMeta == nil {
+		return nil, status.Errorf(codes.NotFound, "Block not found for height %d", height)
+	}
+	results, err := s.stateStore.LoadFinalizeBlockResponse(height)
+	if errors.Is(err, sm.ErrFinalizeBlockResponsesNotPersisted) {
+		// The response of the latest height is kept nevertheless.
+		if results, err = s.stateStore.LoadLastFinalizeBlockResponse(height); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"The node does not persist FinalizeBlock responses, and the response of height %d was discarded", height)
+		}
+	}
+	switch {
+	case errors.As(err, &sm.ErrNoABCIResponsesForHeight{}):
+		return nil, status.Errorf(codes.NotFound, "FinalizeBlock response not found for height %d", height)
+	case err != nil:
+		return nil, err
+	}
+	return newBlockWithResults(blockMeta.BlockID, block, results)
+}
+
+func newBlockWithResults(blockID types.BlockID, block *types.Block, results *abci.FinalizeBlockResponse) (*blocksvc.GetBlocksWithResultsResponse, error) {
+	bp, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	blockIDProto := blockID.ToProto()
+	res := &blocksvc.GetBlocksWithResultsResponse{
+		BlockId:               &blockIDProto,
+		Block:                 bp,
+		FinalizeBlockResponse: results,
+		BlockEvents:           indexedEvents(results.Events),
+		TxEvents:              make([]*blocksvc.TxEvents, len(results.TxResults)),
+	}
+	for i, txResult := range results.TxResults {
+		res.TxEvents[i] = &blocksvc.TxEvents{Events: indexedEvents(txResult.GetEvents())}
+	}
+	return res, nil
+}
+
+// indexedEvents returns the events as indexed by the node: the events with a
+// type, with their indexed attributes only.
+func indexedEvents(events []abci.Event) []*abci.Event {
+	indexed := make([]*abci.Event, 0, len(events))
+	for _, event := range events {
+		if event.Type == "" {
+			continue
+		}
+		e := &abci.Event{Type: event.Type}
+		for _, attr := range event.Attributes {
+			if attr.Index {
+				e.Attributes = append(e.Attributes, attr)
+			}
+		}
+		indexed = append(indexed, e)
+	}
+	return indexed
+}
+
 func validateBlockHeight(height, baseHeight, latestHeight int64) error {
 	switch {
 	case height <= 0:
@@ -0,0 +1,243 @@
+package blockservice
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/api/cometbft/abci/v2"
+	blocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v2"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// testChain commits blocks like consensus does: the block is saved, then its
+// results and the state, then the new block event is published.
+type testChain struct {
+	t          *testing.T
+	blockStore *store.BlockStore
+	stateStore sm.Store
+	eventBus   *types.EventBus
+	state      sm.State
+	privVals   []types.PrivValidator
+	lastCommit *types.Commit
+}
+
+func newTestChain(t *testing.T, discardABCIResponses bool) *testChain {
+	t.Helper()
+	vals, privVals := test.ValidatorSet(context.Background(), t, 1, 10)
+	state := sm.State{
+		Version:                          sm.InitStateVersion,
+		ChainID:                          test.DefaultTestChainID,
+		InitialHeight:                    1,
+		Validators:                       vals,
+		NextValidators:                   vals,
+		LastValidators:                   types.NewValidatorSet(nil),
+		LastHeightValidatorsChanged:      1,
+		ConsensusParams:                  *test.ConsensusParams(),
+		LastHeightConsensusParamsChanged: 1,
+	}
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: discardABCIResponses})
+	require.NoError(t, stateStore.Bootstrap(state))
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { require.NoError(t, eventBus.Stop()) })
+
+	return &testChain{
+		t:          t,
+		blockStore: store.NewBlockStore(dbm.NewMemDB()),
+		stateStore: stateStore,
+		eventBus:   eventBus,
+		state:      state,
+		privVals:   privVals,
+		lastCommit: &types.Commit{},
+	}
+}
+
+func (c *testChain) commit() {
+	c.t.Helper()
+	height := c.state.LastBlockHeight + 1
+	block := c.state.MakeBlock(height, test.MakeNTxs(height, 2), c.lastCommit, nil,
+		c.state.Validators.GetProposer().Address)
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(c.t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	c.lastCommit, err = test.MakeCommit(blockID, height, 0, c.state.Validators, c.privVals, c.state.ChainID, time.Now())
+	require.NoError(c.t, err)
+	c.blockStore.SaveBlock(block, parts, c.lastCommit)
+
+	res := &abci.FinalizeBlockResponse{
+		Events:  []abci.Event{testEvent("block", height)},
+		AppHash: []byte{byte(height)},
+	}
+	for range block.Txs {
+		res.TxResults = append(res.TxResults, &abci.ExecTxResult{Events: []abci.Event{testEvent("tx", height)}})
+	}
+	require.NoError(c.t, c.stateStore.SaveFinalizeBlockResponse(height, res))
+	c.state.LastBlockHeight = height
+	c.state.LastValidators = c.state.Validators
+	require.NoError(c.t, c.stateStore.Save(c.state))
+
+	require.NoError(c.t, c.eventBus.PublishEventNewBlock(types.EventDataNewBlock{
+		Block:               block,
+		BlockID:             blockID,
+		ResultFinalizeBlock: *res,
+	}))
+}
+
+// testEvent returns an event with an indexed and a non-indexed attribute.
+func testEvent(typ string, height int64) abci.Event {
+	return abci.Event{Type: typ, Attributes: []abci.EventAttribute{
+		{Key: "height", Value: strconv.FormatInt(height, 10), Index: true},
+		{Key: "ignored", Value: "value"},
+	}}
+}
+
+type blocksStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *blocksvc.GetBlocksWithResultsResponse
+}
+
+func (s *blocksStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *blocksStream) Send(res *blocksvc.GetBlocksWithResultsResponse) error {
+	select {
+	case s.responses <- res:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// startStream streams the blocks with their results from fromHeight, to an
+// unbuffered stream, and returns it along with the error of the stream.
+func startStream(t *testing.T, chain *testChain, fromHeight int64) (*blocksStream, <-chan error) {
+	t.Helper()
+	srv := New(chain.blockStore, chain.stateStore, chain.eventBus, log.TestingLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	stream := &blocksStream{ctx: ctx, responses: make(chan *blocksvc.GetBlocksWithResultsResponse)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.GetBlocksWithResults(&blocksvc.GetBlocksWithResultsRequest{FromHeight: fromHeight}, stream)
+	}()
+	return stream, errCh
+}
+
+// requireBlocks requires the next blocks of stream to be the blocks from
+// fromHeight to toHeight, with their results.
+func requireBlocks(t *testing.T, stream *blocksStream, errCh <-chan error, fromHeight, toHeight int64) {
+	t.Helper()
+	for height := fromHeight; height <= toHeight; height++ {
+		var res *blocksvc.GetBlocksWithResultsResponse
+		select {
+		case res = <-stream.responses:
+		case err := <-errCh:
+			require.FailNow(t, "stream failed", "height %d: %v", height, err)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "block not received", "height %d", height)
+		}
+		require.Equal(t, height, res.Block.Header.Height)
+		require.Equal(t, []byte{byte(height)}, res.FinalizeBlockResponse.AppHash)
+
+		// Only the indexed attributes of the events are returned.
+		event := testEvent("block", height)
+		event.Attributes = event.Attributes[:1]
+		require.Equal(t, []*abci.Event{&event}, res.BlockEvents)
+		require.Len(t, res.TxEvents, 2)
+		for _, txEvents := range res.TxEvents {
+			event := testEvent("tx", height)
+			event.Attributes = event.Attributes[:1]
+			require.Equal(t, []*abci.Event{&event}, txEvents.Events)
+		}
+	}
+}
+
+func TestGetBlocksWithResults(t *testing.T) {
+	chain := newTestChain(t, false)
+	for i := 0; i < 3; i++ {
+		chain.commit()
+	}
+
+	// The past blocks are loaded from the stores, then the new ones are
+	// streamed as they are committed.
+	stream, errCh := startStream(t, chain, 2)
+	requireBlocks(t, stream, errCh, 2, 3)
+	chain.commit()
+	requireBlocks(t, stream, errCh, 4, 4)
+	chain.commit()
+	requireBlocks(t, stream, errCh, 5, 5)
+
+	// From the latest height.
+	stream, errCh = startStream(t, chain, 0)
+	requireBlocks(t, stream, errCh, 5, 5)
+
+	_, errCh = startStream(t, chain, 6)
+	require.Equal(t, codes.OutOfRange, status.Code(<-errCh))
+	_, errCh = startStream(t, chain, -1)
+	require.Equal(t, codes.InvalidArgument, status.Code(<-errCh))
+}
+
+func TestGetBlocksWithResultsCatchUp(t *testing.T) {
+	chain := newTestChain(t, false)
+	chain.commit()
+
+	// The blocks committed while the consumer is not reading are neither
+	// skipped nor cancel the stream, even beyond the new blocks kept.
+	stream, errCh := startStream(t, chain, 1)
+	for i := 0; i < recentBlocksSize+10; i++ {
+		chain.commit()
+	}
+	requireBlocks(t, stream, errCh, 1, recentBlocksSize+11)
+	chain.commit()
+	requireBlocks(t, stream, errCh, recentBlocksSize+12, recentBlocksSize+12)
+}
+
+func TestGetBlocksWithResultsDiscardABCIResponses(t *testing.T) {
+	chain := newTestChain(t, true)
+	for i := 0; i < 3; i++ {
+		chain.commit()
+	}
+
+	// The results of the past blocks are discarded, but the latest's.
+	_, errCh := startStream(t, chain, 2)
+	require.Equal(t, codes.FailedPrecondition, status.Code(<-errCh))
+	stream, errCh := startStream(t, chain, 3)
+	requireBlocks(t, stream, errCh, 3, 3)
+
+	// The results of the new blocks are kept for a consumer falling behind.
+	for i := 0; i < 10; i++ {
+		chain.commit()
+	}
+	requireBlocks(t, stream, errCh, 4, 13)
+}
+
+func TestRecentBlocks(t *testing.T) {
+	recent := newRecentBlocks(recentBlocksSize)
+	for height := int64(1); height <= recentBlocksSize+1; height++ {
+		recent.add(types.EventDataNewBlock{Block: &types.Block{Header: types.Header{Height: height}}})
+	}
+	require.Len(t, recent.newBlock, 1)
+	require.EqualValues(t, recentBlocksSize+1, recent.latestHeight())
+
+	// Only the latest blocks are kept.
+	_, ok := recent.get(1)
+	require.False(t, ok)
+	data, ok := recent.get(2)
+	require.True(t, ok)
+	require.EqualValues(t, 2, data.Block.Height)
+}
@@ -0,0 +1,49 @@
+package evidenceservice
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	evsvc "github.com/cometbft/cometbft/api/cometbft/services/evidence/v1"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+type evidenceServiceServer struct {
+	evidencePool sm.EvidencePool
+	logger       log.Logger
+}
+
+// New creates a new CometBFT evidence service server.
+func New(evidencePool sm.EvidencePool, logger log.Logger) evsvc.EvidenceServiceServer {
+	return &evidenceServiceServer{
+		evidencePool: evidencePool,
+		logger:       logger.With("service", "EvidenceService"),
+	}
+}
+
+// BroadcastEvidence implements v1.EvidenceServiceServer BroadcastEvidence
+// method.
+func (s *evidenceServiceServer) BroadcastEvidence(_ context.Context, req *evsvc.BroadcastEvidenceRequest) (*evsvc.BroadcastEvidenceResponse, error) {
+	logger := s.logger.With("endpoint", "BroadcastEvidence")
+	if req.Evidence == nil {
+		return nil, status.Error(codes.InvalidArgument, "Evidence cannot be empty")
+	}
+	ev, err := types.EvidenceFromProto(req.Evidence)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid evidence: %v", err)
+	}
+	if err := ev.ValidateBasic(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid evidence: %v", err)
+	}
+
+	if err := s.evidencePool.AddEvidence(ev); err != nil {
+		logger.Debug("Failed to add evidence", "evidence", ev, "err", err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to add evidence: %v", err)
+	}
+
+	return &evsvc.BroadcastEvidenceResponse{Hash: ev.Hash()}, nil
+}
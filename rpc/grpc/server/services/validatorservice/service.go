@@ -0,0 +1,303 @@
+package validatorservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	valsvc "github.com/cometbft/cometbft/api/cometbft/services/validator/v1"
+	"github.com/cometbft/cometbft/v2/internal/rpctrace"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// newBlockSubscriptionSize is the capacity of the new block subscription of
+// a stream of changes.
+const newBlockSubscriptionSize = 100
+
+type validatorServiceServer struct {
+	blockStore *store.BlockStore
+	stateStore sm.Store
+	eventBus   *types.EventBus
+	logger     log.Logger
+}
+
+// New creates a new CometBFT validator service server.
+func New(bs *store.BlockStore, ss sm.Store, eventBus *types.EventBus, logger log.Logger) valsvc.ValidatorServiceServer {
+	return &validatorServiceServer{
+		blockStore: bs,
+		stateStore: ss,
+		eventBus:   eventBus,
+		logger:     logger.With("service", "ValidatorService"),
+	}
+}
+
+// GetValidatorSet implements v1.ValidatorServiceServer GetValidatorSet method.
+func (s *validatorServiceServer) GetValidatorSet(_ context.Context, req *valsvc.GetValidatorSetRequest) (*valsvc.GetValidatorSetResponse, error) {
+	logger := s.logger.With("endpoint", "GetValidatorSet")
+	height, err := s.resolveHeight(req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	vals, err := s.stateStore.LoadValidators(height)
+	if err != nil {
+		if errors.As(err, &sm.ErrNoValSetForHeight{}) {
+			return nil, status.Errorf(codes.NotFound, "Validator set not found for height %d", height)
+		}
+		logger.Error("Error loading validator set", "height", height, "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	valsProto, err := vals.ToProto()
+	if err != nil {
+		logger.Error("Error attempting to convert validator set to its Protobuf representation", "height", height, "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+
+	return &valsvc.GetValidatorSetResponse{
+		Height:       height,
+		ValidatorSet: valsProto,
+	}, nil
+}
+
+// GetConsensusParams implements v1.ValidatorServiceServer GetConsensusParams method.
+func (s *validatorServiceServer) GetConsensusParams(_ context.Context, req *valsvc.GetConsensusParamsRequest) (*valsvc.GetConsensusParamsResponse, error) {
+	logger := s.logger.With("endpoint", "GetConsensusParams")
+	height, err := s.resolveHeight(req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := s.stateStore.LoadConsensusParams(height)
+	if err != nil {
+		if errors.As(err, &sm.ErrNoConsensusParamsForHeight{}) {
+			return nil, status.Errorf(codes.NotFound, "Consensus params not found for height %d", height)
+		}
+		logger.Error("Error loading consensus params", "height", height, "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	paramsProto := params.ToProto()
+
+	return &valsvc.GetConsensusParamsResponse{
+		Height:          height,
+		ConsensusParams: &paramsProto,
+	}, nil
+}
+
+// GetChanges implements v1.ValidatorServiceServer GetChanges method.
+func (s *validatorServiceServer) GetChanges(req *valsvc.GetChangesRequest, stream valsvc.ValidatorService_GetChangesServer) error {
+	logger := s.logger.With("endpoint", "GetChanges")
+	if req.FromHeight < 0 {
+		return status.Error(codes.InvalidArgument, "Height cannot be negative")
+	}
+	if latestHeight := s.blockStore.Height(); req.FromHeight > latestHeight {
+		return status.Errorf(codes.OutOfRange, "Requested height %d is higher than latest height %d", req.FromHeight, latestHeight)
+	}
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	// Subscribe before catching up, not to miss any block. The trace ID is
+	// reused as a unique subscriber ID.
+	query := types.QueryForEvent(types.EventNewBlock)
+	sub, err := s.eventBus.Subscribe(context.Background(), traceID, query, newBlockSubscriptionSize)
+	if err != nil {
+		logger.Error("Cannot subscribe to new block events", "err", err, "traceID", traceID)
+		return status.Errorf(codes.Internal, "Cannot subscribe to new block events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.Unsubscribe(context.Background(), traceID, query); err != nil && !errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Cannot unsubscribe from new block events", "err", err, "traceID", traceID)
+		}
+	}()
+	// The changes caused by the blocks committed after subscribing, which
+	// take effect from the height after the next one at the latest, are
+	// known from the new block events. The validator sets and consensus
+	// params of the heights before are compared with each other.
+	liveHeight := s.blockStore.Height() + 3
+
+	// Drain the subscription, so that it's not canceled while the stream is
+	// catching up, recording the heights at which the changes take effect. A
+	// single notification is enough to catch up with any number of new
+	// blocks.
+	changes := newChangeHeights()
+	newBlock := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				if ev, ok := msg.Data().(types.EventDataNewBlock); ok {
+					changes.add(ev)
+				}
+				select {
+				case newBlock <- struct{}{}:
+				default:
+				}
+			case <-sub.Canceled():
+				return
+			}
+		}
+	}()
+
+	var (
+		height               = req.FromHeight
+		valsHash, paramsHash []byte
+		firstResponse        = true
+	)
+	for {
+		if height == 0 {
+			height = s.blockStore.Height()
+		}
+		for ; height > 0; height++ {
+			loadVals, loadParams := true, true
+			if height < liveHeight {
+				// The validator set and consensus params of a height are
+				// saved by the time it's the latest height of the block
+				// store.
+				if height > s.blockStore.Height() {
+					break
+				}
+			} else {
+				var ok bool
+				if loadVals, loadParams, ok = changes.take(height); !ok {
+					break
+				}
+			}
+			res := &valsvc.GetChangesResponse{Height: height}
+
+			if loadVals {
+				vals, err := s.stateStore.LoadValidators(height)
+				if err != nil {
+					if errors.As(err, &sm.ErrNoValSetForHeight{}) {
+						return status.Errorf(codes.NotFound, "Validator set not found for height %d", height)
+					}
+					logger.Error("Error loading validator set", "height", height, "err", err, "traceID", traceID)
+					return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+				}
+				if hash := vals.Hash(); firstResponse || !bytes.Equal(hash, valsHash) {
+					valsHash = hash
+					if res.ValidatorSet, err = vals.ToProto(); err != nil {
+						logger.Error("Error attempting to convert validator set to its Protobuf representation", "height", height, "err", err, "traceID", traceID)
+						return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+					}
+				}
+			}
+
+			if loadParams {
+				params, err := s.stateStore.LoadConsensusParams(height)
+				if err != nil {
+					if errors.As(err, &sm.ErrNoConsensusParamsForHeight{}) {
+						return status.Errorf(codes.NotFound, "Consensus params not found for height %d", height)
+					}
+					logger.Error("Error loading consensus params", "height", height, "err", err, "traceID", traceID)
+					return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+				}
+				if hash := params.Hash(); firstResponse || !bytes.Equal(hash, paramsHash) {
+					paramsHash = hash
+					paramsProto := params.ToProto()
+					res.ConsensusParams = &paramsProto
+				}
+			}
+
+			if res.ValidatorSet == nil && res.ConsensusParams == nil {
+				continue
+			}
+			firstResponse = false
+			if err := stream.Send(res); err != nil {
+				logger.Error("Failed to stream changes", "err", err, "height", height, "traceID", traceID)
+				return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+			}
+		}
+
+		select {
+		case <-newBlock:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-sub.Canceled():
+			switch sub.Err() {
+			case cmtpubsub.ErrUnsubscribed:
+				return status.Error(codes.Canceled, "Subscription terminated")
+			case nil:
+				return status.Error(codes.Canceled, "Subscription canceled without errors")
+			default:
+				logger.Info("Subscription canceled with errors", "err", sub.Err(), "traceID", traceID)
+				return status.Errorf(codes.Canceled, "Subscription canceled with errors (see logs for trace ID: %s)", traceID)
+			}
+		}
+	}
+}
+
+// changeHeights records the heights at which the validator set and the
+// consensus params change, from the validator and consensus params updates
+// of the new blocks.
+type changeHeights struct {
+	mtx    sync.Mutex
+	latest int64 // height of the latest new block
+	vals   map[int64]struct{}
+	params map[int64]struct{}
+}
+
+func newChangeHeights() *changeHeights {
+	return &changeHeights{
+		vals:   make(map[int64]struct{}),
+		params: make(map[int64]struct{}),
+	}
+}
+
+// add records the changes caused by a new block. The validator updates of a
+// block take effect two heights later, and its consensus params updates at
+// the next height.
+func (c *changeHeights) add(ev types.EventDataNewBlock) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	height := ev.Block.Height
+	c.latest = height
+	if len(ev.ResultFinalizeBlock.ValidatorUpdates) > 0 {
+		c.vals[height+2] = struct{}{}
+	}
+	if ev.ResultFinalizeBlock.ConsensusParamUpdates != nil {
+		c.params[height+1] = struct{}{}
+	}
+}
+
+// take returns whether the validator set and the consensus params may change
+// at height, which must follow the heights taken before. It returns false if
+// the block of height is not known yet.
+func (c *changeHeights) take(height int64) (vals, params, ok bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if height > c.latest {
+		return false, false, false
+	}
+	_, vals = c.vals[height]
+	_, params = c.params[height]
+	delete(c.vals, height)
+	delete(c.params, height)
+	return vals, params, true
+}
+
+// resolveHeight returns the height requested, or the latest height if 0. The
+// validator set and consensus params of the next height are known already.
+func (s *validatorServiceServer) resolveHeight(height int64) (int64, error) {
+	latestHeight := s.blockStore.Height()
+	if height == 0 {
+		height = latestHeight
+	}
+	switch {
+	case height < 0:
+		return 0, status.Error(codes.InvalidArgument, "Height cannot be negative")
+	case height == 0 || height > latestHeight+1:
+		return 0, status.Errorf(codes.OutOfRange, "Requested height %d is higher than next height %d", height, latestHeight+1)
+	}
+	return height, nil
+}
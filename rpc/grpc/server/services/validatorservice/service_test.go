@@ -0,0 +1,227 @@
+package validatorservice
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/api/cometbft/abci/v2"
+	valsvc "github.com/cometbft/cometbft/api/cometbft/services/validator/v1"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// testChain commits blocks like consensus does: the block is saved, then the
+// state, then the new block event is published.
+type testChain struct {
+	t          *testing.T
+	blockStore *store.BlockStore
+	stateStore *countingStore
+	eventBus   *types.EventBus
+	state      sm.State
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+	vals, _ := test.ValidatorSet(context.Background(), t, 2, 10)
+	state := sm.State{
+		ChainID:                          test.DefaultTestChainID,
+		InitialHeight:                    1,
+		Validators:                       vals,
+		NextValidators:                   vals.CopyIncrementProposerPriority(1),
+		LastValidators:                   types.NewValidatorSet(nil),
+		LastHeightValidatorsChanged:      1,
+		ConsensusParams:                  *test.ConsensusParams(),
+		LastHeightConsensusParamsChanged: 1,
+	}
+	stateStore := &countingStore{Store: sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})}
+	require.NoError(t, stateStore.Bootstrap(state))
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { require.NoError(t, eventBus.Stop()) })
+
+	return &testChain{
+		t:          t,
+		blockStore: store.NewBlockStore(dbm.NewMemDB()),
+		stateStore: stateStore,
+		eventBus:   eventBus,
+		state:      state,
+	}
+}
+
+// commit commits the next block, with validator updates and consensus params
+// updates if requested.
+func (c *testChain) commit(valUpdates, paramsUpdates bool) {
+	c.t.Helper()
+	height := c.state.LastBlockHeight + 1
+	block := types.MakeBlock(height, nil, &types.Commit{Height: height - 1}, nil)
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(c.t, err)
+	c.blockStore.SaveBlock(block, parts, &types.Commit{Height: height})
+
+	res := abci.FinalizeBlockResponse{}
+	c.state.LastBlockHeight = height
+	c.state.LastValidators = c.state.Validators
+	c.state.Validators = c.state.NextValidators
+	c.state.NextValidators = c.state.NextValidators.CopyIncrementProposerPriority(1)
+	if valUpdates {
+		val, _, err := test.Validator(context.Background(), 10)
+		require.NoError(c.t, err)
+		require.NoError(c.t, c.state.NextValidators.UpdateWithChangeSet([]*types.Validator{val}))
+		c.state.LastHeightValidatorsChanged = height + 2
+		res.ValidatorUpdates = []abci.ValidatorUpdate{{
+			Power:       val.VotingPower,
+			PubKeyBytes: val.PubKey.Bytes(),
+			PubKeyType:  val.PubKey.Type(),
+		}}
+	}
+	if paramsUpdates {
+		c.state.ConsensusParams.Block.MaxBytes++
+		c.state.LastHeightConsensusParamsChanged = height + 1
+		paramsProto := c.state.ConsensusParams.ToProto()
+		res.ConsensusParamUpdates = &paramsProto
+	}
+	require.NoError(c.t, c.stateStore.Save(c.state))
+
+	require.NoError(c.t, c.eventBus.PublishEventNewBlock(types.EventDataNewBlock{
+		Block:               block,
+		ResultFinalizeBlock: res,
+	}))
+}
+
+// countingStore counts the validator sets and consensus params loaded.
+type countingStore struct {
+	sm.Store
+	loads atomic.Int64
+}
+
+func (s *countingStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
+	s.loads.Add(1)
+	return s.Store.LoadValidators(height)
+}
+
+func (s *countingStore) LoadConsensusParams(height int64) (types.ConsensusParams, error) {
+	s.loads.Add(1)
+	return s.Store.LoadConsensusParams(height)
+}
+
+type changesStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *valsvc.GetChangesResponse
+}
+
+func (s *changesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *changesStream) Send(res *valsvc.GetChangesResponse) error {
+	s.responses <- res
+	return nil
+}
+
+func TestGetConsensusParams(t *testing.T) {
+	chain := newTestChain(t)
+	srv := New(chain.blockStore, chain.stateStore, chain.eventBus, log.NewNopLogger())
+	chain.commit(false, false)
+	chain.commit(false, true)
+
+	res, err := srv.GetConsensusParams(context.Background(), &valsvc.GetConsensusParamsRequest{})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, res.Height)
+	require.Equal(t, test.ConsensusParams().Block.MaxBytes, res.ConsensusParams.Block.MaxBytes)
+
+	// The consensus params of the next height are known already.
+	res, err = srv.GetConsensusParams(context.Background(), &valsvc.GetConsensusParamsRequest{Height: 3})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, res.Height)
+	require.Equal(t, test.ConsensusParams().Block.MaxBytes+1, res.ConsensusParams.Block.MaxBytes)
+
+	_, err = srv.GetConsensusParams(context.Background(), &valsvc.GetConsensusParamsRequest{Height: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.GetConsensusParams(context.Background(), &valsvc.GetConsensusParamsRequest{Height: 4})
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestGetChanges(t *testing.T) {
+	chain := newTestChain(t)
+	srv := New(chain.blockStore, chain.stateStore, chain.eventBus, log.NewNopLogger())
+	chain.commit(false, false) // 1
+	chain.commit(true, false)  // 2: validators change at 4
+	chain.commit(false, true)  // 3: params change at 4
+	chain.commit(false, false) // 4
+
+	err := srv.GetChanges(&valsvc.GetChangesRequest{FromHeight: 5}, &changesStream{ctx: context.Background()})
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &changesStream{ctx: ctx, responses: make(chan *valsvc.GetChangesResponse, 10)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.GetChanges(&valsvc.GetChangesRequest{FromHeight: 1}, stream)
+	}()
+	receive := func() *valsvc.GetChangesResponse {
+		t.Helper()
+		select {
+		case res := <-stream.responses:
+			return res
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no changes received")
+			return nil
+		}
+	}
+
+	// The first response has both.
+	res := receive()
+	require.EqualValues(t, 1, res.Height)
+	require.NotNil(t, res.ValidatorSet)
+	require.NotNil(t, res.ConsensusParams)
+
+	// Catching up.
+	res = receive()
+	require.EqualValues(t, 4, res.Height)
+	require.Len(t, res.ValidatorSet.Validators, 3)
+	require.Equal(t, test.ConsensusParams().Block.MaxBytes+1, res.ConsensusParams.Block.MaxBytes)
+
+	// New blocks. The validator sets and consensus params are only loaded
+	// when updated, once the stream is live.
+	for i := 0; i < 5; i++ {
+		chain.commit(false, false)
+	}
+	chain.commit(true, true) // 10: params change at 11, validators at 12
+	chain.commit(false, false)
+	chain.commit(false, false)
+	res = receive()
+	require.EqualValues(t, 11, res.Height)
+	require.Nil(t, res.ValidatorSet)
+	require.Equal(t, test.ConsensusParams().Block.MaxBytes+2, res.ConsensusParams.Block.MaxBytes)
+	res = receive()
+	require.EqualValues(t, 12, res.Height)
+	require.Len(t, res.ValidatorSet.Validators, 4)
+	require.Nil(t, res.ConsensusParams)
+
+	loads := chain.stateStore.loads.Load()
+	for i := 0; i < 5; i++ {
+		chain.commit(false, false)
+	}
+	chain.commit(false, true) // 18: params change at 19
+	chain.commit(false, false)
+	res = receive()
+	require.EqualValues(t, 19, res.Height)
+	require.Equal(t, loads+1, chain.stateStore.loads.Load())
+
+	cancel()
+	require.Equal(t, codes.Canceled, status.Code(<-errCh))
+	require.Empty(t, stream.responses)
+}
@@ -553,6 +553,14 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeAfter subscribes to a query, resuming from the event following the
+// one with the given cursor. Note the server must have a "subscribe" route
+// defined, which accepts a cursor.
+func (c *WSClient) SubscribeAfter(ctx context.Context, query, cursor string) error {
+	params := map[string]any{"query": query, "cursor": cursor}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
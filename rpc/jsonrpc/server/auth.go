@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
+	"github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
+)
+
+// AuthHandler is a middleware function that authenticates the caller with the
+// bearer token of the Authorization header, and adds its session to the
+// context of the request. The calls of the caller are then authorized per RPC
+// function by the handlers of this package. It returns an error if the token
+// is invalid, while callers presenting no token are anonymous.
+func AuthHandler(next http.Handler, a *auth.Authenticator, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := a.Authenticate(auth.BearerToken(r.Header.Get("Authorization")))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cometbft"`)
+			res := types.RPCInvalidRequestError(nil, err)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusUnauthorized, res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+			return
+		}
+
+		session := a.NewSession(principal, r.RemoteAddr)
+		next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), session)))
+	})
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
+	"github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
+)
+
+var testJWTSecret = bytes.Repeat([]byte{'s'}, sha256.Size)
+
+// makeJWT returns a HS256 JWT with the given claims, signed with testJWTSecret.
+func makeJWT(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, testJWTSecret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func testAuthHandler(t *testing.T) http.Handler {
+	t.Helper()
+	hash := sha256.Sum256([]byte("reader-key"))
+	a, err := auth.NewAuthenticator(auth.Config{
+		APIKeys: []auth.APIKey{
+			{Name: "reader", SHA256: hex.EncodeToString(hash[:]), Scopes: []auth.Scope{auth.ScopeRead}},
+		},
+		JWTSecret: testJWTSecret,
+	}, log.NewNopLogger())
+	require.NoError(t, err)
+
+	funcMap := map[string]*RPCFunc{
+		"status": NewRPCFunc(func(_ *types.Context) (string, error) { return "ok", nil }, "", REST("GET /status")),
+		"broadcast": NewRPCFunc(func(_ *types.Context) (string, error) { return "sent", nil }, "",
+			REST("POST /txs"), Scope(auth.ScopeBroadcast)),
+		"subscribe": NewWSRPCFunc(func(_ *types.Context) (string, error) { return "subscribed", nil }, "",
+			Scope(auth.ScopeSubscribe)),
+	}
+	wm := NewWebsocketManager(funcMap)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger())
+	RegisterRESTFuncs(mux, "/rest", funcMap, func(error) int { return http.StatusInternalServerError }, log.NewNopLogger())
+	return AuthHandler(mux, a, log.NewNopLogger())
+}
+
+func TestAuthHandler(t *testing.T) {
+	handler := testAuthHandler(t)
+
+	testCases := []struct {
+		name, method, url, body, token string
+		wantCode                       int
+		wantBody                       string
+	}{
+		{"URI allowed", "GET", "/status", "", "reader-key", http.StatusOK, `"result":"ok"`},
+		{"URI forbidden", "GET", "/broadcast", "", "reader-key", http.StatusForbidden, "broadcast scope"},
+		{"URI anonymous", "GET", "/status", "", "", http.StatusForbidden, "anonymous"},
+		{"URI unknown key", "GET", "/status", "", "other-key", http.StatusUnauthorized, "unknown API key"},
+		{
+			"JSON-RPC allowed", "POST", "/", `{"jsonrpc":"2.0","id":1,"method":"status"}`, "reader-key",
+			http.StatusOK, `"result":"ok"`,
+		},
+		{
+			"JSON-RPC forbidden", "POST", "/", `{"jsonrpc":"2.0","id":1,"method":"broadcast"}`, "reader-key",
+			http.StatusOK, "broadcast scope",
+		},
+		{"REST allowed", "GET", "/rest/status", "", "reader-key", http.StatusOK, `"ok"`},
+		{"REST forbidden", "POST", "/rest/txs", "{}", "reader-key", http.StatusForbidden, "broadcast scope"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tc.wantCode, rec.Code, rec.Body.String())
+			assert.Contains(t, rec.Body.String(), tc.wantBody)
+		})
+	}
+}
+
+func TestAuthHandler_Websocket(t *testing.T) {
+	s := httptest.NewServer(testAuthHandler(t))
+	defer s.Close()
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer reader-key")
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", header)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	require.NoError(t, c.WriteJSON(types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCIntID(1), Method: "subscribe"}))
+	var resp types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Data, "subscribe scope")
+
+	// Invalid credentials are rejected before the connection is upgraded.
+	_, dialResp, err = websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket",
+		http.Header{"Authorization": []string{"Bearer other-key"}})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, dialResp.StatusCode)
+	var errResp types.RPCResponse
+	require.NoError(t, json.NewDecoder(dialResp.Body).Decode(&errResp))
+	dialResp.Body.Close()
+	require.NotNil(t, errResp.Error)
+}
+
+func TestAuthHandler_WebsocketExpiry(t *testing.T) {
+	s := httptest.NewServer(testAuthHandler(t))
+	defer s.Close()
+
+	token := makeJWT(t, map[string]any{"sub": "partner", "scope": "read", "exp": time.Now().Add(2 * time.Second).Unix()})
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", header)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	require.NoError(t, c.WriteJSON(types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCIntID(1), Method: "status"}))
+	var resp types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resp))
+	require.Nil(t, resp.Error)
+
+	// The connection is closed when the JWT expires.
+	require.NoError(t, c.SetReadDeadline(time.Now().Add(10*time.Second)))
+	err = c.ReadJSON(&resp)
+	require.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), err)
+}
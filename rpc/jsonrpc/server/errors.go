@@ -3,6 +3,7 @@ package server
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 var ErrConnectionStopped = errors.New("connection was stopped")
@@ -31,3 +32,21 @@ func (e ErrListening) Error() string {
 func (e ErrListening) Unwrap() error {
 	return e.Source
 }
+
+// ErrRateLimited is returned when a client IP exceeds its cost budget, or the
+// rate limit of a route.
+type ErrRateLimited struct {
+	Client string
+	// Route is the route whose rate limit is exceeded, or empty if the cost
+	// budget of the client is.
+	Route string
+	// RetryAfter is the time until the call would be allowed.
+	RetryAfter time.Duration
+}
+
+func (e ErrRateLimited) Error() string {
+	if e.Route != "" {
+		return fmt.Sprintf("rate limit of %s exceeded by %s", e.Route, e.Client)
+	}
+	return fmt.Sprintf("rate limit of %s exceeded", e.Client)
+}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
 	"github.com/cometbft/cometbft/v2/libs/log"
@@ -42,6 +43,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		var (
 			requests  []types.RPCRequest
 			responses []types.RPCResponse
+			batch     = true
 		)
 		if err := json.Unmarshal(b, &requests); err != nil {
 			// next, try to unmarshal as a single request
@@ -54,6 +56,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				return
 			}
 			requests = []types.RPCRequest{request}
+			batch = false
 		}
 
 		// Set the default response cache to true unless
@@ -61,6 +64,14 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		// 2. Any RPC request doesn't allow to be cached.
 		// 3. Any RPC request has the height argument and the value is 0 (the default).
 		cache := true
+		// If a single request is rejected by a rate limit, the response has
+		// status 429 and tells the client when it would be allowed. The calls
+		// of a batch are rate limited one by one, with an error response each,
+		// as the other calls may already have been served.
+		var (
+			rateLimited bool
+			retry       time.Duration
+		)
 		for _, req := range requests {
 			request := req
 			// A Notification is a Request object without an "id" member.
@@ -87,6 +98,15 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				cache = false
 				continue
 			}
+			if err := authorizeCall(r, request.Method, rpcFunc); err != nil {
+				if d, ok := retryAfter(err); ok && !batch {
+					rateLimited = true
+					retry = max(retry, d)
+				}
+				responses = append(responses, types.RPCServerError(request.ID, err))
+				cache = false
+				continue
+			}
 			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
@@ -117,7 +137,10 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 
 		if len(responses) > 0 {
 			var wErr error
-			if cache {
+			if rateLimited {
+				setRetryAfter(w.Header(), retry)
+				wErr = writeRPCResponseHTTP(w, http.StatusTooManyRequests, []httpHeader{}, responses...)
+			} else if cache {
 				wErr = WriteCacheableRPCResponseHTTP(w, responses...)
 			} else {
 				wErr = WriteRPCResponseHTTP(w, responses...)
@@ -170,16 +193,20 @@ func arrayParamsToArgs(
 	params []json.RawMessage,
 	argsOffset int,
 ) ([]reflect.Value, error) {
-	if len(rpcFunc.argNames) != len(params) {
+	if len(params) < rpcFunc.minArgs() || len(params) > len(rpcFunc.argNames) {
 		return nil, fmt.Errorf("expected %v parameters (%v), got %v (%v)",
 			len(rpcFunc.argNames), rpcFunc.argNames, len(params), params)
 	}
 
-	values := make([]reflect.Value, len(params))
-	for i, p := range params {
+	values := make([]reflect.Value, len(rpcFunc.argNames))
+	for i := range rpcFunc.argNames {
 		argType := rpcFunc.args[i+argsOffset]
+		if i >= len(params) { // omitted optional argument
+			values[i] = reflect.Zero(argType)
+			continue
+		}
 		val := reflect.New(argType)
-		err := cmtjson.Unmarshal(p, val.Interface())
+		err := cmtjson.Unmarshal(params[i], val.Interface())
 		if err != nil {
 			return nil, err
 		}
@@ -202,14 +202,14 @@ func WriteRPCResponseHTTPError(
 
 // WriteRPCResponseHTTP marshals res as JSON (with indent) and writes it to w.
 func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error {
-	return writeRPCResponseHTTP(w, []httpHeader{}, res...)
+	return writeRPCResponseHTTP(w, http.StatusOK, []httpHeader{}, res...)
 }
 
 // WriteCacheableRPCResponseHTTP marshals res as JSON (with indent) and writes
 // it to w. Adds Cache-Control to the response header and sets the expiry to
 // one day.
 func WriteCacheableRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error {
-	return writeRPCResponseHTTP(w, []httpHeader{{"Cache-Control", "public, max-age=86400"}}, res...)
+	return writeRPCResponseHTTP(w, http.StatusOK, []httpHeader{{"Cache-Control", "public, max-age=86400"}}, res...)
 }
 
 type httpHeader struct {
@@ -217,7 +217,7 @@ type httpHeader struct {
 	value string
 }
 
-func writeRPCResponseHTTP(w http.ResponseWriter, headers []httpHeader, res ...types.RPCResponse) error {
+func writeRPCResponseHTTP(w http.ResponseWriter, httpCode int, headers []httpHeader, res ...types.RPCResponse) error {
 	var v any
 	if len(res) == 1 {
 		v = res[0]
@@ -233,7 +233,7 @@ func writeRPCResponseHTTP(w http.ResponseWriter, headers []httpHeader, res ...ty
 	for _, header := range headers {
 		w.Header().Set(header.name, header.value)
 	}
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(httpCode)
 	_, err = w.Write(jsonBytes)
 	return err
 }
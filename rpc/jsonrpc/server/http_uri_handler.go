@@ -18,7 +18,7 @@ import (
 var reInt = regexp.MustCompile(`^-?[0-9]+$`)
 
 // convert from a function name to the http handler.
-func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(funcName string, rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
 	// Always return -1 as there's no ID here.
 	dummyID := types.JSONRPCIntID(-1) // URIClientRequestID
 
@@ -42,6 +42,17 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 			"postForm": r.PostForm,
 		})
 
+		if err := authorizeCall(r, funcName, rpcFunc); err != nil {
+			if d, ok := retryAfter(err); ok {
+				setRetryAfter(w.Header(), d)
+			}
+			res := types.RPCServerError(dummyID, err)
+			if wErr := WriteRPCResponseHTTPError(w, callErrorStatus(err), res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+			return
+		}
+
 		ctx := &types.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
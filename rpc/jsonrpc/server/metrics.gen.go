@@ -0,0 +1,30 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package server
+
+import (
+	"github.com/cometbft/cometbft/v2/libs/metrics/discard"
+	prometheus "github.com/cometbft/cometbft/v2/libs/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RejectedRequests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_requests",
+			Help:      "Number of RPC calls rejected by the rate limits, by route and by limit exceeded: client for the cost budget of the client IP, or route for the rate limit of the route.",
+		}, append(labels, "route", "limit")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RejectedRequests: discard.NewCounter(),
+	}
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"github.com/cometbft/cometbft/v2/libs/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+//go:generate go run ../../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains the prometheus metrics exposed by the RPC server.
+type Metrics struct {
+	// Number of RPC calls rejected by the rate limits, by route and by limit
+	// exceeded: client for the cost budget of the client IP, or route for the
+	// rate limit of the route.
+	RejectedRequests metrics.Counter `metrics_labels:"route,limit"`
+}
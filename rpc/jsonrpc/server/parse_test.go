@@ -170,6 +170,25 @@ func TestParseJSONRPC(t *testing.T) {
 	}
 }
 
+func TestParseJSONRPCOptionalArgs(t *testing.T) {
+	demo := func(_ *types.Context, _ int, _ string) {}
+	call := NewRPCFunc(demo, "height,name", OptionalArgs("name"))
+
+	vals, err := jsonParamsToArgs(call, []byte(`["7"]`))
+	require.NoError(t, err)
+	require.Len(t, vals, 2)
+	assert.Equal(t, int64(7), vals[0].Int())
+	assert.Empty(t, vals[1].String())
+
+	vals, err = jsonParamsToArgs(call, []byte(`["7", "flew"]`))
+	require.NoError(t, err)
+	require.Len(t, vals, 2)
+	assert.Equal(t, "flew", vals[1].String())
+
+	_, err = jsonParamsToArgs(call, []byte(`[]`))
+	require.Error(t, err)
+}
+
 func TestParseURI(t *testing.T) {
 	demo := func(_ *types.Context, _ int, _ string) {}
 	call := NewRPCFunc(demo, "height,name")
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cometbft/cometbft/v2/internal/ratelimit"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// RateLimitConfig configures a RateLimiter.
+type RateLimitConfig struct {
+	// Rate is the number of cost units per second a client IP may spend on RPC
+	// calls. Every call costs the cost of its RPC function. 0 means unlimited.
+	Rate float64
+	// Burst is the number of cost units a client IP may spend in a burst.
+	Burst int
+	// RouteRates are the numbers of calls per second a client IP may make to
+	// the routes they name, in addition to its cost budget.
+	RouteRates map[string]float64
+}
+
+// RateLimiter limits the rate of the RPC calls of each client IP. It is safe
+// for concurrent use.
+type RateLimiter struct {
+	client  *ratelimit.Limiter            // nil if unlimited
+	routes  map[string]*ratelimit.Limiter // by route
+	metrics *Metrics
+}
+
+// NewRateLimiter returns a RateLimiter configured by cfg, counting the calls it
+// rejects in metrics.
+func NewRateLimiter(cfg RateLimitConfig, metrics *Metrics) (*RateLimiter, error) {
+	if cfg.Rate < 0 {
+		return nil, fmt.Errorf("negative rate limit: %v", cfg.Rate)
+	}
+	if cfg.Burst < 0 {
+		return nil, fmt.Errorf("negative rate burst: %v", cfg.Burst)
+	}
+	rl := &RateLimiter{
+		routes:  make(map[string]*ratelimit.Limiter, len(cfg.RouteRates)),
+		metrics: metrics,
+	}
+	if cfg.Rate > 0 {
+		rl.client = ratelimit.NewLimiter(cfg.Rate, cfg.Burst)
+	}
+	for route, rate := range cfg.RouteRates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate limit of route %s must be positive, got %v", route, rate)
+		}
+		// A second's worth of calls may be made in a burst.
+		rl.routes[route] = ratelimit.NewLimiter(rate, int(math.Ceil(rate)))
+	}
+	return rl, nil
+}
+
+// allow checks that client may call route, spending cost units of its budget.
+// A rejected call spends nothing.
+func (rl *RateLimiter) allow(client, route string, cost int) error {
+	now := cmttime.Now()
+	routeLimiter, limited := rl.routes[route]
+	if limited {
+		if ok, retryAfter := routeLimiter.Take(client, now, 1); !ok {
+			rl.metrics.RejectedRequests.With("route", route, "limit", "route").Add(1)
+			return ErrRateLimited{Client: client, Route: route, RetryAfter: retryAfter}
+		}
+	}
+	if rl.client != nil {
+		if ok, retryAfter := rl.client.Take(client, now, float64(cost)); !ok {
+			if limited {
+				routeLimiter.Refund(client, now, 1)
+			}
+			rl.metrics.RejectedRequests.With("route", route, "limit", "client").Add(1)
+			return ErrRateLimited{Client: client, RetryAfter: retryAfter}
+		}
+	}
+	return nil
+}
+
+// clientLimiter is the rate limiter of a client IP.
+type clientLimiter struct {
+	rl     *RateLimiter
+	client string
+}
+
+// allow checks that the client may call route, spending cost units of its
+// budget. All calls are allowed without a clientLimiter, that is if rate
+// limiting is disabled.
+func (c *clientLimiter) allow(route string, cost int) error {
+	if c == nil {
+		return nil
+	}
+	return c.rl.allow(c.client, route, cost)
+}
+
+type clientLimiterKey struct{}
+
+func clientLimiterFromContext(ctx context.Context) *clientLimiter {
+	c, _ := ctx.Value(clientLimiterKey{}).(*clientLimiter)
+	return c
+}
+
+// RateLimitHandler is a middleware function that adds the rate limiter of the
+// client IP to the context of the request. The calls of the client are then
+// rate limited per RPC function by the handlers of this package.
+//
+// The client IP is the remote address of the request, so the clients behind a
+// proxy share the budget of the proxy.
+func RateLimitHandler(next http.Handler, rl *RateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			client = r.RemoteAddr
+		}
+		ctx := context.WithValue(r.Context(), clientLimiterKey{}, &clientLimiter{rl: rl, client: client})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authorizeCall checks that the caller of an HTTP request may call method,
+// which is implemented by rpcFunc: that it is granted the scope of the
+// function, and that neither it nor its client IP exceeds its rate limits.
+func authorizeCall(r *http.Request, method string, rpcFunc *RPCFunc) error {
+	if err := auth.FromContext(r.Context()).Authorize(method, rpcFunc.scope); err != nil {
+		return err
+	}
+	return clientLimiterFromContext(r.Context()).allow(method, rpcFunc.cost)
+}
+
+// callErrorStatus returns the HTTP status code of the response to a call
+// rejected by authorizeCall with err.
+func callErrorStatus(err error) int {
+	if errors.As(err, &ErrRateLimited{}) {
+		return http.StatusTooManyRequests
+	}
+	return auth.HTTPStatus(err)
+}
+
+// retryAfter returns the time until a call rejected with err by a rate limit
+// would be allowed, or false if err is not a rate limit error.
+func retryAfter(err error) (time.Duration, bool) {
+	var (
+		errServer ErrRateLimited
+		errAuth   auth.ErrRateLimited
+	)
+	switch {
+	case errors.As(err, &errServer):
+		return errServer.RetryAfter, true
+	case errors.As(err, &errAuth):
+		return errAuth.RetryAfter, true
+	default:
+		return 0, false
+	}
+}
+
+// setRetryAfter sets the Retry-After header of a response, in whole seconds
+// rounded up, to the time until a rejected call would be allowed.
+func setRetryAfter(h http.Header, d time.Duration) {
+	h.Set("Retry-After", strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10))
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+func testRateLimitHandler(t *testing.T, cfg RateLimitConfig) http.Handler {
+	t.Helper()
+	rl, err := NewRateLimiter(cfg, NopMetrics())
+	require.NoError(t, err)
+
+	funcMap := map[string]*RPCFunc{
+		"status": NewRPCFunc(func(_ *types.Context) (string, error) { return "ok", nil }, "", REST("GET /status")),
+		"search": NewRPCFunc(func(_ *types.Context) (string, error) { return "found", nil }, "",
+			REST("GET /search"), Cost(10)),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger())
+	RegisterRESTFuncs(mux, "/rest", funcMap, func(error) int { return http.StatusInternalServerError }, log.NewNopLogger())
+	return RateLimitHandler(mux, rl)
+}
+
+func serveRateLimited(handler http.Handler, method, url, body, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitHandler_Cost(t *testing.T) {
+	// The budget refills too slowly to matter during the test.
+	handler := testRateLimitHandler(t, RateLimitConfig{Rate: 0.01, Burst: 12})
+
+	// An expensive call spends most of the budget.
+	rec := serveRateLimited(handler, "GET", "/search", "", "10.0.0.1:1000")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = serveRateLimited(handler, "GET", "/rest/search", "", "10.0.0.1:1001")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// Cheaper calls still fit in the budget, from any port of the client IP.
+	rec = serveRateLimited(handler, "GET", "/status", "", "10.0.0.1:1002")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = serveRateLimited(handler, "POST", "/", `{"jsonrpc":"2.0","id":1,"method":"status"}`, "10.0.0.1:1003")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = serveRateLimited(handler, "POST", "/", `{"jsonrpc":"2.0","id":1,"method":"status"}`, "10.0.0.1:1004")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	assert.Equal(t, "100", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "rate limit of 10.0.0.1 exceeded")
+
+	// Other clients have their own budget.
+	rec = serveRateLimited(handler, "GET", "/search", "", "10.0.0.2:1000")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestRateLimitHandler_Route(t *testing.T) {
+	handler := testRateLimitHandler(t, RateLimitConfig{RouteRates: map[string]float64{"search": 1}})
+
+	rec := serveRateLimited(handler, "GET", "/search", "", "10.0.0.1:1000")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = serveRateLimited(handler, "GET", "/search", "", "10.0.0.1:1000")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "rate limit of search exceeded")
+
+	// Other routes are not rate limited.
+	rec = serveRateLimited(handler, "GET", "/status", "", "10.0.0.1:1000")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// The calls of a batch are rejected one by one.
+	rec = serveRateLimited(handler, "POST", "/",
+		`[{"jsonrpc":"2.0","id":1,"method":"status"},{"jsonrpc":"2.0","id":2,"method":"search"}]`, "10.0.0.1:1000")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), `"result":"ok"`)
+	assert.Contains(t, rec.Body.String(), "rate limit of search exceeded")
+}
+
+func TestRateLimiter_Refund(t *testing.T) {
+	rl, err := NewRateLimiter(RateLimitConfig{Rate: 0.01, Burst: 1, RouteRates: map[string]float64{"search": 1}}, NopMetrics())
+	require.NoError(t, err)
+
+	require.NoError(t, rl.allow("10.0.0.1", "status", 1))
+	// A call rejected by the cost budget doesn't spend the rate of its route.
+	err = rl.allow("10.0.0.1", "search", 1)
+	var errLimited ErrRateLimited
+	require.ErrorAs(t, err, &errLimited)
+	assert.Empty(t, errLimited.Route)
+	ok, _ := rl.routes["search"].Take("10.0.0.1", cmttime.Now(), 1)
+	assert.True(t, ok)
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	_, err := NewRateLimiter(RateLimitConfig{Rate: -1}, NopMetrics())
+	require.Error(t, err)
+	_, err = NewRateLimiter(RateLimitConfig{RouteRates: map[string]float64{"search": 0}}, NopMetrics())
+	require.Error(t, err)
+}
@@ -0,0 +1,428 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
+)
+
+// REST gateway
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// ProtoResult is implemented by the results of RPC functions which the REST
+// gateway can encode as Protobuf.
+type ProtoResult interface {
+	ToProto() (proto.Message, error)
+}
+
+var protoResultType = reflect.TypeOf((*ProtoResult)(nil)).Elem()
+
+// Where the REST gateway reads a parameter from.
+const (
+	InPath  = "path"
+	InQuery = "query"
+	InBody  = "body"
+)
+
+// RESTRoute describes how the REST gateway exposes an RPC function.
+type RESTRoute struct {
+	Name      string // name of the RPC function
+	Method    string
+	Path      string // relative to the prefix of the gateway
+	Params    []RESTParam
+	Cacheable bool
+	Protobuf  bool // whether the result can be encoded as Protobuf
+}
+
+// RESTParam is a parameter of a RESTRoute, one per argument of the function.
+type RESTParam struct {
+	Name string
+	In   string
+	Type reflect.Type
+}
+
+// RESTRoutes returns the routes of the REST gateway for the functions in
+// funcMap, sorted by path and method. Functions for websockets are left out.
+func RESTRoutes(funcMap map[string]*RPCFunc) []RESTRoute {
+	routes := make([]RESTRoute, 0, len(funcMap))
+	for name, rpcFunc := range funcMap {
+		if rpcFunc.ws {
+			continue
+		}
+		routes = append(routes, newRESTRoute(name, rpcFunc))
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+func newRESTRoute(name string, rpcFunc *RPCFunc) RESTRoute {
+	pattern := rpcFunc.restPattern
+	if pattern == "" {
+		pattern = http.MethodGet + " /" + name
+	}
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		panic(fmt.Sprintf("REST pattern of %s must start with a method: %q", name, pattern))
+	}
+
+	wildcards := make(map[string]struct{})
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			wildcards[strings.TrimSuffix(segment[1:len(segment)-1], "...")] = struct{}{}
+		}
+	}
+
+	in := InQuery
+	if method == http.MethodPost {
+		in = InBody
+	}
+	// skip types.Context
+	const argsOffset = 1
+	params := make([]RESTParam, len(rpcFunc.argNames))
+	for i, argName := range rpcFunc.argNames {
+		params[i] = RESTParam{Name: argName, In: in, Type: rpcFunc.args[i+argsOffset]}
+		if _, ok := wildcards[argName]; ok {
+			params[i].In = InPath
+			delete(wildcards, argName)
+		}
+	}
+	for wildcard := range wildcards {
+		panic(fmt.Sprintf("REST pattern of %s has a wildcard which is not an argument: %s", name, wildcard))
+	}
+
+	return RESTRoute{
+		Name:      name,
+		Method:    method,
+		Path:      path,
+		Params:    params,
+		Cacheable: rpcFunc.cacheable,
+		Protobuf:  rpcFunc.returns[0].Implements(protoResultType),
+	}
+}
+
+// RegisterRESTFuncs adds the routes of the REST gateway for the functions in
+// funcMap to mux, under prefix. statusCode returns the HTTP status code of the
+// response when a function returns an error.
+//
+// Parameters are read from the path and the query, or from a JSON object in the
+// body of POST requests. Integers and booleans are passed as is, and byte
+// slices in hex, with or without a 0x prefix. A pointer argument, such as an
+// optional height, is left unset when passed "latest".
+//
+// Results are encoded as JSON, or as Protobuf if the request accepts it and
+// the result implements ProtoResult. The responses of Cacheable functions carry
+// an ETag.
+func RegisterRESTFuncs(
+	mux *http.ServeMux,
+	prefix string,
+	funcMap map[string]*RPCFunc,
+	statusCode func(error) int,
+	logger log.Logger,
+) {
+	for _, route := range RESTRoutes(funcMap) {
+		mux.HandleFunc(route.Method+" "+prefix+route.Path,
+			makeRESTHandler(funcMap[route.Name], route, statusCode, logger))
+	}
+
+	// Requests matching no route are answered by the gateway rather than the
+	// JSON-RPC handlers.
+	catchAll := prefix + "/"
+	mux.HandleFunc(catchAll, func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			req := r.Clone(r.Context())
+			req.Method = method
+			if _, pattern := mux.Handler(req); pattern != catchAll {
+				allowed = append(allowed, method)
+			}
+		}
+
+		code, err := http.StatusNotFound, fmt.Errorf("no route for %s %s", r.Method, r.URL.Path)
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			code, err = http.StatusMethodNotAllowed, fmt.Errorf("%s only accepts %s", r.URL.Path,
+				strings.Join(allowed, " or "))
+		}
+		if wErr := writeRESTError(w, code, err); wErr != nil {
+			logger.Error("failed to write response", "err", wErr)
+		}
+	})
+}
+
+func makeRESTHandler(
+	rpcFunc *RPCFunc,
+	route RESTRoute,
+	statusCode func(error) int,
+	logger log.Logger,
+) func(http.ResponseWriter, *http.Request) {
+	writeError := func(w http.ResponseWriter, code int, err error) {
+		if wErr := writeRESTError(w, code, err); wErr != nil {
+			logger.Error("failed to write response", "err", wErr)
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		contentType, ok := negotiateContentType(r.Header.Get("Accept"), route.Protobuf)
+		if !ok {
+			writeError(w, http.StatusNotAcceptable, fmt.Errorf("%s can only be encoded as %s", r.URL.Path,
+				strings.Join(supportedContentTypes(route.Protobuf), " or ")))
+			return
+		}
+
+		if err := authorizeCall(r, route.Name, rpcFunc); err != nil {
+			if d, ok := retryAfter(err); ok {
+				setRetryAfter(w.Header(), d)
+			}
+			writeError(w, callErrorStatus(err), err)
+			return
+		}
+
+		fnArgs, err := restParamsToArgs(route, r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		args := append([]reflect.Value{reflect.ValueOf(&types.Context{HTTPReq: r})}, fnArgs...)
+
+		returns := rpcFunc.f.Call(args)
+		if err, _ := returns[1].Interface().(error); err != nil {
+			logger.Debug("HTTP REST", "method", r.Method, "url", r.URL.String(), "err", err)
+			writeError(w, statusCode(err), err)
+			return
+		}
+
+		body, err := encodeRESTResult(returns[0].Interface(), contentType)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, ErrMarshalResponse{Source: err})
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Vary", "Accept")
+		if rpcFunc.cacheableWithArgs(args) {
+			sum := sha256.Sum256(body)
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Cache-Control", "public, max-age=86400")
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			logger.Error("failed to write response", "err", err)
+		}
+	}
+}
+
+// restParamsToArgs converts the parameters of a request to the REST gateway to
+// a list of properly typed values.
+func restParamsToArgs(route RESTRoute, r *http.Request) ([]reflect.Value, error) {
+	var body map[string]json.RawMessage
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error decoding the request body: %w", err)
+		}
+	}
+
+	values := make([]reflect.Value, len(route.Params))
+	for i, param := range route.Params {
+		values[i] = reflect.Zero(param.Type)
+
+		var arg string
+		switch param.In {
+		case InPath:
+			arg = r.PathValue(param.Name)
+		case InQuery:
+			arg = r.URL.Query().Get(param.Name)
+		case InBody:
+			raw, ok := body[param.Name]
+			if !ok {
+				continue
+			}
+			v := reflect.New(param.Type)
+			if err := cmtjson.Unmarshal(raw, v.Interface()); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", param.Name, err)
+			}
+			values[i] = v.Elem()
+			continue
+		}
+
+		if arg == "" || (arg == "latest" && param.Type.Kind() == reflect.Ptr) {
+			continue
+		}
+		v, err := restStringToArg(param.Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", param.Name, err)
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+func restStringToArg(rt reflect.Type, arg string) (reflect.Value, error) {
+	if rt.Kind() == reflect.Ptr {
+		v, err := restStringToArg(rt.Elem(), arg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv := reflect.New(rt.Elem())
+		rv.Elem().Set(v)
+		return rv, nil
+	}
+
+	rv := reflect.New(rt).Elem()
+	switch rt.Kind() {
+	case reflect.String:
+		rv.SetString(arg)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(arg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(arg, 10, rt.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(arg, 10, rt.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv.SetUint(n)
+	case reflect.Slice:
+		if rt.Elem().Kind() != reflect.Uint8 {
+			return jsonStringToArg(rt, arg)
+		}
+		b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(arg, "0x"), "0X"))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv.SetBytes(b)
+	default:
+		return jsonStringToArg(rt, arg)
+	}
+	return rv, nil
+}
+
+func encodeRESTResult(result any, contentType string) ([]byte, error) {
+	if contentType == ContentTypeProtobuf {
+		msg, err := result.(ProtoResult).ToProto()
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(msg)
+	}
+	return cmtjson.Marshal(result)
+}
+
+func supportedContentTypes(protobuf bool) []string {
+	if protobuf {
+		return []string{ContentTypeJSON, ContentTypeProtobuf}
+	}
+	return []string{ContentTypeJSON}
+}
+
+// negotiateContentType returns the content type of the response to a request
+// with the given Accept header, preferring JSON between types of equal quality.
+// It returns false if none of the types accepted is supported.
+func negotiateContentType(accept string, protobuf bool) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return ContentTypeJSON, true
+	}
+
+	type acceptedType struct {
+		mediaType string
+		quality   float64
+	}
+	var accepted []acceptedType
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			accepted = append(accepted, acceptedType{mediaType: mediaType, quality: quality})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	for _, a := range accepted {
+		switch a.mediaType {
+		case ContentTypeJSON, "application/*", "*/*":
+			return ContentTypeJSON, true
+		case ContentTypeProtobuf, "application/protobuf":
+			if protobuf {
+				return ContentTypeProtobuf, true
+			}
+		}
+	}
+	return "", false
+}
+
+// etagMatches returns true if the If-None-Match header of a request matches
+// etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// RESTError is the body of the responses of the REST gateway to failed
+// requests.
+type RESTError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeRESTError(w http.ResponseWriter, code int, err error) error {
+	jsonBytes, mErr := json.Marshal(RESTError{Code: code, Message: err.Error()})
+	if mErr != nil {
+		return ErrMarshalResponse{Source: mErr}
+	}
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(code)
+	_, wErr := w.Write(jsonBytes)
+	return wErr
+}
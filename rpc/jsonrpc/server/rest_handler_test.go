@@ -0,0 +1,214 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
+)
+
+var errNotFound = errors.New("not found")
+
+type testBlock struct {
+	Height int64             `json:"height"`
+	Hash   cmtbytes.HexBytes `json:"hash"`
+}
+
+func (b *testBlock) ToProto() (proto.Message, error) {
+	return &cmtproto.Header{Height: b.Height}, nil
+}
+
+func testRESTMux() *http.ServeMux {
+	funcMap := map[string]*RPCFunc{
+		"block": NewRPCFunc(func(_ *types.Context, height *int64) (*testBlock, error) {
+			if height == nil {
+				return &testBlock{Height: 10}, nil
+			}
+			if *height > 10 {
+				return nil, errNotFound
+			}
+			return &testBlock{Height: *height}, nil
+		}, "height", Cacheable("height"), REST("GET /blocks/{height}")),
+		"block_by_hash": NewRPCFunc(func(_ *types.Context, hash []byte) (*testBlock, error) {
+			return &testBlock{Hash: hash}, nil
+		}, "hash", REST("GET /blocks/by_hash/{hash}")),
+		"search": NewRPCFunc(func(_ *types.Context, query string, prove bool, page *int) (string, error) {
+			if page == nil {
+				return query, nil
+			}
+			return strings.Repeat(query, *page), nil
+		}, "query,prove,page"),
+		"broadcast": NewRPCFunc(func(_ *types.Context, tx []byte) (string, error) {
+			return string(tx), nil
+		}, "tx", REST("POST /txs")),
+		"subscribe": NewWSRPCFunc(func(_ *types.Context, _ string) (string, error) {
+			return "", nil
+		}, "query"),
+	}
+	statusCode := func(err error) int {
+		if errors.Is(err, errNotFound) {
+			return http.StatusNotFound
+		}
+		return http.StatusInternalServerError
+	}
+	mux := http.NewServeMux()
+	RegisterRESTFuncs(mux, "/rest", funcMap, statusCode, log.NewNopLogger())
+	return mux
+}
+
+func restRequest(t *testing.T, mux *http.ServeMux, req *http.Request) (*http.Response, []byte) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	res := rec.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res, body
+}
+
+func TestRESTRoutes(t *testing.T) {
+	mux := testRESTMux()
+
+	testCases := []struct {
+		method, url, body string
+		wantCode          int
+		wantBody          string
+	}{
+		{"GET", "/rest/blocks/5", "", http.StatusOK, `{"height":"5","hash":""}`},
+		{"GET", "/rest/blocks/latest", "", http.StatusOK, `{"height":"10","hash":""}`},
+		{"GET", "/rest/blocks/11", "", http.StatusNotFound, `{"code":404,"message":"not found"}`},
+		{"GET", "/rest/blocks/abc", "", http.StatusBadRequest, ""},
+		{"GET", "/rest/blocks/by_hash/0xABCD", "", http.StatusOK, `{"height":"0","hash":"ABCD"}`},
+		{"GET", "/rest/blocks/by_hash/abcd", "", http.StatusOK, `{"height":"0","hash":"ABCD"}`},
+		{"GET", "/rest/search?query=tx.height%3D5", "", http.StatusOK, `"tx.height=5"`},
+		{"GET", "/rest/search?query=a&prove=true&page=3", "", http.StatusOK, `"aaa"`},
+		{"GET", "/rest/search?query=a&prove=maybe", "", http.StatusBadRequest, ""},
+		{"POST", "/rest/txs", `{"tx":"aGVsbG8="}`, http.StatusOK, `"hello"`},
+		{"POST", "/rest/txs", `{"tx":`, http.StatusBadRequest, ""},
+		{"GET", "/rest/subscribe?query=a", "", http.StatusNotFound, ""},
+		{"DELETE", "/rest/blocks/5", "", http.StatusMethodNotAllowed, ""},
+		{"GET", "/rest/txs", "", http.StatusMethodNotAllowed, `{"code":405,"message":"/rest/txs only accepts POST"}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method+" "+tc.url, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			res, body := restRequest(t, mux, req)
+			assert.Equal(t, tc.wantCode, res.StatusCode, string(body))
+			if tc.wantBody != "" {
+				assert.JSONEq(t, tc.wantBody, string(body))
+			}
+			assert.Equal(t, ContentTypeJSON, res.Header.Get("Content-Type"))
+			if tc.wantCode >= http.StatusBadRequest {
+				var restErr RESTError
+				require.NoError(t, json.Unmarshal(body, &restErr))
+				assert.Equal(t, tc.wantCode, restErr.Code)
+				assert.NotEmpty(t, restErr.Message)
+			}
+		})
+	}
+}
+
+func TestRESTETag(t *testing.T) {
+	mux := testRESTMux()
+
+	res, body := restRequest(t, mux, httptest.NewRequest("GET", "/rest/blocks/5", nil))
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	etag := res.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "public, max-age=86400", res.Header.Get("Cache-Control"))
+
+	req := httptest.NewRequest("GET", "/rest/blocks/5", nil)
+	req.Header.Set("If-None-Match", etag)
+	res, notModified := restRequest(t, mux, req)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	assert.Empty(t, notModified)
+
+	req = httptest.NewRequest("GET", "/rest/blocks/6", nil)
+	req.Header.Set("If-None-Match", etag)
+	res, other := restRequest(t, mux, req)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotEqual(t, body, other)
+	assert.NotEqual(t, etag, res.Header.Get("ETag"))
+
+	// The latest block is not cacheable.
+	res, _ = restRequest(t, mux, httptest.NewRequest("GET", "/rest/blocks/latest", nil))
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Empty(t, res.Header.Get("ETag"))
+	assert.Empty(t, res.Header.Get("Cache-Control"))
+}
+
+func TestRESTContentNegotiation(t *testing.T) {
+	mux := testRESTMux()
+
+	testCases := []struct {
+		url, accept     string
+		wantCode        int
+		wantContentType string
+	}{
+		{"/rest/blocks/5", "", http.StatusOK, ContentTypeJSON},
+		{"/rest/blocks/5", "*/*", http.StatusOK, ContentTypeJSON},
+		{"/rest/blocks/5", "application/x-protobuf", http.StatusOK, ContentTypeProtobuf},
+		{"/rest/blocks/5", "application/protobuf", http.StatusOK, ContentTypeProtobuf},
+		{"/rest/blocks/5", "application/json;q=0.5, application/x-protobuf", http.StatusOK, ContentTypeProtobuf},
+		{"/rest/blocks/5", "application/x-protobuf;q=0.5, application/json", http.StatusOK, ContentTypeJSON},
+		{"/rest/blocks/5", "text/html", http.StatusNotAcceptable, ContentTypeJSON},
+		{"/rest/search?query=a", "application/x-protobuf", http.StatusNotAcceptable, ContentTypeJSON},
+		{"/rest/search?query=a", "application/x-protobuf, */*;q=0.1", http.StatusOK, ContentTypeJSON},
+	}
+	for _, tc := range testCases {
+		req := httptest.NewRequest("GET", tc.url, nil)
+		req.Header.Set("Accept", tc.accept)
+		res, body := restRequest(t, mux, req)
+		assert.Equal(t, tc.wantCode, res.StatusCode, tc.accept)
+		assert.Equal(t, tc.wantContentType, res.Header.Get("Content-Type"), tc.accept)
+
+		if tc.wantContentType == ContentTypeProtobuf {
+			var header cmtproto.Header
+			require.NoError(t, proto.Unmarshal(body, &header))
+			assert.Equal(t, int64(5), header.Height)
+		}
+	}
+}
+
+func TestRESTRoutesParams(t *testing.T) {
+	routes := RESTRoutes(map[string]*RPCFunc{
+		"block": NewRPCFunc(func(_ *types.Context, _ *int64, _ bool) (string, error) { return "", nil },
+			"height,prove", REST("GET /blocks/{height}")),
+		"broadcast": NewRPCFunc(func(_ *types.Context, _ []byte) (string, error) { return "", nil },
+			"tx", REST("POST /txs")),
+	})
+	require.Len(t, routes, 2)
+	assert.Equal(t, "/blocks/{height}", routes[0].Path)
+	assert.Equal(t, InPath, routes[0].Params[0].In)
+	assert.Equal(t, InQuery, routes[0].Params[1].In)
+	assert.Equal(t, "/txs", routes[1].Path)
+	assert.Equal(t, http.MethodPost, routes[1].Method)
+	assert.Equal(t, InBody, routes[1].Params[0].In)
+
+	assert.Panics(t, func() {
+		RESTRoutes(map[string]*RPCFunc{
+			"block": NewRPCFunc(func(_ *types.Context, _ int64) (string, error) { return "", nil },
+				"height", REST("GET /blocks/{hash}")),
+		})
+	})
+}
+
+func TestRESTUnknownRoute(t *testing.T) {
+	mux := testRESTMux()
+	res, body := restRequest(t, mux, httptest.NewRequest("GET", "/rest/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Contains(t, string(body), "no route for GET /rest/unknown")
+}
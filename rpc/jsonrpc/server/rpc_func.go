@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
 )
 
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as
@@ -16,8 +17,8 @@ import (
 func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
-		mux.HandleFunc("/v1/"+funcName, makeHTTPHandler(rpcFunc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(funcName, rpcFunc, logger))
+		mux.HandleFunc("/v1/"+funcName, makeHTTPHandler(funcName, rpcFunc, logger))
 	}
 
 	// JSONRPC endpoints
@@ -44,6 +45,46 @@ func Cacheable(noCacheDefArgs ...string) Option {
 	}
 }
 
+// OptionalArgs lets the given trailing arguments be omitted when parameters are
+// passed as an array, so that arguments can be added to an RPC function without
+// breaking its callers. Omitted arguments are set to their defaults.
+func OptionalArgs(args ...string) Option {
+	return func(r *RPCFunc) {
+		r.optionalArgs = make(map[string]struct{})
+		for _, arg := range args {
+			r.optionalArgs[arg] = struct{}{}
+		}
+	}
+}
+
+// REST sets the pattern at which the REST gateway exposes the RPC function,
+// such as "GET /blocks/{height}". It is a net/http.ServeMux pattern relative to
+// the prefix of the gateway, whose wildcards name arguments of the function.
+// Without it, the function is exposed at "GET /<name>".
+func REST(pattern string) Option {
+	return func(r *RPCFunc) {
+		r.restPattern = pattern
+	}
+}
+
+// Scope sets the scope a caller must be granted to call the RPC function when
+// authentication is enabled. Without it, the function requires auth.ScopeRead.
+func Scope(scope auth.Scope) Option {
+	return func(r *RPCFunc) {
+		r.scope = scope
+	}
+}
+
+// Cost sets the number of units a call to the RPC function spends of the budget
+// of its client IP when rate limiting is enabled. It should reflect the load
+// the call puts on the node, so that expensive searches and paginated queries
+// are rate limited more strictly. Without it, a call costs 1 unit.
+func Cost(cost int) Option {
+	return func(r *RPCFunc) {
+		r.cost = cost
+	}
+}
+
 // Ws enables WebSocket communication.
 func Ws() Option {
 	return func(r *RPCFunc) {
@@ -53,13 +94,30 @@ func Ws() Option {
 
 // RPCFunc contains the introspected type information for a function.
 type RPCFunc struct {
-	f              reflect.Value  // underlying rpc function
-	args           []reflect.Type // type of each function arg
-	returns        []reflect.Type // type of each return arg
-	argNames       []string       // name of each argument
-	cacheable      bool           // enable cache control
-	ws             bool           // enable websocket communication
-	noCacheDefArgs map[string]any // a lookup table of args that, if not supplied or are set to default values, cause us to not cache
+	f              reflect.Value       // underlying rpc function
+	args           []reflect.Type      // type of each function arg
+	returns        []reflect.Type      // type of each return arg
+	argNames       []string            // name of each argument
+	cacheable      bool                // enable cache control
+	ws             bool                // enable websocket communication
+	noCacheDefArgs map[string]any      // a lookup table of args that, if not supplied or are set to default values, cause us to not cache
+	optionalArgs   map[string]struct{} // args that may be omitted from the end of array params
+	restPattern    string              // pattern of the REST gateway route
+	scope          auth.Scope          // scope required to call the function
+	cost           int                 // cost of a call to the function for rate limiting
+}
+
+// minArgs returns the number of arguments which must be passed as an array,
+// not counting the trailing optional ones.
+func (f *RPCFunc) minArgs() int {
+	n := len(f.argNames)
+	for n > 0 {
+		if _, ok := f.optionalArgs[f.argNames[n-1]]; !ok {
+			break
+		}
+		n--
+	}
+	return n
 }
 
 // NewRPCFunc wraps a function for introspection.
@@ -109,6 +167,8 @@ func newRPCFunc(f any, args string, options ...Option) *RPCFunc {
 		args:     funcArgTypes(f),
 		returns:  funcReturnTypes(f),
 		argNames: argNames,
+		scope:    auth.ScopeRead,
+		cost:     1,
 	}
 
 	for _, opt := range options {
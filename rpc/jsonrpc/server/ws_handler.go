@@ -13,6 +13,7 @@ import (
 
 	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/libs/service"
+	"github.com/cometbft/cometbft/v2/rpc/auth"
 	"github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
 )
 
@@ -85,6 +86,8 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.session = auth.FromContext(r.Context())
+	con.limiter = clientLimiterFromContext(r.Context())
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -117,6 +120,13 @@ type wsConnection struct {
 
 	funcMap map[string]*RPCFunc
 
+	// session of the caller, which authorizes its calls. It is nil if
+	// authentication is disabled.
+	session *auth.Session
+
+	// rate limiter of the client IP. It is nil if rate limiting is disabled.
+	limiter *clientLimiter
+
 	// write channel capacity
 	writeChanCapacity int
 
@@ -241,6 +251,15 @@ func (wsc *wsConnection) OnStop() {
 	}
 }
 
+// authorizeCall checks that the caller may call method, which is implemented
+// by rpcFunc, as authorizeCall does for HTTP requests.
+func (wsc *wsConnection) authorizeCall(method string, rpcFunc *RPCFunc) error {
+	if err := wsc.session.Authorize(method, rpcFunc.scope); err != nil {
+		return err
+	}
+	return wsc.limiter.allow(method, rpcFunc.cost)
+}
+
 // GetRemoteAddr returns the remote address of the underlying connection.
 // It implements WSRPCConnection.
 func (wsc *wsConnection) GetRemoteAddr() string {
@@ -362,6 +381,13 @@ func (wsc *wsConnection) readRoutine() {
 				continue
 			}
 
+			if err := wsc.authorizeCall(request.Method, rpcFunc); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, err)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+
 			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
@@ -397,11 +423,19 @@ func (wsc *wsConnection) readRoutine() {
 	}
 }
 
-// receives on a write channel and writes out on the socket.
+// receives on a write channel and writes out on the socket. The connection is
+// closed when the credentials of the caller expire.
 func (wsc *wsConnection) writeRoutine() {
 	pingTicker := time.NewTicker(wsc.pingPeriod)
 	defer pingTicker.Stop()
 
+	var expired <-chan time.Time
+	if expiresAt := wsc.session.ExpiresAt(); !expiresAt.IsZero() {
+		expiryTimer := time.NewTimer(time.Until(expiresAt))
+		defer expiryTimer.Stop()
+		expired = expiryTimer.C
+	}
+
 	// https://github.com/gorilla/websocket/issues/97
 	pongs := make(chan string, 1)
 	wsc.baseConn.SetPingHandler(func(m string) error {
@@ -418,6 +452,13 @@ func (wsc *wsConnection) writeRoutine() {
 			return
 		case <-wsc.readRoutineQuit: // error in readRoutine
 			return
+		case <-expired:
+			wsc.Logger.Info("Closing connection, as the credentials of the caller expired")
+			msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "credentials expired")
+			if err := wsc.writeMessageWithDeadline(websocket.CloseMessage, msg); err != nil {
+				wsc.Logger.Error("Failed to write close message", "err", err)
+			}
+			return
 		case m := <-pongs:
 			err := wsc.writeMessageWithDeadline(websocket.PongMessage, []byte(m))
 			if err != nil {
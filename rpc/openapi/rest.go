@@ -0,0 +1,231 @@
+// Package openapi holds the OpenAPI document of the RPC, and derives the one of
+// the REST gateway from it.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	rpcserver "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
+)
+
+// Spec is the OpenAPI document of the RPC, in YAML.
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+const restDescription = `The REST gateway exposes the RPC functions at resource-style paths.
+
+Parameters are passed in the path and the query, or as a JSON object in the body of POST requests.
+Byte slices are passed in hex in the path and the query. Heights in the path can be set to ` + "`latest`" + `.
+
+Results are encoded as JSON, or as Protobuf for the routes which support it, if the Accept header
+includes ` + "`" + rpcserver.ContentTypeProtobuf + "`" + `. Failures are reported with the matching HTTP status code.
+
+Responses which can be cached carry an ETag, and requests with a matching If-None-Match header are
+answered with 304 Not Modified.`
+
+// REST returns the OpenAPI document, in JSON, of the REST gateway serving
+// routes under prefix. The summaries and descriptions of the routes, as well
+// as the schemas of their results, are taken from the document of the RPC.
+func REST(routes []rpcserver.RESTRoute, prefix string) ([]byte, error) {
+	var spec map[string]any
+	if err := yaml.Unmarshal(Spec, &spec); err != nil {
+		return nil, fmt.Errorf("parsing the OpenAPI document of the RPC: %w", err)
+	}
+	info, _ := spec["info"].(map[string]any)
+	components, _ := spec["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	rpcPaths, _ := spec["paths"].(map[string]any)
+
+	paths := make(map[string]any)
+	for _, route := range routes {
+		path := prefix + route.Path
+		ops, ok := paths[path].(map[string]any)
+		if !ok {
+			ops = make(map[string]any)
+			paths[path] = ops
+		}
+		rpcPath, _ := rpcPaths["/v1/"+route.Name].(map[string]any)
+		rpcOp, _ := rpcPath["get"].(map[string]any)
+		ops[strings.ToLower(route.Method)] = restOperation(route, rpcOp, schemas)
+	}
+
+	restSchemas := make(map[string]any, len(schemas)+1)
+	for name, schema := range schemas {
+		restSchemas[name] = schema
+	}
+	restSchemas["RESTError"] = map[string]any{
+		"type":     "object",
+		"required": []string{"code", "message"},
+		"properties": map[string]any{
+			"code":    map[string]any{"type": "integer", "example": http.StatusNotFound},
+			"message": map[string]any{"type": "string", "example": "tx not found: 0123"},
+		},
+	}
+
+	return json.Marshal(map[string]any{
+		"openapi": spec["openapi"],
+		"info": map[string]any{
+			"title":       "CometBFT REST gateway",
+			"description": restDescription,
+			"version":     info["version"],
+			"license":     info["license"],
+		},
+		"tags":       spec["tags"],
+		"paths":      paths,
+		"components": map[string]any{"schemas": restSchemas},
+	})
+}
+
+// restOperation returns the operation of route, based on rpcOp, the operation
+// of the RPC function in the document of the RPC, if any.
+func restOperation(route rpcserver.RESTRoute, rpcOp map[string]any, schemas map[string]any) map[string]any {
+	op := map[string]any{
+		"operationId": route.Name,
+		"summary":     route.Name,
+	}
+	for _, key := range []string{"summary", "description", "tags"} {
+		if v, ok := rpcOp[key]; ok {
+			op[key] = v
+		}
+	}
+
+	descriptions := make(map[string]any)
+	rpcParams, _ := rpcOp["parameters"].([]any)
+	for _, p := range rpcParams {
+		if p, ok := p.(map[string]any); ok {
+			if name, ok := p["name"].(string); ok {
+				descriptions[name] = p["description"]
+			}
+		}
+	}
+
+	var (
+		params     []any
+		bodyParams = make(map[string]any)
+	)
+	for _, param := range route.Params {
+		if param.In == rpcserver.InBody {
+			bodyParams[param.Name] = schemaOf(param.Type, true)
+			continue
+		}
+		p := map[string]any{
+			"in":       param.In,
+			"name":     param.Name,
+			"required": param.In == rpcserver.InPath,
+			"schema":   schemaOf(param.Type, false),
+		}
+		if d, ok := descriptions[param.Name]; ok && d != nil {
+			p["description"] = d
+		}
+		params = append(params, p)
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if len(bodyParams) > 0 {
+		op["requestBody"] = map[string]any{
+			"content": map[string]any{
+				rpcserver.ContentTypeJSON: map[string]any{
+					"schema": map[string]any{"type": "object", "properties": bodyParams},
+				},
+			},
+		}
+	}
+
+	content := map[string]any{
+		rpcserver.ContentTypeJSON: map[string]any{"schema": resultSchema(rpcOp, schemas)},
+	}
+	if route.Protobuf {
+		content[rpcserver.ContentTypeProtobuf] = map[string]any{
+			"schema": map[string]any{"type": "string", "format": "binary"},
+		}
+	}
+	responses := map[string]any{
+		"200": map[string]any{"description": "Success", "content": content},
+		"default": map[string]any{
+			"description": "Error",
+			"content": map[string]any{
+				rpcserver.ContentTypeJSON: map[string]any{
+					"schema": map[string]any{"$ref": "#/components/schemas/RESTError"},
+				},
+			},
+		},
+	}
+	if route.Cacheable {
+		responses["304"] = map[string]any{"description": "Not modified since the ETag in If-None-Match"}
+	}
+	op["responses"] = responses
+
+	return op
+}
+
+// resultSchema returns the schema of the result in the JSON-RPC response of
+// rpcOp on success.
+func resultSchema(rpcOp map[string]any, schemas map[string]any) any {
+	anySchema := map[string]any{"type": "object"}
+
+	responses, _ := rpcOp["responses"].(map[string]any)
+	ok, _ := responses["200"].(map[string]any)
+	content, _ := ok["content"].(map[string]any)
+	jsonContent, _ := content[rpcserver.ContentTypeJSON].(map[string]any)
+	schema, _ := jsonContent["schema"].(map[string]any)
+	if ref, ok := schema["$ref"].(string); ok {
+		schema, _ = schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+	}
+
+	// The schema is either an object with a result property, or the
+	// composition of the JSON-RPC envelope and such an object.
+	candidates := []any{schema}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		candidates = append(candidates, allOf...)
+	}
+	for _, c := range candidates {
+		c, _ := c.(map[string]any)
+		properties, _ := c["properties"].(map[string]any)
+		if result, ok := properties["result"]; ok {
+			return result
+		}
+	}
+	return anySchema
+}
+
+// schemaOf returns the schema of a parameter of type t, passed in the body of
+// the request or else as a string.
+func schemaOf(t reflect.Type, inBody bool) map[string]any {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		// 64-bit integers are encoded as strings in JSON.
+		if inBody {
+			return map[string]any{"type": "string", "format": "int64"}
+		}
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if inBody {
+				return map[string]any{"type": "string", "format": "byte"}
+			}
+			return map[string]any{"type": "string", "pattern": "^(0x)?[0-9a-fA-F]*$"}
+		}
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem(), true)}
+	default:
+		return map[string]any{"type": "object"}
+	}
+}
@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/rpc/core"
+	rpcserver "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
+)
+
+func TestREST(t *testing.T) {
+	env := &core.Environment{}
+	routes := rpcserver.RESTRoutes(env.GetRoutes())
+
+	doc, err := REST(routes, "/rest")
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Summary     string `json:"summary"`
+			Parameters  []struct {
+				In       string `json:"in"`
+				Name     string `json:"name"`
+				Required bool   `json:"required"`
+			} `json:"parameters"`
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]any `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(doc, &spec))
+	assert.Contains(t, spec.Components.Schemas, "RESTError")
+
+	for _, route := range routes {
+		op, ok := spec.Paths["/rest"+route.Path][map[string]string{"GET": "get", "POST": "post"}[route.Method]]
+		require.True(t, ok, route.Name)
+		assert.Equal(t, route.Name, op.OperationID)
+		// Every route is documented in the OpenAPI document of the RPC.
+		assert.NotEqual(t, route.Name, op.Summary, route.Name)
+		assert.NotEmpty(t, op.Responses["200"].Content, route.Name)
+	}
+
+	block := spec.Paths["/rest/blocks/{height}"]["get"]
+	require.Len(t, block.Parameters, 1)
+	assert.Equal(t, "path", block.Parameters[0].In)
+	assert.True(t, block.Parameters[0].Required)
+	assert.Contains(t, block.Responses, "304")
+	assert.Contains(t, block.Responses["200"].Content, rpcserver.ContentTypeProtobuf)
+	assert.Equal(t, "#/components/schemas/BlockComplete", block.Responses["200"].Content[rpcserver.ContentTypeJSON].Schema["$ref"])
+}
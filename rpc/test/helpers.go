@@ -89,6 +89,8 @@ func createConfig() *cfg.Config {
 	c.RPC.CORSAllowedOrigins = []string{"https://cometbft.com/"}
 	c.GRPC.ListenAddress = makeAddr()
 	c.GRPC.VersionService.Enabled = true
+	c.GRPC.ValidatorService.Enabled = true
+	c.GRPC.EvidenceService.Enabled = true
 	c.GRPC.Privileged.ListenAddress = makeAddr()
 	c.GRPC.Privileged.PruningService.Enabled = true
 	// Set pruning interval to a value lower than the default for some of the
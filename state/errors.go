@@ -89,6 +89,17 @@ type (
 		Err    error
 		Height int64
 	}
+
+	ErrInvalidRollbackHeight struct {
+		Height      int64
+		Base        int64
+		StateHeight int64
+	}
+
+	ErrRollbackColdStorage struct {
+		Height     int64
+		ColdHeight int64
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -129,6 +140,16 @@ func (e ErrStateMismatch) Error() string {
 	)
 }
 
+func (e ErrInvalidRollbackHeight) Error() string {
+	return fmt.Sprintf("cannot roll back to height %d, it must be between the block store base (%d) and the state height (%d)",
+		e.Height, e.Base, e.StateHeight)
+}
+
+func (e ErrRollbackColdStorage) Error() string {
+	return fmt.Sprintf("cannot remove the blocks above height %d, the blocks up to height %d were moved to the cold storage",
+		e.Height, e.ColdHeight)
+}
+
 func (e ErrNoValSetForHeight) Error() string {
 	return fmt.Sprintf("could not find validator set for height #%d", e.Height)
 }
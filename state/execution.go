@@ -135,7 +135,10 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	}
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxReapBytes, maxGas)
-	commit := lastExtCommit.ToCommit()
+	commit, err := blockExec.makeLastCommit(height, state, lastExtCommit)
+	if err != nil {
+		return nil, err
+	}
 	block := state.MakeBlock(height, txs, commit, evidence, proposerAddr)
 	rpp, err := blockExec.proxyApp.PrepareProposal(
 		ctx,
@@ -170,6 +173,59 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	return state.MakeBlock(height, txl, commit, evidence, proposerAddr), nil
 }
 
+// makeLastCommit makes the last commit of the block at height from
+// lastExtCommit, aggregating its signatures if BLS commit aggregation is
+// enabled and all the last validators have BLS12-381 keys.
+func (blockExec *BlockExecutor) makeLastCommit(
+	height int64,
+	state State,
+	lastExtCommit *types.ExtendedCommit,
+) (*types.Commit, error) {
+	commit := lastExtCommit.ToCommit()
+	if len(commit.Signatures) == 0 ||
+		!state.ConsensusParams.Feature.BlsAggregateCommitsEnabled(height) ||
+		!state.LastValidators.AllKeysAreBLS12381() {
+		return commit, nil
+	}
+
+	// The votes of a last commit reconstructed from an aggregated seen commit,
+	// e.g. after block sync, carry no signature: their aggregated signature is
+	// that of the seen commit.
+	for _, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag != types.BlockIDFlagAbsent && len(commitSig.Signature) == 0 {
+			seenCommit := blockExec.blockStore.LoadSeenCommit(commit.Height)
+			if seenCommit == nil || !seenCommit.IsAggregated() {
+				return nil, fmt.Errorf("vote without signature in the last commit, but no aggregated seen commit at height %d",
+					commit.Height)
+			}
+			commit.AggregatedSignature = seenCommit.AggregatedSignature
+			break
+		}
+	}
+	aggregated, err := commit.Aggregate(state.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Aggregate leaves out votes with duplicate sign bytes. Rather keep such
+	// votes, if the commit does not need to be aggregated.
+	if !commit.IsAggregated() && numVotes(aggregated) < numVotes(commit) {
+		return commit, nil
+	}
+	return aggregated, nil
+}
+
+// numVotes returns the number of votes of commit that are not absent.
+func numVotes(commit *types.Commit) int {
+	n := 0
+	for _, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag != types.BlockIDFlagAbsent {
+			n++
+		}
+	}
+	return n
+}
+
 func (blockExec *BlockExecutor) ProcessProposal(
 	block *types.Block,
 	state State,
@@ -771,6 +827,23 @@ func fireEvents(
 	}
 }
 
+// FireBlockEvents publishes the events of a committed block, along with the
+// response of the application to FinalizeBlock, to eventBus. They are published
+// in the order they were when the block was committed.
+func FireBlockEvents(
+	eventBus types.BlockEventPublisher,
+	block *types.Block,
+	blockID types.BlockID,
+	abciResponse *abci.FinalizeBlockResponse,
+) error {
+	validatorUpdates, err := types.PB2TM.ValidatorUpdates(abciResponse.ValidatorUpdates)
+	if err != nil {
+		return err
+	}
+	fireEvents(log.NewNopLogger(), eventBus, block, blockID, abciResponse, validatorUpdates, NopMetrics())
+	return nil
+}
+
 // ----------------------------------------------------------------------------------------------------
 // Execute block without state. TODO: eliminate
 
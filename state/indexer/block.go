@@ -31,3 +31,11 @@ type BlockIndexer interface {
 
 	GetRetainHeight() (int64, error)
 }
+
+// Rollbacker is implemented by the indexers which can delete their entries
+// above a height, when the chain is rolled back to it.
+type Rollbacker interface {
+	// Rollback deletes the entries indexed above height, and returns the
+	// number of heights they belonged to.
+	Rollback(height int64) (int64, error)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
 	"strconv"
@@ -203,6 +204,36 @@ func (idx *BlockerIndexer) Prune(retainHeight int64) (numPruned int64, newRetain
 	return int64(len(affectedHeights)), retainHeight, err
 }
 
+// Rollback deletes the events indexed above height, when the chain is rolled
+// back to it. It implements indexer.Rollbacker.
+func (idx *BlockerIndexer) Rollback(height int64) (int64, error) {
+	itr, err := idx.store.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Close()
+
+	batch := idx.store.NewBatch()
+	defer batch.Close()
+	affectedHeights := make(map[int64]struct{})
+	for ; itr.Valid(); itr.Next() {
+		if !keyBelongsToHeightRange(itr.Key(), height+1, math.MaxInt64) {
+			continue
+		}
+		if err := batch.Delete(itr.Key()); err != nil {
+			return 0, err
+		}
+		affectedHeights[getHeightFromKey(itr.Key())] = struct{}{}
+	}
+	if err := itr.Error(); err != nil {
+		return 0, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return int64(len(affectedHeights)), nil
+}
+
 func (idx *BlockerIndexer) SetRetainHeight(retainHeight int64) error {
 	return idx.store.SetSync(BlockIndexerRetainHeightKey, int64ToBytes(retainHeight))
 }
@@ -57,6 +57,28 @@ func TestBlockerIndexer_Prune(t *testing.T) {
 	require.True(t, emptyIntersection(keys1, keys3))
 }
 
+func TestBlockerIndexer_Rollback(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	require.NoError(t, indexer.Index(getEventsForTesting(1)))
+	keys1 := blockidxkv.GetKeys(*indexer)
+	require.NoError(t, indexer.Index(getEventsForTesting(2)))
+	require.NoError(t, indexer.Index(getEventsForTesting(3)))
+
+	numDeleted, err := indexer.Rollback(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), numDeleted)
+	require.True(t, isEqualSets(keys1, blockidxkv.GetKeys(*indexer)))
+
+	has, err := indexer.Has(2)
+	require.NoError(t, err)
+	require.False(t, has)
+	has, err = indexer.Has(1)
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
 func BenchmarkBlockerIndexer_Prune(_ *testing.B) {
 	config := test.ResetTestRoot("block_indexer")
 	defer func() {
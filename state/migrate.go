@@ -0,0 +1,145 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/orderedcode"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/internal/keylayout"
+	"github.com/cometbft/cometbft/v2/libs/log"
+)
+
+// NewKeyLayoutMigrator returns a migrator of the validators, consensus params
+// and ABCI responses of the state store in db to the key layout with the
+// given version ("v1" or "v2"). It returns keylayout.ErrSameLayout if the
+// state store already uses this layout.
+//
+// The state store must not be in use while the migration completes, see
+// keylayout.Migrator.
+func NewKeyLayoutMigrator(db dbm.DB, version string, logger log.Logger) (*keylayout.Migrator, error) {
+	current, err := keylayout.Version(db)
+	if err != nil {
+		return nil, err
+	}
+	if current == version {
+		return nil, keylayout.ErrSameLayout{Version: version}
+	}
+	from, err := stateKeyLayout(current)
+	if err != nil {
+		return nil, err
+	}
+	to, err := stateKeyLayout(version)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]keylayout.Record, 0, len(stateRecordKinds))
+	for _, kind := range stateRecordKinds {
+		records = append(records, kind.record(from, to))
+	}
+	return keylayout.NewMigrator(db, current, version, records, logger), nil
+}
+
+func stateKeyLayout(version string) (KeyLayout, error) {
+	switch version {
+	case "v1":
+		return v1LegacyLayout{}, nil
+	case "v2":
+		return v2Layout{}, nil
+	default:
+		return nil, fmt.Errorf("unknown key layout version %q", version)
+	}
+}
+
+// stateRecordKind is a kind of records of the state store, stored by height,
+// with its keys in each layout.
+type stateRecordKind struct {
+	name     string
+	v1Prefix string
+	v2Prefix int64
+	key      func(layout KeyLayout, height int64) []byte
+}
+
+var stateRecordKinds = []stateRecordKind{
+	{
+		name:     "validators",
+		v1Prefix: "validatorsKey:",
+		v2Prefix: prefixValidators,
+		key:      KeyLayout.CalcValidatorsKey,
+	},
+	{
+		name:     "consensus params",
+		v1Prefix: "consensusParamsKey:",
+		v2Prefix: prefixConsensusParams,
+		key:      KeyLayout.CalcConsensusParamsKey,
+	},
+	{
+		name:     "ABCI responses",
+		v1Prefix: "abciResponsesKey:",
+		v2Prefix: prefixABCIResponses,
+		key:      KeyLayout.CalcABCIResponsesKey,
+	},
+}
+
+func isV1Layout(layout KeyLayout) bool {
+	switch layout.(type) {
+	case v1LegacyLayout, *v1LegacyLayout:
+		return true
+	}
+	return false
+}
+
+func (kind stateRecordKind) prefix(layout KeyLayout) []byte {
+	if isV1Layout(layout) {
+		return []byte(kind.v1Prefix)
+	}
+	prefix, err := orderedcode.Append(nil, kind.v2Prefix)
+	if err != nil {
+		panic(err)
+	}
+	return prefix
+}
+
+func (kind stateRecordKind) parseHeight(layout KeyLayout, key []byte) (int64, error) {
+	s, ok := strings.CutPrefix(string(key), string(kind.prefix(layout)))
+	if !ok {
+		return 0, fmt.Errorf("expected %s key prefix", kind.name)
+	}
+	if isV1Layout(layout) {
+		return strconv.ParseInt(s, 10, 64)
+	}
+	var height int64
+	remaining, err := orderedcode.Parse(s, &height)
+	if err != nil {
+		return 0, err
+	}
+	if remaining != "" {
+		return 0, errors.New("unexpected key suffix")
+	}
+	return height, nil
+}
+
+// record returns the migration of the records of this kind from one layout
+// to the other.
+func (kind stateRecordKind) record(from, to KeyLayout) keylayout.Record {
+	convert := func(from, to KeyLayout) func([]byte) ([]byte, error) {
+		return func(key []byte) ([]byte, error) {
+			height, err := kind.parseHeight(from, key)
+			if err != nil {
+				return nil, err
+			}
+			return kind.key(to, height), nil
+		}
+	}
+	return keylayout.Record{
+		Name:      kind.name,
+		OldPrefix: kind.prefix(from),
+		NewPrefix: kind.prefix(to),
+		NewKey:    convert(from, to),
+		OldKey:    convert(to, from),
+	}
+}
@@ -0,0 +1,55 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/internal/keylayout"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+)
+
+func TestKeyLayoutMigration(t *testing.T) {
+	state, stateDB, _ := makeState(4, 10, chainID)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	for height := int64(1); height <= 10; height++ {
+		err := stateStore.SaveFinalizeBlockResponse(height, &abci.FinalizeBlockResponse{AppHash: []byte{byte(height)}})
+		require.NoError(t, err)
+	}
+
+	migrator, err := sm.NewKeyLayoutMigrator(stateDB, "v2", log.NewNopLogger())
+	require.NoError(t, err)
+	stats, err := migrator.Run(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 10, stats["ABCI responses"].Count)
+	require.NotZero(t, stats["validators"].Count)
+	require.NotZero(t, stats["consensus params"].Count)
+
+	version, err := keylayout.Version(stateDB)
+	require.NoError(t, err)
+	require.Equal(t, "v2", version)
+
+	stateStore = sm.NewStore(stateDB, sm.StoreOptions{DBKeyLayout: "v1"})
+	loaded, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, state.LastBlockHeight, loaded.LastBlockHeight)
+	for height := int64(1); height <= 10; height++ {
+		vals, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		require.Equal(t, state.Validators.Hash(), vals.Hash())
+
+		params, err := stateStore.LoadConsensusParams(height)
+		require.NoError(t, err)
+		require.Equal(t, state.ConsensusParams, params)
+
+		resp, err := stateStore.LoadFinalizeBlockResponse(height)
+		require.NoError(t, err)
+		require.Equal(t, []byte{byte(height)}, resp.AppHash)
+	}
+
+	_, err = sm.NewKeyLayoutMigrator(stateDB, "v2", log.NewNopLogger())
+	require.ErrorAs(t, err, &keylayout.ErrSameLayout{})
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -124,3 +125,174 @@ func Rollback(bs BlockStore, ss Store, removeBlock bool) (int64, []byte, error)
 
 	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
 }
+
+// RollbackPlan describes a rollback of the state and the block store to a
+// target height, as computed by PlanRollback.
+type RollbackPlan struct {
+	// State is the state at the target height, which replaces the current one.
+	State State
+	// StateHeight is the height of the current state.
+	StateHeight int64
+	// BlockStoreHeight is the height of the block store before the rollback.
+	BlockStoreHeight int64
+	// RetainBlockHeight is the height of the block store after the rollback.
+	// The blocks above it are deleted.
+	RetainBlockHeight int64
+}
+
+// PlanRollback computes a rollback of the state and the block store to the
+// given height, without changing them. The state at height is restored from
+// the stored validators and consensus params, and its app hash and last
+// results hash from the header of the next block, which must match the stored
+// FinalizeBlockResponse of height, if any.
+//
+// The blocks above height + 1 are deleted, since consensus only replays one
+// block on top of the state, and if removeBlock is true, the block at height +
+// 1 is deleted too. The block store may be more than one height above the
+// state if a previous rollback was interrupted, in which case rolling back to
+// the same height completes it. Blocks moved to the cold storage of the block
+// store can't be deleted.
+// Note that this function does not affect application state.
+func PlanRollback(bs BlockStore, ss Store, height int64, removeBlock bool) (RollbackPlan, error) {
+	invalidState, err := ss.Load()
+	if err != nil {
+		return RollbackPlan{}, err
+	}
+	if invalidState.IsEmpty() {
+		return RollbackPlan{}, errors.New("no state found")
+	}
+
+	plan := RollbackPlan{
+		StateHeight:       invalidState.LastBlockHeight,
+		BlockStoreHeight:  bs.Height(),
+		RetainBlockHeight: height + 1,
+	}
+	if removeBlock {
+		plan.RetainBlockHeight = height
+	}
+	if plan.BlockStoreHeight < plan.StateHeight {
+		return RollbackPlan{}, fmt.Errorf("blockstore height (%d) is below statestore height (%d)",
+			plan.BlockStoreHeight, plan.StateHeight)
+	}
+	if height > plan.StateHeight || height < bs.Base() || height < invalidState.InitialHeight {
+		return RollbackPlan{}, ErrInvalidRollbackHeight{Height: height, Base: bs.Base(), StateHeight: plan.StateHeight}
+	}
+	plan.RetainBlockHeight = min(plan.RetainBlockHeight, plan.BlockStoreHeight)
+	if cbs, ok := bs.(coldBlockStore); ok && plan.RetainBlockHeight < cbs.ColdHeight() {
+		return RollbackPlan{}, ErrRollbackColdStorage{Height: plan.RetainBlockHeight, ColdHeight: cbs.ColdHeight()}
+	}
+
+	if height == plan.StateHeight {
+		// Only blocks are deleted.
+		plan.State = invalidState
+		return plan, nil
+	}
+
+	rollbackBlock := bs.LoadBlockMeta(height)
+	if rollbackBlock == nil {
+		return RollbackPlan{}, fmt.Errorf("block at height %d not found", height)
+	}
+	// The app hash and last results hash are only agreed upon in the
+	// following block.
+	nextBlock := bs.LoadBlockMeta(height + 1)
+	if nextBlock == nil {
+		return RollbackPlan{}, fmt.Errorf("block at height %d not found", height+1)
+	}
+	resp, err := ss.LoadFinalizeBlockResponse(height)
+	switch {
+	case err == nil:
+		if !bytes.Equal(resp.AppHash, nextBlock.Header.AppHash) ||
+			!bytes.Equal(TxResultsHash(resp.TxResults), nextBlock.Header.LastResultsHash) {
+			return RollbackPlan{}, fmt.Errorf("stored results of height %d don't match the header of block %d", height, height+1)
+		}
+	case errors.Is(err, ErrFinalizeBlockResponsesNotPersisted) || errors.As(err, &ErrNoABCIResponsesForHeight{}):
+		// Rely on the header only.
+	default:
+		return RollbackPlan{}, err
+	}
+
+	lastValidators, err := ss.LoadValidators(height)
+	if err != nil {
+		return RollbackPlan{}, err
+	}
+	validators, err := ss.LoadValidators(height + 1)
+	if err != nil {
+		return RollbackPlan{}, err
+	}
+	nextValidators, err := ss.LoadValidators(height + 2)
+	if err != nil {
+		return RollbackPlan{}, err
+	}
+	params, err := ss.LoadConsensusParams(height + 1)
+	if err != nil {
+		return RollbackPlan{}, err
+	}
+
+	// The validators and params are saved again at these heights, so they
+	// can't be above them.
+	valChangeHeight := min(invalidState.LastHeightValidatorsChanged, height+2)
+	paramsChangeHeight := min(invalidState.LastHeightConsensusParamsChanged, height+1)
+
+	plan.State = State{
+		Version: cmtstate.Version{
+			// The header of the next block has the version of the state.
+			Consensus: cmtversion.Consensus{
+				Block: version.BlockProtocol,
+				App:   nextBlock.Header.Version.App,
+			},
+			Software: version.CMTSemVer,
+		},
+		// immutable fields
+		ChainID:       invalidState.ChainID,
+		InitialHeight: invalidState.InitialHeight,
+
+		LastBlockHeight: rollbackBlock.Header.Height,
+		LastBlockID:     rollbackBlock.BlockID,
+		LastBlockTime:   rollbackBlock.Header.Time,
+
+		NextValidators:              nextValidators,
+		Validators:                  validators,
+		LastValidators:              lastValidators,
+		LastHeightValidatorsChanged: valChangeHeight,
+
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: paramsChangeHeight,
+
+		LastResultsHash: nextBlock.Header.LastResultsHash,
+		AppHash:         nextBlock.Header.AppHash,
+	}
+	return plan, nil
+}
+
+// coldBlockStore is implemented by block stores moving old blocks to a cold
+// storage, from which they can't be deleted.
+type coldBlockStore interface {
+	ColdHeight() int64
+}
+
+// ExecuteRollback applies a plan computed by PlanRollback: it saves the state
+// at the target height, then deletes the blocks above the retained height. If
+// it's interrupted, planning a rollback to the same height again completes
+// it.
+func ExecuteRollback(bs BlockStore, ss Store, plan RollbackPlan) error {
+	current, err := ss.Load()
+	if err != nil {
+		return err
+	}
+	if current.LastBlockHeight != plan.StateHeight || bs.Height() != plan.BlockStoreHeight {
+		return errors.New("the stores changed since the rollback was planned")
+	}
+
+	if plan.State.LastBlockHeight < plan.StateHeight {
+		if err := ss.Save(plan.State); err != nil {
+			return fmt.Errorf("failed to save rolled back state: %w", err)
+		}
+	}
+	for bs.Height() > plan.RetainBlockHeight {
+		height := bs.Height()
+		if err := bs.DeleteLatestBlock(); err != nil {
+			return fmt.Errorf("failed to remove block %d from blockstore: %w", height, err)
+		}
+	}
+	return nil
+}
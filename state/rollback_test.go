@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	dbm "github.com/cometbft/cometbft-db"
@@ -12,9 +13,13 @@ import (
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
 	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	mpmocks "github.com/cometbft/cometbft/v2/mempool/mocks"
+	"github.com/cometbft/cometbft/v2/proxy"
 	"github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/state/mocks"
 	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/store/segment"
 	"github.com/cometbft/cometbft/v2/types"
 	cmttime "github.com/cometbft/cometbft/v2/types/time"
 	"github.com/cometbft/cometbft/v2/version"
@@ -238,6 +243,119 @@ func TestRollbackDifferentStateHeight(t *testing.T) {
 	require.Equal(t, "statestore height (100) is not one below or equal to blockstore height (102)", err.Error())
 }
 
+// makeRollbackChain executes blocks up to height, saving them to blockStore,
+// and returns the state store and the state after each height.
+func makeRollbackChain(t *testing.T, blockStore *store.BlockStore, height int64) (state.Store, map[int64]state.State) {
+	t.Helper()
+	app := &testApp{AppHash: tmhash.Sum([]byte("app_hash"))}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	st, stateDB, privVals := makeState(1, 1, chainID)
+	stateStore := state.NewStore(stateDB, state.StoreOptions{DiscardABCIResponses: false})
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("PreUpdate").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	blockExec := state.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mp, state.EmptyEvidencePool{}, blockStore)
+
+	states := make(map[int64]state.State)
+	lastCommit := &types.Commit{}
+	for h := int64(1); h <= height; h++ {
+		block := makeBlock(st, h, lastCommit)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		st, err = blockExec.ApplyBlock(st, blockID, block, h)
+		require.NoError(t, err)
+		extCommit, err := makeValidCommit(h, blockID, st.LastValidators, privVals)
+		require.NoError(t, err)
+		lastCommit = extCommit.ToCommit()
+		blockStore.SaveBlock(block, parts, lastCommit)
+		states[h] = st
+	}
+	return stateStore, states
+}
+
+func TestRollbackToHeight(t *testing.T) {
+	const height int64 = 10
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore, states := makeRollbackChain(t, blockStore, height)
+
+	_, err := state.PlanRollback(blockStore, stateStore, height+1, false)
+	require.ErrorAs(t, err, &state.ErrInvalidRollbackHeight{})
+	_, err = state.PlanRollback(blockStore, stateStore, 0, false)
+	require.ErrorAs(t, err, &state.ErrInvalidRollbackHeight{})
+
+	plan, err := state.PlanRollback(blockStore, stateStore, 5, false)
+	require.NoError(t, err)
+	require.Equal(t, height, plan.StateHeight)
+	require.Equal(t, height, plan.BlockStoreHeight)
+	require.EqualValues(t, 6, plan.RetainBlockHeight)
+	expected := states[5]
+	require.Equal(t, expected.LastBlockID, plan.State.LastBlockID)
+	require.Equal(t, expected.AppHash, plan.State.AppHash)
+	require.Equal(t, expected.LastResultsHash, plan.State.LastResultsHash)
+	require.Equal(t, expected.LastValidators.Hash(), plan.State.LastValidators.Hash())
+	require.Equal(t, expected.Validators.Hash(), plan.State.Validators.Hash())
+	require.Equal(t, expected.NextValidators.Hash(), plan.State.NextValidators.Hash())
+	require.Equal(t, expected.ConsensusParams.Hash(), plan.State.ConsensusParams.Hash())
+
+	// Planning doesn't change the stores.
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, height, loadedState.LastBlockHeight)
+	require.Equal(t, height, blockStore.Height())
+
+	require.NoError(t, state.ExecuteRollback(blockStore, stateStore, plan))
+	loadedState, err = stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, expected.LastBlockID, loadedState.LastBlockID)
+	require.EqualValues(t, 6, blockStore.Height())
+
+	// The plan is stale once executed.
+	require.Error(t, state.ExecuteRollback(blockStore, stateStore, plan))
+
+	plan, err = state.PlanRollback(blockStore, stateStore, 3, true)
+	require.NoError(t, err)
+	require.NoError(t, state.ExecuteRollback(blockStore, stateStore, plan))
+	loadedState, err = stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, states[3].LastBlockID, loadedState.LastBlockID)
+	require.EqualValues(t, 3, blockStore.Height())
+}
+
+func TestRollbackToHeightColdStorage(t *testing.T) {
+	const height int64 = 10
+	cold, err := segment.Open(t.TempDir(), 2)
+	require.NoError(t, err)
+	blockStore := store.NewBlockStore(dbm.NewMemDB(), store.WithColdStorage(cold, 3))
+	defer blockStore.Close()
+	stateStore, states := makeRollbackChain(t, blockStore, height)
+	// The blocks are moved in the background.
+	require.Eventually(t, func() bool { return blockStore.ColdHeight() == 7 }, 5*time.Second, 10*time.Millisecond)
+
+	// The blocks moved to the cold storage can't be removed.
+	_, err = state.PlanRollback(blockStore, stateStore, 5, false)
+	require.ErrorAs(t, err, &state.ErrRollbackColdStorage{})
+	_, err = state.PlanRollback(blockStore, stateStore, 6, true)
+	require.ErrorAs(t, err, &state.ErrRollbackColdStorage{})
+
+	// The state can be rolled back to the last block in the cold storage, as
+	// long as the next block is retained.
+	plan, err := state.PlanRollback(blockStore, stateStore, 7, false)
+	require.NoError(t, err)
+	require.NoError(t, state.ExecuteRollback(blockStore, stateStore, plan))
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, states[7].LastBlockID, loadedState.LastBlockID)
+	require.EqualValues(t, 8, blockStore.Height())
+	require.EqualValues(t, 7, blockStore.ColdHeight())
+}
+
 func setupStateStore(t *testing.T, height int64) state.Store {
 	t.Helper()
 	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{DiscardABCIResponses: false})
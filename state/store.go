@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -201,6 +202,14 @@ type Store interface {
 	Close() error
 }
 
+// HistoricalStore is implemented by the stores into which the history of a
+// chain can be imported, like the store returned by NewStore.
+type HistoricalStore interface {
+	// SaveHistoricalHeight saves the validators and consensus params of a
+	// height of the chain history, e.g. imported from another node
+	SaveHistoricalHeight(height int64, vals *types.ValidatorSet, params types.ConsensusParams) error
+}
+
 // dbStore wraps a db (github.com/cometbft/cometbft-db).
 type dbStore struct {
 	db dbm.DB
@@ -230,7 +239,10 @@ type StoreOptions struct {
 	DBKeyLayout string
 }
 
-var _ Store = (*dbStore)(nil)
+var (
+	_ Store           = (*dbStore)(nil)
+	_ HistoricalStore = (*dbStore)(nil)
+)
 
 func IsEmpty(store dbStore) (bool, error) {
 	state, err := store.Load()
@@ -469,6 +481,40 @@ func (store dbStore) Bootstrap(state State) error {
 	return batch.Close()
 }
 
+// SaveHistoricalHeight saves the validator set and consensus params of a
+// height of the chain history. The heights must be saved in increasing order:
+// like when saving a state, the validator set and consensus params are only
+// stored in full if they changed since the previous height, or at a
+// checkpoint height.
+func (store dbStore) SaveHistoricalHeight(height int64, vals *types.ValidatorSet, params types.ConsensusParams) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	valsChanged, paramsChanged := height, height
+	if prevVals, err := store.LoadValidators(height - 1); err == nil && bytes.Equal(prevVals.Hash(), vals.Hash()) {
+		valInfo, _, err := loadValidatorsInfo(store.db, store.DBKeyLayout.CalcValidatorsKey(height-1))
+		if err != nil {
+			return err
+		}
+		valsChanged = valInfo.LastHeightChanged
+	}
+	if prevParams, err := store.LoadConsensusParams(height - 1); err == nil && bytes.Equal(prevParams.Hash(), params.Hash()) {
+		paramsInfo, err := store.loadConsensusParamsInfo(height - 1)
+		if err != nil {
+			return err
+		}
+		paramsChanged = paramsInfo.LastHeightChanged
+	}
+
+	if err := store.saveValidatorsInfo(height, valsChanged, vals, batch); err != nil {
+		return err
+	}
+	if err := store.saveConsensusParamsInfo(height, paramsChanged, params, batch); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
 // PruneStates deletes states between the given heights (including from, excluding to). It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at to must also exist.
@@ -1071,7 +1117,7 @@ func (store dbStore) loadConsensusParamsInfo(height int64) (*cmtstate.ConsensusP
 	addTimeSample(store.StoreOptions.Metrics.StoreAccessDurationSeconds.With("method", "load_consensus_params"), start)()
 
 	if len(buf) == 0 {
-		return nil, errors.New("value retrieved from db is empty")
+		return nil, ErrNoConsensusParamsForHeight{Height: height}
 	}
 
 	paramsInfo := new(cmtstate.ConsensusParamsInfo)
@@ -225,6 +225,7 @@ func TestPruneStates(t *testing.T) {
 					require.NotEmpty(t, params)
 				} else {
 					require.Error(t, err, "params height %v", h)
+					require.ErrorAs(t, err, &sm.ErrNoConsensusParamsForHeight{})
 					require.Empty(t, params)
 				}
 
@@ -2,6 +2,7 @@ package txindex
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/cometbft/cometbft/v2/libs/service"
 	"github.com/cometbft/cometbft/v2/state/indexer"
@@ -23,6 +24,8 @@ type IndexerService struct {
 	blockIdxr        indexer.BlockIndexer
 	eventBus         *types.EventBus
 	terminateOnError bool
+
+	indexedHeight atomic.Int64
 }
 
 // NewIndexerService returns a new service instance.
@@ -117,12 +120,19 @@ func (is *IndexerService) OnStart() error {
 				} else {
 					is.Logger.Debug("indexed transactions", "height", height, "num_txs", numTxs)
 				}
+				is.indexedHeight.Store(height)
 			}
 		}
 	}()
 	return nil
 }
 
+// IndexedHeight returns the last height indexed since the service started, or
+// 0 if none was.
+func (is *IndexerService) IndexedHeight() int64 {
+	return is.indexedHeight.Load()
+}
+
 // OnStop implements service.Service by unsubscribing from all transactions.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
@@ -18,9 +18,10 @@ import (
 )
 
 func TestIndexerServiceIndexesBlocks(t *testing.T) {
-	_, txIndexer, blockIndexer, eventBus := createTestSetup(t)
+	service, txIndexer, blockIndexer, eventBus := createTestSetup(t)
 
 	height := int64(1)
+	require.Zero(t, service.IndexedHeight())
 
 	events, txResult1, txResult2 := getEventsAndResults(height)
 	// publish block with events
@@ -34,6 +35,7 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	require.NoError(t, err)
 
 	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, height, service.IndexedHeight())
 
 	res, err := txIndexer.Get(types.Tx(fmt.Sprintf("foo%d", height)).Hash())
 	require.NoError(t, err)
@@ -168,6 +168,30 @@ func (txi *TxIndex) Prune(retainHeight int64) (numPruned int64, newRetainHeight
 	return numHeightsPersistentlyPruned, currentPersistentlyRetainedHeight, err
 }
 
+// Rollback deletes the transactions indexed above height, when the chain is
+// rolled back to it. It implements indexer.Rollbacker.
+func (txi *TxIndex) Rollback(height int64) (int64, error) {
+	results, _, err := txi.Search(context.Background(), query.MustCompile(
+		fmt.Sprintf("tx.height > %d", height)), txindex.Pagination{})
+	if err != nil {
+		return 0, err
+	}
+
+	batch := txi.store.NewBatch()
+	defer batch.Close()
+	affectedHeights := make(map[int64]struct{})
+	for _, result := range results {
+		if err := txi.deleteResult(result, batch); err != nil {
+			return 0, err
+		}
+		affectedHeights[result.Height] = struct{}{}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return int64(len(affectedHeights)), nil
+}
+
 func (txi *TxIndex) SetRetainHeight(retainHeight int64) error {
 	return txi.store.SetSync(TxIndexerRetainHeightKey, int64ToBytes(retainHeight))
 }
@@ -149,6 +149,41 @@ func TestTxIndex_Prune(t *testing.T) {
 	assert.True(t, proto.Equal(txResult2, loadedTxResult2))
 }
 
+func TestTxIndex_Rollback(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	events := []abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
+	}
+	results := make([]*abci.TxResult, 0, 3)
+	for h := int64(1); h <= 3; h++ {
+		txResult := &abci.TxResult{
+			Height: h,
+			Tx:     types.Tx(fmt.Sprintf("TX %d", h)),
+			Result: abci.ExecTxResult{Code: abci.CodeTypeOK, Events: events},
+		}
+		require.NoError(t, indexer.Index(txResult))
+		results = append(results, txResult)
+	}
+
+	numDeleted, err := indexer.Rollback(1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), numDeleted)
+
+	loaded, err := indexer.Get(types.Tx(results[0].Tx).Hash())
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(results[0], loaded))
+	for _, result := range results[1:] {
+		loaded, err := indexer.Get(types.Tx(result.Tx).Hash())
+		require.NoError(t, err)
+		assert.Nil(t, loaded)
+	}
+
+	found, _, err := indexer.Search(context.Background(), query.MustCompile("account.number = 1"), txindex.Pagination{})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+}
+
 func TestTxSearch(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -90,6 +90,9 @@ func validateBlock(state State, block *types.Block) error {
 			return errors.New("initial block can't have LastCommit signatures")
 		}
 	} else {
+		if block.LastCommit.IsAggregated() && !state.ConsensusParams.Feature.BlsAggregateCommitsEnabled(block.Height) {
+			return errors.New("aggregated LastCommit while BLS commit aggregation is disabled")
+		}
 		// LastCommit.Signatures length is checked in VerifyCommit.
 		if err := state.LastValidators.VerifyCommit(
 			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
@@ -10,6 +10,7 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	"github.com/cometbft/cometbft/v2/internal/test"
@@ -212,6 +213,18 @@ func TestValidateBlockCommit(t *testing.T) {
 				height,
 				err,
 			)
+
+			/*
+				an aggregated LastCommit is invalid while BLS commit aggregation is disabled
+			*/
+			aggregatedCommit := lastCommit.Clone()
+			for i := range aggregatedCommit.Signatures {
+				aggregatedCommit.Signatures[i].Signature = nil
+			}
+			aggregatedCommit.AggregatedSignature = make([]byte, bls12381.SignatureLength)
+			block = makeBlock(state, height, aggregatedCommit)
+			err = blockExec.ValidateBlock(state, block)
+			require.ErrorContains(t, err, "BLS commit aggregation is disabled", "height %d", height)
 		}
 
 		/*
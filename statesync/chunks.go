@@ -35,6 +35,7 @@ type chunkQueue struct {
 	chunkSenders   map[uint32]p2p.ID          // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
+	chunkSkipped   map[uint32]bool            // chunks already applied before a restart, see SkipApplied()
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
 }
 
@@ -55,6 +56,7 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkSenders:   make(map[uint32]p2p.ID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		chunkSkipped:   make(map[uint32]bool),
 		waiters:        make(map[uint32][]chan<- uint32),
 	}, nil
 }
@@ -157,6 +159,12 @@ func (q *chunkQueue) discard(index uint32) error {
 	}
 	path := q.chunkFiles[index]
 	if path == "" {
+		// Skipped chunks are not held by any fetcher, so they can be refetched.
+		if q.chunkSkipped[index] {
+			delete(q.chunkSkipped, index)
+			delete(q.chunkReturned, index)
+			delete(q.chunkAllocated, index)
+		}
 		return nil
 	}
 	err := os.Remove(path)
@@ -278,11 +286,50 @@ func (q *chunkQueue) Retry(index uint32) {
 	delete(q.chunkReturned, index)
 }
 
-// RetryAll schedules all chunks to be retried, without refetching them.
+// RetryAll schedules all chunks to be retried, without refetching them. Skipped chunks were
+// never fetched, so they are scheduled for fetching instead.
 func (q *chunkQueue) RetryAll() {
 	q.Lock()
 	defer q.Unlock()
 	q.chunkReturned = make(map[uint32]bool)
+	for index := range q.chunkSkipped {
+		delete(q.chunkAllocated, index)
+	}
+	q.chunkSkipped = make(map[uint32]bool)
+}
+
+// SkipApplied marks the first count chunks as already applied by the app, e.g. before the node
+// was restarted. They will not be fetched nor returned via Next(), unless they are discarded or
+// all chunks are retried.
+func (q *chunkQueue) SkipApplied(count uint32) {
+	q.Lock()
+	defer q.Unlock()
+	if q.snapshot == nil {
+		return
+	}
+	for i := uint32(0); i < count && i < q.snapshot.Chunks; i++ {
+		if q.chunkFiles[i] != "" || q.chunkAllocated[i] {
+			continue
+		}
+		q.chunkAllocated[i] = true
+		q.chunkReturned[i] = true
+		q.chunkSkipped[i] = true
+	}
+}
+
+// Applied returns the number of consecutive chunks, starting from index 0, that have been
+// returned via Next() (or skipped) and not scheduled for retrying or refetching since.
+func (q *chunkQueue) Applied() uint32 {
+	q.Lock()
+	defer q.Unlock()
+	if q.snapshot == nil {
+		return 0
+	}
+	var count uint32
+	for count < q.snapshot.Chunks && q.chunkReturned[count] {
+		count++
+	}
+	return count
 }
 
 // Size returns the total number of chunks for the snapshot and queue, or 0 when closed.
@@ -478,6 +478,40 @@ func TestChunkQueue_RetryAll(t *testing.T) {
 	assert.Equal(t, errDone, err)
 }
 
+func TestChunkQueue_SkipApplied(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	queue.SkipApplied(2)
+	assert.EqualValues(t, 2, queue.Applied())
+
+	// Skipped chunks are neither allocated nor returned.
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 2, Chunk: []byte{3, 1, 2}})
+	require.NoError(t, err)
+	chunk, err := queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, chunk.Index)
+	assert.EqualValues(t, 3, queue.Applied())
+
+	// Discarding a skipped chunk schedules it for refetching.
+	err = queue.Discard(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, queue.Applied())
+	index, err = queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, index)
+
+	// Retrying all chunks schedules the remaining skipped chunks for fetching.
+	queue.RetryAll()
+	assert.EqualValues(t, 0, queue.Applied())
+	index, err = queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, index)
+}
+
 func TestChunkQueue_Size(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
@@ -56,6 +56,7 @@ func NewReactor(
 		cfg:       cfg,
 		conn:      conn,
 		connQuery: connQuery,
+		tempDir:   cfg.TempDir,
 		metrics:   metrics,
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
@@ -0,0 +1,177 @@
+package statesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cmtstate "github.com/cometbft/cometbft/api/cometbft/state/v2"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// resumeFileName is the name of the file, within the state sync temp dir, that
+// records the progress of an in-flight snapshot restoration.
+const resumeFileName = "statesync-resume.json"
+
+// resumeState is the on-disk record of an in-flight snapshot restoration. It
+// allows a node that crashed or was stopped halfway through Sync() to offer the
+// same snapshot to the app again and continue applying chunks from where it left
+// off, instead of starting over from snapshot discovery.
+type resumeState struct {
+	Height         uint64 `json:"height"`
+	Format         uint32 `json:"format"`
+	Chunks         uint32 `json:"chunks"`
+	Hash           []byte `json:"hash"`
+	Metadata       []byte `json:"metadata"`
+	TrustedAppHash []byte `json:"trusted_app_hash"`
+
+	// State and Commit are the protobuf-encoded state and commit at the snapshot
+	// height, built from the light block verified by the state provider. They
+	// are nil until the state provider has returned them.
+	State  []byte `json:"state,omitempty"`
+	Commit []byte `json:"commit,omitempty"`
+
+	// AppliedChunks is the number of chunks, starting from index 0, that the app
+	// has accepted.
+	AppliedChunks uint32 `json:"applied_chunks"`
+}
+
+// newResumeState creates a resume record for the given snapshot.
+func newResumeState(snapshot *snapshot) *resumeState {
+	return &resumeState{
+		Height:         snapshot.Height,
+		Format:         snapshot.Format,
+		Chunks:         snapshot.Chunks,
+		Hash:           snapshot.Hash,
+		Metadata:       snapshot.Metadata,
+		TrustedAppHash: snapshot.trustedAppHash,
+	}
+}
+
+// Snapshot returns the snapshot recorded in the resume record.
+func (r *resumeState) Snapshot() *snapshot {
+	return &snapshot{
+		Height:         r.Height,
+		Format:         r.Format,
+		Chunks:         r.Chunks,
+		Hash:           r.Hash,
+		Metadata:       r.Metadata,
+		trustedAppHash: r.TrustedAppHash,
+	}
+}
+
+// Matches returns true if the resume record refers to the given snapshot.
+func (r *resumeState) Matches(snapshot *snapshot) bool {
+	return r.Snapshot().Key() == snapshot.Key()
+}
+
+// SetStateAndCommit records the verified state and commit at the snapshot height.
+func (r *resumeState) SetStateAndCommit(state sm.State, commit *types.Commit) error {
+	stateProto, err := state.ToProto()
+	if err != nil {
+		return err
+	}
+	stateBz, err := stateProto.Marshal()
+	if err != nil {
+		return err
+	}
+	commitBz, err := commit.ToProto().Marshal()
+	if err != nil {
+		return err
+	}
+	r.State = stateBz
+	r.Commit = commitBz
+	return nil
+}
+
+// StateAndCommit returns the recorded state and commit, or false if they have
+// not been recorded yet.
+func (r *resumeState) StateAndCommit() (sm.State, *types.Commit, bool, error) {
+	if r.State == nil || r.Commit == nil {
+		return sm.State{}, nil, false, nil
+	}
+	stateProto := new(cmtstate.State)
+	if err := stateProto.Unmarshal(r.State); err != nil {
+		return sm.State{}, nil, false, err
+	}
+	state, err := sm.FromProto(stateProto)
+	if err != nil {
+		return sm.State{}, nil, false, err
+	}
+	commitProto := new(cmtproto.Commit)
+	if err := commitProto.Unmarshal(r.Commit); err != nil {
+		return sm.State{}, nil, false, err
+	}
+	commit, err := types.CommitFromProto(commitProto)
+	if err != nil {
+		return sm.State{}, nil, false, err
+	}
+	return *state, commit, true, nil
+}
+
+// resumeStore persists resume records to a file in the state sync temp dir. A
+// zero-value resumeStore (no directory configured) is valid and persists
+// nothing.
+type resumeStore struct {
+	path string
+}
+
+// newResumeStore creates a resume store in the given directory. If dir is empty,
+// progress is not persisted, since the OS temp dir is not guaranteed to survive
+// a restart.
+func newResumeStore(dir string) *resumeStore {
+	if dir == "" {
+		return &resumeStore{}
+	}
+	return &resumeStore{path: filepath.Join(dir, resumeFileName)}
+}
+
+// Load loads the resume record, or returns nil if there is none.
+func (s *resumeStore) Load() (*resumeState, error) {
+	if s.path == "" {
+		return nil, nil
+	}
+	bz, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read state sync resume file %v: %w", s.path, err)
+	}
+	r := new(resumeState)
+	if err := json.Unmarshal(bz, r); err != nil {
+		return nil, fmt.Errorf("failed to decode state sync resume file %v: %w", s.path, err)
+	}
+	return r, nil
+}
+
+// Save atomically writes the resume record.
+func (s *resumeStore) Save(r *resumeState) error {
+	if s.path == "" {
+		return nil
+	}
+	bz, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(s.path, bz, 0o600); err != nil {
+		return fmt.Errorf("failed to write state sync resume file %v: %w", s.path, err)
+	}
+	return nil
+}
+
+// Remove removes the resume record, if any.
+func (s *resumeStore) Remove() error {
+	if s.path == "" {
+		return nil
+	}
+	err := os.Remove(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove state sync resume file %v: %w", s.path, err)
+	}
+	return nil
+}
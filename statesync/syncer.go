@@ -35,8 +35,11 @@ var (
 	errRejectFormat = errors.New("snapshot format was rejected")
 	// errRejectSender is returned by Sync() when the snapshot sender is rejected.
 	errRejectSender = errors.New("snapshot sender was rejected")
-	// errVerifyFailed is returned by Sync() when app hash or last height verification fails.
+	// errVerifyFailed is returned by Sync() when app hash, last height or app version verification
+	// fails.
 	errVerifyFailed = errors.New("verification failed")
+	// errUnknownResult is returned by Sync() when the app responds with an unknown result.
+	errUnknownResult = errors.New("unknown result")
 	// errTimeout is returned by Sync() when we've waited too long to receive a chunk.
 	errTimeout = errors.New("timed out waiting for chunk")
 	// errNoSnapshots is returned by SyncAny() if no snapshots are found and discovery is disabled.
@@ -53,6 +56,7 @@ type syncer struct {
 	connQuery     proxy.AppConnQuery
 	snapshots     *snapshotPool
 	tempDir       string
+	resume        *resumeStore
 	chunkFetchers int32
 	retryTimeout  time.Duration
 
@@ -76,6 +80,7 @@ func newSyncer(
 		connQuery:     connQuery,
 		snapshots:     newSnapshotPool(),
 		tempDir:       tempDir,
+		resume:        newResumeStore(tempDir),
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
 	}
@@ -141,6 +146,10 @@ func (s *syncer) RemovePeer(peer p2p.Peer) {
 //
 // If none snapshots are found after maxDiscoveryTime, errNoSnapshots is
 // returned.
+//
+// If a previous restoration was interrupted (e.g. by a crash) and its snapshot
+// is still offered by peers, that snapshot is tried first, resuming from the
+// first chunk not yet applied by the app.
 func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHook func()) (sm.State, *types.Commit, error) {
 	timeStart := time.Now()
 
@@ -154,10 +163,27 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 		chunks   *chunkQueue
 		err      error
 	)
+	progress, err := s.resume.Load()
+	if err != nil {
+		s.logger.Error("Failed to load state sync progress, starting over", "err", err)
+		s.clearProgress()
+	}
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
-			snapshot = s.snapshots.Best()
+			if progress != nil {
+				if resumed := progress.Snapshot(); s.snapshots.GetPeer(resumed) != nil {
+					s.logger.Info("Found interrupted snapshot restoration", "height", resumed.Height,
+						"format", resumed.Format, "hash", log.NewLazySprintf("%X", resumed.Hash),
+						"applied", progress.AppliedChunks)
+					snapshot = resumed
+				}
+				// Only try to resume once; Sync() takes care of the progress from now on.
+				progress = nil
+			}
+			if snapshot == nil {
+				snapshot = s.snapshots.Best()
+			}
 			chunks = nil
 		}
 		if snapshot == nil {
@@ -180,9 +206,11 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 		newState, commit, err := s.Sync(snapshot, chunks)
 		switch {
 		case err == nil:
+			s.clearProgress()
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			s.clearProgress()
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
@@ -218,9 +246,19 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 			s.snapshots.Reject(snapshot)
 
 		default:
+			// The progress is kept if the restoration failed for reasons unrelated to the snapshot,
+			// e.g. because the app connection failed, so that it can be resumed after a restart.
+			// The app's restoration of the snapshot can't be trusted if it failed verification or
+			// the app responded unexpectedly though.
+			if errors.Is(err, errVerifyFailed) || errors.Is(err, errUnknownResult) {
+				s.clearProgress()
+			}
 			return sm.State{}, nil, fmt.Errorf("snapshot restoration failed: %w", err)
 		}
 
+		// The snapshot won't be restored, so there is nothing to resume.
+		s.clearProgress()
+
 		// Discard snapshot and chunks for next iteration
 		err = chunks.Close()
 		if err != nil {
@@ -247,25 +285,54 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		s.mtx.Unlock()
 	}()
 
-	hctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
-	defer cancel()
-
-	appHash, err := s.stateProvider.AppHash(hctx, snapshot.Height)
+	// Pick up the progress of an interrupted restoration of this snapshot, if any.
+	progress, err := s.resume.Load()
 	if err != nil {
-		s.logger.Info("failed to fetch and verify app hash", "err", err)
-		if errors.Is(err, light.ErrNoWitnesses) {
-			return sm.State{}, nil, err
+		s.logger.Error("Failed to load state sync progress", "err", err)
+		progress = nil
+	}
+	if progress != nil && progress.Matches(snapshot) {
+		snapshot.trustedAppHash = progress.TrustedAppHash
+	} else {
+		hctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+		defer cancel()
+
+		appHash, err := s.stateProvider.AppHash(hctx, snapshot.Height)
+		if err != nil {
+			s.logger.Info("failed to fetch and verify app hash", "err", err)
+			if errors.Is(err, light.ErrNoWitnesses) {
+				return sm.State{}, nil, err
+			}
+			return sm.State{}, nil, errRejectSnapshot
 		}
-		return sm.State{}, nil, errRejectSnapshot
+		snapshot.trustedAppHash = appHash
+
+		progress = newResumeState(snapshot)
+		s.saveProgress(progress)
 	}
-	snapshot.trustedAppHash = appHash
 
 	// Offer snapshot to ABCI app.
-	err = s.offerSnapshot(snapshot)
+	resume, err := s.offerSnapshot(snapshot, progress.AppliedChunks)
 	if err != nil {
 		return sm.State{}, nil, err
 	}
 
+	// Continue an interrupted restoration from the first chunk the app has not applied yet only
+	// if the app confirmed it kept the applied ones, otherwise start over from the first chunk.
+	switch {
+	case progress.AppliedChunks == 0:
+	case resume:
+		s.logger.Info("Resuming snapshot restoration", "height", snapshot.Height,
+			"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash),
+			"applied", progress.AppliedChunks, "total", snapshot.Chunks)
+		chunks.SkipApplied(progress.AppliedChunks)
+	default:
+		s.logger.Info("App did not resume snapshot restoration, applying all chunks", "height", snapshot.Height,
+			"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash))
+		progress.AppliedChunks = 0
+		s.saveProgress(progress)
+	}
+
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context canceled.
 	fetchCtx, cancel := context.WithCancel(context.TODO())
 	defer cancel()
@@ -273,11 +340,45 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		go s.fetchChunks(fetchCtx, snapshot, chunks)
 	}
 
+	// Optimistically build new state, so we don't discover any light client failures at the end.
+	state, commit, err := s.verifiedStateAndCommit(snapshot, progress)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+
+	// Restore snapshot
+	err = s.applyChunks(chunks, progress)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+
+	// Verify app and app version
+	if err := s.verifyApp(snapshot, state.Version.Consensus.App); err != nil {
+		return sm.State{}, nil, err
+	}
+
+	// Done! 🎉
+	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
+		"hash", log.NewLazySprintf("%X", snapshot.Hash))
+
+	return state, commit, nil
+}
+
+// verifiedStateAndCommit returns the state and commit at the snapshot height, either from the
+// progress of an interrupted restoration or by fetching and verifying them with the state
+// provider. Fetched values are recorded in the progress.
+func (s *syncer) verifiedStateAndCommit(snapshot *snapshot, progress *resumeState) (sm.State, *types.Commit, error) {
+	state, commit, ok, err := progress.StateAndCommit()
+	if err != nil {
+		s.logger.Error("Failed to decode state sync progress, refetching state", "err", err)
+	} else if ok {
+		return state, commit, nil
+	}
+
 	pctx, pcancel := context.WithTimeout(context.TODO(), 30*time.Second)
 	defer pcancel()
 
-	// Optimistically build new state, so we don't discover any light client failures at the end.
-	state, err := s.stateProvider.State(pctx, snapshot.Height)
+	state, err = s.stateProvider.State(pctx, snapshot.Height)
 	if err != nil {
 		s.logger.Info("failed to fetch and verify CometBFT state", "err", err)
 		if errors.Is(err, light.ErrNoWitnesses) {
@@ -285,7 +386,7 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		}
 		return sm.State{}, nil, errRejectSnapshot
 	}
-	commit, err := s.stateProvider.Commit(pctx, snapshot.Height)
+	commit, err = s.stateProvider.Commit(pctx, snapshot.Height)
 	if err != nil {
 		s.logger.Info("failed to fetch and verify commit", "err", err)
 		if errors.Is(err, light.ErrNoWitnesses) {
@@ -294,27 +395,34 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		return sm.State{}, nil, errRejectSnapshot
 	}
 
-	// Restore snapshot
-	err = s.applyChunks(chunks)
-	if err != nil {
-		return sm.State{}, nil, err
+	if err := progress.SetStateAndCommit(state, commit); err != nil {
+		s.logger.Error("Failed to encode state sync progress", "err", err)
+	} else {
+		s.saveProgress(progress)
 	}
+	return state, commit, nil
+}
 
-	// Verify app and app version
-	if err := s.verifyApp(snapshot, state.Version.Consensus.App); err != nil {
-		return sm.State{}, nil, err
+// saveProgress persists the progress of the current restoration. Failures are only logged, since
+// they merely prevent resuming after a restart.
+func (s *syncer) saveProgress(progress *resumeState) {
+	if err := s.resume.Save(progress); err != nil {
+		s.logger.Error("Failed to save state sync progress", "err", err)
 	}
+}
 
-	// Done! 🎉
-	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
-		"hash", log.NewLazySprintf("%X", snapshot.Hash))
-
-	return state, commit, nil
+// clearProgress removes the persisted progress of the current restoration.
+func (s *syncer) clearProgress() {
+	if err := s.resume.Remove(); err != nil {
+		s.logger.Error("Failed to remove state sync progress", "err", err)
+	}
 }
 
-// offerSnapshot offers a snapshot to the app. It returns various errors depending on the app's
-// response, or nil if the snapshot was accepted.
-func (s *syncer) offerSnapshot(snapshot *snapshot) error {
+// offerSnapshot offers a snapshot to the app, along with the number of chunks it applied before
+// the restoration was interrupted. It returns various errors depending on the app's response, or
+// nil if the snapshot was accepted, in which case resume is true if the app continues the
+// interrupted restoration.
+func (s *syncer) offerSnapshot(snapshot *snapshot, appliedChunks uint32) (resume bool, err error) {
 	s.logger.Info("Offering snapshot to ABCI app", "height", snapshot.Height,
 		"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash))
 	resp, err := s.conn.OfferSnapshot(context.TODO(), &abci.OfferSnapshotRequest{
@@ -325,32 +433,34 @@ func (s *syncer) offerSnapshot(snapshot *snapshot) error {
 			Hash:     snapshot.Hash,
 			Metadata: snapshot.Metadata,
 		},
-		AppHash: snapshot.trustedAppHash,
+		AppHash:       snapshot.trustedAppHash,
+		AppliedChunks: appliedChunks,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to offer snapshot: %w", err)
+		return false, fmt.Errorf("failed to offer snapshot: %w", err)
 	}
 	switch resp.Result {
 	case abci.OFFER_SNAPSHOT_RESULT_ACCEPT:
 		s.logger.Info("Snapshot accepted, restoring", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash))
-		return nil
+		return resp.Resume && appliedChunks > 0, nil
 	case abci.OFFER_SNAPSHOT_RESULT_ABORT:
-		return errAbort
+		return false, errAbort
 	case abci.OFFER_SNAPSHOT_RESULT_REJECT:
-		return errRejectSnapshot
+		return false, errRejectSnapshot
 	case abci.OFFER_SNAPSHOT_RESULT_REJECT_FORMAT:
-		return errRejectFormat
+		return false, errRejectFormat
 	case abci.OFFER_SNAPSHOT_RESULT_REJECT_SENDER:
-		return errRejectSender
+		return false, errRejectSender
 	default:
-		return fmt.Errorf("unknown ResponseOfferSnapshot result %v", resp.Result)
+		return false, fmt.Errorf("%w of ResponseOfferSnapshot: %v", errUnknownResult, resp.Result)
 	}
 }
 
 // applyChunks applies chunks to the app. It returns various errors depending on the app's
-// response, or nil once the snapshot is fully restored.
-func (s *syncer) applyChunks(chunks *chunkQueue) error {
+// response, or nil once the snapshot is fully restored. If progress is not nil, the number of
+// applied chunks is recorded in it and persisted as the app accepts them.
+func (s *syncer) applyChunks(chunks *chunkQueue, progress *resumeState) error {
 	for {
 		chunk, err := chunks.Next()
 		if errors.Is(err, errDone) {
@@ -391,16 +501,24 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 
 		switch resp.Result {
 		case abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT:
+			if applied := chunks.Applied(); progress != nil && applied != progress.AppliedChunks {
+				progress.AppliedChunks = applied
+				s.saveProgress(progress)
+			}
 		case abci.APPLY_SNAPSHOT_CHUNK_RESULT_ABORT:
 			return errAbort
 		case abci.APPLY_SNAPSHOT_CHUNK_RESULT_RETRY:
 			chunks.Retry(chunk.Index)
 		case abci.APPLY_SNAPSHOT_CHUNK_RESULT_RETRY_SNAPSHOT:
+			if progress != nil {
+				progress.AppliedChunks = 0
+				s.saveProgress(progress)
+			}
 			return errRetrySnapshot
 		case abci.APPLY_SNAPSHOT_CHUNK_RESULT_REJECT_SNAPSHOT:
 			return errRejectSnapshot
 		default:
-			return fmt.Errorf("unknown ResponseApplySnapshotChunk result %v", resp.Result)
+			return fmt.Errorf("%w of ResponseApplySnapshotChunk: %v", errUnknownResult, resp.Result)
 		}
 	}
 }
@@ -483,8 +601,8 @@ func (s *syncer) verifyApp(snapshot *snapshot, appVersion uint64) error {
 	if resp.AppVersion != appVersion {
 		// An error here most likely means that the app hasn't implemented state sync
 		// or the Info call correctly
-		return fmt.Errorf("app version mismatch. Expected: %d, got: %d",
-			appVersion, resp.AppVersion)
+		return fmt.Errorf("%w: app version mismatch. Expected: %d, got: %d",
+			errVerifyFailed, appVersion, resp.AppVersion)
 	}
 	if !bytes.Equal(snapshot.trustedAppHash, resp.LastBlockAppHash) {
 		s.logger.Error("appHash verification failed",
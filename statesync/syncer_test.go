@@ -14,6 +14,7 @@ import (
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
 	abci "github.com/cometbft/cometbft/v2/abci/types"
 	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
 	"github.com/cometbft/cometbft/v2/p2p"
@@ -256,6 +257,112 @@ func TestSyncer_SyncAny_abort(t *testing.T) {
 	connSnapshot.AssertExpectations(t)
 }
 
+func TestSyncer_SyncAny_resume(t *testing.T) {
+	vals, _ := types.RandValidatorSet(1, 10)
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("blockhash"))}
+	state := sm.State{
+		ChainID: "chain",
+		Version: cmtstate.Version{
+			Consensus: cmtversion.Consensus{
+				Block: version.BlockProtocol,
+				App:   testAppVersion,
+			},
+			Software: version.CMTSemVer,
+		},
+
+		LastBlockHeight: 1,
+		LastBlockID:     blockID,
+		LastBlockTime:   cmttime.Now(),
+		AppHash:         []byte("app_hash"),
+
+		LastValidators: vals,
+		Validators:     vals,
+		NextValidators: vals,
+
+		ConsensusParams:                  *types.DefaultConsensusParams(),
+		LastHeightConsensusParamsChanged: 1,
+	}
+	commit := &types.Commit{Height: 1, BlockID: blockID, Signatures: []types.CommitSig{types.NewCommitSigAbsent()}}
+
+	testcases := map[string]struct {
+		resume        bool     // whether the app confirms it resumes the restoration
+		appHash       []byte   // the app hash reported by the app once restored
+		appliedChunks []uint32 // the chunks expected to be fetched and applied
+		expectErr     error
+	}{
+		"resumed":      {true, []byte("app_hash"), []uint32{2}, nil},
+		"not resumed":  {false, []byte("app_hash"), []uint32{0, 1, 2}, nil},
+		"verify fails": {true, []byte("other_hash"), []uint32{2}, errVerifyFailed},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}, trustedAppHash: []byte("app_hash")}
+
+			// A previous run applied the first two chunks before it was interrupted.
+			tempDir := t.TempDir()
+			progress := newResumeState(s)
+			require.NoError(t, progress.SetStateAndCommit(state, commit))
+			progress.AppliedChunks = 2
+			require.NoError(t, newResumeStore(tempDir).Save(progress))
+
+			// The state provider must not be used, since the state and commit were persisted.
+			stateProvider := &mocks.StateProvider{}
+			connSnapshot := &proxymocks.AppConnSnapshot{}
+			connQuery := &proxymocks.AppConnQuery{}
+			cfg := config.DefaultStateSyncConfig()
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, tempDir)
+
+			peer := &p2pmocks.Peer{}
+			peer.On("ID").Return("a")
+			peer.On("Send", mock.MatchedBy(func(i any) bool {
+				e, ok := i.(p2p.Envelope)
+				return ok && e.ChannelID == ChunkChannel
+			})).Maybe().Run(func(args mock.Arguments) {
+				msg := args[0].(p2p.Envelope).Message.(*ssproto.ChunkRequest)
+				require.Contains(t, tc.appliedChunks, msg.Index)
+				_, err := syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: msg.Index, Chunk: []byte{1, 1, byte(msg.Index)}})
+				require.NoError(t, err)
+			}).Return(nil)
+			_, err := syncer.AddSnapshot(peer, &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}})
+			require.NoError(t, err)
+
+			connSnapshot.On("OfferSnapshot", mock.Anything, &abci.OfferSnapshotRequest{
+				Snapshot: toABCI(s), AppHash: []byte("app_hash"), AppliedChunks: 2,
+			}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ACCEPT, Resume: tc.resume}, nil)
+			for _, index := range tc.appliedChunks {
+				connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.ApplySnapshotChunkRequest{
+					Index: index, Chunk: []byte{1, 1, byte(index)},
+				}).Once().Return(&abci.ApplySnapshotChunkResponse{Result: abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT}, nil)
+			}
+			connQuery.On("Info", mock.Anything, proxy.InfoRequest).Return(&abci.InfoResponse{
+				AppVersion:       testAppVersion,
+				LastBlockHeight:  1,
+				LastBlockAppHash: tc.appHash,
+			}, nil)
+
+			newState, lastCommit, err := syncer.SyncAny(0, maxDiscoveryTime, func() {})
+			if tc.expectErr != nil {
+				require.ErrorIs(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, state.AppHash, newState.AppHash)
+				assert.Equal(t, state.Validators.Hash(), newState.Validators.Hash())
+				assert.Equal(t, commit.BlockID, lastCommit.BlockID)
+			}
+
+			// The progress is removed once the snapshot is restored, or if the restoration
+			// can't be trusted.
+			progress, err = newResumeStore(tempDir).Load()
+			require.NoError(t, err)
+			assert.Nil(t, progress)
+
+			connSnapshot.AssertExpectations(t)
+			connQuery.AssertExpectations(t)
+			stateProvider.AssertExpectations(t)
+		})
+	}
+}
+
 func TestSyncer_SyncAny_reject(t *testing.T) {
 	syncer, connSnapshot := setupOfferSyncer()
 
@@ -394,7 +501,7 @@ func TestSyncer_offerSnapshot(t *testing.T) {
 				Snapshot: toABCI(s),
 				AppHash:  []byte("app_hash"),
 			}).Return(&abci.OfferSnapshotResponse{Result: tc.result}, tc.err)
-			err := syncer.offerSnapshot(s)
+			_, err := syncer.offerSnapshot(s, 0)
 			if tc.expectErr == unknownErr {
 				require.Error(t, err)
 			} else {
@@ -453,7 +560,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 				}, nil)
 			}
 
-			err = syncer.applyChunks(chunks)
+			err = syncer.applyChunks(chunks, nil)
 			if tc.expectErr == unknownErr {
 				require.Error(t, err)
 			} else {
@@ -519,7 +626,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			// check the queue contents, and finally close the queue to end the goroutine.
 			// We don't really care about the result of applyChunks, since it has separate test.
 			go func() {
-				syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				syncer.applyChunks(chunks, nil) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -611,7 +718,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			// However, it will block on e.g. retry result, so we spawn a goroutine that will
 			// be shut down when the chunk queue closes.
 			go func() {
-				syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				syncer.applyChunks(chunks, nil) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -635,7 +742,6 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 func TestSyncer_verifyApp(t *testing.T) {
 	boom := errors.New("boom")
 	const appVersion = 9
-	appVersionMismatchErr := errors.New("app version mismatch. Expected: 9, got: 2")
 	s := &snapshot{Height: 3, Format: 1, Chunks: 5, Hash: []byte{1, 2, 3}, trustedAppHash: []byte("app_hash")}
 
 	testcases := map[string]struct {
@@ -652,7 +758,7 @@ func TestSyncer_verifyApp(t *testing.T) {
 			LastBlockHeight:  3,
 			LastBlockAppHash: []byte("app_hash"),
 			AppVersion:       2,
-		}, nil, appVersionMismatchErr},
+		}, nil, errVerifyFailed},
 		"invalid height": {&abci.InfoResponse{
 			LastBlockHeight:  5,
 			LastBlockAppHash: []byte("app_hash"),
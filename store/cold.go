@@ -0,0 +1,334 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/store/segment"
+)
+
+const (
+	// maxBlocksToMove is the maximum number of blocks moved to the cold
+	// storage at once, so that the database records of the moved blocks are
+	// deleted in batches of reasonable size.
+	maxBlocksToMove = 100
+
+	// coldStorageRetryInterval is the time to wait before moving blocks to the
+	// cold storage again after a failure.
+	coldStorageRetryInterval = 10 * time.Second
+)
+
+// WithColdStorage moves the blocks older than retainBlocks heights to the
+// given cold storage, from which they are still loaded transparently. The
+// block hashes are kept in the database. At least one block is retained, as
+// the commit of a block is saved along with the next one.
+//
+// The blocks are moved by a background routine, so that saving blocks isn't
+// slowed down by writing to the cold storage. The block store takes ownership
+// of the cold storage, and closes it.
+func WithColdStorage(cold *segment.Store, retainBlocks int64) BlockStoreOption {
+	return func(bs *BlockStore) {
+		bs.cold = cold
+		bs.coldRetainBlocks = max(retainBlocks, 1)
+	}
+}
+
+// ColdStorageFromConfig opens the cold storage configured in cfg, and returns
+// the options of the block store enabling it. It returns no options if the
+// cold storage is disabled.
+func ColdStorageFromConfig(cfg *config.ColdStorageConfig) ([]BlockStoreOption, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+	cold, err := segment.Open(cfg.DirPath(), cfg.SegmentSize)
+	if err != nil {
+		return nil, fmt.Errorf("opening cold storage: %w", err)
+	}
+	return []BlockStoreOption{WithColdStorage(cold, cfg.RetainBlocks)}, nil
+}
+
+// ColdHeight returns the height of the last block moved to the cold storage,
+// or 0 if it's disabled. These blocks can't be deleted with DeleteLatestBlock.
+func (bs *BlockStore) ColdHeight() int64 {
+	if bs.cold == nil {
+		return 0
+	}
+	return bs.cold.Height()
+}
+
+// inColdStorage returns whether the records at height were moved to the cold
+// storage.
+func (bs *BlockStore) inColdStorage(height int64) bool {
+	return bs.cold != nil && height <= bs.cold.Height()
+}
+
+// loadRecord returns the record with the given key in the database, or the
+// given field of the entry at height in the cold storage if the block was
+// moved there.
+func (bs *BlockStore) loadRecord(height int64, key []byte, field func(e *segment.Entry) []byte) []byte {
+	bz, err := bs.getRecord(height, key, field)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// getRecord is like loadRecord, but returns an error instead of panicking.
+// The records of the blocks in the cold storage repaired by a Verifier are
+// kept in the database, so the database is looked up first.
+func (bs *BlockStore) getRecord(height int64, key []byte, field func(e *segment.Entry) []byte) ([]byte, error) {
+	bz, err := bs.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 && bs.inColdStorage(height) {
+		return bs.getColdRecord(height, field)
+	}
+	return bz, nil
+}
+
+func (bs *BlockStore) getColdRecord(height int64, field func(e *segment.Entry) []byte) ([]byte, error) {
+	e, err := bs.cold.Get(height)
+	if err != nil {
+		return nil, fmt.Errorf("loading block %d from cold storage: %w", height, err)
+	}
+	if e == nil {
+		return nil, nil
+	}
+	return field(e), nil
+}
+
+// startColdStorageRoutine starts the routine moving blocks to the cold
+// storage, if enabled. It is stopped by Close.
+func (bs *BlockStore) startColdStorageRoutine() {
+	if bs.cold == nil {
+		return
+	}
+	bs.coldTrigger = make(chan struct{}, 1)
+	bs.coldQuit = make(chan struct{})
+	bs.coldDone = make(chan struct{})
+	go bs.coldStorageRoutine()
+}
+
+// stopColdStorageRoutine stops the routine moving blocks to the cold storage,
+// and waits for it to return.
+func (bs *BlockStore) stopColdStorageRoutine() {
+	if bs.coldQuit == nil {
+		return
+	}
+	bs.coldStop.Do(func() { close(bs.coldQuit) })
+	<-bs.coldDone
+}
+
+// triggerColdStorage notifies the routine moving blocks to the cold storage
+// that a block was saved. It never blocks.
+func (bs *BlockStore) triggerColdStorage() {
+	if bs.coldTrigger == nil {
+		return
+	}
+	select {
+	case bs.coldTrigger <- struct{}{}:
+	default:
+	}
+}
+
+// coldStorageRoutine moves the blocks beyond the retained ones to the cold
+// storage whenever a block is saved. Failures are logged, and moving the
+// blocks is retried after coldStorageRetryInterval.
+func (bs *BlockStore) coldStorageRoutine() {
+	defer close(bs.coldDone)
+
+	// The records of the blocks moved before the node stopped may still be in
+	// the database, which are deleted first.
+	deletedDuplicates := false
+	for {
+		var (
+			more bool
+			err  error
+		)
+		if !deletedDuplicates {
+			err = bs.deleteColdDuplicates()
+			deletedDuplicates = err == nil
+		}
+		if err == nil {
+			more, err = bs.moveToColdStorage()
+		}
+
+		var retry <-chan time.Time
+		switch {
+		case err != nil:
+			bs.logger.Error("Failed to move blocks to cold storage", "err", err, "retry_in", coldStorageRetryInterval)
+			retry = time.After(coldStorageRetryInterval)
+		case more:
+			// Catch up without waiting for the next block.
+			select {
+			case <-bs.coldQuit:
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-bs.coldTrigger:
+		case <-retry:
+		case <-bs.coldQuit:
+			return
+		}
+	}
+}
+
+// moveToColdStorage moves up to maxBlocksToMove of the oldest blocks beyond
+// the retained ones to the cold storage. It returns true if there are more
+// blocks to move.
+func (bs *BlockStore) moveToColdStorage() (bool, error) {
+	defer addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "move_to_cold_storage"), time.Now())()
+
+	// Blocks must not be pruned while they are moved.
+	bs.coldMtx.Lock()
+	defer bs.coldMtx.Unlock()
+
+	target := bs.Height() - bs.coldRetainBlocks
+	from := max(bs.cold.Height()+1, bs.Base())
+	if from <= 0 || from > target {
+		return false, nil
+	}
+	to := min(target, from+maxBlocksToMove-1)
+
+	entries := make([]*segment.Entry, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		e, err := bs.coldEntry(h)
+		if err != nil {
+			return false, err
+		}
+		if e != nil {
+			entries = append(entries, e)
+		}
+	}
+	if err := bs.cold.Append(entries...); err != nil {
+		return false, fmt.Errorf("appending blocks to cold storage: %w", err)
+	}
+	if err := bs.deleteMovedRecords(entries); err != nil {
+		return false, err
+	}
+	return to < target, nil
+}
+
+// coldEntry returns the records of the block at height in the database, or
+// nil if there is no block at this height.
+func (bs *BlockStore) coldEntry(height int64) (*segment.Entry, error) {
+	get := func(key []byte) ([]byte, error) {
+		bz, err := bs.db.Get(key)
+		if len(bz) == 0 {
+			return nil, err
+		}
+		return bz, err
+	}
+	meta, err := get(bs.dbKeyLayout.CalcBlockMetaKey(height))
+	if err != nil || meta == nil {
+		return nil, err
+	}
+	pbbm := new(cmtproto.BlockMeta)
+	if err := proto.Unmarshal(meta, pbbm); err != nil {
+		return nil, fmt.Errorf("unmarshal to cmtproto.BlockMeta: %w", err)
+	}
+	e := &segment.Entry{
+		Height: height,
+		Meta:   meta,
+		Parts:  make([][]byte, pbbm.BlockID.PartSetHeader.Total),
+	}
+	for i := range e.Parts {
+		if e.Parts[i], err = get(bs.dbKeyLayout.CalcBlockPartKey(height, i)); err != nil {
+			return nil, err
+		}
+	}
+	if e.Commit, err = get(bs.dbKeyLayout.CalcBlockCommitKey(height)); err != nil {
+		return nil, err
+	}
+	if e.SeenCommit, err = get(bs.dbKeyLayout.CalcSeenCommitKey(height)); err != nil {
+		return nil, err
+	}
+	if e.ExtCommit, err = get(bs.dbKeyLayout.CalcExtCommitKey(height)); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// deleteMovedRecords deletes the records of the given entries from the
+// database, once they are in the cold storage.
+func (bs *BlockStore) deleteMovedRecords(entries []*segment.Entry) error {
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	for _, e := range entries {
+		keys := [][]byte{
+			bs.dbKeyLayout.CalcBlockMetaKey(e.Height),
+			bs.dbKeyLayout.CalcBlockCommitKey(e.Height),
+			bs.dbKeyLayout.CalcSeenCommitKey(e.Height),
+			bs.dbKeyLayout.CalcExtCommitKey(e.Height),
+		}
+		for i := range e.Parts {
+			keys = append(keys, bs.dbKeyLayout.CalcBlockPartKey(e.Height, i))
+		}
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				return ErrDBOpt{Err: err}
+			}
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return ErrDBOpt{Err: err}
+	}
+	return nil
+}
+
+// deleteColdDuplicates deletes the records of the last blocks moved to the
+// cold storage that are still in the database, if the node stopped while
+// they were moved. The repaired records, which differ from the ones in the
+// cold storage, are kept.
+func (bs *BlockStore) deleteColdDuplicates() error {
+	bs.coldMtx.Lock()
+	defer bs.coldMtx.Unlock()
+
+	var entries []*segment.Entry
+	for h := bs.cold.Height(); h >= bs.Base() && h > 0 && bs.cold.Height()-h < maxBlocksToMove; h-- {
+		e, err := bs.coldEntry(h)
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			break
+		}
+		// A corrupted cold entry may have been repaired.
+		if cold, err := bs.cold.Get(h); err == nil && isDuplicate(e, cold) {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return bs.deleteMovedRecords(entries)
+}
+
+// isDuplicate returns whether the records of the database entry e are the
+// same as the ones of the cold storage entry cold.
+func isDuplicate(e, cold *segment.Entry) bool {
+	if cold == nil || len(e.Parts) != len(cold.Parts) {
+		return false
+	}
+	for i := range e.Parts {
+		if !bytes.Equal(e.Parts[i], cold.Parts[i]) {
+			return false
+		}
+	}
+	// The commit of the height is saved with the next block, so may not be
+	// in the database yet.
+	return bytes.Equal(e.Meta, cold.Meta) &&
+		(e.Commit == nil || bytes.Equal(e.Commit, cold.Commit)) &&
+		bytes.Equal(e.SeenCommit, cold.SeenCommit) &&
+		bytes.Equal(e.ExtCommit, cold.ExtCommit)
+}
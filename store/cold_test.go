@@ -0,0 +1,142 @@
+package store
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store/segment"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+func TestColdStorage(t *testing.T) {
+	config := test.ResetTestRoot("block_store_cold_storage_test")
+	defer os.RemoveAll(config.RootDir)
+	state, err := sm.MakeGenesisStateFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	cold, err := segment.Open(dir, 5)
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db, WithColdStorage(cold, 3))
+
+	blocks := make(map[int64]*types.Block)
+	for height := int64(1); height <= 20; height++ {
+		var lastCommit *types.Commit
+		if height > 1 {
+			lastCommit = makeTestExtCommit(height-1, cmttime.Now()).ToCommit()
+		} else {
+			lastCommit = new(types.Commit)
+		}
+		block := state.MakeBlock(height, test.MakeNTxs(height, 10), lastCommit, nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(height, cmttime.Now()))
+		blocks[height] = block
+	}
+	// The blocks are moved in the background.
+	require.Eventually(t, func() bool { return cold.Height() == 17 }, 5*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 1, bs.Base())
+	require.EqualValues(t, 20, bs.Height())
+
+	// The moved blocks are no longer in the database.
+	bz, err := db.Get(bs.dbKeyLayout.CalcBlockMetaKey(10))
+	require.NoError(t, err)
+	require.Empty(t, bz)
+	bz, err = db.Get(bs.dbKeyLayout.CalcBlockMetaKey(18))
+	require.NoError(t, err)
+	require.NotEmpty(t, bz)
+
+	requireBlocks := func(bs *BlockStore, from, to int64) {
+		t.Helper()
+		for height := from; height <= to; height++ {
+			block, meta := bs.LoadBlock(height)
+			require.NotNil(t, block, "height %d", height)
+			require.Equal(t, blocks[height].Hash(), block.Hash())
+			require.Equal(t, blocks[height].Hash(), meta.BlockID.Hash)
+			require.NotNil(t, bs.LoadBlockMetaByHash(block.Hash()))
+			require.NotNil(t, bs.LoadSeenCommit(height))
+			require.NotNil(t, bs.LoadBlockExtendedCommit(height))
+			if height < 20 {
+				require.Equal(t, blocks[height+1].LastCommit.Hash(), bs.LoadBlockCommit(height).Hash())
+			}
+		}
+	}
+	requireBlocks(bs, 1, 20)
+
+	// Blocks are still loaded from the cold storage after a restart.
+	require.NoError(t, bs.Close())
+	cold, err = segment.Open(dir, 5)
+	require.NoError(t, err)
+	bs = NewBlockStore(db, WithColdStorage(cold, 3))
+	defer bs.Close()
+	requireBlocks(bs, 1, 20)
+
+	// Pruning drops the segments whose blocks are all pruned.
+	pruneState := state.Copy()
+	pruneState.LastBlockHeight = 1_000_000
+	pruneState.LastBlockTime = cmttime.Now().Add(100_000 * time.Hour)
+	pruned, _, err := bs.PruneBlocks(13, pruneState)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, pruned)
+	require.EqualValues(t, 11, cold.Base())
+	block, _ := bs.LoadBlock(5)
+	require.Nil(t, block)
+	require.Nil(t, bs.LoadBlockMetaByHash(blocks[12].Hash()))
+	requireBlocks(bs, 13, 20)
+
+	// The blocks in the cold storage can't be deleted.
+	for height := int64(20); height > 17; height-- {
+		require.NoError(t, bs.DeleteLatestBlock())
+	}
+	require.ErrorAs(t, bs.DeleteLatestBlock(), &ErrDeleteColdBlock{})
+	require.EqualValues(t, 17, bs.Height())
+	requireBlocks(bs, 13, 17)
+}
+
+func TestColdStorageDuplicates(t *testing.T) {
+	config := test.ResetTestRoot("block_store_cold_storage_test")
+	defer os.RemoveAll(config.RootDir)
+	state, err := sm.MakeGenesisStateFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+	for height := int64(1); height <= 5; height++ {
+		block := state.MakeBlock(height, test.MakeNTxs(height, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		bs.SaveBlock(block, partSet, makeTestExtCommit(height, cmttime.Now()).ToCommit())
+	}
+
+	// Simulate a crash after the blocks were appended to the cold storage,
+	// but before they were deleted from the database.
+	cold, err := segment.Open(t.TempDir(), 10)
+	require.NoError(t, err)
+	for height := int64(1); height <= 3; height++ {
+		e, err := bs.coldEntry(height)
+		require.NoError(t, err)
+		require.NoError(t, cold.Append(e))
+	}
+
+	bs = NewBlockStore(db, WithColdStorage(cold, 2))
+	defer bs.Close()
+	for height := int64(1); height <= 3; height++ {
+		require.Eventually(t, func() bool {
+			bz, err := db.Get(bs.dbKeyLayout.CalcBlockMetaKey(height))
+			return err == nil && len(bz) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		block, _ := bs.LoadBlock(height)
+		require.NotNil(t, block)
+	}
+	bz, err := db.Get(bs.dbKeyLayout.CalcBlockMetaKey(4))
+	require.NoError(t, err)
+	require.NotEmpty(t, bz)
+}
@@ -24,6 +24,14 @@ func (e ErrExceedBaseHeight) Error() string {
 	return fmt.Sprintf("cannot prune to height %v, it is lower than base height %v", e.Height, e.Base)
 }
 
+type ErrDeleteColdBlock struct {
+	Height int64
+}
+
+func (e ErrDeleteColdBlock) Error() string {
+	return fmt.Sprintf("cannot delete block %v, it was moved to the cold storage", e.Height)
+}
+
 type ErrMarshalCommit struct {
 	Err error
 }
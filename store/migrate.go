@@ -0,0 +1,224 @@
+package store
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/orderedcode"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/internal/keylayout"
+	"github.com/cometbft/cometbft/v2/libs/log"
+)
+
+// NewKeyLayoutMigrator returns a migrator of the block metas, parts, commits
+// and hashes of the block store in db to the key layout with the given
+// version ("v1" or "v2"). It returns keylayout.ErrSameLayout if the block
+// store already uses this layout.
+//
+// The block store must not be in use while the migration completes, see
+// keylayout.Migrator.
+func NewKeyLayoutMigrator(db dbm.DB, version string, logger log.Logger) (*keylayout.Migrator, error) {
+	current, err := keylayout.Version(db)
+	if err != nil {
+		return nil, err
+	}
+	if current == version {
+		return nil, keylayout.ErrSameLayout{Version: version}
+	}
+	from, err := blockKeyLayout(current)
+	if err != nil {
+		return nil, err
+	}
+	to, err := blockKeyLayout(version)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]keylayout.Record, 0, len(blockRecordKinds))
+	for _, kind := range blockRecordKinds {
+		records = append(records, kind.record(from, to))
+	}
+	return keylayout.NewMigrator(db, current, version, records, logger), nil
+}
+
+func blockKeyLayout(version string) (BlockKeyLayout, error) {
+	switch version {
+	case "v1":
+		return &v1LegacyLayout{}, nil
+	case "v2":
+		return &v2Layout{}, nil
+	default:
+		return nil, fmt.Errorf("unknown key layout version %q", version)
+	}
+}
+
+// blockRecordKey identifies a record of the block store.
+type blockRecordKey struct {
+	height int64
+	index  int    // of block parts
+	hash   []byte // of block hashes
+}
+
+// blockRecordKind is a kind of records of the block store, with its keys in
+// each layout.
+type blockRecordKind struct {
+	name     string
+	v1Prefix string
+	v2Prefix int64
+	key      func(layout BlockKeyLayout, k blockRecordKey) []byte
+	// parseV1 parses the part of a v1 key after the prefix.
+	parseV1 func(s string) (blockRecordKey, error)
+	// parseV2 parses the part of a v2 key after the prefix.
+	parseV2 func(s string) (blockRecordKey, error)
+}
+
+var blockRecordKinds = []blockRecordKind{
+	{
+		name:     "block meta",
+		v1Prefix: "H:",
+		v2Prefix: prefixBlockMeta,
+		key:      func(l BlockKeyLayout, k blockRecordKey) []byte { return l.CalcBlockMetaKey(k.height) },
+		parseV1:  parseV1Height,
+		parseV2:  parseV2Height,
+	},
+	{
+		name:     "block part",
+		v1Prefix: "P:",
+		v2Prefix: prefixBlockPart,
+		key:      func(l BlockKeyLayout, k blockRecordKey) []byte { return l.CalcBlockPartKey(k.height, k.index) },
+		parseV1: func(s string) (blockRecordKey, error) {
+			heightStr, indexStr, ok := strings.Cut(s, ":")
+			if !ok {
+				return blockRecordKey{}, errors.New("missing part index")
+			}
+			height, err := strconv.ParseInt(heightStr, 10, 64)
+			if err != nil {
+				return blockRecordKey{}, err
+			}
+			index, err := strconv.Atoi(indexStr)
+			if err != nil {
+				return blockRecordKey{}, err
+			}
+			return blockRecordKey{height: height, index: index}, nil
+		},
+		parseV2: func(s string) (blockRecordKey, error) {
+			var height, index int64
+			if err := parseV2(s, &height, &index); err != nil {
+				return blockRecordKey{}, err
+			}
+			return blockRecordKey{height: height, index: int(index)}, nil
+		},
+	},
+	{
+		name:     "block commit",
+		v1Prefix: "C:",
+		v2Prefix: prefixBlockCommit,
+		key:      func(l BlockKeyLayout, k blockRecordKey) []byte { return l.CalcBlockCommitKey(k.height) },
+		parseV1:  parseV1Height,
+		parseV2:  parseV2Height,
+	},
+	{
+		name:     "seen commit",
+		v1Prefix: "SC:",
+		v2Prefix: prefixSeenCommit,
+		key:      func(l BlockKeyLayout, k blockRecordKey) []byte { return l.CalcSeenCommitKey(k.height) },
+		parseV1:  parseV1Height,
+		parseV2:  parseV2Height,
+	},
+	{
+		name:     "extended commit",
+		v1Prefix: "EC:",
+		v2Prefix: prefixExtCommit,
+		key:      func(l BlockKeyLayout, k blockRecordKey) []byte { return l.CalcExtCommitKey(k.height) },
+		parseV1:  parseV1Height,
+		parseV2:  parseV2Height,
+	},
+	{
+		name:     "block hash",
+		v1Prefix: "BH:",
+		v2Prefix: prefixBlockHash,
+		key:      func(l BlockKeyLayout, k blockRecordKey) []byte { return l.CalcBlockHashKey(k.hash) },
+		parseV1: func(s string) (blockRecordKey, error) {
+			hash, err := hex.DecodeString(s)
+			return blockRecordKey{hash: hash}, err
+		},
+		parseV2: func(s string) (blockRecordKey, error) {
+			var hash string
+			if err := parseV2(s, &hash); err != nil {
+				return blockRecordKey{}, err
+			}
+			return blockRecordKey{hash: []byte(hash)}, nil
+		},
+	},
+}
+
+func (kind blockRecordKind) prefix(layout BlockKeyLayout) []byte {
+	if _, ok := layout.(*v1LegacyLayout); ok {
+		return []byte(kind.v1Prefix)
+	}
+	prefix, err := orderedcode.Append(nil, kind.v2Prefix)
+	if err != nil {
+		panic(err)
+	}
+	return prefix
+}
+
+func (kind blockRecordKind) parse(layout BlockKeyLayout, key []byte) (blockRecordKey, error) {
+	prefix := kind.prefix(layout)
+	s, ok := strings.CutPrefix(string(key), string(prefix))
+	if !ok {
+		return blockRecordKey{}, fmt.Errorf("expected %s key prefix", kind.name)
+	}
+	if _, ok := layout.(*v1LegacyLayout); ok {
+		return kind.parseV1(s)
+	}
+	return kind.parseV2(s)
+}
+
+// record returns the migration of the records of this kind from one layout
+// to the other.
+func (kind blockRecordKind) record(from, to BlockKeyLayout) keylayout.Record {
+	convert := func(from, to BlockKeyLayout) func([]byte) ([]byte, error) {
+		return func(key []byte) ([]byte, error) {
+			k, err := kind.parse(from, key)
+			if err != nil {
+				return nil, err
+			}
+			return kind.key(to, k), nil
+		}
+	}
+	return keylayout.Record{
+		Name:      kind.name,
+		OldPrefix: kind.prefix(from),
+		NewPrefix: kind.prefix(to),
+		NewKey:    convert(from, to),
+		OldKey:    convert(to, from),
+	}
+}
+
+func parseV1Height(s string) (blockRecordKey, error) {
+	height, err := strconv.ParseInt(s, 10, 64)
+	return blockRecordKey{height: height}, err
+}
+
+func parseV2Height(s string) (blockRecordKey, error) {
+	var height int64
+	err := parseV2(s, &height)
+	return blockRecordKey{height: height}, err
+}
+
+// parseV2 parses the items of a v2 key after the prefix.
+func parseV2(s string, items ...any) error {
+	remaining, err := orderedcode.Parse(s, items...)
+	if err != nil {
+		return err
+	}
+	if remaining != "" {
+		return errors.New("unexpected key suffix")
+	}
+	return nil
+}
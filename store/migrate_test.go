@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/internal/keylayout"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+func TestKeyLayoutMigration(t *testing.T) {
+	config := test.ResetTestRoot("block_store_migration_test")
+	defer os.RemoveAll(config.RootDir)
+	state, err := sm.MakeGenesisStateFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db, WithDBKeyLayout("v1"))
+	saveBlock := func(height int64) *types.Block {
+		block := state.MakeBlock(height, test.MakeNTxs(height, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(height, cmttime.Now()))
+		return block
+	}
+	var blocks []*types.Block
+	for height := int64(1); height <= 5; height++ {
+		blocks = append(blocks, saveBlock(height))
+	}
+
+	migrator, err := NewKeyLayoutMigrator(db, "v2", log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, "v2", migrator.Version())
+
+	// Copy in the background while the store is in use, and prune a block.
+	require.NoError(t, migrator.Copy(context.Background()))
+	blocks = append(blocks, saveBlock(6))
+	// Expire the evidence, so the pruned block is fully deleted.
+	pruneState := state.Copy()
+	pruneState.LastBlockHeight = 1_000_000
+	pruneState.LastBlockTime = cmttime.Now().Add(100_000 * time.Hour)
+	_, _, err = bs.PruneBlocks(2, pruneState)
+	require.NoError(t, err)
+	blocks = blocks[1:]
+	require.NoError(t, migrator.Copy(context.Background()))
+	blocks = append(blocks, saveBlock(7))
+
+	stats, err := migrator.Run(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, len(blocks), stats["block meta"].Count)
+	require.EqualValues(t, len(blocks), stats["block hash"].Count)
+
+	version, err := keylayout.Version(db)
+	require.NoError(t, err)
+	require.Equal(t, "v2", version)
+
+	// No record is left in the old layout.
+	for _, kind := range blockRecordKinds {
+		it, err := dbm.IteratePrefix(db, []byte(kind.v1Prefix))
+		require.NoError(t, err)
+		require.False(t, it.Valid(), kind.name)
+		it.Close()
+	}
+
+	bs = NewBlockStore(db, WithDBKeyLayout("v1"))
+	require.Equal(t, "v2", bs.GetVersion())
+	require.EqualValues(t, 2, bs.Base())
+	require.EqualValues(t, 7, bs.Height())
+	for _, block := range blocks {
+		loaded, _ := bs.LoadBlock(block.Height)
+		require.NotNil(t, loaded)
+		require.Equal(t, block.Hash(), loaded.Hash())
+		require.NotNil(t, bs.LoadBlockMetaByHash(block.Hash()))
+		require.NotNil(t, bs.LoadBlockExtendedCommit(block.Height))
+	}
+
+	require.NotNil(t, bs.LoadSeenCommit(7))
+
+	_, err = NewKeyLayoutMigrator(db, "v2", log.NewNopLogger())
+	require.ErrorAs(t, err, &keylayout.ErrSameLayout{})
+}
+
+func TestKeyLayoutMigrationResume(t *testing.T) {
+	config := test.ResetTestRoot("block_store_migration_test")
+	defer os.RemoveAll(config.RootDir)
+	state, err := sm.MakeGenesisStateFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db, WithDBKeyLayout("v1"))
+	for height := int64(1); height <= 3; height++ {
+		block := state.MakeBlock(height, test.MakeNTxs(height, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		bs.SaveBlock(block, partSet, makeTestExtCommit(height, cmttime.Now()).ToCommit())
+	}
+
+	migrator, err := NewKeyLayoutMigrator(db, "v2", log.NewNopLogger())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = migrator.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	version, err := keylayout.Version(db)
+	require.NoError(t, err)
+	require.Equal(t, "v1", version)
+
+	migrator, err = NewKeyLayoutMigrator(db, "v2", log.NewNopLogger())
+	require.NoError(t, err)
+	_, err = migrator.Run(context.Background())
+	require.NoError(t, err)
+
+	bs = NewBlockStore(db)
+	require.Equal(t, "v2", bs.GetVersion())
+	require.EqualValues(t, 3, bs.Height())
+	block, _ := bs.LoadBlock(3)
+	require.NotNil(t, block)
+}
@@ -0,0 +1,139 @@
+package segment
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Entry holds the records of the block store for a height, as they are
+// encoded in the database.
+type Entry struct {
+	Height int64
+	// Meta is the block meta.
+	Meta []byte
+	// Parts are the block parts, by index.
+	Parts [][]byte
+	// Commit is the commit for Height, saved along with the block at
+	// Height+1.
+	Commit []byte
+	// SeenCommit is the locally seen commit, if any.
+	SeenCommit []byte
+	// ExtCommit is the extended commit, if any.
+	ExtCommit []byte
+}
+
+// encode returns the compressed encoding of the entry.
+func (e *Entry) encode() ([]byte, error) {
+	var raw []byte
+	raw = binary.AppendVarint(raw, e.Height)
+	raw = appendBytes(raw, e.Meta)
+	raw = binary.AppendUvarint(raw, uint64(len(e.Parts)))
+	for _, part := range e.Parts {
+		raw = appendBytes(raw, part)
+	}
+	raw = appendBytes(raw, e.Commit)
+	raw = appendBytes(raw, e.SeenCommit)
+	raw = appendBytes(raw, e.ExtCommit)
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeEntry decodes an entry encoded by Entry.encode.
+func decodeEntry(bz []byte) (*Entry, error) {
+	raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(bz)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress entry: %w", err)
+	}
+	d := decoder{buf: raw}
+	e := &Entry{Height: d.varint()}
+	e.Meta = d.bytes()
+	numParts := d.uvarint()
+	if numParts > uint64(len(d.buf)) {
+		return nil, errors.New("invalid number of block parts")
+	}
+	e.Parts = make([][]byte, numParts)
+	for i := range e.Parts {
+		e.Parts[i] = d.bytes()
+	}
+	e.Commit = d.bytes()
+	e.SeenCommit = d.bytes()
+	e.ExtCommit = d.bytes()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.buf) > 0 {
+		return nil, errors.New("unexpected trailing bytes in entry")
+	}
+	return e, nil
+}
+
+func appendBytes(buf, bz []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(bz)))
+	return append(buf, bz...)
+}
+
+// decoder decodes the fields of an entry, keeping the first error.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+var errTruncatedEntry = errors.New("truncated entry")
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errTruncatedEntry
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errTruncatedEntry
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	l := d.uvarint()
+	if d.err != nil {
+		return nil
+	}
+	if l > uint64(len(d.buf)) {
+		d.err = errTruncatedEntry
+		return nil
+	}
+	if l == 0 {
+		return nil
+	}
+	bz := d.buf[:l:l]
+	d.buf = d.buf[l:]
+	return bz
+}
@@ -0,0 +1,551 @@
+// Package segment implements the cold storage tier of the block store.
+//
+// Blocks are stored in append-only segment files, each holding the
+// compressed records of the heights of a fixed range, along with an index
+// from heights to the offsets of their records. The index of the last
+// segment, which is still being appended to, is kept in memory and rebuilt
+// from the segment when opening the store. Once a segment is complete, its
+// index is written to disk and the segment is sealed.
+//
+// A segment file is a sequence of frames:
+//
+//	height (8 bytes) | length (4 bytes) | CRC32-C (4 bytes) | compressed entry
+//
+// and an index file is a sequence of (height, offset) pairs of 8 bytes each.
+package segment
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+)
+
+const (
+	segmentExt = ".seg"
+	indexExt   = ".idx"
+
+	frameHeaderSize = 16
+	indexEntrySize  = 16
+
+	// maxEntrySize bounds the size of an encoded entry, to detect corrupted
+	// frame headers.
+	maxEntrySize = 1 << 30
+
+	// cacheSize is the number of decoded entries kept in memory, so that
+	// loading the parts of a block one by one decodes the entry once.
+	cacheSize = 32
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrNonIncreasingHeight is returned when appending an entry whose height is
+// not above the last height of the store.
+type ErrNonIncreasingHeight struct {
+	Height int64
+	Last   int64
+}
+
+func (e ErrNonIncreasingHeight) Error() string {
+	return fmt.Sprintf("cannot append height %d, the last height is %d", e.Height, e.Last)
+}
+
+// ErrCorruptedSegment is returned when a record can't be read from a segment.
+type ErrCorruptedSegment struct {
+	Path string
+	Err  error
+}
+
+func (e ErrCorruptedSegment) Error() string {
+	return fmt.Sprintf("corrupted segment %s: %v", e.Path, e.Err)
+}
+
+func (e ErrCorruptedSegment) Unwrap() error {
+	return e.Err
+}
+
+type indexEntry struct {
+	height int64
+	offset int64
+}
+
+// segment is a segment file of the store.
+type segment struct {
+	// start is the first height of the range of the segment.
+	start int64
+	// base and last are the first and last heights stored in the segment,
+	// or 0 if it is empty.
+	base, last int64
+	sealed     bool
+	// index of the last segment, which is not sealed.
+	index []indexEntry
+}
+
+// Store is a cold storage of blocks, in segment files. It is safe for
+// concurrent use.
+type Store struct {
+	dir  string
+	size int64
+
+	mtx      cmtsync.RWMutex
+	segments []*segment // ordered by start
+	height   int64
+	head     *os.File // file of the last segment, if not sealed
+	headSize int64
+
+	cache *lru.Cache[int64, *Entry]
+}
+
+// Open opens the store in dir, creating it if needed. Each segment holds
+// the entries of segmentSize heights.
+func Open(dir string, segmentSize int64) (*Store, error) {
+	if segmentSize <= 0 {
+		return nil, fmt.Errorf("segment size must be positive, got %d", segmentSize)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	cache, err := lru.New[int64, *Entry](cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{dir: dir, size: segmentSize, cache: cache}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), segmentExt)
+		if !ok || f.IsDir() {
+			continue
+		}
+		start, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected segment file %s: %w", f.Name(), err)
+		}
+		s.segments = append(s.segments, &segment{start: start})
+	}
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i].start < s.segments[j].start })
+
+	for i, seg := range s.segments {
+		if err := s.load(seg, i == len(s.segments)-1); err != nil {
+			s.Close()
+			return nil, err
+		}
+		if seg.last > 0 {
+			s.height = seg.last
+		}
+	}
+	return s, nil
+}
+
+func (s *Store) segmentPath(start int64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", start, segmentExt))
+}
+
+func (s *Store) indexPath(start int64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", start, indexExt))
+}
+
+// load loads the heights of a segment. A segment without an index file is
+// scanned, and sealed unless it is the last one, which is opened for
+// appending.
+func (s *Store) load(seg *segment, isLast bool) error {
+	indexPath := s.indexPath(seg.start)
+	if info, err := os.Stat(indexPath); err == nil {
+		seg.sealed = true
+		n := info.Size() / indexEntrySize
+		if n == 0 {
+			return nil
+		}
+		f, err := os.Open(indexPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		first, err := readIndexEntry(f, 0)
+		if err != nil {
+			return ErrCorruptedSegment{Path: indexPath, Err: err}
+		}
+		last, err := readIndexEntry(f, n-1)
+		if err != nil {
+			return ErrCorruptedSegment{Path: indexPath, Err: err}
+		}
+		seg.base, seg.last = first.height, last.height
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	path := s.segmentPath(seg.start)
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+	index, size, err := scan(f)
+	if err != nil {
+		f.Close()
+		return ErrCorruptedSegment{Path: path, Err: err}
+	}
+	// Drop a partially written frame.
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return err
+	}
+	seg.index = index
+	if len(index) > 0 {
+		seg.base, seg.last = index[0].height, index[len(index)-1].height
+	}
+	if isLast {
+		s.head, s.headSize = f, size
+		return nil
+	}
+	f.Close()
+	return s.seal(seg)
+}
+
+// scan returns the index of the complete frames of a segment, and the size
+// of these frames.
+func scan(f *os.File) ([]indexEntry, int64, error) {
+	var (
+		index  []indexEntry
+		offset int64
+		header [frameHeaderSize]byte
+	)
+	for {
+		if _, err := f.ReadAt(header[:], offset); err != nil {
+			if errors.Is(err, io.EOF) {
+				return index, offset, nil
+			}
+			return nil, 0, err
+		}
+		height := int64(binary.BigEndian.Uint64(header[0:8]))
+		length := int64(binary.BigEndian.Uint32(header[8:12]))
+		if length > maxEntrySize {
+			return index, offset, nil
+		}
+		payload := make([]byte, length)
+		if _, err := f.ReadAt(payload, offset+frameHeaderSize); err != nil {
+			if errors.Is(err, io.EOF) {
+				return index, offset, nil
+			}
+			return nil, 0, err
+		}
+		if frameChecksum(header[0:8], payload) != binary.BigEndian.Uint32(header[12:16]) {
+			return index, offset, nil
+		}
+		index = append(index, indexEntry{height: height, offset: offset})
+		offset += frameHeaderSize + length
+	}
+}
+
+func frameChecksum(height, payload []byte) uint32 {
+	return crc32.Update(crc32.Checksum(height, crc32c), crc32c, payload)
+}
+
+// seal writes the index of a segment to disk.
+func (s *Store) seal(seg *segment) error {
+	buf := make([]byte, 0, len(seg.index)*indexEntrySize)
+	for _, e := range seg.index {
+		buf = binary.BigEndian.AppendUint64(buf, uint64(e.height))
+		buf = binary.BigEndian.AppendUint64(buf, uint64(e.offset))
+	}
+	path := s.indexPath(seg.start)
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	seg.sealed = true
+	seg.index = nil
+	return nil
+}
+
+func readIndexEntry(f *os.File, i int64) (indexEntry, error) {
+	var buf [indexEntrySize]byte
+	if _, err := f.ReadAt(buf[:], i*indexEntrySize); err != nil {
+		return indexEntry{}, err
+	}
+	return indexEntry{
+		height: int64(binary.BigEndian.Uint64(buf[0:8])),
+		offset: int64(binary.BigEndian.Uint64(buf[8:16])),
+	}, nil
+}
+
+// segmentStart returns the first height of the range of the segment
+// holding the given height.
+func (s *Store) segmentStart(height int64) int64 {
+	return (height-1)/s.size*s.size + 1
+}
+
+// Base returns the first height of the store, or 0 if it's empty.
+func (s *Store) Base() int64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	for _, seg := range s.segments {
+		if seg.base > 0 {
+			return seg.base
+		}
+	}
+	return 0
+}
+
+// Height returns the last height appended to the store, or 0 if none was.
+func (s *Store) Height() int64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.height
+}
+
+// Append appends the given entries, in increasing height order, and syncs
+// them to disk. Heights may be skipped.
+func (s *Store) Append(entries ...*Entry) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for _, e := range entries {
+		if e.Height <= s.height || e.Height <= 0 {
+			return ErrNonIncreasingHeight{Height: e.Height, Last: s.height}
+		}
+		seg, err := s.headSegment(s.segmentStart(e.Height))
+		if err != nil {
+			return err
+		}
+		payload, err := e.encode()
+		if err != nil {
+			return err
+		}
+		frame := make([]byte, frameHeaderSize, frameHeaderSize+len(payload))
+		binary.BigEndian.PutUint64(frame[0:8], uint64(e.Height))
+		binary.BigEndian.PutUint32(frame[8:12], uint32(len(payload)))
+		binary.BigEndian.PutUint32(frame[12:16], frameChecksum(frame[0:8], payload))
+		frame = append(frame, payload...)
+		if _, err := s.head.WriteAt(frame, s.headSize); err != nil {
+			// Drop the partially written frame, if any.
+			_ = s.head.Truncate(s.headSize)
+			return err
+		}
+		seg.index = append(seg.index, indexEntry{height: e.Height, offset: s.headSize})
+		if seg.base == 0 {
+			seg.base = e.Height
+		}
+		seg.last = e.Height
+		s.headSize += int64(len(frame))
+		s.height = e.Height
+	}
+	if s.head != nil {
+		return s.head.Sync()
+	}
+	return nil
+}
+
+// headSegment returns the segment starting at start, sealing the previous
+// one and creating it if needed.
+//
+// Contract: the caller must hold the write lock.
+func (s *Store) headSegment(start int64) (*segment, error) {
+	if n := len(s.segments); n > 0 {
+		head := s.segments[n-1]
+		if head.start == start && !head.sealed {
+			return head, nil
+		}
+		if !head.sealed {
+			if err := s.head.Sync(); err != nil {
+				return nil, err
+			}
+			if err := s.seal(head); err != nil {
+				return nil, err
+			}
+			if err := s.head.Close(); err != nil {
+				return nil, err
+			}
+			s.head = nil
+		}
+	}
+
+	f, err := os.OpenFile(s.segmentPath(start), os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	seg := &segment{start: start}
+	s.segments = append(s.segments, seg)
+	s.head, s.headSize = f, 0
+	return seg, nil
+}
+
+// Get returns the entry at the given height, or nil if there is none.
+func (s *Store) Get(height int64) (*Entry, error) {
+	if e, ok := s.cache.Get(height); ok {
+		return e, nil
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	i := sort.Search(len(s.segments), func(i int) bool { return s.segments[i].start > height }) - 1
+	if i < 0 {
+		return nil, nil
+	}
+	seg := s.segments[i]
+	if seg.base == 0 || height < seg.base || height > seg.last {
+		return nil, nil
+	}
+
+	var (
+		f   *os.File
+		err error
+	)
+	if seg.sealed {
+		f, err = os.Open(s.segmentPath(seg.start))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+	} else {
+		f = s.head
+	}
+
+	offset, found, err := s.lookup(seg, height)
+	if err != nil || !found {
+		return nil, err
+	}
+	e, err := readFrame(f, offset)
+	if err != nil {
+		return nil, ErrCorruptedSegment{Path: s.segmentPath(seg.start), Err: err}
+	}
+	if e.Height != height {
+		return nil, ErrCorruptedSegment{
+			Path: s.segmentPath(seg.start),
+			Err:  fmt.Errorf("expected height %d at offset %d, got %d", height, offset, e.Height),
+		}
+	}
+	s.cache.Add(height, e)
+	return e, nil
+}
+
+// lookup returns the offset of the frame of the given height in a segment.
+//
+// Contract: the caller must hold the read lock.
+func (s *Store) lookup(seg *segment, height int64) (int64, bool, error) {
+	if !seg.sealed {
+		i := sort.Search(len(seg.index), func(i int) bool { return seg.index[i].height >= height })
+		if i == len(seg.index) || seg.index[i].height != height {
+			return 0, false, nil
+		}
+		return seg.index[i].offset, true, nil
+	}
+
+	path := s.indexPath(seg.start)
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, false, err
+	}
+	var searchErr error
+	n := int(info.Size() / indexEntrySize)
+	i := sort.Search(n, func(i int) bool {
+		e, err := readIndexEntry(f, int64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return e.height >= height
+	})
+	if searchErr != nil {
+		return 0, false, ErrCorruptedSegment{Path: path, Err: searchErr}
+	}
+	if i == n {
+		return 0, false, nil
+	}
+	e, err := readIndexEntry(f, int64(i))
+	if err != nil {
+		return 0, false, ErrCorruptedSegment{Path: path, Err: err}
+	}
+	return e.offset, e.height == height, nil
+}
+
+func readFrame(f *os.File, offset int64) (*Entry, error) {
+	var header [frameHeaderSize]byte
+	if _, err := f.ReadAt(header[:], offset); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[8:12])
+	if length > maxEntrySize {
+		return nil, fmt.Errorf("invalid entry length %d", length)
+	}
+	payload := make([]byte, length)
+	if _, err := f.ReadAt(payload, offset+frameHeaderSize); err != nil {
+		return nil, err
+	}
+	if frameChecksum(header[0:8], payload) != binary.BigEndian.Uint32(header[12:16]) {
+		return nil, errors.New("checksum mismatch")
+	}
+	return decodeEntry(payload)
+}
+
+// DropBefore deletes the sealed segments whose heights are all below the
+// given height, and returns the number of deleted segments.
+func (s *Store) DropBefore(height int64) (int, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	dropped := 0
+	for _, seg := range s.segments {
+		if !seg.sealed || seg.last >= height {
+			break
+		}
+		if err := os.Remove(s.indexPath(seg.start)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return dropped, err
+		}
+		if err := os.Remove(s.segmentPath(seg.start)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return dropped, err
+		}
+		dropped++
+	}
+	s.segments = s.segments[dropped:]
+	if dropped > 0 {
+		s.cache.Purge()
+	}
+	return dropped, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.head == nil {
+		return nil
+	}
+	err := s.head.Close()
+	s.head = nil
+	return err
+}
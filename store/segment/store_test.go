@@ -0,0 +1,162 @@
+package segment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testEntry(height int64) *Entry {
+	return &Entry{
+		Height:     height,
+		Meta:       []byte(fmt.Sprintf("meta %d", height)),
+		Parts:      [][]byte{[]byte(fmt.Sprintf("part %d/0", height)), []byte(fmt.Sprintf("part %d/1", height))},
+		Commit:     []byte(fmt.Sprintf("commit %d", height)),
+		SeenCommit: []byte(fmt.Sprintf("seen commit %d", height)),
+	}
+}
+
+func appendHeights(t *testing.T, s *Store, from, to int64) {
+	t.Helper()
+	for h := from; h <= to; h++ {
+		require.NoError(t, s.Append(testEntry(h)))
+	}
+}
+
+func requireEntries(t *testing.T, s *Store, from, to int64) {
+	t.Helper()
+	for h := from; h <= to; h++ {
+		e, err := s.Get(h)
+		require.NoError(t, err)
+		require.Equal(t, testEntry(h), e, "height %d", h)
+	}
+}
+
+func TestStoreAppendGet(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 10)
+	require.NoError(t, err)
+	require.Zero(t, s.Base())
+	require.Zero(t, s.Height())
+
+	appendHeights(t, s, 3, 25)
+	require.EqualValues(t, 3, s.Base())
+	require.EqualValues(t, 25, s.Height())
+	requireEntries(t, s, 3, 25)
+
+	e, err := s.Get(2)
+	require.NoError(t, err)
+	require.Nil(t, e)
+	e, err = s.Get(26)
+	require.NoError(t, err)
+	require.Nil(t, e)
+
+	err = s.Append(testEntry(25))
+	require.ErrorAs(t, err, &ErrNonIncreasingHeight{})
+
+	// Heights may be skipped.
+	require.NoError(t, s.Append(testEntry(28), testEntry(45)))
+	e, err = s.Get(30)
+	require.NoError(t, err)
+	require.Nil(t, e)
+	requireEntries(t, s, 45, 45)
+
+	// The complete segments are sealed with an index.
+	for _, start := range []int64{1, 11, 21} {
+		require.FileExists(t, filepath.Join(dir, fmt.Sprintf("%020d.idx", start)))
+	}
+	require.NoFileExists(t, filepath.Join(dir, fmt.Sprintf("%020d.idx", 41)))
+	require.NoError(t, s.Close())
+
+	s, err = Open(dir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+	require.EqualValues(t, 3, s.Base())
+	require.EqualValues(t, 45, s.Height())
+	requireEntries(t, s, 3, 25)
+	requireEntries(t, s, 28, 28)
+	requireEntries(t, s, 45, 45)
+	appendHeights(t, s, 46, 50)
+	requireEntries(t, s, 45, 50)
+}
+
+func TestStoreTruncatedFrame(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 10)
+	require.NoError(t, err)
+	appendHeights(t, s, 1, 5)
+	require.NoError(t, s.Close())
+
+	// Simulate a crash while appending height 6.
+	path := filepath.Join(dir, fmt.Sprintf("%020d.seg", 1))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 1})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s, err = Open(dir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+	require.EqualValues(t, 5, s.Height())
+	appendHeights(t, s, 6, 12)
+	requireEntries(t, s, 1, 12)
+}
+
+func TestStoreCorruptedEntry(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 10)
+	require.NoError(t, err)
+	appendHeights(t, s, 1, 12)
+	require.NoError(t, s.Close())
+
+	path := filepath.Join(dir, fmt.Sprintf("%020d.seg", 1))
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	bz[frameHeaderSize] ^= 0xff
+	require.NoError(t, os.WriteFile(path, bz, 0o600))
+
+	s, err = Open(dir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+	_, err = s.Get(1)
+	require.ErrorAs(t, err, &ErrCorruptedSegment{})
+	requireEntries(t, s, 2, 12)
+}
+
+func TestStoreDropBefore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 10)
+	require.NoError(t, err)
+	defer s.Close()
+	appendHeights(t, s, 1, 35)
+
+	dropped, err := s.DropBefore(20)
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+	require.EqualValues(t, 11, s.Base())
+	e, err := s.Get(5)
+	require.NoError(t, err)
+	require.Nil(t, e)
+	requireEntries(t, s, 11, 35)
+
+	dropped, err = s.DropBefore(21)
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+	require.EqualValues(t, 21, s.Base())
+
+	// The last segment isn't sealed, so it's not dropped.
+	dropped, err = s.DropBefore(100)
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+	require.EqualValues(t, 31, s.Base())
+	require.EqualValues(t, 35, s.Height())
+	requireEntries(t, s, 31, 35)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
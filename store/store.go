@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cosmos/gogoproto/proto"
@@ -13,9 +14,11 @@ import (
 	cmtstore "github.com/cometbft/cometbft/api/cometbft/store/v1"
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	"github.com/cometbft/cometbft/v2/internal/evidence"
+	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/libs/metrics"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
 	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store/segment"
 	"github.com/cometbft/cometbft/v2/types"
 	cmterrors "github.com/cometbft/cometbft/v2/types/errors"
 )
@@ -70,6 +73,19 @@ type BlockStore struct {
 	blockCommitCache         *lru.Cache[int64, *types.Commit]
 	blockExtendedCommitCache *lru.Cache[int64, *types.ExtendedCommit]
 	blockPartCache           *lru.Cache[blockPartIndex, *types.Part]
+
+	// cold is the cold storage of the blocks older than coldRetainBlocks
+	// heights, if enabled. The blocks are moved by a background routine,
+	// notified through coldTrigger when a block is saved.
+	cold             *segment.Store
+	coldRetainBlocks int64
+	coldMtx          cmtsync.Mutex // serializes moving and pruning blocks
+	coldTrigger      chan struct{}
+	coldQuit         chan struct{}
+	coldDone         chan struct{}
+	coldStop         sync.Once
+
+	logger log.Logger
 }
 
 type BlockStoreOption func(*BlockStore)
@@ -87,6 +103,11 @@ func WithMetrics(metrics *Metrics) BlockStoreOption {
 	return func(bs *BlockStore) { bs.metrics = metrics }
 }
 
+// WithLogger sets the logger.
+func WithLogger(logger log.Logger) BlockStoreOption {
+	return func(bs *BlockStore) { bs.logger = logger }
+}
+
 // WithDBKeyLayout the metrics.
 func WithDBKeyLayout(dbKeyLayout string) BlockStoreOption {
 	return func(bs *BlockStore) { setDBLayout(bs, dbKeyLayout) }
@@ -131,6 +152,7 @@ func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
 		height:  bs.Height,
 		db:      db,
 		metrics: NopMetrics(),
+		logger:  log.NewNopLogger(),
 	}
 	bStore.addCaches()
 
@@ -142,6 +164,8 @@ func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
 		setDBLayout(bStore, "v1")
 	}
 
+	bStore.startColdStorageRoutine()
+
 	addTimeSample(bStore.metrics.BlockStoreAccessDurationSeconds.With("method", "new_block_store"), start)()
 	return bStore
 }
@@ -291,17 +315,19 @@ func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 	}
 	pbpart := new(cmtproto.Part)
 	start := time.Now()
-	bz, err := bs.db.Get(bs.dbKeyLayout.CalcBlockPartKey(height, index))
-	if err != nil {
-		panic(err)
-	}
+	bz := bs.loadRecord(height, bs.dbKeyLayout.CalcBlockPartKey(height, index), func(e *segment.Entry) []byte {
+		if index < 0 || index >= len(e.Parts) {
+			return nil
+		}
+		return e.Parts[index]
+	})
 
 	addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "load_block_part"), start)()
 
 	if len(bz) == 0 {
 		return nil
 	}
-	err = proto.Unmarshal(bz, pbpart)
+	err := proto.Unmarshal(bz, pbpart)
 	if err != nil {
 		panic(fmt.Errorf("unmarshal to cmtproto.Part failed: %w", err))
 	}
@@ -318,10 +344,7 @@ func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	pbbm := new(cmtproto.BlockMeta)
 	start := time.Now()
-	bz, err := bs.db.Get(bs.dbKeyLayout.CalcBlockMetaKey(height))
-	if err != nil {
-		panic(err)
-	}
+	bz := bs.loadRecord(height, bs.dbKeyLayout.CalcBlockMetaKey(height), func(e *segment.Entry) []byte { return e.Meta })
 
 	addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "load_block_meta"), start)()
 
@@ -329,7 +352,7 @@ func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 		return nil
 	}
 
-	err = proto.Unmarshal(bz, pbbm)
+	err := proto.Unmarshal(bz, pbbm)
 	if err != nil {
 		panic(fmt.Errorf("unmarshal to cmtproto.BlockMeta: %w", err))
 	}
@@ -377,10 +400,7 @@ func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 	pbc := new(cmtproto.Commit)
 
 	start := time.Now()
-	bz, err := bs.db.Get(bs.dbKeyLayout.CalcBlockCommitKey(height))
-	if err != nil {
-		panic(err)
-	}
+	bz := bs.loadRecord(height, bs.dbKeyLayout.CalcBlockCommitKey(height), func(e *segment.Entry) []byte { return e.Commit })
 
 	addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "load_block_commit"), start)()
 
@@ -388,7 +408,7 @@ func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 		return nil
 	}
 
-	err = proto.Unmarshal(bz, pbc)
+	err := proto.Unmarshal(bz, pbc)
 	if err != nil {
 		panic(fmt.Errorf("error reading block commit: %w", err))
 	}
@@ -411,10 +431,7 @@ func (bs *BlockStore) LoadBlockExtendedCommit(height int64) *types.ExtendedCommi
 	pbec := new(cmtproto.ExtendedCommit)
 
 	start := time.Now()
-	bz, err := bs.db.Get(bs.dbKeyLayout.CalcExtCommitKey(height))
-	if err != nil {
-		panic(fmt.Errorf("fetching extended commit: %w", err))
-	}
+	bz := bs.loadRecord(height, bs.dbKeyLayout.CalcExtCommitKey(height), func(e *segment.Entry) []byte { return e.ExtCommit })
 
 	addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "load_block_ext_commit"), start)()
 
@@ -422,7 +439,7 @@ func (bs *BlockStore) LoadBlockExtendedCommit(height int64) *types.ExtendedCommi
 		return nil
 	}
 
-	err = proto.Unmarshal(bz, pbec)
+	err := proto.Unmarshal(bz, pbec)
 	if err != nil {
 		panic(fmt.Errorf("decoding extended commit: %w", err))
 	}
@@ -444,10 +461,7 @@ func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 	}
 	pbc := new(cmtproto.Commit)
 	start := time.Now()
-	bz, err := bs.db.Get(bs.dbKeyLayout.CalcSeenCommitKey(height))
-	if err != nil {
-		panic(err)
-	}
+	bz := bs.loadRecord(height, bs.dbKeyLayout.CalcSeenCommitKey(height), func(e *segment.Entry) []byte { return e.SeenCommit })
 
 	addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "load_seen_commit"), start)()
 
@@ -455,7 +469,7 @@ func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 		return nil
 	}
 
-	err = proto.Unmarshal(bz, pbc)
+	err := proto.Unmarshal(bz, pbc)
 	if err != nil {
 		panic(fmt.Sprintf("error reading block seen commit: %v", err))
 	}
@@ -485,6 +499,11 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 	if height < base {
 		return 0, -1, ErrExceedBaseHeight{Height: height, Base: base}
 	}
+	// Blocks must not be moved to the cold storage while they are pruned.
+	if bs.cold != nil {
+		bs.coldMtx.Lock()
+		defer bs.coldMtx.Unlock()
+	}
 
 	pruned := uint64(0)
 	batch := bs.db.NewBatch()
@@ -560,6 +579,13 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 	}
 	bs.blocksDeleted += int64(pruned)
 
+	// The blocks in the cold storage are deleted by whole segments.
+	if bs.cold != nil {
+		if _, err := bs.cold.DropBefore(min(height, evidencePoint)); err != nil {
+			return 0, -1, fmt.Errorf("dropping cold storage segments: %w", err)
+		}
+	}
+
 	if bs.compact && bs.blocksDeleted >= bs.compactionInterval {
 		// When the range is nil,nil, the database will try to compact
 		// ALL levels. Another option is to set a predefined range of
@@ -597,6 +623,9 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic(err)
 	}
 
+	// Move the old blocks once the lock is released.
+	defer bs.triggerColdStorage()
+
 	bs.mtx.Lock()
 	defer bs.mtx.Unlock()
 	bs.height = block.Height
@@ -647,6 +676,9 @@ func (bs *BlockStore) SaveBlockWithExtendedCommit(block *types.Block, blockParts
 		panic(err)
 	}
 
+	// Move the old blocks once the lock is released.
+	defer bs.triggerColdStorage()
+
 	bs.mtx.Lock()
 	defer bs.mtx.Unlock()
 	bs.height = height
@@ -802,6 +834,12 @@ func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) err
 }
 
 func (bs *BlockStore) Close() error {
+	if bs.cold != nil {
+		bs.stopColdStorageRoutine()
+		if err := bs.cold.Close(); err != nil {
+			return err
+		}
+	}
 	return bs.db.Close()
 }
 
@@ -859,7 +897,7 @@ func mustEncode(pb proto.Message) []byte {
 // -----------------------------------------------------------------------------
 
 // DeleteLatestBlock removes the block pointed to by height,
-// lowering height by one.
+// lowering height by one. Blocks moved to the cold storage can't be deleted.
 func (bs *BlockStore) DeleteLatestBlock() error {
 	defer addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "delete_latest_block"), time.Now())()
 
@@ -867,6 +905,12 @@ func (bs *BlockStore) DeleteLatestBlock() error {
 	targetHeight := bs.height
 	bs.mtx.RUnlock()
 
+	bs.coldMtx.Lock()
+	defer bs.coldMtx.Unlock()
+	if bs.inColdStorage(targetHeight) {
+		return ErrDeleteColdBlock{Height: targetHeight}
+	}
+
 	batch := bs.db.NewBatch()
 	defer batch.Close()
 
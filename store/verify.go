@@ -0,0 +1,536 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/libs/service"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store/segment"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// ErrCorruptedBlock is returned when the records of a block in the block
+// store are missing or invalid.
+type ErrCorruptedBlock struct {
+	Height int64
+	Err    error
+}
+
+func (e ErrCorruptedBlock) Error() string {
+	return fmt.Sprintf("corrupted block at height %d: %v", e.Height, e.Err)
+}
+
+func (e ErrCorruptedBlock) Unwrap() error {
+	return e.Err
+}
+
+// ErrUntrustedBlock is returned when a block fetched to repair the block
+// store can't be verified.
+type ErrUntrustedBlock struct {
+	Height int64
+	Reason string
+}
+
+func (e ErrUntrustedBlock) Error() string {
+	return fmt.Sprintf("cannot trust the fetched block at height %d: %s", e.Height, e.Reason)
+}
+
+// BlockFetcher fetches blocks from peers, to repair the block store.
+type BlockFetcher interface {
+	// FetchBlock returns the block at height, and its extended commit if vote
+	// extensions are enabled at height.
+	FetchBlock(ctx context.Context, height int64) (*types.Block, *types.ExtendedCommit, error)
+}
+
+// VerifyResult is the result of the verification of a range of heights.
+type VerifyResult struct {
+	From, To int64
+	// Corrupted are the corrupted blocks found, including the repaired ones.
+	Corrupted []ErrCorruptedBlock
+	// Repaired are the heights of the repaired blocks.
+	Repaired []int64
+}
+
+// Verifier checks the integrity of a block store: for each height, the block
+// meta and all the block parts must be present, the parts must match the
+// part set header and reassemble into the block of the block meta, and the
+// commits must be signed by the validators loaded from the state store, when
+// it still has them. It runs a check at every interval as a service, and
+// optionally repairs the corrupted blocks by fetching them from peers.
+type Verifier struct {
+	service.BaseService
+
+	blockStore *BlockStore
+	stateStore sm.Store
+	fetcher    BlockFetcher
+	interval   time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// VerifierOption sets an optional parameter on the Verifier.
+type VerifierOption func(*Verifier)
+
+// WithRepair repairs the corrupted blocks with the blocks fetched by the
+// given fetcher. A fetched block is only saved if it matches the intact
+// records of the block store, or if it is signed by the validators of its
+// height.
+func WithRepair(fetcher BlockFetcher) VerifierOption {
+	return func(v *Verifier) { v.fetcher = fetcher }
+}
+
+// WithVerifyInterval sets the time period between two checks of the whole
+// block store, when running as a service. The default is 24 hours.
+func WithVerifyInterval(interval time.Duration) VerifierOption {
+	return func(v *Verifier) { v.interval = interval }
+}
+
+// NewVerifier returns a verifier of the given block store, checking the
+// commits against the validators of the given state store.
+func NewVerifier(blockStore *BlockStore, stateStore sm.Store, logger log.Logger, options ...VerifierOption) *Verifier {
+	v := &Verifier{
+		blockStore: blockStore,
+		stateStore: stateStore,
+		interval:   24 * time.Hour,
+		done:       make(chan struct{}),
+	}
+	for _, option := range options {
+		option(v)
+	}
+	v.BaseService = *service.NewBaseService(logger, "BlockStoreVerifier", v)
+	return v
+}
+
+// OnStart implements service.Service.
+func (v *Verifier) OnStart() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	go v.routine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service. It interrupts the ongoing check and
+// waits for it to return.
+func (v *Verifier) OnStop() {
+	v.cancel()
+	<-v.done
+}
+
+func (v *Verifier) routine(ctx context.Context) {
+	defer close(v.done)
+
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		res, err := v.Verify(ctx, 0, 0)
+		switch {
+		case errors.Is(err, context.Canceled):
+			return
+		case err != nil:
+			v.Logger.Error("Failed to verify the block store", "err", err)
+		default:
+			v.Logger.Info("Verified the block store", "from", res.From, "to", res.To,
+				"corrupted", len(res.Corrupted), "repaired", len(res.Repaired), "duration", time.Since(start))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Verify checks the heights from to to (inclusive). Zero heights default to
+// the base and height of the block store. The corrupted blocks are reported
+// in the result, and repaired if the verifier has a fetcher. Verify only
+// returns an error if the stores can't be read.
+func (v *Verifier) Verify(ctx context.Context, from, to int64) (VerifyResult, error) {
+	base, height := v.blockStore.Base(), v.blockStore.Height()
+	if from == 0 {
+		from = base
+	}
+	if to == 0 {
+		to = height
+	}
+	res := VerifyResult{From: from, To: to}
+	if from < base || to > height || from > to {
+		if v.blockStore.IsEmpty() {
+			return res, nil
+		}
+		return res, fmt.Errorf("cannot verify heights %d to %d, the block store has heights %d to %d", from, to, base, height)
+	}
+	state, err := v.stateStore.Load()
+	if err != nil {
+		return res, err
+	}
+
+	for h := from; h <= to; h++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		err := v.verifyHeight(state.ChainID, h)
+		var corruptedErr ErrCorruptedBlock
+		if !errors.As(err, &corruptedErr) {
+			if err != nil {
+				return res, err
+			}
+			continue
+		}
+		if h < v.blockStore.Base() {
+			// Pruned while it was verified.
+			continue
+		}
+		v.Logger.Error("Found corrupted block", "height", h, "err", corruptedErr.Err)
+		res.Corrupted = append(res.Corrupted, corruptedErr)
+		if v.fetcher == nil {
+			continue
+		}
+
+		if err := v.repair(ctx, state.ChainID, h); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return res, err
+			}
+			v.Logger.Error("Failed to repair block", "height", h, "err", err)
+			continue
+		}
+		if err := v.verifyHeight(state.ChainID, h); err != nil {
+			v.Logger.Error("Repaired block is still corrupted", "height", h, "err", err)
+			continue
+		}
+		v.Logger.Info("Repaired block", "height", h)
+		res.Repaired = append(res.Repaired, h)
+	}
+	return res, nil
+}
+
+// verifyHeight checks the records of the block at height.
+func (v *Verifier) verifyHeight(chainID string, height int64) error {
+	bs := v.blockStore
+	corrupted := func(format string, args ...any) error {
+		return ErrCorruptedBlock{Height: height, Err: fmt.Errorf(format, args...)}
+	}
+
+	block, meta, err := bs.checkBlock(height)
+	if err != nil {
+		return err
+	}
+
+	if height > bs.Base() {
+		// The copy of the last commit of the block, loaded as the commit of
+		// the previous height.
+		commit, err := bs.getCommit(height-1, bs.dbKeyLayout.CalcBlockCommitKey(height-1), func(e *segment.Entry) []byte { return e.Commit })
+		if corruptedErr := (ErrCorruptedBlock{}); errors.As(err, &corruptedErr) {
+			// Saved along with the block, so repaired with it.
+			return corrupted("commit of height %d: %w", height-1, corruptedErr.Err)
+		} else if err != nil {
+			return err
+		}
+		if commit == nil {
+			return corrupted("missing commit of height %d", height-1)
+		}
+		if !bytes.Equal(commit.Hash(), block.LastCommitHash) {
+			return corrupted("commit of height %d doesn't match the last commit of the block", height-1)
+		}
+		if prev, err := bs.getBlockMeta(height - 1); err == nil && prev != nil && !prev.BlockID.Equals(block.LastBlockID) {
+			return corrupted("last block ID %v doesn't match the block meta of height %d", block.LastBlockID, height-1)
+		}
+		if vals, err := v.stateStore.LoadValidators(height - 1); err == nil {
+			if err := vals.VerifyCommit(chainID, block.LastBlockID, height-1, block.LastCommit); err != nil {
+				return corrupted("invalid last commit: %w", err)
+			}
+		}
+	}
+
+	vals, err := v.stateStore.LoadValidators(height)
+	if err != nil {
+		// The validators were pruned.
+		vals = nil
+	}
+	if vals != nil && !bytes.Equal(vals.Hash(), block.ValidatorsHash) {
+		return corrupted("validators hash doesn't match the validators of the state store")
+	}
+
+	if height == bs.Height() {
+		seenCommit, err := bs.getCommit(height, bs.dbKeyLayout.CalcSeenCommitKey(height), func(e *segment.Entry) []byte { return e.SeenCommit })
+		if err != nil {
+			return err
+		}
+		if seenCommit == nil {
+			return corrupted("missing seen commit")
+		}
+		if vals != nil {
+			if err := vals.VerifyCommit(chainID, meta.BlockID, height, seenCommit); err != nil {
+				return corrupted("invalid seen commit: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// repair fetches the block at height and rewrites its records.
+func (v *Verifier) repair(ctx context.Context, chainID string, height int64) error {
+	bs := v.blockStore
+	block, extCommit, err := v.fetcher.FetchBlock(ctx, height)
+	if err != nil {
+		return err
+	}
+	if block.Height != height {
+		return ErrUntrustedBlock{Height: height, Reason: fmt.Sprintf("got block at height %d", block.Height)}
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return ErrUntrustedBlock{Height: height, Reason: err.Error()}
+	}
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		return err
+	}
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+	vals, err := v.stateStore.LoadValidators(height)
+	if err != nil {
+		// The validators were pruned.
+		vals = nil
+	}
+	// The seen commit is rewritten with the first valid one of the stored
+	// and fetched commits, if any. Without the validators, a commit is only
+	// valid if it's the last commit of the next block.
+	var nextLastCommitHash []byte
+	if vals == nil && height < bs.Height() {
+		if next, err := bs.getBlockMeta(height + 1); err == nil && next != nil {
+			nextLastCommitHash = next.Header.LastCommitHash
+		}
+	}
+	candidates := make([]*types.Commit, 0, 2)
+	if sc, err := bs.getCommit(height, bs.dbKeyLayout.CalcSeenCommitKey(height), func(e *segment.Entry) []byte { return e.SeenCommit }); err == nil && sc != nil {
+		candidates = append(candidates, sc)
+	}
+	if extCommit != nil {
+		candidates = append(candidates, extCommit.ToCommit())
+	}
+	var seenCommit *types.Commit
+	for _, commit := range candidates {
+		if !commit.BlockID.Equals(blockID) {
+			continue
+		}
+		if vals != nil && vals.VerifyCommit(chainID, blockID, height, commit) != nil {
+			continue
+		}
+		if vals == nil && (nextLastCommitHash == nil || !bytes.Equal(commit.Hash(), nextLastCommitHash)) {
+			continue
+		}
+		seenCommit = commit
+		break
+	}
+
+	// The fetched block is trusted if it matches an intact block meta of its
+	// height, or the last block ID of the next block, or if it's signed by
+	// the validators of its height.
+	switch meta, err := bs.getBlockMeta(height); {
+	case err == nil && meta != nil:
+		if !meta.BlockID.Equals(blockID) {
+			return ErrUntrustedBlock{Height: height, Reason: "block doesn't match the block meta"}
+		}
+	case height < bs.Height():
+		next, err := bs.getBlockMeta(height + 1)
+		if err != nil || next == nil {
+			return ErrUntrustedBlock{Height: height, Reason: "the next block meta is corrupted too"}
+		}
+		if !next.Header.LastBlockID.Equals(blockID) {
+			return ErrUntrustedBlock{Height: height, Reason: "block doesn't match the last block ID of the next block"}
+		}
+	case vals == nil || seenCommit == nil:
+		return ErrUntrustedBlock{Height: height, Reason: "no intact record or commit to verify it against"}
+	}
+	return bs.repairBlock(block, parts, seenCommit)
+}
+
+// checkBlock loads the block at height, returning ErrCorruptedBlock instead
+// of panicking if its records are missing or invalid.
+func (bs *BlockStore) checkBlock(height int64) (*types.Block, *types.BlockMeta, error) {
+	corrupted := func(format string, args ...any) error {
+		return ErrCorruptedBlock{Height: height, Err: fmt.Errorf(format, args...)}
+	}
+
+	meta, err := bs.getBlockMeta(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta == nil {
+		return nil, nil, corrupted("missing block meta")
+	}
+
+	partSet := types.NewPartSetFromHeader(meta.BlockID.PartSetHeader)
+	for i := 0; i < int(partSet.Total()); i++ {
+		bz, err := bs.getRecord(height, bs.dbKeyLayout.CalcBlockPartKey(height, i), func(e *segment.Entry) []byte {
+			if i >= len(e.Parts) {
+				return nil
+			}
+			return e.Parts[i]
+		})
+		if err != nil {
+			return nil, nil, corruptedOrErr(height, err)
+		}
+		if len(bz) == 0 {
+			return nil, nil, corrupted("missing block part %d of %d", i, partSet.Total())
+		}
+		pbp := new(cmtproto.Part)
+		if err := proto.Unmarshal(bz, pbp); err != nil {
+			return nil, nil, corrupted("decoding block part %d: %w", i, err)
+		}
+		part, err := types.PartFromProto(pbp)
+		if err != nil {
+			return nil, nil, corrupted("decoding block part %d: %w", i, err)
+		}
+		if part.Index != uint32(i) {
+			return nil, nil, corrupted("block part %d has index %d", i, part.Index)
+		}
+		if _, err := partSet.AddPart(part); err != nil {
+			return nil, nil, corrupted("invalid block part %d: %w", i, err)
+		}
+	}
+
+	bz, err := io.ReadAll(partSet.GetReader())
+	if err != nil {
+		return nil, nil, corrupted("reading block: %w", err)
+	}
+	pbb := new(cmtproto.Block)
+	if err := proto.Unmarshal(bz, pbb); err != nil {
+		return nil, nil, corrupted("decoding block: %w", err)
+	}
+	block, err := types.BlockFromProto(pbb)
+	if err != nil {
+		return nil, nil, corrupted("decoding block: %w", err)
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return nil, nil, corrupted("invalid block: %w", err)
+	}
+	if !bytes.Equal(block.Hash(), meta.BlockID.Hash) {
+		return nil, nil, corrupted("block hash %X doesn't match the block meta", block.Hash())
+	}
+
+	hbz, err := bs.db.Get(bs.dbKeyLayout.CalcBlockHashKey(block.Hash()))
+	if err != nil {
+		return nil, nil, err
+	}
+	if string(hbz) != strconv.FormatInt(height, 10) {
+		return nil, nil, corrupted("missing block hash index")
+	}
+	return block, meta, nil
+}
+
+// getBlockMeta is like LoadBlockMeta, but returns ErrCorruptedBlock instead
+// of panicking if the record is invalid.
+func (bs *BlockStore) getBlockMeta(height int64) (*types.BlockMeta, error) {
+	bz, err := bs.getRecord(height, bs.dbKeyLayout.CalcBlockMetaKey(height), func(e *segment.Entry) []byte { return e.Meta })
+	if err != nil {
+		return nil, corruptedOrErr(height, err)
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	pbbm := new(cmtproto.BlockMeta)
+	if err := proto.Unmarshal(bz, pbbm); err != nil {
+		return nil, ErrCorruptedBlock{Height: height, Err: fmt.Errorf("decoding block meta: %w", err)}
+	}
+	meta, err := types.BlockMetaFromTrustedProto(pbbm)
+	if err != nil {
+		return nil, ErrCorruptedBlock{Height: height, Err: fmt.Errorf("decoding block meta: %w", err)}
+	}
+	if err := meta.ValidateBasic(); err != nil {
+		return nil, ErrCorruptedBlock{Height: height, Err: fmt.Errorf("invalid block meta: %w", err)}
+	}
+	return meta, nil
+}
+
+// getCommit loads the commit record with the given key, returning
+// ErrCorruptedBlock instead of panicking if it's invalid.
+func (bs *BlockStore) getCommit(height int64, key []byte, field func(e *segment.Entry) []byte) (*types.Commit, error) {
+	bz, err := bs.getRecord(height, key, field)
+	if err != nil {
+		return nil, corruptedOrErr(height, err)
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	pbc := new(cmtproto.Commit)
+	if err := proto.Unmarshal(bz, pbc); err != nil {
+		return nil, ErrCorruptedBlock{Height: height, Err: fmt.Errorf("decoding commit: %w", err)}
+	}
+	commit, err := types.CommitFromProto(pbc)
+	if err != nil {
+		return nil, ErrCorruptedBlock{Height: height, Err: fmt.Errorf("decoding commit: %w", err)}
+	}
+	return commit, nil
+}
+
+// corruptedOrErr returns ErrCorruptedBlock if err is due to a corrupted cold
+// storage segment, or err otherwise.
+func corruptedOrErr(height int64, err error) error {
+	if errors.As(err, &segment.ErrCorruptedSegment{}) {
+		return ErrCorruptedBlock{Height: height, Err: err}
+	}
+	return err
+}
+
+// repairBlock rewrites the records of a block already in the block store:
+// its parts, meta, hash index and the copy of its last commit, and the seen
+// commit if not nil. The records of a block in the cold storage are written
+// to the database, where they take precedence over the cold storage.
+func (bs *BlockStore) repairBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) error {
+	height := block.Height
+	// The repaired records must not be deleted by moving the block to the
+	// cold storage meanwhile.
+	if bs.cold != nil {
+		bs.coldMtx.Lock()
+		defer bs.coldMtx.Unlock()
+	}
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if height < bs.base || height > bs.height {
+		return fmt.Errorf("cannot repair block at height %d, the block store has heights %d to %d", height, bs.base, bs.height)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	for i := 0; i < int(blockParts.Total()); i++ {
+		part := blockParts.GetPart(i)
+		bs.saveBlockPart(height, i, part, batch, true)
+		bs.blockPartCache.Add(blockPartIndex{height, i}, part)
+	}
+	blockMeta := types.NewBlockMeta(block, blockParts)
+	if err := batch.Set(bs.dbKeyLayout.CalcBlockMetaKey(height), mustEncode(blockMeta.ToProto())); err != nil {
+		return ErrDBOpt{Err: err}
+	}
+	if err := batch.Set(bs.dbKeyLayout.CalcBlockHashKey(block.Hash()), []byte(strconv.FormatInt(height, 10))); err != nil {
+		return ErrDBOpt{Err: err}
+	}
+	if height > bs.base {
+		if err := batch.Set(bs.dbKeyLayout.CalcBlockCommitKey(height-1), mustEncode(block.LastCommit.ToProto())); err != nil {
+			return ErrDBOpt{Err: err}
+		}
+		bs.blockCommitCache.Remove(height - 1)
+	}
+	if seenCommit != nil {
+		if err := batch.Set(bs.dbKeyLayout.CalcSeenCommitKey(height), mustEncode(seenCommit.ToProto())); err != nil {
+			return ErrDBOpt{Err: err}
+		}
+		bs.seenCommitCache.Remove(height)
+	}
+	if err := batch.WriteSync(); err != nil {
+		return ErrDBOpt{Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/store/segment"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+type mapFetcher map[int64]*types.Block
+
+func (f mapFetcher) FetchBlock(_ context.Context, height int64) (*types.Block, *types.ExtendedCommit, error) {
+	return f[height], nil, nil
+}
+
+// extCommitFetcher fetches a block with an extended commit.
+type extCommitFetcher struct {
+	block     *types.Block
+	extCommit *types.ExtendedCommit
+}
+
+func (f extCommitFetcher) FetchBlock(context.Context, int64) (*types.Block, *types.ExtendedCommit, error) {
+	return f.block, f.extCommit, nil
+}
+
+// makeVerifiedChain saves the given number of blocks, with commits signed by
+// a single validator, and their validators in the state store.
+func makeVerifiedChain(t *testing.T, db dbm.DB, height int64) (*BlockStore, sm.Store, map[int64]*types.Block) {
+	t.Helper()
+	val, privVal := types.RandValidator(false, 10)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		GenesisTime:     cmttime.Now(),
+		ChainID:         test.DefaultTestChainID,
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+		ConsensusParams: types.DefaultConsensusParams(),
+	})
+	require.NoError(t, err)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	require.NoError(t, stateStore.Save(state))
+
+	bs := NewBlockStore(db)
+	blocks := make(map[int64]*types.Block)
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= height; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 10), lastCommit, nil, state.Validators.Proposer.Address)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		lastCommit, err = test.MakeCommit(blockID, h, 0, state.Validators, []types.PrivValidator{privVal}, state.ChainID, cmttime.Now())
+		require.NoError(t, err)
+		bs.SaveBlock(block, parts, lastCommit)
+		require.NoError(t, stateStore.(sm.HistoricalStore).SaveHistoricalHeight(h, state.Validators, state.ConsensusParams))
+		blocks[h] = block
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+	}
+	return bs, stateStore, blocks
+}
+
+func TestVerifier(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs, stateStore, blocks := makeVerifiedChain(t, db, 10)
+	v := NewVerifier(bs, stateStore, log.TestingLogger())
+
+	res, err := v.Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.From)
+	require.EqualValues(t, 10, res.To)
+	require.Empty(t, res.Corrupted)
+
+	// Delete a block part, garble a block meta and a seen commit.
+	require.NoError(t, db.Delete(bs.dbKeyLayout.CalcBlockPartKey(4, 0)))
+	require.NoError(t, db.Set(bs.dbKeyLayout.CalcBlockMetaKey(7), []byte("garbage")))
+	require.NoError(t, db.Delete(bs.dbKeyLayout.CalcSeenCommitKey(10)))
+
+	res, err = v.Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	heights := make([]int64, 0, len(res.Corrupted))
+	for _, corrupted := range res.Corrupted {
+		heights = append(heights, corrupted.Height)
+	}
+	require.Equal(t, []int64{4, 7, 10}, heights)
+	require.Empty(t, res.Repaired)
+
+	// A block which doesn't match the intact records isn't saved.
+	fetcher := mapFetcher{4: blocks[5], 7: blocks[7], 10: blocks[10]}
+	v = NewVerifier(bs, stateStore, log.TestingLogger(), WithRepair(fetcher))
+	res, err = v.Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	// The seen commit of the last block can't be fetched without vote
+	// extensions.
+	require.Equal(t, []int64{7}, res.Repaired)
+	block, _ := bs.LoadBlock(7)
+	require.Equal(t, blocks[7].Hash(), block.Hash())
+
+	fetcher[4] = blocks[4]
+	res, err = v.Verify(context.Background(), 1, 9)
+	require.NoError(t, err)
+	require.Equal(t, []int64{4}, res.Repaired)
+	block, _ = bs.LoadBlock(4)
+	require.Equal(t, blocks[4].Hash(), block.Hash())
+
+	_, err = v.Verify(context.Background(), 5, 11)
+	require.Error(t, err)
+}
+
+func TestVerifierInvalidCommit(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs, stateStore, _ := makeVerifiedChain(t, db, 5)
+
+	// Replace the validators of height 3 in the state store.
+	val, _ := types.RandValidator(false, 10)
+	params, err := stateStore.LoadConsensusParams(3)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.(sm.HistoricalStore).SaveHistoricalHeight(3, types.NewValidatorSet([]*types.Validator{val}), params))
+
+	res, err := NewVerifier(bs, stateStore, log.TestingLogger()).Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.Len(t, res.Corrupted, 2)
+	// The validators hash of the block doesn't match, and neither does the
+	// last commit of the next block.
+	require.EqualValues(t, 3, res.Corrupted[0].Height)
+	require.EqualValues(t, 4, res.Corrupted[1].Height)
+}
+
+func TestVerifierRepairSeenCommitWithoutValidators(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs, _, blocks := makeVerifiedChain(t, db, 5)
+	// The validators were pruned.
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+
+	meta := bs.LoadBlockMeta(3)
+	_, privVal := types.RandValidator(false, 10)
+	vote, err := types.MakeVote(privVal, test.DefaultTestChainID, 0, 3, 0, types.PrecommitType, meta.BlockID, cmttime.Now())
+	require.NoError(t, err)
+	forged := &types.ExtendedCommit{Height: 3, BlockID: meta.BlockID, ExtendedSignatures: []types.ExtendedCommitSig{vote.ExtendedCommitSig()}}
+
+	// A fetched commit which can't be verified isn't saved.
+	require.NoError(t, db.Delete(bs.dbKeyLayout.CalcBlockPartKey(3, 0)))
+	require.NoError(t, db.Delete(bs.dbKeyLayout.CalcSeenCommitKey(3)))
+	bs.seenCommitCache.Remove(3)
+	v := NewVerifier(bs, stateStore, log.TestingLogger(), WithRepair(extCommitFetcher{block: blocks[3], extCommit: forged}))
+	res, err := v.Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, res.Repaired)
+	require.Nil(t, bs.LoadSeenCommit(3))
+
+	// It is saved if it's the last commit of the next block.
+	lastCommit := blocks[4].LastCommit
+	extCommit := &types.ExtendedCommit{Height: 3, BlockID: meta.BlockID, ExtendedSignatures: []types.ExtendedCommitSig{{CommitSig: lastCommit.Signatures[0]}}}
+	require.NoError(t, db.Delete(bs.dbKeyLayout.CalcBlockPartKey(3, 0)))
+	v = NewVerifier(bs, stateStore, log.TestingLogger(), WithRepair(extCommitFetcher{block: blocks[3], extCommit: extCommit}))
+	res, err = v.Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, res.Repaired)
+	require.Equal(t, lastCommit.Hash(), bs.LoadSeenCommit(3).Hash())
+}
+
+func TestVerifierRepairColdStorage(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs, stateStore, blocks := makeVerifiedChain(t, db, 10)
+
+	// Move the first blocks to the cold storage, garbling a block part of
+	// height 4.
+	dir := t.TempDir()
+	cold, err := segment.Open(dir, 5)
+	require.NoError(t, err)
+	entries := make([]*segment.Entry, 0, 6)
+	for h := int64(1); h <= 6; h++ {
+		e, err := bs.coldEntry(h)
+		require.NoError(t, err)
+		if h == 4 {
+			e.Parts[0] = []byte("garbage")
+		}
+		entries = append(entries, e)
+	}
+	require.NoError(t, cold.Append(entries...))
+	require.NoError(t, bs.deleteMovedRecords(entries))
+	require.NoError(t, bs.Close())
+	bs = NewBlockStore(db, WithColdStorage(cold, 4))
+
+	v := NewVerifier(bs, stateStore, log.TestingLogger(), WithRepair(mapFetcher{4: blocks[4]}))
+	res, err := v.Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.Len(t, res.Corrupted, 1)
+	require.Equal(t, []int64{4}, res.Repaired)
+	block, _ := bs.LoadBlock(4)
+	require.Equal(t, blocks[4].Hash(), block.Hash())
+
+	// The repaired records aren't deleted as duplicates of the cold storage
+	// after a restart.
+	require.NoError(t, bs.Close())
+	cold, err = segment.Open(dir, 5)
+	require.NoError(t, err)
+	bs = NewBlockStore(db, WithColdStorage(cold, 4))
+	defer bs.Close()
+	require.NoError(t, bs.deleteColdDuplicates())
+	block, _ = bs.LoadBlock(4)
+	require.Equal(t, blocks[4].Hash(), block.Hash())
+	res, err = NewVerifier(bs, stateStore, log.TestingLogger()).Verify(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, res.Corrupted)
+}
@@ -13,6 +13,7 @@ import (
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	"github.com/cometbft/cometbft/v2/crypto/merkle"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	"github.com/cometbft/cometbft/v2/internal/bits"
@@ -658,6 +659,12 @@ func (cs CommitSig) BlockID(commitBlockID BlockID) BlockID {
 
 // ValidateBasic performs basic validation.
 func (cs CommitSig) ValidateBasic() error {
+	return cs.validateBasic(false)
+}
+
+// validateBasic performs basic validation. The CommitSigs of an aggregated
+// commit carry no signature.
+func (cs CommitSig) validateBasic(aggregated bool) error {
 	switch cs.BlockIDFlag {
 	case BlockIDFlagAbsent:
 	case BlockIDFlagCommit:
@@ -685,6 +692,12 @@ func (cs CommitSig) ValidateBasic() error {
 			)
 		}
 		// NOTE: Timestamp validation is subtle and handled elsewhere.
+		if aggregated {
+			if len(cs.Signature) != 0 {
+				return errors.New("signature is present in aggregated commit")
+			}
+			break
+		}
 		if len(cs.Signature) == 0 {
 			return errors.New("signature is missing")
 		}
@@ -713,12 +726,15 @@ func (cs *CommitSig) ToProto() *cmtproto.CommitSig {
 // FromProto sets a protobuf CommitSig to the given pointer.
 // It returns an error if the CommitSig is invalid.
 func (cs *CommitSig) FromProto(csp cmtproto.CommitSig) error {
+	cs.fromProto(csp)
+	return cs.ValidateBasic()
+}
+
+func (cs *CommitSig) fromProto(csp cmtproto.CommitSig) {
 	cs.BlockIDFlag = BlockIDFlag(csp.BlockIdFlag)
 	cs.ValidatorAddress = csp.ValidatorAddress
 	cs.Timestamp = csp.Timestamp
 	cs.Signature = csp.Signature
-
-	return cs.ValidateBasic()
 }
 
 // -------------------------------------
@@ -875,6 +891,11 @@ type Commit struct {
 	BlockID    BlockID     `json:"block_id"`
 	Signatures []CommitSig `json:"signatures"`
 
+	// AggregatedSignature, if not empty, is a BLS12-381 signature aggregating
+	// the signatures of all the CommitSigs that are not absent, which then
+	// carry no signature of their own. See Aggregate.
+	AggregatedSignature []byte `json:"aggregated_signature,omitempty"`
+
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
 	// unmarshaling.
@@ -949,8 +970,15 @@ func (commit *Commit) ValidateBasic() error {
 		if len(commit.Signatures) == 0 {
 			return errors.New("no signatures in commit")
 		}
+		aggregated := commit.IsAggregated()
+		if aggregated && len(commit.AggregatedSignature) != bls12381.SignatureLength {
+			return fmt.Errorf("expected AggregatedSignature size to be %d bytes, got %d bytes",
+				bls12381.SignatureLength,
+				len(commit.AggregatedSignature),
+			)
+		}
 		for i, commitSig := range commit.Signatures {
-			if err := commitSig.ValidateBasic(); err != nil {
+			if err := commitSig.validateBasic(aggregated); err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %w", i, err)
 			}
 		}
@@ -958,6 +986,62 @@ func (commit *Commit) ValidateBasic() error {
 	return nil
 }
 
+// IsAggregated returns true if the signatures of the commit are aggregated
+// into its AggregatedSignature.
+func (commit *Commit) IsAggregated() bool {
+	return len(commit.AggregatedSignature) != 0
+}
+
+// Aggregate returns a copy of the commit with the signatures of its votes, and
+// its AggregatedSignature if any, aggregated into a single BLS12-381 signature.
+// The AggregatedSignature of the commit, if any, must cover exactly its votes
+// that are not absent and carry no signature.
+//
+// A vote whose sign bytes are identical to those of another vote is marked
+// absent, as an aggregated signature over duplicate messages would be open to
+// rogue key attacks. The caller must ensure all validators have BLS12-381
+// keys.
+func (commit *Commit) Aggregate(chainID string) (*Commit, error) {
+	var (
+		aggregated = commit.Clone()
+		sigs       = make([][]byte, 0, len(commit.Signatures)+1)
+		msgs       = make(map[string]struct{}, len(commit.Signatures))
+	)
+	aggregated.hash = nil
+	if commit.IsAggregated() {
+		sigs = append(sigs, commit.AggregatedSignature)
+	}
+
+	// The votes covered by AggregatedSignature come first, as they cannot be
+	// left out.
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag != BlockIDFlagAbsent && len(commitSig.Signature) == 0 {
+			msgs[string(commit.VoteSignBytes(chainID, int32(idx)))] = struct{}{}
+		}
+	}
+	for idx := range aggregated.Signatures {
+		commitSig := &aggregated.Signatures[idx]
+		if commitSig.BlockIDFlag == BlockIDFlagAbsent || len(commitSig.Signature) == 0 {
+			continue
+		}
+		msg := string(commit.VoteSignBytes(chainID, int32(idx)))
+		if _, ok := msgs[msg]; ok {
+			*commitSig = NewCommitSigAbsent()
+			continue
+		}
+		msgs[msg] = struct{}{}
+		sigs = append(sigs, commitSig.Signature)
+		commitSig.Signature = nil
+	}
+
+	aggSig, err := bls12381.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating signatures of commit: %w", err)
+	}
+	aggregated.AggregatedSignature = aggSig
+	return aggregated, nil
+}
+
 // MedianTime computes the median time for a Commit based on the associated validator set.
 // The median time is the weighted median of the Timestamp fields of the commit votes,
 // with heights defined by the validator's voting powers.
@@ -1000,6 +1084,11 @@ func (commit *Commit) Hash() cmtbytes.HexBytes {
 
 			bs[i] = bz
 		}
+		// The aggregated signature comes last, so that the hash of commits
+		// which are not aggregated is unchanged.
+		if commit.IsAggregated() {
+			bs = append(bs, commit.AggregatedSignature)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -1065,6 +1154,7 @@ func (commit *Commit) ToProto() *cmtproto.Commit {
 	c.Height = commit.Height
 	c.Round = commit.Round
 	c.BlockID = commit.BlockID.ToProto()
+	c.AggregatedSignature = commit.AggregatedSignature
 
 	return c
 }
@@ -1083,17 +1173,18 @@ func CommitFromProto(cp *cmtproto.Commit) (*Commit, error) {
 		return nil, err
 	}
 
+	// The CommitSigs are validated by commit.ValidateBasic, depending on
+	// whether the commit is aggregated.
 	sigs := make([]CommitSig, len(cp.Signatures))
 	for i := range cp.Signatures {
-		if err := sigs[i].FromProto(cp.Signatures[i]); err != nil {
-			return nil, err
-		}
+		sigs[i].fromProto(cp.Signatures[i])
 	}
 	commit.Signatures = sigs
 
 	commit.Height = cp.Height
 	commit.Round = cp.Round
 	commit.BlockID = *bi
+	commit.AggregatedSignature = cp.AggregatedSignature
 
 	return commit, commit.ValidateBasic()
 }
@@ -1148,24 +1239,40 @@ func (ec *ExtendedCommit) addSigsToVoteSet(voteSet *VoteSet) {
 }
 
 // ToVoteSet constructs a VoteSet from the Commit and validator set.
-// Panics if signatures from the commit can't be added to the voteset.
+// Returns an error if signatures from the commit can't be added to the voteset.
 // Inverse of VoteSet.MakeCommit().
-func (commit *Commit) ToVoteSet(chainID string, vals *ValidatorSet) *VoteSet {
+//
+// The votes of an aggregated commit carry no signature: they are verified all
+// at once, and cannot be sent to peers.
+func (commit *Commit) ToVoteSet(chainID string, vals *ValidatorSet) (*VoteSet, error) {
 	voteSet := NewVoteSet(chainID, commit.Height, commit.Round, PrecommitType, vals)
+	if commit.IsAggregated() {
+		if err := VerifyCommit(chainID, vals, commit.BlockID, commit.Height, commit); err != nil {
+			return nil, fmt.Errorf("failed to reconstruct vote set from aggregated commit: %w", err)
+		}
+	}
 	for idx, cs := range commit.Signatures {
 		if cs.BlockIDFlag == BlockIDFlagAbsent {
 			continue // OK, some precommits can be missing.
 		}
 		vote := commit.GetVote(int32(idx))
+		if commit.IsAggregated() {
+			// voteSet is not shared yet, so it need not be locked.
+			voteSet.addVerifiedVote(vote, vote.BlockID.Key(), vals.Validators[idx].VotingPower)
+			continue
+		}
 		if err := vote.ValidateBasic(); err != nil {
-			panic(fmt.Errorf("failed to validate vote reconstructed from commit: %w", err))
+			return nil, fmt.Errorf("failed to validate vote reconstructed from commit: %w", err)
 		}
 		added, err := voteSet.AddVote(vote)
-		if !added || err != nil {
-			panic(fmt.Errorf("failed to reconstruct vote set from commit: %w", err))
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconstruct vote set from commit: %w", err)
+		}
+		if !added {
+			return nil, fmt.Errorf("failed to reconstruct vote set from commit: vote #%d not added", idx)
 		}
 	}
-	return voteSet
+	return voteSet, nil
 }
 
 // EnsureExtensions validates that a vote extensions signature is present for
@@ -15,6 +15,7 @@ import (
 
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	"github.com/cometbft/cometbft/v2/crypto/merkle"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	"github.com/cometbft/cometbft/v2/internal/bits"
@@ -978,3 +979,156 @@ func TestBlockIDEquals(t *testing.T) {
 	assert.True(t, blockIDEmpty.Equals(blockIDEmpty))
 	assert.False(t, blockIDEmpty.Equals(blockIDDifferent))
 }
+
+// makeBLSCommit makes a commit for blockID at height, signed by numValidators
+// validators with BLS12-381 keys, the first numNil of which vote for nil.
+func makeBLSCommit(
+	t *testing.T,
+	height int64,
+	blockID BlockID,
+	numValidators, numNil int,
+) (*Commit, *ValidatorSet, []PrivValidator) {
+	t.Helper()
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	vals := make([]*Validator, numValidators)
+	privKeys := make(map[string]crypto.PrivKey, numValidators)
+	for i := range vals {
+		privKey, err := bls12381.GenPrivKey()
+		require.NoError(t, err)
+		vals[i] = NewValidator(privKey.PubKey(), 1)
+		privKeys[string(vals[i].Address)] = privKey
+	}
+	valSet := NewValidatorSet(vals)
+
+	// The private validators are in the order of valSet.
+	privVals := make([]PrivValidator, numValidators)
+	for i, val := range valSet.Validators {
+		privVals[i] = NewMockPVWithParams(privKeys[string(val.Address)], false, false)
+	}
+
+	voteSet := NewVoteSet("test_chain_id", height, 0, PrecommitType, valSet)
+	now := cmttime.Now()
+	for i := range privVals {
+		voteBlockID := blockID
+		if i < numNil {
+			voteBlockID = BlockID{}
+		}
+		vote := &Vote{
+			ValidatorAddress: valSet.Validators[i].Address,
+			ValidatorIndex:   int32(i),
+			Height:           height,
+			Round:            0,
+			Type:             PrecommitType,
+			BlockID:          voteBlockID,
+			Timestamp:        now.Add(time.Duration(i) * time.Millisecond),
+		}
+		added, err := signAddVote(privVals[i], vote, voteSet)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	return voteSet.MakeExtendedCommit(DefaultFeatureParams()).ToCommit(), valSet, privVals
+}
+
+func TestCommitAggregate(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	commit, valSet, _ := makeBLSCommit(t, 3, blockID, 10, 2)
+
+	aggregated, err := commit.Aggregate("test_chain_id")
+	require.NoError(t, err)
+	require.True(t, aggregated.IsAggregated())
+	require.False(t, commit.IsAggregated())
+	require.NoError(t, aggregated.ValidateBasic())
+	for i, commitSig := range aggregated.Signatures {
+		assert.Empty(t, commitSig.Signature, i)
+		assert.Equal(t, commit.Signatures[i].BlockIDFlag, commitSig.BlockIDFlag, i)
+	}
+	assert.NotEqual(t, commit.Hash(), aggregated.Hash())
+	assert.Less(t, aggregated.ToProto().Size(), commit.ToProto().Size())
+
+	pb := aggregated.ToProto()
+	aggregated2, err := CommitFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, aggregated.Hash(), aggregated2.Hash())
+	require.NoError(t, valSet.VerifyCommit("test_chain_id", blockID, 3, aggregated2))
+
+	// The signatures of further votes can be aggregated with those of an
+	// aggregated commit.
+	partial := commit.Clone()
+	partial.Signatures[4] = NewCommitSigAbsent()
+	partial.Signatures[7] = NewCommitSigAbsent()
+	partial, err = partial.Aggregate("test_chain_id")
+	require.NoError(t, err)
+	partial.Signatures[4] = commit.Signatures[4]
+	partial.Signatures[7] = commit.Signatures[7]
+	merged, err := partial.Aggregate("test_chain_id")
+	require.NoError(t, err)
+	assert.Equal(t, aggregated.AggregatedSignature, merged.AggregatedSignature)
+	assert.Equal(t, aggregated.Signatures, merged.Signatures)
+}
+
+func TestCommitAggregate_DuplicateSignBytes(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	commit, valSet, privVals := makeBLSCommit(t, 3, blockID, 4, 0)
+
+	// Validator 1 signs the same vote as validator 0.
+	vote := commit.GetVote(1)
+	vote.Timestamp = commit.Signatures[0].Timestamp
+	v := vote.ToProto()
+	require.NoError(t, privVals[1].SignVote("test_chain_id", v, false))
+	commit.Signatures[1].Timestamp = vote.Timestamp
+	commit.Signatures[1].Signature = v.Signature
+	require.NoError(t, valSet.VerifyCommit("test_chain_id", blockID, 3, commit))
+
+	aggregated, err := commit.Aggregate("test_chain_id")
+	require.NoError(t, err)
+	assert.Equal(t, BlockIDFlagCommit, aggregated.Signatures[0].BlockIDFlag)
+	assert.Equal(t, BlockIDFlagAbsent, aggregated.Signatures[1].BlockIDFlag)
+	require.NoError(t, valSet.VerifyCommit("test_chain_id", blockID, 3, aggregated))
+}
+
+func TestAggregatedCommitToVoteSet(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	commit, valSet, _ := makeBLSCommit(t, 3, blockID, 10, 2)
+	aggregated, err := commit.Aggregate("test_chain_id")
+	require.NoError(t, err)
+
+	voteSet, err := aggregated.ToVoteSet("test_chain_id", valSet)
+	require.NoError(t, err)
+	assert.True(t, voteSet.HasTwoThirdsMajority())
+	assert.True(t, voteSet.HasAll())
+
+	// The signed votes are duplicates of those of the aggregated commit.
+	added, err := voteSet.AddVote(commit.GetVote(5))
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	aggregated.AggregatedSignature = commit.Signatures[2].Signature
+	_, err = aggregated.ToVoteSet("test_chain_id", valSet)
+	require.Error(t, err)
+}
+
+func TestAggregatedCommitValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		malleateCommit func(*Commit)
+		expectErr      bool
+	}{
+		{"Aggregated Commit", func(_ *Commit) {}, false},
+		{"Signature present", func(com *Commit) { com.Signatures[0].Signature = []byte{0} }, true},
+		{"Incorrect aggregated signature size", func(com *Commit) { com.AggregatedSignature = []byte{0} }, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			com := randCommit(cmttime.Now())
+			for i := range com.Signatures {
+				com.Signatures[i].Signature = nil
+			}
+			com.AggregatedSignature = cmtrand.Bytes(bls12381.SignatureLength)
+			tc.malleateCommit(com)
+			assert.Equal(t, tc.expectErr, com.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
@@ -103,14 +103,18 @@ func (b *EventBus) UnsubscribeAll(ctx context.Context, subscriber string) error
 func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
-	return b.pubsub.PublishWithEvents(ctx, eventData, map[string][]string{EventTypeKey: {eventType}})
+	return b.pubsub.PublishWithEvents(ctx, eventData, typeEvents(eventType))
+}
+
+func typeEvents(eventType string) map[string][]string {
+	return map[string][]string{EventTypeKey: {eventType}}
 }
 
 // validateAndStringifyEvents takes a slice of event objects and creates a
 // map of stringified events where each key is composed of the event
 // type and each of the event's attributes keys in the form of
 // "{event.Type}.{attribute.Key}" and the value is each attribute's value.
-func (*EventBus) validateAndStringifyEvents(events []types.Event) map[string][]string {
+func validateAndStringifyEvents(events []types.Event) map[string][]string {
 	result := make(map[string][]string)
 	for _, event := range events {
 		if len(event.Type) == 0 {
@@ -133,24 +137,31 @@ func (*EventBus) validateAndStringifyEvents(events []types.Event) map[string][]s
 func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
-	events := b.validateAndStringifyEvents(data.ResultFinalizeBlock.Events)
+	return b.pubsub.PublishWithEvents(ctx, data, newBlockEvents(data))
+}
+
+func newBlockEvents(data EventDataNewBlock) map[string][]string {
+	events := validateAndStringifyEvents(data.ResultFinalizeBlock.Events)
 
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlock)
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return events
 }
 
 func (b *EventBus) PublishEventNewBlockEvents(data EventDataNewBlockEvents) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
+	return b.pubsub.PublishWithEvents(ctx, data, newBlockEventsEvents(data))
+}
 
-	events := b.validateAndStringifyEvents(data.Events)
+func newBlockEventsEvents(data EventDataNewBlockEvents) map[string][]string {
+	events := validateAndStringifyEvents(data.Events)
 
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlockEvents)
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return events
 }
 
 func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) error {
@@ -172,10 +183,14 @@ func (b *EventBus) PublishEventValidBlock(data EventDataRoundState) error {
 func (b *EventBus) PublishEventPendingTx(data EventDataPendingTx) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
-	return b.pubsub.PublishWithEvents(ctx, data, map[string][]string{
+	return b.pubsub.PublishWithEvents(ctx, data, pendingTxEvents(data))
+}
+
+func pendingTxEvents(data EventDataPendingTx) map[string][]string {
+	return map[string][]string{
 		EventTypeKey: {EventPendingTx},
 		TxHashKey:    {fmt.Sprintf("%X", Tx(data.Tx).Hash())},
-	})
+	}
 }
 
 // PublishEventTx publishes tx event with events from Result. Note it will add
@@ -184,15 +199,18 @@ func (b *EventBus) PublishEventPendingTx(data EventDataPendingTx) error {
 func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
+	return b.pubsub.PublishWithEvents(ctx, data, txEvents(data))
+}
 
-	events := b.validateAndStringifyEvents(data.Result.Events)
+func txEvents(data EventDataTx) map[string][]string {
+	events := validateAndStringifyEvents(data.Result.Events)
 
 	// add predefined compositeKeys
 	events[EventTypeKey] = append(events[EventTypeKey], EventTx)
 	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
 	events[TxHeightKey] = append(events[TxHeightKey], strconv.FormatInt(data.Height, 10))
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return events
 }
 
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
@@ -314,3 +332,47 @@ func (NopEventBus) PublishEventLock(EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+// -----------------------------------------------------------------------------
+
+// EventRecorder is a BlockEventPublisher which records the events published to
+// it, along with the composite keys an EventBus would match queries against,
+// instead of publishing them. It's used to replay the events of stored blocks.
+type EventRecorder struct {
+	Messages []cmtpubsub.Message
+}
+
+var _ BlockEventPublisher = (*EventRecorder)(nil)
+
+func (r *EventRecorder) record(data TMEventData, events map[string][]string) error {
+	r.Messages = append(r.Messages, cmtpubsub.NewMessage(data, events))
+	return nil
+}
+
+func (r *EventRecorder) PublishEventNewBlock(data EventDataNewBlock) error {
+	return r.record(data, newBlockEvents(data))
+}
+
+func (r *EventRecorder) PublishEventNewBlockHeader(data EventDataNewBlockHeader) error {
+	return r.record(data, typeEvents(EventNewBlockHeader))
+}
+
+func (r *EventRecorder) PublishEventNewBlockEvents(data EventDataNewBlockEvents) error {
+	return r.record(data, newBlockEventsEvents(data))
+}
+
+func (r *EventRecorder) PublishEventNewEvidence(data EventDataNewEvidence) error {
+	return r.record(data, typeEvents(EventNewEvidence))
+}
+
+func (r *EventRecorder) PublishEventPendingTx(data EventDataPendingTx) error {
+	return r.record(data, pendingTxEvents(data))
+}
+
+func (r *EventRecorder) PublishEventTx(data EventDataTx) error {
+	return r.record(data, txEvents(data))
+}
+
+func (r *EventRecorder) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
+	return r.record(data, typeEvents(EventValidatorSetUpdates))
+}
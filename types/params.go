@@ -98,8 +98,9 @@ type VersionParams struct {
 // A value of 0 means the feature is disabled. A value > 0 denotes
 // the height at which the feature will be (or has been) enabled.
 type FeatureParams struct {
-	VoteExtensionsEnableHeight int64 `json:"vote_extensions_enable_height"`
-	PbtsEnableHeight           int64 `json:"pbts_enable_height"`
+	VoteExtensionsEnableHeight      int64 `json:"vote_extensions_enable_height"`
+	PbtsEnableHeight                int64 `json:"pbts_enable_height"`
+	BlsAggregateCommitsEnableHeight int64 `json:"bls_aggregate_commits_enable_height"`
 }
 
 // VoteExtensionsEnabled returns true if vote extensions are enabled at height h
@@ -117,6 +118,15 @@ func (p FeatureParams) PbtsEnabled(h int64) bool {
 	return featureEnabled(enabledHeight, h, "PBTS")
 }
 
+// BlsAggregateCommitsEnabled returns true if the last commit of a block at
+// height h may be aggregated into a single BLS12-381 signature, and false
+// otherwise.
+func (p FeatureParams) BlsAggregateCommitsEnabled(h int64) bool {
+	enabledHeight := p.BlsAggregateCommitsEnableHeight
+
+	return featureEnabled(enabledHeight, h, "BLS Aggregate Commits")
+}
+
 // featureEnabled returns true if `enabledHeight` points to a height that is smaller than `currentHeight“.
 func featureEnabled(enableHeight int64, currentHeight int64, f string) bool {
 	if currentHeight < 1 {
@@ -217,8 +227,9 @@ func DefaultVersionParams() VersionParams {
 // Disabled by default.
 func DefaultFeatureParams() FeatureParams {
 	return FeatureParams{
-		VoteExtensionsEnableHeight: 0,
-		PbtsEnableHeight:           0,
+		VoteExtensionsEnableHeight:      0,
+		PbtsEnableHeight:                0,
+		BlsAggregateCommitsEnableHeight: 0,
 	}
 }
 
@@ -296,6 +307,10 @@ func (params ConsensusParams) ValidateBasic() error {
 		return fmt.Errorf("Feature.PbtsEnableHeight cannot be negative. Got: %d", params.Feature.PbtsEnableHeight)
 	}
 
+	if params.Feature.BlsAggregateCommitsEnableHeight < 0 {
+		return fmt.Errorf("Feature.BlsAggregateCommitsEnableHeight cannot be negative. Got: %d", params.Feature.BlsAggregateCommitsEnableHeight)
+	}
+
 	// Synchrony params are only relevant when PBTS is enabled
 	if params.Feature.PbtsEnableHeight > 0 {
 		if params.Synchrony.MessageDelay <= 0 {
@@ -371,6 +386,13 @@ func validateUpdateFeatures(params FeatureParams, updated cmtproto.FeatureParams
 			return err
 		}
 	}
+
+	if updated.BlsAggregateCommitsEnableHeight != nil {
+		err := validateUpdateFeatureEnableHeight(params.BlsAggregateCommitsEnableHeight, updated.BlsAggregateCommitsEnableHeight.Value, h, "BLS Aggregate Commits")
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -477,6 +499,10 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 		if params2.Feature.PbtsEnableHeight != nil {
 			res.Feature.PbtsEnableHeight = params2.Feature.GetPbtsEnableHeight().Value
 		}
+
+		if params2.Feature.BlsAggregateCommitsEnableHeight != nil {
+			res.Feature.BlsAggregateCommitsEnableHeight = params2.Feature.GetBlsAggregateCommitsEnableHeight().Value
+		}
 	}
 	if params2.Synchrony != nil {
 		if params2.Synchrony.MessageDelay != nil {
@@ -508,8 +534,9 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 			App: params.Version.App,
 		},
 		Feature: &cmtproto.FeatureParams{
-			PbtsEnableHeight:           &gogo.Int64Value{Value: params.Feature.PbtsEnableHeight},
-			VoteExtensionsEnableHeight: &gogo.Int64Value{Value: params.Feature.VoteExtensionsEnableHeight},
+			PbtsEnableHeight:                &gogo.Int64Value{Value: params.Feature.PbtsEnableHeight},
+			VoteExtensionsEnableHeight:      &gogo.Int64Value{Value: params.Feature.VoteExtensionsEnableHeight},
+			BlsAggregateCommitsEnableHeight: &gogo.Int64Value{Value: params.Feature.BlsAggregateCommitsEnableHeight},
 		},
 		Synchrony: &cmtproto.SynchronyParams{
 			MessageDelay: &params.Synchrony.MessageDelay,
@@ -536,8 +563,9 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 			App: pbParams.Version.App,
 		},
 		Feature: FeatureParams{
-			VoteExtensionsEnableHeight: pbParams.GetFeature().GetVoteExtensionsEnableHeight().GetValue(),
-			PbtsEnableHeight:           pbParams.GetFeature().GetPbtsEnableHeight().GetValue(),
+			VoteExtensionsEnableHeight:      pbParams.GetFeature().GetVoteExtensionsEnableHeight().GetValue(),
+			PbtsEnableHeight:                pbParams.GetFeature().GetPbtsEnableHeight().GetValue(),
+			BlsAggregateCommitsEnableHeight: pbParams.GetFeature().GetBlsAggregateCommitsEnableHeight().GetValue(),
 		},
 	}
 	if pbParams.GetSynchrony().GetMessageDelay() != nil {
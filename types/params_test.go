@@ -28,6 +28,7 @@ type makeParamsArgs struct {
 	pubkeyTypes         []string
 	voteExtensionHeight int64
 	pbtsHeight          int64
+	blsAggregateHeight  int64
 	precision           time.Duration
 	messageDelay        time.Duration
 }
@@ -51,8 +52,9 @@ func makeParams(args makeParamsArgs) ConsensusParams {
 			MessageDelay: args.messageDelay,
 		},
 		Feature: FeatureParams{
-			VoteExtensionsEnableHeight: args.voteExtensionHeight,
-			PbtsEnableHeight:           args.pbtsHeight,
+			VoteExtensionsEnableHeight:      args.voteExtensionHeight,
+			PbtsEnableHeight:                args.pbtsHeight,
+			BlsAggregateCommitsEnableHeight: args.blsAggregateHeight,
 		},
 	}
 }
@@ -376,6 +378,27 @@ func TestConsensusParamsValidation(t *testing.T) {
 				}),
 			valid: true,
 		},
+		// BLS aggregate commits enable height
+		{
+			name: "bls aggregate commits height -1",
+			params: makeParams(
+				makeParamsArgs{
+					blockBytes:         1,
+					evidenceAge:        2,
+					blsAggregateHeight: -1,
+				}),
+			valid: false,
+		},
+		{
+			name: "bls aggregate commits from height 100",
+			params: makeParams(
+				makeParamsArgs{
+					blockBytes:         1,
+					evidenceAge:        2,
+					blsAggregateHeight: 100,
+				}),
+			valid: true,
+		},
 	}
 	for _, tc := range testCases {
 		if tc.params.Validator.PubKeyTypes == nil {
@@ -679,6 +702,28 @@ func TestConsensusParamsUpdate_EnableHeight(t *testing.T) {
 		})
 	}
 
+	// Test BLS aggregate commits enabling
+	for _, tc := range testCases {
+		t.Run(tc.name+" BLS aggregate commits", func(*testing.T) {
+			initialParams := makeParams(makeParamsArgs{
+				blsAggregateHeight: tc.from,
+			})
+			update := &cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{}}
+			if tc.to == nilTest {
+				update.Feature.BlsAggregateCommitsEnableHeight = nil
+			} else {
+				update.Feature = &cmtproto.FeatureParams{
+					BlsAggregateCommitsEnableHeight: &types.Int64Value{Value: tc.to},
+				}
+			}
+			if tc.expectedErr {
+				require.Error(t, initialParams.ValidateUpdate(update, tc.current))
+			} else {
+				require.NoError(t, initialParams.ValidateUpdate(update, tc.current))
+			}
+		})
+	}
+
 	// Test PBTS and VE enabling
 	for _, tc := range testCases {
 		t.Run(tc.name+"VE PBTS", func(*testing.T) {
@@ -727,6 +772,7 @@ func consensusParamsForTestProto() []ConsensusParams {
 		makeParams(makeParamsArgs{pbtsHeight: 100}),
 		makeParams(makeParamsArgs{voteExtensionHeight: 100, pbtsHeight: 42}),
 		makeParams(makeParamsArgs{pbtsHeight: 100}),
+		makeParams(makeParamsArgs{blsAggregateHeight: 100}),
 	}
 }
 
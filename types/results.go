@@ -29,6 +29,30 @@ func (a ABCIResults) ProveResult(i int) merkle.Proof {
 	return *proofs[i]
 }
 
+// ProveResults returns a merkle proof of each result from the set.
+func (a ABCIResults) ProveResults() []merkle.Proof {
+	_, proofs := merkle.ProofsFromByteSlices(a.toByteSlices())
+	res := make([]merkle.Proof, len(proofs))
+	for i, proof := range proofs {
+		res[i] = *proof
+	}
+	return res
+}
+
+// VerifyResult verifies that proof proves result to be part of a set of
+// results whose merkle hash is rootHash, the LastResultsHash of the header
+// following the block of the result.
+//
+// Only the deterministic fields of the result are hashed: its code, data and
+// gas. Its log, info and events are not provable.
+func VerifyResult(rootHash []byte, result *abci.ExecTxResult, proof merkle.Proof) error {
+	bz, err := abci.DeterministicExecTxResult(result).Marshal()
+	if err != nil {
+		return err
+	}
+	return proof.Verify(rootHash, bz)
+}
+
 func (a ABCIResults) toByteSlices() [][]byte {
 	l := len(a)
 	bzs := make([][]byte, l)
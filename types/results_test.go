@@ -52,3 +52,27 @@ func TestABCIResults(t *testing.T) {
 		require.NoError(t, valid, "%d", i)
 	}
 }
+
+func TestABCIResults_ProveResults(t *testing.T) {
+	results := NewResults([]*abci.ExecTxResult{
+		{Code: 0, Data: []byte("one"), Log: "ok", Events: []abci.Event{{Type: "transfer"}}},
+		{Code: 14, Data: []byte("foo"), GasUsed: 10},
+		{Code: 0, GasWanted: 5},
+	})
+	root := results.Hash()
+
+	proofs := results.ProveResults()
+	require.Len(t, proofs, len(results))
+	for i, proof := range proofs {
+		assert.Equal(t, results.ProveResult(i), proof)
+		require.NoError(t, VerifyResult(root, results[i], proof), "%d", i)
+	}
+
+	// Only the deterministic fields are proven.
+	res := &abci.ExecTxResult{Code: 0, Data: []byte("one"), Log: "other log"}
+	require.NoError(t, VerifyResult(root, res, proofs[0]))
+
+	res = &abci.ExecTxResult{Code: 1, Data: []byte("one")}
+	require.Error(t, VerifyResult(root, res, proofs[0]))
+	require.Error(t, VerifyResult(root, results[1], proofs[0]))
+}
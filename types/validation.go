@@ -7,6 +7,7 @@ import (
 
 	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/crypto/batch"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
 	cmterrors "github.com/cometbft/cometbft/v2/types/errors"
@@ -15,9 +16,28 @@ import (
 const batchVerifyThreshold = 2
 
 func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
-	return len(commit.Signatures) >= batchVerifyThreshold &&
-		batch.SupportsBatchVerifier(vals.GetProposer().PubKey) &&
-		vals.AllKeysHaveSameType()
+	if len(commit.Signatures) < batchVerifyThreshold {
+		return false
+	}
+	if vals.AllKeysHaveSameType() {
+		return batch.SupportsBatchVerifier(vals.GetProposer().PubKey)
+	}
+	// a mixed-key set is batch verified if every key type supports it, the
+	// signatures being grouped per key type.
+	for _, val := range vals.Validators {
+		if !batch.SupportsBatchVerifier(val.PubKey) {
+			return false
+		}
+	}
+	return true
+}
+
+// newBatchVerifier returns a batch verifier for the keys of vals.
+func newBatchVerifier(vals *ValidatorSet) (crypto.BatchVerifier, bool) {
+	if vals.AllKeysHaveSameType() {
+		return batch.CreateBatchVerifier(vals.GetProposer().PubKey)
+	}
+	return batch.NewBatchVerifier(), true
 }
 
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
@@ -45,6 +65,12 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	// only count the signatures that are for the block
 	count := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagCommit }
 
+	// an aggregated commit is verified all at once
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -126,6 +152,12 @@ func verifyCommitLightInternal(
 	// count all the remaining signatures
 	count := func(_ CommitSig) bool { return true }
 
+	// an aggregated commit is verified all at once
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -229,6 +261,14 @@ func verifyCommitLightTrustingInternal(
 	// count all the remaining signatures
 	count := func(_ CommitSig) bool { return true }
 
+	// an aggregated commit is verified all at once. As the validator set
+	// doesn't necessarily correspond with the validator set that signed the
+	// block we need to look up by address rather than index.
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, false)
+	}
+
 	// attempt to batch verify commit. As the validator set doesn't necessarily
 	// correspond with the validator set that signed the block we need to look
 	// up by address rather than index.
@@ -284,7 +324,7 @@ func verifyCommitBatch(
 	// attempt to create a batch verifier
 	bv, ok := batchVerifier, true
 	if batchVerifier == nil {
-		bv, ok = batch.CreateBatchVerifier(vals.GetProposer().PubKey)
+		bv, ok = newBatchVerifier(vals)
 	}
 	// re-check if batch verification is supported
 	if !ok || len(commit.Signatures) < batchVerifyThreshold {
@@ -503,6 +543,85 @@ func verifyCommitSingle(
 	return nil
 }
 
+// verifyCommitAggregated verifies the aggregated signature of a commit, which
+// covers all its votes that are not absent, whatever ignoreSig says. Only the
+// voting power of the votes that are neither ignored nor uncounted is tallied.
+//
+// If a vote is from a validator which is not in vals, the aggregated signature
+// cannot be verified, and no voting power is deemed to have signed.
+func verifyCommitAggregated(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+	lookUpByIndex bool,
+) error {
+	var (
+		val                *Validator
+		valIdx             int32
+		seenVals           = make(map[int32]int, len(commit.Signatures))
+		pubKeys            = make([]crypto.PubKey, 0, len(commit.Signatures))
+		msgs               = make([][]byte, 0, len(commit.Signatures))
+		talliedVotingPower int64
+	)
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag == BlockIDFlagAbsent {
+			continue
+		}
+
+		// If the vals and commit have a 1-to-1 correspondence we can retrieve
+		// them by index else we need to retrieve them by address
+		if lookUpByIndex {
+			val = vals.Validators[idx]
+		} else {
+			valIdx, val = vals.GetByAddress(commitSig.ValidatorAddress)
+
+			// the aggregated signature can't be verified without the keys of
+			// all the signers
+			if val == nil {
+				return ErrNotEnoughVotingPowerSigned{Got: 0, Needed: votingPowerNeeded}
+			}
+
+			// because we are getting validators by address we need to make sure
+			// that the same validator doesn't commit twice
+			if firstIndex, ok := seenVals[valIdx]; ok {
+				secondIndex := idx
+				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+			}
+			seenVals[valIdx] = idx
+		}
+
+		if val.PubKey == nil {
+			return fmt.Errorf("validator %v has a nil PubKey at index %d", val, idx)
+		}
+		if val.PubKey.Type() != bls12381.KeyType {
+			return fmt.Errorf("validator %v at index %d has a %s key, which can't sign an aggregated commit",
+				val, idx, val.PubKey.Type())
+		}
+
+		pubKeys = append(pubKeys, val.PubKey)
+		msgs = append(msgs, commit.VoteSignBytes(chainID, int32(idx)))
+
+		// If this signature counts then add the voting power of the validator
+		// to the tally
+		if !ignoreSig(commitSig) && countSig(commitSig) {
+			talliedVotingPower += val.VotingPower
+		}
+	}
+
+	if !bls12381.VerifyAggregateSignature(pubKeys, msgs, commit.AggregatedSignature) {
+		return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	return nil
+}
+
 func verifyBasicValsAndCommit(vals *ValidatorSet, commit *Commit, height int64, blockID BlockID) error {
 	if vals == nil {
 		return errors.New("nil validator set")
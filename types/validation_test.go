@@ -3,11 +3,15 @@ package types
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cryptomocks "github.com/cometbft/cometbft/v2/crypto/mocks"
+	"github.com/cometbft/cometbft/v2/crypto/secp256k1"
 	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
 	cmttime "github.com/cometbft/cometbft/v2/types/time"
 )
@@ -565,3 +569,91 @@ func TestValidation_verifyCommitSingle_UsesCache(t *testing.T) {
 	mockValPubkeys[3].AssertNotCalled(t, "VerifySignature")
 	mockValPubkeys[4].AssertNotCalled(t, "VerifySignature")
 }
+
+func TestValidatorSet_VerifyCommit_Aggregated(t *testing.T) {
+	const chainID = "test_chain_id"
+	blockID := makeBlockIDRandom()
+	commit, valSet, _ := makeBLSCommit(t, 1, blockID, 6, 1)
+	aggregated, err := commit.Aggregate(chainID)
+	require.NoError(t, err)
+
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, 1, aggregated))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, 1, aggregated))
+	require.NoError(t, valSet.VerifyCommitLightAllSignatures(chainID, blockID, 1, aggregated))
+	require.NoError(t, valSet.VerifyCommitLightTrusting(chainID, aggregated, cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// The aggregated signature covers the nil vote too.
+	tampered := aggregated.Clone()
+	tampered.Signatures[0] = NewCommitSigAbsent()
+	require.Error(t, valSet.VerifyCommit(chainID, blockID, 1, tampered))
+	require.Error(t, valSet.VerifyCommitLight(chainID, blockID, 1, tampered))
+
+	tampered = aggregated.Clone()
+	tampered.Signatures[3].Timestamp = tampered.Signatures[3].Timestamp.Add(time.Second)
+	require.Error(t, valSet.VerifyCommit(chainID, blockID, 1, tampered))
+
+	// Not enough voting power for the block.
+	partial := commit.Clone()
+	partial.Signatures[4] = NewCommitSigAbsent()
+	partial.Signatures[5] = NewCommitSigAbsent()
+	partial, err = partial.Aggregate(chainID)
+	require.NoError(t, err)
+	err = valSet.VerifyCommit(chainID, blockID, 1, partial)
+	require.ErrorAs(t, err, &ErrNotEnoughVotingPowerSigned{})
+
+	// The aggregated signature can only be verified with the keys of all the
+	// signers.
+	newValSet, _ := RandValidatorSet(2, 1)
+	err = NewValidatorSet(valSet.Validators[1:]).VerifyCommitLightTrusting(chainID, aggregated,
+		cmtmath.Fraction{Numerator: 1, Denominator: 3})
+	require.ErrorAs(t, err, &ErrNotEnoughVotingPowerSigned{})
+
+	// Keys which are not BLS12-381 keys cannot sign an aggregated commit.
+	mixedValSet := NewValidatorSet(append(newValSet.Validators, valSet.Validators...))
+	err = mixedValSet.VerifyCommitLightTrusting(chainID, aggregated, cmtmath.Fraction{Numerator: 1, Denominator: 3})
+	require.NoError(t, err)
+	aggregated.Signatures[0].ValidatorAddress = newValSet.Validators[0].Address
+	err = mixedValSet.VerifyCommitLightTrusting(chainID, aggregated, cmtmath.Fraction{Numerator: 1, Denominator: 3})
+	require.Error(t, err)
+	require.NotErrorAs(t, err, &ErrNotEnoughVotingPowerSigned{})
+}
+
+func TestValidatorSet_VerifyCommit_MixedKeyTypes(t *testing.T) {
+	const chainID = "test_chain_id"
+	var (
+		blockID  = makeBlockIDRandom()
+		privKeys = []crypto.PrivKey{
+			ed25519.GenPrivKey(), secp256k1.GenPrivKey(), ed25519.GenPrivKey(), secp256k1.GenPrivKey(),
+		}
+		vals     = make([]*Validator, len(privKeys))
+		privVals = make(map[string]PrivValidator, len(privKeys))
+	)
+	for i, privKey := range privKeys {
+		vals[i] = NewValidator(privKey.PubKey(), 1)
+		privVals[string(vals[i].Address)] = NewMockPVWithParams(privKey, false, false)
+	}
+	valSet := NewValidatorSet(vals)
+	require.False(t, valSet.AllKeysHaveSameType())
+
+	// The private validators are in the order of valSet.
+	orderedPrivVals := make([]PrivValidator, 0, len(privKeys))
+	for _, val := range valSet.Validators {
+		orderedPrivVals = append(orderedPrivVals, privVals[string(val.Address)])
+	}
+	voteSet := NewVoteSet(chainID, 1, 0, PrecommitType, valSet)
+	extCommit, err := MakeExtCommit(blockID, 1, 0, voteSet, orderedPrivVals, cmttime.Now(), false)
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+
+	require.True(t, shouldBatchVerify(valSet, commit))
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, 1, commit))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, 1, commit))
+	require.NoError(t, valSet.VerifyCommitLightTrusting(chainID, commit, cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	for i := range commit.Signatures {
+		tampered := commit.Clone()
+		tampered.Signatures[i].Signature = commit.Signatures[(i+2)%len(commit.Signatures)].Signature
+		err = valSet.VerifyCommit(chainID, blockID, 1, tampered)
+		require.ErrorContains(t, err, "wrong signature (#"+strconv.Itoa(i)+")")
+	}
+}
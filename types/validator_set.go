@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
 	"github.com/cometbft/cometbft/v2/crypto/merkle"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
@@ -846,6 +847,13 @@ func (vals *ValidatorSet) AllKeysHaveSameType() bool {
 	return vals.allKeysHaveSameType
 }
 
+// AllKeysAreBLS12381 returns true if the set is not empty and all validators
+// have BLS12-381 keys, whose signatures can be aggregated.
+func (vals *ValidatorSet) AllKeysAreBLS12381() bool {
+	return vals.Size() > 0 && vals.allKeysHaveSameType &&
+		vals.Validators[0].PubKey != nil && vals.Validators[0].PubKey.Type() == bls12381.KeyType
+}
+
 // -----------------
 
 // IsErrNotEnoughVotingPowerSigned returns true if err is
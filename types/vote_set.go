@@ -208,7 +208,9 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 
 	// If we already know of this vote, return false.
 	if existing, ok := voteSet.getVote(valIndex, blockKey, &vote.BlockID); ok {
-		if bytes.Equal(existing.Signature, vote.Signature) {
+		// A vote reconstructed from an aggregated commit carries no signature,
+		// and was already verified.
+		if bytes.Equal(existing.Signature, vote.Signature) || len(existing.Signature) == 0 {
 			return false, nil // duplicate
 		}
 		return false, fmt.Errorf("existing vote: %v; new vote: %v: %w", existing, vote, ErrVoteNonDeterministicSignature)